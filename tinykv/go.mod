@@ -35,4 +35,6 @@ require (
 
 go 1.13
 
-replace github.com/pingcap/tidb => github.com/pingcap-incubator/tinysql v0.0.0-20200518090433-a7d00f9e6aa7
+replace github.com/pingcap/tidb => ../tinysql
+
+replace github.com/pingcap-incubator/tinykv v0.0.0-20200320061650-f660f803e910 => ./
@@ -254,6 +254,8 @@ type closureExecutor struct {
 	rowCount int
 	unique   bool
 	limit    int
+	// outputRows is the number of rows in the response.
+	outputRows int
 
 	oldChunks []tipb.Chunk
 	oldRowBuf []byte
@@ -373,6 +375,7 @@ func (e *closureExecutor) countFinish() error {
 		return errors.Trace(err)
 	}
 	e.oldChunks = appendRow(e.oldChunks, rowData, 0)
+	e.outputRows++
 	return nil
 }
 
@@ -554,6 +557,7 @@ func (e *closureExecutor) chunkToOldChunk(chk *chunk.Chunk) error {
 			return errors.Trace(err)
 		}
 		e.oldChunks = appendRow(e.oldChunks, e.oldRowBuf, i)
+		e.outputRows++
 	}
 	chk.Reset()
 	return nil
@@ -734,6 +738,7 @@ func (e *hashAggProcessor) Finish() error {
 		}
 		e.oldRowBuf = append(e.oldRowBuf, gk...)
 		e.oldChunks = appendRow(e.oldChunks, e.oldRowBuf, i)
+		e.outputRows++
 	}
 	return nil
 }
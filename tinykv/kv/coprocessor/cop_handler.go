@@ -56,10 +56,32 @@ func (svr *CopHandler) HandleCopDAGRequest(reader storage.StorageReader, req *co
 	}
 	closureExec, err := svr.buildClosureExecutor(dagCtx, dagReq)
 	if err != nil {
-		return buildResp(nil, nil, err, dagCtx.evalCtx.sc.GetWarnings(), time.Since(startTime))
+		return buildResp(nil, nil, nil, err, dagCtx.evalCtx.sc.GetWarnings(), time.Since(startTime))
 	}
 	chunks, err := closureExec.execute()
-	return buildResp(chunks, nil, err, dagCtx.evalCtx.sc.GetWarnings(), time.Since(startTime))
+	var summaries []*tipb.ExecutorExecutionSummary
+	if dagReq.GetCollectExecutionSummaries() {
+		summaries = buildExecSummaries(dagReq, closureExec, time.Since(startTime))
+	}
+	return buildResp(chunks, nil, summaries, err, dagCtx.evalCtx.sc.GetWarnings(), time.Since(startTime))
+}
+
+// buildExecSummaries builds an execution summary for every executor in the DAG.
+// The executors are fused into a single closure, so they all report the
+// processing time of the whole request and the number of rows it returns.
+func buildExecSummaries(dagReq *tipb.DAGRequest, e *closureExecutor, dur time.Duration) []*tipb.ExecutorExecutionSummary {
+	timeProcessedNs := uint64(dur.Nanoseconds())
+	numProducedRows := uint64(e.outputRows)
+	numIterations := uint64(1)
+	summaries := make([]*tipb.ExecutorExecutionSummary, 0, len(dagReq.Executors))
+	for range dagReq.Executors {
+		summaries = append(summaries, &tipb.ExecutorExecutionSummary{
+			TimeProcessedNs: &timeProcessedNs,
+			NumProducedRows: &numProducedRows,
+			NumIterations:   &numIterations,
+		})
+	}
+	return summaries
 }
 
 func (svr *CopHandler) buildDAG(reader storage.StorageReader, req *coprocessor.Request) (*dagContext, *tipb.DAGRequest, error) {
@@ -222,12 +244,13 @@ func flagsToStatementContext(flags uint64) *stmtctx.StatementContext {
 	return sc
 }
 
-func buildResp(chunks []tipb.Chunk, counts []int64, err error, warnings []stmtctx.SQLWarn, dur time.Duration) *coprocessor.Response {
+func buildResp(chunks []tipb.Chunk, counts []int64, summaries []*tipb.ExecutorExecutionSummary, err error, warnings []stmtctx.SQLWarn, dur time.Duration) *coprocessor.Response {
 	resp := &coprocessor.Response{}
 	selResp := &tipb.SelectResponse{
-		Error:        toPBError(err),
-		Chunks:       chunks,
-		OutputCounts: counts,
+		Error:              toPBError(err),
+		Chunks:             chunks,
+		OutputCounts:       counts,
+		ExecutionSummaries: summaries,
 	}
 	if len(warnings) > 0 {
 		selResp.Warnings = make([]*tipb.Error, 0, len(warnings))
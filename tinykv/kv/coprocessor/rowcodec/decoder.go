@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 )
@@ -66,6 +67,8 @@ func convertDefaultValue(defaultVal []byte) (colVal []byte, err error) {
 		return encodeUint(nil, uint64(math.Float32bits(d.GetFloat32()))), nil
 	case types.KindFloat64:
 		return encodeUint(nil, math.Float64bits(d.GetFloat64())), nil
+	case types.KindMysqlDuration:
+		return encodeInt(nil, int64(d.GetMysqlDuration().Duration)), nil
 	default:
 		return defaultVal[1:], nil
 	}
@@ -196,9 +199,59 @@ func (decoder *Decoder) decodeColData(colIdx int, colData []byte, chk *chunk.Chu
 			return err
 		}
 		chk.AppendFloat64(colIdx, fVal)
+	case mysql.TypeNewDecimal:
+		_, dec, _, frac, err := codec.DecodeDecimal(colData)
+		if err != nil {
+			return err
+		}
+		if ft.Decimal != types.UnspecifiedLength && frac > ft.Decimal {
+			to := new(types.MyDecimal)
+			err := dec.Round(to, ft.Decimal, types.ModeHalfUp)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			dec = to
+		}
+		chk.AppendMyDecimal(colIdx, dec)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		t := types.NewTime(types.ZeroCoreTime, ft.Tp, int8(ft.Decimal))
+		err := t.FromPackedUint(decodeUint(colData))
+		if err != nil {
+			return err
+		}
+		if ft.Tp == mysql.TypeTimestamp && !t.IsZero() && decoder.loc != nil {
+			err = t.ConvertTimeZone(time.UTC, decoder.loc)
+			if err != nil {
+				return err
+			}
+		}
+		chk.AppendTime(colIdx, t)
+	case mysql.TypeDuration:
+		var dur types.Duration
+		dur.Duration = time.Duration(decodeInt(colData))
+		dur.Fsp = int8(ft.Decimal)
+		chk.AppendDuration(colIdx, dur)
+	case mysql.TypeJSON:
+		var j json.BinaryJSON
+		j.TypeCode = colData[0]
+		j.Value = colData[1:]
+		chk.AppendJSON(colIdx, j)
 	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString,
 		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 		chk.AppendBytes(colIdx, colData)
+	case mysql.TypeEnum:
+		// ignore error deliberately, to read empty enum value.
+		enum, err := types.ParseEnumValue(ft.Elems, decodeUint(colData))
+		if err != nil {
+			enum = types.Enum{}
+		}
+		chk.AppendEnum(colIdx, enum)
+	case mysql.TypeSet:
+		set, err := types.ParseSetValue(ft.Elems, decodeUint(colData))
+		if err != nil {
+			return err
+		}
+		chk.AppendSet(colIdx, set)
 	case mysql.TypeBit:
 		byteSize := (ft.Flen + 7) >> 3
 		chk.AppendBytes(colIdx, types.NewBinaryLiteralFromUint(decodeUint(colData), byteSize))
@@ -0,0 +1,76 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import "github.com/pingcap/tidb/parser/ast"
+
+// HintsSet contains all the hints of a statement. The hints are collected in
+// the order of traversing the AST, so they can be bound to another statement
+// which has the same normalized form.
+type HintsSet struct {
+	tableHints [][]*ast.TableOptimizerHint // Table hints of each select statement.
+	indexHints [][]*ast.IndexHint          // Index hints of each table name.
+}
+
+type hintProcessor struct {
+	*HintsSet
+	// bind indicates whether to bind the hints to the statement or to collect them.
+	bind       bool
+	tableIndex int
+	indexIndex int
+}
+
+func (hp *hintProcessor) Enter(in ast.Node) (ast.Node, bool) {
+	switch v := in.(type) {
+	case *ast.SelectStmt:
+		if hp.bind {
+			v.TableHints = hp.tableHints[hp.tableIndex]
+			hp.tableIndex++
+		} else {
+			hp.tableHints = append(hp.tableHints, v.TableHints)
+		}
+	case *ast.TableName:
+		if hp.bind {
+			v.IndexHints = hp.indexHints[hp.indexIndex]
+			hp.indexIndex++
+		} else {
+			hp.indexHints = append(hp.indexHints, v.IndexHints)
+		}
+	}
+	return in, false
+}
+
+func (hp *hintProcessor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// CollectHint collects the hints of a statement.
+func CollectHint(in ast.StmtNode) *HintsSet {
+	hp := hintProcessor{HintsSet: &HintsSet{}}
+	in.Accept(&hp)
+	return hp.HintsSet
+}
+
+// BindHint binds the hints in hintsSet to the statement, which must have the
+// same normalized form as the one the hints are collected from. It returns the
+// original hints of the statement, so that they can be restored later.
+func BindHint(stmt ast.StmtNode, hintsSet *HintsSet) *HintsSet {
+	oldHints := CollectHint(stmt)
+	if len(oldHints.tableHints) != len(hintsSet.tableHints) || len(oldHints.indexHints) != len(hintsSet.indexHints) {
+		return oldHints
+	}
+	hp := hintProcessor{HintsSet: hintsSet, bind: true}
+	stmt.Accept(&hp)
+	return oldHints
+}
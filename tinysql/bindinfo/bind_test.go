@@ -0,0 +1,168 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/bindinfo"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/util/testkit"
+	"github.com/pingcap/tidb/util/testleak"
+)
+
+func TestT(t *testing.T) {
+	CustomVerboseFlag = true
+	TestingT(t)
+}
+
+var _ = Suite(&testSuite{})
+
+type testSuite struct {
+	store kv.Storage
+	dom   *domain.Domain
+}
+
+func (s *testSuite) SetUpSuite(c *C) {
+	testleak.BeforeTest()
+	var err error
+	s.store, err = mockstore.NewMockTikvStore()
+	c.Assert(err, IsNil)
+	session.SetSchemaLease(0)
+	session.DisableStats4Test()
+	s.dom, err = session.BootstrapSession(s.store)
+	c.Assert(err, IsNil)
+}
+
+func (s *testSuite) TearDownSuite(c *C) {
+	s.dom.Close()
+	s.store.Close()
+	testleak.AfterTest(c)()
+}
+
+func (s *testSuite) cleanBindingEnv(tk *testkit.TestKit) {
+	tk.MustExec("truncate table mysql.bind_info")
+	s.dom.BindHandle().Update(true)
+}
+
+// explainContains checks whether the plan of the sql contains the substr.
+func (s *testSuite) explainContains(tk *testkit.TestKit, sql, substr string) bool {
+	for _, row := range tk.MustQuery("explain " + sql).Rows() {
+		for _, col := range row {
+			if strings.Contains(col.(string), substr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *testSuite) TestGlobalBinding(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	s.cleanBindingEnv(tk)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+
+	c.Assert(s.explainContains(tk, "select * from t where a > 1 and b > 1", "IndexLookUp"), IsFalse)
+
+	tk.MustExec("create global binding for select * from t where a > 1 and b > 1 using select * from t use index(idx_b) where a > 1 and b > 1")
+	c.Assert(s.dom.BindHandle().Size(), Equals, 1)
+	record := s.dom.BindHandle().GetBindRecord("select * from t where a > ? and b > ?", "test")
+	c.Assert(record, NotNil)
+	c.Assert(record.BindSQL, Equals, "select * from t use index(idx_b) where a > 1 and b > 1")
+	c.Assert(record.Status, Equals, bindinfo.Using)
+
+	// The binding matches the statements only differing in the literals.
+	c.Assert(s.explainContains(tk, "select * from t where a > 10 and b > 10", "IndexLookUp"), IsTrue)
+	rows := tk.MustQuery("show global bindings").Rows()
+	c.Assert(len(rows), Equals, 1)
+	c.Assert(rows[0][0], Equals, "select * from t where a > ? and b > ?")
+	c.Assert(rows[0][1], Equals, "select * from t use index(idx_b) where a > 1 and b > 1")
+	c.Assert(rows[0][2], Equals, "test")
+	c.Assert(rows[0][3], Equals, "using")
+	tk.MustQuery("select original_sql, status from mysql.bind_info").Check(testkit.Rows(
+		"select * from t where a > ? and b > ? using",
+	))
+
+	// The binding is loaded by the other servers.
+	bindHandle := bindinfo.NewBindHandle(tk.Se)
+	c.Assert(bindHandle.Update(true), IsNil)
+	c.Assert(bindHandle.Size(), Equals, 1)
+	c.Assert(bindHandle.GetBindRecord("select * from t where a > ? and b > ?", "test").HintsSet, NotNil)
+
+	// The binding of the other database doesn't match.
+	tk.MustExec("create database if not exists bind_db")
+	tk.MustExec("use bind_db")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	c.Assert(s.explainContains(tk, "select * from t where a > 1 and b > 1", "IndexLookUp"), IsFalse)
+	tk.MustExec("drop database bind_db")
+	tk.MustExec("use test")
+
+	tk.MustExec("drop global binding for select * from t where a > 1 and b > 1")
+	c.Assert(s.dom.BindHandle().Size(), Equals, 0)
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	tk.MustQuery("select original_sql, status from mysql.bind_info").Check(testkit.Rows(
+		"select * from t where a > ? and b > ? deleted",
+	))
+	c.Assert(bindHandle.Update(false), IsNil)
+	c.Assert(bindHandle.Size(), Equals, 0)
+	c.Assert(s.explainContains(tk, "select * from t where a > 10 and b > 10", "IndexLookUp"), IsFalse)
+}
+
+func (s *testSuite) TestSessionBinding(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	s.cleanBindingEnv(tk)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+
+	tk.MustExec("create session binding for select * from t1, t2 where t1.a = t2.a using select /*+ MERGE_JOIN(t1, t2) */ * from t1, t2 where t1.a = t2.a")
+	c.Assert(s.dom.BindHandle().Size(), Equals, 0)
+	rows := tk.MustQuery("show session bindings").Rows()
+	c.Assert(len(rows), Equals, 1)
+	c.Assert(rows[0][0], Equals, "select * from t1 , t2 where t1 . a = t2 . a")
+	c.Assert(rows[0][1], Equals, "select /*+ MERGE_JOIN(t1, t2) */ * from t1, t2 where t1.a = t2.a")
+	rows = tk.MustQuery("explain select * from t1, t2 where t1.a = t2.a").Rows()
+	c.Assert(rows[0][0], Matches, "MergeJoin.*")
+
+	// The session binding is invisible to the other sessions.
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test")
+	tk1.MustQuery("show session bindings").Check(testkit.Rows())
+	rows = tk1.MustQuery("explain select * from t1, t2 where t1.a = t2.a").Rows()
+	c.Assert(rows[0][0], Matches, "HashLeftJoin.*")
+
+	tk.MustExec("drop session binding for select * from t1, t2 where t1.a = t2.a")
+	tk.MustQuery("show session bindings").Check(testkit.Rows())
+	rows = tk.MustQuery("explain select * from t1, t2 where t1.a = t2.a").Rows()
+	c.Assert(rows[0][0], Matches, "HashLeftJoin.*")
+}
+
+func (s *testSuite) TestBindingMismatch(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	s.cleanBindingEnv(tk)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+	_, err := tk.Exec("create global binding for select * from t where a > 1 using select * from t use index(idx_a) where b > 1")
+	c.Assert(err, NotNil)
+	c.Assert(s.dom.BindHandle().Size(), Equals, 0)
+}
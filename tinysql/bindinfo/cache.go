@@ -0,0 +1,103 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"sort"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+const (
+	// Using is the bind info's in use status.
+	Using = "using"
+	// deleted is the bind info's deleted status.
+	deleted = "deleted"
+)
+
+// BindRecord represents a sql bind record retrieved from the storage.
+type BindRecord struct {
+	// OriginalSQL is the normalized text of the original statement.
+	OriginalSQL string
+	BindSQL     string
+	Db          string
+	// Status represents the status of the binding. It can only be one of the following values:
+	// 1. deleted: BindRecord is deleted, can not be used anymore.
+	// 2. using: BindRecord is in the normal active mode.
+	Status     string
+	CreateTime types.Time
+	UpdateTime types.Time
+	Charset    string
+	Collation  string
+	// HintsSet is the hints collected from the statement of BindSQL.
+	HintsSet *HintsSet
+}
+
+func newBindRecord(row chunk.Row) *BindRecord {
+	return &BindRecord{
+		OriginalSQL: row.GetString(0),
+		BindSQL:     row.GetString(1),
+		Db:          row.GetString(2),
+		Status:      row.GetString(3),
+		CreateTime:  row.GetTime(4),
+		UpdateTime:  row.GetTime(5),
+		Charset:     row.GetString(6),
+		Collation:   row.GetString(7),
+	}
+}
+
+// bindKey returns the key of the bind record in the cache, which is made up of
+// the digest of the normalized original statement and the default database.
+func bindKey(normdOrigSQL, db string) string {
+	return parser.DigestHash(normdOrigSQL) + "." + db
+}
+
+// cache is a map from the bind key to the bind record.
+type cache map[string]*BindRecord
+
+func (c cache) copy() cache {
+	newCache := make(cache, len(c))
+	for k, v := range c {
+		newCache[k] = v
+	}
+	return newCache
+}
+
+func (c cache) getBindRecord(normdOrigSQL, db string) *BindRecord {
+	return c[bindKey(normdOrigSQL, db)]
+}
+
+func (c cache) setBindRecord(record *BindRecord) {
+	c[bindKey(record.OriginalSQL, record.Db)] = record
+}
+
+func (c cache) removeBindRecord(normdOrigSQL, db string) {
+	delete(c, bindKey(normdOrigSQL, db))
+}
+
+func (c cache) getAllBindRecord() []*BindRecord {
+	records := make([]*BindRecord, 0, len(c))
+	for _, record := range c {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].OriginalSQL != records[j].OriginalSQL {
+			return records[i].OriginalSQL < records[j].OriginalSQL
+		}
+		return records[i].Db < records[j].Db
+	})
+	return records
+}
@@ -0,0 +1,221 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// Lease is the interval of loading the bind info from the storage.
+var Lease = 3 * time.Second
+
+// BindHandle is used to handle all global sql bind operations.
+type BindHandle struct {
+	sctx struct {
+		sync.Mutex
+		sessionctx.Context
+	}
+
+	// bindInfo caches the sql bind info from storage.
+	//
+	// The Mutex protects that there is only one goroutine changes the content
+	// of atomic.Value.
+	//
+	// NOTE: Concurrent Value Write:
+	//
+	//    bindInfo.Lock()
+	//    newCache := bindInfo.Value.Load()
+	//    do the write operation on the newCache
+	//    bindInfo.Value.Store(newCache)
+	//    bindInfo.Unlock()
+	//
+	// NOTE: Concurrent Value Read:
+	//
+	//    cache := bindInfo.Load().
+	//    read the content
+	//
+	bindInfo struct {
+		sync.Mutex
+		atomic.Value
+		parser *parser.Parser
+	}
+
+	lastUpdateTime types.Time
+}
+
+// NewBindHandle creates a new BindHandle.
+func NewBindHandle(ctx sessionctx.Context) *BindHandle {
+	handle := &BindHandle{}
+	handle.sctx.Context = ctx
+	handle.bindInfo.Value.Store(make(cache))
+	handle.bindInfo.parser = parser.New()
+	return handle
+}
+
+// Update updates the global sql bind cache. If fullLoad is false, only the
+// bind records updated after the last update are loaded.
+func (h *BindHandle) Update(fullLoad bool) (err error) {
+	sql := "select original_sql, bind_sql, default_db, status, create_time, update_time, charset, collation from mysql.bind_info"
+	if !fullLoad {
+		sql += " where update_time > \"" + h.lastUpdateTime.String() + "\""
+	}
+	sql += " order by update_time"
+
+	h.sctx.Lock()
+	rows, _, err := h.sctx.Context.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	h.sctx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Make sure there is only one goroutine writes the cache and uses parser.
+	h.bindInfo.Lock()
+	defer h.bindInfo.Unlock()
+	newCache := h.bindInfo.Value.Load().(cache).copy()
+	if fullLoad {
+		newCache = make(cache)
+	}
+	for _, row := range rows {
+		record := newBindRecord(row)
+		if record.UpdateTime.Compare(h.lastUpdateTime) > 0 {
+			h.lastUpdateTime = record.UpdateTime
+		}
+		if record.Status != Using {
+			newCache.removeBindRecord(record.OriginalSQL, record.Db)
+			continue
+		}
+		stmt, err := h.bindInfo.parser.ParseOneStmt(record.BindSQL, record.Charset, record.Collation)
+		if err != nil {
+			logutil.BgLogger().Error("parse bind sql failed", zap.String("sql", record.BindSQL), zap.Error(err))
+			continue
+		}
+		record.HintsSet = CollectHint(stmt)
+		newCache.setBindRecord(record)
+	}
+	h.bindInfo.Value.Store(newCache)
+	return nil
+}
+
+// AddBindRecord adds a BindRecord to the storage and the cache. It replaces
+// the record with the same original sql and default db if there is one.
+func (h *BindHandle) AddBindRecord(record *BindRecord) (err error) {
+	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeDatetime, 3)
+	record.Status = Using
+	record.CreateTime = now
+	record.UpdateTime = now
+	if err = h.replaceBindRecord(record); err != nil {
+		return err
+	}
+
+	h.bindInfo.Lock()
+	newCache := h.bindInfo.Value.Load().(cache).copy()
+	newCache.setBindRecord(record)
+	h.bindInfo.Value.Store(newCache)
+	h.bindInfo.Unlock()
+	return nil
+}
+
+// DropBindRecord drops a BindRecord in the cache and marks it deleted in the
+// storage, so that the other servers can remove it from their caches.
+func (h *BindHandle) DropBindRecord(record *BindRecord) (err error) {
+	oldRecord := h.GetBindRecord(record.OriginalSQL, record.Db)
+	if oldRecord == nil {
+		return nil
+	}
+	deletedRecord := *oldRecord
+	deletedRecord.Status = deleted
+	deletedRecord.UpdateTime = types.NewTime(types.FromGoTime(time.Now()), mysql.TypeDatetime, 3)
+	if err = h.replaceBindRecord(&deletedRecord); err != nil {
+		return err
+	}
+
+	h.bindInfo.Lock()
+	newCache := h.bindInfo.Value.Load().(cache).copy()
+	newCache.removeBindRecord(record.OriginalSQL, record.Db)
+	h.bindInfo.Value.Store(newCache)
+	h.bindInfo.Unlock()
+	return nil
+}
+
+// replaceBindRecord replaces the record of the same original sql and default
+// db in the storage with the given one.
+func (h *BindHandle) replaceBindRecord(record *BindRecord) error {
+	return h.execInTxn(
+		fmt.Sprintf("DELETE FROM mysql.bind_info WHERE original_sql = %s AND default_db = %s",
+			quote(record.OriginalSQL), quote(record.Db)),
+		fmt.Sprintf("INSERT INTO mysql.bind_info VALUES(%s, %s, %s, %s, %s, %s, %s, %s)",
+			quote(record.OriginalSQL), quote(record.BindSQL), quote(record.Db), quote(record.Status),
+			quote(record.CreateTime.String()), quote(record.UpdateTime.String()),
+			quote(record.Charset), quote(record.Collation)),
+	)
+}
+
+func (h *BindHandle) execInTxn(sqls ...string) (err error) {
+	h.sctx.Lock()
+	defer h.sctx.Unlock()
+	exec := h.sctx.Context.(sqlexec.SQLExecutor)
+	ctx := context.TODO()
+	if _, err = exec.Execute(ctx, "BEGIN"); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_, err1 := exec.Execute(ctx, "ROLLBACK")
+			terror.Log(err1)
+			return
+		}
+		_, err = exec.Execute(ctx, "COMMIT")
+	}()
+	for _, sql := range sqls {
+		if _, err = exec.Execute(ctx, sql); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// GetBindRecord returns the BindRecord of the (normdOrigSQL,db) if BindRecord exist.
+func (h *BindHandle) GetBindRecord(normdOrigSQL, db string) *BindRecord {
+	return h.bindInfo.Load().(cache).getBindRecord(normdOrigSQL, db)
+}
+
+// GetAllBindRecord returns all bind records in cache.
+func (h *BindHandle) GetAllBindRecord() []*BindRecord {
+	return h.bindInfo.Load().(cache).getAllBindRecord()
+}
+
+// Size returns the size of bind info cache.
+func (h *BindHandle) Size() int {
+	return len(h.bindInfo.Load().(cache))
+}
+
+// quote quotes a string as a SQL string literal.
+func quote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+// SessionHandle is used to handle all session sql bind operations.
+type SessionHandle struct {
+	ch cache
+}
+
+// NewSessionBindHandle creates a new SessionBindHandle.
+func NewSessionBindHandle() *SessionHandle {
+	return &SessionHandle{ch: make(cache)}
+}
+
+// AddBindRecord adds a BindRecord to the cache. It replaces the record with
+// the same original sql and default db if there is one.
+func (h *SessionHandle) AddBindRecord(record *BindRecord) {
+	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeDatetime, 3)
+	record.Status = Using
+	record.CreateTime = now
+	record.UpdateTime = now
+	h.ch.setBindRecord(record)
+}
+
+// DropBindRecord drops a BindRecord in the cache.
+func (h *SessionHandle) DropBindRecord(record *BindRecord) {
+	h.ch.removeBindRecord(record.OriginalSQL, record.Db)
+}
+
+// GetBindRecord returns the BindRecord of the (normdOrigSQL,db) if BindRecord exist.
+func (h *SessionHandle) GetBindRecord(normdOrigSQL, db string) *BindRecord {
+	return h.ch.getBindRecord(normdOrigSQL, db)
+}
+
+// GetAllBindRecord returns all session bind info.
+func (h *SessionHandle) GetAllBindRecord() []*BindRecord {
+	return h.ch.getAllBindRecord()
+}
+
+// sessionBindInfoKeyType is a dummy type to avoid naming collision in context.
+type sessionBindInfoKeyType int
+
+// String defines a Stringer function for debugging and pretty printing.
+func (k sessionBindInfoKeyType) String() string {
+	return "session_bindinfo"
+}
+
+// SessionBindInfoKeyType is a variable key for store session bind info.
+const SessionBindInfoKeyType sessionBindInfoKeyType = 0
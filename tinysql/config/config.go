@@ -29,6 +29,14 @@ const (
 	DefTxnTotalSizeLimit = 1024 * 1024 * 1024
 )
 
+// Valid OOMAction values.
+const (
+	// OOMActionCancel cancels the query when its memory quota is exceeded.
+	OOMActionCancel = "cancel"
+	// OOMActionLog only logs a warning when the memory quota of a query is exceeded.
+	OOMActionLog = "log"
+)
+
 // Valid config maps
 var (
 	ValidStorage = map[string]bool{
@@ -50,8 +58,15 @@ type Config struct {
 	Store            string `toml:"store" json:"store"`
 	Path             string `toml:"path" json:"path"`
 	Lease            string `toml:"lease" json:"lease"`
+	OOMAction        string `toml:"oom-action" json:"oom-action"`
+	// OOMUseTmpStorage enables the executors to spill intermediate data to
+	// TmpStoragePath when the memory quota of a query is exceeded.
+	OOMUseTmpStorage bool   `toml:"oom-use-tmp-storage" json:"oom-use-tmp-storage"`
+	TmpStoragePath   string `toml:"tmp-storage-path" json:"tmp-storage-path"`
 	Log              Log    `toml:"log" json:"log"`
 	Status           Status `toml:"status" json:"status"`
+
+	PreparedPlanCache PreparedPlanCache `toml:"prepared-plan-cache" json:"prepared-plan-cache"`
 }
 
 // Log is the log section of config.
@@ -84,6 +99,12 @@ type Status struct {
 	ReportStatus bool `toml:"report-status" json:"report-status"`
 }
 
+// PreparedPlanCache is the PreparedPlanCache section of the config.
+type PreparedPlanCache struct {
+	Enabled  bool `toml:"enabled" json:"enabled"`
+	Capacity uint `toml:"capacity" json:"capacity"`
+}
+
 var defaultConf = Config{
 	Host:             "0.0.0.0",
 	AdvertiseAddress: "",
@@ -92,6 +113,9 @@ var defaultConf = Config{
 	Store:            "mocktikv",
 	Path:             "/tmp/tinysql",
 	Lease:            "45s",
+	OOMAction:        OOMActionLog,
+	OOMUseTmpStorage: true,
+	TmpStoragePath:   "/tmp/tinysql-tmp-storage",
 	Log: Log{
 		Level: "info",
 		File:  logutil.NewFileLogConfig(logutil.DefaultLogMaxSize),
@@ -101,6 +125,10 @@ var defaultConf = Config{
 		StatusHost:   "0.0.0.0",
 		StatusPort:   10080,
 	},
+	PreparedPlanCache: PreparedPlanCache{
+		Enabled:  true,
+		Capacity: 100,
+	},
 }
 
 var (
@@ -0,0 +1,520 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+func (w *worker) onAddCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := &model.ConstraintInfo{}
+	if err = job.DecodeArgs(constraintInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if existing := tblInfo.FindConstraintInfoByName(constraintInfo.Name.L); existing != nil {
+		if existing.State == model.StatePublic {
+			job.State = model.JobStateCancelled
+			return ver, errCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+		}
+		constraintInfo = existing
+	} else {
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StateNone
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// The new rows are checked from now on, so the existing rows can be verified later.
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		job.SchemaState = model.StateWriteReorganization
+		constraintInfo.State = model.StateWriteReorganization
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		if constraintInfo.Enforced {
+			done, err := w.verifyConstraint(d, t, job, tblInfo, constraintInfo.Name, func() error {
+				return w.verifyRemainRecordsForCheckConstraint(dbInfo, tblInfo, constraintInfo)
+			})
+			if err != nil {
+				if table.ErrCheckConstraintViolated.Equal(err) {
+					logutil.BgLogger().Warn("[ddl] run add check constraint job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+					tblInfo.Constraints = removeConstraintInfo(tblInfo.Constraints, constraintInfo.Name)
+					return finishRollbackConstraintJob(t, job, tblInfo, model.StateNone, err)
+				}
+				return ver, errors.Trace(err)
+			}
+			if !done {
+				return ver, nil
+			}
+		}
+
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+func onDropCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, constraintInfo, err := checkDropCheckConstraint(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	// Dropping a constraint only relaxes the writes, so it can be removed in one step.
+	tblInfo.Constraints = removeConstraintInfo(tblInfo.Constraints, constraintInfo.Name)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
+
+func checkDropCheckConstraint(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.ConstraintInfo, error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	var constraintName model.CIStr
+	if err = job.DecodeArgs(&constraintName); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, errCheckConstraintNotFound.GenWithStackByArgs(constraintName.O)
+	}
+	return tblInfo, constraintInfo, nil
+}
+
+func (w *worker) onAlterCheckConstraint(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constraintName model.CIStr
+		enforced       bool
+	)
+	if err = job.DecodeArgs(&constraintName, &enforced); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, errCheckConstraintNotFound.GenWithStackByArgs(constraintName.O)
+	}
+
+	// Turning a constraint into NOT ENFORCED, or enforcing an enforced one, doesn't need to verify the rows.
+	if !enforced || (constraintInfo.Enforced && constraintInfo.State == model.StatePublic) {
+		constraintInfo.Enforced = enforced
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	// Enforcing a NOT ENFORCED constraint goes through the same states as adding one,
+	// the constraint is enforced for the new rows before the existing rows are verified.
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// public -> write only
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.Enforced = true
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		job.SchemaState = model.StateWriteReorganization
+		constraintInfo.State = model.StateWriteReorganization
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		done, err := w.verifyConstraint(d, t, job, tblInfo, constraintInfo.Name, func() error {
+			return w.verifyRemainRecordsForCheckConstraint(dbInfo, tblInfo, constraintInfo)
+		})
+		if err != nil {
+			if table.ErrCheckConstraintViolated.Equal(err) {
+				logutil.BgLogger().Warn("[ddl] run alter check constraint job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+				constraintInfo.Enforced = false
+				constraintInfo.State = model.StatePublic
+				return finishRollbackConstraintJob(t, job, tblInfo, model.StatePublic, err)
+			}
+			return ver, errors.Trace(err)
+		}
+		if !done {
+			return ver, nil
+		}
+
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// verifyConstraint verifies the existing rows of the table satisfy the constraint by verifyFn in the reorganization
+// state, it's used by the CHECK constraints and foreign keys. It returns false if the reorganization isn't done yet
+// and the job should be run again.
+func (w *worker) verifyConstraint(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	constraintName model.CIStr, verifyFn func() error) (bool, error) {
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	reorgInfo, err := getReorgInfo(d, t, job, tbl)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, d.lease, func() (verifyErr error) {
+		defer func() {
+			r := recover()
+			if r != nil {
+				buf := util.GetStack()
+				logutil.BgLogger().Error("[ddl] verify constraint panic", zap.Any("panic", r), zap.String("stack", string(buf)))
+				verifyErr = errCancelledDDLJob.GenWithStack("verify table `%v` constraint `%v` panic", tblInfo.Name, constraintName)
+			}
+		}()
+		return verifyFn()
+	})
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// verifyRemainRecordsForCheckConstraint looks for a row the constraint expression is false on.
+func (w *worker) verifyRemainRecordsForCheckConstraint(dbInfo *model.DBInfo, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo) error {
+	// Get sessionctx from context resource pool.
+	ctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(ctx)
+
+	// A NULL result doesn't violate the constraint, so we only look for the rows it is false on.
+	sql := fmt.Sprintf("select 1 from `%s`.`%s` where not (%s) limit 1;", dbInfo.Name.L, tblInfo.Name.L, constraintInfo.ExprString)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return table.ErrCheckConstraintViolated.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	return nil
+}
+
+func rollingbackAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	constraintInfo := &model.ConstraintInfo{}
+	if err = job.DecodeArgs(constraintInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo.Constraints = removeConstraintInfo(tblInfo.Constraints, constraintInfo.Name)
+	return finishRollbackConstraintJob(t, job, tblInfo, model.StateNone, errCancelledDDLJob)
+}
+
+func rollingbackAlterCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	var constraintName model.CIStr
+	if err = job.DecodeArgs(&constraintName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	// Only enforcing a constraint can be running here, it's restored to NOT ENFORCED.
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, errCheckConstraintNotFound.GenWithStackByArgs(constraintName.O)
+	}
+	constraintInfo.Enforced = false
+	constraintInfo.State = model.StatePublic
+	return finishRollbackConstraintJob(t, job, tblInfo, model.StatePublic, errCancelledDDLJob)
+}
+
+// finishRollbackConstraintJob finishes a constraint job which can't be done, the constraint
+// is already restored in tblInfo and occurredErr is returned to the client.
+func finishRollbackConstraintJob(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	schemaState model.SchemaState, occurredErr error) (ver int64, err error) {
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, schemaState, ver, tblInfo)
+	return ver, errors.Trace(occurredErr)
+}
+
+func removeConstraintInfo(constraints []*model.ConstraintInfo, name model.CIStr) []*model.ConstraintInfo {
+	newConstraints := make([]*model.ConstraintInfo, 0, len(constraints))
+	for _, c := range constraints {
+		if c.Name.L != name.L {
+			newConstraints = append(newConstraints, c)
+		}
+	}
+	return newConstraints
+}
+
+func allocateConstraintID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxConstraintID++
+	return tblInfo.MaxConstraintID
+}
+
+// buildConstraintInfo builds the CHECK constraint info from the AST after validating it against tblInfo.
+func buildConstraintInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint) (*model.ConstraintInfo, error) {
+	dependedCols, err := checkCheckConstraintExpr(ctx, tblInfo, constr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &model.ConstraintInfo{
+		Name:           model.NewCIStr(constr.Name),
+		Table:          tblInfo.Name,
+		ConstraintCols: dependedCols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     constr.Expr.Text(),
+		State:          model.StateNone,
+	}, nil
+}
+
+// buildTableCheckConstraints builds the CHECK constraints of CREATE TABLE.
+func buildTableCheckConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo, constraints []*ast.Constraint) error {
+	constrNames := make(map[string]bool)
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintCheck || constr.Name == "" {
+			continue
+		}
+		if constrNames[constr.Name] {
+			return errCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		constrNames[constr.Name] = true
+	}
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintCheck {
+			continue
+		}
+		if constr.Name == "" {
+			constr.Name = genCheckConstraintName(tblInfo, constrNames)
+		}
+		constraintInfo, err := buildConstraintInfo(ctx, tblInfo, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StatePublic
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+	return nil
+}
+
+// genCheckConstraintName generates the constraint name like MySQL does, it's `<table>_chk_<n>`.
+func genCheckConstraintName(tblInfo *model.TableInfo, usedNames map[string]bool) string {
+	for _, c := range tblInfo.Constraints {
+		usedNames[c.Name.L] = true
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_chk_%d", tblInfo.Name.L, i)
+		if !usedNames[name] {
+			usedNames[name] = true
+			return name
+		}
+	}
+}
+
+// checkCheckConstraintExpr checks the expression of the constraint and returns the columns it depends on.
+func checkCheckConstraintExpr(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint) ([]model.CIStr, error) {
+	// A column or a constant alone isn't a condition.
+	switch constr.Expr.(type) {
+	case *ast.ColumnNameExpr, ast.ValueExpr:
+		return nil, errNonBooleanExprForCheckConstraint.GenWithStackByArgs(constr.Name)
+	}
+	c := checkConstraintExprChecker{name: constr.Name}
+	constr.Expr.Accept(&c)
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	var dependedCols []model.CIStr
+	dependedColsMap := make(map[string]struct{})
+	for _, colName := range findColumnNamesInExpr(constr.Expr) {
+		if _, ok := dependedColsMap[colName.Name.L]; ok {
+			continue
+		}
+		dependedColsMap[colName.Name.L] = struct{}{}
+		col := model.FindColumnInfo(tblInfo.Columns, colName.Name.L)
+		if col == nil {
+			return nil, errCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constr.Name, colName.Name.O)
+		}
+		if mysql.HasAutoIncrementFlag(col.Flag) {
+			return nil, errCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(constr.Name)
+		}
+		// A column check constraint may only refer to its own column.
+		if constr.InColumn && len(constr.Keys) > 0 && col.Name.L != constr.Keys[0].Column.Name.L {
+			return nil, errColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(constr.Name)
+		}
+		dependedCols = append(dependedCols, col.Name)
+	}
+
+	// Make sure the expression can be built on the table.
+	if _, err := expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return dependedCols, nil
+}
+
+// checkConstraintExprChecker finds the expressions a CHECK constraint can't contain.
+type checkConstraintExprChecker struct {
+	name string
+	err  error
+}
+
+func (c *checkConstraintExprChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	switch node := inNode.(type) {
+	case *ast.FuncCallExpr:
+		if _, found := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]; found {
+			c.err = errCheckConstraintNamedFunctionIsNotAllowed.GenWithStackByArgs(c.name, node.FnName.L)
+			return inNode, true
+		}
+		// UNIX_TIMESTAMP() without argument returns the current time.
+		if node.FnName.L == ast.UnixTimestamp && len(node.Args) == 0 {
+			c.err = errCheckConstraintNamedFunctionIsNotAllowed.GenWithStackByArgs(c.name, node.FnName.L)
+			return inNode, true
+		}
+	case *ast.VariableExpr:
+		c.err = errCheckConstraintVariables.GenWithStackByArgs(c.name)
+		return inNode, true
+	case *ast.RowExpr:
+		c.err = errCheckConstraintRowValue.GenWithStackByArgs(c.name)
+		return inNode, true
+	case *ast.ValuesExpr, *ast.AggregateFuncExpr, *ast.DefaultExpr:
+		c.err = errCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(c.name)
+		return inNode, true
+	}
+	return inNode, c.err != nil
+}
+
+func (c *checkConstraintExprChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	return inNode, c.err == nil
+}
+
+// checkDependedByCheckConstraint checks whether the column can be dropped or renamed, a column is
+// only allowed to be dropped if the constraints using it don't use any other column.
+func checkDependedByCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr, isDrop bool) error {
+	for _, c := range tblInfo.Constraints {
+		if !c.HasColumn(colName) {
+			continue
+		}
+		if isDrop && len(c.ConstraintCols) == 1 {
+			continue
+		}
+		return errDependentByCheckConstraint.GenWithStackByArgs(c.Name.O, colName.O)
+	}
+	return nil
+}
+
+// removeCheckConstraintsOnlyOnColumn removes the constraints only using the dropped column.
+func removeCheckConstraintsOnlyOnColumn(tblInfo *model.TableInfo, colName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, c := range tblInfo.Constraints {
+		if len(c.ConstraintCols) == 1 && c.HasColumn(colName) {
+			continue
+		}
+		constraints = append(constraints, c)
+	}
+	tblInfo.Constraints = constraints
+}
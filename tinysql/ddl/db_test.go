@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
@@ -1189,3 +1190,251 @@ func init() {
 	// Make sure it will only be executed once.
 	domain.SchemaOutOfDateRetryInterval = int64(50 * time.Millisecond)
 }
+
+func (s *testDBSuite1) TestCreateTableWithPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists tp")
+	tk.MustExec(`create table tp (a int, b int) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than maxvalue)`)
+	tbl := testGetTableByName(c, s.s, "test_db", "tp")
+	pi := tbl.Meta().GetPartitionInfo()
+	c.Assert(pi, NotNil)
+	c.Assert(pi.Type, Equals, model.PartitionTypeRange)
+	c.Assert(pi.Expr, Equals, "a")
+	c.Assert(pi.Definitions, HasLen, 3)
+	c.Assert(pi.Definitions[0].LessThan, DeepEquals, []string{"10"})
+	c.Assert(pi.Definitions[2].LessThan, DeepEquals, []string{"MAXVALUE"})
+	for _, def := range pi.Definitions {
+		c.Assert(def.ID, Not(Equals), tbl.Meta().ID)
+	}
+
+	tk.MustExec("drop table if exists th")
+	tk.MustExec("create table th (a int) partition by hash (a) partitions 4")
+	pi = testGetTableByName(c, s.s, "test_db", "th").Meta().GetPartitionInfo()
+	c.Assert(pi.Type, Equals, model.PartitionTypeHash)
+	c.Assert(pi.Num, Equals, uint64(4))
+	c.Assert(pi.Definitions[3].Name.L, Equals, "p3")
+
+	tk.MustGetErrCode(`create table t1 (a int) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (5))`, mysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode(`create table t1 (a int) partition by range (a) (
+		partition p0 values less than maxvalue,
+		partition p1 values less than (5))`, mysql.ErrPartitionMaxvalue)
+	tk.MustGetErrCode(`create table t1 (a int) partition by range (a) (
+		partition p0 values less than (10),
+		partition p0 values less than (20))`, mysql.ErrSameNamePartition)
+	tk.MustGetErrCode(`create table t1 (a int) partition by list (a) (
+		partition p0 values in (1, 2),
+		partition p1 values in (2, 3))`, mysql.ErrMultipleDefConstInListPart)
+	tk.MustGetErrCode(`create table t1 (a int) partition by range (a) (
+		partition p0 values in (1, 2))`, mysql.ErrPartitionWrongValues)
+	tk.MustGetErrCode("create table t1 (a int) partition by range (a)", mysql.ErrPartitionsMustBeDefined)
+	tk.MustGetErrCode(`create table t1 (a int) partition by range (c) (
+		partition p0 values less than (10))`, mysql.ErrBadField)
+	tk.MustGetErrCode(`create table t1 (a varchar(10)) partition by range (a) (
+		partition p0 values less than (10))`, mysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode(`create table t1 (a int primary key, b int) partition by range (b) (
+		partition p0 values less than (10))`, mysql.ErrUniqueKeyNeedAllFieldsInPf)
+}
+
+func (s *testDBSuite3) TestGeneratedColumnDDL(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists tg")
+	tk.MustExec("create table tg (a int, b int as (a + 1), c int as (b * 2) stored)")
+	tblInfo := testGetTableByName(c, s.s, "test_db", "tg").Meta()
+	c.Assert(tblInfo.Columns[1].GeneratedExprString, Equals, "a + 1")
+	c.Assert(tblInfo.Columns[1].GeneratedStored, IsFalse)
+	c.Assert(tblInfo.Columns[2].GeneratedStored, IsTrue)
+	c.Assert(tblInfo.Columns[2].Dependences, DeepEquals, map[string]struct{}{"b": {}})
+
+	tk.MustGetErrCode("create table t1 (a int as (b + 1), b int as (a + 1))", mysql.ErrGeneratedColumnNonPrior)
+	tk.MustGetErrCode("create table t1 (a int, b int as (c + 1))", mysql.ErrBadField)
+	tk.MustGetErrCode("create table t1 (a int auto_increment primary key, b int as (a + 1))", mysql.ErrGeneratedColumnRefAutoInc)
+	tk.MustGetErrCode("create table t1 (a int, b double as (rand()))", mysql.ErrGeneratedColumnFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int, b int as (@x))", mysql.ErrGeneratedColumnFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int, b int as (a + 1) primary key)", mysql.ErrUnsupportedOnGeneratedColumn)
+	_, err := tk.Exec("create table t1 (a int, b int as (no_such_func(a)))")
+	c.Assert(err, NotNil)
+
+	tk.MustGetErrCode("alter table tg drop column a", mysql.ErrDependentByGeneratedColumn)
+	tk.MustGetErrCode("alter table tg change column b bb int as (a + 1)", mysql.ErrDependentByGeneratedColumn)
+	tk.MustGetErrCode("alter table tg modify column b int as (a + 1) stored", mysql.ErrUnsupportedOnGeneratedColumn)
+	tk.MustGetErrCode("alter table tg add column d int as (a + 2) stored", mysql.ErrUnsupportedOnGeneratedColumn)
+	tk.MustGetErrCode("alter table tg add column d int as (e + 2)", mysql.ErrBadField)
+
+	// The index on the virtual column is backfilled with the evaluated values.
+	tk.MustExec("insert into tg(a) values (1), (2), (3)")
+	tk.MustExec("alter table tg add column d varchar(10) as (concat('v', c))")
+	tk.MustExec("alter table tg add index idx_d(d)")
+	tk.MustQuery("select a, d from tg use index(idx_d) where d > 'v5' order by d").Check(testkit.Rows("2 v6", "3 v8"))
+	tk.MustQuery("select a from tg use index(idx_d) where d = 'v4'").Check(testkit.Rows("1"))
+	tk.MustExec("alter table tg drop index idx_d")
+	tk.MustExec("alter table tg drop column d")
+	tk.MustExec("drop table tg")
+}
+
+func (s *testDBSuite3) TestCheckConstraintDDL(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists tc")
+	tk.MustExec("create table tc (a int check (a > 0), b int, c int, constraint c_ab check (a < b) not enforced, check (c <> 0))")
+	tblInfo := testGetTableByName(c, s.s, "test_db", "tc").Meta()
+	c.Assert(tblInfo.Constraints, HasLen, 3)
+	c.Assert(tblInfo.Constraints[0].Name.O, Equals, "c_ab")
+	c.Assert(tblInfo.Constraints[0].Enforced, IsFalse)
+	c.Assert(tblInfo.Constraints[0].ConstraintCols, DeepEquals, []model.CIStr{model.NewCIStr("a"), model.NewCIStr("b")})
+	c.Assert(tblInfo.Constraints[1].Name.O, Equals, "tc_chk_1")
+	c.Assert(tblInfo.Constraints[1].Enforced, IsTrue)
+	c.Assert(tblInfo.Constraints[2].Name.O, Equals, "tc_chk_2")
+	c.Assert(tblInfo.Constraints[2].InColumn, IsTrue)
+	c.Assert(tblInfo.Constraints[2].ExprString, Equals, "a > 0")
+
+	tk.MustGetErrCode("create table t1 (a int, constraint c1 check (a > 0), constraint c1 check (a < 10))", mysql.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("create table t1 (a int check (b > 0), b int)", mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("create table t1 (a int, check (b > 0))", mysql.ErrCheckConstraintRefersUnknownColumn)
+	tk.MustGetErrCode("create table t1 (a int auto_increment primary key, check (a > 0))", mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table t1 (a datetime, check (a < now()))", mysql.ErrCheckConstraintNamedFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table t1 (a int, check (a > @x))", mysql.ErrCheckConstraintVariables)
+	tk.MustGetErrCode("create table t1 (a int, check (a))", mysql.ErrNonBooleanExprForCheckConstraint)
+
+	// The existing rows are verified when adding or enforcing a constraint.
+	tk.MustExec("insert into tc values (1, 0, 1), (2, 3, 1)")
+	tk.MustGetErrCode("alter table tc add constraint c_b check (b > 0)", mysql.ErrCheckConstraintViolated)
+	c.Assert(testGetTableByName(c, s.s, "test_db", "tc").Meta().FindConstraintInfoByName("c_b"), IsNil)
+	tk.MustGetErrCode("alter table tc alter check c_ab enforced", mysql.ErrCheckConstraintViolated)
+	c.Assert(testGetTableByName(c, s.s, "test_db", "tc").Meta().FindConstraintInfoByName("c_ab").Enforced, IsFalse)
+	tk.MustGetErrCode("alter table tc add constraint c_ab check (b > 0)", mysql.ErrCheckConstraintDupName)
+	tk.MustExec("alter table tc add constraint c_b check (b >= 0)")
+	tk.MustExec("alter table tc add check (c < 10) not enforced")
+	tblInfo = testGetTableByName(c, s.s, "test_db", "tc").Meta()
+	c.Assert(tblInfo.Constraints, HasLen, 5)
+	c.Assert(tblInfo.Constraints[3].State, Equals, model.StatePublic)
+	c.Assert(tblInfo.Constraints[4].Name.O, Equals, "tc_chk_3")
+	c.Assert(tblInfo.Constraints[4].Enforced, IsFalse)
+
+	tk.MustExec("delete from tc where b = 0")
+	tk.MustExec("alter table tc alter check c_ab enforced")
+	c.Assert(testGetTableByName(c, s.s, "test_db", "tc").Meta().FindConstraintInfoByName("c_ab").Enforced, IsTrue)
+	tk.MustExec("alter table tc alter check c_ab not enforced")
+	tk.MustGetErrCode("alter table tc alter check no_such enforced", mysql.ErrCheckConstraintNotFound)
+	tk.MustGetErrCode("alter table tc drop check no_such", mysql.ErrCheckConstraintNotFound)
+	tk.MustExec("alter table tc drop check tc_chk_3")
+	c.Assert(testGetTableByName(c, s.s, "test_db", "tc").Meta().Constraints, HasLen, 4)
+
+	// A column used by a constraint on other columns can't be dropped or renamed,
+	// the constraints only on the dropped column are dropped with it.
+	tk.MustGetErrCode("alter table tc drop column b", mysql.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table tc change column c cc int", mysql.ErrDependentByCheckConstraint)
+	tk.MustExec("alter table tc drop column c")
+	tblInfo = testGetTableByName(c, s.s, "test_db", "tc").Meta()
+	c.Assert(tblInfo.Constraints, HasLen, 3)
+	c.Assert(tblInfo.FindConstraintInfoByName("tc_chk_1"), IsNil)
+	tk.MustExec("drop table tc")
+}
+
+func (s *testDBSuite3) TestForeignKeyDDL(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists fk_c, fk_p")
+	tk.MustExec("create table fk_p (id int primary key, a int, b int, unique key (a), key (b))")
+	tk.MustExec("create table fk_c (id int primary key, pid int, pa int, foreign key (pid) references fk_p (id) on delete cascade, constraint fk_a foreign key (pa) references fk_p (a))")
+	tblInfo := testGetTableByName(c, s.s, "test_db", "fk_c").Meta()
+	c.Assert(tblInfo.ForeignKeys, HasLen, 2)
+	c.Assert(tblInfo.ForeignKeys[0].Name.O, Equals, "fk_c_ibfk_1")
+	c.Assert(tblInfo.ForeignKeys[0].RefSchema.O, Equals, "test_db")
+	c.Assert(tblInfo.ForeignKeys[0].OnDelete, Equals, int(ast.ReferOptionCascade))
+	c.Assert(tblInfo.ForeignKeys[1].Name.O, Equals, "fk_a")
+	// The indexes for the foreign key lookups are created.
+	c.Assert(tblInfo.Indices, HasLen, 2)
+
+	tk.MustGetErrCode("create table t1 (a int, foreign key (a) references fk_p (id, a))", mysql.ErrWrongFkDef)
+	tk.MustGetErrCode("create table t1 (a int, foreign key (a) references no_such (id))", mysql.ErrFkCannotOpenParent)
+	tk.MustGetErrCode("create table t1 (a int, foreign key (a) references fk_p (b))", mysql.ErrFkNoIndexParent)
+	tk.MustGetErrCode("create table t1 (a varchar(10), foreign key (a) references fk_p (id))", mysql.ErrFKIncompatibleColumns)
+	tk.MustGetErrCode("create table t1 (a int not null, foreign key (a) references fk_p (id) on delete set null)", mysql.ErrFkColumnNotNull)
+	tk.MustGetErrCode("create table t1 (a int, constraint f foreign key (a) references fk_p (id), constraint f foreign key (a) references fk_p (a))", mysql.ErrFkDupName)
+	tk.MustGetErrCode("create table t1 (a int, foreign key (a) references fk_p (id)) partition by hash (a) partitions 2", mysql.ErrForeignKeyOnPartitioned)
+	// The parent table may not exist when foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("create table t1 (a int, foreign key (a) references no_such (id))")
+	tk.MustExec("drop table t1")
+	tk.MustExec("set @@foreign_key_checks = 1")
+
+	// The existing rows are verified when adding a foreign key.
+	tk.MustExec("insert into fk_p values (1, 1, 1)")
+	tk.MustExec("alter table fk_c add column pb int")
+	tk.MustExec("insert into fk_c values (1, 1, 1, 1), (2, 1, null, 2)")
+	tk.MustGetErrCode("alter table fk_c add constraint fk_b foreign key (pb) references fk_p (a)", mysql.ErrFkNoIndexChild)
+	tk.MustExec("alter table fk_c add index (pb)")
+	tk.MustGetErrCode("alter table fk_c add constraint fk_b foreign key (pb) references fk_p (a)", mysql.ErrNoReferencedRow2)
+	c.Assert(testGetTableByName(c, s.s, "test_db", "fk_c").Meta().FindFKInfoByName("fk_b"), IsNil)
+	tk.MustGetErrCode("alter table fk_c add constraint fk_a foreign key (pb) references fk_p (a)", mysql.ErrFkDupName)
+	tk.MustExec("delete from fk_c where id = 2")
+	tk.MustExec("alter table fk_c add constraint fk_b foreign key (pb) references fk_p (a)")
+	c.Assert(testGetTableByName(c, s.s, "test_db", "fk_c").Meta().FindFKInfoByName("fk_b").State, Equals, model.StatePublic)
+
+	// The columns and indexes used by the foreign keys can't be dropped or changed.
+	tk.MustGetErrCode("alter table fk_c drop column pb", mysql.ErrFkColumnCannotDrop)
+	tk.MustGetErrCode("alter table fk_p drop column a", mysql.ErrFkColumnCannotDropChild)
+	tk.MustGetErrCode("alter table fk_c change column pa pa2 int", mysql.ErrFkColumnCannotChange)
+	tk.MustGetErrCode("alter table fk_p change column a a2 int", mysql.ErrFkColumnCannotChangeChild)
+	tk.MustGetErrCode("alter table fk_c drop index pb", mysql.ErrDropIndexFk)
+	tk.MustGetErrCode("alter table fk_p drop index a", mysql.ErrDropIndexFk)
+	tk.MustGetErrCode("drop table fk_p", mysql.ErrFkCannotDropParent)
+
+	tk.MustGetErrCode("alter table fk_c drop foreign key no_such", mysql.ErrCantDropFieldOrKey)
+	tk.MustExec("alter table fk_c drop foreign key fk_b")
+	tk.MustExec("alter table fk_c drop index pb")
+	tk.MustExec("alter table fk_c drop foreign key fk_a")
+	tk.MustExec("alter table fk_c drop foreign key fk_c_ibfk_1")
+	c.Assert(testGetTableByName(c, s.s, "test_db", "fk_c").Meta().ForeignKeys, HasLen, 0)
+	tk.MustExec("drop table fk_p")
+	tk.MustExec("drop table fk_c")
+}
+
+func (s *testDBSuite2) TestAlterTablePartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists tp")
+	tk.MustExec(`create table tp (a int, b int) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustExec("insert into tp values (1, 1), (11, 11)")
+
+	tk.MustExec("alter table tp add partition (partition p2 values less than (30))")
+	pi := testGetTableByName(c, s.s, "test_db", "tp").Meta().GetPartitionInfo()
+	c.Assert(pi.Definitions, HasLen, 3)
+	c.Assert(pi.Definitions[2].Name.L, Equals, "p2")
+	tk.MustGetErrCode("alter table tp add partition (partition p3 values less than (25))", mysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode("alter table tp add partition (partition p1 values less than (40))", mysql.ErrSameNamePartition)
+	tk.MustExec("insert into tp values (21, 21)")
+	tk.MustGetErrCode("insert into tp values (31, 31)", mysql.ErrNoPartitionForGivenValue)
+
+	tk.MustExec("alter table tp truncate partition p1")
+	newPi := testGetTableByName(c, s.s, "test_db", "tp").Meta().GetPartitionInfo()
+	c.Assert(newPi.Definitions[1].ID, Not(Equals), pi.Definitions[1].ID)
+	tk.MustQuery("select a from tp order by a").Check(testkit.Rows("1", "21"))
+
+	tk.MustExec("alter table tp drop partition p0")
+	c.Assert(testGetTableByName(c, s.s, "test_db", "tp").Meta().GetPartitionInfo().Definitions, HasLen, 2)
+	tk.MustQuery("select a from tp order by a").Check(testkit.Rows("21"))
+	tk.MustGetErrCode("alter table tp drop partition p0", mysql.ErrDropPartitionNonExistent)
+	tk.MustExec("alter table tp drop partition if exists p0")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
+	tk.MustExec("alter table tp drop partition p1")
+	tk.MustGetErrCode("alter table tp drop partition p2", mysql.ErrDropLastPartition)
+	tk.MustGetErrCode("alter table tp drop column a", mysql.ErrUnsupportedDDLOperation)
+
+	tk.MustExec("drop table if exists th")
+	tk.MustExec("create table th (a int) partition by hash (a) partitions 2")
+	tk.MustGetErrCode("alter table th drop partition p0", mysql.ErrOnlyOnRangeListPartition)
+	tk.MustGetErrCode("alter table th add partition (partition p2 values less than (10))", mysql.ErrOnlyOnRangeListPartition)
+	tk.MustExec("drop table if exists tn")
+	tk.MustExec("create table tn (a int)")
+	tk.MustGetErrCode("alter table tn drop partition p0", mysql.ErrPartitionMgmtOnNonpartitioned)
+}
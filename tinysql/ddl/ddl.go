@@ -72,6 +72,7 @@ var (
 
 	// We don't support dropping column with index covered now.
 	errCantDropColWithIndex      = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "drop column with index"))
+	errCantDropColWithPartition  = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "drop or rename column used by the partition function"))
 	errUnsupportedAddColumn      = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "add column"))
 	errUnsupportedModifyColumn   = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "modify column: %s"))
 	errUnsupportedModifyCharset  = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "modify %s"))
@@ -101,7 +102,65 @@ var (
 	ErrGeneratedColumnRefAutoInc = terror.ClassDDL.New(mysql.ErrGeneratedColumnRefAutoInc, mysql.MySQLErrName[mysql.ErrGeneratedColumnRefAutoInc])
 	// ErrGeneratedColumnFunctionIsNotAllowed returns for unsupported functions for generated columns.
 	ErrGeneratedColumnFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrGeneratedColumnFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrGeneratedColumnFunctionIsNotAllowed])
-	errUnsupportedIndexType                = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "index type"))
+	// errGeneratedColumnNonPrior forbids to refer generated column non prior to it.
+	errGeneratedColumnNonPrior = terror.ClassDDL.New(mysql.ErrGeneratedColumnNonPrior, mysql.MySQLErrName[mysql.ErrGeneratedColumnNonPrior])
+	// errDependentByGeneratedColumn forbids to delete columns which are dependent by generated columns.
+	errDependentByGeneratedColumn = terror.ClassDDL.New(mysql.ErrDependentByGeneratedColumn, mysql.MySQLErrName[mysql.ErrDependentByGeneratedColumn])
+	// ErrUnsupportedOnGeneratedColumn is for unsupported actions on generated columns.
+	ErrUnsupportedOnGeneratedColumn = terror.ClassDDL.New(mysql.ErrUnsupportedOnGeneratedColumn, mysql.MySQLErrName[mysql.ErrUnsupportedOnGeneratedColumn])
+	// errNonBooleanExprForCheckConstraint returns for a check constraint whose expression isn't a condition.
+	errNonBooleanExprForCheckConstraint = terror.ClassDDL.New(mysql.ErrNonBooleanExprForCheckConstraint, mysql.MySQLErrName[mysql.ErrNonBooleanExprForCheckConstraint])
+	// errColumnCheckConstraintReferencesOtherColumn forbids a column check constraint to refer other columns.
+	errColumnCheckConstraintReferencesOtherColumn = terror.ClassDDL.New(mysql.ErrColumnCheckConstraintReferencesOtherColumn, mysql.MySQLErrName[mysql.ErrColumnCheckConstraintReferencesOtherColumn])
+	// errCheckConstraintNamedFunctionIsNotAllowed returns for unsupported functions for check constraints.
+	errCheckConstraintNamedFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrCheckConstraintNamedFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrCheckConstraintNamedFunctionIsNotAllowed])
+	// errCheckConstraintFunctionIsNotAllowed returns for unsupported expressions for check constraints.
+	errCheckConstraintFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrCheckConstraintFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrCheckConstraintFunctionIsNotAllowed])
+	// errCheckConstraintVariables forbids a check constraint to refer user or system variables.
+	errCheckConstraintVariables = terror.ClassDDL.New(mysql.ErrCheckConstraintVariables, mysql.MySQLErrName[mysql.ErrCheckConstraintVariables])
+	// errCheckConstraintRowValue forbids a check constraint to refer row values.
+	errCheckConstraintRowValue = terror.ClassDDL.New(mysql.ErrCheckConstraintRowValue, mysql.MySQLErrName[mysql.ErrCheckConstraintRowValue])
+	// errCheckConstraintRefersAutoIncrementColumn forbids a check constraint to refer the auto-increment column.
+	errCheckConstraintRefersAutoIncrementColumn = terror.ClassDDL.New(mysql.ErrCheckConstraintRefersAutoIncrementColumn, mysql.MySQLErrName[mysql.ErrCheckConstraintRefersAutoIncrementColumn])
+	// errCheckConstraintRefersUnknownColumn returns for a check constraint referring a column that doesn't exist.
+	errCheckConstraintRefersUnknownColumn = terror.ClassDDL.New(mysql.ErrCheckConstraintRefersUnknownColumn, mysql.MySQLErrName[mysql.ErrCheckConstraintRefersUnknownColumn])
+	// errCheckConstraintNotFound returns for altering or dropping a check constraint that doesn't exist.
+	errCheckConstraintNotFound = terror.ClassDDL.New(mysql.ErrCheckConstraintNotFound, mysql.MySQLErrName[mysql.ErrCheckConstraintNotFound])
+	// errCheckConstraintDupName returns for a check constraint name that is already used in the table.
+	errCheckConstraintDupName = terror.ClassDDL.New(mysql.ErrCheckConstraintDupName, mysql.MySQLErrName[mysql.ErrCheckConstraintDupName])
+	// errDependentByCheckConstraint forbids to drop or rename columns which are used by check constraints.
+	errDependentByCheckConstraint = terror.ClassDDL.New(mysql.ErrDependentByCheckConstraint, mysql.MySQLErrName[mysql.ErrDependentByCheckConstraint])
+	// errWrongFkDef returns for a foreign key whose columns don't match the referenced columns.
+	errWrongFkDef = terror.ClassDDL.New(mysql.ErrWrongFkDef, mysql.MySQLErrName[mysql.ErrWrongFkDef])
+	// errCannotAddForeign returns for a foreign key option which isn't supported.
+	errCannotAddForeign = terror.ClassDDL.New(mysql.ErrCannotAddForeign, mysql.MySQLErrName[mysql.ErrCannotAddForeign])
+	// errFkNoIndexChild returns for adding a foreign key without an index on its columns.
+	errFkNoIndexChild = terror.ClassDDL.New(mysql.ErrFkNoIndexChild, mysql.MySQLErrName[mysql.ErrFkNoIndexChild])
+	// errFkNoIndexParent returns for a foreign key referring columns which aren't the primary key or a unique index.
+	errFkNoIndexParent = terror.ClassDDL.New(mysql.ErrFkNoIndexParent, mysql.MySQLErrName[mysql.ErrFkNoIndexParent])
+	// errFkCannotOpenParent returns for a foreign key referring a table that doesn't exist.
+	errFkCannotOpenParent = terror.ClassDDL.New(mysql.ErrFkCannotOpenParent, mysql.MySQLErrName[mysql.ErrFkCannotOpenParent])
+	// errFkDupName returns for a foreign key name that is already used in the table.
+	errFkDupName = terror.ClassDDL.New(mysql.ErrFkDupName, mysql.MySQLErrName[mysql.ErrFkDupName])
+	// errFkColumnCannotDrop forbids to drop columns which are used by foreign keys.
+	errFkColumnCannotDrop = terror.ClassDDL.New(mysql.ErrFkColumnCannotDrop, mysql.MySQLErrName[mysql.ErrFkColumnCannotDrop])
+	// errFkColumnCannotDropChild forbids to drop columns which are referred by foreign keys of other tables.
+	errFkColumnCannotDropChild = terror.ClassDDL.New(mysql.ErrFkColumnCannotDropChild, mysql.MySQLErrName[mysql.ErrFkColumnCannotDropChild])
+	// errFkColumnNotNull forbids a SET NULL foreign key on NOT NULL columns.
+	errFkColumnNotNull = terror.ClassDDL.New(mysql.ErrFkColumnNotNull, mysql.MySQLErrName[mysql.ErrFkColumnNotNull])
+	// errFkColumnCannotChange forbids to change columns which are used by foreign keys.
+	errFkColumnCannotChange = terror.ClassDDL.New(mysql.ErrFkColumnCannotChange, mysql.MySQLErrName[mysql.ErrFkColumnCannotChange])
+	// errFkColumnCannotChangeChild forbids to change columns which are referred by foreign keys of other tables.
+	errFkColumnCannotChangeChild = terror.ClassDDL.New(mysql.ErrFkColumnCannotChangeChild, mysql.MySQLErrName[mysql.ErrFkColumnCannotChangeChild])
+	// errFKIncompatibleColumns returns for a foreign key column whose type differs from the referenced column.
+	errFKIncompatibleColumns = terror.ClassDDL.New(mysql.ErrFKIncompatibleColumns, mysql.MySQLErrName[mysql.ErrFKIncompatibleColumns])
+	// errDropIndexFk forbids to drop indexes which are needed by foreign keys.
+	errDropIndexFk = terror.ClassDDL.New(mysql.ErrDropIndexFk, mysql.MySQLErrName[mysql.ErrDropIndexFk])
+	// errFkCannotDropParent forbids to drop tables which are referred by foreign keys.
+	errFkCannotDropParent = terror.ClassDDL.New(mysql.ErrFkCannotDropParent, mysql.MySQLErrName[mysql.ErrFkCannotDropParent])
+	// errForeignKeyOnPartitioned forbids foreign keys on partitioned tables.
+	errForeignKeyOnPartitioned = terror.ClassDDL.New(mysql.ErrForeignKeyOnPartitioned, mysql.MySQLErrName[mysql.ErrForeignKeyOnPartitioned])
+	errUnsupportedIndexType    = terror.ClassDDL.New(mysql.ErrUnsupportedDDLOperation, fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation], "index type"))
 
 	// ErrDupKeyName returns for duplicated key name
 	ErrDupKeyName = terror.ClassDDL.New(mysql.ErrDupKeyName, mysql.MySQLErrName[mysql.ErrDupKeyName])
@@ -146,12 +205,56 @@ var (
 
 	// ErrPartitionMgmtOnNonpartitioned returns it's not a partition table.
 	ErrPartitionMgmtOnNonpartitioned = terror.ClassDDL.New(mysql.ErrPartitionMgmtOnNonpartitioned, mysql.MySQLErrName[mysql.ErrPartitionMgmtOnNonpartitioned])
+	// ErrSameNamePartition returns duplicate partition name.
+	ErrSameNamePartition = terror.ClassDDL.New(mysql.ErrSameNamePartition, mysql.MySQLErrName[mysql.ErrSameNamePartition])
+	// ErrRangeNotIncreasing returns values less than value must be strictly increasing for each partition.
+	ErrRangeNotIncreasing = terror.ClassDDL.New(mysql.ErrRangeNotIncreasing, mysql.MySQLErrName[mysql.ErrRangeNotIncreasing])
+	// ErrPartitionMaxvalue returns maxvalue can only be used in last partition definition.
+	ErrPartitionMaxvalue = terror.ClassDDL.New(mysql.ErrPartitionMaxvalue, mysql.MySQLErrName[mysql.ErrPartitionMaxvalue])
+	// ErrPartitionsMustBeDefined returns each partition must be defined.
+	ErrPartitionsMustBeDefined = terror.ClassDDL.New(mysql.ErrPartitionsMustBeDefined, mysql.MySQLErrName[mysql.ErrPartitionsMustBeDefined])
+	// ErrPartitionRequiresValues returns a partition definition lacks the VALUES clause of its partitioning type.
+	ErrPartitionRequiresValues = terror.ClassDDL.New(mysql.ErrPartitionRequiresValues, mysql.MySQLErrName[mysql.ErrPartitionRequiresValues])
+	// ErrPartitionWrongValues returns a partition definition uses the VALUES clause of another partitioning type.
+	ErrPartitionWrongValues = terror.ClassDDL.New(mysql.ErrPartitionWrongValues, mysql.MySQLErrName[mysql.ErrPartitionWrongValues])
+	// ErrPartitionWrongNoPart returns the number of partitions mismatches the definitions.
+	ErrPartitionWrongNoPart = terror.ClassDDL.New(mysql.ErrPartitionWrongNoPart, mysql.MySQLErrName[mysql.ErrPartitionWrongNoPart])
+	// ErrTooManyPartitions returns too many partitions were defined.
+	ErrTooManyPartitions = terror.ClassDDL.New(mysql.ErrTooManyPartitions, mysql.MySQLErrName[mysql.ErrTooManyPartitions])
+	// ErrMultipleDefConstInListPart returns multiple definition of same constant in list partitioning.
+	ErrMultipleDefConstInListPart = terror.ClassDDL.New(mysql.ErrMultipleDefConstInListPart, mysql.MySQLErrName[mysql.ErrMultipleDefConstInListPart])
+	// ErrValuesIsNotIntType returns the VALUES of a partition are not integers.
+	ErrValuesIsNotIntType = terror.ClassDDL.New(mysql.ErrValuesIsNotIntType, mysql.MySQLErrName[mysql.ErrValuesIsNotIntType])
+	// ErrPartitionFuncNotAllowed returns the partitioning function returns the wrong type.
+	ErrPartitionFuncNotAllowed = terror.ClassDDL.New(mysql.ErrPartitionFuncNotAllowed, mysql.MySQLErrName[mysql.ErrPartitionFuncNotAllowed])
+	// ErrPartitionFunctionIsNotAllowed returns the partitioning function is not allowed.
+	ErrPartitionFunctionIsNotAllowed = terror.ClassDDL.New(mysql.ErrPartitionFunctionIsNotAllowed, mysql.MySQLErrName[mysql.ErrPartitionFunctionIsNotAllowed])
+	// ErrWrongExprInPartitionFunc returns constant, random or timezone-dependent expressions are not allowed in the partitioning function.
+	ErrWrongExprInPartitionFunc = terror.ClassDDL.New(mysql.ErrWrongExprInPartitionFunc, mysql.MySQLErrName[mysql.ErrWrongExprInPartitionFunc])
+	// ErrFieldTypeNotAllowedAsPartitionField returns the column type is not allowed as the partitioning column.
+	ErrFieldTypeNotAllowedAsPartitionField = terror.ClassDDL.New(mysql.ErrFieldTypeNotAllowedAsPartitionField, mysql.MySQLErrName[mysql.ErrFieldTypeNotAllowedAsPartitionField])
+	// ErrNullInValuesLessThan returns NULL is used in VALUES LESS THAN.
+	ErrNullInValuesLessThan = terror.ClassDDL.New(mysql.ErrNullInValuesLessThan, mysql.MySQLErrName[mysql.ErrNullInValuesLessThan])
+	// ErrPartitionColumnList returns the partition values are column lists.
+	ErrPartitionColumnList = terror.ClassDDL.New(mysql.ErrPartitionColumnList, mysql.MySQLErrName[mysql.ErrPartitionColumnList])
+	// ErrUniqueKeyNeedAllFieldsInPf returns a unique key must include all columns in the partitioning function.
+	ErrUniqueKeyNeedAllFieldsInPf = terror.ClassDDL.New(mysql.ErrUniqueKeyNeedAllFieldsInPf, mysql.MySQLErrName[mysql.ErrUniqueKeyNeedAllFieldsInPf])
+	// ErrDropLastPartition returns cannot remove all partitions.
+	ErrDropLastPartition = terror.ClassDDL.New(mysql.ErrDropLastPartition, mysql.MySQLErrName[mysql.ErrDropLastPartition])
+	// ErrDropPartitionNonExistent returns the partitions to drop or truncate don't exist.
+	ErrDropPartitionNonExistent = terror.ClassDDL.New(mysql.ErrDropPartitionNonExistent, mysql.MySQLErrName[mysql.ErrDropPartitionNonExistent])
+	// ErrOnlyOnRangeListPartition returns the partition management is only supported by RANGE/LIST partitions.
+	ErrOnlyOnRangeListPartition = terror.ClassDDL.New(mysql.ErrOnlyOnRangeListPartition, mysql.MySQLErrName[mysql.ErrOnlyOnRangeListPartition])
 	// ErrWarnDataTruncated returns data truncated error.
 	ErrWarnDataTruncated = terror.ClassDDL.New(mysql.WarnDataTruncated, mysql.MySQLErrName[mysql.WarnDataTruncated])
 	// ErrAlterOperationNotSupported returns when alter operations is not supported.
 	ErrAlterOperationNotSupported = terror.ClassDDL.New(mysql.ErrAlterOperationNotSupportedReason, mysql.MySQLErrName[mysql.ErrAlterOperationNotSupportedReason])
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = terror.ClassDDL.New(mysql.ErrTableCantHandleFt, mysql.MySQLErrName[mysql.ErrTableCantHandleFt])
+	// ErrViewWrongList returns create view must include all columns in the select clause
+	ErrViewWrongList = terror.ClassDDL.New(mysql.ErrViewWrongList, mysql.MySQLErrName[mysql.ErrViewWrongList])
+	// ErrWrongObject returns for wrong object.
+	ErrWrongObject = terror.ClassDDL.New(mysql.ErrWrongObject, mysql.MySQLErrName[mysql.ErrWrongObject])
 )
 
 // DDL is responsible for updating schema in data store and maintaining in-memory InfoSchema cache.
@@ -159,7 +262,9 @@ type DDL interface {
 	CreateSchema(ctx sessionctx.Context, name model.CIStr, charsetInfo *ast.CharsetOpt) error
 	DropSchema(ctx sessionctx.Context, schema model.CIStr) error
 	CreateTable(ctx sessionctx.Context, stmt *ast.CreateTableStmt) error
+	CreateView(ctx sessionctx.Context, stmt *ast.CreateViewStmt) error
 	DropTable(ctx sessionctx.Context, tableIdent ast.Ident) (err error)
+	DropView(ctx sessionctx.Context, tableIdent ast.Ident) (err error)
 	CreateIndex(ctx sessionctx.Context, tableIdent ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 		columnNames []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error
 	DropIndex(ctx sessionctx.Context, tableIdent ast.Ident, indexName model.CIStr, ifExists bool) error
@@ -486,89 +591,118 @@ func (d *ddl) GetHook() Callback {
 
 func init() {
 	ddlMySQLErrCodes := map[terror.ErrCode]uint16{
-		mysql.ErrAlterOperationNotSupportedReason:     mysql.ErrAlterOperationNotSupportedReason,
-		mysql.ErrBadField:                             mysql.ErrBadField,
-		mysql.ErrBadNull:                              mysql.ErrBadNull,
-		mysql.ErrBlobCantHaveDefault:                  mysql.ErrBlobCantHaveDefault,
-		mysql.ErrBlobKeyWithoutLength:                 mysql.ErrBlobKeyWithoutLength,
-		mysql.ErrCancelledDDLJob:                      mysql.ErrCancelledDDLJob,
-		mysql.ErrCantDecodeIndex:                      mysql.ErrCantDecodeIndex,
-		mysql.ErrCantDropFieldOrKey:                   mysql.ErrCantDropFieldOrKey,
-		mysql.ErrCantRemoveAllFields:                  mysql.ErrCantRemoveAllFields,
-		mysql.ErrCoalesceOnlyOnHashPartition:          mysql.ErrCoalesceOnlyOnHashPartition,
-		mysql.ErrCollationCharsetMismatch:             mysql.ErrCollationCharsetMismatch,
-		mysql.ErrConflictingDeclarations:              mysql.ErrConflictingDeclarations,
-		mysql.ErrDependentByGeneratedColumn:           mysql.ErrDependentByGeneratedColumn,
-		mysql.ErrDropLastPartition:                    mysql.ErrDropLastPartition,
-		mysql.ErrDropPartitionNonExistent:             mysql.ErrDropPartitionNonExistent,
-		mysql.ErrDupKeyName:                           mysql.ErrDupKeyName,
-		mysql.ErrErrorOnRename:                        mysql.ErrErrorOnRename,
-		mysql.ErrFieldNotFoundPart:                    mysql.ErrFieldNotFoundPart,
-		mysql.ErrFieldTypeNotAllowedAsPartitionField:  mysql.ErrFieldTypeNotAllowedAsPartitionField,
-		mysql.ErrFileNotFound:                         mysql.ErrFileNotFound,
-		mysql.ErrGeneratedColumnFunctionIsNotAllowed:  mysql.ErrGeneratedColumnFunctionIsNotAllowed,
-		mysql.ErrGeneratedColumnNonPrior:              mysql.ErrGeneratedColumnNonPrior,
-		mysql.ErrGeneratedColumnRefAutoInc:            mysql.ErrGeneratedColumnRefAutoInc,
-		mysql.ErrInvalidDDLJob:                        mysql.ErrInvalidDDLJob,
-		mysql.ErrInvalidDDLState:                      mysql.ErrInvalidDDLState,
-		mysql.ErrInvalidDDLWorker:                     mysql.ErrInvalidDDLWorker,
-		mysql.ErrInvalidDefault:                       mysql.ErrInvalidDefault,
-		mysql.ErrInvalidGroupFuncUse:                  mysql.ErrInvalidGroupFuncUse,
-		mysql.ErrInvalidDDLJobFlag:                    mysql.ErrInvalidDDLJobFlag,
-		mysql.ErrInvalidDDLJobVersion:                 mysql.ErrInvalidDDLJobVersion,
-		mysql.ErrInvalidOnUpdate:                      mysql.ErrInvalidOnUpdate,
-		mysql.ErrInvalidSplitRegionRanges:             mysql.ErrInvalidSplitRegionRanges,
-		mysql.ErrInvalidStoreVersion:                  mysql.ErrInvalidStoreVersion,
-		mysql.ErrInvalidUseOfNull:                     mysql.ErrInvalidUseOfNull,
-		mysql.ErrJSONUsedAsKey:                        mysql.ErrJSONUsedAsKey,
-		mysql.ErrKeyColumnDoesNotExits:                mysql.ErrKeyColumnDoesNotExits,
-		mysql.ErrLockWaitTimeout:                      mysql.ErrLockWaitTimeout,
-		mysql.ErrNoParts:                              mysql.ErrNoParts,
-		mysql.ErrNotOwner:                             mysql.ErrNotOwner,
-		mysql.ErrOnlyOnRangeListPartition:             mysql.ErrOnlyOnRangeListPartition,
-		mysql.ErrPartitionColumnList:                  mysql.ErrPartitionColumnList,
-		mysql.ErrPartitionFuncNotAllowed:              mysql.ErrPartitionFuncNotAllowed,
-		mysql.ErrPartitionFunctionIsNotAllowed:        mysql.ErrPartitionFunctionIsNotAllowed,
-		mysql.ErrPartitionMaxvalue:                    mysql.ErrPartitionMaxvalue,
-		mysql.ErrPartitionMgmtOnNonpartitioned:        mysql.ErrPartitionMgmtOnNonpartitioned,
-		mysql.ErrPartitionRequiresValues:              mysql.ErrPartitionRequiresValues,
-		mysql.ErrPartitionWrongNoPart:                 mysql.ErrPartitionWrongNoPart,
-		mysql.ErrPartitionWrongNoSubpart:              mysql.ErrPartitionWrongNoSubpart,
-		mysql.ErrPartitionWrongValues:                 mysql.ErrPartitionWrongValues,
-		mysql.ErrPartitionsMustBeDefined:              mysql.ErrPartitionsMustBeDefined,
-		mysql.ErrPrimaryCantHaveNull:                  mysql.ErrPrimaryCantHaveNull,
-		mysql.ErrRangeNotIncreasing:                   mysql.ErrRangeNotIncreasing,
-		mysql.ErrRowSinglePartitionField:              mysql.ErrRowSinglePartitionField,
-		mysql.ErrSameNamePartition:                    mysql.ErrSameNamePartition,
-		mysql.ErrSubpartition:                         mysql.ErrSubpartition,
-		mysql.ErrSystemVersioningWrongPartitions:      mysql.ErrSystemVersioningWrongPartitions,
-		mysql.ErrTableCantHandleFt:                    mysql.ErrTableCantHandleFt,
-		mysql.ErrTableMustHaveColumns:                 mysql.ErrTableMustHaveColumns,
-		mysql.ErrTooLongIdent:                         mysql.ErrTooLongIdent,
-		mysql.ErrTooLongIndexComment:                  mysql.ErrTooLongIndexComment,
-		mysql.ErrTooLongKey:                           mysql.ErrTooLongKey,
-		mysql.ErrTooManyFields:                        mysql.ErrTooManyFields,
-		mysql.ErrTooManyPartitions:                    mysql.ErrTooManyPartitions,
-		mysql.ErrTooManyValues:                        mysql.ErrTooManyValues,
-		mysql.ErrUniqueKeyNeedAllFieldsInPf:           mysql.ErrUniqueKeyNeedAllFieldsInPf,
-		mysql.ErrUnknownCharacterSet:                  mysql.ErrUnknownCharacterSet,
-		mysql.ErrUnknownCollation:                     mysql.ErrUnknownCollation,
-		mysql.ErrUnknownPartition:                     mysql.ErrUnknownPartition,
-		mysql.ErrUnsupportedDDLOperation:              mysql.ErrUnsupportedDDLOperation,
-		mysql.ErrUnsupportedOnGeneratedColumn:         mysql.ErrUnsupportedOnGeneratedColumn,
-		mysql.ErrViewWrongList:                        mysql.ErrViewWrongList,
-		mysql.ErrWrongColumnName:                      mysql.ErrWrongColumnName,
-		mysql.ErrWrongDBName:                          mysql.ErrWrongDBName,
-		mysql.ErrWrongExprInPartitionFunc:             mysql.ErrWrongExprInPartitionFunc,
-		mysql.ErrWrongFKOptionForGeneratedColumn:      mysql.ErrWrongFKOptionForGeneratedColumn,
-		mysql.ErrWrongKeyColumn:                       mysql.ErrWrongKeyColumn,
-		mysql.ErrWrongNameForIndex:                    mysql.ErrWrongNameForIndex,
-		mysql.ErrWrongObject:                          mysql.ErrWrongObject,
-		mysql.ErrWrongPartitionTypeExpectedSystemTime: mysql.ErrWrongPartitionTypeExpectedSystemTime,
-		mysql.ErrWrongSubKey:                          mysql.ErrWrongSubKey,
-		mysql.ErrWrongTableName:                       mysql.ErrWrongTableName,
-		mysql.ErrWrongTypeColumnValue:                 mysql.ErrWrongTypeColumnValue,
-		mysql.WarnDataTruncated:                       mysql.WarnDataTruncated,
+		mysql.ErrAlterOperationNotSupportedReason:           mysql.ErrAlterOperationNotSupportedReason,
+		mysql.ErrBadField:                                   mysql.ErrBadField,
+		mysql.ErrBadNull:                                    mysql.ErrBadNull,
+		mysql.ErrBlobCantHaveDefault:                        mysql.ErrBlobCantHaveDefault,
+		mysql.ErrBlobKeyWithoutLength:                       mysql.ErrBlobKeyWithoutLength,
+		mysql.ErrCancelledDDLJob:                            mysql.ErrCancelledDDLJob,
+		mysql.ErrCannotAddForeign:                           mysql.ErrCannotAddForeign,
+		mysql.ErrCantDecodeIndex:                            mysql.ErrCantDecodeIndex,
+		mysql.ErrCantDropFieldOrKey:                         mysql.ErrCantDropFieldOrKey,
+		mysql.ErrCantRemoveAllFields:                        mysql.ErrCantRemoveAllFields,
+		mysql.ErrCheckConstraintDupName:                     mysql.ErrCheckConstraintDupName,
+		mysql.ErrCheckConstraintFunctionIsNotAllowed:        mysql.ErrCheckConstraintFunctionIsNotAllowed,
+		mysql.ErrCheckConstraintNamedFunctionIsNotAllowed:   mysql.ErrCheckConstraintNamedFunctionIsNotAllowed,
+		mysql.ErrCheckConstraintNotFound:                    mysql.ErrCheckConstraintNotFound,
+		mysql.ErrCheckConstraintRefersAutoIncrementColumn:   mysql.ErrCheckConstraintRefersAutoIncrementColumn,
+		mysql.ErrCheckConstraintRefersUnknownColumn:         mysql.ErrCheckConstraintRefersUnknownColumn,
+		mysql.ErrCheckConstraintRowValue:                    mysql.ErrCheckConstraintRowValue,
+		mysql.ErrCheckConstraintVariables:                   mysql.ErrCheckConstraintVariables,
+		mysql.ErrCoalesceOnlyOnHashPartition:                mysql.ErrCoalesceOnlyOnHashPartition,
+		mysql.ErrCollationCharsetMismatch:                   mysql.ErrCollationCharsetMismatch,
+		mysql.ErrColumnCheckConstraintReferencesOtherColumn: mysql.ErrColumnCheckConstraintReferencesOtherColumn,
+		mysql.ErrConflictingDeclarations:                    mysql.ErrConflictingDeclarations,
+		mysql.ErrDependentByCheckConstraint:                 mysql.ErrDependentByCheckConstraint,
+		mysql.ErrDependentByGeneratedColumn:                 mysql.ErrDependentByGeneratedColumn,
+		mysql.ErrDropIndexFk:                                mysql.ErrDropIndexFk,
+		mysql.ErrDropLastPartition:                          mysql.ErrDropLastPartition,
+		mysql.ErrDropPartitionNonExistent:                   mysql.ErrDropPartitionNonExistent,
+		mysql.ErrDupKeyName:                                 mysql.ErrDupKeyName,
+		mysql.ErrErrorOnRename:                              mysql.ErrErrorOnRename,
+		mysql.ErrFKIncompatibleColumns:                      mysql.ErrFKIncompatibleColumns,
+		mysql.ErrFieldNotFoundPart:                          mysql.ErrFieldNotFoundPart,
+		mysql.ErrFieldTypeNotAllowedAsPartitionField:        mysql.ErrFieldTypeNotAllowedAsPartitionField,
+		mysql.ErrFileNotFound:                               mysql.ErrFileNotFound,
+		mysql.ErrFkCannotDropParent:                         mysql.ErrFkCannotDropParent,
+		mysql.ErrFkCannotOpenParent:                         mysql.ErrFkCannotOpenParent,
+		mysql.ErrFkColumnCannotChange:                       mysql.ErrFkColumnCannotChange,
+		mysql.ErrFkColumnCannotChangeChild:                  mysql.ErrFkColumnCannotChangeChild,
+		mysql.ErrFkColumnCannotDrop:                         mysql.ErrFkColumnCannotDrop,
+		mysql.ErrFkColumnCannotDropChild:                    mysql.ErrFkColumnCannotDropChild,
+		mysql.ErrFkColumnNotNull:                            mysql.ErrFkColumnNotNull,
+		mysql.ErrFkDupName:                                  mysql.ErrFkDupName,
+		mysql.ErrFkNoIndexChild:                             mysql.ErrFkNoIndexChild,
+		mysql.ErrFkNoIndexParent:                            mysql.ErrFkNoIndexParent,
+		mysql.ErrForeignKeyOnPartitioned:                    mysql.ErrForeignKeyOnPartitioned,
+		mysql.ErrGeneratedColumnFunctionIsNotAllowed:        mysql.ErrGeneratedColumnFunctionIsNotAllowed,
+		mysql.ErrGeneratedColumnNonPrior:                    mysql.ErrGeneratedColumnNonPrior,
+		mysql.ErrGeneratedColumnRefAutoInc:                  mysql.ErrGeneratedColumnRefAutoInc,
+		mysql.ErrInvalidDDLJob:                              mysql.ErrInvalidDDLJob,
+		mysql.ErrInvalidDDLState:                            mysql.ErrInvalidDDLState,
+		mysql.ErrInvalidDDLWorker:                           mysql.ErrInvalidDDLWorker,
+		mysql.ErrInvalidDefault:                             mysql.ErrInvalidDefault,
+		mysql.ErrInvalidGroupFuncUse:                        mysql.ErrInvalidGroupFuncUse,
+		mysql.ErrInvalidDDLJobFlag:                          mysql.ErrInvalidDDLJobFlag,
+		mysql.ErrInvalidDDLJobVersion:                       mysql.ErrInvalidDDLJobVersion,
+		mysql.ErrInvalidOnUpdate:                            mysql.ErrInvalidOnUpdate,
+		mysql.ErrInvalidSplitRegionRanges:                   mysql.ErrInvalidSplitRegionRanges,
+		mysql.ErrInvalidStoreVersion:                        mysql.ErrInvalidStoreVersion,
+		mysql.ErrInvalidUseOfNull:                           mysql.ErrInvalidUseOfNull,
+		mysql.ErrJSONUsedAsKey:                              mysql.ErrJSONUsedAsKey,
+		mysql.ErrKeyColumnDoesNotExits:                      mysql.ErrKeyColumnDoesNotExits,
+		mysql.ErrLockWaitTimeout:                            mysql.ErrLockWaitTimeout,
+		mysql.ErrNonBooleanExprForCheckConstraint:           mysql.ErrNonBooleanExprForCheckConstraint,
+		mysql.ErrMultipleDefConstInListPart:                 mysql.ErrMultipleDefConstInListPart,
+		mysql.ErrNoParts:                                    mysql.ErrNoParts,
+		mysql.ErrNotOwner:                                   mysql.ErrNotOwner,
+		mysql.ErrNullInValuesLessThan:                       mysql.ErrNullInValuesLessThan,
+		mysql.ErrOnlyOnRangeListPartition:                   mysql.ErrOnlyOnRangeListPartition,
+		mysql.ErrPartitionColumnList:                        mysql.ErrPartitionColumnList,
+		mysql.ErrPartitionFuncNotAllowed:                    mysql.ErrPartitionFuncNotAllowed,
+		mysql.ErrPartitionFunctionIsNotAllowed:              mysql.ErrPartitionFunctionIsNotAllowed,
+		mysql.ErrPartitionMaxvalue:                          mysql.ErrPartitionMaxvalue,
+		mysql.ErrPartitionMgmtOnNonpartitioned:              mysql.ErrPartitionMgmtOnNonpartitioned,
+		mysql.ErrPartitionRequiresValues:                    mysql.ErrPartitionRequiresValues,
+		mysql.ErrPartitionWrongNoPart:                       mysql.ErrPartitionWrongNoPart,
+		mysql.ErrPartitionWrongNoSubpart:                    mysql.ErrPartitionWrongNoSubpart,
+		mysql.ErrPartitionWrongValues:                       mysql.ErrPartitionWrongValues,
+		mysql.ErrPartitionsMustBeDefined:                    mysql.ErrPartitionsMustBeDefined,
+		mysql.ErrPrimaryCantHaveNull:                        mysql.ErrPrimaryCantHaveNull,
+		mysql.ErrRangeNotIncreasing:                         mysql.ErrRangeNotIncreasing,
+		mysql.ErrRowSinglePartitionField:                    mysql.ErrRowSinglePartitionField,
+		mysql.ErrSameNamePartition:                          mysql.ErrSameNamePartition,
+		mysql.ErrSubpartition:                               mysql.ErrSubpartition,
+		mysql.ErrSystemVersioningWrongPartitions:            mysql.ErrSystemVersioningWrongPartitions,
+		mysql.ErrTableCantHandleFt:                          mysql.ErrTableCantHandleFt,
+		mysql.ErrTableMustHaveColumns:                       mysql.ErrTableMustHaveColumns,
+		mysql.ErrTooLongIdent:                               mysql.ErrTooLongIdent,
+		mysql.ErrTooLongIndexComment:                        mysql.ErrTooLongIndexComment,
+		mysql.ErrTooLongKey:                                 mysql.ErrTooLongKey,
+		mysql.ErrTooManyFields:                              mysql.ErrTooManyFields,
+		mysql.ErrTooManyPartitions:                          mysql.ErrTooManyPartitions,
+		mysql.ErrTooManyValues:                              mysql.ErrTooManyValues,
+		mysql.ErrUniqueKeyNeedAllFieldsInPf:                 mysql.ErrUniqueKeyNeedAllFieldsInPf,
+		mysql.ErrUnknownCharacterSet:                        mysql.ErrUnknownCharacterSet,
+		mysql.ErrUnknownCollation:                           mysql.ErrUnknownCollation,
+		mysql.ErrUnknownPartition:                           mysql.ErrUnknownPartition,
+		mysql.ErrUnsupportedDDLOperation:                    mysql.ErrUnsupportedDDLOperation,
+		mysql.ErrUnsupportedOnGeneratedColumn:               mysql.ErrUnsupportedOnGeneratedColumn,
+		mysql.ErrViewWrongList:                              mysql.ErrViewWrongList,
+		mysql.ErrWrongColumnName:                            mysql.ErrWrongColumnName,
+		mysql.ErrWrongDBName:                                mysql.ErrWrongDBName,
+		mysql.ErrValuesIsNotIntType:                         mysql.ErrValuesIsNotIntType,
+		mysql.ErrWrongExprInPartitionFunc:                   mysql.ErrWrongExprInPartitionFunc,
+		mysql.ErrWrongFKOptionForGeneratedColumn:            mysql.ErrWrongFKOptionForGeneratedColumn,
+		mysql.ErrWrongFkDef:                                 mysql.ErrWrongFkDef,
+		mysql.ErrWrongKeyColumn:                             mysql.ErrWrongKeyColumn,
+		mysql.ErrWrongNameForIndex:                          mysql.ErrWrongNameForIndex,
+		mysql.ErrWrongObject:                                mysql.ErrWrongObject,
+		mysql.ErrWrongPartitionTypeExpectedSystemTime:       mysql.ErrWrongPartitionTypeExpectedSystemTime,
+		mysql.ErrWrongSubKey:                                mysql.ErrWrongSubKey,
+		mysql.ErrWrongTableName:                             mysql.ErrWrongTableName,
+		mysql.ErrWrongTypeColumnValue:                       mysql.ErrWrongTypeColumnValue,
+		mysql.WarnDataTruncated:                             mysql.WarnDataTruncated,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassDDL] = ddlMySQLErrCodes
 }
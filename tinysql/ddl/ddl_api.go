@@ -332,7 +332,8 @@ func buildColumnAndConstraint(ctx sessionctx.Context, offset int,
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value interface{}) (bool, interface{}, error) {
 	hasDefaultValue := true
-	if value != nil && (col.Tp == mysql.TypeTinyBlob || col.Tp == mysql.TypeMediumBlob ||
+	if value != nil && (col.Tp == mysql.TypeJSON ||
+		col.Tp == mysql.TypeTinyBlob || col.Tp == mysql.TypeMediumBlob ||
 		col.Tp == mysql.TypeLongBlob || col.Tp == mysql.TypeBlob) {
 		// In non-strict SQL mode.
 		if !ctx.GetSessionVars().SQLMode.HasStrictMode() && value == "" {
@@ -405,6 +406,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 					return nil, nil, errors.Trace(err)
 				}
 				removeOnUpdateNowFlag(col)
+			case ast.ColumnOptionOnUpdate:
+				if err = setOnUpdateNowFlag(ctx, col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionComment:
 				err := setColumnComment(ctx, col, v)
 				if err != nil {
@@ -414,8 +419,23 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				if field_types.HasCharset(colDef.Tp) {
 					col.FieldType.Collate = v.StrValue
 				}
+			case ast.ColumnOptionGenerated:
+				col.GeneratedExprString = v.Expr.Text()
+				col.GeneratedStored = v.Stored
+				_, dependColNames := findDependedColumnNames(colDef)
+				col.Dependences = dependColNames
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+			case ast.ColumnOptionCheck:
+				constraint := &ast.Constraint{
+					Tp:       ast.ConstraintCheck,
+					Name:     v.ConstraintName,
+					Keys:     keys,
+					Expr:     v.Expr,
+					Enforced: v.Enforced,
+					InColumn: true,
+				}
+				constraints = append(constraints, constraint)
 			}
 		}
 	}
@@ -463,6 +483,12 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 }
 
 func getDefaultValue(ctx sessionctx.Context, col *table.Column, c *ast.ColumnOption) (interface{}, error) {
+	if x, ok := c.Expr.(*ast.FuncCallExpr); ok && x.FnName.L == ast.CurrentTimestamp {
+		if !isValidCurrentTimestampExpr(ctx, x, col) {
+			return nil, types.ErrInvalidDefault.GenWithStackByArgs(col.Name)
+		}
+		return strings.ToUpper(ast.CurrentTimestamp), nil
+	}
 	v, err := expression.EvalAstExpr(ctx, c.Expr)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -475,6 +501,36 @@ func getDefaultValue(ctx sessionctx.Context, col *table.Column, c *ast.ColumnOpt
 	return v.ToString()
 }
 
+// isValidCurrentTimestampExpr checks whether CURRENT_TIMESTAMP can be used as the default
+// or on update value of the column. The column must be TIMESTAMP or DATETIME and
+// the fsp of the expression must equal to the fsp of the column.
+func isValidCurrentTimestampExpr(ctx sessionctx.Context, x *ast.FuncCallExpr, col *table.Column) bool {
+	if col.Tp != mysql.TypeTimestamp && col.Tp != mysql.TypeDatetime {
+		return false
+	}
+	fsp := int64(0)
+	if len(x.Args) == 1 {
+		v, err := expression.EvalAstExpr(ctx, x.Args[0])
+		if err != nil {
+			return false
+		}
+		if fsp, err = v.ToInt64(ctx.GetSessionVars().StmtCtx); err != nil {
+			return false
+		}
+	}
+	return fsp == int64(col.Decimal)
+}
+
+// setOnUpdateNowFlag sets the OnUpdateNowFlag for the column with an `ON UPDATE CURRENT_TIMESTAMP` option.
+func setOnUpdateNowFlag(ctx sessionctx.Context, col *table.Column, option *ast.ColumnOption) error {
+	x, ok := option.Expr.(*ast.FuncCallExpr)
+	if !ok || x.FnName.L != ast.CurrentTimestamp || !isValidCurrentTimestampExpr(ctx, x, col) {
+		return ErrInvalidOnUpdate.GenWithStackByArgs(col.Name)
+	}
+	col.Flag |= mysql.OnUpdateNowFlag
+	return nil
+}
+
 func removeOnUpdateNowFlag(c *table.Column) {
 	// For timestamp Col, if it is set null or default value,
 	// OnUpdateNowFlag should be removed.
@@ -694,7 +750,12 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 	constrNames := map[string]bool{}
 
 	// Check not empty constraint name whether is duplicated.
+	// CHECK constraints and foreign keys have their own namespaces, see buildTableCheckConstraints
+	// and buildTableForeignKeys.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck || constr.Tp == ast.ConstraintForeignKey {
+			continue
+		}
 		err := checkDuplicateConstraint(constrNames, constr.Name)
 		if err != nil {
 			return errors.Trace(err)
@@ -703,6 +764,9 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 
 	// Set empty constraint names.
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintCheck || constr.Tp == ast.ConstraintForeignKey {
+			continue
+		}
 		setEmptyConstraintName(constrNames, constr)
 	}
 
@@ -749,6 +813,10 @@ func buildTableInfo(ctx sessionctx.Context, d *ddl, tableName model.CIStr, cols
 			sc.AppendWarning(ErrTableCantHandleFt)
 			continue
 		}
+		if constr.Tp == ast.ConstraintCheck || constr.Tp == ast.ConstraintForeignKey {
+			// CHECK constraints and foreign keys are built after the columns and indexes are complete.
+			continue
+		}
 		// build index info.
 		idxInfo, err := buildIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, model.StatePublic)
 		if err != nil {
@@ -821,6 +889,9 @@ func buildTableInfoWithCheck(ctx sessionctx.Context, d *ddl, s *ast.CreateTableS
 	if err := checkColumnsAttributes(colDefs); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkGeneratedColumn(colDefs); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// The column charset haven't been resolved here.
 	cols, newConstraints, err := buildColumnsAndConstraints(ctx, colDefs, s.Constraints)
@@ -839,7 +910,21 @@ func buildTableInfoWithCheck(ctx sessionctx.Context, d *ddl, s *ast.CreateTableS
 		return nil, errors.Trace(err)
 	}
 	tbInfo.Charset, tbInfo.Collate = charset.GetDefaultCharsetAndCollate()
+	if err = checkGeneratedColumnExpr(ctx, tbInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = buildTableCheckConstraints(ctx, tbInfo, newConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = buildTableForeignKeys(tbInfo, ident.Schema, newConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 
+	if s.Partition != nil {
+		if err = buildTablePartitionInfo(ctx, d, s, tbInfo); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return tbInfo, nil
 }
 
@@ -863,6 +948,9 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = checkTableForeignKeysValid(ctx, is, schema.Name, tbInfo); err != nil {
+		return errors.Trace(err)
+	}
 	tbInfo.State = model.StatePublic
 	err = checkTableInfoValid(tbInfo)
 	if err != nil {
@@ -890,6 +978,80 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 	return errors.Trace(err)
 }
 
+// CreateView creates a view, it replaces the existing view when OR REPLACE
+// is specified.
+func (d *ddl) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt) (err error) {
+	ident := ast.Ident{Name: s.ViewName.Name, Schema: s.ViewName.Schema}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	oldView, err := is.TableByName(ident.Schema, ident.Name)
+	if err == nil && !s.OrReplace {
+		return infoschema.ErrTableExists.GenWithStackByArgs(ident)
+	}
+	if oldView != nil && !oldView.Meta().IsView() {
+		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "VIEW")
+	}
+	if err = checkTooLongTable(ident.Name); err != nil {
+		return err
+	}
+	if len(s.Cols) != len(s.SchemaCols) {
+		return ErrViewWrongList
+	}
+
+	cols := make([]*table.Column, len(s.Cols))
+	colObjects := make([]interface{}, 0, len(s.Cols))
+	for i, v := range s.Cols {
+		cols[i] = table.ToColumn(&model.ColumnInfo{
+			Name:   v,
+			Offset: i,
+			State:  model.StatePublic,
+		})
+		colObjects = append(colObjects, v)
+	}
+	if err = checkTooLongColumn(colObjects); err != nil {
+		return err
+	}
+	if err = checkDuplicateColumn(colObjects); err != nil {
+		return err
+	}
+
+	tbInfo, err := buildTableInfo(ctx, d, ident.Name, cols, nil)
+	if err != nil {
+		return err
+	}
+	// A replaced view keeps its table ID, so the infoschema simply reloads it.
+	if oldView != nil {
+		tbInfo.ID = oldView.Meta().ID
+	}
+	tbInfo.View = &model.ViewInfo{
+		Algorithm:   s.Algorithm,
+		Definer:     s.Definer,
+		Security:    s.Security,
+		SelectStmt:  s.Select.Text(),
+		CheckOption: s.CheckOption,
+		Cols:        s.SchemaCols,
+	}
+	tbInfo.Charset, tbInfo.Collate = ctx.GetSessionVars().GetCharsetInfo()
+	if tbInfo.Charset == "" || tbInfo.Collate == "" {
+		tbInfo.Charset, tbInfo.Collate = charset.GetDefaultCharsetAndCollate()
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tbInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateView,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{tbInfo, s.OrReplace},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func checkCharsetAndCollation(cs string, co string) error {
 	if !charset.ValidCharsetAndCollation(cs, co) {
 		return ErrUnknownCharacterSet.GenWithStackByArgs(cs)
@@ -929,6 +1091,10 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 	if err != nil {
 		return errors.Trace(err)
 	}
+	is := d.infoHandle.Get()
+	if tb, err := is.TableByName(ident.Schema, ident.Name); err == nil && tb.Meta().IsView() {
+		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
+	}
 
 	for _, spec := range validSpecs {
 		switch spec.Tp {
@@ -956,15 +1122,31 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 				err = d.CreatePrimaryKey()
 			case ast.ConstraintFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+			case ast.ConstraintCheck:
+				err = d.CreateCheckConstraint(ctx, ident, constr)
+			case ast.ConstraintForeignKey:
+				err = d.CreateForeignKey(ctx, ident, model.NewCIStr(constr.Name), constr.Keys, constr.Refer)
 			default:
 				// Nothing to do now.
 			}
+		case ast.AlterTableDropForeignKey:
+			err = d.DropForeignKey(ctx, ident, model.NewCIStr(spec.Name))
+		case ast.AlterTableDropCheck:
+			err = d.DropCheckConstraint(ctx, ident, model.NewCIStr(spec.Constraint.Name))
+		case ast.AlterTableAlterCheck:
+			err = d.AlterCheckConstraint(ctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableModifyColumn:
 			err = d.ModifyColumn(ctx, ident, spec)
 		case ast.AlterTableChangeColumn:
 			err = d.ChangeColumn(ctx, ident, spec)
 		case ast.AlterTableAlterColumn:
 			err = d.AlterColumn(ctx, ident, spec)
+		case ast.AlterTableAddPartitions:
+			err = d.AddTablePartitions(ctx, ident, spec)
+		case ast.AlterTableDropPartition:
+			err = d.DropTablePartition(ctx, ident, spec)
+		case ast.AlterTableTruncatePartition:
+			err = d.TruncateTablePartition(ctx, ident, spec)
 		case ast.AlterTablePartition:
 			// Prevent silent succeed if user executes ALTER TABLE x PARTITION BY ...
 			err = errors.New("alter table partition is unsupported")
@@ -980,6 +1162,97 @@ func (d *ddl) AlterTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.A
 	return nil
 }
 
+// AddTablePartitions adds the partitions of ALTER TABLE ADD PARTITION to a RANGE or LIST partitioned table.
+func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	partInfo, err := buildAddedPartitionInfo(ctx, d, t.Meta(), spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// DropTablePartition drops the partitions of a RANGE or LIST partitioned table.
+func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta := t.Meta()
+	if meta.GetPartitionInfo() == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if err = checkDropTablePartition(meta, spec.PartitionNames); err != nil {
+		if ErrDropPartitionNonExistent.Equal(err) && spec.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{spec.PartitionNames},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	if ErrDropPartitionNonExistent.Equal(err) && spec.IfExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// TruncateTablePartition removes all the rows of the partitions of a partitioned table.
+func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if err = checkPartitionNamesExist(pi, spec.PartitionNames, "TRUNCATE"); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionTruncateTablePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{spec.PartitionNames},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 // ShardRowID shards the implicit row ID by adding shard value to the row ID's first few bits.
 func (d *ddl) ShardRowID(ctx sessionctx.Context, tableIdent ast.Ident, uVal uint64) error {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, tableIdent)
@@ -1032,6 +1305,8 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 			return errUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint PRIMARY KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		case ast.ColumnOptionUniqKey:
 			return errUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint UNIQUE KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
+		case ast.ColumnOptionCheck:
+			return errUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint CHECK when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		}
 	}
 
@@ -1074,6 +1349,34 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		return ErrTooLongIdent.GenWithStackByArgs(colName)
 	}
 
+	// If new column is a generated column, do validation.
+	// NOTE: we do check whether the column refers other generated
+	// columns occurring later in table, but we don't handle the col offset.
+	for _, option := range specNewColumn.Options {
+		if option.Tp == ast.ColumnOptionGenerated {
+			if err := checkIllegalFn4GeneratedColumn(specNewColumn.Name.Name.L, option.Expr); err != nil {
+				return errors.Trace(err)
+			}
+
+			if option.Stored {
+				return errors.Trace(ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE"))
+			}
+
+			_, dependColNames := findDependedColumnNames(specNewColumn)
+			if err = checkAutoIncrementRef(specNewColumn.Name.Name.L, dependColNames, t.Meta()); err != nil {
+				return errors.Trace(err)
+			}
+
+			normalColNames := make(map[string]struct{}, len(t.Cols()))
+			for _, col := range t.Cols() {
+				normalColNames[col.Name.L] = struct{}{}
+			}
+			if err = columnNamesCover(normalColNames, dependColNames); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	// Ignore table constraints now, maybe return error later.
 	// We use length(t.Cols()) as the default offset firstly, we will change the
 	// column's offset later.
@@ -1081,6 +1384,13 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if col.IsGenerated() {
+		tblInfo := t.Meta().Clone()
+		tblInfo.Columns = append(tblInfo.Columns, col.ToInfo())
+		if err = checkGeneratedColumnExpr(ctx, tblInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	col.OriginDefaultValue, err = generateOriginDefaultValue(col.ToInfo())
 	if err != nil {
@@ -1126,9 +1436,19 @@ func (d *ddl) DropColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTa
 	}
 
 	tblInfo := t.Meta()
+	// The foreign key columns are covered by the indexes, check them first for a clearer error.
+	if err = checkColumnUsedByFK(d.infoHandle.Get(), schema.Name, tblInfo, colName, true); err != nil {
+		return errors.Trace(err)
+	}
 	if err = isDroppableColumn(tblInfo, colName); err != nil {
 		return errors.Trace(err)
 	}
+	if err = checkDependedByGeneratedColumn(t, colName); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkDependedByCheckConstraint(tblInfo, colName, true); err != nil {
+		return errors.Trace(err)
+	}
 	// We don't support dropping column with PK handle covered now.
 	if col.IsPKHandleColumn(tblInfo) {
 		return errUnsupportedPKHandle
@@ -1289,6 +1609,10 @@ func processColumnOptions(ctx sessionctx.Context, col *table.Column, options []*
 			if err != nil {
 				return errors.Trace(err)
 			}
+		case ast.ColumnOptionOnUpdate:
+			if err = setOnUpdateNowFlag(ctx, col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		case ast.ColumnOptionNotNull:
 			col.Flag |= mysql.NotNullFlag
 		case ast.ColumnOptionNull:
@@ -1299,10 +1623,19 @@ func processColumnOptions(ctx sessionctx.Context, col *table.Column, options []*
 			return errUnsupportedModifyColumn.GenWithStack("can't change column constraint - %v", opt.Tp)
 		case ast.ColumnOptionCollate:
 			col.Collate = opt.StrValue
+		case ast.ColumnOptionGenerated:
+			col.GeneratedExprString = opt.Expr.Text()
+			col.GeneratedStored = opt.Stored
+			col.Dependences = make(map[string]struct{})
+			for _, colName := range findColumnNamesInExpr(opt.Expr) {
+				col.Dependences[colName.Name.L] = struct{}{}
+			}
 		case ast.ColumnOptionReference:
 			return errors.Trace(errUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
 		case ast.ColumnOptionFulltext:
 			return errors.Trace(errUnsupportedModifyColumn.GenWithStackByArgs("can't modify with full text"))
+		case ast.ColumnOptionCheck:
+			return errors.Trace(errUnsupportedModifyColumn.GenWithStackByArgs("can't modify with check"))
 		default:
 			return errors.Trace(errUnsupportedModifyColumn.GenWithStackByArgs(fmt.Sprintf("unknown column option type: %d", opt.Tp)))
 		}
@@ -1347,6 +1680,9 @@ func (d *ddl) getModifiableColumnJob(ctx sessionctx.Context, ident ast.Ident, or
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if isPartitionColumn(t.Meta(), originalColName) {
+			return nil, errors.Trace(errCantDropColWithPartition)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -1401,6 +1737,24 @@ func (d *ddl) getModifiableColumnJob(ctx sessionctx.Context, ident ast.Ident, or
 		return nil, errors.Trace(err)
 	}
 
+	if newColName.L != originalColName.L {
+		if err = checkDependedByGeneratedColumn(t, originalColName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err = checkDependedByCheckConstraint(t.Meta(), originalColName, false); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// The foreign key lookups encode the values of the column, so its name and type can't be changed.
+	if newColName.L != originalColName.L || !isFKColumnCompatible(&col.FieldType, &newCol.FieldType) {
+		if err = checkColumnUsedByFK(is, schema.Name, t.Meta(), originalColName, false); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err = checkModifyGeneratedColumn(t, col, newCol, specNewColumn); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Copy index related options to the new spec.
 	indexFlags := col.FieldType.Flag & (mysql.PriKeyFlag | mysql.UniqueKeyFlag | mysql.MultipleKeyFlag)
 	newCol.FieldType.Flag |= indexFlags
@@ -1745,6 +2099,12 @@ func (d *ddl) DropTable(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if tb.Meta().IsView() {
+		return infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name)
+	}
+	if err = checkDropTableReferredByFK(ctx, d.infoHandle.Get(), schema.Name, tb.Meta()); err != nil {
+		return errors.Trace(err)
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -1759,6 +2119,29 @@ func (d *ddl) DropTable(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	return errors.Trace(err)
 }
 
+// DropView will proceed even if some view in the list does not exists.
+func (d *ddl) DropView(ctx sessionctx.Context, ti ast.Ident) (err error) {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !tb.Meta().IsView() {
+		return ErrWrongObject.GenWithStackByArgs(ti.Schema, ti.Name, "VIEW")
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropView,
+		BinlogInfo: &model.HistoryInfo{},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func getAnonymousIndex(t table.Table, colName model.CIStr) model.CIStr {
 	id := 2
 	l := len(t.Indices())
@@ -1789,6 +2172,9 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if t.Meta().IsView() {
+		return ErrWrongObject.GenWithStackByArgs(ti.Schema, ti.Name, "BASE TABLE")
+	}
 
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
@@ -1818,6 +2204,11 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if unique {
+		if err = checkUniqueIndexCoversPartitionColumns(tblInfo, idxColNames); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	// May be truncate comment here, when index comment too long and sql_mode is't strict.
 	if _, err = validateCommentLength(ctx.GetSessionVars(), indexName.String(), indexOption); err != nil {
 		return errors.Trace(err)
@@ -1876,6 +2267,9 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, isPK bool, indexNa
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = checkDropIndexUsedByFK(is, schema.Name, t.Meta(), indexInfo); err != nil {
+		return errors.Trace(err)
+	}
 
 	jobTp := model.ActionDropIndex
 	if isPK {
@@ -1901,6 +2295,161 @@ func (d *ddl) dropIndex(ctx sessionctx.Context, ti ast.Ident, isPK bool, indexNa
 	return errors.Trace(err)
 }
 
+// CreateCheckConstraint adds a CHECK constraint to the table, the existing rows are verified
+// in the reorganization state if the constraint is enforced.
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tblInfo := t.Meta()
+	if constr.Name == "" {
+		constr.Name = genCheckConstraintName(tblInfo, make(map[string]bool))
+	} else if tblInfo.FindConstraintInfoByName(constr.Name) != nil {
+		return errCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+	}
+	constraintInfo, err := buildConstraintInfo(ctx, tblInfo, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constraintInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// DropCheckConstraint drops a CHECK constraint of the table.
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return errCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterCheckConstraint changes a CHECK constraint of the table to ENFORCED or NOT ENFORCED,
+// the existing rows are verified when a NOT ENFORCED constraint is enforced.
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	constraintInfo := t.Meta().FindConstraintInfoByName(constrName.L)
+	if constraintInfo == nil {
+		return errCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+	if constraintInfo.Enforced == enforced {
+		// Nothing to do.
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName, enforced},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// CreateForeignKey adds a foreign key to the table, the existing rows are verified in the reorganization
+// state if foreign_key_checks is on. The foreign key columns must be covered by an index already.
+func (d *ddl) CreateForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.CIStr,
+	keys []*ast.IndexPartSpecification, refer *ast.ReferenceDef) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tblInfo := t.Meta()
+	if tblInfo.GetPartitionInfo() != nil {
+		return errors.Trace(errForeignKeyOnPartitioned)
+	}
+	if fkName.L == "" {
+		fkName = genForeignKeyName(tblInfo, make(map[string]bool))
+	} else if tblInfo.FindFKInfoByName(fkName.L) != nil {
+		return errFkDupName.GenWithStackByArgs(fkName.O)
+	}
+	fkInfo, err := buildFKInfo(tblInfo, schema.Name, fkName, keys, refer)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if FindFKChildIndex(tblInfo, fkInfo) == nil && !isFKOnHandle(tblInfo, fkInfo) {
+		return errFkNoIndexChild.GenWithStackByArgs(fkName.O, tblInfo.Name.O)
+	}
+	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
+	if err = checkFKReferTable(d.infoHandle.Get(), schema.Name, tblInfo, fkInfo, fkCheck); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddForeignKey,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{fkInfo, fkCheck},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// DropForeignKey drops a foreign key of the table.
+func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.CIStr) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if t.Meta().FindFKInfoByName(fkName.L) == nil {
+		return ErrCantDropFieldOrKey.GenWithStack("foreign key %s doesn't exist", fkName)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropForeignKey,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{fkName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
 	if len(tblInfo.Columns) == 1 {
 		return ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
@@ -1911,6 +2460,9 @@ func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
 	if isColumnWithIndex(colName.L, tblInfo.Indices) {
 		return errCantDropColWithIndex.GenWithStack("can't drop column %s with index covered now", colName)
 	}
+	if isPartitionColumn(tblInfo, colName) {
+		return errCantDropColWithPartition
+	}
 	return nil
 }
 
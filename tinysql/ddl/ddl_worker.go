@@ -450,7 +450,9 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onDropSchema(t, job)
 	case model.ActionCreateTable:
 		ver, err = onCreateTable(d, t, job)
-	case model.ActionDropTable:
+	case model.ActionCreateView:
+		ver, err = onCreateView(d, t, job)
+	case model.ActionDropTable, model.ActionDropView:
 		ver, err = onDropTableOrView(t, job)
 	case model.ActionAddColumn:
 		ver, err = onAddColumn(d, t, job)
@@ -472,6 +474,22 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onModifyTableComment(t, job)
 	case model.ActionModifyTableCharsetAndCollate:
 		ver, err = onModifyTableCharsetAndCollate(t, job)
+	case model.ActionAddTablePartition:
+		ver, err = onAddTablePartition(t, job)
+	case model.ActionDropTablePartition:
+		ver, err = onDropTablePartition(t, job)
+	case model.ActionTruncateTablePartition:
+		ver, err = onTruncateTablePartition(t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = w.onAddCheckConstraint(d, t, job)
+	case model.ActionDropCheckConstraint:
+		ver, err = onDropCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = w.onAlterCheckConstraint(d, t, job)
+	case model.ActionAddForeignKey:
+		ver, err = w.onCreateForeignKey(d, t, job)
+	case model.ActionDropForeignKey:
+		ver, err = onDropForeignKey(t, job)
 	default:
 		// Invalid job, cancel it.
 		job.State = model.JobStateCancelled
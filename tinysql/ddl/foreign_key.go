@@ -0,0 +1,568 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+func (w *worker) onCreateForeignKey(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		fkInfo  = &model.FKInfo{}
+		fkCheck bool
+	)
+	if err = job.DecodeArgs(fkInfo, &fkCheck); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if existing := tblInfo.FindFKInfoByName(fkInfo.Name.L); existing != nil {
+		if existing.State == model.StatePublic {
+			job.State = model.JobStateCancelled
+			return ver, errFkDupName.GenWithStackByArgs(fkInfo.Name.O)
+		}
+		fkInfo = existing
+	} else {
+		fkInfo.ID = allocateFKID(tblInfo)
+		fkInfo.State = model.StateNone
+		tblInfo.ForeignKeys = append(tblInfo.ForeignKeys, fkInfo)
+	}
+
+	originalState := fkInfo.State
+	switch fkInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// The new rows are checked from now on, so the existing rows can be verified later.
+		job.SchemaState = model.StateWriteOnly
+		fkInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != fkInfo.State)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		job.SchemaState = model.StateWriteReorganization
+		fkInfo.State = model.StateWriteReorganization
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != fkInfo.State)
+	case model.StateWriteReorganization:
+		// reorganization -> public
+		if fkCheck {
+			done, err := w.verifyConstraint(d, t, job, tblInfo, fkInfo.Name, func() error {
+				return w.verifyRemainRecordsForForeignKey(dbInfo, tblInfo, fkInfo)
+			})
+			if err != nil {
+				if table.ErrNoReferencedRow2.Equal(err) {
+					logutil.BgLogger().Warn("[ddl] run add foreign key job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+					tblInfo.ForeignKeys = removeFKInfo(tblInfo.ForeignKeys, fkInfo.Name)
+					return finishRollbackConstraintJob(t, job, tblInfo, model.StateNone, err)
+				}
+				return ver, errors.Trace(err)
+			}
+			if !done {
+				return ver, nil
+			}
+		}
+
+		fkInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != fkInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("foreign key", fkInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+func onDropForeignKey(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var fkName model.CIStr
+	if err = job.DecodeArgs(&fkName); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.FindFKInfoByName(fkName.L) == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrCantDropFieldOrKey.GenWithStack("foreign key %s doesn't exist", fkName)
+	}
+
+	// Dropping a foreign key only relaxes the writes, so it can be removed in one step.
+	tblInfo.ForeignKeys = removeFKInfo(tblInfo.ForeignKeys, fkName)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
+
+// verifyRemainRecordsForForeignKey looks for a row whose foreign key columns refer a row that doesn't exist in the parent table.
+func (w *worker) verifyRemainRecordsForForeignKey(dbInfo *model.DBInfo, tblInfo *model.TableInfo, fkInfo *model.FKInfo) error {
+	// Get sessionctx from context resource pool.
+	ctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(ctx)
+
+	// The rows with NULL in the foreign key columns don't refer any row, and the referenced columns
+	// of a matched parent row can't be NULL, so a NULL referenced column means there's no parent row.
+	onConds := make([]string, 0, len(fkInfo.Cols))
+	whereConds := make([]string, 0, len(fkInfo.Cols)+1)
+	for i, col := range fkInfo.Cols {
+		onConds = append(onConds, fmt.Sprintf("c.`%s` = p.`%s`", col.L, fkInfo.RefCols[i].L))
+		whereConds = append(whereConds, fmt.Sprintf("c.`%s` is not null", col.L))
+	}
+	whereConds = append(whereConds, fmt.Sprintf("p.`%s` is null", fkInfo.RefCols[0].L))
+	sql := fmt.Sprintf("select 1 from `%s`.`%s` as c left join `%s`.`%s` as p on %s where %s limit 1;",
+		dbInfo.Name.L, tblInfo.Name.L, fkInfo.RefSchema.L, fkInfo.RefTable.L,
+		strings.Join(onConds, " and "), strings.Join(whereConds, " and "))
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return table.ErrNoReferencedRow2.GenWithStackByArgs(FKConstraintString(dbInfo.Name, tblInfo.Name, fkInfo))
+	}
+	return nil
+}
+
+func rollingbackAddForeignKey(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.SchemaState == model.StateNone {
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	fkInfo := &model.FKInfo{}
+	if err = job.DecodeArgs(fkInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo.ForeignKeys = removeFKInfo(tblInfo.ForeignKeys, fkInfo.Name)
+	return finishRollbackConstraintJob(t, job, tblInfo, model.StateNone, errCancelledDDLJob)
+}
+
+func removeFKInfo(fks []*model.FKInfo, name model.CIStr) []*model.FKInfo {
+	newFKs := make([]*model.FKInfo, 0, len(fks))
+	for _, fk := range fks {
+		if fk.Name.L != name.L {
+			newFKs = append(newFKs, fk)
+		}
+	}
+	return newFKs
+}
+
+func allocateFKID(tblInfo *model.TableInfo) int64 {
+	var maxID int64
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.ID > maxID {
+			maxID = fk.ID
+		}
+	}
+	return maxID + 1
+}
+
+// FKConstraintString returns the foreign key description used in the error messages,
+// it's like "`db`.`child`, CONSTRAINT `fk` FOREIGN KEY (`a`) REFERENCES `parent` (`id`)".
+func FKConstraintString(schema, tbl model.CIStr, fkInfo *model.FKInfo) string {
+	cols := make([]string, 0, len(fkInfo.Cols))
+	for _, col := range fkInfo.Cols {
+		cols = append(cols, "`"+col.O+"`")
+	}
+	refCols := make([]string, 0, len(fkInfo.RefCols))
+	for _, col := range fkInfo.RefCols {
+		refCols = append(refCols, "`"+col.O+"`")
+	}
+	return fmt.Sprintf("`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (%s) REFERENCES `%s` (%s)",
+		schema.O, tbl.O, fkInfo.Name.O, strings.Join(cols, ", "), fkInfo.RefTable.O, strings.Join(refCols, ", "))
+}
+
+// buildFKInfo builds the foreign key info from the AST after validating it against the child table tblInfo.
+func buildFKInfo(tblInfo *model.TableInfo, schema model.CIStr, fkName model.CIStr, keys []*ast.IndexPartSpecification,
+	refer *ast.ReferenceDef) (*model.FKInfo, error) {
+	if len(keys) != len(refer.IndexPartSpecifications) {
+		return nil, errWrongFkDef.GenWithStackByArgs(fkName.O, "Key reference and table reference don't match")
+	}
+
+	fkInfo := &model.FKInfo{
+		Name:      fkName,
+		RefSchema: refer.Table.Schema,
+		RefTable:  refer.Table.Name,
+		Cols:      make([]model.CIStr, len(keys)),
+		RefCols:   make([]model.CIStr, len(keys)),
+		OnDelete:  int(refer.OnDelete.ReferOpt),
+		OnUpdate:  int(refer.OnUpdate.ReferOpt),
+		State:     model.StatePublic,
+	}
+	if fkInfo.RefSchema.L == "" {
+		fkInfo.RefSchema = schema
+	}
+	if refer.OnDelete.ReferOpt == ast.ReferOptionSetDefault || refer.OnUpdate.ReferOpt == ast.ReferOptionSetDefault {
+		return nil, errCannotAddForeign.GenWithStack("Foreign key option SET DEFAULT is not supported")
+	}
+
+	for i, key := range keys {
+		col := model.FindColumnInfo(tblInfo.Columns, key.Column.Name.L)
+		if col == nil {
+			return nil, errKeyColumnDoesNotExits.GenWithStackByArgs(key.Column.Name.O)
+		}
+		if col.IsGenerated() {
+			return nil, errCannotAddForeign.GenWithStack("Foreign key on generated column '%s' is not supported", col.Name.O)
+		}
+		if mysql.HasNotNullFlag(col.Flag) &&
+			(refer.OnDelete.ReferOpt == ast.ReferOptionSetNull || refer.OnUpdate.ReferOpt == ast.ReferOptionSetNull) {
+			return nil, errFkColumnNotNull.GenWithStackByArgs(col.Name.O, fkName.O)
+		}
+		fkInfo.Cols[i] = col.Name
+		fkInfo.RefCols[i] = refer.IndexPartSpecifications[i].Column.Name
+	}
+	return fkInfo, nil
+}
+
+// buildTableForeignKeys builds the foreign keys of CREATE TABLE, an index is added for the foreign key
+// columns if there isn't one, so the child rows of a parent row can be found by the index.
+func buildTableForeignKeys(tblInfo *model.TableInfo, schema model.CIStr, constraints []*ast.Constraint) error {
+	fkNames := make(map[string]bool)
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintForeignKey || constr.Name == "" {
+			continue
+		}
+		if fkNames[strings.ToLower(constr.Name)] {
+			return errFkDupName.GenWithStackByArgs(constr.Name)
+		}
+		fkNames[strings.ToLower(constr.Name)] = true
+	}
+	idxNames := make(map[string]bool)
+	for _, idx := range tblInfo.Indices {
+		idxNames[idx.Name.L] = true
+	}
+
+	for _, constr := range constraints {
+		if constr.Tp != ast.ConstraintForeignKey {
+			continue
+		}
+		fkName := model.NewCIStr(constr.Name)
+		if constr.Name == "" {
+			fkName = genForeignKeyName(tblInfo, fkNames)
+		}
+		fkInfo, err := buildFKInfo(tblInfo, schema, fkName, constr.Keys, constr.Refer)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fkInfo.ID = allocateFKID(tblInfo)
+		tblInfo.ForeignKeys = append(tblInfo.ForeignKeys, fkInfo)
+
+		if FindFKChildIndex(tblInfo, fkInfo) != nil || isFKOnHandle(tblInfo, fkInfo) {
+			continue
+		}
+		idxName := constr.Name
+		if idxName == "" {
+			idxName = genIndexNameForFK(fkInfo, idxNames)
+		} else if idxNames[strings.ToLower(idxName)] {
+			return ErrDupKeyName.GenWithStack("duplicate key name %s", idxName)
+		}
+		idxNames[strings.ToLower(idxName)] = true
+		idxInfo, err := buildIndexInfo(tblInfo, model.NewCIStr(idxName), constr.Keys, model.StatePublic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		idxInfo.Tp = model.IndexTypeBtree
+		idxInfo.ID = allocateIndexID(tblInfo)
+		tblInfo.Indices = append(tblInfo.Indices, idxInfo)
+		addIndexColumnFlag(tblInfo, idxInfo)
+	}
+	return nil
+}
+
+// genForeignKeyName generates the foreign key name like MySQL does, it's `<table>_ibfk_<n>`.
+func genForeignKeyName(tblInfo *model.TableInfo, usedNames map[string]bool) model.CIStr {
+	for _, fk := range tblInfo.ForeignKeys {
+		usedNames[fk.Name.L] = true
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_ibfk_%d", tblInfo.Name.L, i)
+		if !usedNames[name] {
+			usedNames[name] = true
+			return model.NewCIStr(name)
+		}
+	}
+}
+
+// genIndexNameForFK names the index added for a foreign key after its first column.
+func genIndexNameForFK(fkInfo *model.FKInfo, usedNames map[string]bool) string {
+	name := fkInfo.Cols[0].L
+	for i := 2; usedNames[name]; i++ {
+		name = fmt.Sprintf("%s_%d", fkInfo.Cols[0].L, i)
+	}
+	return name
+}
+
+// isFKOnHandle checks whether the foreign key columns are the integer primary key of the table.
+func isFKOnHandle(tblInfo *model.TableInfo, fkInfo *model.FKInfo) bool {
+	if !tblInfo.PKIsHandle || len(fkInfo.Cols) != 1 {
+		return false
+	}
+	pkCol := tblInfo.GetPkColInfo()
+	return pkCol != nil && pkCol.Name.L == fkInfo.Cols[0].L
+}
+
+// FindFKChildIndex finds the index of the child table whose leading columns are the foreign key columns,
+// the index is used to find the child rows referring a parent row.
+func FindFKChildIndex(tblInfo *model.TableInfo, fkInfo *model.FKInfo) *model.IndexInfo {
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic || len(idx.Columns) < len(fkInfo.Cols) {
+			continue
+		}
+		if indexColumnsMatch(idx.Columns[:len(fkInfo.Cols)], fkInfo.Cols) {
+			return idx
+		}
+	}
+	return nil
+}
+
+// FindFKParentIndex finds the unique index of the parent table on exactly the referenced columns, the foreign
+// key checks are point lookups on it. It returns nil if the referenced column is the integer primary key.
+func FindFKParentIndex(refTblInfo *model.TableInfo, fkInfo *model.FKInfo) (*model.IndexInfo, bool) {
+	if refTblInfo.PKIsHandle && len(fkInfo.RefCols) == 1 {
+		if pkCol := refTblInfo.GetPkColInfo(); pkCol != nil && pkCol.Name.L == fkInfo.RefCols[0].L {
+			return nil, true
+		}
+	}
+	for _, idx := range refTblInfo.Indices {
+		if !idx.Unique || idx.State != model.StatePublic || len(idx.Columns) != len(fkInfo.RefCols) {
+			continue
+		}
+		if indexColumnsMatch(idx.Columns, fkInfo.RefCols) {
+			return idx, true
+		}
+	}
+	return nil, false
+}
+
+func indexColumnsMatch(idxCols []*model.IndexColumn, cols []model.CIStr) bool {
+	for i, idxCol := range idxCols {
+		// A prefix index can't be used to look up the whole values.
+		if idxCol.Name.L != cols[i].L || idxCol.Length != types.UnspecifiedLength {
+			return false
+		}
+	}
+	return true
+}
+
+// checkFKReferTable checks the parent table of the foreign key. The parent table is allowed not to exist
+// when foreign_key_checks is off, the foreign key is checked when the writes find the parent table.
+func checkFKReferTable(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo, fkInfo *model.FKInfo, fkCheck bool) error {
+	refTblInfo := tblInfo
+	if fkInfo.RefSchema.L != schema.L || fkInfo.RefTable.L != tblInfo.Name.L {
+		refTbl, err := is.TableByName(fkInfo.RefSchema, fkInfo.RefTable)
+		if err != nil {
+			if !fkCheck {
+				return nil
+			}
+			return errFkCannotOpenParent.GenWithStackByArgs(fkInfo.RefTable.O)
+		}
+		refTblInfo = refTbl.Meta()
+	}
+	if refTblInfo.IsView() {
+		return errFkCannotOpenParent.GenWithStackByArgs(fkInfo.RefTable.O)
+	}
+	if refTblInfo.GetPartitionInfo() != nil {
+		return errForeignKeyOnPartitioned
+	}
+
+	for i, refColName := range fkInfo.RefCols {
+		refCol := model.FindColumnInfo(refTblInfo.Columns, refColName.L)
+		if refCol == nil {
+			return errKeyColumnDoesNotExits.GenWithStackByArgs(refColName.O)
+		}
+		col := model.FindColumnInfo(tblInfo.Columns, fkInfo.Cols[i].L)
+		if !isFKColumnCompatible(&col.FieldType, &refCol.FieldType) {
+			return errFKIncompatibleColumns.GenWithStackByArgs(col.Name.O, fkInfo.Name.O)
+		}
+	}
+	if _, ok := FindFKParentIndex(refTblInfo, fkInfo); !ok {
+		return errFkNoIndexParent.GenWithStackByArgs(fkInfo.Name.O, fkInfo.RefTable.O)
+	}
+	return nil
+}
+
+// isFKColumnCompatible checks the foreign key column can store the values of the referenced column,
+// so the values are encoded the same way in the lookups.
+func isFKColumnCompatible(ft, refFt *types.FieldType) bool {
+	if ft.EvalType() != refFt.EvalType() {
+		return false
+	}
+	switch ft.EvalType() {
+	case types.ETInt:
+		return mysql.HasUnsignedFlag(ft.Flag) == mysql.HasUnsignedFlag(refFt.Flag)
+	case types.ETString:
+		return ft.Tp == refFt.Tp || (types.IsString(ft.Tp) && types.IsString(refFt.Tp) && ft.Charset == refFt.Charset)
+	}
+	return ft.Tp == refFt.Tp
+}
+
+// checkTableForeignKeysValid checks the parent tables of the foreign keys of CREATE TABLE.
+func checkTableForeignKeysValid(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo) error {
+	if len(tblInfo.ForeignKeys) == 0 {
+		return nil
+	}
+	if tblInfo.GetPartitionInfo() != nil {
+		return errForeignKeyOnPartitioned
+	}
+	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
+	for _, fkInfo := range tblInfo.ForeignKeys {
+		if err := checkFKReferTable(is, schema, tblInfo, fkInfo, fkCheck); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// referredFK is a foreign key of another table referring the table.
+type referredFK struct {
+	childSchema model.CIStr
+	childTable  *model.TableInfo
+	fk          *model.FKInfo
+}
+
+// getReferredFKs returns the foreign keys of the other tables referring the table.
+func getReferredFKs(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo) []referredFK {
+	var referredFKs []referredFK
+	for _, db := range is.AllSchemas() {
+		for _, child := range db.Tables {
+			if child.ID == tblInfo.ID {
+				continue
+			}
+			for _, fk := range child.ForeignKeys {
+				if fk.RefSchema.L == schema.L && fk.RefTable.L == tblInfo.Name.L {
+					referredFKs = append(referredFKs, referredFK{childSchema: db.Name, childTable: child, fk: fk})
+				}
+			}
+		}
+	}
+	return referredFKs
+}
+
+// checkDropTableReferredByFK forbids to drop a table referred by the foreign keys of other tables.
+func checkDropTableReferredByFK(ctx sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo) error {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	if referredFKs := getReferredFKs(is, schema, tblInfo); len(referredFKs) > 0 {
+		return errFkCannotDropParent.GenWithStackByArgs(tblInfo.Name.O, referredFKs[0].fk.Name.O, referredFKs[0].childTable.Name.O)
+	}
+	return nil
+}
+
+// checkColumnUsedByFK forbids to drop or change a column used by the foreign keys of the table,
+// or referred by the foreign keys of other tables.
+func checkColumnUsedByFK(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo, colName model.CIStr, isDrop bool) error {
+	for _, fk := range tblInfo.ForeignKeys {
+		if !hasFKColumn(fk.Cols, colName) {
+			continue
+		}
+		if isDrop {
+			return errFkColumnCannotDrop.GenWithStackByArgs(colName.O, fk.Name.O)
+		}
+		return errFkColumnCannotChange.GenWithStackByArgs(colName.O, fk.Name.O)
+	}
+	referredFKs := getReferredFKs(is, schema, tblInfo)
+	// A self-referencing foreign key refers the table too.
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.RefSchema.L == schema.L && fk.RefTable.L == tblInfo.Name.L {
+			referredFKs = append(referredFKs, referredFK{childSchema: schema, childTable: tblInfo, fk: fk})
+		}
+	}
+	for _, referred := range referredFKs {
+		if !hasFKColumn(referred.fk.RefCols, colName) {
+			continue
+		}
+		if isDrop {
+			return errFkColumnCannotDropChild.GenWithStackByArgs(colName.O, referred.fk.Name.O, referred.childTable.Name.O)
+		}
+		return errFkColumnCannotChangeChild.GenWithStackByArgs(colName.O, referred.fk.Name.O, referred.childTable.Name.O)
+	}
+	return nil
+}
+
+// checkDropIndexUsedByFK forbids to drop the index the foreign key lookups depend on.
+func checkDropIndexUsedByFK(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo, indexInfo *model.IndexInfo) error {
+	// Look for the indexes without the dropped one.
+	remainTblInfo := tblInfo.Clone()
+	remainTblInfo.Indices = remainTblInfo.Indices[:0]
+	for _, idx := range tblInfo.Indices {
+		if idx.Name.L != indexInfo.Name.L {
+			remainTblInfo.Indices = append(remainTblInfo.Indices, idx)
+		}
+	}
+
+	for _, fk := range tblInfo.ForeignKeys {
+		if FindFKChildIndex(remainTblInfo, fk) == nil && !isFKOnHandle(remainTblInfo, fk) {
+			return errDropIndexFk.GenWithStackByArgs(indexInfo.Name.O)
+		}
+	}
+	referredFKs := getReferredFKs(is, schema, tblInfo)
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.RefSchema.L == schema.L && fk.RefTable.L == tblInfo.Name.L {
+			referredFKs = append(referredFKs, referredFK{childSchema: schema, childTable: tblInfo, fk: fk})
+		}
+	}
+	for _, referred := range referredFKs {
+		if _, ok := FindFKParentIndex(remainTblInfo, referred.fk); !ok {
+			return errDropIndexFk.GenWithStackByArgs(indexInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+func hasFKColumn(cols []model.CIStr, colName model.CIStr) bool {
+	for _, col := range cols {
+		if col.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
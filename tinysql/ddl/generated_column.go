@@ -0,0 +1,312 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+)
+
+// columnGenerationInDDL is a struct for validating generated columns in DDL.
+type columnGenerationInDDL struct {
+	position    int
+	generated   bool
+	dependences map[string]struct{}
+}
+
+// verifyColumnGeneration is for CREATE TABLE, because we need verify all columns in the table.
+func verifyColumnGeneration(colName2Generation map[string]columnGenerationInDDL, colName string) error {
+	attribute := colName2Generation[colName]
+	if attribute.generated {
+		for depCol := range attribute.dependences {
+			if attr, ok := colName2Generation[depCol]; ok {
+				if attr.generated && attribute.position <= attr.position {
+					// A generated column definition can refer to other
+					// generated columns occurring earlier in the table.
+					err := errGeneratedColumnNonPrior.GenWithStackByArgs()
+					return errors.Trace(err)
+				}
+			} else {
+				err := ErrBadField.GenWithStackByArgs(depCol, "generated column function")
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// columnNamesCover checks whether dependColNames is covered by normalColNames or not.
+// it's only for alter table add column because before alter, we can make sure that all
+// columns in table are verified already.
+func columnNamesCover(normalColNames map[string]struct{}, dependColNames map[string]struct{}) error {
+	for name := range dependColNames {
+		if _, ok := normalColNames[name]; !ok {
+			return errors.Trace(ErrBadField.GenWithStackByArgs(name, "generated column function"))
+		}
+	}
+	return nil
+}
+
+// findDependedColumnNames returns a set of string, which indicates
+// the names of the columns that are depended by colDef.
+func findDependedColumnNames(colDef *ast.ColumnDef) (generated bool, colsMap map[string]struct{}) {
+	colsMap = make(map[string]struct{})
+	for _, option := range colDef.Options {
+		if option.Tp == ast.ColumnOptionGenerated {
+			generated = true
+			colNames := findColumnNamesInExpr(option.Expr)
+			for _, depCol := range colNames {
+				colsMap[depCol.Name.L] = struct{}{}
+			}
+			break
+		}
+	}
+	return
+}
+
+// findColumnNamesInExpr returns a slice of ast.ColumnName which is referred in expr.
+func findColumnNamesInExpr(expr ast.ExprNode) []*ast.ColumnName {
+	var c generatedColumnChecker
+	expr.Accept(&c)
+	return c.cols
+}
+
+type generatedColumnChecker struct {
+	cols []*ast.ColumnName
+}
+
+func (c *generatedColumnChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	return inNode, false
+}
+
+func (c *generatedColumnChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	switch x := inNode.(type) {
+	case *ast.ColumnName:
+		c.cols = append(c.cols, x)
+	}
+	return inNode, true
+}
+
+// checkGeneratedColumn checks the generated columns of a CREATE TABLE statement:
+//  1. the expressions can't contain illegal functions;
+//  2. the generated columns can't refer to auto-increment columns;
+//  3. the generated columns can only refer to existing columns and the
+//     generated columns defined prior to them.
+func checkGeneratedColumn(colDefs []*ast.ColumnDef) error {
+	var colName2Generation = make(map[string]columnGenerationInDDL, len(colDefs))
+	var exists bool
+	var autoIncrementColumn string
+	for i, colDef := range colDefs {
+		for _, option := range colDef.Options {
+			if option.Tp == ast.ColumnOptionGenerated {
+				if err := checkIllegalFn4GeneratedColumn(colDef.Name.Name.L, option.Expr); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if option.Tp == ast.ColumnOptionAutoIncrement {
+				exists, autoIncrementColumn = true, colDef.Name.Name.L
+			}
+		}
+		generated, depCols := findDependedColumnNames(colDef)
+		colName2Generation[colDef.Name.Name.L] = columnGenerationInDDL{
+			position:    i,
+			generated:   generated,
+			dependences: depCols,
+		}
+	}
+
+	// Check whether the generated column refers to any auto-increment columns.
+	if exists {
+		for colName, generated := range colName2Generation {
+			if _, found := generated.dependences[autoIncrementColumn]; found {
+				return ErrGeneratedColumnRefAutoInc.GenWithStackByArgs(colName)
+			}
+		}
+	}
+
+	for _, colDef := range colDefs {
+		colName := colDef.Name.Name.L
+		if err := verifyColumnGeneration(colName2Generation, colName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkGeneratedColumnExpr builds the expressions of the generated columns
+// against the table, the expressions must be valid before the table is used.
+func checkGeneratedColumnExpr(ctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	for _, col := range tblInfo.Columns {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := expression.ParseSimpleExpr(col.GeneratedExprString)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err = expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, expr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkModifyGeneratedColumn checks the modification between
+// old and new is valid or not by such rules:
+//  1. the modification can't change stored status;
+//  2. the expression of a stored or indexed generated column can't be changed;
+//  3. if the new is generated, check its refer rules;
+//  4. check if the modified expr contains non-deterministic functions;
+//  5. check whether new column refers to any auto-increment columns.
+func checkModifyGeneratedColumn(tbl table.Table, oldCol, newCol *table.Column, newColDef *ast.ColumnDef) error {
+	// rule 1.
+	oldColIsStored := !oldCol.IsGenerated() || oldCol.GeneratedStored
+	newColIsStored := !newCol.IsGenerated() || newCol.GeneratedStored
+	if oldColIsStored != newColIsStored {
+		return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status")
+	}
+
+	// rule 2.
+	if oldCol.IsGenerated() && oldCol.GeneratedExprString != newCol.GeneratedExprString {
+		if oldCol.GeneratedStored {
+			return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the expression of a stored generated column")
+		}
+		if isColumnWithIndex(oldCol.Name.L, tbl.Meta().Indices) {
+			return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the expression of an indexed generated column")
+		}
+	}
+
+	// rule 3.
+	originCols := tbl.Cols()
+	var colName2Generation = make(map[string]columnGenerationInDDL, len(originCols))
+	for i, column := range originCols {
+		// We can compare the pointers simply.
+		if column == oldCol {
+			colName2Generation[newCol.Name.L] = columnGenerationInDDL{
+				position:    i,
+				generated:   newCol.IsGenerated(),
+				dependences: newCol.Dependences,
+			}
+		} else {
+			colName2Generation[column.Name.L] = columnGenerationInDDL{
+				position:    i,
+				generated:   column.IsGenerated(),
+				dependences: column.Dependences,
+			}
+		}
+	}
+	// We always need test all columns, even if it's not changed
+	// because other can depend on it so its name can't be changed.
+	for _, column := range originCols {
+		var colName string
+		if column == oldCol {
+			colName = newCol.Name.L
+		} else {
+			colName = column.Name.L
+		}
+		if err := verifyColumnGeneration(colName2Generation, colName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// rule 4.
+	for _, option := range newColDef.Options {
+		if option.Tp == ast.ColumnOptionGenerated {
+			if err := checkIllegalFn4GeneratedColumn(newCol.Name.L, option.Expr); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	// rule 5.
+	return errors.Trace(checkAutoIncrementRef(newCol.Name.O, newCol.Dependences, tbl.Meta()))
+}
+
+// checkAutoIncrementRef checks whether the generated column refers to
+// any auto-increment columns of the table.
+func checkAutoIncrementRef(name string, dependencies map[string]struct{}, tbInfo *model.TableInfo) error {
+	exists, autoIncrementColumn := hasAutoIncrementColumn(tbInfo)
+	if exists {
+		if _, found := dependencies[autoIncrementColumn]; found {
+			return ErrGeneratedColumnRefAutoInc.GenWithStackByArgs(name)
+		}
+	}
+	return nil
+}
+
+// hasAutoIncrementColumn returns the auto-increment column of the table if
+// it exists.
+func hasAutoIncrementColumn(tbInfo *model.TableInfo) (bool, string) {
+	for _, col := range tbInfo.Columns {
+		if mysql.HasAutoIncrementFlag(col.Flag) {
+			return true, col.Name.L
+		}
+	}
+	return false, ""
+}
+
+// checkDependedByGeneratedColumn checks whether the column is referred by
+// any other generated columns, the column can't be dropped or renamed then.
+func checkDependedByGeneratedColumn(tbl table.Table, colName model.CIStr) error {
+	for _, col := range tbl.Cols() {
+		if _, found := col.Dependences[colName.L]; found {
+			return errDependentByGeneratedColumn.GenWithStackByArgs(colName.O)
+		}
+	}
+	return nil
+}
+
+type illegalFunctionChecker struct {
+	found bool
+}
+
+func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipChildren bool) {
+	switch node := inNode.(type) {
+	case *ast.FuncCallExpr:
+		if _, found := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]; found {
+			c.found = true
+			return inNode, true
+		}
+		// UNIX_TIMESTAMP() without argument returns the current time.
+		if node.FnName.L == ast.UnixTimestamp && len(node.Args) == 0 {
+			c.found = true
+			return inNode, true
+		}
+	case *ast.ValuesExpr, *ast.VariableExpr, *ast.AggregateFuncExpr, *ast.DefaultExpr, *ast.RowExpr:
+		c.found = true
+		return inNode, true
+	}
+	return inNode, false
+}
+
+func (c *illegalFunctionChecker) Leave(inNode ast.Node) (node ast.Node, ok bool) {
+	return inNode, true
+}
+
+func checkIllegalFn4GeneratedColumn(colName string, expr ast.ExprNode) error {
+	if expr == nil {
+		return nil
+	}
+	var c illegalFunctionChecker
+	expr.Accept(&c)
+	if c.found {
+		return ErrGeneratedColumnFunctionIsNotAllowed.GenWithStackByArgs(colName)
+	}
+	return nil
+}
@@ -0,0 +1,627 @@
+// Copyright 2018 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+const (
+	// PartitionCountLimit is limit of the number of partitions in a table.
+	// Reference linking https://dev.mysql.com/doc/refman/5.7/en/partitioning-limitations.html.
+	PartitionCountLimit = 1024
+
+	partitionMaxValue = "MAXVALUE"
+	partitionNull     = "NULL"
+)
+
+// allowedPartitionFuncs are the functions allowed in the partitioning expression.
+// See https://dev.mysql.com/doc/refman/5.7/en/partitioning-limitations-functions.html
+var allowedPartitionFuncs = map[string]struct{}{
+	ast.Abs:           {},
+	ast.Ceiling:       {},
+	ast.DateDiff:      {},
+	ast.Day:           {},
+	ast.DayOfMonth:    {},
+	ast.Floor:         {},
+	ast.Hour:          {},
+	ast.Mod:           {},
+	ast.Month:         {},
+	ast.UnixTimestamp: {},
+	ast.Year:          {},
+}
+
+// allowedPartitionOps are the operators allowed in the partitioning expression.
+var allowedPartitionOps = map[opcode.Op]struct{}{
+	opcode.Plus:   {},
+	opcode.Minus:  {},
+	opcode.Mul:    {},
+	opcode.IntDiv: {},
+	opcode.Mod:    {},
+}
+
+// partitionExprChecker checks that the partitioning expression only contains
+// columns, constants and the allowed functions and operators.
+type partitionExprChecker struct {
+	err     error
+	columns []*ast.ColumnName
+}
+
+func (c *partitionExprChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.FuncCallExpr:
+		if _, ok := allowedPartitionFuncs[x.FnName.L]; !ok {
+			c.err = ErrPartitionFunctionIsNotAllowed
+		}
+	case *ast.BinaryOperationExpr:
+		if _, ok := allowedPartitionOps[x.Op]; !ok {
+			c.err = ErrPartitionFunctionIsNotAllowed
+		}
+	case *ast.UnaryOperationExpr:
+		if x.Op != opcode.Minus {
+			c.err = ErrPartitionFunctionIsNotAllowed
+		}
+	case *ast.ColumnNameExpr:
+		c.columns = append(c.columns, x.Name)
+	case *ast.ColumnName, *ast.ParenthesesExpr, ast.ValueExpr, *ast.TimeUnitExpr:
+	default:
+		c.err = ErrPartitionFunctionIsNotAllowed
+	}
+	return in, c.err != nil
+}
+
+func (c *partitionExprChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+// buildTablePartitionInfo builds the partition info of the table from the
+// PARTITION BY clause and checks it.
+func buildTablePartitionInfo(ctx sessionctx.Context, d *ddl, s *ast.CreateTableStmt, tbInfo *model.TableInfo) error {
+	opt := s.Partition
+	if err := checkPartitionExpr(ctx, tbInfo, opt.Expr); err != nil {
+		return errors.Trace(err)
+	}
+	pi := &model.PartitionInfo{
+		Type: opt.Tp,
+		Expr: opt.Expr.Text(),
+		Num:  opt.Num,
+	}
+	defs := opt.Definitions
+	if opt.Tp == model.PartitionTypeHash {
+		if pi.Num == 0 {
+			pi.Num = uint64(len(defs))
+			if pi.Num == 0 {
+				pi.Num = 1
+			}
+		}
+		if len(defs) > 0 && uint64(len(defs)) != pi.Num {
+			return errors.Trace(ErrPartitionWrongNoPart)
+		}
+		if len(defs) == 0 {
+			if pi.Num > PartitionCountLimit {
+				return errors.Trace(ErrTooManyPartitions)
+			}
+			defs = make([]*ast.PartitionDefinition, 0, pi.Num)
+			for i := uint64(0); i < pi.Num; i++ {
+				defs = append(defs, &ast.PartitionDefinition{Name: model.NewCIStr(fmt.Sprintf("p%d", i))})
+			}
+		}
+	} else if len(defs) == 0 {
+		return errors.Trace(ErrPartitionsMustBeDefined.GenWithStackByArgs(opt.Tp.String()))
+	}
+
+	pi.Definitions = make([]model.PartitionDefinition, 0, len(defs))
+	for _, def := range defs {
+		pd, err := buildPartitionDefinition(ctx, pi.Type, def)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pi.Definitions = append(pi.Definitions, pd)
+	}
+	if err := checkPartitionDefinitions(pi, pi.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkUniqueKeysCoverPartitionColumns(tbInfo, opt.Expr); err != nil {
+		return errors.Trace(err)
+	}
+
+	if d != nil {
+		genIDs, err := d.genGlobalIDs(len(pi.Definitions))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i := range pi.Definitions {
+			pi.Definitions[i].ID = genIDs[i]
+		}
+	}
+	tbInfo.Partition = pi
+	return nil
+}
+
+// checkPartitionExpr checks that the partitioning expression is allowed, it
+// must reference a column and return an integer.
+func checkPartitionExpr(ctx sessionctx.Context, tbInfo *model.TableInfo, expr ast.ExprNode) error {
+	checker := &partitionExprChecker{}
+	expr.Accept(checker)
+	if checker.err != nil {
+		return checker.err
+	}
+	if len(checker.columns) == 0 {
+		return ErrWrongExprInPartitionFunc
+	}
+	for _, name := range checker.columns {
+		if findColumnByName(tbInfo, name.Name) == nil {
+			return ErrBadField.GenWithStackByArgs(name.Name.O, "partition function")
+		}
+	}
+	e, err := expression.RewriteSimpleExprWithTableInfo(ctx, tbInfo, expr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if e.GetType().EvalType() == types.ETInt {
+		return nil
+	}
+	if col, ok := expr.(*ast.ColumnNameExpr); ok {
+		return ErrFieldTypeNotAllowedAsPartitionField.GenWithStackByArgs(col.Name.Name.O)
+	}
+	return ErrPartitionFuncNotAllowed.GenWithStackByArgs("PARTITION")
+}
+
+func findColumnByName(tbInfo *model.TableInfo, name model.CIStr) *model.ColumnInfo {
+	for _, col := range tbInfo.Columns {
+		if col.Name.L == name.L {
+			return col
+		}
+	}
+	return nil
+}
+
+// buildPartitionDefinition builds a partition definition, the values of the
+// definition are evaluated to integers and stored as strings.
+func buildPartitionDefinition(ctx sessionctx.Context, tp model.PartitionType, def *ast.PartitionDefinition) (model.PartitionDefinition, error) {
+	pd := model.PartitionDefinition{
+		Name:    def.Name,
+		Comment: def.Comment,
+	}
+	switch tp {
+	case model.PartitionTypeRange:
+		if len(def.InValues) > 0 {
+			return pd, ErrPartitionWrongValues.GenWithStackByArgs("LIST", "IN")
+		}
+		if len(def.LessThan) == 0 {
+			return pd, ErrPartitionRequiresValues.GenWithStackByArgs("RANGE", "LESS THAN")
+		}
+		if len(def.LessThan) != 1 {
+			return pd, ErrPartitionColumnList
+		}
+		if _, ok := def.LessThan[0].(*ast.MaxValueExpr); ok {
+			pd.LessThan = []string{partitionMaxValue}
+			break
+		}
+		v, isNull, err := evalPartitionValue(ctx, def.Name, def.LessThan[0])
+		if err != nil {
+			return pd, err
+		}
+		if isNull {
+			return pd, ErrNullInValuesLessThan
+		}
+		pd.LessThan = []string{v}
+	case model.PartitionTypeList:
+		if len(def.LessThan) > 0 {
+			return pd, ErrPartitionWrongValues.GenWithStackByArgs("RANGE", "LESS THAN")
+		}
+		if len(def.InValues) == 0 {
+			return pd, ErrPartitionRequiresValues.GenWithStackByArgs("LIST", "IN")
+		}
+		for _, vs := range def.InValues {
+			if len(vs) != 1 {
+				return pd, ErrPartitionColumnList
+			}
+			v, isNull, err := evalPartitionValue(ctx, def.Name, vs[0])
+			if err != nil {
+				return pd, err
+			}
+			if isNull {
+				v = partitionNull
+			}
+			pd.InValues = append(pd.InValues, []string{v})
+		}
+	case model.PartitionTypeHash:
+		if len(def.LessThan) > 0 {
+			return pd, ErrPartitionWrongValues.GenWithStackByArgs("RANGE", "LESS THAN")
+		}
+		if len(def.InValues) > 0 {
+			return pd, ErrPartitionWrongValues.GenWithStackByArgs("LIST", "IN")
+		}
+	}
+	return pd, nil
+}
+
+// evalPartitionValue evaluates a value of a partition definition, it must be
+// an integer or NULL.
+func evalPartitionValue(ctx sessionctx.Context, name model.CIStr, expr ast.ExprNode) (string, bool, error) {
+	d, err := expression.EvalAstExpr(ctx, expr)
+	if err != nil {
+		return "", false, errors.Trace(err)
+	}
+	switch d.Kind() {
+	case types.KindNull:
+		return "", true, nil
+	case types.KindInt64:
+		return strconv.FormatInt(d.GetInt64(), 10), false, nil
+	case types.KindUint64:
+		return strconv.FormatUint(d.GetUint64(), 10), false, nil
+	}
+	return "", false, ErrValuesIsNotIntType.GenWithStackByArgs(name.O)
+}
+
+// checkPartitionDefinitions checks the partition count, the partition names
+// and the partition values of the definitions.
+func checkPartitionDefinitions(pi *model.PartitionInfo, defs []model.PartitionDefinition) error {
+	if len(defs) > PartitionCountLimit {
+		return ErrTooManyPartitions
+	}
+	names := make(map[string]struct{}, len(defs))
+	for _, def := range defs {
+		if _, ok := names[def.Name.L]; ok {
+			return ErrSameNamePartition.GenWithStackByArgs(def.Name.O)
+		}
+		names[def.Name.L] = struct{}{}
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		for i, def := range defs {
+			if def.LessThan[0] == partitionMaxValue {
+				if i != len(defs)-1 {
+					return ErrPartitionMaxvalue
+				}
+				continue
+			}
+			if i == 0 {
+				continue
+			}
+			prev := defs[i-1].LessThan[0]
+			if prev == partitionMaxValue || !rangeValueLess(prev, def.LessThan[0]) {
+				return ErrRangeNotIncreasing
+			}
+		}
+	case model.PartitionTypeList:
+		values := make(map[string]struct{})
+		for _, def := range defs {
+			for _, vs := range def.InValues {
+				if _, ok := values[vs[0]]; ok {
+					return ErrMultipleDefConstInListPart
+				}
+				values[vs[0]] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+func rangeValueLess(a, b string) bool {
+	x, err := strconv.ParseInt(a, 10, 64)
+	if err != nil {
+		return false
+	}
+	y, err := strconv.ParseInt(b, 10, 64)
+	if err != nil {
+		return false
+	}
+	return x < y
+}
+
+// checkUniqueKeysCoverPartitionColumns checks that every unique key of the
+// table, including the primary key, covers all the columns of the
+// partitioning expression, so a unique check only has to look at one partition.
+func checkUniqueKeysCoverPartitionColumns(tbInfo *model.TableInfo, expr ast.ExprNode) error {
+	partCols := extractPartitionColumns(expr)
+	if tbInfo.PKIsHandle {
+		pk := tbInfo.GetPkColInfo()
+		if !columnsCovered(partCols, []model.CIStr{pk.Name}) {
+			return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
+		}
+	}
+	for _, idx := range tbInfo.Indices {
+		if !idx.Unique {
+			continue
+		}
+		names := make([]model.CIStr, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			names = append(names, col.Name)
+		}
+		if columnsCovered(partCols, names) {
+			continue
+		}
+		if idx.Primary {
+			return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("PRIMARY KEY")
+		}
+		return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+	}
+	return nil
+}
+
+// checkUniqueIndexCoversPartitionColumns checks that a unique index added to
+// a partitioned table covers all the columns of the partitioning expression.
+func checkUniqueIndexCoversPartitionColumns(tbInfo *model.TableInfo, idxColNames []*ast.IndexPartSpecification) error {
+	pi := tbInfo.GetPartitionInfo()
+	if pi == nil {
+		return nil
+	}
+	expr, err := expression.ParseSimpleExpr(pi.Expr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	names := make([]model.CIStr, 0, len(idxColNames))
+	for _, col := range idxColNames {
+		names = append(names, col.Column.Name)
+	}
+	if !columnsCovered(extractPartitionColumns(expr), names) {
+		return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+	}
+	return nil
+}
+
+func extractPartitionColumns(expr ast.ExprNode) []*ast.ColumnName {
+	checker := &partitionExprChecker{}
+	expr.Accept(checker)
+	return checker.columns
+}
+
+func columnsCovered(partCols []*ast.ColumnName, names []model.CIStr) bool {
+	for _, col := range partCols {
+		found := false
+		for _, name := range names {
+			if name.L == col.Name.L {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// isPartitionColumn checks whether the column is referenced by the
+// partitioning expression of the table.
+func isPartitionColumn(tbInfo *model.TableInfo, colName model.CIStr) bool {
+	pi := tbInfo.GetPartitionInfo()
+	if pi == nil {
+		return false
+	}
+	expr, err := expression.ParseSimpleExpr(pi.Expr)
+	if err != nil {
+		return false
+	}
+	for _, name := range extractPartitionColumns(expr) {
+		if name.Name.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
+
+// buildAddedPartitionInfo builds the partition info holding the partitions
+// added by ALTER TABLE ADD PARTITION.
+func buildAddedPartitionInfo(ctx sessionctx.Context, d *ddl, meta *model.TableInfo, spec *ast.AlterTableSpec) (*model.PartitionInfo, error) {
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return nil, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
+		return nil, errors.Trace(ErrOnlyOnRangeListPartition.GenWithStackByArgs("ADD"))
+	}
+	if len(spec.PartDefinitions) == 0 {
+		return nil, errors.Trace(ErrPartitionsMustBeDefined.GenWithStackByArgs(pi.Type.String()))
+	}
+
+	part := &model.PartitionInfo{Type: pi.Type, Expr: pi.Expr, Num: pi.Num}
+	for _, def := range spec.PartDefinitions {
+		pd, err := buildPartitionDefinition(ctx, pi.Type, def)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		part.Definitions = append(part.Definitions, pd)
+	}
+	// The added partitions are checked together with the existing ones, so
+	// range bounds keep increasing and names and list values stay unique.
+	defs := make([]model.PartitionDefinition, 0, len(pi.Definitions)+len(part.Definitions))
+	defs = append(defs, pi.Definitions...)
+	defs = append(defs, part.Definitions...)
+	if err := checkPartitionDefinitions(pi, defs); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	genIDs, err := d.genGlobalIDs(len(part.Definitions))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for i := range part.Definitions {
+		part.Definitions[i].ID = genIDs[i]
+	}
+	return part, nil
+}
+
+// checkDropTablePartition checks that the partitions to drop exist and that
+// at least one partition is left.
+func checkDropTablePartition(meta *model.TableInfo, partNames []model.CIStr) error {
+	pi := meta.GetPartitionInfo()
+	if pi.Type != model.PartitionTypeRange && pi.Type != model.PartitionTypeList {
+		return ErrOnlyOnRangeListPartition.GenWithStackByArgs("DROP")
+	}
+	if err := checkPartitionNamesExist(pi, partNames, "DROP"); err != nil {
+		return err
+	}
+	if len(partNames) >= len(pi.Definitions) {
+		return ErrDropLastPartition
+	}
+	return nil
+}
+
+func checkPartitionNamesExist(pi *model.PartitionInfo, partNames []model.CIStr, op string) error {
+	var missing []string
+	for _, name := range partNames {
+		if pi.FindPartitionDefinitionByName(name.L) < 0 {
+			missing = append(missing, name.O)
+		}
+	}
+	if len(missing) > 0 {
+		return ErrDropPartitionNonExistent.GenWithStack("Error in list of partitions to %s: %s", op, strings.Join(missing, ","))
+	}
+	return nil
+}
+
+// onAddTablePartition appends the new partitions to the partition definitions.
+func onAddTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	partInfo := &model.PartitionInfo{}
+	if err := job.DecodeArgs(&partInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	defs := make([]model.PartitionDefinition, 0, len(pi.Definitions)+len(partInfo.Definitions))
+	defs = append(defs, pi.Definitions...)
+	defs = append(defs, partInfo.Definitions...)
+	if err = checkPartitionDefinitions(pi, defs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	pi.Definitions = defs
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// removePartitions removes the named partitions from the definitions and
+// returns the physical table IDs of the removed partitions.
+func removePartitions(pi *model.PartitionInfo, partNames []model.CIStr) []int64 {
+	dropped := make(map[string]struct{}, len(partNames))
+	for _, name := range partNames {
+		dropped[name.L] = struct{}{}
+	}
+	physicalTableIDs := make([]int64, 0, len(partNames))
+	defs := make([]model.PartitionDefinition, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		if _, ok := dropped[def.Name.L]; ok {
+			physicalTableIDs = append(physicalTableIDs, def.ID)
+			continue
+		}
+		defs = append(defs, def)
+	}
+	pi.Definitions = defs
+	return physicalTableIDs
+}
+
+// onDropTablePartition removes the partitions from the partition definitions,
+// the dropped physical table IDs are appended to the job arguments.
+func onDropTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var partNames []model.CIStr
+	if err := job.DecodeArgs(&partNames); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.GetPartitionInfo() == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	// If an error occurs, it returns that it cannot delete all partitions or that the partition doesn't exist.
+	if err = checkDropTablePartition(tblInfo, partNames); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	physicalTableIDs := removePartitions(tblInfo.Partition, partNames)
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	job.Args = append(job.Args, physicalTableIDs)
+	return ver, nil
+}
+
+// onTruncateTablePartition gives the partitions new physical table IDs, so
+// the rows of the old ones are no longer visible.
+func onTruncateTablePartition(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var partNames []model.CIStr
+	if err := job.DecodeArgs(&partNames); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if err = checkPartitionNamesExist(pi, partNames, "TRUNCATE"); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	newIDs, err := t.GenGlobalIDs(len(partNames))
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	oldIDs := make([]int64, 0, len(partNames))
+	for i, name := range partNames {
+		idx := pi.FindPartitionDefinitionByName(name.L)
+		oldIDs = append(oldIDs, pi.Definitions[idx].ID)
+		pi.Definitions[idx].ID = newIDs[i]
+	}
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	job.Args = append(job.Args, oldIDs)
+	return ver, nil
+}
@@ -239,12 +239,19 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackDropColumn(t, job)
 	case model.ActionDropIndex, model.ActionDropPrimaryKey:
 		ver, err = rollingbackDropIndex(t, job)
-	case model.ActionDropTable:
+	case model.ActionDropTable, model.ActionDropView:
 		err = rollingbackDropTableOrView(t, job)
 	case model.ActionDropSchema:
 		err = rollingbackDropSchema(t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = rollingbackAddCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = rollingbackAlterCheckConstraint(t, job)
+	case model.ActionAddForeignKey:
+		ver, err = rollingbackAddForeignKey(t, job)
 	case model.ActionShardRowID,
-		model.ActionModifyColumn,
+		model.ActionModifyColumn, model.ActionDropCheckConstraint, model.ActionDropForeignKey,
+		model.ActionAddTablePartition, model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 		model.ActionModifyTableCharsetAndCollate, model.ActionModifySchemaCharsetAndCollate:
 		ver, err = cancelOnlyNotHandledJob(job)
 	default:
@@ -15,6 +15,7 @@ package distsql
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -24,7 +25,10 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/execdetails"
+	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tipb/go-tipb"
+	"go.uber.org/zap"
 )
 
 var _ SelectResult = (*selectResult)(nil)
@@ -55,6 +59,11 @@ type selectResult struct {
 
 	fetchDuration    time.Duration
 	durationReported bool
+
+	// copPlanIDs contains all copTasks' planIDs,
+	// which help to collect copTasks' runtime stats.
+	copPlanIDs []fmt.Stringer
+	rootPlanID fmt.Stringer
 }
 
 func (r *selectResult) fetchResp(ctx context.Context) error {
@@ -91,6 +100,9 @@ func (r *selectResult) fetchResp(ctx context.Context) error {
 		for _, warning := range r.selectResp.Warnings {
 			sc.AppendWarning(terror.ClassTiKV.New(terror.ErrCode(warning.Code), warning.Msg))
 		}
+		if sc.RuntimeStatsColl != nil {
+			r.updateCopRuntimeStats(ctx, resultSubset.GetExecDetails())
+		}
 		r.partialCount++
 		if len(r.selectResp.Chunks) != 0 {
 			break
@@ -153,6 +165,34 @@ func (r *selectResult) readRowsData(chk *chunk.Chunk) (err error) {
 	return nil
 }
 
+func (r *selectResult) updateCopRuntimeStats(ctx context.Context, detail *execdetails.ExecDetails) {
+	if r.rootPlanID == nil {
+		return
+	}
+	if len(r.selectResp.ExecutionSummaries) != len(r.copPlanIDs) {
+		logutil.Logger(ctx).Error("invalid cop task execution summaries length",
+			zap.Int("expected", len(r.copPlanIDs)),
+			zap.Int("received", len(r.selectResp.ExecutionSummaries)))
+		return
+	}
+	callee := ""
+	if detail != nil {
+		callee = detail.CalleeAddress
+	}
+	runtimeStatsColl := r.ctx.GetSessionVars().StmtCtx.RuntimeStatsColl
+	var processTime time.Duration
+	for i, summary := range r.selectResp.ExecutionSummaries {
+		if summary != nil && summary.TimeProcessedNs != nil &&
+			summary.NumProducedRows != nil && summary.NumIterations != nil {
+			runtimeStatsColl.RecordOneCopTask(r.copPlanIDs[i].String(), callee, summary)
+			// The time of an executor includes the time of its children, so the
+			// last one, which is the root of the cop task, covers the whole task.
+			processTime = time.Duration(summary.GetTimeProcessedNs())
+		}
+	}
+	runtimeStatsColl.GetReaderStats(r.rootPlanID.String()).RecordCopTask(detail, processTime)
+}
+
 // Close closes selectResult.
 func (r *selectResult) Close() error {
 	return r.resp.Close()
@@ -24,6 +24,7 @@ import (
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/bindinfo"
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege/privileges"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics"
@@ -50,6 +52,8 @@ type Domain struct {
 	infoHandle      *infoschema.Handle
 	statsHandle     unsafe.Pointer
 	statsLease      time.Duration
+	bindHandle      *bindinfo.BindHandle
+	privHandle      *privileges.Handle
 	ddl             ddl.DDL
 	m               sync.Mutex
 	SchemaValidator SchemaValidator
@@ -614,6 +618,115 @@ func (do *Domain) GetEtcdClient() *clientv3.Client {
 	return do.etcdClient
 }
 
+// PrivilegeHandle returns the MySQLPrivilege.
+func (do *Domain) PrivilegeHandle() *privileges.Handle {
+	return do.privHandle
+}
+
+const privilegeKey = "/tidb/privilege"
+
+// LoadPrivilegeLoop create a goroutine loads privilege tables in a loop, it
+// should be called only once in BootstrapSession.
+func (do *Domain) LoadPrivilegeLoop(ctx sessionctx.Context) error {
+	ctx.GetSessionVars().InRestrictedSQL = true
+	do.privHandle = privileges.NewHandle()
+	if err := do.privHandle.Update(ctx); err != nil {
+		return err
+	}
+
+	var watchCh clientv3.WatchChan
+	duration := 5 * time.Minute
+	if do.etcdClient != nil {
+		watchCh = do.etcdClient.Watch(context.Background(), privilegeKey)
+		duration = 10 * time.Minute
+	}
+
+	do.wg.Add(1)
+	go func() {
+		defer do.wg.Done()
+		defer recoverInDomain("loadPrivilegeInLoop", false)
+		var count int
+		for {
+			ok := true
+			select {
+			case <-do.exit:
+				return
+			case _, ok = <-watchCh:
+			case <-time.After(duration):
+			}
+			if !ok {
+				logutil.BgLogger().Error("load privilege loop watch channel closed")
+				watchCh = do.etcdClient.Watch(context.Background(), privilegeKey)
+				count++
+				if count > 10 {
+					time.Sleep(time.Duration(count) * time.Second)
+				}
+				continue
+			}
+
+			count = 0
+			if err := do.privHandle.Update(ctx); err != nil {
+				logutil.BgLogger().Error("load privilege failed", zap.Error(err))
+			}
+		}
+	}()
+	return nil
+}
+
+// NotifyUpdatePrivilege updates the privilege key in etcd, TiDB client that
+// watches the key will get notification, and it reloads the privilege of
+// this server at once.
+func (do *Domain) NotifyUpdatePrivilege(ctx sessionctx.Context) {
+	if do.etcdClient != nil {
+		_, err := do.etcdClient.KV.Put(context.Background(), privilegeKey, "")
+		if err != nil {
+			logutil.BgLogger().Warn("notify update privilege failed", zap.Error(err))
+		}
+	}
+	if do.privHandle == nil {
+		return
+	}
+	if err := do.privHandle.Update(ctx); err != nil {
+		logutil.BgLogger().Error("load privilege failed", zap.Error(err))
+	}
+}
+
+// BindHandle returns domain's bindHandle.
+func (do *Domain) BindHandle() *bindinfo.BindHandle {
+	return do.bindHandle
+}
+
+// LoadBindInfoLoop creates a goroutine loads BindInfo in a loop, it should
+// be called only once in BootstrapSession.
+func (do *Domain) LoadBindInfoLoop(ctx sessionctx.Context) error {
+	ctx.GetSessionVars().InRestrictedSQL = true
+	do.bindHandle = bindinfo.NewBindHandle(ctx)
+	if err := do.bindHandle.Update(true); err != nil {
+		return err
+	}
+	do.wg.Add(1)
+	go do.loadBindInfoWorker()
+	return nil
+}
+
+func (do *Domain) loadBindInfoWorker() {
+	defer recoverInDomain("loadBindInfoWorker", false)
+	defer do.wg.Done()
+	loadTicker := time.NewTicker(bindinfo.Lease)
+	defer loadTicker.Stop()
+	for {
+		select {
+		case <-loadTicker.C:
+			err := do.bindHandle.Update(false)
+			if err != nil {
+				logutil.BgLogger().Debug("update bind info failed", zap.Error(err))
+			}
+		case <-do.exit:
+			return
+		}
+	}
+}
+
 // StatsHandle returns the statistic handle.
 func (do *Domain) StatsHandle() *statistics.Handle {
 	return (*statistics.Handle)(atomic.LoadPointer(&do.statsHandle))
@@ -156,7 +156,16 @@ func (a *ExecStmt) OriginText() string {
 }
 
 // IsReadOnly returns true if a statement is read only.
+// An EXECUTE statement is read only if the prepared statement is read only.
 func (a *ExecStmt) IsReadOnly() bool {
+	if execStmt, ok := a.StmtNode.(*ast.ExecuteStmt); ok {
+		s, err := getPreparedStmt(execStmt, a.Ctx.GetSessionVars())
+		if err != nil {
+			logutil.BgLogger().Error("getPreparedStmt failed", zap.Error(err))
+			return false
+		}
+		return ast.IsReadOnly(s)
+	}
 	return ast.IsReadOnly(a.StmtNode)
 }
 
@@ -225,6 +234,12 @@ func (a *ExecStmt) HandleNoDelay(ctx context.Context, e Executor) (bool, sqlexec
 func (a *ExecStmt) handleNoDelay(ctx context.Context, e Executor) (bool, sqlexec.RecordSet, error) {
 	toCheck := e
 
+	// EXPLAIN ANALYZE executes the explained statement without delay, so that
+	// it runs in the transaction of the current statement.
+	if explain, ok := e.(*ExplainExec); ok && explain.analyzeExec != nil {
+		return false, nil, explain.executeAnalyzeExec(ctx)
+	}
+
 	// If the executor doesn't return any result to the client, we execute it without delay.
 	if toCheck.Schema().Len() == 0 {
 		// Hint: step I.4.3
@@ -273,6 +288,16 @@ func (a *ExecStmt) buildExecutor() (Executor, error) {
 		return nil, errors.Trace(b.err)
 	}
 
+	// ExecuteExec is not a real Executor, we only use it to build another Executor from a prepared statement.
+	if executorExec, ok := e.(*ExecuteExec); ok {
+		err := executorExec.Build(b)
+		if err != nil {
+			return nil, err
+		}
+		a.OutputNames = executorExec.outputNames
+		a.Plan = executorExec.plan
+		e = executorExec.stmtExec
+	}
 	return e, nil
 }
 
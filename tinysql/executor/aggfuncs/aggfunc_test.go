@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
@@ -66,9 +67,20 @@ type aggTest struct {
 	numRows  int
 	dataGen  func(i int) types.Datum
 	funcName string
+	distinct bool
 	results  []types.Datum
 }
 
+// args returns the arguments of the tested function, which reads the only
+// column of the input. GROUP_CONCAT is given the separator " " as well.
+func (p *aggTest) args() []expression.Expression {
+	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
+	if p.funcName == ast.AggFuncGroupConcat {
+		args = append(args, &expression.Constant{Value: types.NewStringDatum(" "), RetType: types.NewFieldType(mysql.TypeString)})
+	}
+	return args
+}
+
 func (s *testSuite) testMergePartialResult(c *C, p aggTest) {
 	srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{p.dataType}, p.numRows)
 	for i := 0; i < p.numRows; i++ {
@@ -77,10 +89,9 @@ func (s *testSuite) testMergePartialResult(c *C, p aggTest) {
 	}
 	iter := chunk.NewIterator4Chunk(srcChk)
 
-	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
-	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args)
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, p.args(), p.distinct)
 	c.Assert(err, IsNil)
-	partialDesc, finalDesc := desc.Split([]int{0, 1})
+	partialDesc, finalDesc := desc.Split([]int{0, 1, 2})
 
 	// build partial func for partial phase.
 	partialFunc := aggfuncs.Build(s.ctx, partialDesc, 0)
@@ -154,6 +165,8 @@ func getDataGenFunc(ft *types.FieldType) func(i int) types.Datum {
 		return func(i int) types.Datum { return types.NewFloat32Datum(float32(i)) }
 	case mysql.TypeDouble:
 		return func(i int) types.Datum { return types.NewFloat64Datum(float64(i)) }
+	case mysql.TypeNewDecimal:
+		return func(i int) types.Datum { return types.NewDecimalDatum(types.NewDecFromInt(int64(i))) }
 	case mysql.TypeString:
 		return func(i int) types.Datum { return types.NewStringDatum(fmt.Sprintf("%d", i)) }
 	}
@@ -168,8 +181,7 @@ func (s *testSuite) testAggFunc(c *C, p aggTest) {
 	}
 	srcChk.AppendDatum(0, &types.Datum{})
 
-	args := []expression.Expression{&expression.Column{RetType: p.dataType, Index: 0}}
-	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, args)
+	desc, err := aggregation.NewAggFuncDesc(s.ctx, p.funcName, p.args(), p.distinct)
 	c.Assert(err, IsNil)
 	finalFunc := aggfuncs.Build(s.ctx, desc, 0)
 	finalPr := finalFunc.AllocPartialResult()
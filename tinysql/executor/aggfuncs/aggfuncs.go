@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
 )
 
 // All the AggFunc implementations are listed here for navigation.
@@ -27,31 +28,55 @@ var (
 	_ AggFunc = (*countPartial)(nil)
 	_ AggFunc = (*countOriginal4Int)(nil)
 	_ AggFunc = (*countOriginal4Real)(nil)
+	_ AggFunc = (*countOriginal4Decimal)(nil)
 	_ AggFunc = (*countOriginal4String)(nil)
+	_ AggFunc = (*countOriginalWithDistinct)(nil)
 
 	// All the AggFunc implementations for "FIRSTROW" are listed here.
 	_ AggFunc = (*firstRow4Int)(nil)
 	_ AggFunc = (*firstRow4String)(nil)
 	_ AggFunc = (*firstRow4Float32)(nil)
 	_ AggFunc = (*firstRow4Float64)(nil)
+	_ AggFunc = (*firstRow4Decimal)(nil)
 
 	// All the AggFunc implementations for "MAX"/"MIN" are listed here.
 	_ AggFunc = (*maxMin4Int)(nil)
 	_ AggFunc = (*maxMin4Uint)(nil)
 	_ AggFunc = (*maxMin4Float32)(nil)
 	_ AggFunc = (*maxMin4Float64)(nil)
+	_ AggFunc = (*maxMin4Decimal)(nil)
 	_ AggFunc = (*maxMin4String)(nil)
 
 	// All the AggFunc implementations for "AVG" are listed here.
-	_ AggFunc = (*avgOriginal4Int64)(nil)
-	_ AggFunc = (*avgPartial4Int64)(nil)
+	_ AggFunc = (*avgOriginal4Decimal)(nil)
+	_ AggFunc = (*avgPartial4Decimal)(nil)
 
 	_ AggFunc = (*avgOriginal4Float64)(nil)
 	_ AggFunc = (*avgPartial4Float64)(nil)
 
+	_ AggFunc = (*avgDistinct4Decimal)(nil)
+	_ AggFunc = (*avgDistinct4Float64)(nil)
+
 	// All the AggFunc implementations for "SUM" are listed here.
-	_ AggFunc = (*sum4Int64)(nil)
+	_ AggFunc = (*sum4Decimal)(nil)
 	_ AggFunc = (*sum4Float64)(nil)
+	_ AggFunc = (*sumDistinct4Decimal)(nil)
+	_ AggFunc = (*sumDistinct4Float64)(nil)
+
+	// All the AggFunc implementations for "GROUP_CONCAT" are listed here.
+	_ AggFunc = (*groupConcat)(nil)
+	_ AggFunc = (*groupConcatOrderOrDistinct)(nil)
+
+	// All the AggFunc implementations for "BIT_OR", "BIT_XOR" and "BIT_AND"
+	// are listed here.
+	_ AggFunc = (*bitOrUint64)(nil)
+	_ AggFunc = (*bitXorUint64)(nil)
+	_ AggFunc = (*bitAndUint64)(nil)
+
+	// All the AggFunc implementations for "VAR_POP", "VAR_SAMP",
+	// "STDDEV_POP" and "STDDEV_SAMP" are listed here.
+	_ AggFunc = (*varianceOriginal4Float64)(nil)
+	_ AggFunc = (*variancePartial4Float64)(nil)
 )
 
 // PartialResult represents data structure to store the partial result for the
@@ -109,3 +134,24 @@ type baseAggFunc struct {
 func (*baseAggFunc) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
 	return nil
 }
+
+// evalDistinctKey evaluates args on row and encodes their values into buf,
+// so that equal argument lists produce equal keys. hasNull is true if any of
+// the args is NULL, in which case the row is ignored by DISTINCT functions.
+func evalDistinctKey(sctx sessionctx.Context, args []expression.Expression, row chunk.Row, buf []byte) (key []byte, hasNull bool, err error) {
+	sc := sctx.GetSessionVars().StmtCtx
+	for _, arg := range args {
+		d, err := arg.Eval(row)
+		if err != nil {
+			return nil, false, err
+		}
+		if d.IsNull() {
+			return nil, true, nil
+		}
+		buf, err = codec.EncodeValue(sc, buf, d)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return buf, false, nil
+}
@@ -14,11 +14,17 @@
 package aggfuncs
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 )
 
 // Build is used to build a specific AggFunc implementation according to the
@@ -28,15 +34,25 @@ func Build(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal
 	case ast.AggFuncCount:
 		return buildCount(aggFuncDesc, ordinal)
 	case ast.AggFuncSum:
-		return buildSum(aggFuncDesc, ordinal)
+		return buildSum(ctx, aggFuncDesc, ordinal)
 	case ast.AggFuncAvg:
-		return buildAvg(aggFuncDesc, ordinal)
+		return buildAvg(ctx, aggFuncDesc, ordinal)
 	case ast.AggFuncFirstRow:
 		return buildFirstRow(aggFuncDesc, ordinal)
 	case ast.AggFuncMax:
 		return buildMaxMin(aggFuncDesc, ordinal, true)
 	case ast.AggFuncMin:
 		return buildMaxMin(aggFuncDesc, ordinal, false)
+	case ast.AggFuncGroupConcat:
+		return buildGroupConcat(ctx, aggFuncDesc, ordinal)
+	case ast.AggFuncBitOr:
+		return buildBitOr(aggFuncDesc, ordinal)
+	case ast.AggFuncBitXor:
+		return buildBitXor(aggFuncDesc, ordinal)
+	case ast.AggFuncBitAnd:
+		return buildBitAnd(aggFuncDesc, ordinal)
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		return buildVariance(aggFuncDesc, ordinal)
 	}
 	return nil
 }
@@ -48,6 +64,13 @@ func buildCount(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		ordinal: ordinal,
 	}
 
+	// The DISTINCT functions are never pushed down, their partial results
+	// are only merged by the final phase, so they are built the same way in
+	// all modes.
+	if aggFuncDesc.HasDistinct {
+		return &countOriginalWithDistinct{base}
+	}
+
 	switch aggFuncDesc.Mode {
 	case aggregation.CompleteMode, aggregation.Partial1Mode:
 		switch aggFuncDesc.Args[0].GetType().EvalType() {
@@ -55,6 +78,8 @@ func buildCount(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 			return &countOriginal4Int{baseCount{base}}
 		case types.ETReal:
 			return &countOriginal4Real{baseCount{base}}
+		case types.ETDecimal:
+			return &countOriginal4Decimal{baseCount{base}}
 		case types.ETString:
 			return &countOriginal4String{baseCount{base}}
 		}
@@ -65,35 +90,72 @@ func buildCount(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	return nil
 }
 
+// widenIntArgs casts the integer argument of "SUM" and "AVG" which consume the
+// original data to decimal. The plan keeps it as an integer, so the partial
+// aggregation can be pushed down as is.
+func widenIntArgs(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc) []expression.Expression {
+	if aggFuncDesc.Mode != aggregation.CompleteMode && aggFuncDesc.Mode != aggregation.Partial1Mode {
+		return aggFuncDesc.Args
+	}
+	args := make([]expression.Expression, len(aggFuncDesc.Args))
+	for i, arg := range aggFuncDesc.Args {
+		args[i] = arg
+		if aggregation.IsIntWidenedToDecimal(aggFuncDesc.Name, arg, aggFuncDesc.RetTp) {
+			args[i] = expression.BuildCastFunction(ctx, arg, aggFuncDesc.RetTp.Clone())
+		}
+	}
+	return args
+}
+
 // buildSum builds the AggFunc implementation for function "SUM".
-func buildSum(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildSum(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	base := baseSumAggFunc{
 		baseAggFunc: baseAggFunc{
-			args:    aggFuncDesc.Args,
+			args:    widenIntArgs(ctx, aggFuncDesc),
 			ordinal: ordinal,
 		},
 	}
+	if aggFuncDesc.HasDistinct {
+		switch aggFuncDesc.RetTp.EvalType() {
+		case types.ETDecimal:
+			return &sumDistinct4Decimal{baseDistinct4Decimal{base.baseAggFunc}}
+		default:
+			return &sumDistinct4Float64{baseDistinct4Float64{base.baseAggFunc}}
+		}
+	}
 	switch aggFuncDesc.RetTp.EvalType() {
-	case types.ETInt:
-		return &sum4Int64{base}
+	case types.ETDecimal:
+		return &sum4Decimal{base}
 	default:
 		return &sum4Float64{base}
 	}
 }
 
 // buildAvg builds the AggFunc implementation for function "AVG".
-func buildAvg(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildAvg(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	base := baseAggFunc{
-		args:    aggFuncDesc.Args,
+		args:    widenIntArgs(ctx, aggFuncDesc),
 		ordinal: ordinal,
 	}
+	frac := aggFuncDesc.RetTp.Decimal
+	if frac == types.UnspecifiedLength {
+		frac = mysql.MaxDecimalScale
+	}
+	if aggFuncDesc.HasDistinct {
+		switch aggFuncDesc.RetTp.EvalType() {
+		case types.ETDecimal:
+			return &avgDistinct4Decimal{baseDistinct4Decimal{base}, frac}
+		default:
+			return &avgDistinct4Float64{baseDistinct4Float64{base}}
+		}
+	}
 	switch aggFuncDesc.Mode {
 	// Build avg functions which consume the original data and update their
 	// partial results.
 	case aggregation.CompleteMode, aggregation.Partial1Mode:
 		switch aggFuncDesc.RetTp.EvalType() {
-		case types.ETInt:
-			return &avgOriginal4Int64{baseAvgInt64{base}}
+		case types.ETDecimal:
+			return &avgOriginal4Decimal{baseAvgDecimal{base, frac}}
 		default:
 			return &avgOriginal4Float64{baseAvgFloat64{base}}
 		}
@@ -102,8 +164,8 @@ func buildAvg(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	// functions and update their partial results.
 	case aggregation.Partial2Mode, aggregation.FinalMode:
 		switch aggFuncDesc.RetTp.Tp {
-		case mysql.TypeLonglong:
-			return &avgPartial4Int64{baseAvgInt64{base}}
+		case mysql.TypeNewDecimal:
+			return &avgPartial4Decimal{baseAvgDecimal{base, frac}}
 		case mysql.TypeDouble:
 			return &avgPartial4Float64{baseAvgFloat64{base}}
 		}
@@ -132,6 +194,8 @@ func buildFirstRow(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 		case mysql.TypeDouble:
 			return &firstRow4Float64{base}
 		}
+	case types.ETDecimal:
+		return &firstRow4Decimal{base}
 	case types.ETString:
 		return &firstRow4String{base}
 	}
@@ -165,8 +229,89 @@ func buildMaxMin(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, isMax bool)
 		case mysql.TypeDouble:
 			return &maxMin4Float64{base}
 		}
+	case types.ETDecimal:
+		return &maxMin4Decimal{base}
 	case types.ETString:
 		return &maxMin4String{base}
 	}
 	return nil
 }
+
+// buildGroupConcat builds the AggFunc implementation for function "GROUP_CONCAT".
+func buildGroupConcat(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	// The separator is the last argument and it is always a constant.
+	sepArg := aggFuncDesc.Args[len(aggFuncDesc.Args)-1]
+	sep, isNull, err := sepArg.EvalString(ctx, chunk.Row{})
+	// This should never happen.
+	if err != nil || isNull {
+		panic(fmt.Sprintf("Error happened when buildGroupConcat: invalid separator %s", sepArg.String()))
+	}
+	s, err := variable.GetSessionSystemVar(ctx.GetSessionVars(), variable.GroupConcatMaxLen)
+	if err != nil {
+		panic(fmt.Sprintf("Error happened when buildGroupConcat: %s", err.Error()))
+	}
+	maxLen, err := strconv.ParseUint(s, 10, 64)
+	// Should never happen.
+	if err != nil {
+		panic(fmt.Sprintf("Error happened when buildGroupConcat: %s", err.Error()))
+	}
+	base := baseGroupConcat4String{
+		baseAggFunc: baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+		},
+		byItems: aggFuncDesc.OrderByItems,
+		sep:     sep,
+		maxLen:  maxLen,
+	}
+	if aggFuncDesc.HasDistinct || len(aggFuncDesc.OrderByItems) > 0 {
+		return &groupConcatOrderOrDistinct{base, aggFuncDesc.HasDistinct}
+	}
+	return &groupConcat{base}
+}
+
+// buildBitOr builds the AggFunc implementation for function "BIT_OR".
+func buildBitOr(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitOrUint64{baseBitAggFunc{base}}
+}
+
+// buildBitXor builds the AggFunc implementation for function "BIT_XOR".
+func buildBitXor(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitXorUint64{baseBitAggFunc{base}}
+}
+
+// buildBitAnd builds the AggFunc implementation for function "BIT_AND".
+func buildBitAnd(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return &bitAndUint64{baseBitAggFunc{base}}
+}
+
+// buildVariance builds the AggFunc implementation for function "VAR_POP",
+// "VAR_SAMP", "STDDEV_POP" and "STDDEV_SAMP".
+func buildVariance(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := baseVariance4Float64{
+		baseAggFunc: baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+		},
+		name: aggFuncDesc.Name,
+	}
+	switch aggFuncDesc.Mode {
+	case aggregation.CompleteMode, aggregation.Partial1Mode:
+		return &varianceOriginal4Float64{base}
+	case aggregation.Partial2Mode, aggregation.FinalMode:
+		return &variancePartial4Float64{base}
+	}
+	return nil
+}
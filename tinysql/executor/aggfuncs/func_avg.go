@@ -20,48 +20,62 @@ import (
 )
 
 // All the following avg function implementations return the decimal result,
-// which store the partial results in "partialResult4AvgInt64".
+// which store the partial results in "partialResult4AvgDecimal".
 //
 // "baseAvgDecimal" is wrapped by:
-// - "avgOriginal4Int64"
-// - "avgPartial4Int64"
-type baseAvgInt64 struct {
+// - "avgOriginal4Decimal"
+// - "avgPartial4Decimal"
+type baseAvgDecimal struct {
 	baseAggFunc
+
+	// frac is the fraction digits of the inferred return type.
+	frac int
 }
 
-type partialResult4AvgInt64 struct {
-	sum   int64
+type partialResult4AvgDecimal struct {
+	sum   types.MyDecimal
 	count int64
 }
 
-func (e *baseAvgInt64) AllocPartialResult() PartialResult {
-	return PartialResult(&partialResult4AvgInt64{})
+func (e *baseAvgDecimal) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4AvgDecimal{})
 }
 
-func (e *baseAvgInt64) ResetPartialResult(pr PartialResult) {
-	p := (*partialResult4AvgInt64)(pr)
-	p.sum = 0
+func (e *baseAvgDecimal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4AvgDecimal)(pr)
+	p.sum = *types.NewDecFromInt(0)
 	p.count = int64(0)
 }
 
-func (e *baseAvgInt64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
-	p := (*partialResult4AvgInt64)(pr)
+func (e *baseAvgDecimal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4AvgDecimal)(pr)
 	if p.count == 0 {
 		chk.AppendNull(e.ordinal)
 		return nil
 	}
-	chk.AppendInt64(e.ordinal, p.sum/p.count)
+	decimalCount := types.NewDecFromInt(p.count)
+	finalResult := new(types.MyDecimal)
+	err := types.DecimalDiv(&p.sum, decimalCount, finalResult, types.DivFracIncr)
+	if err != nil {
+		return err
+	}
+	// Make the decimal be the result of type inferring.
+	err = finalResult.Round(finalResult, e.frac, types.ModeHalfUp)
+	if err != nil {
+		return err
+	}
+	chk.AppendMyDecimal(e.ordinal, finalResult)
 	return nil
 }
 
-type avgOriginal4Int64 struct {
-	baseAvgInt64
+type avgOriginal4Decimal struct {
+	baseAvgDecimal
 }
 
-func (e *avgOriginal4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
-	p := (*partialResult4AvgInt64)(pr)
+func (e *avgOriginal4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4AvgDecimal)(pr)
 	for _, row := range rowsInGroup {
-		input, isNull, err := e.args[0].EvalInt(sctx, row)
+		input, isNull, err := e.args[0].EvalDecimal(sctx, row)
 		if err != nil {
 			return err
 		}
@@ -69,24 +83,26 @@ func (e *avgOriginal4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInG
 			continue
 		}
 
-		newSum, err := types.AddInt64(p.sum, input)
+		newSum := new(types.MyDecimal)
+		err = types.DecimalAdd(&p.sum, input, newSum)
 		if err != nil {
 			return err
 		}
-		p.sum = newSum
+		p.sum = *newSum
 		p.count++
 	}
 	return nil
 }
 
-type avgPartial4Int64 struct {
-	baseAvgInt64
+type avgPartial4Decimal struct {
+	baseAvgDecimal
 }
 
-func (e *avgPartial4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
-	p := (*partialResult4AvgInt64)(pr)
+func (e *avgPartial4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4AvgDecimal)(pr)
+	newSum := new(types.MyDecimal)
 	for _, row := range rowsInGroup {
-		inputSum, isNull, err := e.args[1].EvalInt(sctx, row)
+		inputSum, isNull, err := e.args[1].EvalDecimal(sctx, row)
 		if err != nil {
 			return err
 		}
@@ -102,26 +118,26 @@ func (e *avgPartial4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInGr
 			continue
 		}
 
-		newSum, err := types.AddInt64(p.sum, inputSum)
-		if err != nil {
+		if err = types.DecimalAdd(&p.sum, inputSum, newSum); err != nil {
 			return err
 		}
-		p.sum = newSum
+		p.sum = *newSum
 		p.count += inputCount
 	}
 	return nil
 }
 
-func (e *avgPartial4Int64) MergePartialResult(sctx sessionctx.Context, src PartialResult, dst PartialResult) error {
-	p1, p2 := (*partialResult4AvgInt64)(src), (*partialResult4AvgInt64)(dst)
+func (e *avgPartial4Decimal) MergePartialResult(sctx sessionctx.Context, src PartialResult, dst PartialResult) error {
+	p1, p2 := (*partialResult4AvgDecimal)(src), (*partialResult4AvgDecimal)(dst)
 	if p1.count == 0 {
 		return nil
 	}
-	newSum, err := types.AddInt64(p1.sum, p2.sum)
+	newSum := new(types.MyDecimal)
+	err := types.DecimalAdd(&p1.sum, &p2.sum, newSum)
 	if err != nil {
 		return err
 	}
-	p2.sum = newSum
+	p2.sum = *newSum
 	p2.count += p1.count
 	return nil
 }
@@ -216,3 +232,52 @@ func (e *avgPartial4Float64) MergePartialResult(sctx sessionctx.Context, src Par
 	p2.count += p1.count
 	return nil
 }
+
+// avgDistinct4Decimal and avgDistinct4Float64 implement "AVG(DISTINCT ...)",
+// see "baseDistinct4Decimal" and "baseDistinct4Float64" for the partial
+// results they share with "SUM(DISTINCT ...)".
+type avgDistinct4Decimal struct {
+	baseDistinct4Decimal
+
+	// frac is the fraction digits of the inferred return type.
+	frac int
+}
+
+func (e *avgDistinct4Decimal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4DistinctDecimal)(pr)
+	if len(p.valSet) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	sum, err := p.sum()
+	if err != nil {
+		return err
+	}
+	decimalCount := types.NewDecFromInt(int64(len(p.valSet)))
+	finalResult := new(types.MyDecimal)
+	err = types.DecimalDiv(sum, decimalCount, finalResult, types.DivFracIncr)
+	if err != nil {
+		return err
+	}
+	// Make the decimal be the result of type inferring.
+	err = finalResult.Round(finalResult, e.frac, types.ModeHalfUp)
+	if err != nil {
+		return err
+	}
+	chk.AppendMyDecimal(e.ordinal, finalResult)
+	return nil
+}
+
+type avgDistinct4Float64 struct {
+	baseDistinct4Float64
+}
+
+func (e *avgDistinct4Float64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4DistinctFloat64)(pr)
+	if len(p.valSet) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, p.sum()/float64(len(p.valSet)))
+	return nil
+}
@@ -70,6 +70,28 @@ func (e *countOriginal4Real) UpdatePartialResult(sctx sessionctx.Context, rowsIn
 	return nil
 }
 
+type countOriginal4Decimal struct {
+	baseCount
+}
+
+func (e *countOriginal4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4Count)(pr)
+
+	for _, row := range rowsInGroup {
+		_, isNull, err := e.args[0].EvalDecimal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+
+		*p++
+	}
+
+	return nil
+}
+
 type countOriginal4String struct {
 	baseCount
 }
@@ -117,3 +139,54 @@ func (*countPartial) MergePartialResult(sctx sessionctx.Context, src, dst Partia
 	*p2 += *p1
 	return nil
 }
+
+type partialResult4CountWithDistinct struct {
+	valSet map[string]struct{}
+}
+
+// countOriginalWithDistinct implements "COUNT(DISTINCT ...)". Its partial
+// result keeps the encoded values seen so far rather than a counter, so
+// that the partial results of different workers can be merged.
+type countOriginalWithDistinct struct {
+	baseAggFunc
+}
+
+func (e *countOriginalWithDistinct) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4CountWithDistinct{valSet: make(map[string]struct{})})
+}
+
+func (e *countOriginalWithDistinct) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4CountWithDistinct)(pr)
+	p.valSet = make(map[string]struct{})
+}
+
+func (e *countOriginalWithDistinct) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4CountWithDistinct)(pr)
+	chk.AppendInt64(e.ordinal, int64(len(p.valSet)))
+	return nil
+}
+
+func (e *countOriginalWithDistinct) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4CountWithDistinct)(pr)
+	var buf []byte
+	for _, row := range rowsInGroup {
+		key, hasNull, err := evalDistinctKey(sctx, e.args, row, buf[:0])
+		if err != nil {
+			return err
+		}
+		buf = key
+		if hasNull {
+			continue
+		}
+		p.valSet[string(key)] = struct{}{}
+	}
+	return nil
+}
+
+func (e *countOriginalWithDistinct) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4CountWithDistinct)(src), (*partialResult4CountWithDistinct)(dst)
+	for key := range p1.valSet {
+		p2.valSet[key] = struct{}{}
+	}
+	return nil
+}
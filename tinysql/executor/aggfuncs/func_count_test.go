@@ -24,6 +24,12 @@ func (s *testSuite) TestMergePartialResult4Count(c *C) {
 	s.testMergePartialResult(c, tester)
 }
 
+func (s *testSuite) TestMergePartialResult4CountDistinct(c *C) {
+	tester := buildAggTester(ast.AggFuncCount, mysql.TypeLonglong, 5, 5, 3, 5)
+	tester.distinct = true
+	s.testMergePartialResult(c, tester)
+}
+
 func (s *testSuite) TestCount(c *C) {
 	tests := []aggTest{
 		buildAggTester(ast.AggFuncCount, mysql.TypeLonglong, 5, 0, 5),
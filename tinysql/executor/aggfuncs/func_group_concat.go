@@ -0,0 +1,294 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// All the following group_concat function implementations concatenate the
+// values of all but the last argument, the last one is the separator which
+// is evaluated when the function is built. The result is truncated to
+// "group_concat_max_len" bytes and a warning is appended if it happens.
+//
+// "baseGroupConcat4String" is wrapped by:
+// - "groupConcat"
+// - "groupConcatOrderOrDistinct"
+type baseGroupConcat4String struct {
+	baseAggFunc
+
+	byItems []*util.ByItems
+	sep     string
+	maxLen  uint64
+}
+
+// valueArgs returns the arguments whose values are concatenated.
+func (e *baseGroupConcat4String) valueArgs() []expression.Expression {
+	return e.args[:len(e.args)-1]
+}
+
+// evalValue concatenates the values of the value arguments on row. isNull is
+// true if any of them is NULL, in which case the row is ignored.
+func (e *baseGroupConcat4String) evalValue(sctx sessionctx.Context, row chunk.Row, buf *bytes.Buffer) (isNull bool, err error) {
+	for _, arg := range e.valueArgs() {
+		v, isNull, err := arg.EvalString(sctx, row)
+		if err != nil {
+			return false, err
+		}
+		if isNull {
+			return true, nil
+		}
+		buf.WriteString(v)
+	}
+	return false, nil
+}
+
+// truncate cuts buffer down to maxLen bytes and reports whether it did.
+func (e *baseGroupConcat4String) truncate(buffer *bytes.Buffer) bool {
+	if uint64(buffer.Len()) <= e.maxLen {
+		return false
+	}
+	buffer.Truncate(int(e.maxLen))
+	return true
+}
+
+func (e *baseGroupConcat4String) appendTruncatedWarning(sctx sessionctx.Context) {
+	sctx.GetSessionVars().StmtCtx.AppendWarning(expression.ErrCutValueGroupConcat.GenWithStackByArgs(e.args[0].String()))
+}
+
+type partialResult4GroupConcat struct {
+	// buffer is nil if no value has been concatenated yet.
+	buffer    *bytes.Buffer
+	truncated bool
+}
+
+// groupConcat implements "GROUP_CONCAT" without DISTINCT and ORDER BY. It
+// also consumes the partial results of other group_concat functions, which
+// are simply concatenated again.
+type groupConcat struct {
+	baseGroupConcat4String
+}
+
+func (e *groupConcat) AllocPartialResult() PartialResult {
+	return PartialResult(new(partialResult4GroupConcat))
+}
+
+func (e *groupConcat) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4GroupConcat)(pr)
+	p.buffer = nil
+	p.truncated = false
+}
+
+func (e *groupConcat) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4GroupConcat)(pr)
+	if p.truncated {
+		return nil
+	}
+	valBuf := &bytes.Buffer{}
+	for _, row := range rowsInGroup {
+		valBuf.Reset()
+		isNull, err := e.evalValue(sctx, row, valBuf)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		if p.buffer == nil {
+			p.buffer = &bytes.Buffer{}
+		} else {
+			p.buffer.WriteString(e.sep)
+		}
+		p.buffer.Write(valBuf.Bytes())
+		if e.truncate(p.buffer) {
+			p.truncated = true
+			return nil
+		}
+	}
+	return nil
+}
+
+func (e *groupConcat) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4GroupConcat)(src), (*partialResult4GroupConcat)(dst)
+	if p1.buffer == nil {
+		return nil
+	}
+	if p2.buffer == nil {
+		p2.buffer = p1.buffer
+		p2.truncated = p1.truncated
+		return nil
+	}
+	p2.truncated = p2.truncated || p1.truncated
+	if p2.truncated {
+		return nil
+	}
+	p2.buffer.WriteString(e.sep)
+	p2.buffer.Write(p1.buffer.Bytes())
+	p2.truncated = e.truncate(p2.buffer)
+	return nil
+}
+
+func (e *groupConcat) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4GroupConcat)(pr)
+	if p.buffer == nil {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	if p.truncated {
+		e.appendTruncatedWarning(sctx)
+	}
+	chk.AppendString(e.ordinal, p.buffer.String())
+	return nil
+}
+
+type groupConcatRow struct {
+	value string
+	// key is the encoded arguments of the row, it is only set with DISTINCT.
+	key     string
+	byItems []types.Datum
+}
+
+type partialResult4GroupConcatOrderOrDistinct struct {
+	rows []groupConcatRow
+	// valSet holds the keys of the rows, it is only used with DISTINCT.
+	valSet map[string]struct{}
+}
+
+// groupConcatOrderOrDistinct implements "GROUP_CONCAT" with DISTINCT and/or
+// ORDER BY. The rows of a group are kept until the final result is
+// appended, where they are sorted, concatenated and truncated.
+type groupConcatOrderOrDistinct struct {
+	baseGroupConcat4String
+
+	distinct bool
+}
+
+func (e *groupConcatOrderOrDistinct) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4GroupConcatOrderOrDistinct{valSet: make(map[string]struct{})})
+}
+
+func (e *groupConcatOrderOrDistinct) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4GroupConcatOrderOrDistinct)(pr)
+	p.rows = nil
+	p.valSet = make(map[string]struct{})
+}
+
+func (e *groupConcatOrderOrDistinct) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4GroupConcatOrderOrDistinct)(pr)
+	valBuf := &bytes.Buffer{}
+	var keyBuf []byte
+	for _, row := range rowsInGroup {
+		var key string
+		if e.distinct {
+			encoded, hasNull, err := evalDistinctKey(sctx, e.valueArgs(), row, keyBuf[:0])
+			if err != nil {
+				return err
+			}
+			keyBuf = encoded
+			if hasNull {
+				continue
+			}
+			key = string(encoded)
+			if _, ok := p.valSet[key]; ok {
+				continue
+			}
+			p.valSet[key] = struct{}{}
+		}
+		valBuf.Reset()
+		isNull, err := e.evalValue(sctx, row, valBuf)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		r := groupConcatRow{value: valBuf.String(), key: key}
+		for _, byItem := range e.byItems {
+			d, err := byItem.Expr.Eval(row)
+			if err != nil {
+				return err
+			}
+			r.byItems = append(r.byItems, *d.Copy())
+		}
+		p.rows = append(p.rows, r)
+	}
+	return nil
+}
+
+func (e *groupConcatOrderOrDistinct) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4GroupConcatOrderOrDistinct)(src), (*partialResult4GroupConcatOrderOrDistinct)(dst)
+	if !e.distinct {
+		p2.rows = append(p2.rows, p1.rows...)
+		return nil
+	}
+	for _, r := range p1.rows {
+		if _, ok := p2.valSet[r.key]; ok {
+			continue
+		}
+		p2.valSet[r.key] = struct{}{}
+		p2.rows = append(p2.rows, r)
+	}
+	return nil
+}
+
+func (e *groupConcatOrderOrDistinct) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4GroupConcatOrderOrDistinct)(pr)
+	if len(p.rows) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	if len(e.byItems) > 0 {
+		sc := sctx.GetSessionVars().StmtCtx
+		var sortErr error
+		sort.SliceStable(p.rows, func(i, j int) bool {
+			for k, byItem := range e.byItems {
+				cmp, err := p.rows[i].byItems[k].CompareDatum(sc, &p.rows[j].byItems[k])
+				if err != nil {
+					sortErr = err
+					return false
+				}
+				if byItem.Desc {
+					cmp = -cmp
+				}
+				if cmp != 0 {
+					return cmp < 0
+				}
+			}
+			return false
+		})
+		if sortErr != nil {
+			return sortErr
+		}
+	}
+	buffer := &bytes.Buffer{}
+	for i, r := range p.rows {
+		if i > 0 {
+			buffer.WriteString(e.sep)
+		}
+		buffer.WriteString(r.value)
+		if e.truncate(buffer) {
+			e.appendTruncatedWarning(sctx)
+			break
+		}
+	}
+	chk.AppendString(e.ordinal, buffer.String())
+	return nil
+}
@@ -43,6 +43,11 @@ type partialResult4MaxMinFloat64 struct {
 	isNull bool
 }
 
+type partialResult4MaxMinDecimal struct {
+	val    types.MyDecimal
+	isNull bool
+}
+
 type partialResult4MaxMinString struct {
 	val    string
 	isNull bool
@@ -309,6 +314,70 @@ func (e *maxMin4Float64) MergePartialResult(sctx sessionctx.Context, src, dst Pa
 	return nil
 }
 
+type maxMin4Decimal struct {
+	baseMaxMinAggFunc
+}
+
+func (e *maxMin4Decimal) AllocPartialResult() PartialResult {
+	p := new(partialResult4MaxMinDecimal)
+	p.isNull = true
+	return PartialResult(p)
+}
+
+func (e *maxMin4Decimal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4MaxMinDecimal)(pr)
+	p.isNull = true
+}
+
+func (e *maxMin4Decimal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4MaxMinDecimal)(pr)
+	if p.isNull {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendMyDecimal(e.ordinal, &p.val)
+	return nil
+}
+
+func (e *maxMin4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4MaxMinDecimal)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalDecimal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		if p.isNull {
+			p.val = *input
+			p.isNull = false
+			continue
+		}
+		cmp := input.Compare(&p.val)
+		if e.isMax && cmp > 0 || !e.isMax && cmp < 0 {
+			p.val = *input
+		}
+	}
+	return nil
+}
+
+func (e *maxMin4Decimal) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4MaxMinDecimal)(src), (*partialResult4MaxMinDecimal)(dst)
+	if p1.isNull {
+		return nil
+	}
+	if p2.isNull {
+		*p2 = *p1
+		return nil
+	}
+	cmp := (&p1.val).Compare(&p2.val)
+	if e.isMax && cmp > 0 || !e.isMax && cmp < 0 {
+		p2.val, p2.isNull = p1.val, false
+	}
+	return nil
+}
+
 type maxMin4String struct {
 	baseMaxMinAggFunc
 }
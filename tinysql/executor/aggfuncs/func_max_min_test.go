@@ -28,12 +28,14 @@ func (s *testSuite) TestMergePartialResult4MaxMin(c *C) {
 		buildAggTesterWithFieldType(ast.AggFuncMax, unsignedType, 5, 4, 4, 4),
 		buildAggTester(ast.AggFuncMax, mysql.TypeFloat, 5, 4.0, 4.0, 4.0),
 		buildAggTester(ast.AggFuncMax, mysql.TypeDouble, 5, 4.0, 4.0, 4.0),
+		buildAggTester(ast.AggFuncMax, mysql.TypeNewDecimal, 5, types.NewDecFromInt(4), types.NewDecFromInt(4), types.NewDecFromInt(4)),
 		buildAggTester(ast.AggFuncMax, mysql.TypeString, 5, "4", "4", "4"),
 
 		buildAggTester(ast.AggFuncMin, mysql.TypeLonglong, 5, 0, 2, 0),
 		buildAggTesterWithFieldType(ast.AggFuncMin, unsignedType, 5, 0, 2, 0),
 		buildAggTester(ast.AggFuncMin, mysql.TypeFloat, 5, 0.0, 2.0, 0.0),
 		buildAggTester(ast.AggFuncMin, mysql.TypeDouble, 5, 0.0, 2.0, 0.0),
+		buildAggTester(ast.AggFuncMin, mysql.TypeNewDecimal, 5, types.NewDecFromInt(0), types.NewDecFromInt(2), types.NewDecFromInt(0)),
 		buildAggTester(ast.AggFuncMin, mysql.TypeString, 5, "0", "2", "0"),
 	}
 	for _, test := range tests {
@@ -49,12 +51,14 @@ func (s *testSuite) TestMaxMin(c *C) {
 		buildAggTesterWithFieldType(ast.AggFuncMax, unsignedType, 5, nil, 4),
 		buildAggTester(ast.AggFuncMax, mysql.TypeFloat, 5, nil, 4.0),
 		buildAggTester(ast.AggFuncMax, mysql.TypeDouble, 5, nil, 4.0),
+		buildAggTester(ast.AggFuncMax, mysql.TypeNewDecimal, 5, nil, types.NewDecFromInt(4)),
 		buildAggTester(ast.AggFuncMax, mysql.TypeString, 5, nil, "4", "4"),
 
 		buildAggTester(ast.AggFuncMin, mysql.TypeLonglong, 5, nil, 0),
 		buildAggTesterWithFieldType(ast.AggFuncMin, unsignedType, 5, nil, 0),
 		buildAggTester(ast.AggFuncMin, mysql.TypeFloat, 5, nil, 0.0),
 		buildAggTester(ast.AggFuncMin, mysql.TypeDouble, 5, nil, 0.0),
+		buildAggTester(ast.AggFuncMin, mysql.TypeNewDecimal, 5, nil, types.NewDecFromInt(0)),
 		buildAggTester(ast.AggFuncMin, mysql.TypeString, 5, nil, "0"),
 	}
 	for _, test := range tests {
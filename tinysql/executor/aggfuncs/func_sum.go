@@ -14,6 +14,8 @@
 package aggfuncs
 
 import (
+	"sort"
+
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
@@ -24,8 +26,8 @@ type partialResult4SumFloat64 struct {
 	isNull bool
 }
 
-type partialResult4Int64 struct {
-	val    int64
+type partialResult4SumDecimal struct {
+	val    types.MyDecimal
 	isNull bool
 }
 
@@ -89,35 +91,35 @@ func (e *sum4Float64) MergePartialResult(sctx sessionctx.Context, src, dst Parti
 	return nil
 }
 
-type sum4Int64 struct {
+type sum4Decimal struct {
 	baseSumAggFunc
 }
 
-func (e *sum4Int64) AllocPartialResult() PartialResult {
-	p := new(partialResult4Int64)
+func (e *sum4Decimal) AllocPartialResult() PartialResult {
+	p := new(partialResult4SumDecimal)
 	p.isNull = true
 	return PartialResult(p)
 }
 
-func (e *sum4Int64) ResetPartialResult(pr PartialResult) {
-	p := (*partialResult4Int64)(pr)
+func (e *sum4Decimal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SumDecimal)(pr)
 	p.isNull = true
 }
 
-func (e *sum4Int64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
-	p := (*partialResult4Int64)(pr)
+func (e *sum4Decimal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SumDecimal)(pr)
 	if p.isNull {
 		chk.AppendNull(e.ordinal)
 		return nil
 	}
-	chk.AppendInt64(e.ordinal, p.val)
+	chk.AppendMyDecimal(e.ordinal, &p.val)
 	return nil
 }
 
-func (e *sum4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
-	p := (*partialResult4Int64)(pr)
+func (e *sum4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4SumDecimal)(pr)
 	for _, row := range rowsInGroup {
-		input, isNull, err := e.args[0].EvalInt(sctx, row)
+		input, isNull, err := e.args[0].EvalDecimal(sctx, row)
 		if err != nil {
 			return err
 		}
@@ -125,30 +127,191 @@ func (e *sum4Int64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []c
 			continue
 		}
 		if p.isNull {
-			p.val = input
+			p.val = *input
 			p.isNull = false
 			continue
 		}
 
-		newSum, err := types.AddInt64(p.val, input)
+		newSum := new(types.MyDecimal)
+		err = types.DecimalAdd(&p.val, input, newSum)
 		if err != nil {
 			return err
 		}
-		p.val = newSum
+		p.val = *newSum
 	}
 	return nil
 }
 
-func (e *sum4Int64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
-	p1, p2 := (*partialResult4Int64)(src), (*partialResult4Int64)(dst)
+func (e *sum4Decimal) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4SumDecimal)(src), (*partialResult4SumDecimal)(dst)
 	if p1.isNull {
 		return nil
 	}
-	newSum, err := types.AddInt64(p1.val, p2.val)
+	newSum := new(types.MyDecimal)
+	err := types.DecimalAdd(&p1.val, &p2.val, newSum)
 	if err != nil {
 		return err
 	}
-	p2.val = newSum
+	p2.val = *newSum
 	p2.isNull = false
 	return nil
 }
+
+// The DISTINCT versions of "SUM" and "AVG" keep the set of distinct input
+// values as their partial results and only sum them up when appending the
+// final result, so that the partial results of different workers can be
+// merged without counting a value twice.
+//
+// "baseDistinct4Float64" is wrapped by:
+// - "sumDistinct4Float64"
+// - "avgDistinct4Float64"
+//
+// "baseDistinct4Decimal" is wrapped by:
+// - "sumDistinct4Decimal"
+// - "avgDistinct4Decimal"
+type partialResult4DistinctFloat64 struct {
+	valSet map[float64]struct{}
+}
+
+type baseDistinct4Float64 struct {
+	baseAggFunc
+}
+
+func (e *baseDistinct4Float64) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4DistinctFloat64{valSet: make(map[float64]struct{})})
+}
+
+func (e *baseDistinct4Float64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4DistinctFloat64)(pr)
+	p.valSet = make(map[float64]struct{})
+}
+
+func (e *baseDistinct4Float64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4DistinctFloat64)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		p.valSet[input] = struct{}{}
+	}
+	return nil
+}
+
+func (e *baseDistinct4Float64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4DistinctFloat64)(src), (*partialResult4DistinctFloat64)(dst)
+	for val := range p1.valSet {
+		p2.valSet[val] = struct{}{}
+	}
+	return nil
+}
+
+// sum adds up the distinct values in ascending order, so that the result
+// does not depend on the iteration order of the set.
+func (p *partialResult4DistinctFloat64) sum() float64 {
+	vals := make([]float64, 0, len(p.valSet))
+	for val := range p.valSet {
+		vals = append(vals, val)
+	}
+	sort.Float64s(vals)
+	sum := float64(0)
+	for _, val := range vals {
+		sum += val
+	}
+	return sum
+}
+
+type sumDistinct4Float64 struct {
+	baseDistinct4Float64
+}
+
+func (e *sumDistinct4Float64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4DistinctFloat64)(pr)
+	if len(p.valSet) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, p.sum())
+	return nil
+}
+
+type partialResult4DistinctDecimal struct {
+	valSet map[string]*types.MyDecimal
+}
+
+type baseDistinct4Decimal struct {
+	baseAggFunc
+}
+
+func (e *baseDistinct4Decimal) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4DistinctDecimal{valSet: make(map[string]*types.MyDecimal)})
+}
+
+func (e *baseDistinct4Decimal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4DistinctDecimal)(pr)
+	p.valSet = make(map[string]*types.MyDecimal)
+}
+
+func (e *baseDistinct4Decimal) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4DistinctDecimal)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalDecimal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		hash, err := input.ToHashKey()
+		if err != nil {
+			return err
+		}
+		if _, ok := p.valSet[string(hash)]; ok {
+			continue
+		}
+		val := *input
+		p.valSet[string(hash)] = &val
+	}
+	return nil
+}
+
+func (e *baseDistinct4Decimal) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4DistinctDecimal)(src), (*partialResult4DistinctDecimal)(dst)
+	for hash, val := range p1.valSet {
+		p2.valSet[hash] = val
+	}
+	return nil
+}
+
+func (p *partialResult4DistinctDecimal) sum() (*types.MyDecimal, error) {
+	sum := types.NewDecFromInt(0)
+	for _, val := range p.valSet {
+		newSum := new(types.MyDecimal)
+		if err := types.DecimalAdd(sum, val, newSum); err != nil {
+			return nil, err
+		}
+		sum = newSum
+	}
+	return sum, nil
+}
+
+type sumDistinct4Decimal struct {
+	baseDistinct4Decimal
+}
+
+func (e *sumDistinct4Decimal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4DistinctDecimal)(pr)
+	if len(p.valSet) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	sum, err := p.sum()
+	if err != nil {
+		return err
+	}
+	chk.AppendMyDecimal(e.ordinal, sum)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// All the following variance function implementations return the float64
+// result, which store the partial results in "partialResult4Variance".
+// "VAR_POP", "VAR_SAMP", "STDDEV_POP" and "STDDEV_SAMP" only differ in how
+// the final result is derived from it.
+//
+// "baseVariance4Float64" is wrapped by:
+// - "varianceOriginal4Float64"
+// - "variancePartial4Float64"
+type baseVariance4Float64 struct {
+	baseAggFunc
+
+	// name is the name of the aggregate function.
+	name string
+}
+
+type partialResult4Variance struct {
+	count int64
+	sum   float64
+	// variance is the sum of squared differences from the mean.
+	variance float64
+}
+
+func (e *baseVariance4Float64) AllocPartialResult() PartialResult {
+	return PartialResult(&partialResult4Variance{})
+}
+
+func (e *baseVariance4Float64) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4Variance)(pr)
+	p.count = 0
+	p.sum = 0
+	p.variance = 0
+}
+
+func (e *baseVariance4Float64) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4Variance)(pr)
+	res, ok := aggregation.FinalizeVariance(e.name, p.count, p.variance)
+	if !ok {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendFloat64(e.ordinal, res)
+	return nil
+}
+
+func (e *baseVariance4Float64) MergePartialResult(sctx sessionctx.Context, src, dst PartialResult) error {
+	p1, p2 := (*partialResult4Variance)(src), (*partialResult4Variance)(dst)
+	mergeVariance(p1.count, p1.sum, p1.variance, p2)
+	return nil
+}
+
+// mergeVariance merges the partial result of another group into p.
+func mergeVariance(count int64, sum float64, variance float64, p *partialResult4Variance) {
+	if count == 0 {
+		return
+	}
+	if p.count == 0 {
+		p.count, p.sum, p.variance = count, sum, variance
+		return
+	}
+	p.variance = aggregation.CalculateVarianceMerge(count, p.count, sum, p.sum, variance, p.variance)
+	p.count += count
+	p.sum += sum
+}
+
+type varianceOriginal4Float64 struct {
+	baseVariance4Float64
+}
+
+func (e *varianceOriginal4Float64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4Variance)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		p.count++
+		p.sum += input
+		if p.count > 1 {
+			p.variance = aggregation.CalculateVarianceIntermediate(p.count, p.sum, input, p.variance)
+		}
+	}
+	return nil
+}
+
+// variancePartial4Float64 consumes the partial results of other variance
+// functions, which are passed as the count, sum and variance columns.
+type variancePartial4Float64 struct {
+	baseVariance4Float64
+}
+
+func (e *variancePartial4Float64) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) error {
+	p := (*partialResult4Variance)(pr)
+	for _, row := range rowsInGroup {
+		inputCount, isNull, err := e.args[0].EvalInt(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull || inputCount == 0 {
+			continue
+		}
+		inputSum, isNull, err := e.args[1].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		inputVariance, isNull, err := e.args[2].EvalReal(sctx, row)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		mergeVariance(inputCount, inputSum, inputVariance, p)
+	}
+	return nil
+}
@@ -14,6 +14,9 @@
 package executor_test
 
 import (
+	"fmt"
+	"strings"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/util/testkit"
 	"github.com/pingcap/tidb/util/testutil"
@@ -68,6 +71,88 @@ func (s *testSuiteAgg) TestAggPushDown(c *C) {
 	tk.MustQuery("select count(a) from t where b>0 group by a, b order by a limit 1;").Check(testkit.Rows("3"))
 }
 
+func (s *testSuiteAgg) TestDistinctAndMoreAggFuncs(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c varchar(10))")
+	tk.MustQuery("select count(distinct b), sum(distinct b), avg(distinct b), group_concat(c), bit_and(b), bit_or(b), bit_xor(b), var_pop(b) from t").
+		Check(testkit.Rows("0 <nil> <nil> <nil> 18446744073709551615 0 0 <nil>"))
+	tk.MustExec("insert into t values(1, 1, 'x'), (1, 1, 'y'), (1, 2, 'z'), (2, 3, 'x'), (2, NULL, NULL), (2, 3, 'y')")
+
+	tk.MustQuery("select count(distinct b), sum(distinct b), avg(distinct b) from t").Check(testkit.Rows("3 6 2.0000"))
+	tk.MustQuery("select a, count(distinct b), sum(distinct b), avg(distinct b) from t group by a order by a").
+		Check(testkit.Rows("1 2 3 1.5000", "2 1 3 3.0000"))
+	tk.MustQuery("select count(distinct a, b), count(distinct c) from t").Check(testkit.Rows("3 3"))
+
+	tk.MustQuery("select group_concat(c order by c desc) from t").Check(testkit.Rows("z,y,y,x,x"))
+	tk.MustQuery("select group_concat(distinct c order by c separator '|') from t").Check(testkit.Rows("x|y|z"))
+	tk.MustQuery("select a, group_concat(b, c order by b, c separator ' ') from t group by a order by a").
+		Check(testkit.Rows("1 1x 1y 2z", "2 3x 3y"))
+	tk.MustQuery("select a, length(group_concat(c)) from t group by a order by a").Check(testkit.Rows("1 5", "2 3"))
+	tk.MustExec("set @@group_concat_max_len = 4")
+	tk.MustQuery("select group_concat(c order by c) from t").Check(testkit.Rows("x,x,"))
+	tk.MustQuery("select @@warning_count").Check(testkit.Rows("1"))
+	tk.MustQuery("select length(group_concat(c)) from t").Check(testkit.Rows("4"))
+	tk.MustExec("set @@group_concat_max_len = 1024")
+
+	tk.MustQuery("select bit_and(b), bit_or(b), bit_xor(b) from t").Check(testkit.Rows("0 3 2"))
+	tk.MustQuery("select a, bit_and(b), bit_or(b), bit_xor(b) from t group by a order by a").
+		Check(testkit.Rows("1 0 3 2", "2 3 3 0"))
+
+	tk.MustQuery("select var_pop(b), var_samp(b), stddev_pop(b), stddev_samp(b) from t where a = 2").
+		Check(testkit.Rows("0 0 0 0"))
+	tk.MustQuery("select var_pop(b), var_samp(b), std(b), stddev(b), variance(b) from t").
+		Check(testkit.Rows("0.8 1 0.8944271909999159 0.8944271909999159 0.8"))
+	tk.MustQuery("select a, var_samp(b) from t group by a order by a").Check(testkit.Rows("1 0.3333333333333333", "2 0"))
+	tk.MustQuery("select var_samp(b) from t where b = 2").Check(testkit.Rows("<nil>"))
+
+	// The coprocessor computes the partial results, TiDB merges them in the final aggregation.
+	tk.MustQuery("explain select /*+ AGG_TO_COP() */ a, group_concat(c), bit_or(b), var_pop(b) from t group by a").Check(testkit.Rows(
+		"Projection_4 8000.00 root test.t.a, Column#5, Column#6, Column#7",
+		`└─HashAgg_9 8000.00 root group by:test.t.a, funcs:group_concat(Column#8 separator ",")->Column#5, funcs:bit_or(Column#9)->Column#6, funcs:var_pop(Column#10, Column#11, Column#12)->Column#7, funcs:firstrow(test.t.a)->test.t.a`,
+		"  └─TableReader_10 8000.00 root data:HashAgg_5",
+		`    └─HashAgg_5 8000.00 cop group by:test.t.a, funcs:group_concat(test.t.c separator ",")->Column#8, funcs:bit_or(test.t.b)->Column#9, funcs:var_pop(cast(test.t.b))->Column#10`,
+		"      └─TableScan_8 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo"))
+	tk.MustQuery("select /*+ AGG_TO_COP() */ a, group_concat(c), bit_and(b), bit_or(b), bit_xor(b), var_pop(b), var_samp(b), stddev_pop(b), stddev_samp(b) from t group by a order by a").
+		Check(testkit.Rows("1 x,y,z 0 3 2 0.2222222222222222 0.3333333333333333 0.4714045207910317 0.5773502691896257", "2 x,y 3 3 0 0 0 0 0"))
+	tk.MustQuery("select /*+ AGG_TO_COP() */ group_concat(c), bit_or(b), var_pop(b), stddev_samp(b) from t").Check(testkit.Rows("x,y,z,x,y 3 0.8 1"))
+}
+
+func (s *testSuiteAgg) TestStreamAgg(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b int, c int, key idx_bc(b, c))")
+	tk.MustQuery("select count(c), sum(c) from t use index(idx_bc)").Check(testkit.Rows("0 <nil>"))
+	// Make the groups span several chunks.
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values(%d, %d, %d)", i, i/40, i%5))
+	}
+
+	hasStreamAgg := func(sql string) bool {
+		for _, row := range tk.MustQuery("explain " + sql).Rows() {
+			if strings.Contains(row[0].(string), "StreamAgg") {
+				return true
+			}
+		}
+		return false
+	}
+	sql := "select b, count(c), sum(c), max(c), avg(c), round(var_pop(c), 4) from t use index(idx_bc) group by b order by b"
+	c.Assert(hasStreamAgg(sql), IsTrue)
+	tk.MustQuery(sql).Check(testkit.Rows("0 40 80 4 2.0000 2", "1 40 80 4 2.0000 2", "2 20 40 4 2.0000 2"))
+	sql = "select b, count(c) from t use index(idx_bc) group by b order by b desc"
+	c.Assert(hasStreamAgg(sql), IsTrue)
+	tk.MustQuery(sql).Check(testkit.Rows("2 20", "1 40", "0 40"))
+	sql = "select b, count(distinct c), group_concat(distinct c order by c) from t use index(idx_bc) group by b order by b"
+	c.Assert(hasStreamAgg(sql), IsTrue)
+	tk.MustQuery(sql).Check(testkit.Rows("0 5 0,1,2,3,4", "1 5 0,1,2,3,4", "2 5 0,1,2,3,4"))
+	sql = "select b, c, count(*) from t use index(idx_bc) where b = 2 group by b, c order by b, c"
+	c.Assert(hasStreamAgg(sql), IsTrue)
+	tk.MustQuery(sql).Check(testkit.Rows("2 0 4", "2 1 4", "2 2 4", "2 3 4", "2 4 4"))
+	tk.MustQuery("select count(c), sum(c) from t use index(idx_bc) where b > 100").Check(testkit.Rows("0 <nil>"))
+	tk.MustQuery("select b from t use index(idx_bc) where b > 100 group by b").Check(testkit.Rows())
+}
+
 func (s *testSuiteAgg) TestAggEliminator(c *C) {
 	tk := testkit.NewTestKitWithInit(c, s.store)
 
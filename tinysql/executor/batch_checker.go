@@ -17,6 +17,7 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
@@ -24,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 )
 
 type keyValue struct {
@@ -86,8 +88,18 @@ func getKeysNeedCheck(ctx context.Context, sctx sessionctx.Context, t table.Tabl
 	}
 
 	var err error
+	pt, isPartition := t.(table.PartitionedTable)
 	for _, row := range rows {
-		toBeCheckRows, err = getKeysNeedCheckOneRow(sctx, t, row, nUnique, handleCol, toBeCheckRows)
+		// The keys of a row of a partitioned table are encoded with the
+		// physical table ID of the partition the row belongs to.
+		tbl := t
+		if isPartition {
+			tbl, err = pt.GetPartitionByRow(sctx, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		toBeCheckRows, err = getKeysNeedCheckOneRow(sctx, tbl, row, nUnique, handleCol, toBeCheckRows)
 		if err != nil {
 			return nil, err
 		}
@@ -158,7 +170,8 @@ func getKeysNeedCheckOneRow(ctx sessionctx.Context, t table.Table, row []types.D
 
 // getOldRow gets the table record row from storage for batch check.
 // t could be a normal table or a partition, but it must not be a PartitionedTable.
-func getOldRow(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, t table.Table, handle int64) ([]types.Datum, error) {
+func getOldRow(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, t table.Table, handle int64,
+	genExprs []expression.Expression) ([]types.Datum, error) {
 	oldValue, err := txn.Get(ctx, t.RecordKey(handle))
 	if err != nil {
 		return nil, err
@@ -169,6 +182,7 @@ func getOldRow(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction,
 	if err != nil {
 		return nil, err
 	}
+	gIdx := 0
 	for _, col := range cols {
 		if col.State != model.StatePublic && oldRow[col.Offset].IsNull() {
 			_, found := oldRowMap[col.ID]
@@ -179,6 +193,20 @@ func getOldRow(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction,
 				}
 			}
 		}
+		if col.IsGenerated() && col.State == model.StatePublic {
+			// only the virtual column needs fill back.
+			if !col.GeneratedStored {
+				val, err := genExprs[gIdx].Eval(chunk.MutRowFromDatums(oldRow).ToRow())
+				if err != nil {
+					return nil, err
+				}
+				oldRow[col.Offset], err = table.CastValue(sctx, val, col.ToInfo())
+				if err != nil {
+					return nil, err
+				}
+			}
+			gIdx++
+		}
 	}
 	return oldRow, nil
 }
@@ -0,0 +1,82 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/bindinfo"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/parser/ast"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// SQLBindExec represents a bind executor.
+type SQLBindExec struct {
+	baseExecutor
+
+	sqlBind      plannercore.SQLBindOpType
+	normdOrigSQL string
+	bindSQL      string
+	charset      string
+	collation    string
+	isGlobal     bool
+	bindAst      ast.StmtNode
+	db           string
+}
+
+// Next implements the Executor Next interface.
+func (e *SQLBindExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	switch e.sqlBind {
+	case plannercore.OpSQLBindCreate:
+		return e.createSQLBind()
+	case plannercore.OpSQLBindDrop:
+		return e.dropSQLBind()
+	default:
+		return errors.Errorf("unsupported SQL bind operation: %v", e.sqlBind)
+	}
+}
+
+func (e *SQLBindExec) dropSQLBind() error {
+	record := &bindinfo.BindRecord{
+		OriginalSQL: e.normdOrigSQL,
+		Db:          e.db,
+	}
+	if !e.isGlobal {
+		handle := e.ctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
+		handle.DropBindRecord(record)
+		return nil
+	}
+	return domain.GetDomain(e.ctx).BindHandle().DropBindRecord(record)
+}
+
+func (e *SQLBindExec) createSQLBind() error {
+	record := &bindinfo.BindRecord{
+		OriginalSQL: e.normdOrigSQL,
+		BindSQL:     e.bindSQL,
+		Db:          e.db,
+		Charset:     e.charset,
+		Collation:   e.collation,
+		HintsSet:    bindinfo.CollectHint(e.bindAst),
+	}
+	if !e.isGlobal {
+		handle := e.ctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
+		handle.AddBindRecord(record)
+		return nil
+	}
+	return domain.GetDomain(e.ctx).BindHandle().AddBindRecord(record)
+}
@@ -16,8 +16,10 @@ package executor_test
 import (
 	"fmt"
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/ddl"
 	ddlutil "github.com/pingcap/tidb/ddl/util"
 	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
@@ -263,3 +265,76 @@ func (s *testSuite6) TestSetDDLErrorCountLimit(c *C) {
 	res := tk.MustQuery("select @@global.tidb_ddl_error_count_limit")
 	res.Check(testkit.Rows("100"))
 }
+
+func (s *testSuite6) TestCreateDropView(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t_view (a int primary key, b int, c varchar(10))")
+	tk.MustExec("insert into t_view values (1, 10, 'x'), (2, 20, 'y'), (3, 30, 'z')")
+	tk.MustExec("create view v1 as select a, b + 1, c as d from t_view where a > 1")
+	tk.MustQuery("select * from v1").Sort().Check(testkit.Rows("2 21 y", "3 31 z"))
+	tk.MustQuery("select `b + 1`, d from v1 where a = 3").Check(testkit.Rows("31 z"))
+	tk.MustExec("create view v2 (x, y) as select a, d from v1")
+	tk.MustQuery("select y from v2 where x = 2").Check(testkit.Rows("y"))
+	tk.MustQuery("select v2.x, t_view.b from v2 join t_view on v2.x = t_view.a").Sort().Check(testkit.Rows("2 20", "3 30"))
+
+	_, err := tk.Exec("create view v1 as select a from t_view")
+	c.Assert(terror.ErrorEqual(err, infoschema.ErrTableExists), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create or replace view t_view as select 1")
+	c.Assert(terror.ErrorEqual(err, ddl.ErrWrongObject), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create view v3 (x) as select a, b from t_view")
+	c.Assert(terror.ErrorEqual(err, ddl.ErrViewWrongList), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create view v3 as select a, a from t_view")
+	c.Assert(terror.ErrorEqual(err, infoschema.ErrColumnExists), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create or replace view v1 as select * from v2")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrViewRecursive), IsTrue, Commentf("err %v", err))
+
+	tk.MustExec("create or replace view v1 as select a, b + 1, c as d from t_view where a < 3")
+	tk.MustQuery("select x, y from v2").Sort().Check(testkit.Rows("1 x", "2 y"))
+	_, err = tk.Exec("drop view t_view")
+	c.Assert(terror.ErrorEqual(err, ddl.ErrWrongObject), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("drop table v1")
+	c.Assert(terror.ErrorEqual(err, infoschema.ErrTableDropExists), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create index idx on v1 (a)")
+	c.Assert(terror.ErrorEqual(err, ddl.ErrWrongObject), IsTrue, Commentf("err %v", err))
+	tk.MustExec("drop view v1")
+	_, err = tk.Exec("select * from v2")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrViewInvalid), IsTrue, Commentf("err %v", err))
+	tk.MustExec("drop view if exists v1, v2")
+	tk.MustExec("drop table t_view")
+}
+
+func (s *testSuite6) TestViewCheckOption(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t_check (a int primary key, b int)")
+	tk.MustExec("create view v_none as select a, b from t_check where b > 0")
+	tk.MustExec("create view v_local as select a, b as c from v_none where a < 10 with local check option")
+	tk.MustExec("create view v_cascaded as select a, c from v_local with cascaded check option")
+
+	tk.MustExec("insert into v_none values (1, -1)")
+	tk.MustExec("insert into v_local values (2, -2)")
+	_, err := tk.Exec("insert into v_local values (20, 2)")
+	c.Assert(err.Error(), Equals, "[executor:1369]CHECK OPTION failed 'test.v_local'")
+	_, err = tk.Exec("insert into v_cascaded (a, c) values (3, -3)")
+	c.Assert(err.Error(), Equals, "[executor:1369]CHECK OPTION failed 'test.v_cascaded'")
+	_, err = tk.Exec("insert into v_cascaded set a = 30, c = 3")
+	c.Assert(err.Error(), Equals, "[executor:1369]CHECK OPTION failed 'test.v_cascaded'")
+	tk.MustExec("insert into v_cascaded set a = 4, c = 4")
+	tk.MustExec("replace into v_cascaded select a + 4, b + 10 from t_check where a = 4")
+	_, err = tk.Exec("insert into v_local select a + 100, b from t_check where a = 4")
+	c.Assert(err.Error(), Equals, "[executor:1369]CHECK OPTION failed 'test.v_local'")
+	tk.MustQuery("select * from t_check").Check(testkit.Rows("1 -1", "2 -2", "4 4", "8 14"))
+
+	tk.MustExec("create view v_expr as select a, b + 1 as b1 from t_check")
+	_, err = tk.Exec("insert into v_expr values (5, 5)")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrNonupdateableColumn), IsTrue, Commentf("err %v", err))
+	tk.MustExec("insert into v_expr (a) values (5)")
+	tk.MustExec("create view v_agg as select count(*) as cnt from t_check")
+	_, err = tk.Exec("insert into v_agg values (1)")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrNonInsertableTable), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("delete from v_none where a = 1")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrNonUpdatableTable), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("create view v_agg_check as select count(*) as cnt from t_check with check option")
+	c.Assert(terror.ErrorEqual(err, plannercore.ErrViewNonupdCheck), IsTrue, Commentf("err %v", err))
+}
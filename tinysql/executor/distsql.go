@@ -15,6 +15,7 @@ package executor
 
 import (
 	"context"
+	"fmt"
 	"go.uber.org/zap"
 	"math"
 	"runtime"
@@ -114,6 +115,14 @@ func handleIsExtra(col *expression.Column) bool {
 	return false
 }
 
+func getPhysicalPlanIDs(plans []plannercore.PhysicalPlan) []fmt.Stringer {
+	planIDs := make([]fmt.Stringer, 0, len(plans))
+	for _, p := range plans {
+		planIDs = append(planIDs, p.ExplainID())
+	}
+	return planIDs
+}
+
 func splitRanges(ranges []*ranger.Range, keepOrder bool, desc bool) ([]*ranger.Range, []*ranger.Range) {
 	if len(ranges) == 0 || ranges[0].LowVal[0].Kind() == types.KindInt64 {
 		return ranges, nil
@@ -229,7 +238,7 @@ func (e *IndexReaderExecutor) open(ctx context.Context, kvRanges []kv.KeyRange)
 	if err != nil {
 		return err
 	}
-	e.result, err = distsql.Select(ctx, e.ctx, kvReq, retTypes(e))
+	e.result, err = distsql.SelectWithRuntimeStats(ctx, e.ctx, kvReq, retTypes(e), getPhysicalPlanIDs(e.plans), e.id)
 	return err
 }
 
@@ -247,7 +256,7 @@ type IndexLookUpExecutor struct {
 	// handleIdx is the index of handle, which is only used for case of keeping order.
 	handleIdx    int
 	tableRequest *tipb.DAGRequest
-	// columns are only required by union scan.
+	// columns are only required by union scan and virtual column.
 	columns []*model.ColumnInfo
 	*dataReaderBuilder
 	// All fields above are immutable.
@@ -311,7 +320,7 @@ func (e *IndexLookUpExecutor) startIndexWorker(ctx context.Context, kvRanges []k
 		return err
 	}
 	tps := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
-	result, err := distsql.Select(ctx, e.ctx, kvReq, tps)
+	result, err := distsql.SelectWithRuntimeStats(ctx, e.ctx, kvReq, tps, getPhysicalPlanIDs(e.idxPlans), e.id)
 	if err != nil {
 		return err
 	}
@@ -377,6 +386,7 @@ func (e *IndexLookUpExecutor) buildTableReader(ctx context.Context, handles []in
 		columns:      e.columns,
 		plans:        e.tblPlans,
 	}
+	tableReaderExec.buildVirtualColumnInfo()
 	tableReader, err := e.dataReaderBuilder.buildTableReaderFromHandles(ctx, tableReaderExec, handles)
 	if err != nil {
 		logutil.Logger(ctx).Error("build table reader from handles failed", zap.Error(err))
@@ -40,7 +40,12 @@ var (
 	ErrBadDB                       = terror.ClassExecutor.New(mysql.ErrBadDB, mysql.MySQLErrName[mysql.ErrBadDB])
 	ErrWrongObject                 = terror.ClassExecutor.New(mysql.ErrWrongObject, mysql.MySQLErrName[mysql.ErrWrongObject])
 	ErrRoleNotGranted              = terror.ClassPrivilege.New(mysql.ErrRoleNotGranted, mysql.MySQLErrName[mysql.ErrRoleNotGranted])
+	ErrUnknownAuthID               = terror.ClassExecutor.New(mysql.ErrUnknownAuthID, mysql.MySQLErrName[mysql.ErrUnknownAuthID])
+	ErrRoleGrantedToItself         = terror.ClassExecutor.New(mysql.ErrRoleGrantedToItself, mysql.MySQLErrName[mysql.ErrRoleGrantedToItself])
 	ErrQueryInterrupted            = terror.ClassExecutor.New(mysql.ErrQueryInterrupted, mysql.MySQLErrName[mysql.ErrQueryInterrupted])
+	ErrIllegalGrantForTable        = terror.ClassExecutor.New(mysql.ErrIllegalGrantForTable, mysql.MySQLErrName[mysql.ErrIllegalGrantForTable])
+	ErrNonexistingGrant            = terror.ClassExecutor.New(mysql.ErrNonexistingGrant, mysql.MySQLErrName[mysql.ErrNonexistingGrant])
+	ErrViewCheckFailed             = terror.ClassExecutor.New(mysql.ErrViewCheckFailed, mysql.MySQLErrName[mysql.ErrViewCheckFailed])
 )
 
 func init() {
@@ -66,7 +71,12 @@ func init() {
 		mysql.ErrBadDB:                       mysql.ErrBadDB,
 		mysql.ErrWrongObject:                 mysql.ErrWrongObject,
 		mysql.ErrRoleNotGranted:              mysql.ErrRoleNotGranted,
+		mysql.ErrUnknownAuthID:               mysql.ErrUnknownAuthID,
+		mysql.ErrRoleGrantedToItself:         mysql.ErrRoleGrantedToItself,
 		mysql.ErrQueryInterrupted:            mysql.ErrQueryInterrupted,
+		mysql.ErrIllegalGrantForTable:        mysql.ErrIllegalGrantForTable,
+		mysql.ErrNonexistingGrant:            mysql.ErrNonexistingGrant,
+		mysql.ErrViewCheckFailed:             mysql.ErrViewCheckFailed,
 		mysql.ErrWrongValueCountOnRow:        mysql.ErrWrongValueCountOnRow,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassExecutor] = tableMySQLErrCodes
@@ -17,9 +17,11 @@ import (
 	"context"
 	"fmt"
 	"sync/atomic"
+	"time"
 
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
@@ -31,6 +33,9 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/admin"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/execdetails"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 var (
@@ -60,6 +65,7 @@ type baseExecutor struct {
 	maxChunkSize  int
 	children      []Executor
 	retFieldTypes []*types.FieldType
+	runtimeStats  *execdetails.RuntimeStats
 }
 
 // base returns the baseExecutor of an executor, don't override this method!
@@ -123,6 +129,11 @@ func newBaseExecutor(ctx sessionctx.Context, schema *expression.Schema, id fmt.S
 		initCap:      ctx.GetSessionVars().InitChunkSize,
 		maxChunkSize: ctx.GetSessionVars().MaxChunkSize,
 	}
+	if ctx.GetSessionVars().StmtCtx.RuntimeStatsColl != nil {
+		if e.id != nil {
+			e.runtimeStats = ctx.GetSessionVars().StmtCtx.RuntimeStatsColl.GetRootStats(e.id.String())
+		}
+	}
 	if schema != nil {
 		cols := schema.Columns
 		e.retFieldTypes = make([]*types.FieldType, len(cols))
@@ -158,6 +169,10 @@ func Next(ctx context.Context, e Executor, req *chunk.Chunk) error {
 	if atomic.CompareAndSwapUint32(&sessVars.Killed, 1, 0) {
 		return ErrQueryInterrupted
 	}
+	if base.runtimeStats != nil {
+		start := time.Now()
+		defer func() { base.runtimeStats.Record(time.Since(start), req.NumRows()) }()
+	}
 	return e.Next(ctx, req)
 }
 
@@ -609,13 +624,39 @@ func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHin
 // ResetContextOfStmt resets the StmtContext and session variables.
 // Before every execution, we must clear statement context.
 func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
+	vars := ctx.GetSessionVars()
+	vars.PrevFoundInPlanCache = vars.FoundInPlanCache
+	vars.FoundInPlanCache = false
+	// The context of an EXECUTE statement is set by the prepared statement.
+	if execStmt, ok := s.(*ast.ExecuteStmt); ok {
+		s, err = getPreparedStmt(execStmt, vars)
+		if err != nil {
+			return
+		}
+	}
 	hints := extractStmtHintsFromStmtNode(s)
 	stmtHints, hintWarns := handleStmtHints(hints)
-	vars := ctx.GetSessionVars()
+	memQuota := vars.MemQuotaQuery
+	if stmtHints.HasMemQuotaHint {
+		memQuota = stmtHints.MemQuotaQuery
+	}
 	sc := &stmtctx.StatementContext{
-		StmtHints: stmtHints,
-		TimeZone:  vars.Location(),
+		StmtHints:   stmtHints,
+		TimeZone:    vars.Location(),
+		MemTracker:  memory.NewTracker(stringutil.MemoizeStr(s.Text), memQuota),
+		DiskTracker: memory.NewTracker(stringutil.MemoizeStr(s.Text), -1),
+	}
+	switch config.GetGlobalConfig().OOMAction {
+	case config.OOMActionCancel:
+		sc.MemTracker.SetActionOnExceed(&memory.PanicOnExceed{ConnID: vars.ConnectionID})
+	default:
+		sc.MemTracker.SetActionOnExceed(&memory.LogOnExceed{ConnID: vars.ConnectionID})
 	}
+	// The memory tracker of the previous statement is no longer needed by the session.
+	if vars.StmtCtx != nil && vars.StmtCtx.MemTracker != nil {
+		vars.StmtCtx.MemTracker.Detach()
+	}
+	sc.MemTracker.AttachTo(vars.MemTracker)
 	if explainStmt, ok := s.(*ast.ExplainStmt); ok {
 		sc.InExplainStmt = true
 		sc.CastStrToIntStrict = true
@@ -694,3 +735,33 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	}
 	return
 }
+
+// UnionExec pulls all its children's result and returns to its parent directly.
+// The children are the readers of the partitions of a partitioned table, they
+// are read one after another.
+type UnionExec struct {
+	baseExecutor
+
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *UnionExec) Open(ctx context.Context) error {
+	e.cursor = 0
+	return e.baseExecutor.Open(ctx)
+}
+
+// Next implements the Executor Next interface.
+func (e *UnionExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	for e.cursor < len(e.children) {
+		if err := Next(ctx, e.children[e.cursor], req); err != nil {
+			return err
+		}
+		if req.NumRows() > 0 {
+			return nil
+		}
+		e.cursor++
+	}
+	return nil
+}
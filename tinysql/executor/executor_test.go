@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tidb/store/mockstore/mocktikv"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
 	"github.com/pingcap/tidb/util/testkit"
@@ -37,6 +38,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -1227,7 +1229,11 @@ func (s *testSuite6) TearDownTest(c *C) {
 	r := tk.MustQuery("show full tables")
 	for _, tb := range r.Rows() {
 		tableName := tb[0]
-		tk.MustExec(fmt.Sprintf("drop table %v", tableName))
+		if tb[1] == "VIEW" {
+			tk.MustExec(fmt.Sprintf("drop view %v", tableName))
+		} else {
+			tk.MustExec(fmt.Sprintf("drop table %v", tableName))
+		}
 	}
 }
 
@@ -1258,3 +1264,73 @@ func (s *testSuite8) TearDownTest(c *C) {
 		tk.MustExec(fmt.Sprintf("drop table %v", tableName))
 	}
 }
+
+func (s *testSuite) TestPartitionTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists clicks")
+	tk.MustExec(`create table clicks (id int, ts int, v int, index idx_v(v)) partition by range (ts) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30))`)
+	tk.MustExec("insert into clicks values (1, 1, 1), (2, 5, 2), (3, 12, 3), (4, 25, 4), (5, null, 5)")
+	_, err := tk.Exec("insert into clicks values (6, 30, 6)")
+	c.Assert(table.ErrNoPartitionForGivenValue.Equal(err), IsTrue)
+
+	tk.MustQuery("select id from clicks order by id").Check(testkit.Rows("1", "2", "3", "4", "5"))
+	tk.MustQuery("select id from clicks where ts < 10 order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from clicks where ts is null").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from clicks where v = 3").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from clicks partition (p1, p2) order by id").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select id from clicks partition (p0) where ts > 20").Check(testkit.Rows())
+	_, err = tk.Exec("select * from clicks partition (p9)")
+	c.Assert(table.ErrUnknownPartition.Equal(err), IsTrue)
+
+	scannedPartitions := func(sql string) []string {
+		var parts []string
+		for _, row := range tk.MustQuery("explain " + sql).Rows() {
+			info := row[3].(string)
+			if idx := strings.Index(info, "partition:"); idx >= 0 {
+				parts = append(parts, strings.Split(info[idx+len("partition:"):], ",")[0])
+			}
+		}
+		return parts
+	}
+	c.Assert(scannedPartitions("select * from clicks"), DeepEquals, []string{"p0", "p1", "p2"})
+	c.Assert(scannedPartitions("select * from clicks where ts = 12"), DeepEquals, []string{"p1"})
+	c.Assert(scannedPartitions("select * from clicks where ts >= 10 and ts < 20"), DeepEquals, []string{"p1"})
+	c.Assert(scannedPartitions("select * from clicks where ts > 15"), DeepEquals, []string{"p1", "p2"})
+	c.Assert(scannedPartitions("select * from clicks where ts in (1, 25)"), DeepEquals, []string{"p0", "p2"})
+	c.Assert(scannedPartitions("select * from clicks where ts > 100"), HasLen, 0)
+
+	tk.MustExec("analyze table clicks")
+	tk.MustQuery("select count(*) from clicks where ts < 20").Check(testkit.Rows("3"))
+
+	// Dropping the oldest partition is the cheap retention of time-series data.
+	tk.MustExec("alter table clicks drop partition p0")
+	tk.MustQuery("select id from clicks order by id").Check(testkit.Rows("3", "4"))
+	tk.MustExec("alter table clicks add partition (partition p3 values less than (40))")
+	tk.MustExec("insert into clicks values (6, 30, 6)")
+	tk.MustExec("alter table clicks truncate partition p2")
+	tk.MustQuery("select id from clicks order by id").Check(testkit.Rows("3", "6"))
+
+	tk.MustExec("drop table if exists th, tl")
+	tk.MustExec("create table th (a int primary key, b int) partition by hash (a) partitions 3")
+	tk.MustExec("insert into th values (1, 1), (2, 2), (3, 3), (-4, 4)")
+	_, err = tk.Exec("insert into th values (3, 3)")
+	c.Assert(kv.ErrKeyExists.Equal(err), IsTrue)
+	tk.MustQuery("select a from th order by a").Check(testkit.Rows("-4", "1", "2", "3"))
+	tk.MustQuery("select b from th where a = 2").Check(testkit.Rows("2"))
+	c.Assert(scannedPartitions("select * from th where a = 2"), DeepEquals, []string{"p2"})
+	tk.MustQuery("select a from th partition (p1) order by a").Check(testkit.Rows("-4", "1"))
+
+	tk.MustExec(`create table tl (a int, b int) partition by list (a) (
+		partition p0 values in (1, 3, null),
+		partition p1 values in (2, 4))`)
+	tk.MustExec("insert into tl values (1, 1), (2, 2), (null, 3), (4, 4)")
+	_, err = tk.Exec("insert into tl values (5, 5)")
+	c.Assert(table.ErrNoPartitionForGivenValue.Equal(err), IsTrue)
+	tk.MustQuery("select b from tl partition (p0) order by b").Check(testkit.Rows("1", "3"))
+	c.Assert(scannedPartitions("select * from tl where a = 4"), DeepEquals, []string{"p1"})
+	c.Assert(scannedPartitions("select * from tl where a is null"), DeepEquals, []string{"p0"})
+}
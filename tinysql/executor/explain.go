@@ -28,16 +28,29 @@ type ExplainExec struct {
 	explain *core.Explain
 	rows    [][]string
 	cursor  int
+
+	// analyzeExec is the executor of the explained statement, it is only set
+	// for EXPLAIN ANALYZE and is run to collect the actual execution info.
+	analyzeExec Executor
+	executed    bool
 }
 
 // Open implements the Executor Open interface.
 func (e *ExplainExec) Open(ctx context.Context) error {
+	if e.analyzeExec != nil {
+		return e.analyzeExec.Open(ctx)
+	}
 	return nil
 }
 
 // Close implements the Executor Close interface.
 func (e *ExplainExec) Close() error {
 	e.rows = nil
+	if e.analyzeExec != nil {
+		err := e.analyzeExec.Close()
+		e.analyzeExec = nil
+		return err
+	}
 	return nil
 }
 
@@ -66,7 +79,29 @@ func (e *ExplainExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// executeAnalyzeExec runs the explained statement to the end for EXPLAIN ANALYZE.
+func (e *ExplainExec) executeAnalyzeExec(ctx context.Context) error {
+	if e.analyzeExec == nil || e.executed {
+		return nil
+	}
+	e.executed = true
+	chk := newFirstChunk(e.analyzeExec)
+	for {
+		if err := Next(ctx, e.analyzeExec, chk); err != nil {
+			return err
+		}
+		if chk.NumRows() == 0 {
+			return nil
+		}
+	}
+}
+
 func (e *ExplainExec) generateExplainInfo(ctx context.Context) ([][]string, error) {
+	if err := e.executeAnalyzeExec(ctx); err != nil {
+		return nil, err
+	}
+	// The result is rendered before closing analyzeExec, the memory trackers
+	// of the executors are detached from the statement's tracker on Close.
 	if err := e.explain.RenderResult(); err != nil {
 		return nil, err
 	}
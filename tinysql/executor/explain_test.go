@@ -0,0 +1,58 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"strings"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+func (s *testSuite1) TestExplainAnalyze(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key, b int, c int, index idx(b))")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4)")
+
+	// Without ANALYZE, the statement is not executed.
+	rows := tk.MustQuery("explain select * from t where c > 1").Rows()
+	c.Assert(rows[0], HasLen, 4)
+
+	rows = tk.MustQuery("explain analyze select * from t where c > 1").Rows()
+	c.Assert(rows, HasLen, 3)
+	c.Assert(rows[0], HasLen, 6)
+	c.Assert(rows[0][0], Matches, "TableReader_.*")
+	c.Assert(rows[0][4], Matches, "time:.*, loops:2, rows:3, cop_task: {num: 1, regions: 1, process: .*, max_process: .*}")
+	c.Assert(rows[1][0], Matches, ".*Selection_.*")
+	c.Assert(rows[1][4], Matches, "time:.*, loops:.*, rows:3")
+
+	rows = tk.MustQuery("explain analyze select * from t use index(idx) where b > 2").Rows()
+	c.Assert(rows[0][0], Matches, "IndexLookUp_.*")
+	// Both the index side and the table side send coprocessor tasks.
+	c.Assert(strings.Count(rows[0][4].(string), "cop_task"), Equals, 2)
+	c.Assert(rows[0][4], Matches, "time:.*, loops:.*, rows:2, .*")
+
+	rows = tk.MustQuery("explain analyze select * from t t1 join t t2 on t1.c = t2.c").Rows()
+	c.Assert(rows[0][0], Matches, "HashLeftJoin_.*")
+	c.Assert(rows[0][4], Matches, "time:.*, loops:.*, rows:4")
+	c.Assert(rows[0][5], Not(Equals), "N/A")
+
+	// DML statements are executed as well.
+	rows = tk.MustQuery("explain analyze insert into t values (5, 5, 5)").Rows()
+	c.Assert(rows[0][0], Matches, "Insert_.*")
+	c.Assert(rows[0][4], Matches, "time:.*, loops:1, rows:0")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("5"))
+}
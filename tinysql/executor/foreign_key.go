@@ -0,0 +1,276 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+)
+
+// maxFKCascadeDepth is the max depth of the cascading foreign key actions, it's the same as MySQL.
+const maxFKCascadeDepth = 15
+
+// Foreign keys are enforced by the point lookups in the same transaction as the written rows.
+// The parent keys found by the checks of the child rows are locked, so they are written as lock
+// mutations in the 2PC and a concurrent transaction deleting the parent rows conflicts with the
+// transaction. The child rows are found by the index of the foreign key columns, and the actions
+// on them are written to the transaction too, so all of them commit atomically.
+
+// checkForeignKeysOnInsert checks the parent rows referred by the new row of t exist.
+func checkForeignKeysOnInsert(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, t table.Table, row []types.Datum) error {
+	tblInfo := t.Meta()
+	if len(tblInfo.ForeignKeys) == 0 || !sctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	is := infoschema.GetInfoSchema(sctx)
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.State == model.StateNone {
+			continue
+		}
+		vals, hasNull := fkColumnValues(tblInfo, fk.Cols, row)
+		if hasNull {
+			// A row with a NULL in the foreign key columns doesn't refer any row.
+			continue
+		}
+		parentKey, err := fkParentKey(sctx, is, tblInfo, fk, vals)
+		if err == nil && parentKey != nil {
+			_, err = txn.Get(ctx, parentKey)
+		}
+		if parentKey == nil || kv.IsErrNotFound(err) {
+			return table.ErrNoReferencedRow2.GenWithStackByArgs(fkString(is, tblInfo, fk))
+		}
+		if err != nil {
+			return err
+		}
+		// Lock the parent row, so it can't be deleted by the other transactions before this one commits.
+		if err = txn.LockKeys(ctx, new(kv.LockCtx), parentKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fkParentKey returns the key to look up the parent row of the foreign key, it's the row key if the
+// referenced column is the integer primary key, otherwise it's the key of the unique index. It
+// returns nil if the parent table or the unique index doesn't exist.
+func fkParentKey(sctx sessionctx.Context, is infoschema.InfoSchema, tblInfo *model.TableInfo, fk *model.FKInfo,
+	vals []types.Datum) (kv.Key, error) {
+	parent, err := is.TableByName(fk.RefSchema, fk.RefTable)
+	if err != nil {
+		// The parent table may not exist if the foreign key is created when foreign_key_checks is off.
+		return nil, nil
+	}
+	idxInfo, ok := ddl.FindFKParentIndex(parent.Meta(), fk)
+	if !ok {
+		return nil, nil
+	}
+	if idxInfo == nil {
+		return parent.RecordKey(vals[0].GetInt64()), nil
+	}
+	for _, idx := range parent.Indices() {
+		if idx.Meta().ID != idxInfo.ID {
+			continue
+		}
+		key, _, err := idx.GenIndexKey(sctx.GetSessionVars().StmtCtx, vals, 0, nil)
+		return key, err
+	}
+	return nil, nil
+}
+
+// onDeleteParentRow runs the ON DELETE actions of the foreign keys referring the deleted row of t.
+// The row must be removed before, so a row referring itself isn't taken as its child.
+func onDeleteParentRow(ctx context.Context, sctx sessionctx.Context, t table.Table, row []types.Datum, depth int) error {
+	if !sctx.GetSessionVars().ForeignKeyChecks {
+		return nil
+	}
+	is := infoschema.GetInfoSchema(sctx)
+	tblInfo := t.Meta()
+	dbInfo, ok := is.SchemaByTable(tblInfo)
+	if !ok {
+		return nil
+	}
+	for _, db := range is.AllSchemas() {
+		for _, childInfo := range db.Tables {
+			for _, fk := range childInfo.ForeignKeys {
+				if fk.State == model.StateNone || fk.RefSchema.L != dbInfo.Name.L || fk.RefTable.L != tblInfo.Name.L {
+					continue
+				}
+				child, ok := is.TableByID(childInfo.ID)
+				if !ok {
+					continue
+				}
+				if err := onDeleteFK(ctx, sctx, is, tblInfo, child, fk, row, depth); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func onDeleteFK(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, tblInfo *model.TableInfo,
+	child table.Table, fk *model.FKInfo, row []types.Datum, depth int) error {
+	vals, hasNull := fkColumnValues(tblInfo, fk.RefCols, row)
+	if hasNull {
+		return nil
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	handles, err := fkChildHandles(ctx, sctx, txn, child, fk, vals)
+	if err != nil || len(handles) == 0 {
+		return err
+	}
+
+	opt := ast.ReferOptionType(fk.OnDelete)
+	switch opt {
+	case ast.ReferOptionCascade, ast.ReferOptionSetNull:
+	default:
+		return table.ErrRowIsReferenced2.GenWithStackByArgs(fkString(is, child.Meta(), fk))
+	}
+	if depth >= maxFKCascadeDepth {
+		return table.ErrForeignKeyCascadeDepthExceeded.GenWithStackByArgs(maxFKCascadeDepth)
+	}
+
+	genExprs, err := buildGenExprs(sctx, child)
+	if err != nil {
+		return err
+	}
+	for _, h := range handles {
+		childRow, err := getOldRow(ctx, sctx, txn, child, h, genExprs)
+		if err != nil {
+			if kv.IsErrNotFound(err) {
+				// The row has been removed by another cascading action.
+				continue
+			}
+			return err
+		}
+		if opt == ast.ReferOptionCascade {
+			if err = child.RemoveRecord(sctx, h, childRow); err != nil {
+				return err
+			}
+			if err = onDeleteParentRow(ctx, sctx, child, childRow, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		newRow := make([]types.Datum, len(childRow))
+		copy(newRow, childRow)
+		touched := make([]bool, len(childRow))
+		for _, colName := range fk.Cols {
+			col := model.FindColumnInfo(child.Meta().Columns, colName.L)
+			newRow[col.Offset].SetNull()
+			touched[col.Offset] = true
+		}
+		if err = child.UpdateRecord(sctx, h, childRow, newRow, touched); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fkChildHandles finds the handles of the child rows whose foreign key columns are vals.
+func fkChildHandles(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, child table.Table, fk *model.FKInfo,
+	vals []types.Datum) ([]int64, error) {
+	childInfo := child.Meta()
+	idxInfo := ddl.FindFKChildIndex(childInfo, fk)
+	if idxInfo == nil {
+		// The foreign key column is the integer primary key of the child table.
+		h := vals[0].GetInt64()
+		_, err := txn.Get(ctx, child.RecordKey(h))
+		if kv.IsErrNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []int64{h}, nil
+	}
+
+	encoded, err := codec.EncodeKey(sctx.GetSessionVars().StmtCtx, nil, vals...)
+	if err != nil {
+		return nil, err
+	}
+	prefix := tablecodec.EncodeIndexSeekKey(childInfo.ID, idxInfo.ID, encoded)
+	it, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var handles []int64
+	pkTp := types.NewFieldType(mysql.TypeLonglong)
+	for it.Valid() && it.Key().HasPrefix(prefix) {
+		h, err := tablecodec.DecodeIndexHandle(it.Key(), it.Value(), len(idxInfo.Columns), pkTp)
+		if err != nil {
+			return nil, err
+		}
+		handles = append(handles, h)
+		if err = it.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return handles, nil
+}
+
+// buildGenExprs builds the expressions of the generated columns of t, which are used to fill back the
+// virtual columns of the rows read by getOldRow.
+func buildGenExprs(sctx sessionctx.Context, t table.Table) ([]expression.Expression, error) {
+	var genExprs []expression.Expression
+	for _, col := range t.WritableCols() {
+		if !col.IsGenerated() || col.State != model.StatePublic {
+			continue
+		}
+		expr, err := expression.RewriteSimpleExprWithTableInfo(sctx, t.Meta(), col.GeneratedExpr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		genExprs = append(genExprs, expr)
+	}
+	return genExprs, nil
+}
+
+func fkColumnValues(tblInfo *model.TableInfo, cols []model.CIStr, row []types.Datum) ([]types.Datum, bool) {
+	vals := make([]types.Datum, 0, len(cols))
+	for _, colName := range cols {
+		col := model.FindColumnInfo(tblInfo.Columns, colName.L)
+		if col == nil || row[col.Offset].IsNull() {
+			return nil, true
+		}
+		vals = append(vals, row[col.Offset])
+	}
+	return vals, false
+}
+
+func fkString(is infoschema.InfoSchema, tblInfo *model.TableInfo, fk *model.FKInfo) string {
+	var schema model.CIStr
+	if dbInfo, ok := is.SchemaByTable(tblInfo); ok {
+		schema = dbInfo.Name
+	}
+	return ddl.FKConstraintString(schema, tblInfo.Name, fk)
+}
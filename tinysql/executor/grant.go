@@ -0,0 +1,288 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+var (
+	// userTableCols are the columns of mysql.user.
+	userTableCols = append([]string{"Host", "User", "authentication_string", "Account_locked"}, privCols(mysql.AllGlobalPrivs)...)
+	// dbTableCols are the columns of mysql.db.
+	dbTableCols = append([]string{"Host", "DB", "User"}, privCols(mysql.AllDBPrivs)...)
+)
+
+func privCols(privs []mysql.PrivilegeType) []string {
+	cols := make([]string, 0, len(privs)+1)
+	for _, priv := range privs {
+		cols = append(cols, mysql.Priv2UserCol[priv])
+	}
+	return append(cols, mysql.Priv2UserCol[mysql.GrantPriv])
+}
+
+/***
+ * Grant Statement
+ * See https://dev.mysql.com/doc/refman/5.7/en/grant.html
+ ************************************************************************************/
+var (
+	_ Executor = (*GrantExec)(nil)
+)
+
+// GrantExec executes GrantStmt.
+type GrantExec struct {
+	baseExecutor
+
+	Privs      []*ast.PrivElem
+	ObjectType ast.ObjectTypeType
+	Level      *ast.GrantLevel
+	Users      []*ast.UserSpec
+	WithGrant  bool
+
+	is   infoschema.InfoSchema
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *GrantExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	dbName, err := checkGrantLevel(e.ctx, e.is, e.Level)
+	if err != nil {
+		return err
+	}
+	priv, err := levelPrivileges(e.Privs, e.Level.Level)
+	if err != nil {
+		return err
+	}
+	if e.WithGrant {
+		priv |= mysql.GrantPriv
+	}
+
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	for _, user := range e.Users {
+		exists, err := userExists(e.ctx, user.User.Username, user.User.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCantCreateUserWithGrant
+		}
+		// GRANT ... IDENTIFIED BY changes the password of the user.
+		if user.AuthOpt != nil {
+			pwd, ok := user.EncodedPassword()
+			if !ok {
+				return errors.Trace(ErrPasswordFormat)
+			}
+			sql := composeRewriteSQL(mysql.UserTable, userTableCols, map[string]string{
+				"authentication_string": quoteString(pwd),
+			}, userCond(user.User.Username, user.User.Hostname))
+			if _, _, err = exec.ExecRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+
+		switch e.Level.Level {
+		case ast.GrantLevelGlobal:
+			err = e.grantGlobalPriv(priv, user)
+		case ast.GrantLevelDB:
+			err = e.grantDBPriv(priv, user, dbName)
+		case ast.GrantLevelTable:
+			err = e.grantTablePriv(priv, user, dbName)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *GrantExec) grantGlobalPriv(priv mysql.PrivilegeType, user *ast.UserSpec) error {
+	sql := composeRewriteSQL(mysql.UserTable, userTableCols, privValues(priv, "Y"),
+		userCond(user.User.Username, user.User.Hostname))
+	_, _, err := e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	return err
+}
+
+func (e *GrantExec) grantDBPriv(priv mysql.PrivilegeType, user *ast.UserSpec, dbName string) error {
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	cond := dbCond(user.User.Username, user.User.Hostname, dbName)
+	rows, _, err := exec.ExecRestrictedSQL(fmt.Sprintf("SELECT * FROM %s.%s WHERE %s", mysql.SystemDB, mysql.DBTable, cond))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		sql := fmt.Sprintf("INSERT INTO %s.%s (Host, DB, User) VALUES (%s, %s, %s)", mysql.SystemDB, mysql.DBTable,
+			quoteString(user.User.Hostname), quoteString(dbName), quoteString(user.User.Username))
+		if _, _, err = exec.ExecRestrictedSQL(sql); err != nil {
+			return err
+		}
+	}
+	_, _, err = exec.ExecRestrictedSQL(composeRewriteSQL(mysql.DBTable, dbTableCols, privValues(priv, "Y"), cond))
+	return err
+}
+
+func (e *GrantExec) grantTablePriv(priv mysql.PrivilegeType, user *ast.UserSpec, dbName string) error {
+	oldPriv, _, err := getTablePriv(e.ctx, user, dbName, e.Level.TableName)
+	if err != nil {
+		return err
+	}
+	return replaceTablePriv(e.ctx, user, dbName, e.Level.TableName, oldPriv|priv)
+}
+
+// checkGrantLevel checks the object of GRANT or REVOKE, and returns the
+// database of the object.
+func checkGrantLevel(ctx sessionctx.Context, is infoschema.InfoSchema, level *ast.GrantLevel) (string, error) {
+	if level.Level == ast.GrantLevelGlobal {
+		return "", nil
+	}
+	dbName := level.DBName
+	if len(dbName) == 0 {
+		dbName = ctx.GetSessionVars().CurrentDB
+		if len(dbName) == 0 {
+			return "", plannercore.ErrNoDB
+		}
+	}
+	if level.Level == ast.GrantLevelTable {
+		if _, err := is.TableByName(model.NewCIStr(dbName), model.NewCIStr(level.TableName)); err != nil {
+			return "", err
+		}
+	}
+	return dbName, nil
+}
+
+// levelPrivileges merges the privileges of GRANT or REVOKE, ALL is expanded
+// to all the privileges of the level.
+func levelPrivileges(privs []*ast.PrivElem, level ast.GrantLevelType) (mysql.PrivilegeType, error) {
+	var allPrivs []mysql.PrivilegeType
+	switch level {
+	case ast.GrantLevelGlobal:
+		allPrivs = mysql.AllGlobalPrivs
+	case ast.GrantLevelDB:
+		allPrivs = mysql.AllDBPrivs
+	case ast.GrantLevelTable:
+		allPrivs = mysql.AllTablePrivs
+	}
+	var ret mysql.PrivilegeType
+	for _, item := range privs {
+		if item.Priv == mysql.AllPriv {
+			for _, priv := range allPrivs {
+				ret |= priv
+			}
+			continue
+		}
+		if item.Priv != mysql.GrantPriv && !containsPriv(allPrivs, item.Priv) {
+			return 0, ErrIllegalGrantForTable
+		}
+		ret |= item.Priv
+	}
+	return ret, nil
+}
+
+func containsPriv(privs []mysql.PrivilegeType, priv mysql.PrivilegeType) bool {
+	for _, p := range privs {
+		if p == priv {
+			return true
+		}
+	}
+	return false
+}
+
+// privValues returns the new values of the privilege columns of priv.
+func privValues(priv mysql.PrivilegeType, value string) map[string]string {
+	values := make(map[string]string)
+	for p, col := range mysql.Priv2UserCol {
+		if priv&p != 0 {
+			values[col] = quoteString(value)
+		}
+	}
+	return values
+}
+
+// composeRewriteSQL builds a statement which rewrites the rows of the
+// privilege table matched by cond. The columns in newValues are set to the
+// given expressions, and the others keep their values.
+func composeRewriteSQL(table string, cols []string, newValues map[string]string, cond string) string {
+	exprs := make([]string, 0, len(cols))
+	for _, col := range cols {
+		if v, ok := newValues[col]; ok {
+			exprs = append(exprs, v)
+		} else {
+			exprs = append(exprs, col)
+		}
+	}
+	return fmt.Sprintf("REPLACE INTO %s.%s (%s) SELECT %s FROM %s.%s WHERE %s", mysql.SystemDB, table,
+		strings.Join(cols, ", "), strings.Join(exprs, ", "), mysql.SystemDB, table, cond)
+}
+
+func dbCond(name, host, db string) string {
+	return fmt.Sprintf("%s AND DB = %s", userCond(name, host), quoteString(db))
+}
+
+func tableCond(name, host, db, table string) string {
+	return fmt.Sprintf("%s AND Table_name = %s", dbCond(name, host, db), quoteString(table))
+}
+
+// getTablePriv gets the privileges granted to the user on the table, the
+// boolean value indicates whether there is a grant on the table.
+func getTablePriv(ctx sessionctx.Context, user *ast.UserSpec, db, table string) (mysql.PrivilegeType, bool, error) {
+	sql := fmt.Sprintf("SELECT Table_priv FROM %s.%s WHERE %s", mysql.SystemDB, mysql.TablePrivTable,
+		tableCond(user.User.Username, user.User.Hostname, db, table))
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil || len(rows) == 0 {
+		return 0, false, err
+	}
+	var priv mysql.PrivilegeType
+	if s := rows[0].GetString(0); s != "" {
+		for _, str := range strings.Split(s, ",") {
+			priv |= mysql.SetStr2Priv[str]
+		}
+	}
+	return priv, true, nil
+}
+
+// replaceTablePriv sets the privileges granted to the user on the table.
+func replaceTablePriv(ctx sessionctx.Context, user *ast.UserSpec, db, table string, priv mysql.PrivilegeType) error {
+	var grantor string
+	if u := ctx.GetSessionVars().User; u != nil {
+		grantor = u.String()
+	}
+	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeDatetime, 0)
+	sql := fmt.Sprintf("REPLACE INTO %s.%s (Host, DB, User, Table_name, Grantor, Timestamp, Table_priv) VALUES (%s, %s, %s, %s, %s, %s, %s)",
+		mysql.SystemDB, mysql.TablePrivTable, quoteString(user.User.Hostname), quoteString(db),
+		quoteString(user.User.Username), quoteString(table), quoteString(grantor), quoteString(now.String()),
+		quoteString(privileges.EncodePrivilegeToSet(priv)))
+	_, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	return err
+}
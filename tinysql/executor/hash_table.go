@@ -16,6 +16,7 @@ package executor
 import (
 	"hash"
 	"hash/fnv"
+	"sync/atomic"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/sessionctx"
@@ -23,6 +24,10 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/stringutil"
+	"go.uber.org/zap"
 )
 
 const (
@@ -72,15 +77,37 @@ func (hc *hashContext) initHash(rows int) {
 }
 
 // hashRowContainer handles the rows and the hash map of a table.
-// TODO: support spilling out to disk when memory is limited.
+// When the memory quota of the statement is exceeded, the rows are spilled
+// out to a chunk.ListInDisk, while the hash map is still kept in memory.
 type hashRowContainer struct {
-	records   *chunk.List
-	hashTable *rowHashMap
+	records       *chunk.List
+	recordsInDisk *chunk.ListInDisk
+	hashTable     *rowHashMap
 
 	sc   *stmtctx.StatementContext
 	hCtx *hashContext
+
+	memTracker  *memory.Tracker
+	diskTracker *memory.Tracker
+
+	// exceeded indicates that records have exceeded memQuota during
+	// this PutChunk and we should spill now.
+	// It's for concurrency usage, so access it with atomic.
+	exceeded uint32
+	// spilled indicates that records have spilled out into disk.
+	// It's for concurrency usage, so access it with atomic.
+	spilled uint32
+	// built indicates that all the records have been put, so they can't
+	// be spilled out from now on.
+	// It's for concurrency usage, so access it with atomic.
+	built uint32
 }
 
+var (
+	hashRowContainerLabel     = stringutil.StringerStr("hashRowContainer")
+	hashRowContainerDiskLabel = stringutil.StringerStr("hashRowContainerInDisk")
+)
+
 func newHashRowContainer(sctx sessionctx.Context, estCount int, hCtx *hashContext, initList *chunk.List) *hashRowContainer {
 	maxChunkSize := sctx.GetSessionVars().MaxChunkSize
 	// The estCount from cost model is not quite accurate and we need
@@ -98,9 +125,12 @@ func newHashRowContainer(sctx sessionctx.Context, estCount int, hCtx *hashContex
 		records:   initList,
 		hashTable: newRowHashMap(estCount),
 
-		sc:   sctx.GetSessionVars().StmtCtx,
-		hCtx: hCtx,
+		sc:          sctx.GetSessionVars().StmtCtx,
+		hCtx:        hCtx,
+		memTracker:  memory.NewTracker(hashRowContainerLabel, -1),
+		diskTracker: memory.NewTracker(hashRowContainerDiskLabel, -1),
 	}
+	initList.GetMemTracker().AttachTo(c.memTracker)
 	return c
 }
 
@@ -114,7 +144,15 @@ func (c *hashRowContainer) GetMatchedRows(probeKey uint64, probeRow chunk.Row, h
 	}
 	matched = make([]chunk.Row, 0, len(innerPtrs))
 	for _, ptr := range innerPtrs {
-		matchedRow := c.records.GetRow(ptr)
+		var matchedRow chunk.Row
+		if c.alreadySpilled() {
+			matchedRow, err = c.recordsInDisk.GetRow(ptr)
+			if err != nil {
+				return
+			}
+		} else {
+			matchedRow = c.records.GetRow(ptr)
+		}
 		var ok bool
 		ok, err = c.matchJoinKey(matchedRow, probeRow, hCtx)
 		if err != nil {
@@ -144,10 +182,23 @@ func (c *hashRowContainer) matchJoinKey(buildRow, probeRow chunk.Row, probeHCtx
 // key of hash table: hash value of key columns
 // value of hash table: RowPtr of the corresponded row
 func (c *hashRowContainer) PutChunk(chk *chunk.Chunk) error {
-	chkIdx := uint32(c.records.NumChunks())
+	var chkIdx uint32
 	numRows := chk.NumRows()
 
-	c.records.Add(chk)
+	if c.alreadySpilled() {
+		chkIdx = uint32(c.recordsInDisk.NumChunks())
+		if err := c.recordsInDisk.Add(chk); err != nil {
+			return err
+		}
+	} else {
+		chkIdx = uint32(c.records.NumChunks())
+		c.records.Add(chk)
+		if atomic.LoadUint32(&c.exceeded) != 0 {
+			if err := c.spillToDisk(); err != nil {
+				return err
+			}
+		}
+	}
 	c.hCtx.initHash(numRows)
 
 	hCtx := c.hCtx
@@ -168,11 +219,85 @@ func (c *hashRowContainer) PutChunk(chk *chunk.Chunk) error {
 	return nil
 }
 
+// spillToDisk writes all the records in memory to disk and releases the
+// memory of them. The records added later are written to disk directly.
+func (c *hashRowContainer) spillToDisk() error {
+	c.recordsInDisk = chunk.NewListInDisk(c.hCtx.allTypes)
+	c.recordsInDisk.GetMemTracker().AttachTo(c.memTracker)
+	c.recordsInDisk.GetDiskTracker().AttachTo(c.diskTracker)
+	for i := 0; i < c.records.NumChunks(); i++ {
+		if err := c.recordsInDisk.Add(c.records.GetChunk(i)); err != nil {
+			return err
+		}
+	}
+	c.records.GetMemTracker().Detach()
+	c.records = nil
+	atomic.StoreUint32(&c.spilled, 1)
+	logutil.BgLogger().Info("memory exceeds quota, spill hash join records to disk",
+		zap.Int("rows", c.recordsInDisk.Len()), zap.Int64("diskBytes", c.diskTracker.BytesConsumed()))
+	return nil
+}
+
+// finishBuild is called after all the records have been put, exceeding the
+// memory quota later triggers the fallback action of spillDiskAction.
+func (c *hashRowContainer) finishBuild() {
+	atomic.StoreUint32(&c.built, 1)
+}
+
+// alreadySpilled indicates that records have spilled out into disk.
+func (c *hashRowContainer) alreadySpilled() bool {
+	return atomic.LoadUint32(&c.spilled) == 1
+}
+
 // Len returns the length of the records in hashRowContainer.
-func (c hashRowContainer) Len() int {
+func (c *hashRowContainer) Len() int {
 	return c.hashTable.Len()
 }
 
+// Close releases the disk resource of hashRowContainer.
+func (c *hashRowContainer) Close() error {
+	if c.recordsInDisk != nil {
+		return c.recordsInDisk.Close()
+	}
+	return nil
+}
+
+// ActionSpill returns a memory.ActionOnExceed for spilling over to disk.
+func (c *hashRowContainer) ActionSpill() memory.ActionOnExceed {
+	return &spillDiskAction{c: c}
+}
+
+// spillDiskAction implements memory.ActionOnExceed for hashRowContainer.
+// If the memory quota of a query is exceeded, spillDiskAction.Action is
+// triggered to mark the container, and the records are spilled out on the
+// next PutChunk. If the records can't be spilled any more, because they have
+// been spilled out already or the hash table has been built, the fallback
+// action is triggered.
+type spillDiskAction struct {
+	c              *hashRowContainer
+	fallbackAction memory.ActionOnExceed
+}
+
+// Action sends a signal to trigger spillToDisk method of hashRowContainer
+// and if the records can't be spilled any more, call its fallbackAction.
+func (a *spillDiskAction) Action(t *memory.Tracker) {
+	if a.c.alreadySpilled() || atomic.LoadUint32(&a.c.built) == 1 {
+		if a.fallbackAction != nil {
+			a.fallbackAction.Action(t)
+		}
+		return
+	}
+	atomic.StoreUint32(&a.c.exceeded, 1)
+}
+
+// SetFallback sets the fallback action.
+func (a *spillDiskAction) SetFallback(fallback memory.ActionOnExceed) {
+	a.fallbackAction = fallback
+}
+
+// SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
+func (a *spillDiskAction) SetLogHook(hook func(uint64)) {}
+
 const (
 	initialEntrySliceLen = 64
 	maxEntrySliceLen     = 8 * 1024
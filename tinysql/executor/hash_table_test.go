@@ -15,7 +15,12 @@ package executor
 
 import (
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/mock"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 func (s *pkgTestSuite) TestRowHashMap(c *C) {
@@ -48,3 +53,43 @@ func (s *pkgTestSuite) TestRowHashMap(c *C) {
 	}
 	c.Check(m.Len(), Equals, totalCount)
 }
+
+func (s *pkgTestSuite) TestHashRowContainerSpillAction(c *C) {
+	sctx := mock.NewContext()
+	fields := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	newContainer := func(bytesLimit int64) (*hashRowContainer, *memory.Tracker, *int) {
+		hCtx := &hashContext{allTypes: fields, keyColIdx: []int{0}}
+		rc := newHashRowContainer(sctx, 0, hCtx, chunk.NewList(fields, 1, 1))
+		fallbackCnt := 0
+		fallback := &memory.LogOnExceed{}
+		fallback.SetLogHook(func(uint64) { fallbackCnt++ })
+		action := rc.ActionSpill()
+		action.SetFallback(fallback)
+		tracker := memory.NewTracker(stringutil.StringerStr("testTracker"), bytesLimit)
+		tracker.SetActionOnExceed(action)
+		rc.memTracker.AttachTo(tracker)
+		return rc, tracker, &fallbackCnt
+	}
+	chk := chunk.NewChunkWithCapacity(fields, 1)
+	chk.AppendInt64(0, 1)
+
+	// Exceeding the quota during building spills the records out, exceeding
+	// it again after spilling goes to the fallback action.
+	rc, tracker, fallbackCnt := newContainer(1)
+	c.Assert(rc.PutChunk(chk), IsNil)
+	c.Assert(rc.alreadySpilled(), IsTrue)
+	c.Assert(*fallbackCnt, Equals, 0)
+	tracker.Consume(1)
+	c.Assert(*fallbackCnt, Equals, 1)
+	c.Assert(rc.Close(), IsNil)
+
+	// Exceeding the quota after building goes to the fallback action too.
+	rc, tracker, fallbackCnt = newContainer(-1)
+	c.Assert(rc.PutChunk(chk), IsNil)
+	rc.finishBuild()
+	tracker.SetBytesLimit(1)
+	tracker.Consume(1)
+	c.Assert(rc.alreadySpilled(), IsFalse)
+	c.Assert(*fallbackCnt, Equals, 1)
+	c.Assert(rc.Close(), IsNil)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
@@ -46,10 +47,18 @@ type InsertValues struct {
 	Lists   [][]expression.Expression
 	SetList []*expression.Assignment
 
+	GenExprs []expression.Expression
+
 	insertColumns []*table.Column
 
 	allAssignmentsAreConstant bool
 
+	// viewChecks are the WITH CHECK OPTION conditions of the view the rows
+	// are inserted through.
+	viewChecks []*plannercore.ViewCheck
+	// checkConstraints are the enforced CHECK constraints of the table.
+	checkConstraints []*plannercore.CheckConstraint
+
 	// colDefaultVals is used to store casted default value.
 	// Because not every insert statement needs colDefaultVals, so we will init the buffer lazily.
 	colDefaultVals  []defaultVal
@@ -213,9 +222,65 @@ func insertRows(ctx context.Context, base insertCommon) (err error) {
 	if err != nil {
 		return err
 	}
+	if err = e.checkViewOption(rows); err != nil {
+		return err
+	}
+	if err = e.checkRowConstraints(rows); err != nil {
+		return err
+	}
 	return base.exec(ctx, rows)
 }
 
+// checkViewOption checks the rows inserted through a view WITH CHECK OPTION,
+// a row the condition of the view is not true on is rejected.
+func (e *InsertValues) checkViewOption(rows [][]types.Datum) error {
+	if len(e.viewChecks) == 0 {
+		return nil
+	}
+	for _, row := range rows {
+		r := chunk.MutRowFromDatums(row).ToRow()
+		for _, check := range e.viewChecks {
+			ok, _, err := expression.EvalBool(e.ctx, []expression.Expression{check.Expr}, r)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return ErrViewCheckFailed.GenWithStackByArgs(check.DBName.O, check.ViewName.O)
+			}
+		}
+	}
+	return nil
+}
+
+// checkRowConstraints checks the rows against the CHECK constraints of the table, a row
+// is rejected if a constraint is false on it, NULL doesn't violate the constraint.
+func (e *InsertValues) checkRowConstraints(rows [][]types.Datum) error {
+	if len(e.checkConstraints) == 0 {
+		return nil
+	}
+	sc := e.ctx.GetSessionVars().StmtCtx
+	for _, row := range rows {
+		r := chunk.MutRowFromDatums(row).ToRow()
+		for _, constraint := range e.checkConstraints {
+			val, err := constraint.Expr.Eval(r)
+			if err != nil {
+				return err
+			}
+			if val.IsNull() {
+				continue
+			}
+			ok, err := val.ToBool(sc)
+			if err != nil {
+				return err
+			}
+			if ok == 0 {
+				return table.ErrCheckConstraintViolated.GenWithStackByArgs(constraint.Name.O)
+			}
+		}
+	}
+	return nil
+}
+
 func (e *InsertValues) handleErr(col *table.Column, val *types.Datum, rowIdx int, err error) error {
 	if err == nil {
 		return nil
@@ -235,7 +300,8 @@ func (e *InsertValues) handleErr(col *table.Column, val *types.Datum, rowIdx int
 		err = resetErrDataTooLong(colName, rowIdx+1, err)
 	} else if types.ErrOverflow.Equal(err) {
 		err = types.ErrWarnDataOutOfRange.GenWithStackByArgs(colName, rowIdx+1)
-	} else if types.ErrTruncated.Equal(err) || types.ErrTruncatedWrongVal.Equal(err) {
+	} else if (types.ErrTruncated.Equal(err) && colTp != mysql.TypeEnum && colTp != mysql.TypeSet) ||
+		types.ErrTruncatedWrongVal.Equal(err) {
 		valStr, err1 := val.ToString()
 		if err1 != nil {
 			logutil.BgLogger().Warn("truncate value failed", zap.Error(err1))
@@ -269,7 +335,7 @@ func (e *InsertValues) evalRow(ctx context.Context, list []expression.Expression
 		if err = e.handleErr(e.insertColumns[i], &val, rowIdx, err); err != nil {
 			return nil, err
 		}
-		val1, err := table.CastValue(e.ctx, val, e.insertColumns[i].ToInfo())
+		val1, err := table.CastValueForRow(e.ctx, val, e.insertColumns[i].ToInfo(), rowIdx)
 		if err = e.handleErr(e.insertColumns[i], &val, rowIdx, err); err != nil {
 			return nil, err
 		}
@@ -297,7 +363,7 @@ func (e *InsertValues) fastEvalRow(ctx context.Context, list []expression.Expres
 		if err = e.handleErr(e.insertColumns[i], &val, rowIdx, err); err != nil {
 			return nil, err
 		}
-		val1, err := table.CastValue(e.ctx, val, e.insertColumns[i].ToInfo())
+		val1, err := table.CastValueForRow(e.ctx, val, e.insertColumns[i].ToInfo(), rowIdx)
 		if err = e.handleErr(e.insertColumns[i], &val, rowIdx, err); err != nil {
 			return nil, err
 		}
@@ -371,6 +437,14 @@ func insertRowsFromSelect(ctx context.Context, base insertCommon) error {
 			rows = append(rows, row)
 		}
 
+		err = e.checkViewOption(rows)
+		if err != nil {
+			return err
+		}
+		err = e.checkRowConstraints(rows)
+		if err != nil {
+			return err
+		}
 		err = base.exec(ctx, rows)
 		if err != nil {
 			return err
@@ -387,7 +461,7 @@ func (e *InsertValues) getRow(ctx context.Context, vals []types.Datum) ([]types.
 	row := make([]types.Datum, len(e.Table.Cols()))
 	hasValue := make([]bool, len(e.Table.Cols()))
 	for i, v := range vals {
-		casted, err := table.CastValue(e.ctx, v, e.insertColumns[i].ToInfo())
+		casted, err := table.CastValueForRow(e.ctx, v, e.insertColumns[i].ToInfo(), int(e.rowCount)-1)
 		if e.handleErr(nil, &v, 0, err) != nil {
 			return nil, err
 		}
@@ -453,8 +527,14 @@ func (e *InsertValues) fillColValue(ctx context.Context, datum types.Datum, idx
 // Other statements like `insert select from` don't guarantee consecutive autoID.
 // https://dev.mysql.com/doc/refman/8.0/en/innodb-auto-increment-handling.html
 func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue []bool) ([]types.Datum, error) {
+	gCols := make([]*table.Column, 0)
 	for i, c := range e.Table.Cols() {
 		var err error
+		// Evaluate the generated columns later after real columns set.
+		if c.IsGenerated() {
+			gCols = append(gCols, c)
+			continue
+		}
 		// Get the default value for all no value columns, the auto increment column is different from the others.
 		if row[i], err = e.fillColValue(ctx, row[i], i, c, hasValue[i]); err != nil {
 			return nil, err
@@ -465,6 +545,21 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			}
 		}
 	}
+	for i, gCol := range gCols {
+		colIdx := gCol.Offset
+		val, err := e.GenExprs[i].Eval(chunk.MutRowFromDatums(row).ToRow())
+		if err != nil {
+			return nil, err
+		}
+		row[colIdx], err = table.CastValue(e.ctx, val, gCol.ToInfo())
+		if err != nil {
+			return nil, err
+		}
+		// Handle the bad null error.
+		if row[colIdx], err = gCol.HandleBadNull(row[colIdx], e.ctx.GetSessionVars().StmtCtx); err != nil {
+			return nil, err
+		}
+	}
 	return row, nil
 }
 
@@ -674,6 +769,9 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) (int64,
 	if err != nil {
 		return 0, err
 	}
+	if err = checkForeignKeysOnInsert(ctx, e.ctx, txn, e.Table, row); err != nil {
+		return 0, err
+	}
 	if e.lastInsertID != 0 {
 		e.ctx.GetSessionVars().SetLastInsertID(e.lastInsertID)
 	}
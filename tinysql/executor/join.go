@@ -18,11 +18,14 @@ import (
 	"sync"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/terror"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/memory"
 )
 
 var _ Executor = &HashJoinExec{}
@@ -56,6 +59,9 @@ type HashJoinExec struct {
 	joinChkResourceCh  []chan *chunk.Chunk
 	joinResultCh       chan *hashjoinWorkerResult
 
+	memTracker  *memory.Tracker // track memory usage.
+	diskTracker *memory.Tracker // track disk usage.
+
 	prepared bool
 }
 
@@ -102,6 +108,14 @@ func (e *HashJoinExec) Close() error {
 		e.outerChkResourceCh = nil
 		e.joinChkResourceCh = nil
 	}
+	if e.rowContainer != nil {
+		terror.Log(e.rowContainer.Close())
+		e.rowContainer = nil
+	}
+	if e.memTracker != nil {
+		e.memTracker.Detach()
+		e.diskTracker.Detach()
+	}
 	err := e.baseExecutor.Close()
 	return err
 }
@@ -115,6 +129,11 @@ func (e *HashJoinExec) Open(ctx context.Context) error {
 	e.prepared = false
 	e.closeCh = make(chan struct{})
 	e.joinWorkerWaitGroup = sync.WaitGroup{}
+
+	e.memTracker = memory.NewTracker(e.id, -1)
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	e.diskTracker = memory.NewTracker(e.id, -1)
+	e.diskTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.DiskTracker)
 	return nil
 }
 
@@ -157,6 +176,11 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) error {
 	}
 	initList := chunk.NewList(allTypes, e.initCap, e.maxChunkSize)
 	e.rowContainer = newHashRowContainer(e.ctx, int(e.innerSideEstCount), hCtx, initList)
+	e.rowContainer.memTracker.AttachTo(e.memTracker)
+	e.rowContainer.diskTracker.AttachTo(e.diskTracker)
+	if stmtTracker := e.ctx.GetSessionVars().StmtCtx.MemTracker; stmtTracker != nil && config.GetGlobalConfig().OOMUseTmpStorage {
+		stmtTracker.FallbackOldAndSetNewAction(e.rowContainer.ActionSpill())
+	}
 
 	for {
 		chk := chunk.NewChunkWithCapacity(e.innerSideExec.base().retFieldTypes, e.ctx.GetSessionVars().MaxChunkSize)
@@ -165,6 +189,7 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) error {
 			return err
 		}
 		if chk.NumRows() == 0 {
+			e.rowContainer.finishBuild()
 			return nil
 		}
 		err = e.rowContainer.PutChunk(chk)
@@ -412,3 +412,44 @@ func (s *testSuiteJoin1) TestInjectProjOnTopN(c *C) {
 		"2",
 	))
 }
+
+func (s *testSuiteJoin2) TestIndexLookupJoin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_index_join_batch_size = 2")
+	tk.MustExec("drop table if exists t, s")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("create table s(a int primary key, b int, c varchar(20), key idx_b(b), key idx_c(c(2)))")
+	tk.MustExec("insert into t values(1, 1), (2, 2), (3, 3), (null, null), (4, 10), (1, 1)")
+	tk.MustExec("insert into s values(1, 1, 'abc'), (2, 2, 'abd'), (3, 30, 'xyz'), (5, 5, 'xya')")
+
+	// The inner side is accessed by the primary key.
+	rows := tk.MustQuery("explain select /*+ INL_JOIN(s) */ * from t join s on t.a = s.a").Rows()
+	c.Assert(rows[0][0], Matches, "IndexJoin.*")
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.a, s.b from t join s on t.a = s.a order by t.a").Check(testkit.Rows(
+		"1 1", "1 1", "2 2", "3 30"))
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.a, s.a from t left join s on t.a = s.a order by t.a, t.b").Check(testkit.Rows(
+		"<nil> <nil>", "1 1", "1 1", "2 2", "3 3", "4 <nil>"))
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.a, s.a from s right join t on t.a = s.a order by t.a, t.b").Check(testkit.Rows(
+		"<nil> <nil>", "1 1", "1 1", "2 2", "3 3", "4 <nil>"))
+
+	// The inner side is accessed by a secondary index, with or without a double read.
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.b, s.a, s.c from t join s on t.b = s.b order by t.b").Check(testkit.Rows(
+		"1 1 abc", "1 1 abc", "2 2 abd"))
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.b, s.b from t left join s on t.b = s.b and s.b > 1 order by t.a, t.b").Check(testkit.Rows(
+		"<nil> <nil>", "1 <nil>", "1 <nil>", "2 2", "3 <nil>", "10 <nil>"))
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t.b, s.b from t join s on t.b = s.b where t.a > 1").Check(testkit.Rows(
+		"2 2"))
+
+	// The inner side is accessed by a prefix index.
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1(a int, c varchar(20))")
+	tk.MustExec("insert into t1 values(1, 'abc'), (2, 'abd'), (3, 'xy'), (4, 'xyz')")
+	tk.MustQuery("select /*+ INL_JOIN(s) */ t1.c, s.a from t1 join s on t1.c = s.c order by t1.c").Check(testkit.Rows(
+		"abc 1", "abd 2", "xyz 3"))
+
+	// The hint is reported as inapplicable when neither side has a usable index.
+	tk.MustQuery("select /*+ INL_JOIN(t1) */ * from t join t1 on t.a = t1.a")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1815 Optimizer Hint /*+ INL_JOIN(t1) */ or /*+ TIDB_INLJ(t1) */ is inapplicable"))
+}
@@ -140,6 +140,9 @@ type memTableReader struct {
 	retFieldTypes []*types.FieldType
 	colIDs        map[int64]int
 	buffer        allocBuf
+	// schema and virtualColumnIndex are used to compute the virtual columns.
+	schema             *expression.Schema
+	virtualColumnIndex []int
 }
 
 type allocBuf struct {
@@ -181,6 +184,8 @@ func buildMemTableReader(us *UnionScanExec, tblReader *TableReaderExecutor) *mem
 			handleBytes: make([]byte, 0, 16),
 			rd:          rd,
 		},
+		schema:             us.Schema(),
+		virtualColumnIndex: buildVirtualColumnIndex(us.Schema(), us.columns),
 	}
 }
 
@@ -194,6 +199,9 @@ func (m *memTableReader) getMemRows() ([][]types.Datum, error) {
 		}
 
 		mutableRow.SetDatums(row...)
+		if err = m.fillVirtualColumnValue(row, mutableRow); err != nil {
+			return err
+		}
 		matched, _, err := expression.EvalBool(m.ctx, m.conditions, mutableRow.ToRow())
 		if err != nil || !matched {
 			return err
@@ -212,6 +220,27 @@ func (m *memTableReader) getMemRows() ([][]types.Datum, error) {
 	return m.addedRows, nil
 }
 
+// fillVirtualColumnValue computes the virtual columns of the decoded row in
+// definition order, the values are filled into both row and mutableRow.
+func (m *memTableReader) fillVirtualColumnValue(row []types.Datum, mutableRow chunk.MutRow) error {
+	for _, idx := range m.virtualColumnIndex {
+		datum, err := m.schema.Columns[idx].EvalVirtualColumn(mutableRow.ToRow())
+		if err != nil {
+			return err
+		}
+		castDatum, err := table.CastValue(m.ctx, datum, m.columns[idx])
+		if err != nil {
+			return err
+		}
+		if (mysql.HasNotNullFlag(m.columns[idx].Flag) || mysql.HasPreventNullInsertFlag(m.columns[idx].Flag)) && castDatum.IsNull() {
+			castDatum = table.GetZeroValue(m.columns[idx])
+		}
+		row[idx] = castDatum
+		mutableRow.SetDatum(idx, castDatum)
+	}
+	return nil
+}
+
 func (m *memTableReader) decodeRecordKeyValue(key, value []byte) ([]types.Datum, error) {
 	handle, err := tablecodec.DecodeRowKey(key)
 	if err != nil {
@@ -325,6 +354,7 @@ type memIndexLookUpReader struct {
 	desc          bool
 	conditions    []expression.Expression
 	retFieldTypes []*types.FieldType
+	schema        *expression.Schema
 
 	idxReader *memIndexReader
 }
@@ -352,6 +382,7 @@ func buildMemIndexLookUpReader(us *UnionScanExec, idxLookUpReader *IndexLookUpEx
 		desc:          idxLookUpReader.desc,
 		conditions:    us.conditions,
 		retFieldTypes: retTypes(us),
+		schema:        us.Schema(),
 		idxReader:     memIdxReader,
 	}
 }
@@ -392,6 +423,8 @@ func (m *memIndexLookUpReader) getMemRows() ([][]types.Datum, error) {
 			handleBytes: make([]byte, 0, 16),
 			rd:          rd,
 		},
+		schema:             m.schema,
+		virtualColumnIndex: buildVirtualColumnIndex(m.schema, m.columns),
 	}
 
 	return memTblReader.getMemRows()
@@ -0,0 +1,149 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+var _ = SerialSuites(&testOOMSuite{})
+
+type testOOMSuite struct {
+	store kv.Storage
+	dom   *domain.Domain
+}
+
+func (s *testOOMSuite) SetUpSuite(c *C) {
+	var err error
+	s.store, err = mockstore.NewMockTikvStore()
+	c.Assert(err, IsNil)
+	s.dom, err = session.BootstrapSession(s.store)
+	c.Assert(err, IsNil)
+}
+
+func (s *testOOMSuite) TearDownSuite(c *C) {
+	s.dom.Close()
+	s.store.Close()
+}
+
+func (s *testOOMSuite) setOOMConfig(action string, useTmpStorage bool) (restore func()) {
+	old := config.GetGlobalConfig()
+	newConf := *old
+	newConf.OOMAction = action
+	newConf.OOMUseTmpStorage = useTmpStorage
+	config.StoreGlobalConfig(&newConf)
+	return func() { config.StoreGlobalConfig(old) }
+}
+
+func (s *testOOMSuite) TestMemQuotaCancel(c *C) {
+	defer s.setOOMConfig(config.OOMActionCancel, false)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t1")
+	tk.MustExec("create table t(a int, b varchar(20))")
+	tk.MustExec("create table t1(a int, b varchar(20))")
+	tk.MustExec("insert into t values(1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into t1 values(1, 'a'), (2, 'b'), (3, 'c')")
+
+	tk.MustExec("set @@tidb_mem_quota_query = 1")
+	queries := []string{
+		"select * from t order by a",
+		"select * from t order by a limit 2",
+		"select a, count(*) from t group by a",
+		"select /*+ HASH_JOIN(t, t1) */ * from t join t1 on t.a = t1.a",
+	}
+	for _, q := range queries {
+		err := tk.QueryToErr(q)
+		c.Assert(err, NotNil, Commentf("sql: %s", q))
+		c.Assert(err.Error(), Matches, "Out Of Memory Quota!.*", Commentf("sql: %s", q))
+	}
+
+	// The memory quota hint overrides the session variable.
+	tk.MustQuery("select /*+ MEMORY_QUOTA(1 MB) */ * from t order by a").Check(testkit.Rows("1 a", "2 b", "3 c"))
+
+	tk.MustExec("set @@tidb_mem_quota_query = 0")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 a", "2 b", "3 c"))
+	tk.MustQuery("select a, count(*) from t group by a order by a").Check(testkit.Rows("1 1", "2 1", "3 1"))
+}
+
+func (s *testOOMSuite) TestHashJoinSpill(c *C) {
+	defer s.setOOMConfig(config.OOMActionCancel, true)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t1")
+	tk.MustExec("create table t(a int, b varchar(20))")
+	tk.MustExec("create table t1(a int, b varchar(20))")
+	tk.MustExec("insert into t values(1, 'a'), (2, 'b'), (3, 'c'), (null, 'd')")
+	tk.MustExec("insert into t1 values(1, 'x'), (2, 'y'), (2, 'z'), (4, 'w')")
+
+	sql := "select /*+ HASH_JOIN(t, t1) */ t.a, t.b, t1.b from t left join t1 on t.a = t1.a order by t.a, t1.b"
+	tk.MustQuery(sql).Check(testkit.Rows("<nil> d <nil>", "1 a x", "2 b y", "2 b z", "3 c <nil>"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), Equals, int64(0))
+
+	// The build side is spilled to disk instead of cancelling the query.
+	tk.MustExec("set @@tidb_mem_quota_query = 1")
+	sql = "select /*+ HASH_JOIN(t, t1) */ t.a, t.b, t1.b from t left join t1 on t.a = t1.a"
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 a x", "2 b y", "2 b z", "3 c <nil>", "<nil> d <nil>"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), Greater, int64(0))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.BytesConsumed(), Equals, int64(0))
+}
+
+func (s *testOOMSuite) TestSortSpill(c *C) {
+	defer s.setOOMConfig(config.OOMActionCancel, true)()
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b varchar(20), c int)")
+	for i := 0; i < 300; i++ {
+		a := (i * 7) % 300
+		if i%50 == 0 {
+			tk.MustExec(fmt.Sprintf("insert into t values(null, 'n%d', %d)", i, i%3))
+			continue
+		}
+		tk.MustExec(fmt.Sprintf("insert into t values(%d, 'v%d', %d)", a, a, i%3))
+	}
+	sqls := []string{
+		"select * from t order by a, b",
+		"select * from t order by a desc, b",
+		"select * from t order by c, a desc, b",
+	}
+	for _, sql := range sqls {
+		rows := tk.MustQuery(sql).Rows()
+		c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), Equals, int64(0))
+
+		// The rows are sorted in runs which are spilled to disk and merged later.
+		tk.MustExec("set @@tidb_max_chunk_size = 32")
+		tk.MustExec("set @@tidb_mem_quota_sort = 1")
+		tk.MustQuery(sql).Check(rows)
+		c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), Greater, int64(0))
+		c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.BytesConsumed(), Equals, int64(0))
+
+		// Exceeding the query quota spills the sort instead of cancelling the query,
+		// the quota only leaves room for the row offsets of the sorted runs.
+		tk.MustExec("set @@tidb_mem_quota_sort = default")
+		tk.MustExec("set @@tidb_mem_quota_query = 4096")
+		tk.MustQuery(sql).Check(rows)
+		c.Assert(tk.Se.GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), Greater, int64(0))
+		tk.MustExec("set @@tidb_mem_quota_query = default")
+		tk.MustExec("set @@tidb_max_chunk_size = default")
+	}
+}
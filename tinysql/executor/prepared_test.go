@@ -0,0 +1,194 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+func (s *testSuite1) TestPrepared(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists prepare_test")
+	tk.MustExec("create table prepare_test (id int PRIMARY KEY, c1 int, c2 int, index idx(c1))")
+	tk.MustExec("insert prepare_test values (1, 1, 1), (2, 2, 4), (3, 3, 9)")
+
+	tk.MustExec(`prepare stmt_test_1 from 'select id from prepare_test where id > ?'`)
+	tk.MustExec(`set @a = 1`)
+	tk.MustQuery(`execute stmt_test_1 using @a`).Check(testkit.Rows("2", "3"))
+	tk.MustExec(`set @a = 2`)
+	tk.MustQuery(`execute stmt_test_1 using @a`).Check(testkit.Rows("3"))
+
+	// The markers are ordered by their position in the statement.
+	tk.MustExec(`prepare stmt_test_2 from 'select c2 from prepare_test where c1 between ? and ? order by c2 limit ?'`)
+	tk.MustExec(`set @b = 3, @c = 1`)
+	tk.MustQuery(`execute stmt_test_2 using @a, @b, @c`).Check(testkit.Rows("4"))
+
+	// Prepare the statement text from a user variable.
+	tk.MustExec(`set @sql = 'insert into prepare_test values (?, ?, ?)'`)
+	tk.MustExec(`prepare stmt_test_3 from @sql`)
+	tk.MustExec(`set @d = 4, @e = 100`)
+	tk.MustExec(`execute stmt_test_3 using @d, @e, @e`)
+	tk.MustQuery(`select c2 from prepare_test where id = 4`).Check(testkit.Rows("100"))
+
+	_, err := tk.Exec(`execute stmt_test_1`)
+	c.Assert(core.ErrWrongParamCount.Equal(err), IsTrue)
+	_, err = tk.Exec(`execute stmt_test_1 using @a, @b`)
+	c.Assert(core.ErrWrongParamCount.Equal(err), IsTrue)
+	_, err = tk.Exec(`prepare stmt_test_4 from 'select 1; select 2'`)
+	c.Assert(executor.ErrPrepareMulti.Equal(err), IsTrue)
+	_, err = tk.Exec(`prepare stmt_test_4 from 'create table t (a int)'`)
+	c.Assert(executor.ErrPrepareDDL.Equal(err), IsTrue)
+	_, err = tk.Exec(`select ?`)
+	c.Assert(err, NotNil)
+
+	tk.MustExec(`deallocate prepare stmt_test_1`)
+	_, err = tk.Exec(`execute stmt_test_1 using @a`)
+	c.Assert(core.ErrStmtNotFound.Equal(err), IsTrue)
+	_, err = tk.Exec(`drop prepare stmt_test_1`)
+	c.Assert(core.ErrStmtNotFound.Equal(err), IsTrue)
+
+	// Execute a prepared statement after the schema has changed.
+	tk.MustExec(`alter table prepare_test add column c3 int default 7`)
+	tk.MustQuery(`execute stmt_test_2 using @a, @b, @c`).Check(testkit.Rows("4"))
+
+	// Binary protocol.
+	ctx := context.Background()
+	id, paramCount, fields, err := tk.Se.PrepareStmt("select id, c1 from prepare_test where id = ?")
+	c.Assert(err, IsNil)
+	c.Assert(paramCount, Equals, 1)
+	c.Assert(fields, HasLen, 2)
+	rs, err := tk.Se.ExecutePreparedStmt(ctx, id, []types.Datum{types.NewDatum(3)})
+	c.Assert(err, IsNil)
+	tk.ResultSetToResult(rs, Commentf("%v", rs)).Check(testkit.Rows("3 3"))
+	c.Assert(tk.Se.DropPreparedStmt(id), IsNil)
+	_, err = tk.Se.ExecutePreparedStmt(ctx, id, []types.Datum{types.NewDatum(3)})
+	c.Assert(core.ErrStmtNotFound.Equal(err), IsTrue)
+	c.Assert(core.ErrStmtNotFound.Equal(tk.Se.DropPreparedStmt(id)), IsTrue)
+}
+
+func (s *testSuite1) TestMaxPreparedStmtCount(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	defer tk.Se.Close()
+	// The limit is global, other sessions may still hold prepared statements.
+	limit := atomic.LoadInt64(&variable.PreparedStmtCount) + 2
+	err := tk.Se.GetSessionVars().SetSystemVar(variable.MaxPreparedStmtCount, strconv.FormatInt(limit, 10))
+	c.Assert(err, IsNil)
+	tk.MustExec(`prepare stmt1 from 'select 1'`)
+	tk.MustExec(`prepare stmt2 from 'select 1'`)
+	_, err = tk.Exec(`prepare stmt3 from 'select 1'`)
+	c.Assert(variable.ErrMaxPreparedStmtCountReached.Equal(err), IsTrue)
+	// Preparing a statement again with the same name does not leak the old one.
+	tk.MustExec(`prepare stmt2 from 'select 2'`)
+	tk.MustQuery(`execute stmt2`).Check(testkit.Rows("2"))
+	tk.MustExec(`deallocate prepare stmt1`)
+	tk.MustExec(`prepare stmt3 from 'select 3'`)
+	tk.MustQuery(`execute stmt3`).Check(testkit.Rows("3"))
+}
+
+func (s *testSuite1) TestPreparedPlanCache(c *C) {
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer core.SetPreparedPlanCache(orgEnable)
+	core.SetPreparedPlanCache(true)
+
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, c1 int, c2 int, index idx(c1))")
+	tk.MustExec("insert t values (1, 1, 1), (2, 2, 4), (3, 3, 9)")
+
+	// The ranges of a cached table scan are rebuilt from the new parameters.
+	tk.MustExec(`prepare stmt1 from 'select id from t where id > ?'`)
+	tk.MustExec(`set @a = 1`)
+	tk.MustQuery(`execute stmt1 using @a`).Check(testkit.Rows("2", "3"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk.MustExec(`set @a = 2`)
+	tk.MustQuery(`execute stmt1 using @a`).Check(testkit.Rows("3"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// A parameter of another type does not share the cached plan.
+	ctx := context.Background()
+	id, _, _, err := tk.Se.PrepareStmt("select id from t where id > ?")
+	c.Assert(err, IsNil)
+	tests := []struct {
+		param     types.Datum
+		result    []string
+		fromCache string
+	}{
+		{types.NewIntDatum(2), []string{"3"}, "0"},
+		{types.NewIntDatum(1), []string{"2", "3"}, "1"},
+		{types.NewFloat64Datum(0.5), []string{"1", "2", "3"}, "0"},
+		{types.NewFloat64Datum(2.5), []string{"3"}, "1"},
+	}
+	for _, tt := range tests {
+		rs, err := tk.Se.ExecutePreparedStmt(ctx, id, []types.Datum{tt.param})
+		c.Assert(err, IsNil)
+		tk.ResultSetToResult(rs, Commentf("%v", tt.param)).Check(testkit.Rows(tt.result...))
+		tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows(tt.fromCache))
+	}
+
+	// The ranges of a cached index scan are rebuilt as well. The user
+	// variables are strings, so the index is built on a string column.
+	tk.MustExec("drop table if exists t2")
+	tk.MustExec("create table t2 (a varchar(10), index idx(a))")
+	tk.MustExec("insert t2 values ('a'), ('b'), ('c')")
+	tk.MustExec(`prepare stmt2 from 'select a from t2 where a between ? and ?'`)
+	tk.MustExec(`set @a = 'a', @b = 'b'`)
+	tk.MustQuery(`execute stmt2 using @a, @b`).Check(testkit.Rows("a", "b"))
+	tk.MustExec(`set @a = 'c', @b = 'd'`)
+	tk.MustQuery(`execute stmt2 using @a, @b`).Check(testkit.Rows("c"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustQuery(`explain execute stmt2 using @a, @b`).Check(testkit.Rows(
+		"IndexReader_6 250.00 root index:IndexScan_5",
+		"└─IndexScan_5 250.00 cop table:t2, index:a, range:[\"c\",\"d\"], keep order:false, stats:pseudo",
+	))
+	tk.MustQuery(`show warnings`).Check(testkit.Rows("Note 1105 Use the plan from the plan cache"))
+
+	// Inserted values are read from the parameters of each execution.
+	tk.MustExec(`prepare stmt3 from 'insert into t values (?, ?, ?)'`)
+	tk.MustExec(`set @a = 4, @b = 16`)
+	tk.MustExec(`execute stmt3 using @a, @a, @b`)
+	tk.MustExec(`set @a = 5, @b = 25`)
+	tk.MustExec(`execute stmt3 using @a, @a, @b`)
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select c2 from t where id >= 4`).Check(testkit.Rows("16", "25"))
+
+	// Statements with a parameterized LIMIT are never cached.
+	tk.MustExec(`prepare stmt4 from 'select id from t order by id limit ?'`)
+	tk.MustExec(`set @a = 1`)
+	tk.MustQuery(`execute stmt4 using @a`).Check(testkit.Rows("1"))
+	tk.MustQuery(`execute stmt4 using @a`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+
+	// The cached plan is not used in a dirty transaction.
+	tk.MustExec(`set @a = 4`)
+	tk.MustQuery(`execute stmt1 using @a`).Check(testkit.Rows("5"))
+	tk.MustExec(`begin`)
+	tk.MustExec(`insert t values (6, 6, 36)`)
+	tk.MustQuery(`execute stmt1 using @a`).Check(testkit.Rows("5", "6"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+	tk.MustExec(`rollback`)
+	tk.MustQuery(`execute stmt1 using @a`).Check(testkit.Rows("5"))
+	tk.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+}
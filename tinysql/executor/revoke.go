@@ -0,0 +1,120 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+/***
+ * Revoke Statement
+ * See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+ ************************************************************************************/
+var (
+	_ Executor = (*RevokeExec)(nil)
+)
+
+// RevokeExec executes RevokeStmt.
+type RevokeExec struct {
+	baseExecutor
+
+	Privs      []*ast.PrivElem
+	ObjectType ast.ObjectTypeType
+	Level      *ast.GrantLevel
+	Users      []*ast.UserSpec
+
+	is   infoschema.InfoSchema
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *RevokeExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	dbName, err := checkGrantLevel(e.ctx, e.is, e.Level)
+	if err != nil {
+		return err
+	}
+	priv, err := levelPrivileges(e.Privs, e.Level.Level)
+	if err != nil {
+		return err
+	}
+
+	for _, user := range e.Users {
+		exists, err := userExists(e.ctx, user.User.Username, user.User.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNonexistingGrant.GenWithStackByArgs(user.User.Username, user.User.Hostname)
+		}
+
+		switch e.Level.Level {
+		case ast.GrantLevelGlobal:
+			err = e.revokeGlobalPriv(priv, user)
+		case ast.GrantLevelDB:
+			err = e.revokeDBPriv(priv, user, dbName)
+		case ast.GrantLevelTable:
+			err = e.revokeTablePriv(priv, user, dbName)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *RevokeExec) revokeGlobalPriv(priv mysql.PrivilegeType, user *ast.UserSpec) error {
+	sql := composeRewriteSQL(mysql.UserTable, userTableCols, privValues(priv, "N"),
+		userCond(user.User.Username, user.User.Hostname))
+	_, _, err := e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	return err
+}
+
+func (e *RevokeExec) revokeDBPriv(priv mysql.PrivilegeType, user *ast.UserSpec, dbName string) error {
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	cond := dbCond(user.User.Username, user.User.Hostname, dbName)
+	rows, _, err := exec.ExecRestrictedSQL(fmt.Sprintf("SELECT * FROM %s.%s WHERE %s", mysql.SystemDB, mysql.DBTable, cond))
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return ErrNonexistingGrant.GenWithStackByArgs(user.User.Username, user.User.Hostname)
+	}
+	_, _, err = exec.ExecRestrictedSQL(composeRewriteSQL(mysql.DBTable, dbTableCols, privValues(priv, "N"), cond))
+	return err
+}
+
+func (e *RevokeExec) revokeTablePriv(priv mysql.PrivilegeType, user *ast.UserSpec, dbName string) error {
+	oldPriv, exists, err := getTablePriv(e.ctx, user, dbName, e.Level.TableName)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonexistingGrant.GenWithStackByArgs(user.User.Username, user.User.Hostname)
+	}
+	return replaceTablePriv(e.ctx, user, dbName, e.Level.TableName, oldPriv&^priv)
+}
@@ -23,13 +23,17 @@ import (
 
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/bindinfo"
+	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -56,8 +60,10 @@ type ShowExec struct {
 	cursor int
 
 	Full        bool
-	IfNotExists bool // Used for `show create database if not exists`
-	GlobalScope bool // GlobalScope is used by show variables
+	IfNotExists bool                 // Used for `show create database if not exists`
+	GlobalScope bool                 // GlobalScope is used by show variables
+	User        *auth.UserIdentity   // Used for show grants.
+	Roles       []*auth.RoleIdentity // Used for show grants.
 }
 
 // Next implements the Executor Next interface.
@@ -95,6 +101,8 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	switch e.Tp {
 	case ast.ShowCreateTable:
 		return e.fetchShowCreateTable()
+	case ast.ShowCreateView:
+		return e.fetchShowCreateView()
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
 	case ast.ShowDatabases:
@@ -107,6 +115,58 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowWarnings(false)
 	case ast.ShowErrors:
 		return e.fetchShowWarnings(true)
+	case ast.ShowBindings:
+		return e.fetchShowBind()
+	case ast.ShowGrants:
+		return e.fetchShowGrants()
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowGrants() error {
+	// The sessions without a user are internal ones, which have no grants
+	// to show.
+	if e.User == nil {
+		return nil
+	}
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	if checker == nil {
+		return errors.New("miss privilege checker")
+	}
+	for _, role := range e.Roles {
+		if !checker.FindEdge(e.ctx, role, e.User) {
+			return ErrRoleNotGranted.GenWithStackByArgs(role.String(), e.User.String())
+		}
+	}
+	gs, err := checker.ShowGrants(e.ctx, e.User, e.Roles)
+	if err != nil {
+		return err
+	}
+	for _, g := range gs {
+		e.appendRow([]interface{}{g})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowBind() error {
+	var bindRecords []*bindinfo.BindRecord
+	if !e.GlobalScope {
+		handle := e.ctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
+		bindRecords = handle.GetAllBindRecord()
+	} else {
+		bindRecords = domain.GetDomain(e.ctx).BindHandle().GetAllBindRecord()
+	}
+	for _, bindData := range bindRecords {
+		e.appendRow([]interface{}{
+			bindData.OriginalSQL,
+			bindData.BindSQL,
+			bindData.Db,
+			bindData.Status,
+			bindData.CreateTime,
+			bindData.UpdateTime,
+			bindData.Charset,
+			bindData.Collation,
+		})
 	}
 	return nil
 }
@@ -129,7 +189,11 @@ func (e *ShowExec) fetchShowDatabases() error {
 	sort.Strings(dbs)
 	// let information_schema be the first database
 	moveInfoSchemaToFront(dbs)
+	checker := privilege.GetPrivilegeManager(e.ctx)
 	for _, d := range dbs {
+		if checker != nil && !checker.DBIsVisible(e.ctx.GetSessionVars().ActiveRoles, d) {
+			continue
+		}
 		e.appendRow([]interface{}{
 			d,
 		})
@@ -144,9 +208,18 @@ func (e *ShowExec) fetchShowTables() error {
 	// sort for tables
 	tableNames := make([]string, 0, len(e.is.SchemaTables(e.DBName)))
 	var tableTypes = make(map[string]string)
+	checker := privilege.GetPrivilegeManager(e.ctx)
 	for _, v := range e.is.SchemaTables(e.DBName) {
+		// Test with mysql.AllPrivMask means any privilege would be OK.
+		if checker != nil && !checker.RequestVerification(e.ctx.GetSessionVars().ActiveRoles, e.DBName.O, v.Meta().Name.O, "", mysql.AllPrivMask) {
+			continue
+		}
 		tableNames = append(tableNames, v.Meta().Name.O)
-		tableTypes[v.Meta().Name.O] = "BASE TABLE"
+		if v.Meta().IsView() {
+			tableTypes[v.Meta().Name.O] = "VIEW"
+		} else {
+			tableTypes[v.Meta().Name.O] = "BASE TABLE"
+		}
 	}
 	sort.Strings(tableNames)
 	for _, v := range tableNames {
@@ -258,6 +331,15 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 				}
 			}
 		}
+		if col.IsGenerated() {
+			// It's a generated column.
+			fmt.Fprintf(buf, " GENERATED ALWAYS AS (%s)", col.GeneratedExprString)
+			if col.GeneratedStored {
+				buf.WriteString(" STORED")
+			} else {
+				buf.WriteString(" VIRTUAL")
+			}
+		}
 		if mysql.HasAutoIncrementFlag(col.Flag) {
 			hasAutoIncID = true
 			buf.WriteString(" NOT NULL AUTO_INCREMENT")
@@ -266,13 +348,16 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 				buf.WriteString(" NOT NULL")
 			}
 			// default values are not shown for generated columns in MySQL
-			if !mysql.HasNoDefaultValueFlag(col.Flag) {
+			if !mysql.HasNoDefaultValueFlag(col.Flag) && !col.IsGenerated() {
 				defaultValue := col.GetDefaultValue()
 				switch defaultValue {
 				case nil:
 					if !mysql.HasNotNullFlag(col.Flag) {
 						buf.WriteString(" DEFAULT NULL")
 					}
+				case "CURRENT_TIMESTAMP":
+					buf.WriteString(" DEFAULT CURRENT_TIMESTAMP")
+					buf.WriteString(table.OptionalFsp(&col.FieldType))
 				default:
 					defaultValStr := fmt.Sprintf("%v", defaultValue)
 
@@ -334,6 +419,47 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	var dbName model.CIStr
+	if dbInfo, ok := infoschema.GetInfoSchema(ctx).SchemaByTable(tableInfo); ok {
+		dbName = dbInfo.Name
+	}
+	for _, fk := range tableInfo.ForeignKeys {
+		if fk.State != model.StatePublic {
+			continue
+		}
+		cols := make([]string, 0, len(fk.Cols))
+		for _, c := range fk.Cols {
+			cols = append(cols, escape(c, sqlMode))
+		}
+		refCols := make([]string, 0, len(fk.RefCols))
+		for _, c := range fk.RefCols {
+			refCols = append(refCols, escape(c, sqlMode))
+		}
+		refTable := escape(fk.RefTable, sqlMode)
+		// The schema of the referenced table is shown only if it's not the schema of the table.
+		if fk.RefSchema.L != "" && fk.RefSchema.L != dbName.L {
+			refTable = escape(fk.RefSchema, sqlMode) + "." + refTable
+		}
+		fmt.Fprintf(buf, ",\n  CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (%s)", escape(fk.Name, sqlMode),
+			strings.Join(cols, ","), refTable, strings.Join(refCols, ","))
+		if onDelete := ast.ReferOptionType(fk.OnDelete); onDelete != ast.ReferOptionNoOption {
+			fmt.Fprintf(buf, " ON DELETE %s", onDelete)
+		}
+		if onUpdate := ast.ReferOptionType(fk.OnUpdate); onUpdate != ast.ReferOptionNoOption {
+			fmt.Fprintf(buf, " ON UPDATE %s", onUpdate)
+		}
+	}
+
+	for _, constraint := range tableInfo.Constraints {
+		if constraint.State != model.StatePublic {
+			continue
+		}
+		fmt.Fprintf(buf, ",\n  CONSTRAINT %s CHECK (%s)", escape(constraint.Name, sqlMode), constraint.ExprString)
+		if !constraint.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	buf.WriteString(") ENGINE=InnoDB")
@@ -374,15 +500,51 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 	if len(tableInfo.Comment) > 0 {
 		fmt.Fprintf(buf, " COMMENT='%s'", format.OutputFormat(tableInfo.Comment))
 	}
+	// add partition info here.
+	appendPartitionInfo(tableInfo.Partition, buf)
 	return nil
 }
 
+// appendPartitionInfo is used in SHOW CREATE TABLE to display the partitions.
+func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer) {
+	if partitionInfo == nil {
+		return
+	}
+	if partitionInfo.Type == model.PartitionTypeHash {
+		fmt.Fprintf(buf, "\nPARTITION BY HASH( %s )", partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITIONS %d", partitionInfo.Num)
+		return
+	}
+	fmt.Fprintf(buf, "\nPARTITION BY %s ( %s ) (\n", partitionInfo.Type.String(), partitionInfo.Expr)
+	for i, def := range partitionInfo.Definitions {
+		if partitionInfo.Type == model.PartitionTypeRange {
+			fmt.Fprintf(buf, "  PARTITION %s VALUES LESS THAN (%s)", def.Name, strings.Join(def.LessThan, ","))
+		} else {
+			values := make([]string, 0, len(def.InValues))
+			for _, vs := range def.InValues {
+				values = append(values, strings.Join(vs, ","))
+			}
+			fmt.Fprintf(buf, "  PARTITION %s VALUES IN (%s)", def.Name, strings.Join(values, ","))
+		}
+		if i < len(partitionInfo.Definitions)-1 {
+			buf.WriteString(",\n")
+		} else {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString(")")
+}
+
 func (e *ShowExec) fetchShowCreateTable() error {
 	tb, err := e.getTable()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
+	if tb.Meta().IsView() {
+		return e.fetchShowCreateView()
+	}
+
 	allocator := tb.Allocator(e.ctx)
 	var buf bytes.Buffer
 	// TODO: let the result more like MySQL.
@@ -394,6 +556,40 @@ func (e *ShowExec) fetchShowCreateTable() error {
 	return nil
 }
 
+func (e *ShowExec) fetchShowCreateView() error {
+	tb, err := e.getTable()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var buf bytes.Buffer
+	ConstructResultOfShowCreateView(e.ctx, tb.Meta(), &buf)
+	e.appendRow([]interface{}{tb.Meta().Name.O, buf.String(), tb.Meta().Charset, tb.Meta().Collate})
+	return nil
+}
+
+// ConstructResultOfShowCreateView constructs the result for show create view.
+func ConstructResultOfShowCreateView(ctx sessionctx.Context, tableInfo *model.TableInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	view := tableInfo.View
+	fmt.Fprintf(buf, "CREATE ALGORITHM=%s ", view.Algorithm.String())
+	if view.Definer != nil && view.Definer.Username != "" {
+		fmt.Fprintf(buf, "DEFINER=%s@%s ", escape(model.NewCIStr(view.Definer.Username), sqlMode),
+			escape(model.NewCIStr(view.Definer.Hostname), sqlMode))
+	}
+	fmt.Fprintf(buf, "SQL SECURITY %s VIEW %s (", view.Security.String(), escape(tableInfo.Name, sqlMode))
+	for i, col := range tableInfo.Columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(escape(col.Name, sqlMode))
+	}
+	fmt.Fprintf(buf, ") AS %s", view.SelectStmt)
+	if view.CheckOption != model.CheckOptionNone {
+		fmt.Fprintf(buf, " WITH %s CHECK OPTION", view.CheckOption.String())
+	}
+}
+
 // ConstructResultOfShowCreateDatabase constructs the result for show create database.
 func ConstructResultOfShowCreateDatabase(ctx sessionctx.Context, dbInfo *model.DBInfo, ifNotExists bool, buf *bytes.Buffer) (err error) {
 	sqlMode := ctx.GetSessionVars().SQLMode
@@ -476,6 +672,8 @@ func (e *ShowExec) appendRow(row []interface{}) {
 			e.result.AppendString(i, x)
 		case []byte:
 			e.result.AppendBytes(i, x)
+		case types.Time:
+			e.result.AppendTime(i, x)
 		default:
 			e.result.AppendNull(i)
 		}
@@ -79,3 +79,105 @@ func (s *testSuite5) TestShowEscape(c *C) {
 	tk.MustExec("drop table \"t`abl\"\"e\"")
 	tk.MustExec("set sql_mode=@old_sql_mode")
 }
+
+func (s *testSuite5) TestShowCreateView(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_show_view")
+	tk.MustExec("drop view if exists v_show, v_show_check")
+	tk.MustExec("create table t_show_view (a int, b int)")
+	tk.MustExec("create view v_show (x, y) as select a, b from t_show_view where a > 1")
+	tk.MustQuery("show create view v_show").Check(testutil.RowsWithSep("|",
+		"v_show|CREATE ALGORITHM=UNDEFINED SQL SECURITY DEFINER VIEW `v_show` (`x`, `y`) AS select a, b from t_show_view where a > 1|utf8mb4|utf8mb4_bin"))
+	tk.MustQuery("show create table v_show").Check(testutil.RowsWithSep("|",
+		"v_show|CREATE ALGORITHM=UNDEFINED SQL SECURITY DEFINER VIEW `v_show` (`x`, `y`) AS select a, b from t_show_view where a > 1|utf8mb4|utf8mb4_bin"))
+	tk.MustExec("create algorithm = merge definer = 'root'@'%' sql security invoker view v_show_check as select * from v_show with local check option")
+	tk.MustQuery("show create view v_show_check").Check(testutil.RowsWithSep("|",
+		"v_show_check|CREATE ALGORITHM=MERGE DEFINER=`root`@`%` SQL SECURITY INVOKER VIEW `v_show_check` (`x`, `y`) AS select * from v_show WITH LOCAL CHECK OPTION|utf8mb4|utf8mb4_bin"))
+	tk.MustQuery("show full tables where Table_type = 'VIEW'").Check(testkit.Rows("v_show VIEW", "v_show_check VIEW"))
+	_, err := tk.Exec("show create view t_show_view")
+	c.Assert(err.Error(), Equals, "[ddl:1347]'test.t_show_view' is not VIEW")
+	tk.MustExec("drop view v_show, v_show_check")
+	tk.MustExec("drop table t_show_view")
+}
+
+func (s *testSuite5) TestShowCreateTableGeneratedColumn(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists tg")
+	tk.MustExec("create table tg (a int, b varchar(10) as (lower(a)) virtual not null, c int as (a + 1) stored)")
+	tk.MustQuery("show create table tg").Check(testutil.RowsWithSep("|",
+		""+
+			"tg CREATE TABLE `tg` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` varchar(10) GENERATED ALWAYS AS (lower(a)) VIRTUAL NOT NULL,\n"+
+			"  `c` int(11) GENERATED ALWAYS AS (a + 1) STORED\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+	))
+}
+
+func (s *testSuite5) TestShowCreateTableCheckConstraint(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists tc")
+	tk.MustExec("create table tc (a int check (a > 0), b int, key idx_b(b), constraint c_ab check (a < b) not enforced)")
+	tk.MustQuery("show create table tc").Check(testutil.RowsWithSep("|",
+		""+
+			"tc CREATE TABLE `tc` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL,\n"+
+			"  KEY `idx_b` (`b`),\n"+
+			"  CONSTRAINT `c_ab` CHECK (a < b) /*!80016 NOT ENFORCED */,\n"+
+			"  CONSTRAINT `tc_chk_1` CHECK (a > 0)\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+	))
+}
+
+func (s *testSuite5) TestShowCreateTableForeignKey(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_c, fk_p")
+	tk.MustExec("create table fk_p (id int primary key, a int, unique key (a))")
+	tk.MustExec("create table fk_c (a int, b int, key idx_a(a), foreign key (a) references fk_p (id) on delete cascade, constraint fk_b foreign key (b) references test.fk_p (a) on delete set null on update restrict)")
+	tk.MustQuery("show create table fk_c").Check(testutil.RowsWithSep("|",
+		""+
+			"fk_c CREATE TABLE `fk_c` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL,\n"+
+			"  KEY `idx_a` (`a`),\n"+
+			"  KEY `fk_b` (`b`),\n"+
+			"  CONSTRAINT `fk_c_ibfk_1` FOREIGN KEY (`a`) REFERENCES `fk_p` (`id`) ON DELETE CASCADE,\n"+
+			"  CONSTRAINT `fk_b` FOREIGN KEY (`b`) REFERENCES `fk_p` (`a`) ON DELETE SET NULL ON UPDATE RESTRICT\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+	))
+}
+
+func (s *testSuite5) TestShowCreateTablePartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists tp, th")
+	tk.MustExec(`create table tp (a int, b int) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than maxvalue)`)
+	tk.MustQuery("show create table tp").Check(testutil.RowsWithSep("|",
+		""+
+			"tp CREATE TABLE `tp` (\n"+
+			"  `a` int(11) DEFAULT NULL,\n"+
+			"  `b` int(11) DEFAULT NULL\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY RANGE ( a ) (\n"+
+			"  PARTITION p0 VALUES LESS THAN (10),\n"+
+			"  PARTITION p1 VALUES LESS THAN (MAXVALUE)\n"+
+			")",
+	))
+	tk.MustExec("create table th (a int) partition by hash (a) partitions 4")
+	tk.MustQuery("show create table th").Check(testutil.RowsWithSep("|",
+		""+
+			"th CREATE TABLE `th` (\n"+
+			"  `a` int(11) DEFAULT NULL\n"+
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+			"PARTITION BY HASH( a )\n"+
+			"PARTITIONS 4",
+	))
+	tk.MustExec("drop table tp, th")
+}
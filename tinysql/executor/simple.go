@@ -15,22 +15,31 @@ package executor
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
 	"go.uber.org/zap"
 )
 
 // SimpleExec represents simple statement executor.
 // For statements do simple execution.
-// includes `UseStmt`,`BeginStmt`, `CommitStmt` and `RollbackStmt`.
+// includes `UseStmt`,`BeginStmt`, `CommitStmt`, `RollbackStmt` and the
+// account management statements.
 type SimpleExec struct {
 	baseExecutor
 
@@ -54,6 +63,22 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		e.executeCommit(x)
 	case *ast.RollbackStmt:
 		err = e.executeRollback(x)
+	case *ast.CreateUserStmt:
+		err = e.executeCreateUser(x)
+	case *ast.AlterUserStmt:
+		err = e.executeAlterUser(x)
+	case *ast.DropUserStmt:
+		err = e.executeDropUser(x)
+	case *ast.SetPwdStmt:
+		err = e.executeSetPwd(x)
+	case *ast.GrantRoleStmt:
+		err = e.executeGrantRole(x)
+	case *ast.RevokeRoleStmt:
+		err = e.executeRevokeRole(x)
+	case *ast.SetRoleStmt:
+		err = e.executeSetRole(x)
+	case *ast.SetDefaultRoleStmt:
+		err = e.executeSetDefaultRole(x)
 	}
 	e.done = true
 	return err
@@ -66,6 +91,10 @@ func (e *SimpleExec) executeUse(s *ast.UseStmt) error {
 	if !exists {
 		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(dbname)
 	}
+	if checker := privilege.GetPrivilegeManager(e.ctx); checker != nil && !checker.DBIsVisible(e.ctx.GetSessionVars().ActiveRoles, dbname.O) {
+		user := e.ctx.GetSessionVars().User
+		return ErrDBaccessDenied.GenWithStackByArgs(user.Username, user.Hostname, dbname.O)
+	}
 	e.ctx.GetSessionVars().CurrentDB = dbname.O
 	// character_set_database is the character set used by the default database.
 	// The server sets this variable whenever the default database changes.
@@ -136,3 +165,366 @@ func (e *SimpleExec) executeRollback(s *ast.RollbackStmt) error {
 	}
 	return nil
 }
+
+func (e *SimpleExec) executeCreateUser(s *ast.CreateUserStmt) error {
+	// The roles are locked accounts, which can't log in.
+	stmtName, locked := "CREATE USER", "N"
+	if s.IsCreateRole {
+		stmtName, locked = "CREATE ROLE", "Y"
+	}
+	users := make([]string, 0, len(s.Specs))
+	var failedUsers []string
+	for _, spec := range s.Specs {
+		exists, err := userExists(e.ctx, spec.User.Username, spec.User.Hostname)
+		if err != nil {
+			return err
+		}
+		if exists {
+			if !s.IfNotExists {
+				failedUsers = append(failedUsers, spec.User.String())
+			}
+			continue
+		}
+		pwd, ok := spec.EncodedPassword()
+		if !ok {
+			return errors.Trace(ErrPasswordFormat)
+		}
+		users = append(users, fmt.Sprintf("(%s, %s, %s, %s)", quoteString(spec.User.Hostname),
+			quoteString(spec.User.Username), quoteString(pwd), quoteString(locked)))
+	}
+	if len(failedUsers) > 0 {
+		return ErrCannotUser.GenWithStackByArgs(stmtName, strings.Join(failedUsers, ","))
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s.%s (Host, User, authentication_string, Account_locked) VALUES %s",
+		mysql.SystemDB, mysql.UserTable, strings.Join(users, ", "))
+	if _, _, err := e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql); err != nil {
+		return err
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *SimpleExec) executeAlterUser(s *ast.AlterUserStmt) error {
+	if s.CurrentAuth != nil {
+		user := e.ctx.GetSessionVars().User
+		if user == nil {
+			return errors.New("Session user is empty")
+		}
+		spec := &ast.UserSpec{
+			User:    &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname},
+			AuthOpt: s.CurrentAuth,
+		}
+		s.Specs = []*ast.UserSpec{spec}
+	}
+
+	var failedUsers []string
+	for _, spec := range s.Specs {
+		exists, err := userExists(e.ctx, spec.User.Username, spec.User.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if !s.IfExists {
+				failedUsers = append(failedUsers, spec.User.String())
+			}
+			continue
+		}
+		if spec.AuthOpt == nil {
+			continue
+		}
+		pwd, ok := spec.EncodedPassword()
+		if !ok {
+			return errors.Trace(ErrPasswordFormat)
+		}
+		if err = e.updatePassword(spec.User, pwd); err != nil {
+			return err
+		}
+	}
+	if len(failedUsers) > 0 {
+		return ErrCannotUser.GenWithStackByArgs("ALTER USER", strings.Join(failedUsers, ","))
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *SimpleExec) executeDropUser(s *ast.DropUserStmt) error {
+	stmtName := "DROP USER"
+	if s.IsDropRole {
+		stmtName = "DROP ROLE"
+	}
+	var failedUsers []string
+	for _, user := range s.UserList {
+		exists, err := userExists(e.ctx, user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if !s.IfExists {
+				failedUsers = append(failedUsers, user.String())
+			}
+			continue
+		}
+
+		// Remove the user, all the privileges granted to the user and the
+		// edges of the user in the role graph.
+		cond := userCond(user.Username, user.Hostname)
+		sqls := make([]string, 0, 5)
+		for _, table := range []string{mysql.UserTable, mysql.DBTable, mysql.TablePrivTable} {
+			sqls = append(sqls, fmt.Sprintf("DELETE FROM %s.%s WHERE %s", mysql.SystemDB, table, cond))
+		}
+		name, host := quoteString(user.Username), quoteString(user.Hostname)
+		sqls = append(sqls,
+			fmt.Sprintf("DELETE FROM %s.%s WHERE (FROM_USER = %s AND FROM_HOST = %s) OR (TO_USER = %s AND TO_HOST = %s)",
+				mysql.SystemDB, mysql.RoleEdgeTable, name, host, name, host),
+			fmt.Sprintf("DELETE FROM %s.%s WHERE (USER = %s AND HOST = %s) OR (DEFAULT_ROLE_USER = %s AND DEFAULT_ROLE_HOST = %s)",
+				mysql.SystemDB, mysql.DefaultRoleTable, name, host, name, host))
+		for _, sql := range sqls {
+			if _, _, err = e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql); err != nil {
+				failedUsers = append(failedUsers, user.String())
+				break
+			}
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	if len(failedUsers) > 0 {
+		return ErrCannotUser.GenWithStackByArgs(stmtName, strings.Join(failedUsers, ","))
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeSetPwd(s *ast.SetPwdStmt) error {
+	var u, h string
+	if s.User == nil || s.User.CurrentUser {
+		user := e.ctx.GetSessionVars().User
+		if user == nil {
+			return errors.New("Session user is empty")
+		}
+		u, h = user.AuthUsername, user.AuthHostname
+	} else {
+		u, h = s.User.Username, s.User.Hostname
+	}
+	exists, err := userExists(e.ctx, u, h)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Trace(ErrPasswordNoMatch)
+	}
+
+	if err = e.updatePassword(&auth.UserIdentity{Username: u, Hostname: h}, auth.EncodePassword(s.Password)); err != nil {
+		return err
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *SimpleExec) executeGrantRole(s *ast.GrantRoleStmt) error {
+	for _, role := range s.Roles {
+		exists, err := userExists(e.ctx, role.Username, role.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUnknownAuthID.GenWithStackByArgs(role.String())
+		}
+	}
+	mysqlPrivilege := domain.GetDomain(e.ctx).PrivilegeHandle().Get()
+	for _, user := range s.Users {
+		exists, err := userExists(e.ctx, user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCannotUser.GenWithStackByArgs("GRANT ROLE", user.String())
+		}
+		// The grant must not make a cycle in the role graph, that is, the
+		// user must not be the role or one of the roles granted to it.
+		for _, role := range s.Roles {
+			for _, r := range mysqlPrivilege.FindAllRole([]*auth.RoleIdentity{role}) {
+				if r.Username == user.Username && r.Hostname == user.Hostname {
+					return ErrRoleGrantedToItself.GenWithStackByArgs(r.String(), role.String())
+				}
+			}
+		}
+	}
+
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	for _, user := range s.Users {
+		for _, role := range s.Roles {
+			sql := fmt.Sprintf("REPLACE INTO %s.%s (FROM_HOST, FROM_USER, TO_HOST, TO_USER) VALUES (%s, %s, %s, %s)",
+				mysql.SystemDB, mysql.RoleEdgeTable, quoteString(role.Hostname), quoteString(role.Username),
+				quoteString(user.Hostname), quoteString(user.Username))
+			if _, _, err := exec.ExecRestrictedSQL(sql); err != nil {
+				return err
+			}
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *SimpleExec) executeRevokeRole(s *ast.RevokeRoleStmt) error {
+	for _, role := range s.Roles {
+		exists, err := userExists(e.ctx, role.Username, role.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUnknownAuthID.GenWithStackByArgs(role.String())
+		}
+	}
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	for _, user := range s.Users {
+		exists, err := userExists(e.ctx, user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCannotUser.GenWithStackByArgs("REVOKE ROLE", user.String())
+		}
+		// A revoked role is no longer a default role of the user.
+		for _, role := range s.Roles {
+			sqls := []string{
+				fmt.Sprintf("DELETE FROM %s.%s WHERE FROM_HOST = %s AND FROM_USER = %s AND TO_HOST = %s AND TO_USER = %s",
+					mysql.SystemDB, mysql.RoleEdgeTable, quoteString(role.Hostname), quoteString(role.Username),
+					quoteString(user.Hostname), quoteString(user.Username)),
+				fmt.Sprintf("DELETE FROM %s.%s WHERE DEFAULT_ROLE_HOST = %s AND DEFAULT_ROLE_USER = %s AND HOST = %s AND USER = %s",
+					mysql.SystemDB, mysql.DefaultRoleTable, quoteString(role.Hostname), quoteString(role.Username),
+					quoteString(user.Hostname), quoteString(user.Username)),
+			}
+			for _, sql := range sqls {
+				if _, _, err = exec.ExecRestrictedSQL(sql); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func (e *SimpleExec) executeSetRole(s *ast.SetRoleStmt) error {
+	vars := e.ctx.GetSessionVars()
+	user := vars.User
+	if user == nil {
+		return errors.New("Session user is empty")
+	}
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	switch s.SetRoleOpt {
+	case ast.SetRoleDefault:
+		vars.ActiveRoles = checker.GetDefaultRoles(e.ctx, user.AuthUsername, user.AuthHostname)
+	case ast.SetRoleNone:
+		vars.ActiveRoles = make([]*auth.RoleIdentity, 0)
+	case ast.SetRoleAll:
+		vars.ActiveRoles = checker.GetAllRoles(e.ctx, user.AuthUsername, user.AuthHostname)
+	case ast.SetRoleAllExcept:
+		roles := make([]*auth.RoleIdentity, 0)
+		for _, role := range checker.GetAllRoles(e.ctx, user.AuthUsername, user.AuthHostname) {
+			if !containsRole(s.RoleList, role) {
+				roles = append(roles, role)
+			}
+		}
+		vars.ActiveRoles = roles
+	case ast.SetRoleRegular:
+		if ok, role := checker.ActiveRoles(e.ctx, s.RoleList); !ok {
+			return ErrRoleNotGranted.GenWithStackByArgs(role, user.String())
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeSetDefaultRole(s *ast.SetDefaultRoleStmt) error {
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	exec := e.ctx.(sqlexec.RestrictedSQLExecutor)
+	for _, user := range s.UserList {
+		if user.CurrentUser {
+			current := e.ctx.GetSessionVars().User
+			if current == nil {
+				return errors.New("Session user is empty")
+			}
+			user = &auth.UserIdentity{Username: current.AuthUsername, Hostname: current.AuthHostname}
+		}
+		exists, err := userExists(e.ctx, user.Username, user.Hostname)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrCannotUser.GenWithStackByArgs("SET DEFAULT ROLE", user.String())
+		}
+
+		var roles []*auth.RoleIdentity
+		switch s.SetRoleOpt {
+		case ast.SetRoleAll:
+			roles = checker.GetAllRoles(e.ctx, user.Username, user.Hostname)
+		case ast.SetRoleRegular:
+			for _, role := range s.RoleList {
+				if !checker.FindEdge(e.ctx, role, user) {
+					return ErrRoleNotGranted.GenWithStackByArgs(role.String(), user.String())
+				}
+			}
+			roles = s.RoleList
+		}
+
+		sql := fmt.Sprintf("DELETE FROM %s.%s WHERE USER = %s AND HOST = %s",
+			mysql.SystemDB, mysql.DefaultRoleTable, quoteString(user.Username), quoteString(user.Hostname))
+		if _, _, err = exec.ExecRestrictedSQL(sql); err != nil {
+			return err
+		}
+		if len(roles) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(roles))
+		for _, role := range roles {
+			values = append(values, fmt.Sprintf("(%s, %s, %s, %s)", quoteString(user.Hostname),
+				quoteString(user.Username), quoteString(role.Hostname), quoteString(role.Username)))
+		}
+		sql = fmt.Sprintf("REPLACE INTO %s.%s (HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER) VALUES %s",
+			mysql.SystemDB, mysql.DefaultRoleTable, strings.Join(values, ", "))
+		if _, _, err = exec.ExecRestrictedSQL(sql); err != nil {
+			return err
+		}
+	}
+	domain.GetDomain(e.ctx).NotifyUpdatePrivilege(e.ctx)
+	return nil
+}
+
+func containsRole(roles []*auth.RoleIdentity, role *auth.RoleIdentity) bool {
+	for _, r := range roles {
+		if r.Username == role.Username && r.Hostname == role.Hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// updatePassword sets the authentication string of the user.
+func (e *SimpleExec) updatePassword(user *auth.UserIdentity, pwd string) error {
+	sql := composeRewriteSQL(mysql.UserTable, userTableCols, map[string]string{
+		"authentication_string": quoteString(pwd),
+	}, userCond(user.Username, user.Hostname))
+	_, _, err := e.ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	return err
+}
+
+// userExists checks whether the account of name@host exists.
+func userExists(ctx sessionctx.Context, name string, host string) (bool, error) {
+	sql := fmt.Sprintf("SELECT * FROM %s.%s WHERE %s", mysql.SystemDB, mysql.UserTable, userCond(name, host))
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+func userCond(name, host string) string {
+	return fmt.Sprintf("User = %s AND Host = %s", quoteString(name), quoteString(host))
+}
+
+// quoteString quotes a string as a SQL string literal.
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -16,18 +16,26 @@ package executor
 import (
 	"container/heap"
 	"context"
+	"fmt"
 	"sort"
+	"sync/atomic"
 
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/expression"
 	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
+var rowChunksLabel fmt.Stringer = stringutil.StringerStr("rowChunks")
+
 // SortExec represents sorting executor.
 type SortExec struct {
 	baseExecutor
 
-	ByItems []*plannercore.ByItems
+	ByItems []*util.ByItems
 	Idx     int
 	fetched bool
 	schema  *expression.Schema
@@ -40,10 +48,37 @@ type SortExec struct {
 	rowChunks *chunk.List
 	// rowPointer store the chunk index and row index for each row.
 	rowPtrs []chunk.RowPtr
+
+	memTracker  *memory.Tracker
+	diskTracker *memory.Tracker
+
+	// exceeded is set to 1 by sortSpillDiskAction when the memory threshold
+	// is exceeded, and the buffered rows are spilled out on the next chunk.
+	exceeded uint32
+	// partitionList is the sorted runs spilled out to disk.
+	partitionList []*chunk.ListInDisk
+	// multiWayMerge merges the sorted runs in partitionList.
+	multiWayMerge *multiWayMerge
 }
 
 // Close implements the Executor Close interface.
 func (e *SortExec) Close() error {
+	for _, partition := range e.partitionList {
+		if err := partition.Close(); err != nil {
+			return err
+		}
+	}
+	e.partitionList = nil
+	e.multiWayMerge = nil
+	e.rowChunks = nil
+	if e.memTracker != nil {
+		e.memTracker.Detach()
+		e.memTracker = nil
+	}
+	if e.diskTracker != nil {
+		e.diskTracker.Detach()
+		e.diskTracker = nil
+	}
 	return e.children[0].Close()
 }
 
@@ -51,23 +86,49 @@ func (e *SortExec) Close() error {
 func (e *SortExec) Open(ctx context.Context) error {
 	e.fetched = false
 	e.Idx = 0
+	atomic.StoreUint32(&e.exceeded, 0)
+
+	// To avoid duplicated initialization for TopNExec.
+	if e.memTracker == nil {
+		sessVars := e.ctx.GetSessionVars()
+		e.memTracker = memory.NewTracker(e.id, -1)
+		e.memTracker.AttachTo(sessVars.StmtCtx.MemTracker)
+		e.diskTracker = memory.NewTracker(e.id, -1)
+		e.diskTracker.AttachTo(sessVars.StmtCtx.DiskTracker)
+		if config.GetGlobalConfig().OOMUseTmpStorage {
+			// The sort spills when the query quota is exceeded, a positive
+			// tidb_mem_quota_sort makes it spill earlier.
+			e.memTracker.SetBytesLimit(sessVars.MemQuotaSort)
+			e.memTracker.SetActionOnExceed(&sortSpillDiskAction{e: e})
+			if stmtTracker := sessVars.StmtCtx.MemTracker; stmtTracker != nil {
+				stmtTracker.FallbackOldAndSetNewAction(&sortSpillDiskAction{e: e})
+			}
+		}
+	}
 	return e.children[0].Open(ctx)
 }
 
 // Next implements the Executor Next interface.
+// If the rows have been spilled to disk during fetching, the sorted runs are
+// merged by a multi-way merge, otherwise the rows are sorted in memory.
 func (e *SortExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.Reset()
 	if !e.fetched {
+		e.initCompareFuncs()
+		e.buildKeyColumns()
 		err := e.fetchRowChunks(ctx)
 		if err != nil {
 			return err
 		}
-		e.initPointers()
-		e.initCompareFuncs()
-		e.buildKeyColumns()
-		sort.Slice(e.rowPtrs, e.keyColumnsLess)
+		if len(e.partitionList) == 0 {
+			e.initPointers()
+			sort.Slice(e.rowPtrs, e.keyColumnsLess)
+		}
 		e.fetched = true
 	}
+	if len(e.partitionList) > 0 {
+		return e.externalSorting(req)
+	}
 	for !req.IsFull() && e.Idx < len(e.rowPtrs) {
 		rowPtr := e.rowPtrs[e.Idx]
 		req.AppendRow(e.rowChunks.GetRow(rowPtr))
@@ -77,8 +138,7 @@ func (e *SortExec) Next(ctx context.Context, req *chunk.Chunk) error {
 }
 
 func (e *SortExec) fetchRowChunks(ctx context.Context) error {
-	fields := retTypes(e)
-	e.rowChunks = chunk.NewList(fields, e.initCap, e.maxChunkSize)
+	e.rowChunks = e.newRowChunks()
 	for {
 		chk := newFirstChunk(e.children[0])
 		err := Next(ctx, e.children[0], chk)
@@ -90,11 +150,108 @@ func (e *SortExec) fetchRowChunks(ctx context.Context) error {
 			break
 		}
 		e.rowChunks.Add(chk)
+		if atomic.LoadUint32(&e.exceeded) == 1 {
+			if err = e.spillToDisk(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(e.partitionList) > 0 && e.rowChunks.Len() > 0 {
+		if err := e.spillToDisk(); err != nil {
+			return err
+		}
+	}
+	// All the rows are fetched, nothing can be spilled from now on, so
+	// the later exceeding goes to the fallback action.
+	atomic.StoreUint32(&e.exceeded, 1)
+	return nil
+}
+
+func (e *SortExec) newRowChunks() *chunk.List {
+	rowChunks := chunk.NewList(retTypes(e), e.initCap, e.maxChunkSize)
+	rowChunks.GetMemTracker().AttachTo(e.memTracker)
+	rowChunks.GetMemTracker().SetLabel(rowChunksLabel)
+	return rowChunks
+}
+
+// spillToDisk sorts the rows buffered in memory and writes them to a temporary
+// file as a sorted run, then releases the memory of the buffered rows.
+func (e *SortExec) spillToDisk() error {
+	var partition *chunk.ListInDisk
+	if e.rowChunks.Len() > 0 {
+		// The row pointers only live during spilling, they are not tracked,
+		// otherwise consuming them triggers the spill action again.
+		e.buildRowPtrs()
+		sort.Slice(e.rowPtrs, e.keyColumnsLess)
+		partition = chunk.NewListInDisk(retTypes(e))
+		partition.GetDiskTracker().AttachTo(e.diskTracker)
+		e.partitionList = append(e.partitionList, partition)
+		chk := chunk.NewChunkWithCapacity(retTypes(e), e.maxChunkSize)
+		for _, rowPtr := range e.rowPtrs {
+			chk.AppendRow(e.rowChunks.GetRow(rowPtr))
+			if chk.IsFull() {
+				if err := partition.Add(chk); err != nil {
+					return err
+				}
+				chk.Reset()
+			}
+		}
+		if chk.NumRows() > 0 {
+			if err := partition.Add(chk); err != nil {
+				return err
+			}
+		}
+		e.rowPtrs = nil
+		e.rowChunks.GetMemTracker().Detach()
+		e.rowChunks = e.newRowChunks()
+	}
+	atomic.StoreUint32(&e.exceeded, 0)
+	if partition != nil {
+		// The row offsets of the run are tracked after the buffered rows are
+		// released, exceeding the threshold by them marks the next spill.
+		partition.GetMemTracker().AttachTo(e.memTracker)
+	}
+	return nil
+}
+
+// externalSorting merges the sorted runs in partitionList and fills req.
+func (e *SortExec) externalSorting(req *chunk.Chunk) error {
+	if e.multiWayMerge == nil {
+		e.multiWayMerge = &multiWayMerge{lessRowFunction: e.lessRow}
+		for _, partition := range e.partitionList {
+			cursor := &partitionCursor{partition: partition}
+			if err := cursor.loadChunk(); err != nil {
+				return err
+			}
+			e.multiWayMerge.elements = append(e.multiWayMerge.elements, cursor)
+		}
+		heap.Init(e.multiWayMerge)
+	}
+	for !req.IsFull() && e.multiWayMerge.Len() > 0 {
+		cursor := e.multiWayMerge.elements[0]
+		req.AppendRow(cursor.current())
+		cursor.rowIdx++
+		if cursor.rowIdx >= cursor.chk.NumRows() {
+			cursor.chkIdx++
+			if cursor.chkIdx >= cursor.partition.NumChunks() {
+				heap.Pop(e.multiWayMerge)
+				continue
+			}
+			if err := cursor.loadChunk(); err != nil {
+				return err
+			}
+		}
+		heap.Fix(e.multiWayMerge, 0)
 	}
 	return nil
 }
 
 func (e *SortExec) initPointers() {
+	e.memTracker.Consume(int64(8 * e.rowChunks.Len()))
+	e.buildRowPtrs()
+}
+
+func (e *SortExec) buildRowPtrs() {
 	e.rowPtrs = make([]chunk.RowPtr, 0, e.rowChunks.Len())
 	for chkIdx := 0; chkIdx < e.rowChunks.NumChunks(); chkIdx++ {
 		rowChk := e.rowChunks.GetChunk(chkIdx)
@@ -143,6 +300,77 @@ func (e *SortExec) keyColumnsLess(i, j int) bool {
 	return e.lessRow(rowI, rowJ)
 }
 
+// partitionCursor points to the current row of a sorted run spilled to disk.
+type partitionCursor struct {
+	partition *chunk.ListInDisk
+	chk       *chunk.Chunk
+	chkIdx    int
+	rowIdx    int
+}
+
+func (c *partitionCursor) loadChunk() (err error) {
+	c.chk, err = c.partition.GetChunk(c.chkIdx)
+	c.rowIdx = 0
+	return err
+}
+
+func (c *partitionCursor) current() chunk.Row {
+	return c.chk.GetRow(c.rowIdx)
+}
+
+// multiWayMerge implements heap.Interface, it is a min heap of the current
+// rows of the sorted runs.
+type multiWayMerge struct {
+	lessRowFunction func(rowI, rowJ chunk.Row) bool
+	elements        []*partitionCursor
+}
+
+func (h *multiWayMerge) Less(i, j int) bool {
+	return h.lessRowFunction(h.elements[i].current(), h.elements[j].current())
+}
+
+func (h *multiWayMerge) Len() int {
+	return len(h.elements)
+}
+
+func (h *multiWayMerge) Push(x interface{}) {
+	// Should never be called.
+}
+
+func (h *multiWayMerge) Pop() interface{} {
+	h.elements = h.elements[:len(h.elements)-1]
+	return nil
+}
+
+func (h *multiWayMerge) Swap(i, j int) {
+	h.elements[i], h.elements[j] = h.elements[j], h.elements[i]
+}
+
+// sortSpillDiskAction implements memory.ActionOnExceed for SortExec.
+// If the memory threshold is exceeded, sortSpillDiskAction.Action marks the
+// SortExec, and the buffered rows are spilled out to disk after the current
+// chunk is fetched. If the SortExec is already marked or no longer able to
+// spill, the fallback action is triggered.
+type sortSpillDiskAction struct {
+	e              *SortExec
+	fallbackAction memory.ActionOnExceed
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *sortSpillDiskAction) Action(t *memory.Tracker) {
+	if !atomic.CompareAndSwapUint32(&a.e.exceeded, 0, 1) && a.fallbackAction != nil {
+		a.fallbackAction.Action(t)
+	}
+}
+
+// SetFallback sets the fallback action.
+func (a *sortSpillDiskAction) SetFallback(fallback memory.ActionOnExceed) {
+	a.fallbackAction = fallback
+}
+
+// SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
+func (a *sortSpillDiskAction) SetLogHook(hook func(uint64)) {}
+
 // TopNExec implements a Top-N algorithm and it is built from a SELECT statement with ORDER BY and LIMIT.
 // Instead of sorting all the rows fetched from the table, it keeps the Top-N elements only in a heap to reduce memory usage.
 type TopNExec struct {
@@ -201,7 +429,14 @@ func (h *topNChunkHeap) Swap(i, j int) {
 }
 
 // Open implements the Executor Open interface.
+// TopNExec keeps only N rows in memory, so it never spills to disk.
 func (e *TopNExec) Open(ctx context.Context) error {
+	// The memTracker is kept when the executor is opened again without
+	// being closed, otherwise the old one stays attached to the statement.
+	if e.memTracker == nil {
+		e.memTracker = memory.NewTracker(e.id, -1)
+		e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
+	}
 	return e.SortExec.Open(ctx)
 }
 
@@ -235,6 +470,8 @@ func (e *TopNExec) Next(ctx context.Context, req *chunk.Chunk) error {
 func (e *TopNExec) loadChunksUntilTotalLimit(ctx context.Context) error {
 	e.chkHeap = &topNChunkHeap{e}
 	e.rowChunks = chunk.NewList(retTypes(e), e.initCap, e.maxChunkSize)
+	e.rowChunks.GetMemTracker().AttachTo(e.memTracker)
+	e.rowChunks.GetMemTracker().SetLabel(rowChunksLabel)
 	for uint64(e.rowChunks.Len()) < e.totalLimit {
 		srcChk := newFirstChunk(e.children[0])
 		// adjust required rows by total limit
@@ -312,6 +549,8 @@ func (e *TopNExec) doCompaction() error {
 		newRowPtr := newRowChunks.AppendRow(e.rowChunks.GetRow(rowPtr))
 		newRowPtrs = append(newRowPtrs, newRowPtr)
 	}
+	newRowChunks.GetMemTracker().SetLabel(rowChunksLabel)
+	e.memTracker.ReplaceChild(e.rowChunks.GetMemTracker(), newRowChunks.GetMemTracker())
 	e.rowChunks = newRowChunks
 	e.rowPtrs = newRowPtrs
 	return nil
@@ -20,6 +20,8 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/model"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/mock"
@@ -314,6 +316,208 @@ func (s *testSuite4) TestInsertSetWithDefault(c *C) {
 	tk.MustQuery("select * from t1;").Check(testkit.Rows("30 20"))
 }
 
+func (s *testSuite4) TestInsertGeneratedColumn(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b varchar(20), c varchar(20) as (lower(b)), d int as (a div 10) stored,
+		e int as (d + 1) virtual, index idx_c(c), index idx_e(e))`)
+	tk.MustExec("insert into t(a, b) values (15, 'Hello'), (27, 'WORLD')")
+	tk.MustExec("insert into t set a = 3, b = 'Foo', c = default")
+	tk.MustExec("insert into t values (4, 'Bar', default, default, default)")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows(
+		"3 Foo foo 0 1", "4 Bar bar 0 1", "15 Hello hello 1 2", "27 WORLD world 2 3"))
+	tk.MustQuery("select a from t where c = 'world'").Check(testkit.Rows("27"))
+	tk.MustQuery("select a, b from t use index(idx_c) where c > 'g' order by a").Check(testkit.Rows("15 Hello", "27 WORLD"))
+	tk.MustQuery("select a from t use index(idx_e) where e = 1 order by a").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select a from t ignore index(idx_e) where e = 2").Check(testkit.Rows("15"))
+	tk.MustQuery("select count(*), sum(e) from t ignore index(idx_e) where e > 1").Check(testkit.Rows("2 5"))
+	tk.MustQuery("select e from t ignore index(idx_e) order by e desc limit 1").Check(testkit.Rows("3"))
+
+	// The rows in the transaction memory buffer are read by union scan.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t(a, b) values (38, 'Txn')")
+	tk.MustQuery("select a, c from t ignore index(idx_e) where e = 4").Check(testkit.Rows("38 txn"))
+	tk.MustQuery("select a, e from t use index(idx_c) where c = 'txn'").Check(testkit.Rows("38 4"))
+	tk.MustExec("rollback")
+
+	tk.MustExec("delete from t where e = 1")
+	tk.MustQuery("select a from t use index(idx_e) order by a").Check(testkit.Rows("15", "27"))
+
+	// Only DEFAULT is allowed for the generated columns.
+	_, err := tk.Exec("insert into t(a, b, c) values (1, 'x', 'y')")
+	c.Assert(plannercore.ErrBadGeneratedColumn.Equal(err), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("insert into t values (1, 'x', 'x', 0, 1)")
+	c.Assert(plannercore.ErrBadGeneratedColumn.Equal(err), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("insert into t set a = 1, d = 2")
+	c.Assert(plannercore.ErrBadGeneratedColumn.Equal(err), IsTrue, Commentf("err %v", err))
+	_, err = tk.Exec("insert into t(a, b, e) select a, b, e from t")
+	c.Assert(plannercore.ErrBadGeneratedColumn.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustExec("insert into t(a, b) select a + 1, b from t")
+	tk.MustQuery("select a, c, e from t use index(idx_c) where c = 'hello' order by a").Check(testkit.Rows("15 hello 2", "16 hello 2"))
+
+	// The unique index on the virtual column is checked by REPLACE.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key, b varchar(20), c varchar(20) as (upper(b)), unique key(c))")
+	tk.MustExec("insert into t(a, b) values (1, 'x')")
+	_, err = tk.Exec("insert into t(a, b) values (2, 'X')")
+	c.Assert(err, NotNil)
+	tk.MustExec("replace into t(a, b) values (2, 'X')")
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 X X"))
+	tk.MustQuery("select a from t where c = 'X'").Check(testkit.Rows("2"))
+}
+
+func (s *testSuite4) TestInsertCheckConstraint(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int primary key, b int check (b > 0), c int, d int as (b + c), constraint c_d check (d < 10), constraint c_c check (c > 0) not enforced)")
+	tk.MustExec("insert into t(a, b, c) values (1, 1, 1)")
+	// NULL doesn't violate a constraint.
+	tk.MustExec("insert into t(a, b, c) values (2, null, null)")
+	// NOT ENFORCED constraints are not checked.
+	tk.MustExec("insert into t(a, b, c) values (3, 1, -1)")
+	_, err := tk.Exec("insert into t(a, b, c) values (4, 0, 1)")
+	c.Assert(table.ErrCheckConstraintViolated.Equal(err), IsTrue, Commentf("err %v", err))
+	c.Assert(err.Error(), Equals, "[table:3819]Check constraint 't_chk_1' is violated.")
+	// The constraints on the generated columns are checked too.
+	for _, sql := range []string{
+		"insert into t(a, b, c) values (4, 5, 5)",
+		"insert into t set a = 4, b = 5, c = 5",
+		"insert into t(a, b, c) select a + 10, b - 1, c from t where a = 1",
+		"replace into t(a, b, c) values (1, -1, 1)",
+		// The whole statement fails in a multi-row insert.
+		"insert into t(a, b, c) values (5, 1, 1), (6, -1, 1)",
+	} {
+		_, err = tk.Exec(sql)
+		c.Assert(table.ErrCheckConstraintViolated.Equal(err), IsTrue, Commentf("sql %s, err %v", sql, err))
+	}
+	tk.MustExec("replace into t(a, b, c) values (1, 2, 3)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 2 3 5", "2 <nil> <nil> <nil>", "3 1 -1 0"))
+	tk.MustQuery("select count(*) from t where a > 4").Check(testkit.Rows("0"))
+
+	// Enforcing the constraint validates the existing rows.
+	_, err = tk.Exec("alter table t alter check c_c enforced")
+	c.Assert(table.ErrCheckConstraintViolated.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustExec("delete from t where a = 3")
+	tk.MustExec("alter table t alter check c_c enforced")
+	_, err = tk.Exec("insert into t(a, b, c) values (3, 1, -1)")
+	c.Assert(table.ErrCheckConstraintViolated.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustExec("alter table t drop check c_c")
+	tk.MustExec("insert into t(a, b, c) values (3, 1, -1)")
+}
+
+func (s *testSuite4) TestInsertForeignKey(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_child, fk_parent")
+	tk.MustExec("create table fk_parent (id int primary key, u int, unique key (u))")
+	tk.MustExec("create table fk_child (id int primary key, pid int, pu int, foreign key fk_1 (pid) references fk_parent (id), foreign key fk_2 (pu) references fk_parent (u))")
+	tk.MustExec("insert into fk_parent values (1, 10), (2, 20)")
+	tk.MustExec("insert into fk_child values (1, 1, 10), (2, 2, null), (3, null, null)")
+	for _, sql := range []string{
+		"insert into fk_child values (4, 3, null)",
+		"insert into fk_child values (4, 1, 30)",
+		"replace into fk_child values (1, 3, 10)",
+		// The whole statement fails in a multi-row insert.
+		"insert into fk_child values (4, 1, 10), (5, 3, 10)",
+	} {
+		_, err := tk.Exec(sql)
+		c.Assert(table.ErrNoReferencedRow2.Equal(err), IsTrue, Commentf("sql %s, err %v", sql, err))
+	}
+	_, err := tk.Exec("insert into fk_child values (4, 3, null)")
+	c.Assert(err.Error(), Equals, "[table:1452]Cannot add or update a child row: a foreign key constraint fails "+
+		"(`test`.`fk_child`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_parent` (`id`))")
+	tk.MustQuery("select count(*) from fk_child").Check(testkit.Rows("3"))
+
+	// The parent row inserted in the same transaction is found.
+	tk.MustExec("begin")
+	tk.MustExec("insert into fk_parent values (3, 30)")
+	tk.MustExec("insert into fk_child values (4, 3, 30)")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from fk_child where id = 4").Check(testkit.Rows("4 3 30"))
+
+	// The parent row is locked, deleting it in another transaction conflicts with the insert.
+	tk.MustExec("insert into fk_parent values (9, 90)")
+	tk.MustExec("begin")
+	tk.MustExec("insert into fk_child values (9, 9, null)")
+	tk2 := testkit.NewTestKit(c, s.store)
+	tk2.MustExec("use test")
+	tk2.MustExec("delete from fk_parent where id = 9")
+	_, err = tk.Exec("commit")
+	c.Assert(err, NotNil)
+	tk.MustQuery("select count(*) from fk_child where id = 9").Check(testkit.Rows("0"))
+
+	// A row can refer itself.
+	tk.MustExec("drop table if exists fk_self")
+	tk.MustExec("create table fk_self (id int primary key, pid int, foreign key (pid) references fk_self (id))")
+	tk.MustExec("insert into fk_self values (1, 1), (2, 1)")
+	_, err = tk.Exec("insert into fk_self values (3, 4)")
+	c.Assert(table.ErrNoReferencedRow2.Equal(err), IsTrue, Commentf("err %v", err))
+
+	// The foreign keys aren't checked when foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("insert into fk_child values (5, 5, 50)")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustQuery("select * from fk_child where id = 5").Check(testkit.Rows("5 5 50"))
+}
+
+func (s *testSuite4) TestDeleteForeignKey(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists fk_c1, fk_c2, fk_c3, fk_p")
+	tk.MustExec("create table fk_p (id int primary key, v int)")
+	tk.MustExec("create table fk_c1 (id int primary key, pid int, foreign key fk_1 (pid) references fk_p (id))")
+	tk.MustExec("create table fk_c2 (id int primary key, pid int, foreign key (pid) references fk_p (id) on delete cascade)")
+	tk.MustExec("create table fk_c3 (id int primary key, pid int, key (pid), foreign key (pid) references fk_p (id) on delete set null)")
+	tk.MustExec("insert into fk_p values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into fk_c1 values (1, 1)")
+	tk.MustExec("insert into fk_c2 values (1, 2), (2, 2), (3, 3)")
+	tk.MustExec("insert into fk_c3 values (1, 2), (2, 3)")
+
+	// RESTRICT is the default action.
+	_, err := tk.Exec("delete from fk_p where id = 1")
+	c.Assert(table.ErrRowIsReferenced2.Equal(err), IsTrue, Commentf("err %v", err))
+	c.Assert(err.Error(), Equals, "[table:1451]Cannot delete or update a parent row: a foreign key constraint fails "+
+		"(`test`.`fk_c1`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `fk_p` (`id`))")
+	_, err = tk.Exec("replace into fk_p values (1, 10)")
+	c.Assert(table.ErrRowIsReferenced2.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustQuery("select * from fk_p").Check(testkit.Rows("1 1", "2 2", "3 3"))
+
+	tk.MustExec("delete from fk_p where id = 2")
+	c.Assert(tk.Se.AffectedRows(), Equals, uint64(1))
+	tk.MustQuery("select * from fk_c2").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select * from fk_c3").Check(testkit.Rows("1 <nil>", "2 3"))
+	tk.MustQuery("select * from fk_c3 use index(pid) where pid is null").Check(testkit.Rows("1 <nil>"))
+
+	// The actions are rolled back with the statement.
+	_, err = tk.Exec("delete from fk_p where id in (3, 1)")
+	c.Assert(table.ErrRowIsReferenced2.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustQuery("select * from fk_c2").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select * from fk_c3").Check(testkit.Rows("1 <nil>", "2 3"))
+
+	// The cascading deletes are recursive.
+	tk.MustExec("drop table if exists fk_tree")
+	tk.MustExec("create table fk_tree (id int primary key, pid int, foreign key (pid) references fk_tree (id) on delete cascade)")
+	tk.MustExec("insert into fk_tree values (1, null), (2, 1), (3, 2), (4, 3), (5, null)")
+	tk.MustExec("delete from fk_tree where id = 2")
+	tk.MustQuery("select * from fk_tree").Check(testkit.Rows("1 <nil>", "5 <nil>"))
+	tk.MustExec("insert into fk_tree values (10, null)")
+	for i := 11; i <= 30; i++ {
+		tk.MustExec(fmt.Sprintf("insert into fk_tree values (%d, %d)", i, i-1))
+	}
+	_, err = tk.Exec("delete from fk_tree where id = 10")
+	c.Assert(table.ErrForeignKeyCascadeDepthExceeded.Equal(err), IsTrue, Commentf("err %v", err))
+	tk.MustExec("delete from fk_tree where id = 20")
+	tk.MustQuery("select count(*) from fk_tree where id >= 10").Check(testkit.Rows("10"))
+
+	// No action is taken when foreign_key_checks is off.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("delete from fk_p where id = 1")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustQuery("select * from fk_c1").Check(testkit.Rows("1 1"))
+}
+
 func (s *testSuite4) TestReplace(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
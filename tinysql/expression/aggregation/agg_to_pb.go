@@ -25,6 +25,11 @@ import (
 
 // AggFuncToPBExpr converts aggregate function to pb.
 func AggFuncToPBExpr(sc *stmtctx.StatementContext, client kv.Client, aggFunc *AggFuncDesc) *tipb.Expr {
+	// The coprocessor can neither dedup nor sort the input values, so such
+	// functions are always computed by TiDB.
+	if aggFunc.HasDistinct || len(aggFunc.OrderByItems) > 0 {
+		return nil
+	}
 	pc := expression.NewPBConverter(client, sc)
 	var tp tipb.ExprType
 	switch aggFunc.Name {
@@ -40,6 +45,22 @@ func AggFuncToPBExpr(sc *stmtctx.StatementContext, client kv.Client, aggFunc *Ag
 		tp = tipb.ExprType_Sum
 	case ast.AggFuncAvg:
 		tp = tipb.ExprType_Avg
+	case ast.AggFuncGroupConcat:
+		tp = tipb.ExprType_GroupConcat
+	case ast.AggFuncBitOr:
+		tp = tipb.ExprType_Agg_BitOr
+	case ast.AggFuncBitXor:
+		tp = tipb.ExprType_Agg_BitXor
+	case ast.AggFuncBitAnd:
+		tp = tipb.ExprType_Agg_BitAnd
+	case ast.AggFuncVarPop:
+		tp = tipb.ExprType_VarPop
+	case ast.AggFuncVarSamp:
+		tp = tipb.ExprType_VarSamp
+	case ast.AggFuncStddevPop:
+		tp = tipb.ExprType_StddevPop
+	case ast.AggFuncStddevSamp:
+		tp = tipb.ExprType_StddevSamp
 	}
 	if !client.IsRequestTypeSupported(kv.ReqTypeSelect, int64(tp)) {
 		return nil
@@ -72,6 +93,22 @@ func PBExprToAggFuncDesc(sc *stmtctx.StatementContext, aggFunc *tipb.Expr, field
 		name = ast.AggFuncSum
 	case tipb.ExprType_Avg:
 		name = ast.AggFuncAvg
+	case tipb.ExprType_GroupConcat:
+		name = ast.AggFuncGroupConcat
+	case tipb.ExprType_Agg_BitOr:
+		name = ast.AggFuncBitOr
+	case tipb.ExprType_Agg_BitXor:
+		name = ast.AggFuncBitXor
+	case tipb.ExprType_Agg_BitAnd:
+		name = ast.AggFuncBitAnd
+	case tipb.ExprType_VarPop:
+		name = ast.AggFuncVarPop
+	case tipb.ExprType_VarSamp:
+		name = ast.AggFuncVarSamp
+	case tipb.ExprType_StddevPop:
+		name = ast.AggFuncStddevPop
+	case tipb.ExprType_StddevSamp:
+		name = ast.AggFuncStddevSamp
 	default:
 		return nil, errors.Errorf("unknown aggregation function type: %v", aggFunc.Tp)
 	}
@@ -14,6 +14,7 @@
 package aggregation
 
 import (
+	"math"
 	"testing"
 
 	. "github.com/pingcap/check"
@@ -63,7 +64,7 @@ func (s *testAggFuncSuit) TestAvg(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	avgFunc := desc.GetAggFunc(ctx)
 	evalCtx := avgFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -76,11 +77,12 @@ func (s *testAggFuncSuit) TestAvg(c *C) {
 		c.Assert(err, IsNil)
 	}
 	result = avgFunc.GetResult(evalCtx)
-	c.Assert(result.GetInt64(), Equals, int64(67))
+	needed := types.NewDecFromStringForTest("67.000000000000000000000000000000")
+	c.Assert(result.GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 	err = avgFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.nullRow)
 	c.Assert(err, IsNil)
 	result = avgFunc.GetResult(evalCtx)
-	c.Assert(result.GetInt64(), Equals, int64(67))
+	c.Assert(result.GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 }
 
 func (s *testAggFuncSuit) TestAvgFinalMode(c *C) {
@@ -97,7 +99,7 @@ func (s *testAggFuncSuit) TestAvgFinalMode(c *C) {
 		Index:   1,
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
-	aggFunc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{cntCol, sumCol})
+	aggFunc, err := NewAggFuncDesc(s.ctx, ast.AggFuncAvg, []expression.Expression{cntCol, sumCol}, false)
 	c.Assert(err, IsNil)
 	aggFunc.Mode = FinalMode
 	avgFunc := aggFunc.GetAggFunc(ctx)
@@ -108,7 +110,8 @@ func (s *testAggFuncSuit) TestAvgFinalMode(c *C) {
 		c.Assert(err, IsNil)
 	}
 	result := avgFunc.GetResult(evalCtx)
-	c.Assert(result.GetInt64(), Equals, int64(67))
+	needed := types.NewDecFromStringForTest("67.000000000000000000000000000000")
+	c.Assert(result.GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 }
 
 func (s *testAggFuncSuit) TestSum(c *C) {
@@ -117,7 +120,7 @@ func (s *testAggFuncSuit) TestSum(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncSum, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncSum, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	sumFunc := desc.GetAggFunc(ctx)
 	evalCtx := sumFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -130,13 +133,14 @@ func (s *testAggFuncSuit) TestSum(c *C) {
 		c.Assert(err, IsNil)
 	}
 	result = sumFunc.GetResult(evalCtx)
-	c.Assert(result.GetInt64(), Equals, int64(338350))
+	needed := types.NewDecFromStringForTest("338350")
+	c.Assert(result.GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 	err = sumFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.nullRow)
 	c.Assert(err, IsNil)
 	result = sumFunc.GetResult(evalCtx)
-	c.Assert(result.GetInt64(), Equals, int64(338350))
+	c.Assert(result.GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 	partialResult := sumFunc.GetPartialResult(evalCtx)
-	c.Assert(partialResult[0].GetInt64(), Equals, int64(338350))
+	c.Assert(partialResult[0].GetMysqlDecimal().Compare(needed) == 0, IsTrue)
 }
 
 func (s *testAggFuncSuit) TestCount(c *C) {
@@ -145,7 +149,7 @@ func (s *testAggFuncSuit) TestCount(c *C) {
 		RetType: types.NewFieldType(mysql.TypeLonglong),
 	}
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncCount, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncCount, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	countFunc := desc.GetAggFunc(ctx)
 	evalCtx := countFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -174,7 +178,7 @@ func (s *testAggFuncSuit) TestFirstRow(c *C) {
 	}
 
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	firstRowFunc := desc.GetAggFunc(ctx)
 	evalCtx := firstRowFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -201,10 +205,10 @@ func (s *testAggFuncSuit) TestMaxMin(c *C) {
 	}
 
 	ctx := mock.NewContext()
-	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncMax, []expression.Expression{col})
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncMax, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	maxFunc := desc.GetAggFunc(ctx)
-	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncMin, []expression.Expression{col})
+	desc, err = NewAggFuncDesc(s.ctx, ast.AggFuncMin, []expression.Expression{col}, false)
 	c.Assert(err, IsNil)
 	minFunc := desc.GetAggFunc(ctx)
 	maxEvalCtx := maxFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
@@ -257,3 +261,124 @@ func (s *testAggFuncSuit) TestMaxMin(c *C) {
 	partialResult := minFunc.GetPartialResult(minEvalCtx)
 	c.Assert(partialResult[0].GetInt64(), Equals, int64(1))
 }
+
+func (s *testAggFuncSuit) TestBitFuncs(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	ctx := mock.NewContext()
+	tests := []struct {
+		name   string
+		empty  uint64
+		result uint64
+	}{
+		{ast.AggFuncBitAnd, math.MaxUint64, 0},
+		{ast.AggFuncBitOr, 0, 127},
+		{ast.AggFuncBitXor, 0, 2},
+	}
+	for _, t := range tests {
+		desc, err := NewAggFuncDesc(s.ctx, t.name, []expression.Expression{col}, false)
+		c.Assert(err, IsNil)
+		bitFunc := desc.GetAggFunc(ctx)
+		evalCtx := bitFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+
+		result := bitFunc.GetResult(evalCtx)
+		c.Assert(result.GetUint64(), Equals, t.empty)
+
+		for _, row := range s.rows {
+			err := bitFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+			c.Assert(err, IsNil)
+		}
+		err = bitFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.nullRow)
+		c.Assert(err, IsNil)
+		result = bitFunc.GetResult(evalCtx)
+		c.Assert(result.GetUint64(), Equals, t.result)
+		partialResult := bitFunc.GetPartialResult(evalCtx)
+		c.Assert(partialResult[0].GetUint64(), Equals, t.result)
+	}
+}
+
+func (s *testAggFuncSuit) TestGroupConcat(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeVarchar),
+	}
+	sep := &expression.Constant{Value: types.NewStringDatum("|"), RetType: types.NewFieldType(mysql.TypeVarchar)}
+	ctx := mock.NewContext()
+	desc, err := NewAggFuncDesc(s.ctx, ast.AggFuncGroupConcat, []expression.Expression{col, sep}, false)
+	c.Assert(err, IsNil)
+	concatFunc := desc.GetAggFunc(ctx)
+	evalCtx := concatFunc.CreateContext(s.ctx.GetSessionVars().StmtCtx)
+
+	result := concatFunc.GetResult(evalCtx)
+	c.Assert(result.IsNull(), IsTrue)
+
+	for _, v := range []string{"a", "b", "c"} {
+		row := chunk.MutRowFromDatums(types.MakeDatums(v)).ToRow()
+		err := concatFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, row)
+		c.Assert(err, IsNil)
+	}
+	err = concatFunc.Update(evalCtx, s.ctx.GetSessionVars().StmtCtx, s.nullRow)
+	c.Assert(err, IsNil)
+	result = concatFunc.GetResult(evalCtx)
+	c.Assert(result.GetString(), Equals, "a|b|c")
+	partialResult := concatFunc.GetPartialResult(evalCtx)
+	c.Assert(partialResult[0].GetString(), Equals, "a|b|c")
+}
+
+func (s *testAggFuncSuit) TestVariance(c *C) {
+	col := &expression.Column{
+		Index:   0,
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}
+	ctx := mock.NewContext()
+	sc := s.ctx.GetSessionVars().StmtCtx
+	tests := []struct {
+		name   string
+		result float64
+	}{
+		{ast.AggFuncVarPop, 561},
+		{ast.AggFuncVarSamp, 561.1111111111111},
+		{ast.AggFuncStddevPop, math.Sqrt(561)},
+		{ast.AggFuncStddevSamp, math.Sqrt(561.1111111111111)},
+	}
+	for _, t := range tests {
+		desc, err := NewAggFuncDesc(s.ctx, t.name, []expression.Expression{col}, false)
+		c.Assert(err, IsNil)
+		varFunc := desc.GetAggFunc(ctx)
+		evalCtx := varFunc.CreateContext(sc)
+		result := varFunc.GetResult(evalCtx)
+		c.Assert(result.IsNull(), IsTrue)
+
+		// Compute two halves of the rows separately and merge their partial
+		// results in final mode.
+		partialCtxs := []*AggEvaluateContext{varFunc.CreateContext(sc), varFunc.CreateContext(sc)}
+		for i, row := range s.rows {
+			err := varFunc.Update(evalCtx, sc, row)
+			c.Assert(err, IsNil)
+			err = varFunc.Update(partialCtxs[i%2], sc, row)
+			c.Assert(err, IsNil)
+		}
+		err = varFunc.Update(evalCtx, sc, s.nullRow)
+		c.Assert(err, IsNil)
+		result = varFunc.GetResult(evalCtx)
+		c.Assert(math.Abs(result.GetFloat64()-t.result) < 1e-9, IsTrue, Commentf("%s", t.name))
+
+		cntCol := &expression.Column{Index: 0, RetType: types.NewFieldType(mysql.TypeLonglong)}
+		sumCol := &expression.Column{Index: 1, RetType: types.NewFieldType(mysql.TypeDouble)}
+		varCol := &expression.Column{Index: 2, RetType: types.NewFieldType(mysql.TypeDouble)}
+		finalDesc, err := NewAggFuncDesc(s.ctx, t.name, []expression.Expression{cntCol, sumCol, varCol}, false)
+		c.Assert(err, IsNil)
+		finalDesc.Mode = FinalMode
+		finalFunc := finalDesc.GetAggFunc(ctx)
+		finalCtx := finalFunc.CreateContext(sc)
+		for _, partialCtx := range partialCtxs {
+			row := chunk.MutRowFromDatums(varFunc.GetPartialResult(partialCtx)).ToRow()
+			err = finalFunc.Update(finalCtx, sc, row)
+			c.Assert(err, IsNil)
+		}
+		result = finalFunc.GetResult(finalCtx)
+		c.Assert(math.Abs(result.GetFloat64()-t.result) < 1e-9, IsTrue, Commentf("%s", t.name))
+	}
+}
@@ -15,13 +15,14 @@ package aggregation
 
 import (
 	"bytes"
-	"fmt"
-	log "github.com/sirupsen/logrus"
+	"math"
 	"strings"
 
+	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
@@ -46,14 +47,33 @@ func newBaseFuncDesc(ctx sessionctx.Context, name string, args []expression.Expr
 	if _, ok := noNeedCastAggFuncs[name]; ok {
 		return b, nil
 	}
-	for _, arg := range args {
+	// Wrap a cast upon the args whose eval type differs from the return type,
+	// e.g. "sum(double)" over a string.
+	for i, arg := range args {
+		// The first arg of a two-args "avg" is the partial count, keep it as an integer.
+		if b.Name == ast.AggFuncAvg && len(args) == 2 && i == 0 {
+			continue
+		}
+		// "sum(int)" and "avg(int)" return a decimal, the integer is widened to decimal
+		// when it is added, so the aggregation can be pushed down without a cast.
+		if IsIntWidenedToDecimal(b.Name, arg, b.RetTp) {
+			continue
+		}
 		if arg.GetType().EvalType() != b.RetTp.EvalType() {
-			log.Warn(fmt.Sprintf("unmatched arg tp %v with return tp %v", arg.GetType().EvalType(), b.RetTp.EvalType()))
+			b.Args[i] = expression.BuildCastFunction(ctx, arg, b.RetTp.Clone())
 		}
 	}
 	return b, nil
 }
 
+// IsIntWidenedToDecimal checks whether arg is an integer argument of "sum" or "avg"
+// which returns a decimal. Such an argument is evaluated as an integer and widened
+// to decimal by the aggregation itself.
+func IsIntWidenedToDecimal(name string, arg expression.Expression, retTp *types.FieldType) bool {
+	return (name == ast.AggFuncSum || name == ast.AggFuncAvg) &&
+		arg.GetType().EvalType() == types.ETInt && retTp.EvalType() == types.ETDecimal
+}
+
 func (a *baseFuncDesc) equal(ctx sessionctx.Context, other *baseFuncDesc) bool {
 	if a.Name != other.Name || len(a.Args) != len(other.Args) {
 		return false
@@ -102,6 +122,12 @@ func (a *baseFuncDesc) typeInfer(ctx sessionctx.Context) error {
 		a.typeInfer4Avg(ctx)
 	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
 		a.typeInfer4MaxMin(ctx)
+	case ast.AggFuncGroupConcat:
+		a.typeInfer4GroupConcat(ctx)
+	case ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor:
+		a.typeInfer4BitFuncs(ctx)
+	case ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+		a.typeInfer4Variance(ctx)
 	default:
 		return errors.Errorf("unsupported agg function: %s", a.Name)
 	}
@@ -119,7 +145,14 @@ func (a *baseFuncDesc) typeInfer4Count(ctx sessionctx.Context) {
 func (a *baseFuncDesc) typeInfer4Sum(ctx sessionctx.Context) {
 	switch a.Args[0].GetType().Tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
-		a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+		a.RetTp = types.NewFieldType(mysql.TypeNewDecimal)
+		a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxDecimalWidth, 0
+	case mysql.TypeNewDecimal:
+		a.RetTp = types.NewFieldType(mysql.TypeNewDecimal)
+		a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxDecimalWidth, a.Args[0].GetType().Decimal
+		if a.RetTp.Decimal < 0 || a.RetTp.Decimal > mysql.MaxDecimalScale {
+			a.RetTp.Decimal = mysql.MaxDecimalScale
+		}
 	case mysql.TypeDouble, mysql.TypeFloat:
 		a.RetTp = types.NewFieldType(mysql.TypeDouble)
 		a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxRealWidth, a.Args[0].GetType().Decimal
@@ -134,8 +167,14 @@ func (a *baseFuncDesc) typeInfer4Sum(ctx sessionctx.Context) {
 // Because child returns integer or decimal type.
 func (a *baseFuncDesc) typeInfer4Avg(ctx sessionctx.Context) {
 	switch a.Args[0].GetType().Tp {
-	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
-		a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeNewDecimal:
+		a.RetTp = types.NewFieldType(mysql.TypeNewDecimal)
+		if a.Args[0].GetType().Decimal < 0 {
+			a.RetTp.Decimal = mysql.MaxDecimalScale
+		} else {
+			a.RetTp.Decimal = mathutil.Min(a.Args[0].GetType().Decimal+types.DivFracIncr, mysql.MaxDecimalScale)
+		}
+		a.RetTp.Flen = mysql.MaxDecimalWidth
 	case mysql.TypeDouble, mysql.TypeFloat:
 		a.RetTp = types.NewFieldType(mysql.TypeDouble)
 		a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxRealWidth, a.Args[0].GetType().Decimal
@@ -157,6 +196,31 @@ func (a *baseFuncDesc) typeInfer4MaxMin(ctx sessionctx.Context) {
 	}
 }
 
+// typeInfer4GroupConcat returns a "varchar". Its arguments, including the
+// trailing separator, are evaluated as strings.
+func (a *baseFuncDesc) typeInfer4GroupConcat(ctx sessionctx.Context) {
+	a.RetTp = types.NewFieldType(mysql.TypeVarString)
+	a.RetTp.Charset, a.RetTp.Collate = charset.GetDefaultCharsetAndCollate()
+	a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxBlobWidth, 0
+}
+
+// typeInfer4BitFuncs returns an unsigned "bigint", its argument is evaluated
+// as an unsigned integer.
+func (a *baseFuncDesc) typeInfer4BitFuncs(ctx sessionctx.Context) {
+	a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+	a.RetTp.Flen = 21
+	types.SetBinChsClnFlag(a.RetTp)
+	a.RetTp.Flag |= mysql.UnsignedFlag
+}
+
+// typeInfer4Variance returns a "double" for var_pop, var_samp, stddev_pop
+// and stddev_samp.
+func (a *baseFuncDesc) typeInfer4Variance(ctx sessionctx.Context) {
+	a.RetTp = types.NewFieldType(mysql.TypeDouble)
+	a.RetTp.Flen, a.RetTp.Decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+	types.SetBinChsClnFlag(a.RetTp)
+}
+
 // GetDefaultValue gets the default value when the function's input is null.
 // According to MySQL, default values of the function are listed as follows:
 // e.g.
@@ -175,10 +239,13 @@ func (a *baseFuncDesc) typeInfer4MaxMin(ctx sessionctx.Context) {
 // +------+--------+--------+----------+------------+-----------+----------------------+--------+--------+-----------------+
 func (a *baseFuncDesc) GetDefaultValue() (v types.Datum) {
 	switch a.Name {
-	case ast.AggFuncCount:
+	case ast.AggFuncCount, ast.AggFuncBitOr, ast.AggFuncBitXor:
 		v = types.NewIntDatum(0)
+	case ast.AggFuncBitAnd:
+		v = types.NewUintDatum(uint64(math.MaxUint64))
 	case ast.AggFuncFirstRow, ast.AggFuncAvg, ast.AggFuncSum, ast.AggFuncMax,
-		ast.AggFuncMin:
+		ast.AggFuncMin, ast.AggFuncGroupConcat, ast.AggFuncVarPop, ast.AggFuncVarSamp,
+		ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
 		v = types.Datum{}
 	}
 	return
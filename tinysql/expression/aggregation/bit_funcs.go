@@ -0,0 +1,144 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// evalBitFuncArg evaluates the only arg of a bit function as an unsigned
+// integer.
+func evalBitFuncArg(af *aggFunction, sc *stmtctx.StatementContext, row chunk.Row) (uint64, bool, error) {
+	value, err := af.Args[0].Eval(row)
+	if err != nil || value.IsNull() {
+		return 0, true, err
+	}
+	if value.Kind() == types.KindUint64 {
+		return value.GetUint64(), false, nil
+	}
+	i64, err := value.ToInt64(sc)
+	return uint64(i64), false, err
+}
+
+type bitOrFunction struct {
+	aggFunction
+}
+
+// CreateContext implements Aggregation interface.
+func (bf *bitOrFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
+	evalCtx := bf.aggFunction.CreateContext(sc)
+	evalCtx.Value.SetUint64(0)
+	return evalCtx
+}
+
+// ResetContext implements Aggregation interface.
+func (bf *bitOrFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Value.SetUint64(0)
+}
+
+// Update implements Aggregation interface.
+func (bf *bitOrFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	v, isNull, err := evalBitFuncArg(&bf.aggFunction, sc, row)
+	if err != nil || isNull {
+		return err
+	}
+	evalCtx.Value.SetUint64(evalCtx.Value.GetUint64() | v)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (bf *bitOrFunction) GetResult(evalCtx *AggEvaluateContext) types.Datum {
+	return evalCtx.Value
+}
+
+// GetPartialResult implements Aggregation interface.
+func (bf *bitOrFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{bf.GetResult(evalCtx)}
+}
+
+type bitXorFunction struct {
+	aggFunction
+}
+
+// CreateContext implements Aggregation interface.
+func (bf *bitXorFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
+	evalCtx := bf.aggFunction.CreateContext(sc)
+	evalCtx.Value.SetUint64(0)
+	return evalCtx
+}
+
+// ResetContext implements Aggregation interface.
+func (bf *bitXorFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Value.SetUint64(0)
+}
+
+// Update implements Aggregation interface.
+func (bf *bitXorFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	v, isNull, err := evalBitFuncArg(&bf.aggFunction, sc, row)
+	if err != nil || isNull {
+		return err
+	}
+	evalCtx.Value.SetUint64(evalCtx.Value.GetUint64() ^ v)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (bf *bitXorFunction) GetResult(evalCtx *AggEvaluateContext) types.Datum {
+	return evalCtx.Value
+}
+
+// GetPartialResult implements Aggregation interface.
+func (bf *bitXorFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{bf.GetResult(evalCtx)}
+}
+
+type bitAndFunction struct {
+	aggFunction
+}
+
+// CreateContext implements Aggregation interface.
+func (bf *bitAndFunction) CreateContext(sc *stmtctx.StatementContext) *AggEvaluateContext {
+	evalCtx := bf.aggFunction.CreateContext(sc)
+	evalCtx.Value.SetUint64(math.MaxUint64)
+	return evalCtx
+}
+
+// ResetContext implements Aggregation interface.
+func (bf *bitAndFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Value.SetUint64(math.MaxUint64)
+}
+
+// Update implements Aggregation interface.
+func (bf *bitAndFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	v, isNull, err := evalBitFuncArg(&bf.aggFunction, sc, row)
+	if err != nil || isNull {
+		return err
+	}
+	evalCtx.Value.SetUint64(evalCtx.Value.GetUint64() & v)
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (bf *bitAndFunction) GetResult(evalCtx *AggEvaluateContext) types.Datum {
+	return evalCtx.Value
+}
+
+// GetPartialResult implements Aggregation interface.
+func (bf *bitAndFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{bf.GetResult(evalCtx)}
+}
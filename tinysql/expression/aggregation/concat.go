@@ -0,0 +1,83 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"bytes"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+type concatFunction struct {
+	aggFunction
+}
+
+// ResetContext implements Aggregation interface.
+func (cf *concatFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Buffer = nil
+}
+
+// Update implements Aggregation interface. The last arg is the separator,
+// only the values of the others are concatenated.
+func (cf *concatFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	values := make([]string, 0, len(cf.Args)-1)
+	for _, arg := range cf.Args[:len(cf.Args)-1] {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return err
+		}
+		if value.IsNull() {
+			return nil
+		}
+		s, err := value.ToString()
+		if err != nil {
+			return err
+		}
+		values = append(values, s)
+	}
+	if evalCtx.Buffer == nil {
+		evalCtx.Buffer = &bytes.Buffer{}
+	} else {
+		sep, err := cf.Args[len(cf.Args)-1].Eval(row)
+		if err != nil {
+			return err
+		}
+		if sep.IsNull() {
+			return errors.Errorf("Invalid separator argument.")
+		}
+		evalCtx.Buffer.WriteString(sep.GetString())
+	}
+	for _, s := range values {
+		evalCtx.Buffer.WriteString(s)
+	}
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (cf *concatFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	if evalCtx.Buffer != nil {
+		d.SetString(evalCtx.Buffer.String())
+	} else {
+		d.SetNull()
+	}
+	return d
+}
+
+// GetPartialResult implements Aggregation interface.
+func (cf *concatFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	return []types.Datum{cf.GetResult(evalCtx)}
+}
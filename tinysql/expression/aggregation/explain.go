@@ -16,17 +16,37 @@ package aggregation
 import (
 	"bytes"
 	"fmt"
+
+	"github.com/pingcap/tidb/parser/ast"
 )
 
 // ExplainAggFunc generates explain information for a aggregation function.
 func ExplainAggFunc(agg *AggFuncDesc) string {
 	var buffer bytes.Buffer
 	fmt.Fprintf(&buffer, "%s(", agg.Name)
+	if agg.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
 	for i, arg := range agg.Args {
-		buffer.WriteString(arg.ExplainInfo())
-		if i+1 < len(agg.Args) {
+		if agg.Name == ast.AggFuncGroupConcat && i == len(agg.Args)-1 {
+			if len(agg.OrderByItems) > 0 {
+				buffer.WriteString(" order by ")
+				for j, item := range agg.OrderByItems {
+					order := "asc"
+					if item.Desc {
+						order = "desc"
+					}
+					fmt.Fprintf(&buffer, "%s %s", item.Expr.ExplainInfo(), order)
+					if j+1 < len(agg.OrderByItems) {
+						buffer.WriteString(", ")
+					}
+				}
+			}
+			buffer.WriteString(" separator ")
+		} else if i != 0 {
 			buffer.WriteString(", ")
 		}
+		buffer.WriteString(arg.ExplainInfo())
 	}
 	buffer.WriteString(")")
 	return buffer.String()
@@ -0,0 +1,151 @@
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// varianceFunction computes var_pop, var_samp, stddev_pop and stddev_samp.
+// Its partial result is the count, the sum and the sum of squared
+// differences from the mean of the input values.
+type varianceFunction struct {
+	aggFunction
+}
+
+// ResetContext implements Aggregation interface.
+func (vf *varianceFunction) ResetContext(sc *stmtctx.StatementContext, evalCtx *AggEvaluateContext) {
+	evalCtx.Count = 0
+	evalCtx.Value.SetNull()
+	evalCtx.Variance = 0
+}
+
+// Update implements Aggregation interface.
+func (vf *varianceFunction) Update(evalCtx *AggEvaluateContext, sc *stmtctx.StatementContext, row chunk.Row) error {
+	switch vf.Mode {
+	case Partial1Mode, CompleteMode:
+		value, err := vf.Args[0].Eval(row)
+		if err != nil || value.IsNull() {
+			return err
+		}
+		input, err := value.ToFloat64(sc)
+		if err != nil {
+			return err
+		}
+		sum := input
+		if evalCtx.Count > 0 {
+			sum += evalCtx.Value.GetFloat64()
+		}
+		evalCtx.Count++
+		evalCtx.Value.SetFloat64(sum)
+		if evalCtx.Count > 1 {
+			evalCtx.Variance = CalculateVarianceIntermediate(evalCtx.Count, sum, input, evalCtx.Variance)
+		}
+	case Partial2Mode, FinalMode:
+		datums := make([]types.Datum, 0, 3)
+		for _, arg := range vf.Args {
+			value, err := arg.Eval(row)
+			if err != nil {
+				return err
+			}
+			datums = append(datums, value)
+		}
+		count, err := datums[0].ToInt64(sc)
+		if err != nil || count == 0 {
+			return err
+		}
+		sum, err := datums[1].ToFloat64(sc)
+		if err != nil {
+			return err
+		}
+		variance, err := datums[2].ToFloat64(sc)
+		if err != nil {
+			return err
+		}
+		if evalCtx.Count > 0 {
+			dstSum := evalCtx.Value.GetFloat64()
+			evalCtx.Variance = CalculateVarianceMerge(count, evalCtx.Count, sum, dstSum, variance, evalCtx.Variance)
+			sum += dstSum
+		} else {
+			evalCtx.Variance = variance
+		}
+		evalCtx.Count += count
+		evalCtx.Value.SetFloat64(sum)
+	}
+	return nil
+}
+
+// GetResult implements Aggregation interface.
+func (vf *varianceFunction) GetResult(evalCtx *AggEvaluateContext) (d types.Datum) {
+	res, ok := FinalizeVariance(vf.Name, evalCtx.Count, evalCtx.Variance)
+	if ok {
+		d.SetFloat64(res)
+	}
+	return d
+}
+
+// GetPartialResult implements Aggregation interface.
+func (vf *varianceFunction) GetPartialResult(evalCtx *AggEvaluateContext) []types.Datum {
+	sum := evalCtx.Value
+	if evalCtx.Count == 0 {
+		sum = types.NewFloat64Datum(0)
+	}
+	return []types.Datum{types.NewIntDatum(evalCtx.Count), sum, types.NewFloat64Datum(evalCtx.Variance)}
+}
+
+// CalculateVarianceIntermediate adds input to the sum of squared differences
+// variance. count and sum must already include input and count must be
+// greater than 1.
+func CalculateVarianceIntermediate(count int64, sum float64, input float64, variance float64) float64 {
+	t := float64(count)*input - sum
+	variance += (t * t) / (float64(count) * float64(count-1))
+	return variance
+}
+
+// CalculateVarianceMerge merges the sums of squared differences of two
+// non-empty partial groups.
+func CalculateVarianceMerge(srcCount, dstCount int64, srcSum, dstSum, srcVariance, dstVariance float64) float64 {
+	srcCountFloat64 := float64(srcCount)
+	dstCountFloat64 := float64(dstCount)
+	t := (srcCountFloat64/dstCountFloat64)*dstSum - srcSum
+	dstVariance += srcVariance + ((dstCountFloat64/srcCountFloat64)/(dstCountFloat64+srcCountFloat64))*t*t
+	return dstVariance
+}
+
+// FinalizeVariance computes the result of the variance function name from
+// the count and the sum of squared differences of its group. ok is false if
+// the result is NULL.
+func FinalizeVariance(name string, count int64, variance float64) (res float64, ok bool) {
+	switch name {
+	case ast.AggFuncVarPop, ast.AggFuncStddevPop:
+		if count == 0 {
+			return 0, false
+		}
+		res = variance / float64(count)
+	case ast.AggFuncVarSamp, ast.AggFuncStddevSamp:
+		if count <= 1 {
+			return 0, false
+		}
+		res = variance / float64(count-1)
+	}
+	if name == ast.AggFuncStddevPop || name == ast.AggFuncStddevSamp {
+		res = math.Sqrt(res)
+	}
+	return res, true
+}
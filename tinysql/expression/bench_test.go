@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/mock"
 )
@@ -73,12 +74,43 @@ func (g *defaultGener) gen() interface{} {
 			return -rand.Float64() * 1000000
 		}
 		return rand.Float64() * 1000000
+	case types.ETDecimal:
+		d := new(types.MyDecimal)
+		f := rand.Float64() * 100000
+		if rand.Float64() < 0.5 {
+			f = -f
+		}
+		if err := d.FromFloat64(f); err != nil {
+			panic(err)
+		}
+		return d
+	case types.ETDatetime, types.ETTimestamp:
+		gt := getRandomTime()
+		t := types.NewTime(gt, convertETType(g.eType), 0)
+		return t
+	case types.ETDuration:
+		d := types.Duration{
+			// use rand.Int32() to make it not overflow when AddDuration
+			Duration: time.Duration(rand.Int31()),
+		}
+		return d
 	case types.ETString:
 		return randString()
+	case types.ETJson:
+		m := make(map[string]interface{}, 3)
+		for i := 0; i < 3; i++ {
+			m[randString()] = rand.Int63()
+		}
+		return json.CreateBinary(m)
 	}
 	return nil
 }
 
+func getRandomTime() types.CoreTime {
+	return types.FromDate(rand.Intn(2200), rand.Intn(10)+1, rand.Intn(20)+1,
+		rand.Intn(12), rand.Intn(60), rand.Intn(60), rand.Intn(1000000))
+}
+
 // selectStringGener select one string randomly from the candidates array
 type selectStringGener struct {
 	candidates []string
@@ -151,6 +183,111 @@ func (g *randLenStrGener) gen() interface{} {
 	return string(buf)
 }
 
+// dateTimeGener is used to generate a dataTime
+type dateTimeGener struct {
+	Fsp   int
+	Year  int
+	Month int
+	Day   int
+}
+
+func (g *dateTimeGener) gen() interface{} {
+	if g.Year == 0 {
+		g.Year = 1970 + rand.Intn(100)
+	}
+	if g.Month == 0 {
+		g.Month = rand.Intn(10) + 1
+	}
+	if g.Day == 0 {
+		g.Day = rand.Intn(20) + 1
+	}
+	var gt types.CoreTime
+	if g.Fsp > 0 && g.Fsp <= 6 {
+		gt = types.FromDate(g.Year, g.Month, g.Day, rand.Intn(12), rand.Intn(60), rand.Intn(60), rand.Intn(1000000))
+	} else {
+		gt = types.FromDate(g.Year, g.Month, g.Day, rand.Intn(12), rand.Intn(60), rand.Intn(60), 0)
+	}
+	t := types.NewTime(gt, mysql.TypeDatetime, types.DefaultFsp)
+	return t
+}
+
+// dateTimeStrGener is used to generate strings which are dataTime format
+type dateTimeStrGener struct {
+	Fsp   int
+	Year  int
+	Month int
+	Day   int
+}
+
+func (g *dateTimeStrGener) gen() interface{} {
+	if g.Year == 0 {
+		g.Year = 1970 + rand.Intn(100)
+	}
+	if g.Month == 0 {
+		g.Month = rand.Intn(10) + 1
+	}
+	if g.Day == 0 {
+		g.Day = rand.Intn(20) + 1
+	}
+	hour := rand.Intn(12)
+	minute := rand.Intn(60)
+	second := rand.Intn(60)
+	dataTimeStr := fmt.Sprintf("%d-%d-%d %d:%d:%d",
+		g.Year, g.Month, g.Day, hour, minute, second)
+	if g.Fsp > 0 && g.Fsp <= 6 {
+		maxFrac := 1
+		for i := 0; i < g.Fsp; i++ {
+			maxFrac *= 10
+		}
+		microFmt := fmt.Sprintf(".%%0%dd", g.Fsp)
+		return dataTimeStr + fmt.Sprintf(microFmt, rand.Intn(maxFrac))
+	}
+
+	return dataTimeStr
+}
+
+// dateStrGener is used to generate strings which are date format
+type dateStrGener struct {
+	Year       int
+	Month      int
+	Day        int
+	NullRation float64
+}
+
+func (g *dateStrGener) gen() interface{} {
+	if g.NullRation > 1e-6 && rand.Float64() < g.NullRation {
+		return nil
+	}
+
+	if g.Year == 0 {
+		g.Year = 1970 + rand.Intn(100)
+	}
+	if g.Month == 0 {
+		g.Month = rand.Intn(10) + 1
+	}
+	if g.Day == 0 {
+		g.Day = rand.Intn(20) + 1
+	}
+
+	return fmt.Sprintf("%d-%d-%d", g.Year, g.Month, g.Day)
+}
+
+// timeStrGener is used to generate strings which are time format
+type timeStrGener struct {
+	nullRation float64
+}
+
+func (g *timeStrGener) gen() interface{} {
+	if g.nullRation > 1e-6 && rand.Float64() < g.nullRation {
+		return nil
+	}
+	hour := rand.Intn(12)
+	minute := rand.Intn(60)
+	second := rand.Intn(60)
+
+	return fmt.Sprintf("%d:%d:%d", hour, minute, second)
+}
+
 type vecExprBenchCase struct {
 	// retEvalType is the EvalType of the expression result.
 	// This field is required.
@@ -206,8 +343,16 @@ func fillColumnWithGener(eType types.EvalType, chk *chunk.Chunk, colIdx int, gen
 			col.AppendInt64(v.(int64))
 		case types.ETReal:
 			col.AppendFloat64(v.(float64))
+		case types.ETDecimal:
+			col.AppendMyDecimal(v.(*types.MyDecimal))
+		case types.ETDatetime, types.ETTimestamp:
+			col.AppendTime(v.(types.Time))
+		case types.ETDuration:
+			col.AppendDuration(v.(types.Duration))
 		case types.ETString:
 			col.AppendString(v.(string))
+		case types.ETJson:
+			col.AppendJSON(v.(json.BinaryJSON))
 		}
 	}
 }
@@ -234,8 +379,18 @@ func eType2FieldType(eType types.EvalType) *types.FieldType {
 		return types.NewFieldType(mysql.TypeLonglong)
 	case types.ETReal:
 		return types.NewFieldType(mysql.TypeDouble)
+	case types.ETDecimal:
+		return types.NewFieldType(mysql.TypeNewDecimal)
+	case types.ETDatetime:
+		return types.NewFieldType(mysql.TypeDatetime)
+	case types.ETTimestamp:
+		return types.NewFieldType(mysql.TypeTimestamp)
+	case types.ETDuration:
+		return types.NewFieldType(mysql.TypeDuration)
 	case types.ETString:
 		return types.NewFieldType(mysql.TypeVarString)
+	case types.ETJson:
+		return types.NewFieldType(mysql.TypeJSON)
 	default:
 		panic(fmt.Sprintf("EvalType=%v is not supported.", eType))
 	}
@@ -305,6 +460,27 @@ func testVectorizedEvalOneVec(c *C, vecExprCases vecExprBenchCases) {
 						c.Assert(c1.GetFloat64(i), Equals, c2.GetFloat64(i), commentf(i))
 					}
 				}
+			case types.ETDecimal:
+				for i := 0; i < input.NumRows(); i++ {
+					c.Assert(c1.IsNull(i), Equals, c2.IsNull(i), commentf(i))
+					if !c1.IsNull(i) {
+						c.Assert(c1.GetDecimal(i).Compare(c2.GetDecimal(i)), Equals, 0, commentf(i))
+					}
+				}
+			case types.ETDatetime, types.ETTimestamp:
+				for i := 0; i < input.NumRows(); i++ {
+					c.Assert(c1.IsNull(i), Equals, c2.IsNull(i), commentf(i))
+					if !c1.IsNull(i) {
+						c.Assert(c1.GetTime(i).Compare(c2.GetTime(i)), Equals, 0, commentf(i))
+					}
+				}
+			case types.ETDuration:
+				for i := 0; i < input.NumRows(); i++ {
+					c.Assert(c1.IsNull(i), Equals, c2.IsNull(i), commentf(i))
+					if !c1.IsNull(i) {
+						c.Assert(c1.GetDuration(i, 0), Equals, c2.GetDuration(i, 0), commentf(i))
+					}
+				}
 			case types.ETString:
 				for i := 0; i < input.NumRows(); i++ {
 					c.Assert(c1.IsNull(i), Equals, c2.IsNull(i), commentf(i))
@@ -312,6 +488,13 @@ func testVectorizedEvalOneVec(c *C, vecExprCases vecExprBenchCases) {
 						c.Assert(c1.GetString(i), Equals, c2.GetString(i), commentf(i))
 					}
 				}
+			case types.ETJson:
+				for i := 0; i < input.NumRows(); i++ {
+					c.Assert(c1.IsNull(i), Equals, c2.IsNull(i), commentf(i))
+					if !c1.IsNull(i) {
+						c.Assert(json.CompareBinary(c1.GetJSON(i), c2.GetJSON(i)), Equals, 0, commentf(i))
+					}
+				}
 			}
 		}
 	}
@@ -379,7 +562,30 @@ func genVecBuiltinFuncBenchCase(ctx sessionctx.Context, funcName string, testCas
 		input.SetNumVirtualRows(testCase.chunkSize)
 	}
 
-	baseFunc, err := funcs[funcName].getFunction(ctx, cols)
+	var err error
+	if funcName == ast.Cast {
+		var fc functionClass
+		tp := eType2FieldType(testCase.retEvalType)
+		switch testCase.retEvalType {
+		case types.ETInt:
+			fc = &castAsIntFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETReal:
+			fc = &castAsRealFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETDecimal:
+			fc = &castAsDecimalFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETDatetime, types.ETTimestamp:
+			fc = &castAsTimeFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETDuration:
+			fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETString:
+			fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		case types.ETJson:
+			fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
+		baseFunc, err = fc.getFunction(ctx, cols)
+	} else {
+		baseFunc, err = funcs[funcName].getFunction(ctx, cols)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -473,6 +679,54 @@ func testVectorizedBuiltinFunc(c *C, vecExprCases vecExprBenchCases) {
 					}
 					i++
 				}
+			case types.ETDecimal:
+				err := baseFunc.vecEvalDecimal(input, output)
+				c.Assert(err, IsNil, Commentf("func: %v, case: %+v", baseFuncName, testCase))
+				// do not forget to call ResizeXXX/ReserveXXX
+				c.Assert(getColumnLen(output, testCase.retEvalType), Equals, input.NumRows())
+				vecWarnCnt = ctx.GetSessionVars().StmtCtx.WarningCount()
+				d64s := output.Decimals()
+				for row := it.Begin(); row != it.End(); row = it.Next() {
+					val, isNull, err := baseFunc.evalDecimal(row)
+					c.Assert(err, IsNil, commentf(i))
+					c.Assert(isNull, Equals, output.IsNull(i), commentf(i))
+					if !isNull {
+						c.Assert(val.Compare(&d64s[i]), Equals, 0, commentf(i))
+					}
+					i++
+				}
+			case types.ETDatetime, types.ETTimestamp:
+				err := baseFunc.vecEvalTime(input, output)
+				c.Assert(err, IsNil, Commentf("func: %v, case: %+v", baseFuncName, testCase))
+				// do not forget to call ResizeXXX/ReserveXXX
+				c.Assert(getColumnLen(output, testCase.retEvalType), Equals, input.NumRows())
+				vecWarnCnt = ctx.GetSessionVars().StmtCtx.WarningCount()
+				t64s := output.Times()
+				for row := it.Begin(); row != it.End(); row = it.Next() {
+					val, isNull, err := baseFunc.evalTime(row)
+					c.Assert(err, IsNil, commentf(i))
+					c.Assert(isNull, Equals, output.IsNull(i), commentf(i))
+					if !isNull {
+						c.Assert(val.Compare(t64s[i]), Equals, 0, commentf(i))
+					}
+					i++
+				}
+			case types.ETDuration:
+				err := baseFunc.vecEvalDuration(input, output)
+				c.Assert(err, IsNil, Commentf("func: %v, case: %+v", baseFuncName, testCase))
+				// do not forget to call ResizeXXX/ReserveXXX
+				c.Assert(getColumnLen(output, testCase.retEvalType), Equals, input.NumRows())
+				vecWarnCnt = ctx.GetSessionVars().StmtCtx.WarningCount()
+				d64s := output.GoDurations()
+				for row := it.Begin(); row != it.End(); row = it.Next() {
+					val, isNull, err := baseFunc.evalDuration(row)
+					c.Assert(err, IsNil, commentf(i))
+					c.Assert(isNull, Equals, output.IsNull(i), commentf(i))
+					if !isNull {
+						c.Assert(val.Duration, Equals, d64s[i], commentf(i))
+					}
+					i++
+				}
 			case types.ETString:
 				err := baseFunc.vecEvalString(input, output)
 				c.Assert(err, IsNil, Commentf("func: %v, case: %+v", baseFuncName, testCase))
@@ -488,6 +742,21 @@ func testVectorizedBuiltinFunc(c *C, vecExprCases vecExprBenchCases) {
 					}
 					i++
 				}
+			case types.ETJson:
+				err := baseFunc.vecEvalJSON(input, output)
+				c.Assert(err, IsNil, Commentf("func: %v, case: %+v", baseFuncName, testCase))
+				// do not forget to call ResizeXXX/ReserveXXX
+				c.Assert(getColumnLen(output, testCase.retEvalType), Equals, input.NumRows())
+				vecWarnCnt = ctx.GetSessionVars().StmtCtx.WarningCount()
+				for row := it.Begin(); row != it.End(); row = it.Next() {
+					val, isNull, err := baseFunc.evalJSON(row)
+					c.Assert(err, IsNil, commentf(i))
+					c.Assert(isNull, Equals, output.IsNull(i), commentf(i))
+					if !isNull {
+						c.Assert(json.CompareBinary(val, output.GetJSON(i)), Equals, 0, commentf(i))
+					}
+					i++
+				}
 			default:
 				c.Fatal(fmt.Sprintf("evalType=%v is not supported", testCase.retEvalType))
 			}
@@ -543,12 +812,36 @@ func benchmarkVectorizedBuiltinFunc(b *testing.B, vecExprCases vecExprBenchCases
 							b.Fatal(err)
 						}
 					}
+				case types.ETDecimal:
+					for i := 0; i < b.N; i++ {
+						if err := baseFunc.vecEvalDecimal(input, output); err != nil {
+							b.Fatal(err)
+						}
+					}
+				case types.ETDatetime, types.ETTimestamp:
+					for i := 0; i < b.N; i++ {
+						if err := baseFunc.vecEvalTime(input, output); err != nil {
+							b.Fatal(err)
+						}
+					}
+				case types.ETDuration:
+					for i := 0; i < b.N; i++ {
+						if err := baseFunc.vecEvalDuration(input, output); err != nil {
+							b.Fatal(err)
+						}
+					}
 				case types.ETString:
 					for i := 0; i < b.N; i++ {
 						if err := baseFunc.vecEvalString(input, output); err != nil {
 							b.Fatal(err)
 						}
 					}
+				case types.ETJson:
+					for i := 0; i < b.N; i++ {
+						if err := baseFunc.vecEvalJSON(input, output); err != nil {
+							b.Fatal(err)
+						}
+					}
 				default:
 					b.Fatal(fmt.Sprintf("evalType=%v is not supported", testCase.retEvalType))
 				}
@@ -587,6 +880,51 @@ func benchmarkVectorizedBuiltinFunc(b *testing.B, vecExprCases vecExprBenchCases
 							}
 						}
 					}
+				case types.ETDecimal:
+					for i := 0; i < b.N; i++ {
+						output.Reset(testCase.retEvalType)
+						for row := it.Begin(); row != it.End(); row = it.Next() {
+							v, isNull, err := baseFunc.evalDecimal(row)
+							if err != nil {
+								b.Fatal(err)
+							}
+							if isNull {
+								output.AppendNull()
+							} else {
+								output.AppendMyDecimal(v)
+							}
+						}
+					}
+				case types.ETDatetime, types.ETTimestamp:
+					for i := 0; i < b.N; i++ {
+						output.Reset(testCase.retEvalType)
+						for row := it.Begin(); row != it.End(); row = it.Next() {
+							v, isNull, err := baseFunc.evalTime(row)
+							if err != nil {
+								b.Fatal(err)
+							}
+							if isNull {
+								output.AppendNull()
+							} else {
+								output.AppendTime(v)
+							}
+						}
+					}
+				case types.ETDuration:
+					for i := 0; i < b.N; i++ {
+						output.Reset(testCase.retEvalType)
+						for row := it.Begin(); row != it.End(); row = it.Next() {
+							v, isNull, err := baseFunc.evalDuration(row)
+							if err != nil {
+								b.Fatal(err)
+							}
+							if isNull {
+								output.AppendNull()
+							} else {
+								output.AppendDuration(v)
+							}
+						}
+					}
 				case types.ETString:
 					for i := 0; i < b.N; i++ {
 						output.Reset(testCase.retEvalType)
@@ -602,6 +940,21 @@ func benchmarkVectorizedBuiltinFunc(b *testing.B, vecExprCases vecExprBenchCases
 							}
 						}
 					}
+				case types.ETJson:
+					for i := 0; i < b.N; i++ {
+						output.Reset(testCase.retEvalType)
+						for row := it.Begin(); row != it.End(); row = it.Next() {
+							v, isNull, err := baseFunc.evalJSON(row)
+							if err != nil {
+								b.Fatal(err)
+							}
+							if isNull {
+								output.AppendNull()
+							} else {
+								output.AppendJSON(v)
+							}
+						}
+					}
 				default:
 					b.Fatal(fmt.Sprintf("evalType=%v is not supported", testCase.retEvalType))
 				}
@@ -30,17 +30,29 @@ var (
 	_ functionClass = &arithmeticMinusFunctionClass{}
 	_ functionClass = &arithmeticDivideFunctionClass{}
 	_ functionClass = &arithmeticMultiplyFunctionClass{}
+	_ functionClass = &arithmeticIntDivideFunctionClass{}
+	_ functionClass = &arithmeticModFunctionClass{}
 )
 
 var (
 	_ builtinFunc = &builtinArithmeticPlusRealSig{}
+	_ builtinFunc = &builtinArithmeticPlusDecimalSig{}
 	_ builtinFunc = &builtinArithmeticPlusIntSig{}
 	_ builtinFunc = &builtinArithmeticMinusRealSig{}
+	_ builtinFunc = &builtinArithmeticMinusDecimalSig{}
 	_ builtinFunc = &builtinArithmeticMinusIntSig{}
 	_ builtinFunc = &builtinArithmeticDivideRealSig{}
+	_ builtinFunc = &builtinArithmeticDivideDecimalSig{}
 	_ builtinFunc = &builtinArithmeticMultiplyRealSig{}
+	_ builtinFunc = &builtinArithmeticMultiplyDecimalSig{}
 	_ builtinFunc = &builtinArithmeticMultiplyIntUnsignedSig{}
 	_ builtinFunc = &builtinArithmeticMultiplyIntSig{}
+	_ builtinFunc = &builtinArithmeticIntDivideIntSig{}
+	_ builtinFunc = &builtinArithmeticIntDivideRealSig{}
+	_ builtinFunc = &builtinArithmeticIntDivideDecimalSig{}
+	_ builtinFunc = &builtinArithmeticModIntSig{}
+	_ builtinFunc = &builtinArithmeticModRealSig{}
+	_ builtinFunc = &builtinArithmeticModDecimalSig{}
 )
 
 // numericContextResultType returns types.EvalType for numeric function's parameters.
@@ -52,7 +64,7 @@ func numericContextResultType(ft *types.FieldType) types.EvalType {
 	evalTp4Ft := types.ETReal
 	if !ft.Hybrid() {
 		evalTp4Ft = ft.EvalType()
-		if evalTp4Ft != types.ETInt {
+		if evalTp4Ft != types.ETDecimal && evalTp4Ft != types.ETInt {
 			evalTp4Ft = types.ETReal
 		}
 	}
@@ -100,6 +112,28 @@ func setFlenDecimal4RealOrDecimal(retTp, a, b *types.FieldType, isReal bool, isM
 	}
 }
 
+func (c *arithmeticDivideFunctionClass) setType4DivDecimal(retTp, a, b *types.FieldType) {
+	var deca, decb = a.Decimal, b.Decimal
+	if deca == types.UnspecifiedLength {
+		deca = 0
+	}
+	if decb == types.UnspecifiedLength {
+		decb = 0
+	}
+	retTp.Decimal = deca + types.DivFracIncr
+	if retTp.Decimal > mysql.MaxDecimalScale {
+		retTp.Decimal = mysql.MaxDecimalScale
+	}
+	if a.Flen == types.UnspecifiedLength {
+		retTp.Flen = mysql.MaxDecimalWidth
+		return
+	}
+	retTp.Flen = a.Flen + decb + types.DivFracIncr
+	if retTp.Flen > mysql.MaxDecimalWidth {
+		retTp.Flen = mysql.MaxDecimalWidth
+	}
+}
+
 func (c *arithmeticDivideFunctionClass) setType4DivReal(retTp *types.FieldType) {
 	retTp.Decimal = types.UnspecifiedLength
 	retTp.Flen = mysql.MaxRealWidth
@@ -121,6 +155,12 @@ func (c *arithmeticPlusFunctionClass) getFunction(ctx sessionctx.Context, args [
 		sig := &builtinArithmeticPlusRealSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_PlusReal)
 		return sig, nil
+	} else if lhsEvalTp == types.ETDecimal || rhsEvalTp == types.ETDecimal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal, types.ETDecimal)
+		setFlenDecimal4RealOrDecimal(bf.tp, args[0].GetType(), args[1].GetType(), false, false)
+		sig := &builtinArithmeticPlusDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_PlusDecimal)
+		return sig, nil
 	}
 	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
 	if mysql.HasUnsignedFlag(args[0].GetType().Flag) || mysql.HasUnsignedFlag(args[1].GetType().Flag) {
@@ -184,6 +224,33 @@ func (s *builtinArithmeticPlusIntSig) evalInt(row chunk.Row) (val int64, isNull
 	return a + b, false, nil
 }
 
+type builtinArithmeticPlusDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (s *builtinArithmeticPlusDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticPlusDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticPlusDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	c := &types.MyDecimal{}
+	err = types.DecimalAdd(a, b, c)
+	if err != nil {
+		return nil, true, err
+	}
+	return c, false, nil
+}
+
 type builtinArithmeticPlusRealSig struct {
 	baseBuiltinFunc
 }
@@ -225,6 +292,12 @@ func (c *arithmeticMinusFunctionClass) getFunction(ctx sessionctx.Context, args
 		sig := &builtinArithmeticMinusRealSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_MinusReal)
 		return sig, nil
+	} else if lhsEvalTp == types.ETDecimal || rhsEvalTp == types.ETDecimal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal, types.ETDecimal)
+		setFlenDecimal4RealOrDecimal(bf.tp, args[0].GetType(), args[1].GetType(), false, false)
+		sig := &builtinArithmeticMinusDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_MinusDecimal)
+		return sig, nil
 	}
 	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
 	setFlenDecimal4Int(bf.tp, args[0].GetType(), args[1].GetType())
@@ -261,6 +334,33 @@ func (s *builtinArithmeticMinusRealSig) evalReal(row chunk.Row) (float64, bool,
 	return a - b, false, nil
 }
 
+type builtinArithmeticMinusDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (s *builtinArithmeticMinusDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticMinusDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticMinusDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	c := &types.MyDecimal{}
+	err = types.DecimalSub(a, b, c)
+	if err != nil {
+		return nil, true, err
+	}
+	return c, false, nil
+}
+
 type builtinArithmeticMinusIntSig struct {
 	baseBuiltinFunc
 }
@@ -339,6 +439,12 @@ func (c *arithmeticMultiplyFunctionClass) getFunction(ctx sessionctx.Context, ar
 		sig := &builtinArithmeticMultiplyRealSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_MultiplyReal)
 		return sig, nil
+	} else if lhsEvalTp == types.ETDecimal || rhsEvalTp == types.ETDecimal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal, types.ETDecimal)
+		setFlenDecimal4RealOrDecimal(bf.tp, args[0].GetType(), args[1].GetType(), false, true)
+		sig := &builtinArithmeticMultiplyDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_MultiplyDecimal)
+		return sig, nil
 	}
 	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
 	if mysql.HasUnsignedFlag(lhsTp.Flag) || mysql.HasUnsignedFlag(rhsTp.Flag) {
@@ -362,6 +468,14 @@ func (s *builtinArithmeticMultiplyRealSig) Clone() builtinFunc {
 	return newSig
 }
 
+type builtinArithmeticMultiplyDecimalSig struct{ baseBuiltinFunc }
+
+func (s *builtinArithmeticMultiplyDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticMultiplyDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
 type builtinArithmeticMultiplyIntUnsignedSig struct{ baseBuiltinFunc }
 
 func (s *builtinArithmeticMultiplyIntUnsignedSig) Clone() builtinFunc {
@@ -394,6 +508,26 @@ func (s *builtinArithmeticMultiplyRealSig) evalReal(row chunk.Row) (float64, boo
 	return result, false, nil
 }
 
+func (s *builtinArithmeticMultiplyDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	c := &types.MyDecimal{}
+	err = types.DecimalMul(a, b, c)
+	if err != nil && err != types.ErrTruncated {
+		if err == types.ErrOverflow {
+			err = types.ErrOverflow.GenWithStackByArgs("DECIMAL", fmt.Sprintf("(%s * %s)", s.args[0].String(), s.args[1].String()))
+		}
+		return nil, true, err
+	}
+	return c, false, nil
+}
+
 func (s *builtinArithmeticMultiplyIntUnsignedSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
 	a, isNull, err := s.args[0].EvalInt(s.ctx, row)
 	if isNull || err != nil {
@@ -436,10 +570,19 @@ func (c *arithmeticDivideFunctionClass) getFunction(ctx sessionctx.Context, args
 	if err := c.verifyArgs(args); err != nil {
 		return nil, err
 	}
-	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETReal, types.ETReal, types.ETReal)
-	c.setType4DivReal(bf.tp)
-	sig := &builtinArithmeticDivideRealSig{bf}
-	sig.setPbCode(tipb.ScalarFuncSig_DivideReal)
+	lhsTp, rhsTp := args[0].GetType(), args[1].GetType()
+	lhsEvalTp, rhsEvalTp := numericContextResultType(lhsTp), numericContextResultType(rhsTp)
+	if lhsEvalTp == types.ETReal || rhsEvalTp == types.ETReal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETReal, types.ETReal, types.ETReal)
+		c.setType4DivReal(bf.tp)
+		sig := &builtinArithmeticDivideRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_DivideReal)
+		return sig, nil
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal, types.ETDecimal)
+	c.setType4DivDecimal(bf.tp, lhsTp, rhsTp)
+	sig := &builtinArithmeticDivideDecimalSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_DivideDecimal)
 	return sig, nil
 }
 
@@ -469,3 +612,389 @@ func (s *builtinArithmeticDivideRealSig) evalReal(row chunk.Row) (float64, bool,
 	}
 	return result, false, nil
 }
+
+type builtinArithmeticDivideDecimalSig struct{ baseBuiltinFunc }
+
+func (s *builtinArithmeticDivideDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticDivideDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticDivideDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	c := &types.MyDecimal{}
+	err = s.divide(a, b, c)
+	if err == types.ErrDivByZero {
+		return nil, true, handleDivisionByZeroError(s.ctx)
+	}
+	return c, err != nil, err
+}
+
+// divide computes a / b into c and rounds the quotient to the fraction of the return type.
+func (s *builtinArithmeticDivideDecimalSig) divide(a, b, c *types.MyDecimal) error {
+	err := types.DecimalDiv(a, b, c, types.DivFracIncr)
+	if err == types.ErrTruncated {
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, frac := c.PrecisionAndFrac(); frac < s.tp.Decimal {
+		err = c.Round(c, s.tp.Decimal, types.ModeHalfUp)
+	}
+	return err
+}
+
+type arithmeticIntDivideFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *arithmeticIntDivideFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	lhsTp, rhsTp := args[0].GetType(), args[1].GetType()
+	lhsEvalTp, rhsEvalTp := numericContextResultType(lhsTp), numericContextResultType(rhsTp)
+	if lhsEvalTp == types.ETInt && rhsEvalTp == types.ETInt {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+		if mysql.HasUnsignedFlag(lhsTp.Flag) || mysql.HasUnsignedFlag(rhsTp.Flag) {
+			bf.tp.Flag |= mysql.UnsignedFlag
+		}
+		setFlenDecimal4Int(bf.tp, lhsTp, rhsTp)
+		sig := &builtinArithmeticIntDivideIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IntDivideInt)
+		return sig, nil
+	}
+	if lhsEvalTp != types.ETReal && rhsEvalTp != types.ETReal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDecimal, types.ETDecimal)
+		if mysql.HasUnsignedFlag(lhsTp.Flag) || mysql.HasUnsignedFlag(rhsTp.Flag) {
+			bf.tp.Flag |= mysql.UnsignedFlag
+		}
+		setFlenDecimal4Int(bf.tp, lhsTp, rhsTp)
+		sig := &builtinArithmeticIntDivideDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IntDivideDecimal)
+		return sig, nil
+	}
+	// There is no protobuf signature for dividing two reals into an integer,
+	// so this variant is always evaluated in TiDB.
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETReal, types.ETReal)
+	setFlenDecimal4Int(bf.tp, lhsTp, rhsTp)
+	sig := &builtinArithmeticIntDivideRealSig{bf}
+	return sig, nil
+}
+
+type builtinArithmeticIntDivideIntSig struct{ baseBuiltinFunc }
+
+func (s *builtinArithmeticIntDivideIntSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticIntDivideIntSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticIntDivideIntSig) evalInt(row chunk.Row) (int64, bool, error) {
+	a, isNull, err := s.args[0].EvalInt(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	b, isNull, err := s.args[1].EvalInt(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	if b == 0 {
+		return 0, true, handleDivisionByZeroError(s.ctx)
+	}
+
+	ret, err := s.intDivide(a, b)
+	return ret, err != nil, err
+}
+
+func (s *builtinArithmeticIntDivideIntSig) intDivide(a, b int64) (int64, error) {
+	isLHSUnsigned := mysql.HasUnsignedFlag(s.args[0].GetType().Flag)
+	isRHSUnsigned := mysql.HasUnsignedFlag(s.args[1].GetType().Flag)
+
+	var (
+		ret uint64
+		err error
+	)
+	switch {
+	case isLHSUnsigned && isRHSUnsigned:
+		ret = uint64(a) / uint64(b)
+	case isLHSUnsigned && !isRHSUnsigned:
+		ret, err = types.DivUintWithInt(uint64(a), b)
+	case !isLHSUnsigned && isRHSUnsigned:
+		ret, err = types.DivIntWithUint(a, uint64(b))
+	case !isLHSUnsigned && !isRHSUnsigned:
+		return types.DivInt64(a, b)
+	}
+	return int64(ret), err
+}
+
+type builtinArithmeticIntDivideRealSig struct{ baseBuiltinFunc }
+
+func (s *builtinArithmeticIntDivideRealSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticIntDivideRealSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticIntDivideRealSig) evalInt(row chunk.Row) (int64, bool, error) {
+	a, isNull, err := s.args[0].EvalReal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	b, isNull, err := s.args[1].EvalReal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	if b == 0 {
+		return 0, true, handleDivisionByZeroError(s.ctx)
+	}
+
+	ret, err := s.intDivide(a, b)
+	return ret, err != nil, err
+}
+
+func (s *builtinArithmeticIntDivideRealSig) intDivide(a, b float64) (int64, error) {
+	ret := math.Trunc(a / b)
+	if ret < math.MinInt64 || ret >= math.MaxInt64 {
+		return 0, types.ErrOverflow.GenWithStackByArgs("BIGINT", fmt.Sprintf("(%s DIV %s)", s.args[0].String(), s.args[1].String()))
+	}
+	return int64(ret), nil
+}
+
+type builtinArithmeticIntDivideDecimalSig struct{ baseBuiltinFunc }
+
+func (s *builtinArithmeticIntDivideDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticIntDivideDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticIntDivideDecimalSig) evalInt(row chunk.Row) (int64, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	ret, err := s.intDivide(a, b)
+	if err == types.ErrDivByZero {
+		return 0, true, handleDivisionByZeroError(s.ctx)
+	}
+	return ret, err != nil, err
+}
+
+func (s *builtinArithmeticIntDivideDecimalSig) intDivide(a, b *types.MyDecimal) (int64, error) {
+	c := &types.MyDecimal{}
+	err := types.DecimalDiv(a, b, c, types.DivFracIncr)
+	if err == types.ErrTruncated {
+		err = nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	isUnsigned := mysql.HasUnsignedFlag(s.tp.Flag)
+	if isUnsigned {
+		var ret uint64
+		ret, err = c.ToUint()
+		if c.IsNegative() {
+			// -1 < a/b < 0 truncates to 0, any other negative quotient overflows.
+			ret, err = 0, nil
+			if c.Compare(types.NewDecFromInt(-1)) <= 0 {
+				err = types.ErrOverflow
+			}
+		}
+		if err == types.ErrOverflow {
+			return 0, types.ErrOverflow.GenWithStackByArgs("BIGINT UNSIGNED", fmt.Sprintf("(%s DIV %s)", s.args[0].String(), s.args[1].String()))
+		}
+		return int64(ret), nil
+	}
+	ret, err := c.ToInt()
+	// err returned by ToInt may be ErrTruncated or ErrOverflow, only handle ErrOverflow, ignore ErrTruncated.
+	if err == types.ErrOverflow {
+		return 0, types.ErrOverflow.GenWithStackByArgs("BIGINT", fmt.Sprintf("(%s DIV %s)", s.args[0].String(), s.args[1].String()))
+	}
+	return ret, nil
+}
+
+type arithmeticModFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *arithmeticModFunctionClass) setType4ModRealOrDecimal(retTp, a, b *types.FieldType, isDecimal bool) {
+	if a.Decimal == types.UnspecifiedLength || b.Decimal == types.UnspecifiedLength {
+		retTp.Decimal = types.UnspecifiedLength
+	} else {
+		retTp.Decimal = mathutil.Max(a.Decimal, b.Decimal)
+		if isDecimal && retTp.Decimal > mysql.MaxDecimalScale {
+			retTp.Decimal = mysql.MaxDecimalScale
+		}
+	}
+
+	if a.Flen == types.UnspecifiedLength || b.Flen == types.UnspecifiedLength {
+		retTp.Flen = types.UnspecifiedLength
+	} else {
+		retTp.Flen = mathutil.Max(a.Flen, b.Flen)
+		if isDecimal {
+			retTp.Flen = mathutil.Min(retTp.Flen, mysql.MaxDecimalWidth)
+			return
+		}
+		retTp.Flen = mathutil.Min(retTp.Flen, mysql.MaxRealWidth)
+	}
+}
+
+func (c *arithmeticModFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	lhsTp, rhsTp := args[0].GetType(), args[1].GetType()
+	lhsEvalTp, rhsEvalTp := numericContextResultType(lhsTp), numericContextResultType(rhsTp)
+	if lhsEvalTp == types.ETReal || rhsEvalTp == types.ETReal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETReal, types.ETReal, types.ETReal)
+		c.setType4ModRealOrDecimal(bf.tp, lhsTp, rhsTp, false)
+		if mysql.HasUnsignedFlag(lhsTp.Flag) {
+			bf.tp.Flag |= mysql.UnsignedFlag
+		}
+		sig := &builtinArithmeticModRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_ModReal)
+		return sig, nil
+	} else if lhsEvalTp == types.ETDecimal || rhsEvalTp == types.ETDecimal {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal, types.ETDecimal)
+		c.setType4ModRealOrDecimal(bf.tp, lhsTp, rhsTp, true)
+		if mysql.HasUnsignedFlag(lhsTp.Flag) {
+			bf.tp.Flag |= mysql.UnsignedFlag
+		}
+		sig := &builtinArithmeticModDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_ModDecimal)
+		return sig, nil
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	if mysql.HasUnsignedFlag(lhsTp.Flag) {
+		bf.tp.Flag |= mysql.UnsignedFlag
+	}
+	sig := &builtinArithmeticModIntSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_ModInt)
+	return sig, nil
+}
+
+type builtinArithmeticModRealSig struct {
+	baseBuiltinFunc
+}
+
+func (s *builtinArithmeticModRealSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticModRealSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticModRealSig) evalReal(row chunk.Row) (float64, bool, error) {
+	a, isNull, err := s.args[0].EvalReal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	b, isNull, err := s.args[1].EvalReal(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	if b == 0 {
+		return 0, true, handleDivisionByZeroError(s.ctx)
+	}
+
+	return math.Mod(a, b), false, nil
+}
+
+type builtinArithmeticModDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (s *builtinArithmeticModDecimalSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticModDecimalSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticModDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	a, isNull, err := s.args[0].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	b, isNull, err := s.args[1].EvalDecimal(s.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	c := &types.MyDecimal{}
+	err = types.DecimalMod(a, b, c)
+	if err == types.ErrDivByZero {
+		return nil, true, handleDivisionByZeroError(s.ctx)
+	}
+	return c, err != nil, err
+}
+
+type builtinArithmeticModIntSig struct {
+	baseBuiltinFunc
+}
+
+func (s *builtinArithmeticModIntSig) Clone() builtinFunc {
+	newSig := &builtinArithmeticModIntSig{}
+	newSig.cloneFrom(&s.baseBuiltinFunc)
+	return newSig
+}
+
+func (s *builtinArithmeticModIntSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	a, isNull, err := s.args[0].EvalInt(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	b, isNull, err := s.args[1].EvalInt(s.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+
+	if b == 0 {
+		return 0, true, handleDivisionByZeroError(s.ctx)
+	}
+
+	return s.mod(a, b), false, nil
+}
+
+// mod computes a % b, the sign of the result follows the dividend.
+func (s *builtinArithmeticModIntSig) mod(a, b int64) int64 {
+	isLHSUnsigned := mysql.HasUnsignedFlag(s.args[0].GetType().Flag)
+	isRHSUnsigned := mysql.HasUnsignedFlag(s.args[1].GetType().Flag)
+
+	switch {
+	case isLHSUnsigned && isRHSUnsigned:
+		return int64(uint64(a) % uint64(b))
+	case isLHSUnsigned && !isRHSUnsigned:
+		if b < 0 {
+			return int64(uint64(a) % uint64(-b))
+		}
+		return int64(uint64(a) % uint64(b))
+	case !isLHSUnsigned && isRHSUnsigned:
+		if a < 0 {
+			return -int64(uint64(-a) % uint64(b))
+		}
+		return int64(uint64(a) % uint64(b))
+	}
+	return a % b
+}
@@ -14,9 +14,12 @@
 package expression
 
 import (
+	"math"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/testutil"
@@ -291,3 +294,162 @@ func (s *testEvaluatorSuite) TestArithmeticMultiply(c *C) {
 		c.Assert(val, testutil.DatumEquals, types.NewDatum(tc.expect))
 	}
 }
+
+func (s *testEvaluatorSuite) TestArithmeticIntDivide(c *C) {
+	testCases := []struct {
+		args   []interface{}
+		expect interface{}
+		err    error
+	}{
+		{
+			args:   []interface{}{int64(13), int64(11)},
+			expect: int64(1),
+		},
+		{
+			args:   []interface{}{int64(-13), int64(11)},
+			expect: int64(-1),
+		},
+		{
+			args:   []interface{}{int64(13), int64(-11)},
+			expect: int64(-1),
+		},
+		{
+			args:   []interface{}{int64(-13), int64(-11)},
+			expect: int64(1),
+		},
+		{
+			args:   []interface{}{int64(33), int64(11)},
+			expect: int64(3),
+		},
+		{
+			args:   []interface{}{int64(-33), int64(11)},
+			expect: int64(-3),
+		},
+		{
+			args:   []interface{}{uint64(13), int64(11)},
+			expect: int64(1),
+		},
+		{
+			args: []interface{}{uint64(13), int64(-11)},
+			err:  types.ErrOverflow,
+		},
+		{
+			args: []interface{}{int64(-13), uint64(11)},
+			err:  types.ErrOverflow,
+		},
+		{
+			args: []interface{}{int64(math.MinInt64), int64(-1)},
+			err:  types.ErrOverflow,
+		},
+		{
+			args:   []interface{}{float64(11.01), float64(1.1)},
+			expect: int64(10),
+		},
+		{
+			args:   []interface{}{float64(-11.01), float64(1.1)},
+			expect: int64(-10),
+		},
+		{
+			args:   []interface{}{"10", "3"},
+			expect: int64(3),
+		},
+		{
+			args:   []interface{}{int64(10), int64(0)},
+			expect: nil,
+		},
+		{
+			args:   []interface{}{float64(10), float64(0)},
+			expect: nil,
+		},
+		{
+			args:   []interface{}{nil, int64(1)},
+			expect: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		sig, err := funcs[ast.IntDiv].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(tc.args...)))
+		c.Assert(err, IsNil)
+		c.Assert(sig, NotNil)
+		val, err := evalBuiltinFunc(sig, chunk.Row{})
+		if tc.err == nil {
+			c.Assert(err, IsNil)
+			c.Assert(val, testutil.DatumEquals, types.NewDatum(tc.expect))
+		} else {
+			c.Assert(terror.ErrorEqual(err, tc.err), IsTrue, Commentf("%v", tc.args))
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestArithmeticMod(c *C) {
+	testCases := []struct {
+		args   []interface{}
+		expect interface{}
+	}{
+		{
+			args:   []interface{}{int64(13), int64(11)},
+			expect: int64(2),
+		},
+		{
+			args:   []interface{}{int64(-13), int64(11)},
+			expect: int64(-2),
+		},
+		{
+			args:   []interface{}{int64(13), int64(-11)},
+			expect: int64(2),
+		},
+		{
+			args:   []interface{}{int64(-13), int64(-11)},
+			expect: int64(-2),
+		},
+		{
+			args:   []interface{}{uint64(13), int64(-11)},
+			expect: uint64(2),
+		},
+		{
+			args:   []interface{}{int64(-13), uint64(11)},
+			expect: int64(-2),
+		},
+		{
+			args:   []interface{}{uint64(math.MaxUint64), uint64(10)},
+			expect: uint64(5),
+		},
+		{
+			args:   []interface{}{int64(math.MinInt64), int64(-1)},
+			expect: int64(0),
+		},
+		{
+			args:   []interface{}{float64(10.5), float64(3)},
+			expect: float64(1.5),
+		},
+		{
+			args:   []interface{}{float64(-10.5), float64(3)},
+			expect: float64(-1.5),
+		},
+		{
+			args:   []interface{}{int64(10), int64(0)},
+			expect: nil,
+		},
+		{
+			args:   []interface{}{float64(10), float64(0)},
+			expect: nil,
+		},
+		{
+			args:   []interface{}{nil, int64(3)},
+			expect: nil,
+		},
+		{
+			args:   []interface{}{int64(3), nil},
+			expect: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		sig, err := funcs[ast.Mod].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(tc.args...)))
+		c.Assert(err, IsNil)
+		c.Assert(sig, NotNil)
+		val, err := evalBuiltinFunc(sig, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(val, testutil.DatumEquals, types.NewDatum(tc.expect))
+	}
+}
@@ -0,0 +1,1771 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// We implement 7 CastAsXXFunctionClass for `cast` built-in functions.
+// XX means the return type of the `cast` built-in functions.
+// XX contains the following 7 types:
+// Int, Real, Decimal, Time, Duration, JSON, String.
+
+// We implement 49 CastYYAsXXSig built-in function signatures.
+// Each signature means the conversion from type YY to type XX.
+// YY and XX both contain Int, Real, Decimal, Time, Duration, JSON and String.
+
+package expression
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &castAsIntFunctionClass{}
+	_ functionClass = &castAsRealFunctionClass{}
+	_ functionClass = &castAsDecimalFunctionClass{}
+	_ functionClass = &castAsStringFunctionClass{}
+	_ functionClass = &castAsTimeFunctionClass{}
+	_ functionClass = &castAsDurationFunctionClass{}
+	_ functionClass = &castAsJSONFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinCastIntAsIntSig{}
+	_ builtinFunc = &builtinCastIntAsRealSig{}
+	_ builtinFunc = &builtinCastIntAsDecimalSig{}
+	_ builtinFunc = &builtinCastIntAsStringSig{}
+	_ builtinFunc = &builtinCastIntAsTimeSig{}
+	_ builtinFunc = &builtinCastIntAsDurationSig{}
+	_ builtinFunc = &builtinCastIntAsJSONSig{}
+
+	_ builtinFunc = &builtinCastRealAsIntSig{}
+	_ builtinFunc = &builtinCastRealAsRealSig{}
+	_ builtinFunc = &builtinCastRealAsDecimalSig{}
+	_ builtinFunc = &builtinCastRealAsStringSig{}
+	_ builtinFunc = &builtinCastRealAsTimeSig{}
+	_ builtinFunc = &builtinCastRealAsDurationSig{}
+	_ builtinFunc = &builtinCastRealAsJSONSig{}
+
+	_ builtinFunc = &builtinCastDecimalAsIntSig{}
+	_ builtinFunc = &builtinCastDecimalAsRealSig{}
+	_ builtinFunc = &builtinCastDecimalAsDecimalSig{}
+	_ builtinFunc = &builtinCastDecimalAsStringSig{}
+	_ builtinFunc = &builtinCastDecimalAsTimeSig{}
+	_ builtinFunc = &builtinCastDecimalAsDurationSig{}
+	_ builtinFunc = &builtinCastDecimalAsJSONSig{}
+
+	_ builtinFunc = &builtinCastTimeAsIntSig{}
+	_ builtinFunc = &builtinCastTimeAsRealSig{}
+	_ builtinFunc = &builtinCastTimeAsDecimalSig{}
+	_ builtinFunc = &builtinCastTimeAsStringSig{}
+	_ builtinFunc = &builtinCastTimeAsTimeSig{}
+	_ builtinFunc = &builtinCastTimeAsDurationSig{}
+	_ builtinFunc = &builtinCastTimeAsJSONSig{}
+
+	_ builtinFunc = &builtinCastDurationAsIntSig{}
+	_ builtinFunc = &builtinCastDurationAsRealSig{}
+	_ builtinFunc = &builtinCastDurationAsDecimalSig{}
+	_ builtinFunc = &builtinCastDurationAsStringSig{}
+	_ builtinFunc = &builtinCastDurationAsTimeSig{}
+	_ builtinFunc = &builtinCastDurationAsDurationSig{}
+	_ builtinFunc = &builtinCastDurationAsJSONSig{}
+
+	_ builtinFunc = &builtinCastStringAsIntSig{}
+	_ builtinFunc = &builtinCastStringAsRealSig{}
+	_ builtinFunc = &builtinCastStringAsDecimalSig{}
+	_ builtinFunc = &builtinCastStringAsStringSig{}
+	_ builtinFunc = &builtinCastStringAsTimeSig{}
+	_ builtinFunc = &builtinCastStringAsDurationSig{}
+	_ builtinFunc = &builtinCastStringAsJSONSig{}
+
+	_ builtinFunc = &builtinCastJSONAsIntSig{}
+	_ builtinFunc = &builtinCastJSONAsRealSig{}
+	_ builtinFunc = &builtinCastJSONAsDecimalSig{}
+	_ builtinFunc = &builtinCastJSONAsStringSig{}
+	_ builtinFunc = &builtinCastJSONAsTimeSig{}
+	_ builtinFunc = &builtinCastJSONAsDurationSig{}
+	_ builtinFunc = &builtinCastJSONAsJSONSig{}
+)
+
+type castAsIntFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsIntFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	if args[0].GetType().Hybrid() || IsBinaryLiteral(args[0]) {
+		sig = &builtinCastIntAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsInt)
+		return sig, nil
+	}
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsInt)
+	case types.ETReal:
+		sig = &builtinCastRealAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsInt)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsInt)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsInt)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsInt)
+	case types.ETString:
+		sig = &builtinCastStringAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsInt)
+	case types.ETJson:
+		sig = &builtinCastJSONAsIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsInt)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "INTEGER")
+	}
+	return sig, nil
+}
+
+type castAsRealFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsRealFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	if IsBinaryLiteral(args[0]) {
+		sig = &builtinCastRealAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsReal)
+		return sig, nil
+	}
+	argTp := args[0].GetType().EvalType()
+	if args[0].GetType().Hybrid() {
+		argTp = types.ETInt
+	}
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsReal)
+	case types.ETReal:
+		sig = &builtinCastRealAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsReal)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsReal)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsReal)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsReal)
+	case types.ETString:
+		sig = &builtinCastStringAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsReal)
+	case types.ETJson:
+		sig = &builtinCastJSONAsRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsReal)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "DOUBLE")
+	}
+	return sig, nil
+}
+
+type castAsDecimalFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsDecimalFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	if IsBinaryLiteral(args[0]) {
+		sig = &builtinCastDecimalAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsDecimal)
+		return sig, nil
+	}
+	argTp := args[0].GetType().EvalType()
+	if args[0].GetType().Hybrid() {
+		argTp = types.ETInt
+	}
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsDecimal)
+	case types.ETReal:
+		sig = &builtinCastRealAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsDecimal)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsDecimal)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsDecimal)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsDecimal)
+	case types.ETString:
+		sig = &builtinCastStringAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsDecimal)
+	case types.ETJson:
+		sig = &builtinCastJSONAsDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsDecimal)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "DECIMAL")
+	}
+	return sig, nil
+}
+
+type castAsStringFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsStringFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	if args[0].GetType().Hybrid() {
+		sig = &builtinCastStringAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsString)
+		return sig, nil
+	}
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsString)
+	case types.ETReal:
+		sig = &builtinCastRealAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsString)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsString)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsString)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsString)
+	case types.ETString:
+		sig = &builtinCastStringAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsString)
+	case types.ETJson:
+		sig = &builtinCastJSONAsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsString)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "CHAR")
+	}
+	return sig, nil
+}
+
+type castAsTimeFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsTimeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsTime)
+	case types.ETReal:
+		sig = &builtinCastRealAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsTime)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsTime)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsTime)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsTime)
+	case types.ETString:
+		sig = &builtinCastStringAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsTime)
+	case types.ETJson:
+		sig = &builtinCastJSONAsTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsTime)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "DATETIME")
+	}
+	return sig, nil
+}
+
+type castAsDurationFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsDurationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsDuration)
+	case types.ETReal:
+		sig = &builtinCastRealAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsDuration)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsDuration)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsDuration)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsDuration)
+	case types.ETString:
+		sig = &builtinCastStringAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsDuration)
+	case types.ETJson:
+		sig = &builtinCastJSONAsDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsDuration)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "TIME")
+	}
+	return sig, nil
+}
+
+type castAsJSONFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFunc(ctx, args)
+	bf.tp = c.tp
+	argTp := args[0].GetType().EvalType()
+	switch argTp {
+	case types.ETInt:
+		sig = &builtinCastIntAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastIntAsJson)
+	case types.ETReal:
+		sig = &builtinCastRealAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastRealAsJson)
+	case types.ETDecimal:
+		sig = &builtinCastDecimalAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDecimalAsJson)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCastTimeAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastTimeAsJson)
+	case types.ETDuration:
+		sig = &builtinCastDurationAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastDurationAsJson)
+	case types.ETString:
+		sig = &builtinCastStringAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsJson)
+	case types.ETJson:
+		sig = &builtinCastJSONAsJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsJson)
+	default:
+		return nil, errUnsupportedCast.GenWithStackByArgs(args[0].GetType().String(), "JSON")
+	}
+	return sig, nil
+}
+
+type builtinCastIntAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	return b.args[0].EvalInt(b.ctx, row)
+}
+
+type builtinCastIntAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if mysql.HasUnsignedFlag(b.args[0].GetType().Flag) {
+		// recall that, int to float is different from uint to float
+		return float64(uint64(val)), false, nil
+	}
+	return float64(val), false, nil
+}
+
+type builtinCastIntAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if mysql.HasUnsignedFlag(b.args[0].GetType().Flag) {
+		res = types.NewDecFromUint(uint64(val))
+	} else {
+		res = types.NewDecFromInt(val)
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(res, b.tp, b.ctx.GetSessionVars().StmtCtx)
+	return res, false, err
+}
+
+type builtinCastIntAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if mysql.HasUnsignedFlag(b.args[0].GetType().Flag) {
+		res = strconv.FormatUint(uint64(val), 10)
+	} else {
+		res = strconv.FormatInt(val, 10)
+	}
+	res, err = types.ProduceStrForCast(res, b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+type builtinCastRealAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = castRealToInt(b.ctx.GetSessionVars().StmtCtx, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastRealAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	return b.args[0].EvalReal(b.ctx, row)
+}
+
+type builtinCastRealAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = castRealToDecimal(sc, val)
+	if err != nil {
+		return res, false, err
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(res, b.tp, sc)
+	return res, false, err
+}
+
+type builtinCastRealAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(formatReal(val, b.args[0].GetType()), b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+type builtinCastDecimalAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = castDecimalToInt(b.ctx.GetSessionVars().StmtCtx, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastDecimalAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = val.ToFloat64()
+	return res, false, err
+}
+
+type builtinCastDecimalAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	// The argument may point into the memory of a chunk, so round a copy of it.
+	res = new(types.MyDecimal)
+	*res = *val
+	res, err = types.ProduceDecWithSpecifiedTp(res, b.tp, b.ctx.GetSessionVars().StmtCtx)
+	return res, false, err
+}
+
+type builtinCastDecimalAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(string(val.ToString()), b.tp, b.ctx.GetSessionVars().StmtCtx, false)
+	return res, false, err
+}
+
+type builtinCastStringAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = castStringToInt(b.ctx.GetSessionVars().StmtCtx, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastStringAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.StrToFloat(sc, val)
+	if err != nil {
+		return 0, false, err
+	}
+	res, err = types.ProduceFloatWithSpecifiedTp(res, b.tp, sc)
+	return res, false, err
+}
+
+type builtinCastStringAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	if res, err = castStringToDecimal(sc, val); err != nil {
+		return res, false, err
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(res, b.tp, sc)
+	return res, false, err
+}
+
+type builtinCastStringAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	res, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(res, b.tp, b.ctx.GetSessionVars().StmtCtx, true)
+	return res, false, err
+}
+
+type builtinCastIntAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ParseTimeFromNum(b.ctx.GetSessionVars().StmtCtx, val, b.tp.Tp, int8(b.tp.Decimal))
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+	}
+	return res, false, nil
+}
+
+type builtinCastIntAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	dur, err := types.NumberToDuration(val, int8(b.tp.Decimal))
+	if err != nil {
+		if types.ErrOverflow.Equal(err) {
+			err = b.ctx.GetSessionVars().StmtCtx.HandleOverflow(err, err)
+		}
+		return res, true, err
+	}
+	return dur, false, err
+}
+
+type builtinCastRealAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsTimeSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	// MySQL compatibility: 0 should not be converted to null.
+	fv := strconv.FormatFloat(val, 'f', -1, 64)
+	if fv == "0" {
+		return types.ZeroTime, false, nil
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err := types.ParseTimeFromFloatString(sc, fv, b.tp.Tp, int8(b.tp.Decimal))
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+	}
+	return res, false, nil
+}
+
+type builtinCastRealAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return castStringToDuration(b.ctx.GetSessionVars().StmtCtx, strconv.FormatFloat(val, 'f', -1, 64), int8(b.tp.Decimal))
+}
+
+type builtinCastDecimalAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.ParseTimeFromFloatString(sc, string(val.ToString()), b.tp.Tp, int8(b.tp.Decimal))
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+	}
+	return res, false, err
+}
+
+type builtinCastDecimalAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, true, err
+	}
+	return castStringToDuration(b.ctx.GetSessionVars().StmtCtx, string(val.ToString()), int8(b.tp.Decimal))
+}
+
+type builtinCastStringAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.ParseTime(sc, val, b.tp.Tp, int8(b.tp.Decimal))
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+	}
+	return res, false, nil
+}
+
+type builtinCastStringAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return castStringToDuration(b.ctx.GetSessionVars().StmtCtx, val, int8(b.tp.Decimal))
+}
+
+type builtinCastTimeAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	t, err := val.RoundFrac(b.ctx.GetSessionVars().StmtCtx, types.DefaultFsp)
+	if err != nil {
+		return res, false, err
+	}
+	res, err = t.ToNumber().ToInt()
+	return res, false, err
+}
+
+type builtinCastTimeAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = val.ToNumber().ToFloat64()
+	return res, false, err
+}
+
+type builtinCastTimeAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(val.ToNumber(), b.tp, b.ctx.GetSessionVars().StmtCtx)
+	return res, false, err
+}
+
+type builtinCastTimeAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(val.String(), b.tp, b.ctx.GetSessionVars().StmtCtx, false)
+	return res, false, err
+}
+
+type builtinCastTimeAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	res, isNull, err = b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+
+	sc := b.ctx.GetSessionVars().StmtCtx
+	if res, err = res.Convert(sc, b.tp.Tp); err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	res, err = res.RoundFrac(sc, int8(b.tp.Decimal))
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+		res.SetType(b.tp.Tp)
+	}
+	return res, false, err
+}
+
+type builtinCastTimeAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = val.ConvertToDuration()
+	if err != nil {
+		return res, false, err
+	}
+	res, err = res.RoundFrac(int8(b.tp.Decimal))
+	return res, false, err
+}
+
+type builtinCastDurationAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	dur, err := val.RoundFrac(types.DefaultFsp)
+	if err != nil {
+		return res, false, err
+	}
+	res, err = dur.ToNumber().ToInt()
+	return res, false, err
+}
+
+type builtinCastDurationAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = val.ToNumber().ToFloat64()
+	return res, false, err
+}
+
+type builtinCastDurationAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(val.ToNumber(), b.tp, b.ctx.GetSessionVars().StmtCtx)
+	return res, false, err
+}
+
+type builtinCastDurationAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(val.String(), b.tp, b.ctx.GetSessionVars().StmtCtx, false)
+	return res, false, err
+}
+
+type builtinCastDurationAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = val.ConvertToTime(sc, b.tp.Tp)
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	res, err = res.RoundFrac(sc, int8(b.tp.Decimal))
+	return res, false, err
+}
+
+type builtinCastDurationAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	res, isNull, err = b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = res.RoundFrac(int8(b.tp.Decimal))
+	return res, false, err
+}
+
+type builtinCastIntAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastIntAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastIntAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastIntAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return castIntToJSON(val, b.args[0].GetType()), false, nil
+}
+
+type builtinCastRealAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastRealAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastRealAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastRealAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return json.CreateBinary(val), false, nil
+}
+
+type builtinCastDecimalAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDecimalAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastDecimalAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDecimalAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	// FIXME: Only the IEEE 754 double precision of the decimal is kept, since
+	// our binary JSON has no DECIMAL value type.
+	f64, err := val.ToFloat64()
+	if err != nil {
+		return res, true, err
+	}
+	return json.CreateBinary(f64), false, nil
+}
+
+type builtinCastStringAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if mysql.HasParseToJSONFlag(b.tp.Flag) {
+		res, err = json.ParseBinaryFromString(val)
+		return res, err != nil, err
+	}
+	return json.CreateBinary(val), false, nil
+}
+
+type builtinCastTimeAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastTimeAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastTimeAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastTimeAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return json.CreateBinary(val.String()), false, nil
+}
+
+type builtinCastDurationAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastDurationAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastDurationAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastDurationAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return json.CreateBinary(val.String()), false, nil
+}
+
+type builtinCastJSONAsJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsJSONSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	return b.args[0].EvalJSON(b.ctx, row)
+}
+
+type builtinCastJSONAsIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsIntSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.ConvertJSONToInt(sc, val, mysql.HasUnsignedFlag(b.tp.Flag))
+	return res, false, err
+}
+
+type builtinCastJSONAsRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsRealSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ConvertJSONToFloat(b.ctx.GetSessionVars().StmtCtx, val)
+	return res, false, err
+}
+
+type builtinCastJSONAsDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.ConvertJSONToDecimal(sc, val)
+	if err != nil {
+		return res, false, err
+	}
+	res, err = types.ProduceDecWithSpecifiedTp(res, b.tp, sc)
+	return res, false, err
+}
+
+type builtinCastJSONAsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	res, err = types.ProduceStrForCast(val.String(), b.tp, b.ctx.GetSessionVars().StmtCtx, false)
+	return res, false, err
+}
+
+type builtinCastJSONAsTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsTimeSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	s, err := val.Unquote()
+	if err != nil {
+		return res, false, err
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	res, err = types.ParseTime(sc, s, b.tp.Tp, int8(b.tp.Decimal))
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(b.ctx, err)
+	}
+	if b.tp.Tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		res.SetCoreTime(types.FromDate(res.Year(), res.Month(), res.Day(), 0, 0, 0, 0))
+	}
+	return res, false, nil
+}
+
+type builtinCastJSONAsDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsDurationSig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	s, err := val.Unquote()
+	if err != nil {
+		return res, false, err
+	}
+	return castStringToDuration(b.ctx.GetSessionVars().StmtCtx, s, int8(b.tp.Decimal))
+}
+
+// formatReal formats a float value the way MySQL prints a DOUBLE, and takes care
+// of FLOAT arguments which have been widened to float64 by EvalReal.
+func formatReal(val float64, argTp *types.FieldType) string {
+	bits := 64
+	if argTp.Tp == mysql.TypeFloat {
+		// EvalReal casts the value from float32 to float64, for example:
+		// float32(208.867) is cast to float64(208.86700439)
+		// If we strconv.FormatFloat the value with 64bits, the result is incorrect!
+		bits = 32
+	}
+	return strconv.FormatFloat(val, 'f', -1, bits)
+}
+
+// castRealToInt converts a float to a signed or unsigned BIGINT. Out-of-range
+// values are clipped and reported through the statement context.
+func castRealToInt(sc *stmtctx.StatementContext, val float64, unsigned bool) (res int64, err error) {
+	if !unsigned {
+		res, err = types.ConvertFloatToInt(val, types.IntergerSignedLowerBound(mysql.TypeLonglong), types.IntergerSignedUpperBound(mysql.TypeLonglong), mysql.TypeLonglong)
+	} else {
+		var uintVal uint64
+		uintVal, err = types.ConvertFloatToUint(sc, val, types.IntergerUnsignedUpperBound(mysql.TypeLonglong), mysql.TypeLonglong)
+		res = int64(uintVal)
+	}
+	if types.ErrOverflow.Equal(err) {
+		err = sc.HandleOverflow(err, types.ErrTruncatedWrongVal.GenWithStackByArgs("INTEGER", strconv.FormatFloat(val, 'g', -1, 64)))
+	}
+	return res, err
+}
+
+// castRealToDecimal converts a float to a decimal. Values which can not be
+// represented are clipped and reported through the statement context.
+func castRealToDecimal(sc *stmtctx.StatementContext, val float64) (res *types.MyDecimal, err error) {
+	res = new(types.MyDecimal)
+	err = res.FromFloat64(val)
+	if types.ErrOverflow.Equal(err) {
+		err = sc.HandleOverflow(err, types.ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", strconv.FormatFloat(val, 'g', -1, 64)))
+	} else if types.ErrTruncated.Equal(err) {
+		// This behavior is consistent with MySQL.
+		err = nil
+	}
+	return res, err
+}
+
+// castStringToDecimal parses a string to a decimal. The invalid part of the
+// string is truncated and reported through the statement context.
+func castStringToDecimal(sc *stmtctx.StatementContext, val string) (res *types.MyDecimal, err error) {
+	res = new(types.MyDecimal)
+	err = res.FromString([]byte(strings.TrimSpace(val)))
+	if types.ErrTruncated.Equal(err) || types.ErrBadNumber.Equal(err) {
+		err = types.ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", val)
+	}
+	return res, sc.HandleTruncate(err)
+}
+
+// castDecimalToInt rounds a decimal to the nearest signed or unsigned BIGINT.
+func castDecimalToInt(sc *stmtctx.StatementContext, val *types.MyDecimal, unsigned bool) (res int64, err error) {
+	var to types.MyDecimal
+	if err = val.Round(&to, 0, types.ModeHalfUp); err != nil {
+		return 0, err
+	}
+	if !unsigned {
+		res, err = to.ToInt()
+	} else {
+		var uintRes uint64
+		uintRes, err = to.ToUint()
+		res = int64(uintRes)
+	}
+	if types.ErrOverflow.Equal(err) {
+		err = sc.HandleOverflow(err, types.ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", val.String()))
+	}
+	return res, err
+}
+
+// castStringToInt converts a string to a signed or unsigned BIGINT at the best-effort.
+// Truncation is handled by the types package; overflow and sign changes are
+// recorded as warnings in the statement context like MySQL does.
+func castStringToInt(sc *stmtctx.StatementContext, val string, unsigned bool) (res int64, err error) {
+	val = strings.TrimSpace(val)
+	isNegative := len(val) > 1 && val[0] == '-'
+	if !isNegative {
+		var ures uint64
+		ures, err = types.StrToUint(sc, val)
+		res = int64(ures)
+		if err == nil && !unsigned && ures > uint64(math.MaxInt64) {
+			sc.AppendWarning(types.ErrCastAsSignedOverflow)
+		}
+	} else {
+		res, err = types.StrToInt(sc, val)
+		if err == nil && unsigned {
+			sc.AppendWarning(types.ErrCastNegIntAsUnsigned)
+		}
+	}
+	if types.ErrOverflow.Equal(err) {
+		if isNegative {
+			res = math.MinInt64
+		} else {
+			uval := uint64(math.MaxUint64)
+			res = int64(uval)
+		}
+		err = sc.HandleOverflow(err, types.ErrTruncatedWrongVal.GenWithStackByArgs("INTEGER", val))
+	}
+	return res, err
+}
+
+// castStringToDuration parses a string to a duration. A value MySQL can not
+// interpret as TIME is truncated with a warning and evaluates to NULL.
+func castStringToDuration(sc *stmtctx.StatementContext, val string, fsp int8) (res types.Duration, isNull bool, err error) {
+	res, err = types.ParseDuration(sc, val, fsp)
+	if types.ErrTruncatedWrongVal.Equal(err) {
+		err = sc.HandleTruncate(err)
+		// ZeroDuration of error ErrTruncatedWrongVal needs to be considered NULL.
+		if res == types.ZeroDuration {
+			return res, true, err
+		}
+	}
+	return res, false, err
+}
+
+// castIntToJSON converts an integer to a JSON scalar. Boolean literals such as
+// TRUE and FALSE become JSON booleans, as MySQL does.
+func castIntToJSON(val int64, argTp *types.FieldType) json.BinaryJSON {
+	if mysql.HasIsBooleanFlag(argTp.Flag) {
+		return json.CreateBinary(val != 0)
+	}
+	if mysql.HasUnsignedFlag(argTp.Flag) {
+		return json.CreateBinary(uint64(val))
+	}
+	return json.CreateBinary(val)
+}
+
+// castSupported reports whether the cast framework can convert from or to the evaluation type.
+func castSupported(tp types.EvalType) bool {
+	switch tp {
+	case types.ETInt, types.ETReal, types.ETDecimal, types.ETString,
+		types.ETDatetime, types.ETTimestamp, types.ETDuration, types.ETJson:
+		return true
+	}
+	return false
+}
+
+// BuildCastFunction builds a CAST ScalarFunction from the Expression.
+func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldType) (res Expression) {
+	var fc functionClass
+	switch tp.EvalType() {
+	case types.ETInt:
+		fc = &castAsIntFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETReal:
+		fc = &castAsRealFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETDecimal:
+		fc = &castAsDecimalFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETDatetime, types.ETTimestamp:
+		fc = &castAsTimeFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETDuration:
+		fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	case types.ETJson:
+		fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	default:
+		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+	}
+	f, err := fc.getFunction(ctx, []Expression{expr})
+	if err != nil {
+		terror.Log(err)
+		return expr
+	}
+	res = &ScalarFunction{
+		FuncName: model.NewCIStr(ast.Cast),
+		RetType:  tp,
+		Function: f,
+	}
+	return FoldConstant(res)
+}
+
+// WrapWithCastAsInt wraps `expr` with `cast` if the return type of expr is not
+// type int, otherwise, returns `expr` directly.
+func WrapWithCastAsInt(ctx sessionctx.Context, expr Expression) Expression {
+	if tp := expr.GetType().EvalType(); tp == types.ETInt || !castSupported(tp) {
+		return expr
+	}
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	tp.Flen, tp.Decimal = expr.GetType().Flen, 0
+	types.SetBinChsClnFlag(tp)
+	tp.Flag |= expr.GetType().Flag & mysql.UnsignedFlag
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsReal wraps `expr` with `cast` if the return type of expr is not
+// type real, otherwise, returns `expr` directly.
+func WrapWithCastAsReal(ctx sessionctx.Context, expr Expression) Expression {
+	if tp := expr.GetType().EvalType(); tp == types.ETReal || !castSupported(tp) {
+		return expr
+	}
+	tp := types.NewFieldType(mysql.TypeDouble)
+	tp.Flen, tp.Decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+	types.SetBinChsClnFlag(tp)
+	tp.Flag |= expr.GetType().Flag & mysql.UnsignedFlag
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsDecimal wraps `expr` with `cast` if the return type of expr is
+// not type decimal, otherwise, returns `expr` directly.
+func WrapWithCastAsDecimal(ctx sessionctx.Context, expr Expression) Expression {
+	if tp := expr.GetType().EvalType(); tp == types.ETDecimal || !castSupported(tp) {
+		return expr
+	}
+	tp := types.NewFieldType(mysql.TypeNewDecimal)
+	tp.Flen, tp.Decimal = expr.GetType().Flen, expr.GetType().Decimal
+	if expr.GetType().EvalType() == types.ETInt {
+		tp.Flen = mysql.MaxIntWidth
+	}
+	types.SetBinChsClnFlag(tp)
+	tp.Flag |= expr.GetType().Flag & mysql.UnsignedFlag
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsString wraps `expr` with `cast` if the return type of expr is
+// not type string, otherwise, returns `expr` directly.
+func WrapWithCastAsString(ctx sessionctx.Context, expr Expression) Expression {
+	exprTp := expr.GetType()
+	if tp := exprTp.EvalType(); tp == types.ETString || !castSupported(tp) {
+		return expr
+	}
+	argLen := exprTp.Flen
+	switch exprTp.EvalType() {
+	case types.ETInt:
+		argLen = mysql.MaxIntWidth
+	case types.ETReal:
+		// The length of float and double is not accurate, so we do not
+		// truncate the string representation.
+		argLen = types.UnspecifiedLength
+	case types.ETDecimal:
+		// Leave room for the sign and the decimal point.
+		if argLen != types.UnspecifiedLength {
+			argLen += 2
+		}
+	}
+	tp := types.NewFieldType(mysql.TypeVarString)
+	tp.Charset, tp.Collate = charset.GetDefaultCharsetAndCollate()
+	tp.Flen, tp.Decimal = argLen, types.UnspecifiedLength
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsTime wraps `expr` with `cast` if the return type of expr is not
+// same as type of the specified `tp` , otherwise, returns `expr` directly.
+func WrapWithCastAsTime(ctx sessionctx.Context, expr Expression, tp *types.FieldType) Expression {
+	exprTp := expr.GetType().Tp
+	if tp.Tp == exprTp {
+		return expr
+	} else if (exprTp == mysql.TypeDate || exprTp == mysql.TypeTimestamp) && tp.Tp == mysql.TypeDatetime {
+		return expr
+	}
+	switch x := expr.GetType(); x.Tp {
+	case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDate, mysql.TypeDuration:
+		tp.Decimal = x.Decimal
+	default:
+		tp.Decimal = int(types.MaxFsp)
+	}
+	switch tp.Tp {
+	case mysql.TypeDate:
+		tp.Flen = mysql.MaxDateWidth
+	case mysql.TypeDatetime, mysql.TypeTimestamp:
+		tp.Flen = mysql.MaxDatetimeWidthNoFsp
+		if tp.Decimal > 0 {
+			tp.Flen = tp.Flen + 1 + tp.Decimal
+		}
+	}
+	types.SetBinChsClnFlag(tp)
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsDuration wraps `expr` with `cast` if the return type of expr is
+// not type duration, otherwise, returns `expr` directly.
+func WrapWithCastAsDuration(ctx sessionctx.Context, expr Expression) Expression {
+	if expr.GetType().Tp == mysql.TypeDuration {
+		return expr
+	}
+	tp := types.NewFieldType(mysql.TypeDuration)
+	switch x := expr.GetType(); x.Tp {
+	case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDate:
+		tp.Decimal = x.Decimal
+	default:
+		tp.Decimal = int(types.MaxFsp)
+	}
+	tp.Flen = mysql.MaxDurationWidthNoFsp
+	if tp.Decimal > 0 {
+		tp.Flen = tp.Flen + 1 + tp.Decimal
+	}
+	return BuildCastFunction(ctx, expr, tp)
+}
+
+// WrapWithCastAsJSON wraps `expr` with `cast` if the return type of expr is not
+// type json, otherwise, returns `expr` directly. Strings are converted to JSON
+// string scalars rather than being parsed as JSON documents.
+func WrapWithCastAsJSON(ctx sessionctx.Context, expr Expression) Expression {
+	return wrapWithCastAsJSON(ctx, expr, false)
+}
+
+// wrapWithCastAsJSON is like WrapWithCastAsJSON, but parses string arguments
+// as JSON documents when `parse` is true.
+func wrapWithCastAsJSON(ctx sessionctx.Context, expr Expression, parse bool) Expression {
+	if tp := expr.GetType(); tp.Tp == mysql.TypeJSON || !castSupported(tp.EvalType()) {
+		return expr
+	}
+	tp := types.NewFieldType(mysql.TypeJSON)
+	tp.Flen, tp.Decimal = mysql.MaxBlobWidth, 0
+	tp.Charset, tp.Collate = mysql.DefaultCharset, mysql.DefaultCollationName
+	tp.Flag |= mysql.BinaryFlag
+	if parse {
+		tp.Flag |= mysql.ParseToJSONFlag
+	}
+	return BuildCastFunction(ctx, expr, tp)
+}
@@ -0,0 +1,172 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestCast(c *C) {
+	sc := s.ctx.GetSessionVars().StmtCtx
+
+	// Test for AppendWarning.
+	originIgnoreTruncate := sc.IgnoreTruncate
+	originTruncateAsWarning := sc.TruncateAsWarning
+	originOverflowAsWarning := sc.OverflowAsWarning
+	sc.IgnoreTruncate = false
+	sc.TruncateAsWarning = true
+	sc.OverflowAsWarning = true
+	defer func() {
+		sc.IgnoreTruncate = originIgnoreTruncate
+		sc.TruncateAsWarning = originTruncateAsWarning
+		sc.OverflowAsWarning = originOverflowAsWarning
+	}()
+
+	unsignedTp := types.NewFieldType(mysql.TypeLonglong)
+	unsignedTp.Flag |= mysql.UnsignedFlag
+	signedTp := types.NewFieldType(mysql.TypeLonglong)
+	doubleTp := types.NewFieldType(mysql.TypeDouble)
+	charTp := types.NewFieldType(mysql.TypeVarString)
+	charTp.Charset, charTp.Collate = charset.CharsetUTF8MB4, charset.CollationUTF8MB4
+	charTp.Flen = 3
+	binaryTp := types.NewFieldType(mysql.TypeString)
+	types.SetBinChsClnFlag(binaryTp)
+	binaryTp.Flen = 4
+
+	tbl := []struct {
+		arg      interface{}
+		tp       *types.FieldType
+		expected interface{}
+		warnings int
+	}{
+		{int64(-1), unsignedTp, uint64(math.MaxUint64), 0},
+		{uint64(math.MaxUint64), signedTp, int64(-1), 0},
+		{"-1", unsignedTp, uint64(math.MaxUint64), 1},
+		{"18446744073709551615", signedTp, int64(-1), 1},
+		{"99999999999999999999", signedTp, int64(-1), 1},
+		{"12abc", signedTp, int64(12), 1},
+		{1.5, signedTp, int64(2), 0},
+		{-1.5, signedTp, int64(-2), 0},
+		{1e20, signedTp, int64(math.MaxInt64), 1},
+		{"1.5e2", doubleTp, float64(150), 0},
+		{int64(3), doubleTp, float64(3), 0},
+		{"abcdef", charTp, "abc", 1},
+		{int64(12345), charTp, "123", 1},
+		{1.25, charTp, "1.2", 1},
+		{"ab", binaryTp, "ab\x00\x00", 0},
+	}
+	for _, t := range tbl {
+		warnCnt := len(sc.GetWarnings())
+		args := s.primitiveValsToConstants([]interface{}{t.arg})
+		f := BuildCastFunction(s.ctx, &Column{RetType: args[0].GetType(), Index: 0}, t.tp)
+		row := chunk.MutRowFromDatums(types.MakeDatums(t.arg))
+		d, err := f.Eval(row.ToRow())
+		c.Assert(err, IsNil)
+		if mysql.HasUnsignedFlag(t.tp.Flag) {
+			c.Assert(d.GetUint64(), Equals, t.expected)
+		} else {
+			c.Assert(d, testutil.DatumEquals, types.NewDatum(t.expected), Commentf("%v as %v", t.arg, t.tp))
+		}
+		c.Assert(len(sc.GetWarnings())-warnCnt, Equals, t.warnings, Commentf("%v as %v", t.arg, t.tp))
+	}
+
+	// Test for errors when warnings are not allowed.
+	sc.TruncateAsWarning = false
+	sc.OverflowAsWarning = false
+	f := BuildCastFunction(s.ctx, &Column{RetType: types.NewFieldType(mysql.TypeVarString), Index: 0}, signedTp)
+	_, err := f.Eval(chunk.MutRowFromDatums(types.MakeDatums("99999999999999999999")).ToRow())
+	c.Assert(types.ErrOverflow.Equal(err), IsTrue)
+	_, err = f.Eval(chunk.MutRowFromDatums(types.MakeDatums("1a")).ToRow())
+	c.Assert(err, NotNil)
+}
+
+func (s *testEvaluatorSuite) TestWrapWithCastAsTypes(c *C) {
+	intCol := &Column{RetType: types.NewFieldType(mysql.TypeLonglong)}
+	realCol := &Column{RetType: types.NewFieldType(mysql.TypeDouble)}
+	strCol := &Column{RetType: types.NewFieldType(mysql.TypeVarString)}
+
+	c.Assert(WrapWithCastAsInt(s.ctx, intCol), Equals, intCol)
+	c.Assert(WrapWithCastAsReal(s.ctx, realCol), Equals, realCol)
+	c.Assert(WrapWithCastAsString(s.ctx, strCol), Equals, strCol)
+
+	c.Assert(WrapWithCastAsInt(s.ctx, realCol).GetType().EvalType(), Equals, types.ETInt)
+	c.Assert(WrapWithCastAsReal(s.ctx, strCol).GetType().EvalType(), Equals, types.ETReal)
+	c.Assert(WrapWithCastAsString(s.ctx, intCol).GetType().EvalType(), Equals, types.ETString)
+
+	// Constant arguments are folded after being wrapped.
+	cst := WrapWithCastAsReal(s.ctx, &Constant{Value: types.NewStringDatum("1.5"), RetType: types.NewFieldType(mysql.TypeVarString)})
+	con, ok := cst.(*Constant)
+	c.Assert(ok, IsTrue)
+	c.Assert(con.Value.GetFloat64(), Equals, 1.5)
+}
+
+func (s *testEvaluatorSuite) TestCastJSON(c *C) {
+	jsonTp := types.NewFieldType(mysql.TypeJSON)
+	parseTp := types.NewFieldType(mysql.TypeJSON)
+	parseTp.Flag |= mysql.ParseToJSONFlag
+	signedTp := types.NewFieldType(mysql.TypeLonglong)
+	doubleTp := types.NewFieldType(mysql.TypeDouble)
+	charTp := types.NewFieldType(mysql.TypeVarString)
+	charTp.Flen = types.UnspecifiedLength
+	dateTp := types.NewFieldType(mysql.TypeDate)
+
+	tbl := []struct {
+		arg      interface{}
+		tp       *types.FieldType
+		expected string
+	}{
+		{`{"a": [1, "2"]}`, parseTp, `{"a": [1, "2"]}`},
+		{`{"a": [1, "2"]}`, jsonTp, `"{\"a\": [1, \"2\"]}"`},
+		{int64(3), jsonTp, `3`},
+		{1.5, jsonTp, `1.5`},
+		{json.CreateBinary("abc"), charTp, `"abc"`},
+		{json.CreateBinary(int64(7)), signedTp, `7`},
+		{json.CreateBinary("1.5"), doubleTp, `1.5`},
+		{json.CreateBinary("2020-01-02 10:11:12"), dateTp, `2020-01-02`},
+	}
+	for _, t := range tbl {
+		args := s.primitiveValsToConstants([]interface{}{t.arg})
+		f := BuildCastFunction(s.ctx, &Column{RetType: args[0].GetType(), Index: 0}, t.tp)
+		d, err := f.Eval(chunk.MutRowFromDatums(types.MakeDatums(t.arg)).ToRow())
+		c.Assert(err, IsNil)
+		str, err := d.ToString()
+		c.Assert(err, IsNil)
+		c.Assert(str, Equals, t.expected, Commentf("%v as %v", t.arg, t.tp))
+	}
+
+	// Invalid JSON text can not be cast to JSON.
+	f := BuildCastFunction(s.ctx, &Column{RetType: types.NewFieldType(mysql.TypeVarString), Index: 0}, parseTp)
+	_, err := f.Eval(chunk.MutRowFromDatums(types.MakeDatums("{a")).ToRow())
+	c.Assert(json.ErrInvalidJSONText.Equal(err), IsTrue)
+
+	// Boolean literals become JSON booleans.
+	boolTp := types.NewFieldType(mysql.TypeLonglong)
+	boolTp.Flag |= mysql.IsBooleanFlag
+	f = WrapWithCastAsJSON(s.ctx, &Constant{Value: types.NewIntDatum(1), RetType: boolTp})
+	j, isNull, err := f.EvalJSON(s.ctx, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(isNull, IsFalse)
+	c.Assert(j.String(), Equals, "true")
+
+	jsonCol := &Column{RetType: jsonTp}
+	c.Assert(WrapWithCastAsJSON(s.ctx, jsonCol), Equals, jsonCol)
+}
@@ -16,53 +16,782 @@ package expression
 import (
 	"math"
 
+	"github.com/cznic/mathutil"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tipb/go-tipb"
 )
 
 var (
+	_ functionClass = &coalesceFunctionClass{}
+	_ functionClass = &greatestFunctionClass{}
+	_ functionClass = &leastFunctionClass{}
 	_ functionClass = &compareFunctionClass{}
 )
 
 var (
+	_ builtinFunc = &builtinCoalesceIntSig{}
+	_ builtinFunc = &builtinCoalesceRealSig{}
+	_ builtinFunc = &builtinCoalesceDecimalSig{}
+	_ builtinFunc = &builtinCoalesceStringSig{}
+	_ builtinFunc = &builtinCoalesceTimeSig{}
+	_ builtinFunc = &builtinCoalesceDurationSig{}
+	_ builtinFunc = &builtinCoalesceJSONSig{}
+
+	_ builtinFunc = &builtinGreatestIntSig{}
+	_ builtinFunc = &builtinGreatestRealSig{}
+	_ builtinFunc = &builtinGreatestDecimalSig{}
+	_ builtinFunc = &builtinGreatestStringSig{}
+	_ builtinFunc = &builtinGreatestTimeSig{}
+
+	_ builtinFunc = &builtinLeastIntSig{}
+	_ builtinFunc = &builtinLeastRealSig{}
+	_ builtinFunc = &builtinLeastDecimalSig{}
+	_ builtinFunc = &builtinLeastStringSig{}
+	_ builtinFunc = &builtinLeastTimeSig{}
+
 	_ builtinFunc = &builtinLTIntSig{}
 	_ builtinFunc = &builtinLTRealSig{}
+	_ builtinFunc = &builtinLTDecimalSig{}
 	_ builtinFunc = &builtinLTStringSig{}
+	_ builtinFunc = &builtinLTTimeSig{}
+	_ builtinFunc = &builtinLTDurationSig{}
+	_ builtinFunc = &builtinLTJSONSig{}
 
 	_ builtinFunc = &builtinLEIntSig{}
 	_ builtinFunc = &builtinLERealSig{}
+	_ builtinFunc = &builtinLEDecimalSig{}
 	_ builtinFunc = &builtinLEStringSig{}
+	_ builtinFunc = &builtinLETimeSig{}
+	_ builtinFunc = &builtinLEDurationSig{}
+	_ builtinFunc = &builtinLEJSONSig{}
 
 	_ builtinFunc = &builtinGTIntSig{}
 	_ builtinFunc = &builtinGTRealSig{}
+	_ builtinFunc = &builtinGTDecimalSig{}
 	_ builtinFunc = &builtinGTStringSig{}
+	_ builtinFunc = &builtinGTTimeSig{}
+	_ builtinFunc = &builtinGTDurationSig{}
+	_ builtinFunc = &builtinGTJSONSig{}
 
 	_ builtinFunc = &builtinGEIntSig{}
 	_ builtinFunc = &builtinGERealSig{}
+	_ builtinFunc = &builtinGEDecimalSig{}
 	_ builtinFunc = &builtinGEStringSig{}
+	_ builtinFunc = &builtinGETimeSig{}
+	_ builtinFunc = &builtinGEDurationSig{}
+	_ builtinFunc = &builtinGEJSONSig{}
 
 	_ builtinFunc = &builtinNEIntSig{}
 	_ builtinFunc = &builtinNERealSig{}
+	_ builtinFunc = &builtinNEDecimalSig{}
 	_ builtinFunc = &builtinNEStringSig{}
+	_ builtinFunc = &builtinNETimeSig{}
+	_ builtinFunc = &builtinNEDurationSig{}
+	_ builtinFunc = &builtinNEJSONSig{}
 )
 
+// coalesceFunctionClass returns the first non-NULL value in the list,
+// or NULL if there are no non-NULL values.
+type coalesceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *coalesceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+
+	fieldTps := make([]*types.FieldType, 0, len(args))
+	for _, arg := range args {
+		fieldTps = append(fieldTps, arg.GetType())
+	}
+
+	// Use the aggregated field type as retType.
+	resultFieldType := types.AggFieldType(fieldTps)
+	resultEvalType := types.AggregateEvalType(fieldTps, &resultFieldType.Flag)
+	retEvalTp := resultFieldType.EvalType()
+
+	fieldEvalTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		fieldEvalTps = append(fieldEvalTps, retEvalTp)
+	}
+
+	bf := newBaseBuiltinFuncWithTp(ctx, args, retEvalTp, fieldEvalTps...)
+
+	bf.tp.Flag |= resultFieldType.Flag
+	resultFieldType.Flen, resultFieldType.Decimal = 0, types.UnspecifiedLength
+
+	// Set retType to BINARY(0) if all arguments are of type NULL.
+	if resultFieldType.Tp == mysql.TypeNull {
+		types.SetBinChsClnFlag(bf.tp)
+	} else {
+		maxIntLen := 0
+		maxFlen := 0
+
+		// Find the max length of field in `maxFlen`,
+		// and max integer-part length in `maxIntLen`.
+		for _, argTp := range fieldTps {
+			if argTp.Decimal > resultFieldType.Decimal {
+				resultFieldType.Decimal = argTp.Decimal
+			}
+			argIntLen := argTp.Flen
+			if argTp.Decimal > 0 {
+				argIntLen -= argTp.Decimal + 1
+			}
+
+			// Reduce the sign bit if it is a signed integer.
+			if !mysql.HasUnsignedFlag(argTp.Flag) {
+				argIntLen--
+			}
+			if argIntLen > maxIntLen {
+				maxIntLen = argIntLen
+			}
+			if argTp.Flen > maxFlen || argTp.Flen == types.UnspecifiedLength {
+				maxFlen = argTp.Flen
+			}
+		}
+		// For integer, field length = maxIntLen + (1/0 for sign bit).
+		// For decimal, field length = maxIntLen + maxDecimal + (1/0 for sign bit).
+		if resultEvalType == types.ETInt || resultEvalType == types.ETDecimal {
+			if resultEvalType == types.ETInt {
+				resultFieldType.Decimal = 0
+			}
+			resultFieldType.Flen = maxIntLen + resultFieldType.Decimal
+			if resultFieldType.Decimal > 0 {
+				resultFieldType.Flen++
+			}
+			if !mysql.HasUnsignedFlag(resultFieldType.Flag) {
+				resultFieldType.Flen++
+			}
+			bf.tp = resultFieldType
+		} else {
+			bf.tp.Flen = maxFlen
+		}
+		// Set the Flen of result to UnspecifiedLength if any of the arguments' Flen is UnspecifiedLength.
+		if maxFlen == types.UnspecifiedLength {
+			bf.tp.Flen = types.UnspecifiedLength
+		}
+	}
+
+	switch retEvalTp {
+	case types.ETInt:
+		sig = &builtinCoalesceIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceInt)
+	case types.ETReal:
+		sig = &builtinCoalesceRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceReal)
+	case types.ETDecimal:
+		sig = &builtinCoalesceDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceDecimal)
+	case types.ETString:
+		sig = &builtinCoalesceStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceString)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinCoalesceTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceTime)
+	case types.ETDuration:
+		sig = &builtinCoalesceDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceDuration)
+	case types.ETJson:
+		sig = &builtinCoalesceJSONSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceJson)
+	}
+
+	return sig, nil
+}
+
+// builtinCoalesceIntSig is builtin function coalesce signature which return type int
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceIntSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceIntSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalInt(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceRealSig is builtin function coalesce signature which return type real
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceRealSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceRealSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalReal(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceDecimalSig is builtin function coalesce signature which return type Decimal
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceDecimalSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceDecimalSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalDecimal(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceStringSig is builtin function coalesce signature which return type string
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceStringSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceStringSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalString(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceTimeSig is builtin function coalesce signature which return type time
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceTimeSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceTimeSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalTime(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceDurationSig is builtin function coalesce signature which return type duration
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceDurationSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceDurationSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalDuration(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// builtinCoalesceJSONSig is builtin function coalesce signature which return type json.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_coalesce
+type builtinCoalesceJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceJSONSig) Clone() builtinFunc {
+	newSig := &builtinCoalesceJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceJSONSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalJSON(b.ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
+// getCmpTp4MinMax gets compare type for GREATEST and LEAST.
+// The arguments are compared as datetimes if all of them are dates, datetimes
+// or timestamps, as integers if all of them are integers, as strings if all of
+// them are strings, as decimals if all of them are integers or decimals, and
+// as reals otherwise.
+func getCmpTp4MinMax(args []Expression) (argTp types.EvalType) {
+	allInt, allStr, allDecimal, allTime := true, true, true, true
+	for _, arg := range args {
+		ft := arg.GetType()
+		if ft.Tp == mysql.TypeNull {
+			continue
+		}
+		if !types.IsTypeTime(ft.Tp) {
+			allTime = false
+		}
+		evalTp := ft.EvalType()
+		if evalTp != types.ETInt && !ft.Hybrid() {
+			allInt = false
+			if evalTp != types.ETDecimal {
+				allDecimal = false
+			}
+		}
+		if !evalTp.IsStringKind() {
+			allStr = false
+		}
+	}
+	switch {
+	case allTime:
+		return types.ETDatetime
+	case allStr:
+		return types.ETString
+	case allInt:
+		return types.ETInt
+	case allDecimal:
+		return types.ETDecimal
+	}
+	return types.ETReal
+}
+
+func buildMinMaxFuncTp(ctx sessionctx.Context, args []Expression) (bf baseBuiltinFunc, tp types.EvalType) {
+	tp = getCmpTp4MinMax(args)
+	argTps := make([]types.EvalType, len(args))
+	for i := range args {
+		argTps[i] = tp
+	}
+	bf = newBaseBuiltinFuncWithTp(ctx, args, tp, argTps...)
+	flen := 0
+	for _, arg := range args {
+		flen = mathutil.Max(flen, arg.GetType().Flen)
+	}
+	if tp == types.ETString {
+		bf.tp.Flen = flen
+	}
+	return bf, tp
+}
+
+type greatestFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *greatestFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, tp := buildMinMaxFuncTp(ctx, args)
+	switch tp {
+	case types.ETInt:
+		sig = &builtinGreatestIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestInt)
+	case types.ETReal:
+		sig = &builtinGreatestRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestReal)
+	case types.ETDecimal:
+		sig = &builtinGreatestDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestDecimal)
+	case types.ETString:
+		sig = &builtinGreatestStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestString)
+	case types.ETDatetime:
+		sig = &builtinGreatestTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_GreatestTime)
+	}
+	return sig, nil
+}
+
+type builtinGreatestIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestIntSig) Clone() builtinFunc {
+	newSig := &builtinGreatestIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinGreatestIntSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestIntSig) evalInt(row chunk.Row) (max int64, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v int64
+		v, isNull, err = b.args[i].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestRealSig) Clone() builtinFunc {
+	newSig := &builtinGreatestRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinGreatestRealSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestRealSig) evalReal(row chunk.Row) (max float64, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v float64
+		v, isNull, err = b.args[i].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestDecimalSig) Clone() builtinFunc {
+	newSig := &builtinGreatestDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalDecimal evals a builtinGreatestDecimalSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestDecimalSig) evalDecimal(row chunk.Row) (max *types.MyDecimal, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v *types.MyDecimal
+		v, isNull, err = b.args[i].EvalDecimal(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v.Compare(max) > 0 {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestStringSig) Clone() builtinFunc {
+	newSig := &builtinGreatestStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGreatestStringSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestStringSig) evalString(row chunk.Row) (max string, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v string
+		v, isNull, err = b.args[i].EvalString(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if types.CompareString(v, max) > 0 {
+			max = v
+		}
+	}
+	return
+}
+
+type builtinGreatestTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGreatestTimeSig) Clone() builtinFunc {
+	newSig := &builtinGreatestTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals a builtinGreatestTimeSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#function_greatest
+func (b *builtinGreatestTimeSig) evalTime(row chunk.Row) (max types.Time, isNull bool, err error) {
+	max, isNull, err = b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return max, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v types.Time
+		v, isNull, err = b.args[i].EvalTime(b.ctx, row)
+		if isNull || err != nil {
+			return max, isNull, err
+		}
+		if v.Compare(max) > 0 {
+			max = v
+		}
+	}
+	return
+}
+
+type leastFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *leastFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err = c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, tp := buildMinMaxFuncTp(ctx, args)
+	switch tp {
+	case types.ETInt:
+		sig = &builtinLeastIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastInt)
+	case types.ETReal:
+		sig = &builtinLeastRealSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastReal)
+	case types.ETDecimal:
+		sig = &builtinLeastDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastDecimal)
+	case types.ETString:
+		sig = &builtinLeastStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastString)
+	case types.ETDatetime:
+		sig = &builtinLeastTimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_LeastTime)
+	}
+	return sig, nil
+}
+
+type builtinLeastIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastIntSig) Clone() builtinFunc {
+	newSig := &builtinLeastIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinLeastIntSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastIntSig) evalInt(row chunk.Row) (min int64, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v int64
+		v, isNull, err = b.args[i].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v < min {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastRealSig) Clone() builtinFunc {
+	newSig := &builtinLeastRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinLeastRealSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastRealSig) evalReal(row chunk.Row) (min float64, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v float64
+		v, isNull, err = b.args[i].EvalReal(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v < min {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastDecimalSig) Clone() builtinFunc {
+	newSig := &builtinLeastDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalDecimal evals a builtinLeastDecimalSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastDecimalSig) evalDecimal(row chunk.Row) (min *types.MyDecimal, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalDecimal(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v *types.MyDecimal
+		v, isNull, err = b.args[i].EvalDecimal(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v.Compare(min) < 0 {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastStringSig) Clone() builtinFunc {
+	newSig := &builtinLeastStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinLeastStringSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastStringSig) evalString(row chunk.Row) (min string, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v string
+		v, isNull, err = b.args[i].EvalString(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if types.CompareString(v, min) < 0 {
+			min = v
+		}
+	}
+	return
+}
+
+type builtinLeastTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeastTimeSig) Clone() builtinFunc {
+	newSig := &builtinLeastTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals a builtinLeastTimeSig.
+// See http://dev.mysql.com/doc/refman/5.7/en/comparison-operators.html#functionleast
+func (b *builtinLeastTimeSig) evalTime(row chunk.Row) (min types.Time, isNull bool, err error) {
+	min, isNull, err = b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return min, isNull, err
+	}
+	for i := 1; i < len(b.args); i++ {
+		var v types.Time
+		v, isNull, err = b.args[i].EvalTime(b.ctx, row)
+		if isNull || err != nil {
+			return min, isNull, err
+		}
+		if v.Compare(min) < 0 {
+			min = v
+		}
+	}
+	return
+}
+
 type compareFunctionClass struct {
 	baseFunctionClass
 
 	op opcode.Op
 }
 
+func isUntypedTp(tp byte) bool {
+	return tp == mysql.TypeUnspecified || tp == mysql.TypeNull
+}
+
 // getBaseCmpType gets the EvalType that the two args will be treated as when comparing.
 func getBaseCmpType(lhs, rhs types.EvalType, lft, rft *types.FieldType) types.EvalType {
-	if lft.Tp == mysql.TypeUnspecified || rft.Tp == mysql.TypeUnspecified {
-		if lft.Tp == rft.Tp {
+	// A NULL literal takes the type of the other side, so comparing it never
+	// forces a cast on a column.
+	if isUntypedTp(lft.Tp) || isUntypedTp(rft.Tp) {
+		if isUntypedTp(lft.Tp) && isUntypedTp(rft.Tp) {
 			return types.ETString
 		}
-		if lft.Tp == mysql.TypeUnspecified {
+		if isUntypedTp(lft.Tp) {
 			lhs = rhs
 		} else {
 			rhs = lhs
@@ -72,6 +801,9 @@ func getBaseCmpType(lhs, rhs types.EvalType, lft, rft *types.FieldType) types.Ev
 		return types.ETString
 	} else if (lhs == types.ETInt || lft.Hybrid()) && (rhs == types.ETInt || rft.Hybrid()) {
 		return types.ETInt
+	} else if (lhs == types.ETInt || lhs == types.ETDecimal || lft.Hybrid()) &&
+		(rhs == types.ETInt || rhs == types.ETDecimal || rft.Hybrid()) {
+		return types.ETDecimal
 	}
 	return types.ETReal
 }
@@ -82,9 +814,55 @@ func GetAccurateCmpType(lhs, rhs Expression) types.EvalType {
 	lhsFieldType, rhsFieldType := lhs.GetType(), rhs.GetType()
 	lhsEvalType, rhsEvalType := lhsFieldType.EvalType(), rhsFieldType.EvalType()
 	cmpType := getBaseCmpType(lhsEvalType, rhsEvalType, lhsFieldType, rhsFieldType)
+	if lhsEvalType == types.ETJson || rhsEvalType == types.ETJson {
+		// json <cmp> json
+		// json <cmp> non-json
+		// compare as json, the non-json side is converted to a JSON scalar.
+		cmpType = types.ETJson
+	} else if cmpType == types.ETString && (types.IsTypeTime(lhsFieldType.Tp) || types.IsTypeTime(rhsFieldType.Tp)) {
+		// date[time] <cmp> date[time]
+		// string <cmp> date[time]
+		// compare as time
+		if lhsFieldType.Tp == rhsFieldType.Tp {
+			cmpType = lhsFieldType.EvalType()
+		} else {
+			cmpType = types.ETDatetime
+		}
+	} else if lhsFieldType.Tp == mysql.TypeDuration && rhsFieldType.Tp == mysql.TypeDuration {
+		// duration <cmp> duration
+		// compare as duration
+		cmpType = types.ETDuration
+	} else if cmpType == types.ETReal || cmpType == types.ETString {
+		_, isLHSConst := lhs.(*Constant)
+		_, isRHSConst := rhs.(*Constant)
+		if isTemporalColumn(lhs) && isRHSConst || isTemporalColumn(rhs) && isLHSConst {
+			// <temporal column> <cmp> <non-temporal constant>
+			// or
+			// <non-temporal constant> <cmp> <temporal column>
+			// Convert the constant to temporal type.
+			col, isLHSColumn := lhs.(*Column)
+			if !isLHSColumn {
+				col = rhs.(*Column)
+			}
+			if col.GetType().Tp == mysql.TypeDuration {
+				cmpType = types.ETDuration
+			} else {
+				cmpType = types.ETDatetime
+			}
+		}
+	}
 	return cmpType
 }
 
+// isTemporalColumn checks if a expression is a temporal column,
+// temporal column indicates time column or duration column.
+func isTemporalColumn(expr Expression) bool {
+	if _, isCol := expr.(*Column); !isCol {
+		return false
+	}
+	return types.IsTypeTemporal(expr.GetType().Tp)
+}
+
 // GetCmpFunction get the compare function according to two arguments.
 func GetCmpFunction(lhs, rhs Expression) CompareFunc {
 	switch GetAccurateCmpType(lhs, rhs) {
@@ -92,8 +870,16 @@ func GetCmpFunction(lhs, rhs Expression) CompareFunc {
 		return CompareInt
 	case types.ETReal:
 		return CompareReal
+	case types.ETDecimal:
+		return CompareDecimal
 	case types.ETString:
 		return CompareString
+	case types.ETDatetime, types.ETTimestamp:
+		return CompareTime
+	case types.ETDuration:
+		return CompareDuration
+	case types.ETJson:
+		return CompareJSON
 	}
 	return nil
 }
@@ -103,13 +889,117 @@ func (c *compareFunctionClass) getFunction(ctx sessionctx.Context, rawArgs []Exp
 	if err = c.verifyArgs(rawArgs); err != nil {
 		return nil, err
 	}
-	cmpType := GetAccurateCmpType(rawArgs[0], rawArgs[1])
-	sig, err = c.generateCmpSigs(ctx, rawArgs, cmpType)
+	args := c.refineArgs(ctx, rawArgs)
+	cmpType := GetAccurateCmpType(args[0], args[1])
+	sig, err = c.generateCmpSigs(ctx, args, cmpType)
 	return sig, err
 }
 
+// symmetricOp is the operator to use after swapping the two compared arguments.
+var symmetricOp = map[opcode.Op]opcode.Op{
+	opcode.LT: opcode.GT,
+	opcode.GE: opcode.LE,
+	opcode.GT: opcode.LT,
+	opcode.LE: opcode.GE,
+	opcode.EQ: opcode.EQ,
+	opcode.NE: opcode.NE,
+}
+
+// refineArgs converts a non-integer constant compared with an integer expression
+// into an equivalent integer constant, e.g. `a < 1.5` into `a < 2`, so the
+// integer side is compared as is instead of being wrapped in a cast.
+func (c *compareFunctionClass) refineArgs(ctx sessionctx.Context, args []Expression) []Expression {
+	arg0IsInt := args[0].GetType().EvalType() == types.ETInt && !args[0].GetType().Hybrid()
+	arg1IsInt := args[1].GetType().EvalType() == types.ETInt && !args[1].GetType().Hybrid()
+	arg0, arg0IsCon := args[0].(*Constant)
+	arg1, arg1IsCon := args[1].(*Constant)
+	// int non-constant [cmp] non-int constant
+	if arg0IsInt && !arg0IsCon && !arg1IsInt && arg1IsCon {
+		if con, ok := refineComparedConstant(ctx, arg1, c.op); ok {
+			return []Expression{args[0], con}
+		}
+	}
+	// non-int constant [cmp] int non-constant
+	if arg1IsInt && !arg1IsCon && !arg0IsInt && arg0IsCon {
+		if con, ok := refineComparedConstant(ctx, arg0, symmetricOp[c.op]); ok {
+			return []Expression{con, args[1]}
+		}
+	}
+	return args
+}
+
+// refineComparedConstant rounds a real, decimal or string constant to the integer
+// that gives the same result when compared with an integer by op. It returns false
+// if there is no such integer, e.g. for `a = 1.5` or a value out of the BIGINT range.
+func refineComparedConstant(ctx sessionctx.Context, con *Constant, op opcode.Op) (*Constant, bool) {
+	if con.ParamMarker != nil {
+		// The value of a parameter may change in the next execution of a cached plan.
+		return nil, false
+	}
+	evalTp := con.GetType().EvalType()
+	if evalTp != types.ETReal && evalTp != types.ETDecimal && evalTp != types.ETString {
+		return nil, false
+	}
+	dt, err := con.Eval(chunk.Row{})
+	if err != nil || dt.IsNull() {
+		return nil, false
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	if evalTp == types.ETString {
+		// An integer is compared with a string as DOUBLE.
+		dt, err = dt.ConvertTo(sc, types.NewFieldType(mysql.TypeDouble))
+		if err != nil {
+			return nil, false
+		}
+	}
+	dec, err := dt.ToDecimal(sc)
+	if err != nil {
+		return nil, false
+	}
+	// ModeTruncate rounds toward zero and ModeCeiling away from zero.
+	var roundMode types.RoundMode
+	switch op {
+	case opcode.LT, opcode.GE:
+		roundMode = types.ModeCeiling
+		if dec.IsNegative() {
+			roundMode = types.ModeTruncate
+		}
+	case opcode.LE, opcode.GT:
+		roundMode = types.ModeTruncate
+		if dec.IsNegative() {
+			roundMode = types.ModeCeiling
+		}
+	default:
+		roundMode = types.ModeTruncate
+	}
+	rounded := new(types.MyDecimal)
+	if err = dec.Round(rounded, 0, roundMode); err != nil {
+		return nil, false
+	}
+	if (op == opcode.EQ || op == opcode.NE) && rounded.Compare(dec) != 0 {
+		return nil, false
+	}
+	var val types.Datum
+	retTp := types.NewFieldType(mysql.TypeLonglong)
+	if i, err := rounded.ToInt(); err == nil {
+		val.SetInt64(i)
+	} else if u, err := rounded.ToUint(); err == nil && !rounded.IsNegative() {
+		val.SetUint64(u)
+		retTp.Flag |= mysql.UnsignedFlag
+	} else {
+		return nil, false
+	}
+	return &Constant{Value: val, RetType: retTp}, true
+}
+
 // generateCmpSigs generates compare function signatures.
 func (c *compareFunctionClass) generateCmpSigs(ctx sessionctx.Context, args []Expression, tp types.EvalType) (sig builtinFunc, err error) {
+	if tp == types.ETJson {
+		// A string compared with a JSON value is a JSON string rather than a JSON document.
+		for i := range args {
+			args[i] = WrapWithCastAsJSON(ctx, args[i])
+		}
+	}
 	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, tp, tp)
 	bf.tp.Flen = 1
 	switch tp {
@@ -155,6 +1045,27 @@ func (c *compareFunctionClass) generateCmpSigs(ctx sessionctx.Context, args []Ex
 			sig = &builtinNERealSig{bf}
 			sig.setPbCode(tipb.ScalarFuncSig_NEReal)
 		}
+	case types.ETDecimal:
+		switch c.op {
+		case opcode.LT:
+			sig = &builtinLTDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LTDecimal)
+		case opcode.LE:
+			sig = &builtinLEDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LEDecimal)
+		case opcode.GT:
+			sig = &builtinGTDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GTDecimal)
+		case opcode.GE:
+			sig = &builtinGEDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GEDecimal)
+		case opcode.EQ:
+			sig = &builtinEQDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_EQDecimal)
+		case opcode.NE:
+			sig = &builtinNEDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NEDecimal)
+		}
 	case types.ETString:
 		switch c.op {
 		case opcode.LT:
@@ -176,50 +1087,169 @@ func (c *compareFunctionClass) generateCmpSigs(ctx sessionctx.Context, args []Ex
 			sig = &builtinNEStringSig{bf}
 			sig.setPbCode(tipb.ScalarFuncSig_NEString)
 		}
+	case types.ETDatetime, types.ETTimestamp:
+		switch c.op {
+		case opcode.LT:
+			sig = &builtinLTTimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LTTime)
+		case opcode.LE:
+			sig = &builtinLETimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LETime)
+		case opcode.GT:
+			sig = &builtinGTTimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GTTime)
+		case opcode.GE:
+			sig = &builtinGETimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GETime)
+		case opcode.EQ:
+			sig = &builtinEQTimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_EQTime)
+		case opcode.NE:
+			sig = &builtinNETimeSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NETime)
+		}
+	case types.ETDuration:
+		switch c.op {
+		case opcode.LT:
+			sig = &builtinLTDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LTDuration)
+		case opcode.LE:
+			sig = &builtinLEDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LEDuration)
+		case opcode.GT:
+			sig = &builtinGTDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GTDuration)
+		case opcode.GE:
+			sig = &builtinGEDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GEDuration)
+		case opcode.EQ:
+			sig = &builtinEQDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_EQDuration)
+		case opcode.NE:
+			sig = &builtinNEDurationSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NEDuration)
+		}
+	case types.ETJson:
+		switch c.op {
+		case opcode.LT:
+			sig = &builtinLTJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LTJson)
+		case opcode.LE:
+			sig = &builtinLEJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LEJson)
+		case opcode.GT:
+			sig = &builtinGTJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GTJson)
+		case opcode.GE:
+			sig = &builtinGEJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GEJson)
+		case opcode.EQ:
+			sig = &builtinEQJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_EQJson)
+		case opcode.NE:
+			sig = &builtinNEJSONSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NEJson)
+		}
 	}
 	return
 }
 
-type builtinLTIntSig struct {
+type builtinLTIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLTIntSig) Clone() builtinFunc {
+	newSig := &builtinLTIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLTIntSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareInt(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLTRealSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLTRealSig) Clone() builtinFunc {
+	newSig := &builtinLTRealSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLTRealSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLTDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLTDecimalSig) Clone() builtinFunc {
+	newSig := &builtinLTDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLTDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLTStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLTStringSig) Clone() builtinFunc {
+	newSig := &builtinLTStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLTStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareString(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLTTimeSig struct {
 	baseBuiltinFunc
 }
 
-func (b *builtinLTIntSig) Clone() builtinFunc {
-	newSig := &builtinLTIntSig{}
+func (b *builtinLTTimeSig) Clone() builtinFunc {
+	newSig := &builtinLTTimeSig{}
 	newSig.cloneFrom(&b.baseBuiltinFunc)
 	return newSig
 }
 
-func (b *builtinLTIntSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
-	return resOfLT(CompareInt(b.ctx, b.args[0], b.args[1], row, row))
+func (b *builtinLTTimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
 }
 
-type builtinLTRealSig struct {
+type builtinLTDurationSig struct {
 	baseBuiltinFunc
 }
 
-func (b *builtinLTRealSig) Clone() builtinFunc {
-	newSig := &builtinLTRealSig{}
+func (b *builtinLTDurationSig) Clone() builtinFunc {
+	newSig := &builtinLTDurationSig{}
 	newSig.cloneFrom(&b.baseBuiltinFunc)
 	return newSig
 }
 
-func (b *builtinLTRealSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
-	return resOfLT(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
+func (b *builtinLTDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
 }
 
-type builtinLTStringSig struct {
+type builtinLTJSONSig struct {
 	baseBuiltinFunc
 }
 
-func (b *builtinLTStringSig) Clone() builtinFunc {
-	newSig := &builtinLTStringSig{}
+func (b *builtinLTJSONSig) Clone() builtinFunc {
+	newSig := &builtinLTJSONSig{}
 	newSig.cloneFrom(&b.baseBuiltinFunc)
 	return newSig
 }
 
-func (b *builtinLTStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
-	return resOfLT(CompareString(b.ctx, b.args[0], b.args[1], row, row))
+func (b *builtinLTJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
 }
 
 type builtinLEIntSig struct {
@@ -250,6 +1280,20 @@ func (b *builtinLERealSig) evalInt(row chunk.Row) (val int64, isNull bool, err e
 	return resOfLE(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinLEDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLEDecimalSig) Clone() builtinFunc {
+	newSig := &builtinLEDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLEDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLE(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinLEStringSig struct {
 	baseBuiltinFunc
 }
@@ -264,6 +1308,48 @@ func (b *builtinLEStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err
 	return resOfLE(CompareString(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinLETimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLETimeSig) Clone() builtinFunc {
+	newSig := &builtinLETimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLETimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLE(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLEDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLEDurationSig) Clone() builtinFunc {
+	newSig := &builtinLEDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLEDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLE(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLEJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLEJSONSig) Clone() builtinFunc {
+	newSig := &builtinLEJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLEJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLE(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinGTIntSig struct {
 	baseBuiltinFunc
 }
@@ -292,6 +1378,20 @@ func (b *builtinGTRealSig) evalInt(row chunk.Row) (val int64, isNull bool, err e
 	return resOfGT(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinGTDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGTDecimalSig) Clone() builtinFunc {
+	newSig := &builtinGTDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGTDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGT(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinGTStringSig struct {
 	baseBuiltinFunc
 }
@@ -306,6 +1406,48 @@ func (b *builtinGTStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err
 	return resOfGT(CompareString(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinGTTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGTTimeSig) Clone() builtinFunc {
+	newSig := &builtinGTTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGTTimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGT(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGTDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGTDurationSig) Clone() builtinFunc {
+	newSig := &builtinGTDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGTDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGT(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGTJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGTJSONSig) Clone() builtinFunc {
+	newSig := &builtinGTJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGTJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGT(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinGEIntSig struct {
 	baseBuiltinFunc
 }
@@ -334,6 +1476,20 @@ func (b *builtinGERealSig) evalInt(row chunk.Row) (val int64, isNull bool, err e
 	return resOfGE(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinGEDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGEDecimalSig) Clone() builtinFunc {
+	newSig := &builtinGEDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGEDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGE(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinGEStringSig struct {
 	baseBuiltinFunc
 }
@@ -348,6 +1504,48 @@ func (b *builtinGEStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err
 	return resOfGE(CompareString(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinGETimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGETimeSig) Clone() builtinFunc {
+	newSig := &builtinGETimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGETimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGE(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGEDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGEDurationSig) Clone() builtinFunc {
+	newSig := &builtinGEDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGEDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGE(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGEJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGEJSONSig) Clone() builtinFunc {
+	newSig := &builtinGEJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGEJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGE(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinEQIntSig struct {
 	baseBuiltinFunc
 }
@@ -376,6 +1574,20 @@ func (b *builtinEQRealSig) evalInt(row chunk.Row) (val int64, isNull bool, err e
 	return resOfEQ(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinEQDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinEQDecimalSig) Clone() builtinFunc {
+	newSig := &builtinEQDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinEQDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfEQ(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinEQStringSig struct {
 	baseBuiltinFunc
 }
@@ -390,6 +1602,48 @@ func (b *builtinEQStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err
 	return resOfEQ(CompareString(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinEQTimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinEQTimeSig) Clone() builtinFunc {
+	newSig := &builtinEQTimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinEQTimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfEQ(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinEQDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinEQDurationSig) Clone() builtinFunc {
+	newSig := &builtinEQDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinEQDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfEQ(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinEQJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinEQJSONSig) Clone() builtinFunc {
+	newSig := &builtinEQJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinEQJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfEQ(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinNEIntSig struct {
 	baseBuiltinFunc
 }
@@ -418,6 +1672,20 @@ func (b *builtinNERealSig) evalInt(row chunk.Row) (val int64, isNull bool, err e
 	return resOfNE(CompareReal(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinNEDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNEDecimalSig) Clone() builtinFunc {
+	newSig := &builtinNEDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNEDecimalSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfNE(CompareDecimal(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 type builtinNEStringSig struct {
 	baseBuiltinFunc
 }
@@ -432,6 +1700,48 @@ func (b *builtinNEStringSig) evalInt(row chunk.Row) (val int64, isNull bool, err
 	return resOfNE(CompareString(b.ctx, b.args[0], b.args[1], row, row))
 }
 
+type builtinNETimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNETimeSig) Clone() builtinFunc {
+	newSig := &builtinNETimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNETimeSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfNE(CompareTime(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinNEDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNEDurationSig) Clone() builtinFunc {
+	newSig := &builtinNEDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNEDurationSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfNE(CompareDuration(b.ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinNEJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNEJSONSig) Clone() builtinFunc {
+	newSig := &builtinNEJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNEJSONSig) evalInt(row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfNE(CompareJSON(b.ctx, b.args[0], b.args[1], row, row))
+}
+
 func resOfLT(val int64, isNull bool, err error) (int64, bool, error) {
 	if isNull || err != nil {
 		return 0, isNull, err
@@ -596,3 +1906,75 @@ func CompareReal(sctx sessionctx.Context, lhsArg, rhsArg Expression, lhsRow, rhs
 	}
 	return int64(types.CompareFloat64(arg0, arg1)), false, nil
 }
+
+// CompareDecimal compares two decimals.
+func CompareDecimal(sctx sessionctx.Context, lhsArg, rhsArg Expression, lhsRow, rhsRow chunk.Row) (int64, bool, error) {
+	arg0, isNull0, err := lhsArg.EvalDecimal(sctx, lhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	arg1, isNull1, err := rhsArg.EvalDecimal(sctx, rhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	if isNull0 || isNull1 {
+		return compareNull(isNull0, isNull1), true, nil
+	}
+	return int64(arg0.Compare(arg1)), false, nil
+}
+
+// CompareTime compares two datetime or timestamps.
+func CompareTime(sctx sessionctx.Context, lhsArg, rhsArg Expression, lhsRow, rhsRow chunk.Row) (int64, bool, error) {
+	arg0, isNull0, err := lhsArg.EvalTime(sctx, lhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	arg1, isNull1, err := rhsArg.EvalTime(sctx, rhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	if isNull0 || isNull1 {
+		return compareNull(isNull0, isNull1), true, nil
+	}
+	return int64(arg0.Compare(arg1)), false, nil
+}
+
+// CompareDuration compares two durations.
+func CompareDuration(sctx sessionctx.Context, lhsArg, rhsArg Expression, lhsRow, rhsRow chunk.Row) (int64, bool, error) {
+	arg0, isNull0, err := lhsArg.EvalDuration(sctx, lhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	arg1, isNull1, err := rhsArg.EvalDuration(sctx, rhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	if isNull0 || isNull1 {
+		return compareNull(isNull0, isNull1), true, nil
+	}
+	return int64(arg0.Compare(arg1)), false, nil
+}
+
+// CompareJSON compares two JSONs.
+func CompareJSON(sctx sessionctx.Context, lhsArg, rhsArg Expression, lhsRow, rhsRow chunk.Row) (int64, bool, error) {
+	arg0, isNull0, err := lhsArg.EvalJSON(sctx, lhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	arg1, isNull1, err := rhsArg.EvalJSON(sctx, rhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	if isNull0 || isNull1 {
+		return compareNull(isNull0, isNull1), true, nil
+	}
+	return int64(json.CompareBinary(arg0, arg1)), false, nil
+}
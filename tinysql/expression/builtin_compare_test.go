@@ -17,6 +17,7 @@ import (
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 )
 
@@ -48,3 +49,67 @@ func (s *testEvaluatorSuite) TestCompare(c *C) {
 		c.Assert(res, Equals, t.expected)
 	}
 }
+
+func (s *testEvaluatorSuite) TestCoalesce(c *C) {
+	cases := []struct {
+		args     []interface{}
+		expected interface{}
+		isNil    bool
+	}{
+		{[]interface{}{nil}, nil, true},
+		{[]interface{}{nil, nil}, nil, true},
+		{[]interface{}{nil, nil, nil}, nil, true},
+		{[]interface{}{nil, 1}, int64(1), false},
+		{[]interface{}{nil, 1.1}, 1.1, false},
+		{[]interface{}{1, 1.1}, float64(1), false},
+		{[]interface{}{nil, "abc", nil}, "abc", false},
+	}
+
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Coalesce, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetValue(), Equals, t.expected)
+		}
+	}
+
+	_, err := funcs[ast.Coalesce].getFunction(s.ctx, []Expression{Zero})
+	c.Assert(err, IsNil)
+}
+
+func (s *testEvaluatorSuite) TestGreatestLeast(c *C) {
+	cases := []struct {
+		args     []interface{}
+		greatest interface{}
+		least    interface{}
+	}{
+		{[]interface{}{1, 2, 3}, int64(3), int64(1)},
+		{[]interface{}{-1.5, 2.5, 0.5}, 2.5, -1.5},
+		{[]interface{}{-1, 2.5, 0}, 2.5, float64(-1)},
+		{[]interface{}{"10", 9, 8}, float64(10), float64(8)},
+		{[]interface{}{"b", "a", "c"}, "c", "a"},
+		{[]interface{}{1, nil, 3}, nil, nil},
+	}
+
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Greatest, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d.GetValue(), Equals, t.greatest)
+
+		f, err = newFunctionForTest(s.ctx, ast.Least, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err = f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(d.GetValue(), Equals, t.least)
+	}
+
+	_, err := funcs[ast.Greatest].getFunction(s.ctx, []Expression{Zero})
+	c.Assert(err, NotNil)
+}
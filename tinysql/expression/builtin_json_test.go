@@ -0,0 +1,209 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// evalJSONFuncForTest builds funcName over args and evaluates it on an
+// empty row.
+func (s *testEvaluatorSuite) evalJSONFuncForTest(c *C, funcName string, args []interface{}) (types.Datum, error) {
+	f, err := funcs[funcName].getFunction(s.ctx, s.primitiveValsToConstants(args))
+	c.Assert(err, IsNil)
+	return evalBuiltinFunc(f, chunk.Row{})
+}
+
+func (s *testEvaluatorSuite) TestJSONType(c *C) {
+	tbl := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{nil, nil},
+		{`3`, `INTEGER`},
+		{`3.0`, `DOUBLE`},
+		{`null`, `NULL`},
+		{`true`, `BOOLEAN`},
+		{`[]`, `ARRAY`},
+		{`{}`, `OBJECT`},
+		{`"3"`, `STRING`},
+	}
+	for _, t := range tbl {
+		d, err := s.evalJSONFuncForTest(c, ast.JSONType, []interface{}{t.input})
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+			continue
+		}
+		c.Assert(d.GetString(), Equals, t.expected)
+	}
+}
+
+func (s *testEvaluatorSuite) TestJSONUnquote(c *C) {
+	tbl := []struct {
+		input    interface{}
+		expected interface{}
+	}{
+		{nil, nil},
+		{``, ``},
+		{`""`, ``},
+		{`"a"`, `a`},
+		{`3`, `3`},
+		{`{"a": "b"}`, `{"a": "b"}`},
+		{`"hello,\"quoted string\",world"`, `hello,"quoted string",world`},
+		{`"é"`, `é`},
+	}
+	for _, t := range tbl {
+		d, err := s.evalJSONFuncForTest(c, ast.JSONUnquote, []interface{}{t.input})
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+			continue
+		}
+		c.Assert(d.GetString(), Equals, t.expected)
+	}
+}
+
+func (s *testEvaluatorSuite) TestJSONExtract(c *C) {
+	jstr := `{"a": [{"aa": [{"aaa": 1}]}], "aaa": 2}`
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		success  bool
+	}{
+		{[]interface{}{nil, nil}, nil, true},
+		{[]interface{}{jstr, `$.a[0].aa[0].aaa`, `$.aaa`}, `[1, 2]`, true},
+		{[]interface{}{jstr, `$.a[0].aa[0].aaa`, `$InvalidPath`}, nil, false},
+		{[]interface{}{jstr, `$.aaa`}, `2`, true},
+		{[]interface{}{jstr, `$.b`}, nil, true},
+		{[]interface{}{jstr, `$.a[*].aa`}, `[[{"aaa": 1}]]`, true},
+		{[]interface{}{jstr, nil}, nil, true},
+		{[]interface{}{`{"a": "b"`, `$.a`}, nil, false},
+	}
+	for _, t := range tbl {
+		d, err := s.evalJSONFuncForTest(c, ast.JSONExtract, t.input)
+		if !t.success {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+			continue
+		}
+		j, err := json.ParseBinaryFromString(t.expected.(string))
+		c.Assert(err, IsNil)
+		c.Assert(json.CompareBinary(j, d.GetMysqlJSON()), Equals, 0, Commentf("%v", t.input))
+	}
+}
+
+func (s *testEvaluatorSuite) TestJSONModify(c *C) {
+	tbl := []struct {
+		funcName string
+		input    []interface{}
+		expected interface{}
+		success  bool
+	}{
+		{ast.JSONSet, []interface{}{nil, `$`, 3}, nil, true},
+		{ast.JSONSet, []interface{}{`{}`, `$.a`, 3}, `{"a": 3}`, true},
+		{ast.JSONSet, []interface{}{`{"a": 1}`, `$.a`, "x", `$.b`, nil}, `{"a": "x", "b": null}`, true},
+		{ast.JSONSet, []interface{}{`[1]`, `$[1]`, 2.5}, `[1, 2.5]`, true},
+		{ast.JSONSet, []interface{}{`{}`, `$.*`, 3}, nil, false},
+		{ast.JSONSet, []interface{}{`{}`, nil, 3}, nil, true},
+		{ast.JSONInsert, []interface{}{`{"a": 1}`, `$.a`, 3, `$.b`, 4}, `{"a": 1, "b": 4}`, true},
+		{ast.JSONReplace, []interface{}{`{"a": 1}`, `$.a`, 3, `$.b`, 4}, `{"a": 3}`, true},
+		{ast.JSONRemove, []interface{}{`{"a": 1, "b": [1, 2]}`, `$.a`, `$.b[0]`}, `{"b": [2]}`, true},
+		{ast.JSONRemove, []interface{}{`{"a": 1}`, `$`}, nil, false},
+		{ast.JSONRemove, []interface{}{`{"a": 1}`, `$.*`}, nil, false},
+	}
+	for _, t := range tbl {
+		d, err := s.evalJSONFuncForTest(c, t.funcName, t.input)
+		if !t.success {
+			c.Assert(err, NotNil, Commentf("%v", t.input))
+			continue
+		}
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+			continue
+		}
+		c.Assert(d.GetMysqlJSON().String(), Equals, t.expected, Commentf("%v", t.input))
+	}
+
+	_, err := funcs[ast.JSONSet].getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{`{}`, `$.a`, 1, `$.b`}))
+	c.Assert(ErrIncorrectParameterCount.Equal(err), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestJSONObjectAndArray(c *C) {
+	d, err := s.evalJSONFuncForTest(c, ast.JSONObject, []interface{}{"b", 1, "a", "x", "c", nil, "d", 1.5})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetMysqlJSON().String(), Equals, `{"a": "x", "b": 1, "c": null, "d": 1.5}`)
+
+	d, err = s.evalJSONFuncForTest(c, ast.JSONObject, nil)
+	c.Assert(err, IsNil)
+	c.Assert(d.GetMysqlJSON().String(), Equals, `{}`)
+
+	_, err = s.evalJSONFuncForTest(c, ast.JSONObject, []interface{}{nil, 1})
+	c.Assert(json.ErrJSONDocumentNULLKey.Equal(err), IsTrue)
+
+	_, err = funcs[ast.JSONObject].getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{"a"}))
+	c.Assert(ErrIncorrectParameterCount.Equal(err), IsTrue)
+
+	// String elements are JSON strings, not documents.
+	d, err = s.evalJSONFuncForTest(c, ast.JSONArray, []interface{}{1, `[2]`, nil, "a"})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetMysqlJSON().String(), Equals, `[1, "[2]", null, "a"]`)
+
+	d, err = s.evalJSONFuncForTest(c, ast.JSONArray, nil)
+	c.Assert(err, IsNil)
+	c.Assert(d.GetMysqlJSON().String(), Equals, `[]`)
+}
+
+func (s *testEvaluatorSuite) TestJSONContains(c *C) {
+	jstr := `{"a": [1, "2", {"aa": "bb"}, 4.0, {"aa": "cc"}], "b": true, "c": ["d"], "d": {"e": 1}}`
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		success  bool
+	}{
+		{[]interface{}{jstr, `1`, `$.a`}, 1, true},
+		{[]interface{}{jstr, `[1, 4]`, `$.a`}, 1, true},
+		{[]interface{}{jstr, `{"aa": "bb"}`, `$.a`}, 1, true},
+		{[]interface{}{jstr, `5`, `$.a`}, 0, true},
+		{[]interface{}{jstr, `{"d": {"e": 1}}`}, 1, true},
+		{[]interface{}{jstr, `{"d": {"e": 2}}`}, 0, true},
+		{[]interface{}{jstr, `1`, `$.x`}, nil, true},
+		{[]interface{}{jstr, `1`, nil}, nil, true},
+		{[]interface{}{nil, `1`}, nil, true},
+		{[]interface{}{jstr, `1`, `$.*`}, nil, false},
+		{[]interface{}{jstr, `1`, `$a`}, nil, false},
+	}
+	for _, t := range tbl {
+		d, err := s.evalJSONFuncForTest(c, ast.JSONContains, t.input)
+		if !t.success {
+			c.Assert(err, NotNil, Commentf("%v", t.input))
+			continue
+		}
+		c.Assert(err, IsNil)
+		if t.expected == nil {
+			c.Assert(d.IsNull(), IsTrue)
+			continue
+		}
+		c.Assert(d.GetInt64(), Equals, int64(t.expected.(int)), Commentf("%v", t.input))
+	}
+}
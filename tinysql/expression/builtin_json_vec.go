@@ -0,0 +1,368 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalJSONFuncArgs evaluates all args of a JSON function into buffers.
+// Args of JSON type are evaluated as JSON and the others as strings. The
+// caller must return the buffers with putJSONFuncArgs.
+func vecEvalJSONFuncArgs(b *baseBuiltinFunc, input *chunk.Chunk) ([]*chunk.Column, error) {
+	n := input.NumRows()
+	bufs := make([]*chunk.Column, 0, len(b.args))
+	for _, arg := range b.args {
+		evalTp := types.ETString
+		if arg.GetType().EvalType() == types.ETJson {
+			evalTp = types.ETJson
+		}
+		buf, err := b.bufAllocator.get(evalTp, n)
+		if err != nil {
+			putJSONFuncArgs(b, bufs)
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+		if evalTp == types.ETJson {
+			err = arg.VecEvalJSON(b.ctx, input, buf)
+		} else {
+			err = arg.VecEvalString(b.ctx, input, buf)
+		}
+		if err != nil {
+			putJSONFuncArgs(b, bufs)
+			return nil, err
+		}
+	}
+	return bufs, nil
+}
+
+func putJSONFuncArgs(b *baseBuiltinFunc, bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		b.bufAllocator.put(buf)
+	}
+}
+
+// parsePathExprsAt parses the path arguments held in bufs at row i. isNull
+// is true if any of them is NULL.
+func parsePathExprsAt(bufs []*chunk.Column, i int) (pathExprs []json.PathExpression, isNull bool, err error) {
+	pathExprs = make([]json.PathExpression, 0, len(bufs))
+	for _, buf := range bufs {
+		if buf.IsNull(i) {
+			return nil, true, nil
+		}
+		pathExpr, err := json.ParseJSONPathExpr(buf.GetString(i))
+		if err != nil {
+			return nil, true, err
+		}
+		pathExprs = append(pathExprs, pathExpr)
+	}
+	return pathExprs, false, nil
+}
+
+// getJSONOrNullAt returns the JSON held in buf at row i, mapping SQL NULL to
+// JSON null.
+func getJSONOrNullAt(buf *chunk.Column, i int) json.BinaryJSON {
+	if buf.IsNull(i) {
+		return json.CreateBinary(nil)
+	}
+	return buf.GetJSON(i)
+}
+
+func (b *builtinJSONTypeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONTypeSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETJson, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(buf.GetJSON(i).Type())
+	}
+	return nil
+}
+
+func (b *builtinJSONExtractSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONExtractSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(&b.baseBuiltinFunc, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(&b.baseBuiltinFunc, bufs)
+
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		pathExprs, isNull, err := parsePathExprsAt(bufs[1:], i)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		if res, found := bufs[0].GetJSON(i).Extract(pathExprs); found {
+			result.AppendJSON(res)
+		} else {
+			result.AppendNull()
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONUnquoteSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONUnquoteSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		str, err := json.UnquoteString(buf.GetString(i))
+		if err != nil {
+			return err
+		}
+		result.AppendString(str)
+	}
+	return nil
+}
+
+// vecEvalJSONModify is the vectorized version of evalJSONModify.
+func vecEvalJSONModify(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, mt json.ModifyType) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(b, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(b, bufs)
+
+	pathBufs := make([]*chunk.Column, 0, (len(bufs)-1)/2)
+	for j := 1; j < len(bufs); j += 2 {
+		pathBufs = append(pathBufs, bufs[j])
+	}
+	values := make([]json.BinaryJSON, 0, len(pathBufs))
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		pathExprs, isNull, err := parsePathExprsAt(pathBufs, i)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		values = values[:0]
+		for j := 2; j < len(bufs); j += 2 {
+			values = append(values, getJSONOrNullAt(bufs[j], i))
+		}
+		res, err := bufs[0].GetJSON(i).Modify(pathExprs, values, mt)
+		if err != nil {
+			return err
+		}
+		result.AppendJSON(res)
+	}
+	return nil
+}
+
+func (b *builtinJSONSetSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONSetSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalJSONModify(&b.baseBuiltinFunc, input, result, json.ModifySet)
+}
+
+func (b *builtinJSONInsertSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONInsertSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalJSONModify(&b.baseBuiltinFunc, input, result, json.ModifyInsert)
+}
+
+func (b *builtinJSONReplaceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONReplaceSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalJSONModify(&b.baseBuiltinFunc, input, result, json.ModifyReplace)
+}
+
+func (b *builtinJSONRemoveSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONRemoveSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(&b.baseBuiltinFunc, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(&b.baseBuiltinFunc, bufs)
+
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		pathExprs, isNull, err := parsePathExprsAt(bufs[1:], i)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		res, err := bufs[0].GetJSON(i).Remove(pathExprs)
+		if err != nil {
+			return err
+		}
+		result.AppendJSON(res)
+	}
+	return nil
+}
+
+func (b *builtinJSONObjectSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONObjectSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(&b.baseBuiltinFunc, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(&b.baseBuiltinFunc, bufs)
+
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		jsons := make(map[string]interface{}, len(bufs)/2)
+		for j := 0; j < len(bufs); j += 2 {
+			if bufs[j].IsNull(i) {
+				return json.ErrJSONDocumentNULLKey
+			}
+			jsons[bufs[j].GetString(i)] = getJSONOrNullAt(bufs[j+1], i)
+		}
+		result.AppendJSON(json.CreateBinary(jsons))
+	}
+	return nil
+}
+
+func (b *builtinJSONArraySig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONArraySig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(&b.baseBuiltinFunc, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(&b.baseBuiltinFunc, bufs)
+
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		jsons := make([]interface{}, 0, len(bufs))
+		for _, buf := range bufs {
+			jsons = append(jsons, getJSONOrNullAt(buf, i))
+		}
+		result.AppendJSON(json.CreateBinary(jsons))
+	}
+	return nil
+}
+
+func (b *builtinJSONContainsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONContainsSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := vecEvalJSONFuncArgs(&b.baseBuiltinFunc, input)
+	if err != nil {
+		return err
+	}
+	defer putJSONFuncArgs(&b.baseBuiltinFunc, bufs)
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs[0], bufs[1])
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		obj := bufs[0].GetJSON(i)
+		if len(bufs) == 3 {
+			pathExprs, isNull, err := parsePathExprsAt(bufs[2:], i)
+			if err != nil {
+				return err
+			}
+			if isNull {
+				result.SetNull(i, true)
+				continue
+			}
+			if pathExprs[0].ContainsAnyAsterisk() {
+				return json.ErrInvalidJSONPathWildcard
+			}
+			var found bool
+			if obj, found = obj.Extract(pathExprs); !found {
+				result.SetNull(i, true)
+				continue
+			}
+		}
+		i64s[i] = 0
+		if json.ContainsBinary(obj, bufs[1].GetJSON(i)) {
+			i64s[i] = 1
+		}
+	}
+	return nil
+}
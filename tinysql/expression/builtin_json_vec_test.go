@@ -0,0 +1,96 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+)
+
+// jsonDocGener generates JSON objects with keys "a", "b" and "c" so that
+// the candidate paths below match some of them.
+type jsonDocGener struct{}
+
+func (g *jsonDocGener) gen() interface{} {
+	if rand.Float64() < 0.1 {
+		return nil
+	}
+	m := map[string]interface{}{"a": rand.Int63n(100)}
+	if rand.Intn(2) == 0 {
+		m["b"] = []interface{}{rand.Int63n(5), randString()}
+	}
+	if rand.Intn(2) == 0 {
+		m["c"] = map[string]interface{}{"d": rand.Intn(2) == 0}
+	}
+	return json.CreateBinary(m)
+}
+
+var jsonPathCandidates = []string{"$.a", "$.b", "$.b[0]", "$.b[1]", "$.c.d", "$.x"}
+
+var vecBuiltinJSONCases = map[string][]vecExprBenchCase{
+	ast.JSONType: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETJson}, geners: []dataGenerator{&jsonDocGener{}}},
+	},
+	ast.JSONExtract: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}}},
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETString},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+	ast.JSONUnquote: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString},
+			geners: []dataGenerator{&selectStringGener{candidates: []string{`"a"`, `"a\tb"`, `abc`, `""`, `"é"`}}}},
+	},
+	ast.JSONSet: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETInt},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+	ast.JSONInsert: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETString, types.ETString, types.ETReal},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}, nil, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+	ast.JSONReplace: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETJson},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+	ast.JSONRemove: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString},
+			geners: []dataGenerator{&jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+	ast.JSONObject: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETString, types.ETJson},
+			geners: []dataGenerator{&selectStringGener{candidates: []string{"a", "b"}}, nil, &selectStringGener{candidates: []string{"c", "d"}}}},
+	},
+	ast.JSONArray: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETInt, types.ETString, types.ETJson}},
+	},
+	ast.JSONContains: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}, geners: []dataGenerator{&jsonDocGener{}, &jsonDocGener{}}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson, types.ETString},
+			geners: []dataGenerator{&jsonDocGener{}, &jsonDocGener{}, &selectStringGener{candidates: jsonPathCandidates}}},
+	},
+}
+
+func (s *testEvaluatorSuite) TestVectorizedBuiltinJSONFunc(c *C) {
+	testVectorizedBuiltinFunc(c, vecBuiltinJSONCases)
+}
+
+func BenchmarkVectorizedBuiltinJSONFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinJSONCases)
+}
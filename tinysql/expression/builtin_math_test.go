@@ -0,0 +1,352 @@
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestAbs(c *C) {
+	tbl := []struct {
+		Arg interface{}
+		Ret interface{}
+	}{
+		{nil, nil},
+		{int64(1), int64(1)},
+		{uint64(1), uint64(1)},
+		{int64(-1), int64(1)},
+		{float64(3.14), float64(3.14)},
+		{float64(-3.14), float64(3.14)},
+	}
+
+	for _, t := range tbl {
+		fc := funcs[ast.Abs]
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		c.Assert(f, NotNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+
+	f, err := funcs[ast.Abs].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(int64(math.MinInt64))))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(terror.ErrorEqual(err, types.ErrOverflow), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestCeilAndFloor(c *C) {
+	tbl := []struct {
+		Arg   interface{}
+		Ceil  interface{}
+		Floor interface{}
+	}{
+		{nil, nil, nil},
+		{int64(1), int64(1), int64(1)},
+		{float64(1.23), float64(2), float64(1)},
+		{float64(-1.23), float64(-1), float64(-2)},
+		{"1.23", float64(2), float64(1)},
+		{"-1.23", float64(-1), float64(-2)},
+	}
+
+	for _, t := range tbl {
+		for _, name := range []string{ast.Ceil, ast.Ceiling} {
+			f, err := funcs[name].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+			c.Assert(err, IsNil)
+			v, err := evalBuiltinFunc(f, chunk.Row{})
+			c.Assert(err, IsNil)
+			c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ceil))
+		}
+		f, err := funcs[ast.Floor].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Floor))
+	}
+
+	f, err := funcs[ast.Ceil].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(uint64(math.MaxUint64))))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().EvalType(), Equals, types.ETInt)
+	c.Assert(mysql.HasUnsignedFlag(f.getRetTp().Flag), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestRound(c *C) {
+	tbl := []struct {
+		Arg []interface{}
+		Ret interface{}
+	}{
+		{[]interface{}{nil}, nil},
+		{[]interface{}{-1.23}, -1},
+		{[]interface{}{-1.58}, -2},
+		{[]interface{}{1.58}, 2},
+		{[]interface{}{1.298, 1}, 1.3},
+		{[]interface{}{1.298}, 1},
+		{[]interface{}{1.298, 0}, 1},
+		{[]interface{}{23.298, -1}, 20},
+		{[]interface{}{2.5}, 3},
+		{[]interface{}{-2.5}, -3},
+		{[]interface{}{int64(1234), -2}, 1200},
+		{[]interface{}{int64(1250), -2}, 1300},
+		{[]interface{}{1.298, nil}, nil},
+	}
+
+	for _, t := range tbl {
+		fc := funcs[ast.Round]
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg...)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestTruncate(c *C) {
+	tbl := []struct {
+		Arg []interface{}
+		Ret interface{}
+	}{
+		{[]interface{}{-1.23, 0}, -1},
+		{[]interface{}{1.58, 0}, 1},
+		{[]interface{}{1.298, 1}, 1.2},
+		{[]interface{}{123.2, -1}, 120},
+		{[]interface{}{123.2, 100}, 123.2},
+		{[]interface{}{123.2, -100}, 0},
+		{[]interface{}{int64(123), 1}, 123},
+		{[]interface{}{int64(123), -1}, 120},
+		{[]interface{}{int64(-123), -2}, -100},
+		{[]interface{}{int64(123), -100}, 0},
+		{[]interface{}{uint64(math.MaxUint64), -19}, uint64(10000000000000000000)},
+		{[]interface{}{uint64(math.MaxUint64), -100}, uint64(0)},
+		{[]interface{}{nil, 1}, nil},
+		{[]interface{}{1.298, nil}, nil},
+	}
+
+	for _, t := range tbl {
+		fc := funcs[ast.Truncate]
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg...)))
+		c.Assert(err, IsNil)
+		c.Assert(f, NotNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestPow(c *C) {
+	tbl := []struct {
+		Arg []interface{}
+		Ret float64
+	}{
+		{[]interface{}{1, 3}, 1},
+		{[]interface{}{2, 2}, 4},
+		{[]interface{}{4, 0.5}, 2},
+		{[]interface{}{4, -2}, 0.0625},
+	}
+
+	for _, t := range tbl {
+		for _, name := range []string{ast.Pow, ast.Power} {
+			f, err := funcs[name].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg...)))
+			c.Assert(err, IsNil)
+			v, err := evalBuiltinFunc(f, chunk.Row{})
+			c.Assert(err, IsNil)
+			c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+		}
+	}
+
+	errTbl := []struct {
+		Arg []interface{}
+	}{
+		{[]interface{}{10, 700}},
+		{[]interface{}{-1, 0.5}},
+	}
+
+	for _, t := range errTbl {
+		f, err := funcs[ast.Pow].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg...)))
+		c.Assert(err, IsNil)
+		_, err = evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(terror.ErrorEqual(err, types.ErrOverflow), IsTrue)
+	}
+}
+
+func (s *testEvaluatorSuite) TestSqrtAndExp(c *C) {
+	tbl := []struct {
+		Arg  interface{}
+		Sqrt interface{}
+		Exp  interface{}
+	}{
+		{nil, nil, nil},
+		{int64(0), float64(0), float64(1)},
+		{int64(4), float64(2), math.Exp(4)},
+		{float64(2.25), float64(1.5), math.Exp(2.25)},
+		{"16", float64(4), math.Exp(16)},
+		{int64(-4), nil, math.Exp(-4)},
+	}
+
+	for _, t := range tbl {
+		f, err := funcs[ast.Sqrt].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Sqrt))
+
+		f, err = funcs[ast.Exp].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		v, err = evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Exp))
+	}
+
+	f, err := funcs[ast.Exp].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(float64(1000))))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(terror.ErrorEqual(err, types.ErrOverflow), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestLog(c *C) {
+	tests := []struct {
+		fn     string
+		args   []interface{}
+		expect interface{}
+	}{
+		{ast.Ln, []interface{}{nil}, nil},
+		{ast.Ln, []interface{}{math.E}, float64(1)},
+		{ast.Ln, []interface{}{int64(0)}, nil},
+		{ast.Ln, []interface{}{int64(-1)}, nil},
+		{ast.Log, []interface{}{float64(100)}, math.Log(100)},
+		{ast.Log, []interface{}{int64(2), int64(65536)}, float64(16)},
+		{ast.Log, []interface{}{int64(10), int64(100)}, float64(2)},
+		{ast.Log, []interface{}{int64(1), int64(100)}, nil},
+		{ast.Log, []interface{}{int64(10), int64(-1)}, nil},
+		{ast.Log, []interface{}{int64(10), nil}, nil},
+		{ast.Log2, []interface{}{int64(65536)}, float64(16)},
+		{ast.Log2, []interface{}{int64(0)}, nil},
+		{ast.Log10, []interface{}{int64(100)}, float64(2)},
+		{ast.Log10, []interface{}{"-2"}, nil},
+	}
+
+	for _, t := range tests {
+		f, err := funcs[t.fn].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.args...)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.expect), Commentf("%s%v", t.fn, t.args))
+	}
+}
+
+func (s *testEvaluatorSuite) TestSign(c *C) {
+	tbl := []struct {
+		Arg interface{}
+		Ret interface{}
+	}{
+		{nil, nil},
+		{int64(-10), int64(-1)},
+		{int64(0), int64(0)},
+		{uint64(10), int64(1)},
+		{float64(-0.5), int64(-1)},
+		{float64(0.5), int64(1)},
+		{"-3", int64(-1)},
+	}
+
+	for _, t := range tbl {
+		f, err := funcs[ast.Sign].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestRand(c *C) {
+	fc := funcs[ast.Rand]
+	f, err := fc.getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	v, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetFloat64(), Less, float64(1))
+	c.Assert(v.GetFloat64(), GreaterEqual, float64(0))
+
+	// A constant seed is used to initialize the generator only once.
+	arg1 := s.datumsToConstants(types.MakeDatums(1))
+	f, err = fc.getFunction(s.ctx, arg1)
+	c.Assert(err, IsNil)
+	v, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetFloat64(), Equals, 0.40540353712197724)
+	v, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetFloat64(), Equals, 0.8716141803857071)
+
+	// A null seed behaves the same as 0.
+	f, err = fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(nil)))
+	c.Assert(err, IsNil)
+	v, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetFloat64(), Equals, 0.15522042769493574)
+}
+
+func (s *testEvaluatorSuite) TestPI(c *C) {
+	f, err := funcs[ast.PI].getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	pi, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(pi, testutil.DatumEquals, types.NewDatum(math.Pi))
+	c.Assert(f.getRetTp().Decimal, Equals, 6)
+}
+
+func (s *testEvaluatorSuite) TestTrigonometric(c *C) {
+	tests := []struct {
+		fn     string
+		args   []interface{}
+		expect interface{}
+	}{
+		{ast.Sin, []interface{}{nil}, nil},
+		{ast.Sin, []interface{}{int64(0)}, float64(0)},
+		{ast.Sin, []interface{}{math.Pi / 2}, math.Sin(math.Pi / 2)},
+		{ast.Cos, []interface{}{int64(0)}, float64(1)},
+		{ast.Cos, []interface{}{math.Pi}, float64(-1)},
+		{ast.Tan, []interface{}{int64(0)}, float64(0)},
+		{ast.Tan, []interface{}{math.Pi / 4}, math.Tan(math.Pi / 4)},
+		{ast.Cot, []interface{}{int64(1)}, 1 / math.Tan(1)},
+		{ast.Asin, []interface{}{int64(1)}, math.Pi / 2},
+		{ast.Asin, []interface{}{int64(2)}, nil},
+		{ast.Acos, []interface{}{int64(1)}, float64(0)},
+		{ast.Acos, []interface{}{int64(-2)}, nil},
+		{ast.Atan, []interface{}{int64(1)}, math.Pi / 4},
+		{ast.Atan, []interface{}{int64(-1), int64(-1)}, -3 * math.Pi / 4},
+		{ast.Atan2, []interface{}{int64(1), int64(0)}, math.Pi / 2},
+		{ast.Degrees, []interface{}{math.Pi}, float64(180)},
+		{ast.Radians, []interface{}{int64(180)}, math.Pi},
+	}
+
+	for _, t := range tests {
+		f, err := funcs[t.fn].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.args...)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.expect), Commentf("%s%v", t.fn, t.args))
+	}
+
+	f, err := funcs[ast.Cot].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(int64(0))))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(terror.ErrorEqual(err, types.ErrOverflow), IsTrue)
+}
@@ -31,17 +31,33 @@ var (
 	_ functionClass = &unaryMinusFunctionClass{}
 	_ functionClass = &isNullFunctionClass{}
 	_ functionClass = &unaryNotFunctionClass{}
+	_ functionClass = &bitAndFunctionClass{}
+	_ functionClass = &bitOrFunctionClass{}
+	_ functionClass = &bitXorFunctionClass{}
+	_ functionClass = &bitNegFunctionClass{}
+	_ functionClass = &leftShiftFunctionClass{}
+	_ functionClass = &rightShiftFunctionClass{}
 )
 
 var (
 	_ builtinFunc = &builtinLogicAndSig{}
 	_ builtinFunc = &builtinLogicOrSig{}
 	_ builtinFunc = &builtinUnaryMinusIntSig{}
+	_ builtinFunc = &builtinUnaryMinusDecimalSig{}
 	_ builtinFunc = &builtinIntIsNullSig{}
 	_ builtinFunc = &builtinRealIsNullSig{}
 	_ builtinFunc = &builtinStringIsNullSig{}
+	_ builtinFunc = &builtinTimeIsNullSig{}
+	_ builtinFunc = &builtinDurationIsNullSig{}
+	_ builtinFunc = &builtinJSONIsNullSig{}
 	_ builtinFunc = &builtinUnaryNotRealSig{}
 	_ builtinFunc = &builtinUnaryNotIntSig{}
+	_ builtinFunc = &builtinBitAndSig{}
+	_ builtinFunc = &builtinBitOrSig{}
+	_ builtinFunc = &builtinBitXorSig{}
+	_ builtinFunc = &builtinBitNegSig{}
+	_ builtinFunc = &builtinLeftShiftSig{}
+	_ builtinFunc = &builtinRightShiftSig{}
 )
 
 type logicAndFunctionClass struct {
@@ -134,6 +150,224 @@ func (b *builtinLogicOrSig) evalInt(row chunk.Row) (int64, bool, error) {
 	return 0, false, nil
 }
 
+type bitAndFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitAndFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	sig := &builtinBitAndSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitAndSig)
+	sig.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinBitAndSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitAndSig) Clone() builtinFunc {
+	newSig := &builtinBitAndSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitAndSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	arg1, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return arg0 & arg1, false, nil
+}
+
+type bitOrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitOrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	sig := &builtinBitOrSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitOrSig)
+	sig.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinBitOrSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitOrSig) Clone() builtinFunc {
+	newSig := &builtinBitOrSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitOrSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	arg1, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return arg0 | arg1, false, nil
+}
+
+type bitXorFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitXorFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	sig := &builtinBitXorSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitXorSig)
+	sig.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinBitXorSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitXorSig) Clone() builtinFunc {
+	newSig := &builtinBitXorSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitXorSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	arg1, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return arg0 ^ arg1, false, nil
+}
+
+type leftShiftFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *leftShiftFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	sig := &builtinLeftShiftSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_LeftShift)
+	sig.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinLeftShiftSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLeftShiftSig) Clone() builtinFunc {
+	newSig := &builtinLeftShiftSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLeftShiftSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	arg1, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(uint64(arg0) << uint64(arg1)), false, nil
+}
+
+type rightShiftFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *rightShiftFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt, types.ETInt)
+	sig := &builtinRightShiftSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_RightShift)
+	sig.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinRightShiftSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinRightShiftSig) Clone() builtinFunc {
+	newSig := &builtinRightShiftSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinRightShiftSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg0, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	arg1, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(uint64(arg0) >> uint64(arg1)), false, nil
+}
+
+type bitNegFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *bitNegFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt)
+	bf.tp.Flag |= mysql.UnsignedFlag
+	sig := &builtinBitNegSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_BitNegSig)
+	return sig, nil
+}
+
+type builtinBitNegSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinBitNegSig) Clone() builtinFunc {
+	newSig := &builtinBitNegSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinBitNegSig) evalInt(row chunk.Row) (int64, bool, error) {
+	arg, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return ^arg, false, nil
+}
+
 type unaryNotFunctionClass struct {
 	baseFunctionClass
 }
@@ -144,8 +378,11 @@ func (c *unaryNotFunctionClass) getFunction(ctx sessionctx.Context, args []Expre
 	}
 
 	argTp := args[0].GetType().EvalType()
-	if argTp == types.ETString {
+	switch argTp {
+	case types.ETString, types.ETJson:
 		argTp = types.ETReal
+	case types.ETDatetime, types.ETTimestamp, types.ETDuration:
+		argTp = types.ETInt
 	}
 
 	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, argTp)
@@ -215,11 +452,37 @@ type unaryMinusFunctionClass struct {
 // typerInfer will infers the return type as types.ETDecimal, not types.ETInt.
 func (c *unaryMinusFunctionClass) typeInfer(argExpr Expression) (types.EvalType, bool) {
 	tp := argExpr.GetType().EvalType()
-	if tp != types.ETInt {
+	if tp != types.ETInt && tp != types.ETDecimal {
 		tp = types.ETReal
 	}
 
-	return tp, false
+	overflow := false
+	if arg, ok := argExpr.(*Constant); ok && tp == types.ETInt {
+		overflow = c.handleIntOverflow(arg)
+		if overflow {
+			tp = types.ETDecimal
+		}
+	}
+	return tp, overflow
+}
+
+func (c *unaryMinusFunctionClass) handleIntOverflow(arg *Constant) (overflow bool) {
+	if mysql.HasUnsignedFlag(arg.GetType().Flag) {
+		uval := arg.Value.GetUint64()
+		// -math.MinInt64 is 9223372036854775808, so if uval is more than 9223372036854775808, like
+		// 9223372036854775809, -9223372036854775809 is less than math.MinInt64, overflow occurs.
+		if uval > uint64(-math.MinInt64) {
+			return true
+		}
+	} else {
+		val := arg.Value.GetInt64()
+		// The math.MinInt64 is -9223372036854775808, the math.MaxInt64 is 9223372036854775807,
+		// which is less than abs(-9223372036854775808). When val == math.MinInt64, overflow occurs.
+		if val == math.MinInt64 {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *unaryMinusFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
@@ -234,13 +497,20 @@ func (c *unaryMinusFunctionClass) getFunction(ctx sessionctx.Context, args []Exp
 	switch argExprTp.EvalType() {
 	case types.ETInt:
 		if intOverflow {
-			panic("overflows int")
+			bf = newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal)
+			sig = &builtinUnaryMinusDecimalSig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_UnaryMinusDecimal)
 		} else {
 			bf = newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETInt)
 			sig = &builtinUnaryMinusIntSig{bf}
 			sig.setPbCode(tipb.ScalarFuncSig_UnaryMinusInt)
 		}
 		bf.tp.Decimal = 0
+	case types.ETDecimal:
+		bf = newBaseBuiltinFuncWithTp(ctx, args, types.ETDecimal, types.ETDecimal)
+		bf.tp.Decimal = argExprTp.Decimal
+		sig = &builtinUnaryMinusDecimalSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_UnaryMinusDecimal)
 	case types.ETReal:
 		bf = newBaseBuiltinFuncWithTp(ctx, args, types.ETReal, types.ETReal)
 		sig = &builtinUnaryMinusRealSig{bf}
@@ -284,6 +554,24 @@ func (b *builtinUnaryMinusIntSig) evalInt(row chunk.Row) (res int64, isNull bool
 	return -val, false, nil
 }
 
+type builtinUnaryMinusDecimalSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUnaryMinusDecimalSig) Clone() builtinFunc {
+	newSig := &builtinUnaryMinusDecimalSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinUnaryMinusDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	dec, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if err != nil || isNull {
+		return dec, isNull, err
+	}
+	return types.DecimalNeg(dec), false, nil
+}
+
 type builtinUnaryMinusRealSig struct {
 	baseBuiltinFunc
 }
@@ -318,9 +606,21 @@ func (c *isNullFunctionClass) getFunction(ctx sessionctx.Context, args []Express
 	case types.ETReal:
 		sig = &builtinRealIsNullSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_RealIsNull)
+	case types.ETDecimal:
+		sig = &builtinDecimalIsNullSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_DecimalIsNull)
 	case types.ETString:
 		sig = &builtinStringIsNullSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_StringIsNull)
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinTimeIsNullSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_TimeIsNull)
+	case types.ETDuration:
+		sig = &builtinDurationIsNullSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_DurationIsNull)
+	case types.ETJson:
+		sig = &builtinJSONIsNullSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_JsonIsNull)
 	default:
 		panic("unexpected types.EvalType")
 	}
@@ -367,6 +667,21 @@ func (b *builtinRealIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
 	return evalIsNull(isNull, err)
 }
 
+type builtinDecimalIsNullSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDecimalIsNullSig) Clone() builtinFunc {
+	newSig := &builtinDecimalIsNullSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinDecimalIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
+	_, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	return evalIsNull(isNull, err)
+}
+
 type builtinStringIsNullSig struct {
 	baseBuiltinFunc
 }
@@ -381,3 +696,48 @@ func (b *builtinStringIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
 	_, isNull, err := b.args[0].EvalString(b.ctx, row)
 	return evalIsNull(isNull, err)
 }
+
+type builtinTimeIsNullSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinTimeIsNullSig) Clone() builtinFunc {
+	newSig := &builtinTimeIsNullSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinTimeIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
+	_, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	return evalIsNull(isNull, err)
+}
+
+type builtinDurationIsNullSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDurationIsNullSig) Clone() builtinFunc {
+	newSig := &builtinDurationIsNullSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinDurationIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
+	_, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	return evalIsNull(isNull, err)
+}
+
+type builtinJSONIsNullSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONIsNullSig) Clone() builtinFunc {
+	newSig := &builtinJSONIsNullSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinJSONIsNullSig) evalInt(row chunk.Row) (int64, bool, error) {
+	_, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	return evalIsNull(isNull, err)
+}
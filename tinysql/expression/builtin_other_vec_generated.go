@@ -18,6 +18,7 @@ package expression
 import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 )
 
@@ -204,3 +205,231 @@ func (b *builtinInRealSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column)
 func (b *builtinInRealSig) vectorized() bool {
 	return true
 }
+
+func (b *builtinInDecimalSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf0, err := b.bufAllocator.get(types.ETDecimal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf0)
+	if err := b.args[0].VecEvalDecimal(b.ctx, input, buf0); err != nil {
+		return err
+	}
+	buf1, err := b.bufAllocator.get(types.ETDecimal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+
+	args0 := buf0.Decimals()
+	result.ResizeInt64(n, true)
+	r64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		r64s[i] = 0
+	}
+	hasNull := make([]bool, n)
+	var compareResult int
+
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalDecimal(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args1 := buf1.Decimals()
+		buf1.MergeNulls(buf0)
+		for i := 0; i < n; i++ {
+			if buf1.IsNull(i) {
+				hasNull[i] = true
+				continue
+			}
+			arg0 := args0[i]
+			arg1 := args1[i]
+			compareResult = 1
+			if arg0.Compare(&arg1) == 0 {
+				compareResult = 0
+			}
+			if compareResult == 0 {
+				result.SetNull(i, false)
+				r64s[i] = 1
+			}
+		} // for i
+	} // for j
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			result.SetNull(i, hasNull[i])
+		}
+	}
+	return nil
+}
+
+func (b *builtinInDecimalSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinInTimeSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf0, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf0)
+	if err := b.args[0].VecEvalTime(b.ctx, input, buf0); err != nil {
+		return err
+	}
+	buf1, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+
+	args0 := buf0.Times()
+	result.ResizeInt64(n, true)
+	r64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		r64s[i] = 0
+	}
+	hasNull := make([]bool, n)
+	var compareResult int
+
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalTime(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args1 := buf1.Times()
+		buf1.MergeNulls(buf0)
+		for i := 0; i < n; i++ {
+			if buf1.IsNull(i) {
+				hasNull[i] = true
+				continue
+			}
+			arg0 := args0[i]
+			arg1 := args1[i]
+			compareResult = arg0.Compare(arg1)
+			if compareResult == 0 {
+				result.SetNull(i, false)
+				r64s[i] = 1
+			}
+		} // for i
+	} // for j
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			result.SetNull(i, hasNull[i])
+		}
+	}
+	return nil
+}
+
+func (b *builtinInTimeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinInDurationSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf0, err := b.bufAllocator.get(types.ETDuration, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf0)
+	if err := b.args[0].VecEvalDuration(b.ctx, input, buf0); err != nil {
+		return err
+	}
+	buf1, err := b.bufAllocator.get(types.ETDuration, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+
+	args0 := buf0.GoDurations()
+	result.ResizeInt64(n, true)
+	r64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		r64s[i] = 0
+	}
+	hasNull := make([]bool, n)
+	var compareResult int
+
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalDuration(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		args1 := buf1.GoDurations()
+		buf1.MergeNulls(buf0)
+		for i := 0; i < n; i++ {
+			if buf1.IsNull(i) {
+				hasNull[i] = true
+				continue
+			}
+			arg0 := args0[i]
+			arg1 := args1[i]
+			compareResult = types.CompareDuration(arg0, arg1)
+			if compareResult == 0 {
+				result.SetNull(i, false)
+				r64s[i] = 1
+			}
+		} // for i
+	} // for j
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			result.SetNull(i, hasNull[i])
+		}
+	}
+	return nil
+}
+
+func (b *builtinInDurationSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinInJSONSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf0, err := b.bufAllocator.get(types.ETJson, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf0)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, buf0); err != nil {
+		return err
+	}
+	buf1, err := b.bufAllocator.get(types.ETJson, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+
+	result.ResizeInt64(n, true)
+	r64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		r64s[i] = 0
+	}
+	hasNull := make([]bool, n)
+	var compareResult int
+
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalJSON(b.ctx, input, buf1); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if buf1.IsNull(i) || buf0.IsNull(i) {
+				hasNull[i] = true
+				continue
+			}
+			arg0 := buf0.GetJSON(i)
+			arg1 := buf1.GetJSON(i)
+			compareResult = json.CompareBinary(arg0, arg1)
+			if compareResult == 0 {
+				result.SetNull(i, false)
+				r64s[i] = 1
+			}
+		} // for i
+	} // for j
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			result.SetNull(i, hasNull[i])
+		}
+	}
+	return nil
+}
+
+func (b *builtinInJSONSig) vectorized() bool {
+	return true
+}
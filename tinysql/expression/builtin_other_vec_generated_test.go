@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 )
 
 type inGener struct {
@@ -45,8 +47,20 @@ func (g inGener) gen() interface{} {
 			return -float64(randNum)
 		}
 		return float64(randNum)
+	case types.ETDecimal:
+		if rand.Float64() < 0.5 {
+			return types.NewDecFromInt(-randNum)
+		}
+		return types.NewDecFromInt(randNum)
 	case types.ETString:
 		return fmt.Sprint(randNum)
+	case types.ETDatetime, types.ETTimestamp:
+		gt := types.FromDate(2019, 11, 2, 22, 0, int(randNum), rand.Intn(1000000))
+		return types.NewTime(gt, convertETType(g.eType), 0)
+	case types.ETDuration:
+		return types.Duration{Duration: time.Duration(randNum)}
+	case types.ETJson:
+		return json.CreateBinary(randNum)
 	}
 	return randNum
 }
@@ -101,6 +115,70 @@ var vecBuiltinOtherGeneratedCases = map[string][]vecExprBenchCase{
 				inGener{defaultGener{eType: types.ETReal, nullRation: 0.2}},
 			},
 		},
+		// builtinInDecimalSig
+		{
+			retEvalType: types.ETInt,
+			childrenTypes: []types.EvalType{
+				types.ETDecimal,
+				types.ETDecimal,
+				types.ETDecimal,
+				types.ETDecimal,
+			},
+			geners: []dataGenerator{
+				inGener{defaultGener{eType: types.ETDecimal, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDecimal, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDecimal, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDecimal, nullRation: 0.2}},
+			},
+		},
+		// builtinInTimeSig
+		{
+			retEvalType: types.ETInt,
+			childrenTypes: []types.EvalType{
+				types.ETDatetime,
+				types.ETDatetime,
+				types.ETDatetime,
+				types.ETDatetime,
+			},
+			geners: []dataGenerator{
+				inGener{defaultGener{eType: types.ETDatetime, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDatetime, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDatetime, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDatetime, nullRation: 0.2}},
+			},
+		},
+		// builtinInDurationSig
+		{
+			retEvalType: types.ETInt,
+			childrenTypes: []types.EvalType{
+				types.ETDuration,
+				types.ETDuration,
+				types.ETDuration,
+				types.ETDuration,
+			},
+			geners: []dataGenerator{
+				inGener{defaultGener{eType: types.ETDuration, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDuration, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDuration, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETDuration, nullRation: 0.2}},
+			},
+		},
+		// builtinInJSONSig
+		{
+			retEvalType: types.ETInt,
+			childrenTypes: []types.EvalType{
+				types.ETJson,
+				types.ETJson,
+				types.ETJson,
+				types.ETJson,
+			},
+			geners: []dataGenerator{
+				inGener{defaultGener{eType: types.ETJson, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETJson, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETJson, nullRation: 0.2}},
+				inGener{defaultGener{eType: types.ETJson, nullRation: 0.2}},
+			},
+		},
 	},
 }
 
@@ -15,9 +15,14 @@ package expression
 
 import (
 	"fmt"
+	"math"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/stringutil"
@@ -110,3 +115,631 @@ func (s *testEvaluatorSuite) TestTFIDFScore(c *C) {
 	fmt.Println(stringutil.TFIDFScore("2022年4月23日，南京工程高等职业技术学校一学生被骗，嫌疑人通过微信冒充受害人同学，对方以为咖啡店充值返利5倍为由诱骗受害人使用用支付宝扫码的方式转账，后发现被骗，损失1200元",
 		"通知，为更好服务大学生高质量就业，助力县区经济和产业发展。今年新增直播荐岗县区专场，首场活动“百校千企万岗”2022年江苏省大学生就业帮扶“送岗直通车”直播荐岗活动南京六合（智能制造）专场线上直播时间为4月28日（明天）14:30开始，届时有15家优质企业提供约400个岗位，请2022届、2023届毕业生及时收看，详情参见江苏共青团微信推送。谢谢！"))
 }
+
+func (s *testEvaluatorSuite) TestConcat(c *C) {
+	cases := []struct {
+		args    []interface{}
+		isNil   bool
+		getErr  bool
+		res     string
+		retType *types.FieldType
+	}{
+		{
+			[]interface{}{nil},
+			true, false, "",
+			&types.FieldType{Tp: mysql.TypeVarString, Flen: 0, Decimal: types.UnspecifiedLength, Charset: charset.CharsetBin, Collate: charset.CollationBin, Flag: mysql.BinaryFlag},
+		},
+		{
+			[]interface{}{"a", "b", 1, 2, 1.1, 1.2},
+			false, false, "ab121.11.2",
+			&types.FieldType{Tp: mysql.TypeVarString, Flen: mysql.MaxBlobWidth, Decimal: types.UnspecifiedLength, Charset: charset.CharsetUTF8MB4, Collate: charset.CollationUTF8MB4, Flag: 0},
+		},
+		{
+			[]interface{}{"数据", "库"},
+			false, false, "数据库",
+			&types.FieldType{Tp: mysql.TypeVarString, Flen: 15, Decimal: types.UnspecifiedLength, Charset: charset.CharsetUTF8MB4, Collate: charset.CollationUTF8MB4, Flag: 0},
+		},
+		{
+			[]interface{}{"a", "b", nil, "c"},
+			true, false, "",
+			&types.FieldType{Tp: mysql.TypeVarString, Flen: 3, Decimal: types.UnspecifiedLength, Charset: charset.CharsetBin, Collate: charset.CollationBin, Flag: mysql.BinaryFlag},
+		},
+		{
+			[]interface{}{errors.New("must error")},
+			false, true, "",
+			&types.FieldType{Tp: mysql.TypeVarString, Flen: types.UnspecifiedLength, Decimal: types.UnspecifiedLength, Charset: charset.CharsetBin, Collate: charset.CollationBin, Flag: mysql.BinaryFlag},
+		},
+	}
+	fcName := ast.Concat
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		v, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(v.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(v.GetString(), Equals, t.res)
+			}
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestConcatWS(c *C) {
+	cases := []struct {
+		args     []interface{}
+		isNil    bool
+		getErr   bool
+		expected string
+	}{
+		{[]interface{}{nil, nil}, true, false, ""},
+		{[]interface{}{nil, "a", "b"}, true, false, ""},
+		{[]interface{}{",", "a", "b", "hello", "$^%"}, false, false, "a,b,hello,$^%"},
+		{[]interface{}{"|", "a", nil, "b"}, false, false, "a|b"},
+		{[]interface{}{",", "a", ",", "b", "c"}, false, false, "a,,,b,c"},
+		{[]interface{}{errors.New("must error"), "a", "b"}, false, true, ""},
+		{[]interface{}{",", "a", "b", 1, 2, 1.1, 0.11}, false, false, "a,b,1,2,1.1,0.11"},
+		{[]interface{}{"、", "数据库", "系统"}, false, false, "数据库、系统"},
+		{[]interface{}{",", nil}, false, false, ""},
+	}
+	fcName := ast.ConcatWS
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		val, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(val.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(val.GetString(), Equals, t.expected)
+			}
+		}
+	}
+
+	_, err := funcs[ast.ConcatWS].getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{nil}))
+	c.Assert(err, NotNil)
+}
+
+// binaryStrConstant builds a binary string constant, which makes the string
+// functions choose their byte based signatures.
+func binaryStrConstant(str string) *Constant {
+	tp := types.NewFieldType(mysql.TypeVarString)
+	types.SetBinChsClnFlag(tp)
+	return &Constant{Value: types.NewStringDatum(str), RetType: tp}
+}
+
+func (s *testEvaluatorSuite) TestSubstring(c *C) {
+	cases := []struct {
+		args   []interface{}
+		isNil  bool
+		getErr bool
+		res    string
+	}{
+		{[]interface{}{"Quadratically", 5}, false, false, "ratically"},
+		{[]interface{}{"Sakila", 1}, false, false, "Sakila"},
+		{[]interface{}{"Sakila", 2}, false, false, "akila"},
+		{[]interface{}{"Sakila", -3}, false, false, "ila"},
+		{[]interface{}{"Sakila", 0}, false, false, ""},
+		{[]interface{}{"Sakila", 100}, false, false, ""},
+		{[]interface{}{"Sakila", -100}, false, false, ""},
+		{[]interface{}{"Quadratically", 5, 6}, false, false, "ratica"},
+		{[]interface{}{"Sakila", -5, 3}, false, false, "aki"},
+		{[]interface{}{"Sakila", 2, 0}, false, false, ""},
+		{[]interface{}{"Sakila", 2, -1}, false, false, ""},
+		{[]interface{}{"Sakila", 2, 100}, false, false, "akila"},
+		{[]interface{}{"Sakila", 2, math.MaxInt64}, false, false, "akila"},
+		{[]interface{}{"中文字符串", 2}, false, false, "文字符串"},
+		{[]interface{}{"中文字符串", -2}, false, false, "符串"},
+		{[]interface{}{"中文字符串", 2, 2}, false, false, "文字"},
+		{[]interface{}{"中文字符串", -4, 2}, false, false, "文字"},
+		{[]interface{}{nil, 2}, true, false, ""},
+		{[]interface{}{"Sakila", nil}, true, false, ""},
+		{[]interface{}{"Sakila", 2, nil}, true, false, ""},
+		{[]interface{}{errors.New("must error"), 2}, false, true, ""},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Substring, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		v, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(v.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(v.GetString(), Equals, t.res)
+			}
+		}
+	}
+
+	// Substrings of binary strings are counted in bytes.
+	f, err := newFunctionForTest(s.ctx, ast.Substr, binaryStrConstant("中文"), One, One)
+	c.Assert(err, IsNil)
+	v, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetString(), Equals, "\xe4")
+
+	_, err = funcs[ast.Mid].getFunction(s.ctx, s.primitiveValsToConstants([]interface{}{"Sakila", 2}))
+	c.Assert(err, NotNil)
+}
+
+func (s *testEvaluatorSuite) TestLeftRight(c *C) {
+	cases := []struct {
+		args  []interface{}
+		isNil bool
+		left  string
+		right string
+	}{
+		{[]interface{}{"foobarbar", 5}, false, "fooba", "arbar"},
+		{[]interface{}{"foobarbar", 0}, false, "", ""},
+		{[]interface{}{"foobarbar", -1}, false, "", ""},
+		{[]interface{}{"foobarbar", 100}, false, "foobarbar", "foobarbar"},
+		{[]interface{}{"数据库系统", 2}, false, "数据", "系统"},
+		{[]interface{}{1234, 3}, false, "123", "234"},
+		{[]interface{}{"foobarbar", "3"}, false, "foo", "bar"},
+		{[]interface{}{nil, 2}, true, "", ""},
+		{[]interface{}{"foobarbar", nil}, true, "", ""},
+	}
+	for _, t := range cases {
+		for _, fcName := range []string{ast.Left, ast.Right} {
+			f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants(t.args)...)
+			c.Assert(err, IsNil)
+			v, err := f.Eval(chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(v.Kind(), Equals, types.KindNull)
+			} else if fcName == ast.Left {
+				c.Assert(v.GetString(), Equals, t.left)
+			} else {
+				c.Assert(v.GetString(), Equals, t.right)
+			}
+		}
+	}
+
+	f, err := newFunctionForTest(s.ctx, ast.Left, binaryStrConstant("数据"), One)
+	c.Assert(err, IsNil)
+	v, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetString(), Equals, "\xe6")
+}
+
+func (s *testEvaluatorSuite) TestUpperLower(c *C) {
+	cases := []struct {
+		args  interface{}
+		isNil bool
+		upper string
+		lower string
+	}{
+		{"abc", false, "ABC", "abc"},
+		{"aBc中文", false, "ABC中文", "abc中文"},
+		{"ÀÉ", false, "ÀÉ", "àé"},
+		{1, false, "1", "1"},
+		{nil, true, "", ""},
+	}
+	for _, t := range cases {
+		for _, fcName := range []string{ast.Upper, ast.Ucase, ast.Lower, ast.Lcase} {
+			f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants([]interface{}{t.args})...)
+			c.Assert(err, IsNil)
+			v, err := f.Eval(chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(v.Kind(), Equals, types.KindNull)
+			} else if fcName == ast.Upper || fcName == ast.Ucase {
+				c.Assert(v.GetString(), Equals, t.upper)
+			} else {
+				c.Assert(v.GetString(), Equals, t.lower)
+			}
+		}
+	}
+
+	// UPPER() and LOWER() are ineffective when applied to binary strings.
+	for _, fcName := range []string{ast.Upper, ast.Lower} {
+		f, err := newFunctionForTest(s.ctx, fcName, binaryStrConstant("aBc"))
+		c.Assert(err, IsNil)
+		v, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v.GetString(), Equals, "aBc")
+	}
+}
+
+func (s *testEvaluatorSuite) TestTrim(c *C) {
+	cases := []struct {
+		args   []interface{}
+		isNil  bool
+		getErr bool
+		res    string
+	}{
+		{[]interface{}{"   bar   "}, false, false, "bar"},
+		{[]interface{}{"\t   bar   \n"}, false, false, "\t   bar   \n"},
+		{[]interface{}{"\r   bar   \t"}, false, false, "\r   bar   \t"},
+		{[]interface{}{"   \tbar\n     "}, false, false, "\tbar\n"},
+		{[]interface{}{""}, false, false, ""},
+		{[]interface{}{nil}, true, false, ""},
+		{[]interface{}{"xxxbarxxx", "x"}, false, false, "bar"},
+		{[]interface{}{"bar", "x"}, false, false, "bar"},
+		{[]interface{}{"   bar   ", ""}, false, false, "   bar   "},
+		{[]interface{}{"", "x"}, false, false, ""},
+		{[]interface{}{"xyzbarxyz", "xyz"}, false, false, "bar"},
+		{[]interface{}{"数据数据库数据", "数据"}, false, false, "库"},
+		{[]interface{}{"xxxbarxxx", "x", int(ast.TrimLeading)}, false, false, "barxxx"},
+		{[]interface{}{"barxxyz", "xyz", int(ast.TrimTrailing)}, false, false, "barx"},
+		{[]interface{}{"xxxbarxxx", "x", int(ast.TrimBoth)}, false, false, "bar"},
+		{[]interface{}{"  bar  ", " ", int(ast.TrimLeading)}, false, false, "bar  "},
+		{[]interface{}{nil, "x", int(ast.TrimBoth)}, true, false, ""},
+		{[]interface{}{"bar", nil}, true, false, ""},
+		{[]interface{}{errors.New("must error")}, false, true, ""},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Trim, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(d.GetString(), Equals, t.res)
+			}
+		}
+	}
+
+	_, err := funcs[ast.Trim].getFunction(s.ctx, []Expression{Zero, Zero, Zero, Zero})
+	c.Assert(err, NotNil)
+}
+
+func (s *testEvaluatorSuite) TestLTrimRTrim(c *C) {
+	cases := []struct {
+		arg   interface{}
+		isNil bool
+		ltrim string
+		rtrim string
+	}{
+		{"   bar   ", false, "bar   ", "   bar"},
+		{"\t   bar   ", false, "\t   bar   ", "\t   bar"},
+		{"   数据库   ", false, "数据库   ", "   数据库"},
+		{"", false, "", ""},
+		{nil, true, "", ""},
+	}
+	for _, t := range cases {
+		for _, fcName := range []string{ast.LTrim, ast.RTrim} {
+			f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants([]interface{}{t.arg})...)
+			c.Assert(err, IsNil)
+			d, err := f.Eval(chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else if fcName == ast.LTrim {
+				c.Assert(d.GetString(), Equals, t.ltrim)
+			} else {
+				c.Assert(d.GetString(), Equals, t.rtrim)
+			}
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestReplace(c *C) {
+	cases := []struct {
+		args   []interface{}
+		isNil  bool
+		getErr bool
+		res    string
+		flen   int
+	}{
+		{[]interface{}{"www.mysql.com", "mysql", "pingcap"}, false, false, "www.pingcap.com", 17},
+		{[]interface{}{"www.mysql.com", "w", 1}, false, false, "111.mysql.com", 260},
+		{[]interface{}{1234, 2, 55}, false, false, "15534", 20},
+		{[]interface{}{"", "a", "b"}, false, false, "", 0},
+		{[]interface{}{"abc", "", "d"}, false, false, "abc", 3},
+		{[]interface{}{"数据库数据", "数据", "data"}, false, false, "data库data", 15},
+		{[]interface{}{"aaa", "a", ""}, false, false, "", 3},
+		{[]interface{}{nil, "a", "b"}, true, false, "", 0},
+		{[]interface{}{"a", nil, "b"}, true, false, "", 1},
+		{[]interface{}{"a", "b", nil}, true, false, "", 1},
+		{[]interface{}{errors.New("must error"), "a", "b"}, false, true, "", -1},
+	}
+	for i, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Replace, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		c.Assert(f.GetType().Flen, Equals, t.flen, Commentf("case #%d", i))
+		d, err := f.Eval(chunk.Row{})
+		if t.getErr {
+			c.Assert(err, NotNil)
+		} else {
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(d.GetString(), Equals, t.res)
+			}
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestLocateAndInstr(c *C) {
+	cases := []struct {
+		args  []interface{}
+		isNil bool
+		res   int64
+	}{
+		{[]interface{}{"bar", "foobarbar"}, false, 4},
+		{[]interface{}{"xbar", "foobar"}, false, 0},
+		{[]interface{}{"", "foobar"}, false, 1},
+		{[]interface{}{"foobar", ""}, false, 0},
+		{[]interface{}{"", ""}, false, 1},
+		{[]interface{}{"BaR", "foobArbar"}, false, 4},
+		{[]interface{}{"库", "数据库数据库"}, false, 3},
+		{[]interface{}{"bar", "foobarbar", 5}, false, 7},
+		{[]interface{}{"bar", "foobarbar", 0}, false, 0},
+		{[]interface{}{"bar", "foobarbar", -1}, false, 0},
+		{[]interface{}{"", "foobarbar", 10}, false, 10},
+		{[]interface{}{"", "foobarbar", 11}, false, 0},
+		{[]interface{}{"库", "数据库数据库", 4}, false, 6},
+		{[]interface{}{nil, "foobarbar"}, true, 0},
+		{[]interface{}{"bar", nil}, true, 0},
+		{[]interface{}{"bar", "foobarbar", nil}, true, 0},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Locate, s.primitiveValsToConstants(t.args)...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetInt64(), Equals, t.res)
+		}
+
+		// INSTR(str,substr) is the same as the two-argument form of LOCATE(), except that the order of the arguments is reversed.
+		// POSITION(substr IN str) is a synonym for LOCATE(substr,str).
+		if len(t.args) == 2 {
+			for _, fcName := range []string{ast.Instr, ast.Position} {
+				args := t.args
+				if fcName == ast.Instr {
+					args = []interface{}{t.args[1], t.args[0]}
+				}
+				f, err = newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants(args)...)
+				c.Assert(err, IsNil)
+				d, err = f.Eval(chunk.Row{})
+				c.Assert(err, IsNil)
+				if t.isNil {
+					c.Assert(d.Kind(), Equals, types.KindNull)
+				} else {
+					c.Assert(d.GetInt64(), Equals, t.res)
+				}
+			}
+		}
+	}
+
+	// LOCATE() is case-sensitive and counts bytes for binary strings.
+	f, err := newFunctionForTest(s.ctx, ast.Locate, binaryStrConstant("BaR"), binaryStrConstant("foobarbar"))
+	c.Assert(err, IsNil)
+	d, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetInt64(), Equals, int64(0))
+	f, err = newFunctionForTest(s.ctx, ast.Instr, binaryStrConstant("数据库"), binaryStrConstant("库"))
+	c.Assert(err, IsNil)
+	d, err = f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetInt64(), Equals, int64(7))
+}
+
+func (s *testEvaluatorSuite) TestLpadRpad(c *C) {
+	cases := []struct {
+		str    interface{}
+		len    interface{}
+		padStr interface{}
+		isNil  bool
+		lpad   string
+		rpad   string
+	}{
+		{"hi", 5, "?", false, "???hi", "hi???"},
+		{"hi", 1, "?", false, "h", "h"},
+		{"hi", 0, "?", false, "", ""},
+		{"hi", -1, "?", true, "", ""},
+		{"hi", 1, "", false, "h", "h"},
+		{"hi", 5, "", true, "", ""},
+		{"hi", 5, "ab", false, "abahi", "hiaba"},
+		{"hi", 6, "ab", false, "ababhi", "hiabab"},
+		{"数据", 5, "库", false, "库库库数据", "数据库库库"},
+		{"数据库", 2, "?", false, "数据", "数据"},
+		{nil, 5, "?", true, "", ""},
+		{"hi", nil, "?", true, "", ""},
+		{"hi", 5, nil, true, "", ""},
+	}
+	for _, t := range cases {
+		for _, fcName := range []string{ast.Lpad, ast.Rpad} {
+			args := s.primitiveValsToConstants([]interface{}{t.str, t.len, t.padStr})
+			f, err := newFunctionForTest(s.ctx, fcName, args...)
+			c.Assert(err, IsNil)
+			d, err := f.Eval(chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else if fcName == ast.Lpad {
+				c.Assert(d.GetString(), Equals, t.lpad)
+			} else {
+				c.Assert(d.GetString(), Equals, t.rpad)
+			}
+		}
+	}
+
+	// Binary strings are padded in bytes.
+	f, err := newFunctionForTest(s.ctx, ast.Lpad, binaryStrConstant("数据"), One, binaryStrConstant("?"))
+	c.Assert(err, IsNil)
+	d, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, "\xe6")
+
+	// The result exceeding max_allowed_packet is NULL with a warning.
+	sc := s.ctx.GetSessionVars().StmtCtx
+	warnCount := len(sc.GetWarnings())
+	f, err = newFunctionForTest(s.ctx, ast.Rpad, s.primitiveValsToConstants([]interface{}{"hi", 1 << 30, "?"})...)
+	c.Assert(err, IsNil)
+	d, err = f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.Kind(), Equals, types.KindNull)
+	warnings := sc.GetWarnings()
+	c.Assert(len(warnings), Equals, warnCount+1)
+	c.Assert(terror.ErrorEqual(errWarnAllowedPacketOverflowed, warnings[len(warnings)-1].Err), IsTrue)
+}
+
+func (s *testEvaluatorSuite) TestReverse(c *C) {
+	cases := []struct {
+		arg   interface{}
+		isNil bool
+		res   string
+	}{
+		{"abc", false, "cba"},
+		{"LIKE", false, "EKIL"},
+		{123, false, "321"},
+		{"数据库", false, "库据数"},
+		{"", false, ""},
+		{nil, true, ""},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.Reverse, s.primitiveValsToConstants([]interface{}{t.arg})...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetString(), Equals, t.res)
+		}
+	}
+
+	f, err := newFunctionForTest(s.ctx, ast.Reverse, binaryStrConstant("\x01\x02\x03"))
+	c.Assert(err, IsNil)
+	d, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetString(), Equals, "\x03\x02\x01")
+}
+
+func (s *testEvaluatorSuite) TestCharLength(c *C) {
+	cases := []struct {
+		arg   interface{}
+		isNil bool
+		res   int64
+	}{
+		{"abc", false, 3},
+		{"你好", false, 2},
+		{"数据库 TiDB", false, 8},
+		{1, false, 1},
+		{3.14, false, 4},
+		{"", false, 0},
+		{nil, true, 0},
+	}
+	for _, t := range cases {
+		for _, fcName := range []string{ast.CharLength, ast.CharacterLength} {
+			f, err := newFunctionForTest(s.ctx, fcName, s.primitiveValsToConstants([]interface{}{t.arg})...)
+			c.Assert(err, IsNil)
+			d, err := f.Eval(chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.isNil {
+				c.Assert(d.Kind(), Equals, types.KindNull)
+			} else {
+				c.Assert(d.GetInt64(), Equals, t.res)
+			}
+		}
+	}
+
+	// CHAR_LENGTH() of a binary string counts bytes.
+	f, err := newFunctionForTest(s.ctx, ast.CharLength, binaryStrConstant("你好"))
+	c.Assert(err, IsNil)
+	d, err := f.Eval(chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(d.GetInt64(), Equals, int64(6))
+}
+
+func (s *testEvaluatorSuite) TestHexAndUnhex(c *C) {
+	hexCases := []struct {
+		arg   interface{}
+		isNil bool
+		res   string
+	}{
+		{"abc", false, "616263"},
+		{"你好", false, "E4BDA0E5A5BD"},
+		{"", false, ""},
+		{255, false, "FF"},
+		{-1, false, "FFFFFFFFFFFFFFFF"},
+		{1.5, false, "2"},
+		{nil, true, ""},
+	}
+	for _, t := range hexCases {
+		f, err := newFunctionForTest(s.ctx, ast.Hex, s.primitiveValsToConstants([]interface{}{t.arg})...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetString(), Equals, t.res)
+		}
+	}
+
+	unhexCases := []struct {
+		arg   interface{}
+		isNil bool
+		res   string
+	}{
+		{"4D7953514C", false, "MySQL"},
+		{"e4bda0e5a5bd", false, "你好"},
+		{"1267", false, string([]byte{0x12, 0x67})},
+		{"126", false, string([]byte{0x01, 0x26})},
+		{"", false, ""},
+		{1267, false, string([]byte{0x12, 0x67})},
+		{"string", true, ""},
+		{nil, true, ""},
+	}
+	for _, t := range unhexCases {
+		f, err := newFunctionForTest(s.ctx, ast.Unhex, s.primitiveValsToConstants([]interface{}{t.arg})...)
+		c.Assert(err, IsNil)
+		c.Assert(types.IsBinaryStr(f.GetType()), IsTrue)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetString(), Equals, t.res)
+		}
+	}
+}
+
+func (s *testEvaluatorSuite) TestFindInSet(c *C) {
+	cases := []struct {
+		str    interface{}
+		strlst interface{}
+		isNil  bool
+		res    int64
+	}{
+		{"foo", "foo,bar", false, 1},
+		{"foo", "foobar,bar", false, 0},
+		{" foo ", "foo, foo ", false, 2},
+		{"", "foo,bar,", false, 3},
+		{"", "", false, 0},
+		{"数据库", "数据,数据库", false, 2},
+		{1, "1,2", false, 1},
+		{nil, "foo,bar", true, 0},
+		{"foo", nil, true, 0},
+	}
+	for _, t := range cases {
+		f, err := newFunctionForTest(s.ctx, ast.FindInSet, s.primitiveValsToConstants([]interface{}{t.str, t.strlst})...)
+		c.Assert(err, IsNil)
+		d, err := f.Eval(chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.isNil {
+			c.Assert(d.Kind(), Equals, types.KindNull)
+		} else {
+			c.Assert(d.GetInt64(), Equals, t.res)
+		}
+	}
+}
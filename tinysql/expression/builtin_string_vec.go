@@ -14,8 +14,15 @@
 package expression
 
 import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
 )
 
 func (b *builtinStringIsNullSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
@@ -109,3 +116,761 @@ func (b *builtinLengthSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column)
 	}
 	return nil
 }
+
+func (b *builtinConcatSig) vectorized() bool {
+	return true
+}
+
+// vecEvalString evals a CONCAT(str1,str2,...)
+// See https://dev.mysql.com/doc/refman/5.7/en/string-functions.html#function_concat
+func (b *builtinConcatSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+
+	strs := make([][]byte, n)
+	isNulls := make([]bool, n)
+	for j := 0; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if isNulls[i] {
+				continue
+			}
+			if buf.IsNull(i) {
+				isNulls[i] = true
+				continue
+			}
+			strs[i] = append(strs[i], buf.GetBytes(i)...)
+		}
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if isNulls[i] {
+			result.AppendNull()
+		} else {
+			result.AppendBytes(strs[i])
+		}
+	}
+	return nil
+}
+
+func (b *builtinConcatWSSig) vectorized() bool {
+	return true
+}
+
+// vecEvalString evals a CONCAT_WS(separator,str1,str2,...).
+// See https://dev.mysql.com/doc/refman/5.7/en/string-functions.html#function_concat-ws
+func (b *builtinConcatWSSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	sepBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(sepBuf)
+	if err := b.args[0].VecEvalString(b.ctx, input, sepBuf); err != nil {
+		return err
+	}
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+
+	strs := make([][]string, n)
+	for j := 1; j < len(b.args); j++ {
+		if err := b.args[j].VecEvalString(b.ctx, input, buf); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			// CONCAT_WS() skips any NULL values after the separator argument.
+			if sepBuf.IsNull(i) || buf.IsNull(i) {
+				continue
+			}
+			// Copy the string since buf is reused by the next argument.
+			strs[i] = append(strs[i], string(buf.GetBytes(i)))
+		}
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if sepBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(strings.Join(strs[i], sepBuf.GetString(i)))
+	}
+	return nil
+}
+
+// vecEvalStringAndInts evaluates the string argument and the following integer
+// arguments of a string function into the buffers allocated by b.bufAllocator.
+// The caller must put the returned buffers back by putStringAndInts.
+func (b *baseBuiltinFunc) vecEvalStringAndInts(input *chunk.Chunk) (*chunk.Column, []*chunk.Column, error) {
+	n := input.NumRows()
+	strBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	intBufs := make([]*chunk.Column, 0, len(b.args)-1)
+	if err := b.args[0].VecEvalString(b.ctx, input, strBuf); err != nil {
+		b.putStringAndInts(strBuf, intBufs)
+		return nil, nil, err
+	}
+	for _, arg := range b.args[1:] {
+		buf, err := b.bufAllocator.get(types.ETInt, n)
+		if err != nil {
+			b.putStringAndInts(strBuf, intBufs)
+			return nil, nil, err
+		}
+		intBufs = append(intBufs, buf)
+		if err := arg.VecEvalInt(b.ctx, input, buf); err != nil {
+			b.putStringAndInts(strBuf, intBufs)
+			return nil, nil, err
+		}
+	}
+	return strBuf, intBufs, nil
+}
+
+// putStringAndInts puts the buffers returned by vecEvalStringAndInts back.
+func (b *baseBuiltinFunc) putStringAndInts(strBuf *chunk.Column, intBufs []*chunk.Column) {
+	b.bufAllocator.put(strBuf)
+	b.putBufs(intBufs)
+}
+
+// vecEvalSubstring evaluates SUBSTRING with two or three arguments, counting
+// characters when isUTF8 is true and bytes otherwise.
+func (b *baseBuiltinFunc) vecEvalSubstring(input *chunk.Chunk, result *chunk.Column, isUTF8 bool) error {
+	n := input.NumRows()
+	strBuf, intBufs, err := b.vecEvalStringAndInts(input)
+	if err != nil {
+		return err
+	}
+	defer b.putStringAndInts(strBuf, intBufs)
+
+	result.ReserveString(n)
+	positions := intBufs[0].Int64s()
+	for i := 0; i < n; i++ {
+		if strBuf.IsNull(i) || intBufs[0].IsNull(i) || (len(intBufs) == 2 && intBufs[1].IsNull(i)) {
+			result.AppendNull()
+			continue
+		}
+		str := strBuf.GetString(i)
+		if isUTF8 {
+			runes := []rune(str)
+			length := int64(len(runes))
+			if len(intBufs) == 2 {
+				length = intBufs[1].GetInt64(i)
+			}
+			begin, end := getSubstringRange(int64(len(runes)), positions[i], length)
+			result.AppendString(string(runes[begin:end]))
+			continue
+		}
+		length := int64(len(str))
+		if len(intBufs) == 2 {
+			length = intBufs[1].GetInt64(i)
+		}
+		begin, end := getSubstringRange(int64(len(str)), positions[i], length)
+		result.AppendString(str[begin:end])
+	}
+	return nil
+}
+
+func (b *builtinSubstring2ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubstring2ArgsSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalSubstring(input, result, false)
+}
+
+func (b *builtinSubstring2ArgsUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubstring2ArgsUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalSubstring(input, result, true)
+}
+
+func (b *builtinSubstring3ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubstring3ArgsSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalSubstring(input, result, false)
+}
+
+func (b *builtinSubstring3ArgsUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubstring3ArgsUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalSubstring(input, result, true)
+}
+
+// vecEvalLeftOrRight evaluates LEFT and RIGHT, counting characters when isUTF8
+// is true and bytes otherwise.
+func (b *baseBuiltinFunc) vecEvalLeftOrRight(input *chunk.Chunk, result *chunk.Column, left, isUTF8 bool) error {
+	n := input.NumRows()
+	strBuf, intBufs, err := b.vecEvalStringAndInts(input)
+	if err != nil {
+		return err
+	}
+	defer b.putStringAndInts(strBuf, intBufs)
+
+	result.ReserveString(n)
+	lengths := intBufs[0].Int64s()
+	for i := 0; i < n; i++ {
+		if strBuf.IsNull(i) || intBufs[0].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		str := strBuf.GetString(i)
+		switch {
+		case isUTF8 && left:
+			runes := []rune(str)
+			result.AppendString(string(runes[:getPrefixLength(len(runes), lengths[i])]))
+		case isUTF8:
+			runes := []rune(str)
+			result.AppendString(string(runes[len(runes)-getPrefixLength(len(runes), lengths[i]):]))
+		case left:
+			result.AppendString(str[:getPrefixLength(len(str), lengths[i])])
+		default:
+			result.AppendString(str[len(str)-getPrefixLength(len(str), lengths[i]):])
+		}
+	}
+	return nil
+}
+
+func (b *builtinLeftSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeftSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalLeftOrRight(input, result, true, false)
+}
+
+func (b *builtinLeftUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLeftUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalLeftOrRight(input, result, true, true)
+}
+
+func (b *builtinRightSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRightSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalLeftOrRight(input, result, false, false)
+}
+
+func (b *builtinRightUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRightUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalLeftOrRight(input, result, false, true)
+}
+
+// vecTransformString evaluates the only string argument of b and applies fn to every non-NULL value.
+func (b *baseBuiltinFunc) vecTransformString(input *chunk.Chunk, result *chunk.Column, fn func(string) string) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(fn(buf.GetString(i)))
+	}
+	return nil
+}
+
+func (b *builtinUpperSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUpperSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.args[0].VecEvalString(b.ctx, input, result)
+}
+
+func (b *builtinUpperUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUpperUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, strings.ToUpper)
+}
+
+func (b *builtinLowerSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLowerSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	if types.IsBinaryStr(b.args[0].GetType()) {
+		return b.args[0].VecEvalString(b.ctx, input, result)
+	}
+	return b.vecTransformString(input, result, strings.ToLower)
+}
+
+func (b *builtinTrim1ArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinTrim1ArgSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return strings.Trim(str, spaceChars)
+	})
+}
+
+func (b *builtinLTrimSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLTrimSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return strings.TrimLeft(str, spaceChars)
+	})
+}
+
+func (b *builtinRTrimSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRTrimSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return strings.TrimRight(str, spaceChars)
+	})
+}
+
+func (b *builtinReverseSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinReverseSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return string(reverseBytes([]byte(str)))
+	})
+}
+
+func (b *builtinReverseUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinReverseUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return string(reverseRunes([]rune(str)))
+	})
+}
+
+func (b *builtinHexStrArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinHexStrArgSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecTransformString(input, result, func(str string) string {
+		return strings.ToUpper(hex.EncodeToString(hack.Slice(str)))
+	})
+}
+
+func (b *builtinHexIntArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinHexIntArgSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalInt(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	i64s := buf.Int64s()
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(strings.ToUpper(strconv.FormatUint(uint64(i64s[i]), 16)))
+	}
+	return nil
+}
+
+func (b *builtinUnHexSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUnHexSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		res, isNull := unhexString(buf.GetString(i))
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+// vecEvalStrings evaluates all the string arguments of b into the buffers allocated
+// by b.bufAllocator. The caller must put the returned buffers back.
+func (b *baseBuiltinFunc) vecEvalStrings(input *chunk.Chunk) ([]*chunk.Column, error) {
+	n := input.NumRows()
+	bufs := make([]*chunk.Column, 0, len(b.args))
+	for _, arg := range b.args {
+		buf, err := b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			b.putBufs(bufs)
+			return nil, err
+		}
+		bufs = append(bufs, buf)
+		if err := arg.VecEvalString(b.ctx, input, buf); err != nil {
+			b.putBufs(bufs)
+			return nil, err
+		}
+	}
+	return bufs, nil
+}
+
+// putBufs puts the buffers back to b.bufAllocator.
+func (b *baseBuiltinFunc) putBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		b.bufAllocator.put(buf)
+	}
+}
+
+func (b *builtinTrim2ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinTrim2ArgsSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalStrings(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) || bufs[1].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(trimString(bufs[0].GetString(i), bufs[1].GetString(i), ast.TrimBothDefault))
+	}
+	return nil
+}
+
+func (b *builtinTrim3ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinTrim3ArgsSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	strBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(strBuf)
+	if err := b.args[0].VecEvalString(b.ctx, input, strBuf); err != nil {
+		return err
+	}
+	remBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(remBuf)
+	if err := b.args[1].VecEvalString(b.ctx, input, remBuf); err != nil {
+		return err
+	}
+	dirBuf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(dirBuf)
+	if err := b.args[2].VecEvalInt(b.ctx, input, dirBuf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	directions := dirBuf.Int64s()
+	for i := 0; i < n; i++ {
+		if strBuf.IsNull(i) || remBuf.IsNull(i) || dirBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(trimString(strBuf.GetString(i), remBuf.GetString(i), ast.TrimDirectionType(directions[i])))
+	}
+	return nil
+}
+
+func (b *builtinReplaceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinReplaceSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, err := b.vecEvalStrings(input)
+	if err != nil {
+		return err
+	}
+	defer b.putBufs(bufs)
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if bufs[0].IsNull(i) || bufs[1].IsNull(i) || bufs[2].IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		str, oldStr, newStr := bufs[0].GetString(i), bufs[1].GetString(i), bufs[2].GetString(i)
+		if oldStr == "" {
+			result.AppendString(str)
+			continue
+		}
+		result.AppendString(strings.Replace(str, oldStr, newStr, -1))
+	}
+	return nil
+}
+
+// vecEvalStringsToInt evaluates the first numStrs string arguments of b and the optional
+// trailing integer argument, then calls fn for every row whose arguments are all non-NULL.
+func (b *baseBuiltinFunc) vecEvalStringsToInt(input *chunk.Chunk, result *chunk.Column, numStrs int, fn func(strs []string, pos int64) int64) error {
+	n := input.NumRows()
+	bufs := make([]*chunk.Column, 0, len(b.args))
+	defer func() {
+		b.putBufs(bufs)
+	}()
+	for j, arg := range b.args {
+		tp := types.ETString
+		if j >= numStrs {
+			tp = types.ETInt
+		}
+		buf, err := b.bufAllocator.get(tp, n)
+		if err != nil {
+			return err
+		}
+		bufs = append(bufs, buf)
+		if tp == types.ETInt {
+			err = arg.VecEvalInt(b.ctx, input, buf)
+		} else {
+			err = arg.VecEvalString(b.ctx, input, buf)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	strs := make([]string, numStrs)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		for j := 0; j < numStrs; j++ {
+			strs[j] = bufs[j].GetString(i)
+		}
+		var pos int64
+		if len(bufs) > numStrs {
+			pos = bufs[numStrs].GetInt64(i)
+		}
+		i64s[i] = fn(strs, pos)
+	}
+	return nil
+}
+
+func (b *builtinLocate2ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLocate2ArgsSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, _ int64) int64 {
+		return locateSubstr(strs[1], strs[0], 0)
+	})
+}
+
+func (b *builtinLocate2ArgsUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLocate2ArgsUTF8Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, _ int64) int64 {
+		return locateSubstrUTF8(strs[1], strs[0], 0)
+	})
+}
+
+func (b *builtinLocate3ArgsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLocate3ArgsSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, pos int64) int64 {
+		return locateSubstr(strs[1], strs[0], pos-1)
+	})
+}
+
+func (b *builtinLocate3ArgsUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLocate3ArgsUTF8Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, pos int64) int64 {
+		return locateSubstrUTF8(strs[1], strs[0], pos-1)
+	})
+}
+
+func (b *builtinInstrSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinInstrSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, _ int64) int64 {
+		return locateSubstr(strs[0], strs[1], 0)
+	})
+}
+
+func (b *builtinInstrUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinInstrUTF8Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, _ int64) int64 {
+		return locateSubstrUTF8(strs[0], strs[1], 0)
+	})
+}
+
+func (b *builtinFindInSetSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinFindInSetSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 2, func(strs []string, _ int64) int64 {
+		return findInSet(strs[0], strs[1])
+	})
+}
+
+func (b *builtinCharLengthBinarySig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCharLengthBinarySig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 1, func(strs []string, _ int64) int64 {
+		return int64(len(strs[0]))
+	})
+}
+
+func (b *builtinCharLengthUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCharLengthUTF8Sig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalStringsToInt(input, result, 1, func(strs []string, _ int64) int64 {
+		return int64(utf8.RuneCountInString(strs[0]))
+	})
+}
+
+// vecEvalPad evaluates LPAD and RPAD, counting characters when isUTF8 is true and bytes otherwise.
+func (b *baseBuiltinFunc) vecEvalPad(input *chunk.Chunk, result *chunk.Column, funcName string, maxAllowedPacket uint64, left, isUTF8 bool) error {
+	n := input.NumRows()
+	strBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(strBuf)
+	if err := b.args[0].VecEvalString(b.ctx, input, strBuf); err != nil {
+		return err
+	}
+	lenBuf, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(lenBuf)
+	if err := b.args[1].VecEvalInt(b.ctx, input, lenBuf); err != nil {
+		return err
+	}
+	padBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(padBuf)
+	if err := b.args[2].VecEvalString(b.ctx, input, padBuf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	lengths := lenBuf.Int64s()
+	for i := 0; i < n; i++ {
+		if strBuf.IsNull(i) || lenBuf.IsNull(i) || padBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		res, isNull := b.pad(strBuf.GetString(i), padBuf.GetString(i), lengths[i], funcName, maxAllowedPacket, left, isUTF8)
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinLpadSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLpadSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalPad(input, result, "lpad", b.maxAllowedPacket, true, false)
+}
+
+func (b *builtinLpadUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinLpadUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalPad(input, result, "lpad", b.maxAllowedPacket, true, true)
+}
+
+func (b *builtinRpadSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRpadSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalPad(input, result, "rpad", b.maxAllowedPacket, false, false)
+}
+
+func (b *builtinRpadUTF8Sig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRpadUTF8Sig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalPad(input, result, "rpad", b.maxAllowedPacket, false, true)
+}
@@ -18,10 +18,105 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
 )
 
+var (
+	binaryStringTp = func() *types.FieldType {
+		tp := types.NewFieldType(mysql.TypeVarString)
+		types.SetBinChsClnFlag(tp)
+		return tp
+	}()
+	utf8StrGener = &selectStringGener{
+		candidates: []string{"", " ", "abc", "ABCabc", "  a b  ", "数据库", "数据库系统概念", "TiDB 数据库", "ab,数据库,c", "xxabcxx"},
+	}
+)
+
 var vecBuiltinStringCases = map[string][]vecExprBenchCase{
+	ast.Concat: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{utf8StrGener}},
+	},
+	ast.ConcatWS: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{&selectStringGener{candidates: []string{",", "--", ""}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}},
+	},
+	ast.Substring: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETInt}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETInt}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETInt}},
+	},
+	ast.Left: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+	},
+	ast.Right: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-10, 10}}},
+	},
+	ast.Upper: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}},
+	},
+	ast.Lower: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}},
+	},
+	ast.Trim: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: []dataGenerator{utf8StrGener, &selectStringGener{candidates: []string{"x", "a", " ", ""}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETInt}, geners: []dataGenerator{utf8StrGener, &selectStringGener{candidates: []string{"x", "a", " "}}, &rangeInt64Gener{0, 4}}},
+	},
+	ast.LTrim: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+	},
+	ast.RTrim: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+	},
+	ast.Replace: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString}, geners: []dataGenerator{utf8StrGener, &selectStringGener{candidates: []string{"a", "数据", " ", ""}}, &selectStringGener{candidates: []string{"xy", ""}}}},
+	},
+	ast.Locate: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: []dataGenerator{&selectStringGener{candidates: []string{"a", "B", "数据", ""}}, utf8StrGener}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETInt}, geners: []dataGenerator{&selectStringGener{candidates: []string{"a", "B", "数据", ""}}, utf8StrGener, &rangeInt64Gener{-2, 10}}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{&selectStringGener{candidates: []string{"a", "B", "数据", ""}}, utf8StrGener}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETInt}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{&selectStringGener{candidates: []string{"a", "B", "数据", ""}}, utf8StrGener, &rangeInt64Gener{-2, 10}}},
+	},
+	ast.Instr: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: []dataGenerator{utf8StrGener, &selectStringGener{candidates: []string{"a", "B", "数据", ""}}}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &selectStringGener{candidates: []string{"a", "B", "数据", ""}}}},
+	},
+	ast.Lpad: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETString}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-2, 12}, &selectStringGener{candidates: []string{"?", "数据", ""}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-2, 12}, &selectStringGener{candidates: []string{"?", "ab", ""}}}},
+	},
+	ast.Rpad: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETString}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-2, 12}, &selectStringGener{candidates: []string{"?", "数据", ""}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString, types.ETInt, types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener, &rangeInt64Gener{-2, 12}, &selectStringGener{candidates: []string{"?", "ab", ""}}}},
+	},
+	ast.Reverse: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}},
+	},
+	ast.CharLength: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}, childrenFieldTypes: []*types.FieldType{binaryStringTp}, geners: []dataGenerator{utf8StrGener}},
+	},
+	ast.Hex: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{utf8StrGener}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETInt}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETReal}},
+	},
+	ast.Unhex: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&selectStringGener{candidates: []string{"4D7953514C", "e695b0", "abc", "xyz", ""}}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETInt}},
+	},
+	ast.FindInSet: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString, types.ETString}, geners: []dataGenerator{&selectStringGener{candidates: []string{"ab", "数据库", "c", ""}}, utf8StrGener}},
+	},
 	ast.Length: {
 		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{&defaultGener{0.2, types.ETString}}},
 	},
@@ -0,0 +1,1165 @@
+// Copyright 2013 The ql Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSES/QL-LICENSE file.
+
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &nowFunctionClass{}
+	_ functionClass = &currentDateFunctionClass{}
+	_ functionClass = &addDateFunctionClass{}
+	_ functionClass = &subDateFunctionClass{}
+	_ functionClass = &dateDiffFunctionClass{}
+	_ functionClass = &dateFormatFunctionClass{}
+	_ functionClass = &strToDateFunctionClass{}
+	_ functionClass = &unixTimestampFunctionClass{}
+	_ functionClass = &fromUnixTimeFunctionClass{}
+	_ functionClass = &yearFunctionClass{}
+	_ functionClass = &monthFunctionClass{}
+	_ functionClass = &dayOfMonthFunctionClass{}
+	_ functionClass = &hourFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinNowWithoutArgSig{}
+	_ builtinFunc = &builtinNowWithArgSig{}
+	_ builtinFunc = &builtinCurrentDateSig{}
+	_ builtinFunc = &builtinAddDateStringStringSig{}
+	_ builtinFunc = &builtinAddDateDatetimeStringSig{}
+	_ builtinFunc = &builtinSubDateStringStringSig{}
+	_ builtinFunc = &builtinSubDateDatetimeStringSig{}
+	_ builtinFunc = &builtinDateDiffSig{}
+	_ builtinFunc = &builtinDateFormatSig{}
+	_ builtinFunc = &builtinStrToDateDateSig{}
+	_ builtinFunc = &builtinStrToDateDatetimeSig{}
+	_ builtinFunc = &builtinStrToDateDurationSig{}
+	_ builtinFunc = &builtinUnixTimestampCurrentSig{}
+	_ builtinFunc = &builtinUnixTimestampIntSig{}
+	_ builtinFunc = &builtinUnixTimestampDecSig{}
+	_ builtinFunc = &builtinFromUnixTime1ArgSig{}
+	_ builtinFunc = &builtinFromUnixTime2ArgSig{}
+	_ builtinFunc = &builtinYearSig{}
+	_ builtinFunc = &builtinMonthSig{}
+	_ builtinFunc = &builtinDayOfMonthSig{}
+	_ builtinFunc = &builtinHourSig{}
+)
+
+// getTimeZone returns the session time zone, which is used to interpret
+// TIMESTAMP values and the result of NOW().
+func getTimeZone(ctx sessionctx.Context) *time.Location {
+	return ctx.GetSessionVars().Location()
+}
+
+type nowFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *nowFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, 1)
+	if len(args) == 1 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime, argTps...)
+	bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeWidthNoFsp, int(types.MinFsp)
+	if len(args) == 0 {
+		sig := &builtinNowWithoutArgSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_NowWithoutArg)
+		return sig, nil
+	}
+	if cst, ok := args[0].(*Constant); ok {
+		fsp, isNull, err := cst.EvalInt(ctx, chunk.Row{})
+		if err != nil {
+			return nil, err
+		}
+		if !isNull && fsp > 0 && fsp <= int64(types.MaxFsp) {
+			bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeWidthNoFsp+1+int(fsp), int(fsp)
+		}
+	} else {
+		bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeWidthWithFsp, int(types.MaxFsp)
+	}
+	sig := &builtinNowWithArgSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_NowWithArg)
+	return sig, nil
+}
+
+// evalNowWithFsp returns the statement start time in the session time zone,
+// rounded to fsp digits of fractional seconds.
+func evalNowWithFsp(ctx sessionctx.Context, fsp int8) (types.Time, bool, error) {
+	sc := ctx.GetSessionVars().StmtCtx
+	nowTs := sc.GetNowTsCached().In(getTimeZone(ctx))
+	t := types.NewTime(types.FromGoTime(nowTs), mysql.TypeDatetime, types.MaxFsp)
+	result, err := t.RoundFrac(sc, fsp)
+	if err != nil {
+		return types.ZeroTime, true, err
+	}
+	return result, false, nil
+}
+
+// checkNowFsp validates the fsp argument of NOW().
+func checkNowFsp(fsp int64) (int8, error) {
+	if fsp > int64(types.MaxFsp) {
+		return 0, errors.Trace(types.ErrTooBigPrecision.GenWithStackByArgs(fsp, "now", types.MaxFsp))
+	} else if fsp < int64(types.MinFsp) {
+		return 0, errors.Errorf("Invalid negative %d specified, must in [0, 6].", fsp)
+	}
+	return int8(fsp), nil
+}
+
+type builtinNowWithoutArgSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNowWithoutArgSig) Clone() builtinFunc {
+	newSig := &builtinNowWithoutArgSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals NOW() with no argument.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_now
+func (b *builtinNowWithoutArgSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	return evalNowWithFsp(b.ctx, types.MinFsp)
+}
+
+type builtinNowWithArgSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNowWithArgSig) Clone() builtinFunc {
+	newSig := &builtinNowWithArgSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals NOW(fsp).
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_now
+func (b *builtinNowWithArgSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	fsp, isNull, err := b.args[0].EvalInt(b.ctx, row)
+	if err != nil {
+		return types.ZeroTime, true, err
+	}
+	if isNull {
+		fsp = 0
+	}
+	tfsp, err := checkNowFsp(fsp)
+	if err != nil {
+		return types.ZeroTime, true, err
+	}
+	return evalNowWithFsp(b.ctx, tfsp)
+}
+
+type currentDateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *currentDateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime)
+	bf.tp.Tp, bf.tp.Flen, bf.tp.Decimal = mysql.TypeDate, mysql.MaxDateWidth, int(types.MinFsp)
+	sig := &builtinCurrentDateSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_CurrentDate)
+	return sig, nil
+}
+
+type builtinCurrentDateSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCurrentDateSig) Clone() builtinFunc {
+	newSig := &builtinCurrentDateSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals CURDATE().
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_curdate
+func (b *builtinCurrentDateSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	nowTs := b.ctx.GetSessionVars().StmtCtx.GetNowTsCached().In(getTimeZone(b.ctx))
+	year, month, day := nowTs.Date()
+	return types.NewTime(types.FromDate(year, int(month), day, 0, 0, 0, 0), mysql.TypeDate, types.MinFsp), false, nil
+}
+
+// baseDateArithmetical holds the shared logic of DATE_ADD and DATE_SUB.
+type baseDateArithmetical struct{}
+
+// getDateFromString parses the date argument given as a string. The result
+// keeps the DATE type only when the string looks like a date and the unit
+// does not touch the clock part.
+func (du *baseDateArithmetical) getDateFromString(ctx sessionctx.Context, args []Expression, row chunk.Row, unit string) (types.Time, bool, error) {
+	dateStr, isNull, err := args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	return du.parseDateString(ctx, dateStr, unit)
+}
+
+func (du *baseDateArithmetical) parseDateString(ctx sessionctx.Context, dateStr, unit string) (types.Time, bool, error) {
+	dateTp := mysql.TypeDate
+	if !types.IsDateFormat(dateStr) || types.IsClockUnit(unit) {
+		dateTp = mysql.TypeDatetime
+	}
+	date, err := types.ParseTime(ctx.GetSessionVars().StmtCtx, dateStr, dateTp, types.MaxFsp)
+	if err != nil {
+		return types.ZeroTime, true, handleInvalidTimeError(ctx, err)
+	}
+	return date, false, nil
+}
+
+func (du *baseDateArithmetical) getDateFromDatetime(ctx sessionctx.Context, args []Expression, row chunk.Row, unit string) (types.Time, bool, error) {
+	date, isNull, err := args[0].EvalTime(ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	if types.IsClockUnit(unit) {
+		date.SetType(mysql.TypeDatetime)
+	}
+	return date, false, nil
+}
+
+func (du *baseDateArithmetical) getInterval(ctx sessionctx.Context, args []Expression, row chunk.Row) (interval string, unit string, isNull bool, err error) {
+	interval, isNull, err = args[1].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", "", true, err
+	}
+	unit, isNull, err = args[2].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", "", true, err
+	}
+	return interval, unit, false, nil
+}
+
+func (du *baseDateArithmetical) add(ctx sessionctx.Context, date types.Time, interval string, unit string) (types.Time, bool, error) {
+	year, month, day, nano, err := types.ParseDurationValue(unit, interval)
+	if err := handleInvalidTimeError(ctx, err); err != nil {
+		return types.ZeroTime, true, err
+	}
+	if err != nil {
+		return types.ZeroTime, true, nil
+	}
+	return du.addDate(ctx, date, year, month, day, nano)
+}
+
+func (du *baseDateArithmetical) sub(ctx sessionctx.Context, date types.Time, interval string, unit string) (types.Time, bool, error) {
+	year, month, day, nano, err := types.ParseDurationValue(unit, interval)
+	if err := handleInvalidTimeError(ctx, err); err != nil {
+		return types.ZeroTime, true, err
+	}
+	if err != nil {
+		return types.ZeroTime, true, nil
+	}
+	return du.addDate(ctx, date, -year, -month, -day, -nano)
+}
+
+func (du *baseDateArithmetical) addDate(ctx sessionctx.Context, date types.Time, year, month, day, nano int64) (types.Time, bool, error) {
+	// UTC is used here so that the arithmetic never crosses a daylight saving
+	// transition, the result is only interpreted as a wall clock value.
+	goTime, err := date.GoTime(time.UTC)
+	if err := handleInvalidTimeError(ctx, err); err != nil {
+		return types.ZeroTime, true, err
+	}
+	if err != nil {
+		return types.ZeroTime, true, nil
+	}
+
+	goTime = goTime.Add(time.Duration(nano))
+	goTime = types.AddDate(year, month, day, goTime)
+
+	if goTime.Nanosecond() == 0 {
+		date.SetFsp(types.MinFsp)
+	} else {
+		date.SetFsp(types.MaxFsp)
+	}
+
+	if goTime.Year() < 0 || goTime.Year() > 9999 {
+		return types.ZeroTime, true, handleInvalidTimeError(ctx, types.ErrDatetimeFunctionOverflow.GenWithStackByArgs("datetime"))
+	}
+
+	date.SetCoreTime(types.FromGoTime(goTime))
+	return date, false, nil
+}
+
+// getDateArithFunction builds the signature shared by DATE_ADD and DATE_SUB.
+func getDateArithFunction(ctx sessionctx.Context, args []Expression, isSub bool) (builtinFunc, error) {
+	dateEvalTp := args[0].GetType().EvalType()
+	if dateEvalTp != types.ETString {
+		dateEvalTp = types.ETDatetime
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime, dateEvalTp, types.ETString, types.ETString)
+	bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeFullWidth, types.UnspecifiedLength
+	if dateEvalTp == types.ETDatetime && args[0].GetType().Tp == mysql.TypeDate {
+		// DATE_ADD on a DATE with a day based unit still produces a DATE.
+		if cst, ok := args[2].(*Constant); ok {
+			unit, isNull, err := cst.EvalString(ctx, chunk.Row{})
+			if err == nil && !isNull && !types.IsClockUnit(unit) {
+				bf.tp.Tp, bf.tp.Flen, bf.tp.Decimal = mysql.TypeDate, mysql.MaxDateWidth, int(types.MinFsp)
+			}
+		}
+	}
+
+	var sig builtinFunc
+	switch {
+	case dateEvalTp == types.ETString && !isSub:
+		sig = &builtinAddDateStringStringSig{baseBuiltinFunc: bf}
+		sig.setPbCode(tipb.ScalarFuncSig_AddDateStringString)
+	case dateEvalTp == types.ETString && isSub:
+		sig = &builtinSubDateStringStringSig{baseBuiltinFunc: bf}
+		sig.setPbCode(tipb.ScalarFuncSig_SubDateStringString)
+	case !isSub:
+		sig = &builtinAddDateDatetimeStringSig{baseBuiltinFunc: bf}
+		sig.setPbCode(tipb.ScalarFuncSig_AddDateDatetimeString)
+	default:
+		sig = &builtinSubDateDatetimeStringSig{baseBuiltinFunc: bf}
+		sig.setPbCode(tipb.ScalarFuncSig_SubDateDatetimeString)
+	}
+	return sig, nil
+}
+
+type addDateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *addDateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	return getDateArithFunction(ctx, args, false)
+}
+
+type builtinAddDateStringStringSig struct {
+	baseBuiltinFunc
+	baseDateArithmetical
+}
+
+func (b *builtinAddDateStringStringSig) Clone() builtinFunc {
+	newSig := &builtinAddDateStringStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals ADDDATE(date,INTERVAL expr unit) with a string date argument.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_adddate
+func (b *builtinAddDateStringStringSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	interval, unit, isNull, err := b.getInterval(b.ctx, b.args, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	date, isNull, err := b.getDateFromString(b.ctx, b.args, row, unit)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	return b.add(b.ctx, date, interval, unit)
+}
+
+type builtinAddDateDatetimeStringSig struct {
+	baseBuiltinFunc
+	baseDateArithmetical
+}
+
+func (b *builtinAddDateDatetimeStringSig) Clone() builtinFunc {
+	newSig := &builtinAddDateDatetimeStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals ADDDATE(date,INTERVAL expr unit) with a temporal date argument.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_adddate
+func (b *builtinAddDateDatetimeStringSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	interval, unit, isNull, err := b.getInterval(b.ctx, b.args, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	date, isNull, err := b.getDateFromDatetime(b.ctx, b.args, row, unit)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	return b.add(b.ctx, date, interval, unit)
+}
+
+type subDateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *subDateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	return getDateArithFunction(ctx, args, true)
+}
+
+type builtinSubDateStringStringSig struct {
+	baseBuiltinFunc
+	baseDateArithmetical
+}
+
+func (b *builtinSubDateStringStringSig) Clone() builtinFunc {
+	newSig := &builtinSubDateStringStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals SUBDATE(date,INTERVAL expr unit) with a string date argument.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_subdate
+func (b *builtinSubDateStringStringSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	interval, unit, isNull, err := b.getInterval(b.ctx, b.args, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	date, isNull, err := b.getDateFromString(b.ctx, b.args, row, unit)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	return b.sub(b.ctx, date, interval, unit)
+}
+
+type builtinSubDateDatetimeStringSig struct {
+	baseBuiltinFunc
+	baseDateArithmetical
+}
+
+func (b *builtinSubDateDatetimeStringSig) Clone() builtinFunc {
+	newSig := &builtinSubDateDatetimeStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals SUBDATE(date,INTERVAL expr unit) with a temporal date argument.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_subdate
+func (b *builtinSubDateDatetimeStringSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	interval, unit, isNull, err := b.getInterval(b.ctx, b.args, row)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	date, isNull, err := b.getDateFromDatetime(b.ctx, b.args, row, unit)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	return b.sub(b.ctx, date, interval, unit)
+}
+
+type dateDiffFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *dateDiffFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDatetime, types.ETDatetime)
+	sig := &builtinDateDiffSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_DateDiff)
+	return sig, nil
+}
+
+type builtinDateDiffSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDateDiffSig) Clone() builtinFunc {
+	newSig := &builtinDateDiffSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalDateDiff returns lhs - rhs in days, a zero date on either side gives NULL.
+func evalDateDiff(ctx sessionctx.Context, lhs, rhs types.Time) (int64, bool, error) {
+	if invalidLHS, invalidRHS := lhs.InvalidZero(), rhs.InvalidZero(); invalidLHS || invalidRHS {
+		var err error
+		if invalidLHS {
+			err = handleInvalidTimeError(ctx, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, lhs.String()))
+		}
+		if invalidRHS && err == nil {
+			err = handleInvalidTimeError(ctx, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, rhs.String()))
+		}
+		return 0, true, err
+	}
+	return int64(types.DateDiff(lhs.CoreTime(), rhs.CoreTime())), false, nil
+}
+
+// evalInt evals a builtinDateDiffSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_datediff
+func (b *builtinDateDiffSig) evalInt(row chunk.Row) (int64, bool, error) {
+	lhs, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	rhs, isNull, err := b.args[1].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	return evalDateDiff(b.ctx, lhs, rhs)
+}
+
+type dateFormatFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *dateFormatFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETDatetime, types.ETString)
+	// The longest specifier %W (weekday name) takes 9 characters.
+	bf.tp.Flen = 44
+	sig := &builtinDateFormatSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_DateFormatSig)
+	return sig, nil
+}
+
+type builtinDateFormatSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDateFormatSig) Clone() builtinFunc {
+	newSig := &builtinDateFormatSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinDateFormatSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_date-format
+func (b *builtinDateFormatSig) evalString(row chunk.Row) (string, bool, error) {
+	t, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, handleInvalidTimeError(b.ctx, err)
+	}
+	formatMask, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return evalDateFormat(b.ctx, t, formatMask)
+}
+
+func evalDateFormat(ctx sessionctx.Context, t types.Time, formatMask string) (string, bool, error) {
+	if t.InvalidZero() {
+		return "", true, handleInvalidTimeError(ctx, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, t.String()))
+	}
+	res, err := t.DateFormat(formatMask)
+	return res, err != nil, err
+}
+
+type strToDateFunctionClass struct {
+	baseFunctionClass
+}
+
+// getRetTp decides the result type of STR_TO_DATE from a constant format.
+// A format with only date specifiers gives a DATE, one with only clock
+// specifiers gives a TIME, anything else gives a DATETIME.
+func (c *strToDateFunctionClass) getRetTp(ctx sessionctx.Context, arg Expression) (tp byte, fsp int8) {
+	tp = mysql.TypeDatetime
+	if _, ok := arg.(*Constant); !ok {
+		return tp, types.MaxFsp
+	}
+	strArg := WrapWithCastAsString(ctx, arg)
+	format, isNull, err := strArg.EvalString(ctx, chunk.Row{})
+	if err != nil || isNull {
+		return
+	}
+
+	isDuration, isDate := types.GetFormatType(format)
+	if isDuration && !isDate {
+		tp = mysql.TypeDuration
+	} else if !isDuration && isDate {
+		tp = mysql.TypeDate
+	}
+	if strings.Contains(format, "%f") {
+		fsp = types.MaxFsp
+	}
+	return
+}
+
+func (c *strToDateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	retTp, fsp := c.getRetTp(ctx, args[1])
+	var sig builtinFunc
+	switch retTp {
+	case mysql.TypeDate:
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime, types.ETString, types.ETString)
+		bf.tp.Tp, bf.tp.Flen, bf.tp.Decimal = mysql.TypeDate, mysql.MaxDateWidth, int(types.MinFsp)
+		sig = &builtinStrToDateDateSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_StrToDateDate)
+	case mysql.TypeDuration:
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDuration, types.ETString, types.ETString)
+		bf.tp.Flen, bf.tp.Decimal = mysql.MaxDurationWidthNoFsp, int(fsp)
+		if fsp != types.MinFsp {
+			bf.tp.Flen = mysql.MaxDurationWidthWithFsp
+		}
+		sig = &builtinStrToDateDurationSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_StrToDateDuration)
+	default:
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime, types.ETString, types.ETString)
+		bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeWidthNoFsp, int(fsp)
+		if fsp != types.MinFsp {
+			bf.tp.Flen = mysql.MaxDatetimeWidthWithFsp
+		}
+		sig = &builtinStrToDateDatetimeSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_StrToDateDatetime)
+	}
+	return sig, nil
+}
+
+// evalStrToDate parses date with format, a mismatch is reported as a warning
+// and gives NULL.
+func evalStrToDate(ctx sessionctx.Context, date, format string) (types.Time, bool, error) {
+	var t types.Time
+	if !t.StrToDate(ctx.GetSessionVars().StmtCtx, date, format) {
+		return types.ZeroTime, true, handleInvalidTimeError(ctx, types.ErrWrongValue.GenWithStackByArgs(types.DateTimeStr, date))
+	}
+	return t, false, nil
+}
+
+type builtinStrToDateDateSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinStrToDateDateSig) Clone() builtinFunc {
+	newSig := &builtinStrToDateDateSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals STR_TO_DATE(str, format) whose format only has date specifiers.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_str-to-date
+func (b *builtinStrToDateDateSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	date, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, isNull, err
+	}
+	format, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, isNull, err
+	}
+	t, isNull, err := evalStrToDate(b.ctx, date, format)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	t.SetType(mysql.TypeDate)
+	t.SetFsp(types.MinFsp)
+	return t, false, nil
+}
+
+type builtinStrToDateDatetimeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinStrToDateDatetimeSig) Clone() builtinFunc {
+	newSig := &builtinStrToDateDatetimeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals STR_TO_DATE(str, format) returning a DATETIME.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_str-to-date
+func (b *builtinStrToDateDatetimeSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	date, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, isNull, err
+	}
+	format, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.ZeroTime, isNull, err
+	}
+	t, isNull, err := evalStrToDate(b.ctx, date, format)
+	if isNull || err != nil {
+		return types.ZeroTime, true, err
+	}
+	t.SetType(mysql.TypeDatetime)
+	t.SetFsp(int8(b.tp.Decimal))
+	return t, false, nil
+}
+
+type builtinStrToDateDurationSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinStrToDateDurationSig) Clone() builtinFunc {
+	newSig := &builtinStrToDateDurationSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalDuration evals STR_TO_DATE(str, format) whose format only has clock specifiers.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_str-to-date
+func (b *builtinStrToDateDurationSig) evalDuration(row chunk.Row) (types.Duration, bool, error) {
+	date, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.Duration{}, isNull, err
+	}
+	format, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.Duration{}, isNull, err
+	}
+	t, isNull, err := evalStrToDate(b.ctx, date, format)
+	if isNull || err != nil {
+		return types.Duration{}, true, err
+	}
+	t.SetFsp(int8(b.tp.Decimal))
+	dur, err := t.ConvertToDuration()
+	return dur, err != nil, err
+}
+
+type unixTimestampFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *unixTimestampFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	var (
+		argTps     []types.EvalType
+		retTp      = types.ETInt
+		retDecimal int
+	)
+	if len(args) > 0 {
+		argTps = []types.EvalType{types.ETDatetime}
+		argType := args[0].GetType()
+		if argType.EvalType() == types.ETString {
+			// A string argument keeps as many fractional digits as it carries.
+			retDecimal = types.UnspecifiedLength
+			if cst, ok := args[0].(*Constant); ok {
+				str, isNull, err := cst.EvalString(ctx, chunk.Row{})
+				if err != nil {
+					return nil, err
+				}
+				retDecimal = 0
+				if dotIdx := strings.LastIndex(str, "."); !isNull && dotIdx >= 0 {
+					retDecimal = len(str) - dotIdx - 1
+				}
+			}
+		} else {
+			retDecimal = argType.Decimal
+		}
+		if retDecimal > int(types.MaxFsp) || retDecimal == types.UnspecifiedLength {
+			retDecimal = int(types.MaxFsp)
+		}
+		if retDecimal > 0 {
+			retTp = types.ETDecimal
+		}
+	}
+
+	bf := newBaseBuiltinFuncWithTp(ctx, args, retTp, argTps...)
+	bf.tp.Flen, bf.tp.Decimal = 11, 0
+	if retTp == types.ETDecimal {
+		bf.tp.Flen, bf.tp.Decimal = 12+retDecimal, retDecimal
+	}
+
+	var sig builtinFunc
+	switch {
+	case len(args) == 0:
+		sig = &builtinUnixTimestampCurrentSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_UnixTimestampCurrent)
+	case retTp == types.ETInt:
+		sig = &builtinUnixTimestampIntSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_UnixTimestampInt)
+	default:
+		sig = &builtinUnixTimestampDecSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_UnixTimestampDec)
+	}
+	return sig, nil
+}
+
+// goTimeToMysqlUnixTimestamp converts go time into MySQL's Unix timestamp.
+// MySQL's Unix timestamp ranges in int32. Values out of range should be rewritten to 0.
+func goTimeToMysqlUnixTimestamp(t time.Time, decimal int) (*types.MyDecimal, error) {
+	nanoSeconds := t.UnixNano()
+	if nanoSeconds < 0 || (nanoSeconds/1e3) >= (math.MaxInt32+1)*1e6 {
+		return new(types.MyDecimal), nil
+	}
+	dec := new(types.MyDecimal)
+	// Here we don't use float to prevent precision lose.
+	dec.FromInt(nanoSeconds)
+	if err := dec.Shift(-9); err != nil {
+		return nil, err
+	}
+	// Like MySQL, the result is truncated instead of rounded.
+	err := dec.Round(dec, decimal, types.ModeTruncate)
+	return dec, err
+}
+
+// evalUnixTimestamp interprets t in the session time zone and returns its
+// Unix timestamp, an invalid time gives 0.
+func evalUnixTimestamp(ctx sessionctx.Context, t types.Time, decimal int) (*types.MyDecimal, error) {
+	goTime, err := t.GoTime(getTimeZone(ctx))
+	if err != nil {
+		return new(types.MyDecimal), nil
+	}
+	return goTimeToMysqlUnixTimestamp(goTime, decimal)
+}
+
+type builtinUnixTimestampCurrentSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUnixTimestampCurrentSig) Clone() builtinFunc {
+	newSig := &builtinUnixTimestampCurrentSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a UNIX_TIMESTAMP().
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_unix-timestamp
+func (b *builtinUnixTimestampCurrentSig) evalInt(row chunk.Row) (int64, bool, error) {
+	nowTs := b.ctx.GetSessionVars().StmtCtx.GetNowTsCached()
+	dec, err := goTimeToMysqlUnixTimestamp(nowTs, 0)
+	if err != nil {
+		return 0, true, err
+	}
+	intVal, err := dec.ToInt()
+	terror.Log(err)
+	return intVal, false, nil
+}
+
+type builtinUnixTimestampIntSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUnixTimestampIntSig) Clone() builtinFunc {
+	newSig := &builtinUnixTimestampIntSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a UNIX_TIMESTAMP(time).
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_unix-timestamp
+func (b *builtinUnixTimestampIntSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if err != nil {
+		if err = handleInvalidTimeError(b.ctx, err); err != nil {
+			return 0, true, err
+		}
+		// Return 0 for invalid date time.
+		return 0, false, nil
+	}
+	if isNull {
+		return 0, true, nil
+	}
+	dec, err := evalUnixTimestamp(b.ctx, val, 0)
+	if err != nil {
+		return 0, true, err
+	}
+	intVal, err := dec.ToInt()
+	terror.Log(err)
+	return intVal, false, nil
+}
+
+type builtinUnixTimestampDecSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinUnixTimestampDecSig) Clone() builtinFunc {
+	newSig := &builtinUnixTimestampDecSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalDecimal evals a UNIX_TIMESTAMP(time) with fractional seconds.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_unix-timestamp
+func (b *builtinUnixTimestampDecSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	val, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if err != nil {
+		if err = handleInvalidTimeError(b.ctx, err); err != nil {
+			return nil, true, err
+		}
+		// Return 0 for invalid date time.
+		return new(types.MyDecimal), false, nil
+	}
+	if isNull {
+		return nil, true, nil
+	}
+	dec, err := evalUnixTimestamp(b.ctx, val, b.tp.Decimal)
+	return dec, err != nil, err
+}
+
+type fromUnixTimeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *fromUnixTimeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	fsp := args[0].GetType().Decimal
+	if args[0].GetType().EvalType() == types.ETString || fsp == types.UnspecifiedLength || fsp > int(types.MaxFsp) {
+		fsp = int(types.MaxFsp)
+	}
+
+	if len(args) == 2 {
+		bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETString, types.ETDecimal, types.ETString)
+		bf.tp.Flen = 44
+		sig := &builtinFromUnixTime2ArgSig{bf, int8(fsp)}
+		sig.setPbCode(tipb.ScalarFuncSig_FromUnixTime2Arg)
+		return sig, nil
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETDatetime, types.ETDecimal)
+	bf.tp.Flen, bf.tp.Decimal = mysql.MaxDatetimeWidthNoFsp, fsp
+	if fsp > 0 {
+		bf.tp.Flen += 1 + fsp
+	}
+	sig := &builtinFromUnixTime1ArgSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_FromUnixTime1Arg)
+	return sig, nil
+}
+
+// evalFromUnixTime converts a Unix timestamp to a DATETIME in the session
+// time zone. Negative timestamps and timestamps beyond the int32 range give NULL.
+func evalFromUnixTime(ctx sessionctx.Context, fsp int8, unixTimeStamp *types.MyDecimal) (types.Time, bool, error) {
+	if unixTimeStamp.IsNegative() {
+		return types.ZeroTime, true, nil
+	}
+	integralPart, err := unixTimeStamp.ToInt()
+	if err != nil && !terror.ErrorEqual(err, types.ErrTruncated) {
+		return types.ZeroTime, true, err
+	}
+	if integralPart > int64(math.MaxInt32) {
+		return types.ZeroTime, true, nil
+	}
+	// Split the integral part and fractional part of a decimal timestamp.
+	// e.g. for timestamp 12345.678, the integral part is 12345 and
+	// (12345.678 - 12345) * (10^9) gives the fractional part in nanoseconds.
+	integerDecimalTp := new(types.MyDecimal).FromInt(integralPart)
+	fracDecimalTp := new(types.MyDecimal)
+	if err = types.DecimalSub(unixTimeStamp, integerDecimalTp, fracDecimalTp); err != nil {
+		return types.ZeroTime, true, err
+	}
+	nano := new(types.MyDecimal).FromInt(int64(time.Second))
+	x := new(types.MyDecimal)
+	if err = types.DecimalMul(fracDecimalTp, nano, x); err != nil {
+		return types.ZeroTime, true, err
+	}
+	fractionalPart, err := x.ToInt()
+	if err != nil && !terror.ErrorEqual(err, types.ErrTruncated) {
+		return types.ZeroTime, true, err
+	}
+
+	goTime := time.Unix(integralPart, fractionalPart).In(getTimeZone(ctx))
+	t := types.NewTime(types.FromGoTime(goTime), mysql.TypeDatetime, types.MaxFsp)
+	t, err = t.RoundFrac(ctx.GetSessionVars().StmtCtx, fsp)
+	if err != nil {
+		return types.ZeroTime, true, err
+	}
+	return t, false, nil
+}
+
+type builtinFromUnixTime1ArgSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinFromUnixTime1ArgSig) Clone() builtinFunc {
+	newSig := &builtinFromUnixTime1ArgSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalTime evals a builtinFromUnixTime1ArgSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_from-unixtime
+func (b *builtinFromUnixTime1ArgSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	unixTimeStamp, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if err != nil || isNull {
+		return types.ZeroTime, true, err
+	}
+	return evalFromUnixTime(b.ctx, int8(b.tp.Decimal), unixTimeStamp)
+}
+
+type builtinFromUnixTime2ArgSig struct {
+	baseBuiltinFunc
+	fsp int8
+}
+
+func (b *builtinFromUnixTime2ArgSig) Clone() builtinFunc {
+	newSig := &builtinFromUnixTime2ArgSig{fsp: b.fsp}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinFromUnixTime2ArgSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_from-unixtime
+func (b *builtinFromUnixTime2ArgSig) evalString(row chunk.Row) (string, bool, error) {
+	format, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	unixTimeStamp, isNull, err := b.args[0].EvalDecimal(b.ctx, row)
+	if err != nil || isNull {
+		return "", true, err
+	}
+	t, isNull, err := evalFromUnixTime(b.ctx, b.fsp, unixTimeStamp)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	res, err := t.DateFormat(format)
+	return res, err != nil, err
+}
+
+type yearFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *yearFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDatetime)
+	bf.tp.Flen = 4
+	sig := &builtinYearSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_Year)
+	return sig, nil
+}
+
+type builtinYearSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinYearSig) Clone() builtinFunc {
+	newSig := &builtinYearSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals YEAR(date).
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_year
+func (b *builtinYearSig) evalInt(row chunk.Row) (int64, bool, error) {
+	date, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	return int64(date.Year()), false, nil
+}
+
+type monthFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *monthFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDatetime)
+	bf.tp.Flen = 2
+	sig := &builtinMonthSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_Month)
+	return sig, nil
+}
+
+type builtinMonthSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinMonthSig) Clone() builtinFunc {
+	newSig := &builtinMonthSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals MONTH(date).
+// see: https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_month
+func (b *builtinMonthSig) evalInt(row chunk.Row) (int64, bool, error) {
+	date, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	return int64(date.Month()), false, nil
+}
+
+type dayOfMonthFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *dayOfMonthFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDatetime)
+	bf.tp.Flen = 2
+	sig := &builtinDayOfMonthSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_DayOfMonth)
+	return sig, nil
+}
+
+type builtinDayOfMonthSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinDayOfMonthSig) Clone() builtinFunc {
+	newSig := &builtinDayOfMonthSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals DAYOFMONTH(date).
+// see: https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_dayofmonth
+func (b *builtinDayOfMonthSig) evalInt(row chunk.Row) (int64, bool, error) {
+	date, isNull, err := b.args[0].EvalTime(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	return int64(date.Day()), false, nil
+}
+
+type hourFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *hourFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf := newBaseBuiltinFuncWithTp(ctx, args, types.ETInt, types.ETDuration)
+	bf.tp.Flen, bf.tp.Decimal = 3, 0
+	sig := &builtinHourSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_Hour)
+	return sig, nil
+}
+
+type builtinHourSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinHourSig) Clone() builtinFunc {
+	newSig := &builtinHourSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals HOUR(time).
+// See https://dev.mysql.com/doc/refman/5.7/en/date-and-time-functions.html#function_hour
+func (b *builtinHourSig) evalInt(row chunk.Row) (int64, bool, error) {
+	dur, isNull, err := b.args[0].EvalDuration(b.ctx, row)
+	if isNull || err != nil {
+		return 0, true, handleInvalidTimeError(b.ctx, err)
+	}
+	return int64(dur.Hour()), false, nil
+}
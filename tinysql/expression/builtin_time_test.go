@@ -0,0 +1,284 @@
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/testutil"
+)
+
+func (s *testEvaluatorSuite) TestNowAndCurdate(c *C) {
+	sc := s.ctx.GetSessionVars().StmtCtx
+	sc.ResetNowTs()
+	defer sc.ResetNowTs()
+	nowTs := sc.GetNowTsCached().In(s.ctx.GetSessionVars().Location())
+
+	f, err := funcs[ast.Now].getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	v, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	t := v.GetMysqlTime()
+	c.Assert(t.Fsp(), Equals, types.MinFsp)
+	c.Assert(t.Year(), Equals, nowTs.Year())
+	c.Assert(t.Microsecond(), Equals, 0)
+
+	f, err = funcs[ast.CurrentTimestamp].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(6)))
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().Decimal, Equals, 6)
+	v, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetMysqlTime().Fsp(), Equals, int8(6))
+	c.Assert(v.GetMysqlTime().Microsecond(), Equals, nowTs.Round(time.Microsecond).Nanosecond()/1000)
+
+	f, err = funcs[ast.Now].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(7)))
+	c.Assert(err, IsNil)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(types.ErrTooBigPrecision.Equal(err), IsTrue)
+
+	f, err = funcs[ast.Curdate].getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().Tp, Equals, mysql.TypeDate)
+	v, err = evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetMysqlTime().String(), Equals, nowTs.Format("2006-01-02"))
+}
+
+func (s *testEvaluatorSuite) TestDateArith(c *C) {
+	tbl := []struct {
+		Date     interface{}
+		Interval interface{}
+		Unit     string
+		Add      interface{}
+		Sub      interface{}
+	}{
+		{"2011-11-11", "1", "DAY", "2011-11-12", "2011-11-10"},
+		{"2011-11-11 10:10:10", "1", "DAY", "2011-11-12 10:10:10", "2011-11-10 10:10:10"},
+		{"2011-11-11", "1", "HOUR", "2011-11-11 01:00:00", "2011-11-10 23:00:00"},
+		{"2011-01-31", "1", "MONTH", "2011-02-28", "2010-12-31"},
+		{"2011-11-11 10:10:10", "1 1:1:1", "DAY_SECOND", "2011-11-12 11:11:11", "2011-11-10 09:09:09"},
+		{"2011-11-11 10:10:10", "1-2", "YEAR_MONTH", "2013-01-11 10:10:10", "2010-09-11 10:10:10"},
+		{"2011-11-11 10:10:10", "0.000001", "SECOND", "2011-11-11 10:10:10.000001", "2011-11-11 10:10:09.999999"},
+		{"2011-11-11", "-1", "WEEK", "2011-11-04", "2011-11-18"},
+		{"9999-12-31", "1", "DAY", nil, "9999-12-30"},
+		{nil, "1", "DAY", nil, nil},
+		{"2011-11-11", nil, "DAY", nil, nil},
+		{"abc", "1", "DAY", nil, nil},
+	}
+	for _, t := range tbl {
+		args := types.MakeDatums(t.Date, t.Interval, t.Unit)
+		for _, name := range []string{ast.DateAdd, ast.AddDate} {
+			f, err := funcs[name].getFunction(s.ctx, s.datumsToConstants(args))
+			c.Assert(err, IsNil)
+			v, err := evalBuiltinFunc(f, chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.Add == nil {
+				c.Assert(v.IsNull(), IsTrue, Commentf("%v", t))
+			} else {
+				c.Assert(v.GetMysqlTime().String(), Equals, t.Add, Commentf("%v", t))
+			}
+		}
+		for _, name := range []string{ast.DateSub, ast.SubDate} {
+			f, err := funcs[name].getFunction(s.ctx, s.datumsToConstants(args))
+			c.Assert(err, IsNil)
+			v, err := evalBuiltinFunc(f, chunk.Row{})
+			c.Assert(err, IsNil)
+			if t.Sub == nil {
+				c.Assert(v.IsNull(), IsTrue, Commentf("%v", t))
+			} else {
+				c.Assert(v.GetMysqlTime().String(), Equals, t.Sub, Commentf("%v", t))
+			}
+		}
+	}
+
+	// A DATE argument with a day based unit keeps the DATE type.
+	date := types.NewTime(types.FromDate(2020, 2, 28, 0, 0, 0, 0), mysql.TypeDate, 0)
+	args := []Expression{
+		&Constant{Value: types.NewTimeDatum(date), RetType: types.NewFieldType(mysql.TypeDate)},
+		&Constant{Value: types.NewStringDatum("1"), RetType: types.NewFieldType(mysql.TypeVarString)},
+		&Constant{Value: types.NewStringDatum("DAY"), RetType: types.NewFieldType(mysql.TypeVarString)},
+	}
+	f, err := funcs[ast.DateAdd].getFunction(s.ctx, args)
+	c.Assert(err, IsNil)
+	c.Assert(f.getRetTp().Tp, Equals, mysql.TypeDate)
+	v, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetMysqlTime().String(), Equals, "2020-02-29")
+}
+
+func (s *testEvaluatorSuite) TestDateDiff(c *C) {
+	tbl := []struct {
+		Arg1 interface{}
+		Arg2 interface{}
+		Ret  interface{}
+	}{
+		{"2004-05-21", "2004:01:02", int64(140)},
+		{"2010-11-30 23:59:59", "2010-12-31", int64(-31)},
+		{"2010-11-30 00:00:00", "2010-11-29 23:59:59", int64(1)},
+		{"2010-11-30", "0000-00-00", nil},
+		{nil, "2010-11-30", nil},
+	}
+	for _, t := range tbl {
+		f, err := funcs[ast.DateDiff].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg1, t.Arg2)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestDateFormat(c *C) {
+	tbl := []struct {
+		Date   interface{}
+		Format interface{}
+		Ret    interface{}
+	}{
+		{"2010-01-07 23:12:34.12345", "%b %M %m %c %D %d %e %j %k %h %i %p %r %T %s %f %U %u %V %v %a %W %w %X %x %Y %y %%",
+			"Jan January 01 1 7th 07 7 007 23 11 12 PM 11:12:34 PM 23:12:34 34 123450 01 01 01 01 Thu Thursday 4 2010 2010 2010 10 %"},
+		{"2012-12-21 23:58:59", "%Y-%m-%d", "2012-12-21"},
+		{"0000-00-00 00:00:00", "%Y-%m-%d", nil},
+		{nil, "%Y", nil},
+		{"2012-12-21", nil, nil},
+	}
+	for _, t := range tbl {
+		f, err := funcs[ast.DateFormat].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Date, t.Format)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret))
+	}
+}
+
+func (s *testEvaluatorSuite) TestStrToDate(c *C) {
+	tbl := []struct {
+		Date   string
+		Format string
+		RetTp  byte
+		Ret    interface{}
+	}{
+		{"10/28/2011 9:46:29 pm", "%m/%d/%Y %l:%i:%s %p", mysql.TypeDatetime, "2011-10-28 21:46:29"},
+		{"2011/10/28", "%Y/%m/%d", mysql.TypeDate, "2011-10-28"},
+		{"Oct 28, 2011", "%b %d, %Y", mysql.TypeDate, "2011-10-28"},
+		{"21:46:29.123", "%H:%i:%s.%f", mysql.TypeDuration, "21:46:29.123000"},
+		{"2011/10/28 21:46:29.123", "%Y/%m/%d %H:%i:%s.%f", mysql.TypeDatetime, "2011-10-28 21:46:29.123000"},
+		{"2011-13-28", "%Y-%m-%d", mysql.TypeDate, nil},
+		{"abc", "%Y", mysql.TypeDate, nil},
+	}
+	for _, t := range tbl {
+		f, err := funcs[ast.StrToDate].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Date, t.Format)))
+		c.Assert(err, IsNil)
+		c.Assert(f.getRetTp().Tp, Equals, t.RetTp)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.Ret == nil {
+			c.Assert(v.IsNull(), IsTrue, Commentf("%v", t))
+			continue
+		}
+		str, err := v.ToString()
+		c.Assert(err, IsNil)
+		c.Assert(str, Equals, t.Ret)
+	}
+}
+
+func (s *testEvaluatorSuite) TestUnixTimestampAndFromUnixTime(c *C) {
+	sessionVars := s.ctx.GetSessionVars()
+	originTZ := sessionVars.TimeZone
+	defer func() {
+		sessionVars.TimeZone = originTZ
+	}()
+	sessionVars.TimeZone = time.FixedZone("UTC+8", 8*3600)
+
+	tbl := []struct {
+		Arg interface{}
+		Ret interface{}
+	}{
+		{"1970-01-01 08:00:00", int64(0)},
+		{"2015-11-13 10:20:19", int64(1447381219)},
+		{"2015-11-13 10:20:19.012", types.NewDecFromStringForTest("1447381219.012")},
+		{"1969-12-31 23:59:59", int64(0)},
+		{"2038-01-19 11:14:08", int64(0)},
+		{nil, nil},
+	}
+	for _, t := range tbl {
+		f, err := funcs[ast.UnixTimestamp].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		c.Assert(v, testutil.DatumEquals, types.NewDatum(t.Ret), Commentf("%v", t))
+	}
+
+	f, err := funcs[ast.UnixTimestamp].getFunction(s.ctx, nil)
+	c.Assert(err, IsNil)
+	v, err := evalBuiltinFunc(f, chunk.Row{})
+	c.Assert(err, IsNil)
+	c.Assert(v.GetInt64(), Equals, sessionVars.StmtCtx.GetNowTsCached().Unix())
+
+	fromTbl := []struct {
+		Arg    interface{}
+		Format interface{}
+		Ret    interface{}
+	}{
+		{int64(1447381219), nil, "2015-11-13 10:20:19"},
+		{int64(0), nil, "1970-01-01 08:00:00"},
+		{int64(1447381219), "%Y %D %M %h:%i:%s", "2015 13th November 10:20:19"},
+		{int64(-1), nil, nil},
+		{int64(1) << 32, nil, nil},
+	}
+	for _, t := range fromTbl {
+		args := types.MakeDatums(t.Arg)
+		if t.Format != nil {
+			args = append(args, types.NewDatum(t.Format))
+		}
+		f, err := funcs[ast.FromUnixTime].getFunction(s.ctx, s.datumsToConstants(args))
+		c.Assert(err, IsNil)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+		if t.Ret == nil {
+			c.Assert(v.IsNull(), IsTrue, Commentf("%v", t))
+			continue
+		}
+		str, err := v.ToString()
+		c.Assert(err, IsNil)
+		c.Assert(str[:len(t.Ret.(string))], Equals, t.Ret)
+	}
+}
+
+func (s *testEvaluatorSuite) TestTimePart(c *C) {
+	tbl := []struct {
+		Arg   interface{}
+		Year  interface{}
+		Month interface{}
+		Day   interface{}
+		Hour  interface{}
+	}{
+		{"2011-11-11 10:10:10", int64(2011), int64(11), int64(11), int64(10)},
+		{"2000-01-31 23:59:59.999999", int64(2000), int64(1), int64(31), int64(23)},
+		{"0000-00-00 00:00:00", int64(0), int64(0), int64(0), int64(0)},
+		{nil, nil, nil, nil, nil},
+	}
+	for _, t := range tbl {
+		for name, ret := range map[string]interface{}{ast.Year: t.Year, ast.Month: t.Month, ast.Day: t.Day, ast.DayOfMonth: t.Day, ast.Hour: t.Hour} {
+			f, err := funcs[name].getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.Arg)))
+			c.Assert(err, IsNil)
+			v, err := evalBuiltinFunc(f, chunk.Row{})
+			c.Assert(err, IsNil)
+			c.Assert(v, testutil.DatumEquals, types.NewDatum(ret), Commentf("%s(%v)", name, t.Arg))
+		}
+	}
+}
@@ -0,0 +1,654 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+func (b *builtinNowWithoutArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinNowWithoutArgSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	nowTs, isNull, err := evalNowWithFsp(b.ctx, types.MinFsp)
+	if err != nil {
+		return err
+	}
+	result.ResizeTime(n, isNull)
+	if isNull {
+		return nil
+	}
+	times := result.Times()
+	for i := 0; i < n; i++ {
+		times[i] = nowTs
+	}
+	return nil
+}
+
+func (b *builtinNowWithArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinNowWithArgSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufFsp, err := b.bufAllocator.get(types.ETInt, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(bufFsp)
+	if err = b.args[0].VecEvalInt(b.ctx, input, bufFsp); err != nil {
+		return err
+	}
+
+	result.ResizeTime(n, false)
+	times := result.Times()
+	fsps := bufFsp.Int64s()
+	for i := 0; i < n; i++ {
+		fsp := int64(0)
+		if !bufFsp.IsNull(i) {
+			fsp = fsps[i]
+		}
+		tfsp, err := checkNowFsp(fsp)
+		if err != nil {
+			return err
+		}
+		t, isNull, err := evalNowWithFsp(b.ctx, tfsp)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		times[i] = t
+	}
+	return nil
+}
+
+func (b *builtinCurrentDateSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinCurrentDateSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	nowTs, _, err := b.evalTime(chunk.Row{})
+	if err != nil {
+		return err
+	}
+	result.ResizeTime(n, false)
+	times := result.Times()
+	for i := 0; i < n; i++ {
+		times[i] = nowTs
+	}
+	return nil
+}
+
+// vecGetInterval evaluates the interval and unit arguments of DATE_ADD and DATE_SUB.
+func (du *baseDateArithmetical) vecGetInterval(b *baseBuiltinFunc, input *chunk.Chunk, intervalBuf, unitBuf *chunk.Column) error {
+	if err := b.args[1].VecEvalString(b.ctx, input, intervalBuf); err != nil {
+		return err
+	}
+	return b.args[2].VecEvalString(b.ctx, input, unitBuf)
+}
+
+// vecEvalDateArith evaluates DATE_ADD or DATE_SUB, the date argument being a
+// string when isStr is true and a temporal value otherwise.
+func (du *baseDateArithmetical) vecEvalDateArith(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, isStr, isSub bool) error {
+	n := input.NumRows()
+	intervalBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(intervalBuf)
+	unitBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(unitBuf)
+	if err = du.vecGetInterval(b, input, intervalBuf, unitBuf); err != nil {
+		return err
+	}
+
+	var dateBuf *chunk.Column
+	if isStr {
+		dateBuf, err = b.bufAllocator.get(types.ETString, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(dateBuf)
+		err = b.args[0].VecEvalString(b.ctx, input, dateBuf)
+	} else {
+		dateBuf, err = b.bufAllocator.get(types.ETDatetime, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(dateBuf)
+		err = b.args[0].VecEvalTime(b.ctx, input, dateBuf)
+	}
+	if err != nil {
+		return err
+	}
+
+	result.ResizeTime(n, false)
+	result.MergeNulls(dateBuf, intervalBuf, unitBuf)
+	times := result.Times()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		unit := unitBuf.GetString(i)
+		var date types.Time
+		var isNull bool
+		if isStr {
+			date, isNull, err = du.parseDateString(b.ctx, dateBuf.GetString(i), unit)
+			if err != nil {
+				return err
+			}
+			if isNull {
+				result.SetNull(i, true)
+				continue
+			}
+		} else {
+			date = dateBuf.GetTime(i)
+			if types.IsClockUnit(unit) {
+				date.SetType(mysql.TypeDatetime)
+			}
+		}
+
+		var res types.Time
+		if isSub {
+			res, isNull, err = du.sub(b.ctx, date, intervalBuf.GetString(i), unit)
+		} else {
+			res, isNull, err = du.add(b.ctx, date, intervalBuf.GetString(i), unit)
+		}
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		times[i] = res
+	}
+	return nil
+}
+
+func (b *builtinAddDateStringStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAddDateStringStringSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalDateArith(&b.baseBuiltinFunc, input, result, true, false)
+}
+
+func (b *builtinAddDateDatetimeStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinAddDateDatetimeStringSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalDateArith(&b.baseBuiltinFunc, input, result, false, false)
+}
+
+func (b *builtinSubDateStringStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubDateStringStringSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalDateArith(&b.baseBuiltinFunc, input, result, true, true)
+}
+
+func (b *builtinSubDateDatetimeStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSubDateDatetimeStringSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	return b.vecEvalDateArith(&b.baseBuiltinFunc, input, result, false, true)
+}
+
+func (b *builtinDateDiffSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinDateDiffSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	lhs, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(lhs)
+	if err = b.args[0].VecEvalTime(b.ctx, input, lhs); err != nil {
+		return err
+	}
+	rhs, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(rhs)
+	if err = b.args[1].VecEvalTime(b.ctx, input, rhs); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(lhs, rhs)
+	i64s := result.Int64s()
+	lhsTimes, rhsTimes := lhs.Times(), rhs.Times()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		res, isNull, err := evalDateDiff(b.ctx, lhsTimes[i], rhsTimes[i])
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		i64s[i] = res
+	}
+	return nil
+}
+
+func (b *builtinDateFormatSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinDateFormatSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	dateBuf, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(dateBuf)
+	if err = b.args[0].VecEvalTime(b.ctx, input, dateBuf); err != nil {
+		return err
+	}
+	formatBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(formatBuf)
+	if err = b.args[1].VecEvalString(b.ctx, input, formatBuf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	times := dateBuf.Times()
+	for i := 0; i < n; i++ {
+		if dateBuf.IsNull(i) || formatBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		res, isNull, err := evalDateFormat(b.ctx, times[i], formatBuf.GetString(i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+// vecEvalStrToDate evaluates the arguments of STR_TO_DATE and calls fn on
+// every successfully parsed row, failed rows are set to NULL.
+func vecEvalStrToDate(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, fn func(i int, t types.Time) error) error {
+	n := input.NumRows()
+	dateBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(dateBuf)
+	if err = b.args[0].VecEvalString(b.ctx, input, dateBuf); err != nil {
+		return err
+	}
+	formatBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(formatBuf)
+	if err = b.args[1].VecEvalString(b.ctx, input, formatBuf); err != nil {
+		return err
+	}
+
+	result.MergeNulls(dateBuf, formatBuf)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		t, isNull, err := evalStrToDate(b.ctx, dateBuf.GetString(i), formatBuf.GetString(i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		if err = fn(i, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinStrToDateDateSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinStrToDateDateSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeTime(input.NumRows(), false)
+	times := result.Times()
+	return vecEvalStrToDate(&b.baseBuiltinFunc, input, result, func(i int, t types.Time) error {
+		t.SetType(mysql.TypeDate)
+		t.SetFsp(types.MinFsp)
+		times[i] = t
+		return nil
+	})
+}
+
+func (b *builtinStrToDateDatetimeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinStrToDateDatetimeSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeTime(input.NumRows(), false)
+	times := result.Times()
+	fsp := int8(b.tp.Decimal)
+	return vecEvalStrToDate(&b.baseBuiltinFunc, input, result, func(i int, t types.Time) error {
+		t.SetType(mysql.TypeDatetime)
+		t.SetFsp(fsp)
+		times[i] = t
+		return nil
+	})
+}
+
+func (b *builtinStrToDateDurationSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinStrToDateDurationSig) vecEvalDuration(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeGoDuration(input.NumRows(), false)
+	ds := result.GoDurations()
+	fsp := int8(b.tp.Decimal)
+	return vecEvalStrToDate(&b.baseBuiltinFunc, input, result, func(i int, t types.Time) error {
+		t.SetFsp(fsp)
+		dur, err := t.ConvertToDuration()
+		if err != nil {
+			return err
+		}
+		ds[i] = dur.Duration
+		return nil
+	})
+}
+
+func (b *builtinUnixTimestampCurrentSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUnixTimestampCurrentSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	intVal, _, err := b.evalInt(chunk.Row{})
+	if err != nil {
+		return err
+	}
+	result.ResizeInt64(n, false)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		i64s[i] = intVal
+	}
+	return nil
+}
+
+func (b *builtinUnixTimestampIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUnixTimestampIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	result.ResizeInt64(n, false)
+	if err = b.args[0].VecEvalTime(b.ctx, input, buf); err != nil {
+		if err = handleInvalidTimeError(b.ctx, err); err != nil {
+			return err
+		}
+		// Return 0 for invalid date time.
+		return nil
+	}
+
+	result.MergeNulls(buf)
+	i64s := result.Int64s()
+	times := buf.Times()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		dec, err := evalUnixTimestamp(b.ctx, times[i], 0)
+		if err != nil {
+			return err
+		}
+		intVal, err := dec.ToInt()
+		terror.Log(err)
+		i64s[i] = intVal
+	}
+	return nil
+}
+
+func (b *builtinUnixTimestampDecSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinUnixTimestampDecSig) vecEvalDecimal(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	result.ResizeDecimal(n, false)
+	if err = b.args[0].VecEvalTime(b.ctx, input, buf); err != nil {
+		if err = handleInvalidTimeError(b.ctx, err); err != nil {
+			return err
+		}
+		// Return 0 for invalid date time.
+		return nil
+	}
+
+	result.MergeNulls(buf)
+	ds := result.Decimals()
+	times := buf.Times()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		dec, err := evalUnixTimestamp(b.ctx, times[i], b.tp.Decimal)
+		if err != nil {
+			return err
+		}
+		ds[i] = *dec
+	}
+	return nil
+}
+
+func (b *builtinFromUnixTime1ArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinFromUnixTime1ArgSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDecimal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err = b.args[0].VecEvalDecimal(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeTime(n, false)
+	result.MergeNulls(buf)
+	times := result.Times()
+	ds := buf.Decimals()
+	fsp := int8(b.tp.Decimal)
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		t, isNull, err := evalFromUnixTime(b.ctx, fsp, &ds[i])
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		times[i] = t
+	}
+	return nil
+}
+
+func (b *builtinFromUnixTime2ArgSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinFromUnixTime2ArgSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDecimal, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err = b.args[0].VecEvalDecimal(b.ctx, input, buf); err != nil {
+		return err
+	}
+	formatBuf, err := b.bufAllocator.get(types.ETString, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(formatBuf)
+	if err = b.args[1].VecEvalString(b.ctx, input, formatBuf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	ds := buf.Decimals()
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) || formatBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		t, isNull, err := evalFromUnixTime(b.ctx, b.fsp, &ds[i])
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		res, err := t.DateFormat(formatBuf.GetString(i))
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+// vecEvalTimePart evaluates a function extracting an integer field from its
+// only temporal argument.
+func vecEvalTimePart(b *baseBuiltinFunc, input *chunk.Chunk, result *chunk.Column, part func(t types.Time) int) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDatetime, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err = b.args[0].VecEvalTime(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	i64s := result.Int64s()
+	times := buf.Times()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		i64s[i] = int64(part(times[i]))
+	}
+	return nil
+}
+
+func (b *builtinYearSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinYearSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalTimePart(&b.baseBuiltinFunc, input, result, types.Time.Year)
+}
+
+func (b *builtinMonthSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinMonthSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalTimePart(&b.baseBuiltinFunc, input, result, types.Time.Month)
+}
+
+func (b *builtinDayOfMonthSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinDayOfMonthSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalTimePart(&b.baseBuiltinFunc, input, result, types.Time.Day)
+}
+
+func (b *builtinHourSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinHourSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get(types.ETDuration, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err = b.args[0].VecEvalDuration(b.ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	i64s := result.Int64s()
+	ds := buf.GoDurations()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		i64s[i] = int64(types.Duration{Duration: ds[i]}.Hour())
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+var vecBuiltinTimeCases = map[string][]vecExprBenchCase{
+	ast.Now: {
+		{retEvalType: types.ETDatetime},
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETInt}, geners: []dataGenerator{&rangeInt64Gener{0, 7}}},
+	},
+	ast.Curdate: {
+		{retEvalType: types.ETDatetime},
+	},
+	ast.DateAdd: {
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETDatetime, types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateTimeGener{Year: 2019}, &numStrGener{rangeInt64Gener{-1000, 1000}}},
+			constants: []*Constant{nil, nil, {Value: types.NewStringDatum("DAY"), RetType: types.NewFieldType(mysql.TypeString)}}},
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateTimeStrGener{Year: 2019}, &numStrGener{rangeInt64Gener{-100000, 100000}}},
+			constants: []*Constant{nil, nil, {Value: types.NewStringDatum("SECOND"), RetType: types.NewFieldType(mysql.TypeString)}}},
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateStrGener{Year: 2019}, &selectStringGener{candidates: []string{"1-2", "-3-11", "10-0", "x"}}},
+			constants: []*Constant{nil, nil, {Value: types.NewStringDatum("YEAR_MONTH"), RetType: types.NewFieldType(mysql.TypeString)}}},
+	},
+	ast.DateSub: {
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETDatetime, types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateTimeGener{Year: 2019}, &numStrGener{rangeInt64Gener{-1000, 1000}}},
+			constants: []*Constant{nil, nil, {Value: types.NewStringDatum("MONTH"), RetType: types.NewFieldType(mysql.TypeString)}}},
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateTimeStrGener{Year: 2019}, &selectStringGener{candidates: []string{"1 1:1:1", "-2 10:0:0", "0 0:0:1"}}},
+			constants: []*Constant{nil, nil, {Value: types.NewStringDatum("DAY_SECOND"), RetType: types.NewFieldType(mysql.TypeString)}}},
+	},
+	ast.DateDiff: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDatetime, types.ETDatetime}, geners: []dataGenerator{&dateTimeGener{}, &dateTimeGener{}}},
+	},
+	ast.DateFormat: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETDatetime, types.ETString},
+			geners: []dataGenerator{&dateTimeGener{Fsp: 6}, &selectStringGener{candidates: []string{"%Y-%m-%d", "%H:%i:%s.%f", "%W %M %D %y", "%j %p %r"}}}},
+	},
+	ast.StrToDate: {
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateStrGener{}},
+			constants: []*Constant{nil, {Value: types.NewStringDatum("%Y-%m-%d"), RetType: types.NewFieldType(mysql.TypeString)}}},
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:    []dataGenerator{&dateTimeStrGener{}},
+			constants: []*Constant{nil, {Value: types.NewStringDatum("%Y-%m-%d %H:%i:%s"), RetType: types.NewFieldType(mysql.TypeString)}}},
+		{retEvalType: types.ETDuration, childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:    []dataGenerator{&timeStrGener{}},
+			constants: []*Constant{nil, {Value: types.NewStringDatum("%H:%i:%s"), RetType: types.NewFieldType(mysql.TypeString)}}},
+	},
+	ast.UnixTimestamp: {
+		{retEvalType: types.ETInt},
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDatetime}, childrenFieldTypes: []*types.FieldType{{Tp: mysql.TypeDatetime, Decimal: 0}}, geners: []dataGenerator{&dateTimeGener{}}},
+		{retEvalType: types.ETDecimal, childrenTypes: []types.EvalType{types.ETDatetime}, childrenFieldTypes: []*types.FieldType{{Tp: mysql.TypeDatetime, Decimal: 3}}, geners: []dataGenerator{&dateTimeGener{Fsp: 3}}},
+	},
+	ast.FromUnixTime: {
+		{retEvalType: types.ETDatetime, childrenTypes: []types.EvalType{types.ETInt}, geners: []dataGenerator{&rangeInt64Gener{-1000, math.MaxInt32 + 1000}}},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETInt, types.ETString},
+			geners: []dataGenerator{&rangeInt64Gener{0, math.MaxInt32}, &selectStringGener{candidates: []string{"%Y %D %M %h:%i:%s %x", "%Y-%m-%d"}}}},
+	},
+	ast.Year: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDatetime}},
+	},
+	ast.Month: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDatetime}},
+	},
+	ast.DayOfMonth: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDatetime}},
+	},
+	ast.Hour: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETDuration}},
+	},
+}
+
+func (s *testEvaluatorSuite) TestVectorizedBuiltinTimeFunc(c *C) {
+	testVectorizedBuiltinFunc(c, vecBuiltinTimeCases)
+}
+
+func BenchmarkVectorizedBuiltinTimeFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinTimeCases)
+}
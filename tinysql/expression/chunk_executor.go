@@ -101,6 +101,22 @@ func evalOneVec(ctx sessionctx.Context, expr Expression, input *chunk.Chunk, out
 			}
 			output.SetCol(colIdx, buf)
 		}
+	case types.ETDecimal:
+		if err := expr.VecEvalDecimal(ctx, input, result); err != nil {
+			return err
+		}
+	case types.ETDatetime, types.ETTimestamp:
+		if err := expr.VecEvalTime(ctx, input, result); err != nil {
+			return err
+		}
+	case types.ETDuration:
+		if err := expr.VecEvalDuration(ctx, input, result); err != nil {
+			return err
+		}
+	case types.ETJson:
+		if err := expr.VecEvalJSON(ctx, input, result); err != nil {
+			return err
+		}
 	case types.ETString:
 		if err := expr.VecEvalString(ctx, input, result); err != nil {
 			return err
@@ -119,6 +135,22 @@ func evalOneColumn(ctx sessionctx.Context, expr Expression, iterator *chunk.Iter
 		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
 			err = executeToReal(ctx, expr, fieldType, row, output, colID)
 		}
+	case types.ETDecimal:
+		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
+			err = executeToDecimal(ctx, expr, fieldType, row, output, colID)
+		}
+	case types.ETDatetime, types.ETTimestamp:
+		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
+			err = executeToDatetime(ctx, expr, fieldType, row, output, colID)
+		}
+	case types.ETDuration:
+		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
+			err = executeToDuration(ctx, expr, fieldType, row, output, colID)
+		}
+	case types.ETJson:
+		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
+			err = executeToJSON(ctx, expr, fieldType, row, output, colID)
+		}
 	case types.ETString:
 		for row := iterator.Begin(); err == nil && row != iterator.End(); row = iterator.Next() {
 			err = executeToString(ctx, expr, fieldType, row, output, colID)
@@ -133,6 +165,14 @@ func evalOneCell(ctx sessionctx.Context, expr Expression, row chunk.Row, output
 		err = executeToInt(ctx, expr, fieldType, row, output, colID)
 	case types.ETReal:
 		err = executeToReal(ctx, expr, fieldType, row, output, colID)
+	case types.ETDecimal:
+		err = executeToDecimal(ctx, expr, fieldType, row, output, colID)
+	case types.ETDatetime, types.ETTimestamp:
+		err = executeToDatetime(ctx, expr, fieldType, row, output, colID)
+	case types.ETDuration:
+		err = executeToDuration(ctx, expr, fieldType, row, output, colID)
+	case types.ETJson:
+		err = executeToJSON(ctx, expr, fieldType, row, output, colID)
 	case types.ETString:
 		err = executeToString(ctx, expr, fieldType, row, output, colID)
 	}
@@ -177,6 +217,58 @@ func executeToReal(ctx sessionctx.Context, expr Expression, fieldType *types.Fie
 	return nil
 }
 
+func executeToDecimal(ctx sessionctx.Context, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
+	res, isNull, err := expr.EvalDecimal(ctx, row)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		output.AppendNull(colID)
+		return nil
+	}
+	output.AppendMyDecimal(colID, res)
+	return nil
+}
+
+func executeToDatetime(ctx sessionctx.Context, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
+	res, isNull, err := expr.EvalTime(ctx, row)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		output.AppendNull(colID)
+	} else {
+		output.AppendTime(colID, res)
+	}
+	return nil
+}
+
+func executeToDuration(ctx sessionctx.Context, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
+	res, isNull, err := expr.EvalDuration(ctx, row)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		output.AppendNull(colID)
+	} else {
+		output.AppendDuration(colID, res)
+	}
+	return nil
+}
+
+func executeToJSON(ctx sessionctx.Context, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
+	res, isNull, err := expr.EvalJSON(ctx, row)
+	if err != nil {
+		return err
+	}
+	if isNull {
+		output.AppendNull(colID)
+	} else {
+		output.AppendJSON(colID, res)
+	}
+	return nil
+}
+
 func executeToString(ctx sessionctx.Context, expr Expression, fieldType *types.FieldType, row chunk.Row, output *chunk.Chunk, colID int) error {
 	res, isNull, err := expr.EvalString(ctx, row)
 	if err != nil {
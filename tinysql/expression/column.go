@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 )
@@ -41,6 +42,9 @@ type Column struct {
 
 	hashcode []byte
 
+	// VirtualExpr is used to save expression for virtual column
+	VirtualExpr Expression
+
 	OrigName string
 }
 
@@ -76,6 +80,22 @@ func (col *Column) VecEvalInt(ctx sessionctx.Context, input *chunk.Chunk, result
 
 // VecEvalReal evaluates this expression in a vectorized manner.
 func (col *Column) VecEvalReal(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	if col.RetType.Hybrid() {
+		it := chunk.NewIterator4Chunk(input)
+		result.ResizeFloat64(0, false)
+		for row := it.Begin(); row != it.End(); row = it.Next() {
+			v, null, err := col.EvalReal(ctx, row)
+			if err != nil {
+				return err
+			}
+			if null {
+				result.AppendNull()
+			} else {
+				result.AppendFloat64(v)
+			}
+		}
+		return nil
+	}
 	n := input.NumRows()
 	src := input.Column(col.Index)
 	if col.GetType().Tp == mysql.TypeFloat {
@@ -107,6 +127,46 @@ func (col *Column) VecEvalReal(ctx sessionctx.Context, input *chunk.Chunk, resul
 	return nil
 }
 
+// VecEvalDecimal evaluates this expression in a vectorized manner.
+func (col *Column) VecEvalDecimal(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	if col.RetType.Hybrid() {
+		it := chunk.NewIterator4Chunk(input)
+		result.ResizeDecimal(0, false)
+		for row := it.Begin(); row != it.End(); row = it.Next() {
+			v, null, err := col.EvalDecimal(ctx, row)
+			if err != nil {
+				return err
+			}
+			if null {
+				result.AppendNull()
+			} else {
+				result.AppendMyDecimal(v)
+			}
+		}
+		return nil
+	}
+	input.Column(col.Index).CopyReconstruct(input.Sel(), result)
+	return nil
+}
+
+// VecEvalTime evaluates this expression in a vectorized manner.
+func (col *Column) VecEvalTime(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	input.Column(col.Index).CopyReconstruct(input.Sel(), result)
+	return nil
+}
+
+// VecEvalDuration evaluates this expression in a vectorized manner.
+func (col *Column) VecEvalDuration(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	input.Column(col.Index).CopyReconstruct(input.Sel(), result)
+	return nil
+}
+
+// VecEvalJSON evaluates this expression in a vectorized manner.
+func (col *Column) VecEvalJSON(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	input.Column(col.Index).CopyReconstruct(input.Sel(), result)
+	return nil
+}
+
 // VecEvalString evaluates this expression in a vectorized manner.
 func (col *Column) VecEvalString(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
 	if col.RetType.Hybrid() || ctx.GetSessionVars().StmtCtx.PadCharToFullLength {
@@ -177,6 +237,11 @@ func (col *Column) EvalReal(ctx sessionctx.Context, row chunk.Row) (float64, boo
 	if row.IsNull(col.Index) {
 		return 0, true, nil
 	}
+	if col.GetType().Hybrid() {
+		val := row.GetDatum(col.Index, col.RetType)
+		res, err := val.ToFloat64(ctx.GetSessionVars().StmtCtx)
+		return res, err != nil, err
+	}
 	if col.GetType().Tp == mysql.TypeFloat {
 		return float64(row.GetFloat32(col.Index)), false, nil
 	}
@@ -206,6 +271,49 @@ func (col *Column) EvalString(ctx sessionctx.Context, row chunk.Row) (string, bo
 	return val, false, nil
 }
 
+// EvalDecimal returns decimal representation of Column.
+func (col *Column) EvalDecimal(ctx sessionctx.Context, row chunk.Row) (*types.MyDecimal, bool, error) {
+	if row.IsNull(col.Index) {
+		return nil, true, nil
+	}
+	if col.GetType().Hybrid() {
+		val := row.GetDatum(col.Index, col.RetType)
+		res, err := val.ToDecimal(ctx.GetSessionVars().StmtCtx)
+		return res, err != nil, err
+	}
+	return row.GetMyDecimal(col.Index), false, nil
+}
+
+// EvalTime returns DATE/DATETIME/TIMESTAMP representation of Column.
+func (col *Column) EvalTime(ctx sessionctx.Context, row chunk.Row) (types.Time, bool, error) {
+	if row.IsNull(col.Index) {
+		return types.ZeroTime, true, nil
+	}
+	return row.GetTime(col.Index), false, nil
+}
+
+// EvalDuration returns Duration representation of Column.
+func (col *Column) EvalDuration(ctx sessionctx.Context, row chunk.Row) (types.Duration, bool, error) {
+	if row.IsNull(col.Index) {
+		return types.Duration{}, true, nil
+	}
+	duration := row.GetDuration(col.Index, col.RetType.Decimal)
+	return duration, false, nil
+}
+
+// EvalJSON returns JSON representation of Column.
+func (col *Column) EvalJSON(ctx sessionctx.Context, row chunk.Row) (json.BinaryJSON, bool, error) {
+	if row.IsNull(col.Index) {
+		return json.BinaryJSON{}, true, nil
+	}
+	return row.GetJSON(col.Index), false, nil
+}
+
+// EvalVirtualColumn evals the virtual column
+func (col *Column) EvalVirtualColumn(row chunk.Row) (types.Datum, error) {
+	return col.VirtualExpr.Eval(row)
+}
+
 // Clone implements Expression interface.
 func (col *Column) Clone() Expression {
 	newCol := *col
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 )
@@ -45,15 +46,35 @@ var (
 	}
 )
 
+// ParamMarker indicates param provided by COM_STMT_EXECUTE.
+type ParamMarker struct {
+	ctx   sessionctx.Context
+	order int
+}
+
+// GetUserVar returns the corresponding user variable presented in the `EXECUTE` statement or `COM_EXECUTE` command.
+func (d *ParamMarker) GetUserVar() types.Datum {
+	sessionVars := d.ctx.GetSessionVars()
+	return sessionVars.PreparedParams[d.order]
+}
+
 // Constant stands for a constant value.
 type Constant struct {
-	Value    types.Datum
-	RetType  *types.FieldType
-	hashcode []byte
+	Value   types.Datum
+	RetType *types.FieldType
+	// ParamMarker holds param index inside sessionVars.PreparedParams.
+	// It's only used to reference a user variable provided in the `EXECUTE` statement or `COM_EXECUTE` binary protocol,
+	// so that a cached plan always evaluates the parameters of the current execution.
+	ParamMarker *ParamMarker
+	hashcode    []byte
 }
 
 // String implements fmt.Stringer interface.
 func (c *Constant) String() string {
+	if c.ParamMarker != nil {
+		dt := c.ParamMarker.GetUserVar()
+		return fmt.Sprintf("%v", dt.GetValue())
+	}
 	return fmt.Sprintf("%v", c.Value.GetValue())
 }
 
@@ -87,50 +108,124 @@ func (c *Constant) VecEvalString(ctx sessionctx.Context, input *chunk.Chunk, res
 	return genVecFromConstExpr(ctx, c, types.ETString, input, result)
 }
 
+// VecEvalDecimal evaluates this expression in a vectorized manner.
+func (c *Constant) VecEvalDecimal(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	return genVecFromConstExpr(ctx, c, types.ETDecimal, input, result)
+}
+
+// VecEvalTime evaluates this expression in a vectorized manner.
+func (c *Constant) VecEvalTime(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	return genVecFromConstExpr(ctx, c, types.ETTimestamp, input, result)
+}
+
+// VecEvalDuration evaluates this expression in a vectorized manner.
+func (c *Constant) VecEvalDuration(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	return genVecFromConstExpr(ctx, c, types.ETDuration, input, result)
+}
+
+// VecEvalJSON evaluates this expression in a vectorized manner.
+func (c *Constant) VecEvalJSON(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	return genVecFromConstExpr(ctx, c, types.ETJson, input, result)
+}
+
+// getDatum returns the value of the constant, which is read from the current
+// parameters if the constant is a parameter marker.
+func (c *Constant) getDatum() types.Datum {
+	if c.ParamMarker != nil {
+		return c.ParamMarker.GetUserVar()
+	}
+	return c.Value
+}
+
 // Eval implements Expression interface.
 func (c *Constant) Eval(_ chunk.Row) (types.Datum, error) {
-	return c.Value, nil
+	return c.getDatum(), nil
 }
 
 // EvalInt returns int representation of Constant.
 func (c *Constant) EvalInt(ctx sessionctx.Context, _ chunk.Row) (int64, bool, error) {
-	if c.GetType().Tp == mysql.TypeNull || c.Value.IsNull() {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
 		return 0, true, nil
 	}
-	if c.GetType().Hybrid() || c.Value.Kind() == types.KindString {
-		res, err := c.Value.ToInt64(ctx.GetSessionVars().StmtCtx)
+	if c.GetType().Hybrid() || dt.Kind() == types.KindBinaryLiteral || dt.Kind() == types.KindString {
+		res, err := dt.ToInt64(ctx.GetSessionVars().StmtCtx)
 		return res, err != nil, err
 	}
-	return c.Value.GetInt64(), false, nil
+	return dt.GetInt64(), false, nil
 }
 
 // EvalReal returns real representation of Constant.
 func (c *Constant) EvalReal(ctx sessionctx.Context, _ chunk.Row) (float64, bool, error) {
-	if c.GetType().Tp == mysql.TypeNull || c.Value.IsNull() {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
 		return 0, true, nil
 	}
-	if c.GetType().Hybrid() || c.Value.Kind() == types.KindString {
-		res, err := c.Value.ToFloat64(ctx.GetSessionVars().StmtCtx)
+	if c.GetType().Hybrid() || dt.Kind() == types.KindBinaryLiteral || dt.Kind() == types.KindString {
+		res, err := dt.ToFloat64(ctx.GetSessionVars().StmtCtx)
 		return res, err != nil, err
 	}
-	return c.Value.GetFloat64(), false, nil
+	return dt.GetFloat64(), false, nil
 }
 
 // EvalString returns string representation of Constant.
 func (c *Constant) EvalString(ctx sessionctx.Context, _ chunk.Row) (string, bool, error) {
-	if c.GetType().Tp == mysql.TypeNull || c.Value.IsNull() {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
 		return "", true, nil
 	}
-	res, err := c.Value.ToString()
+	res, err := dt.ToString()
 	return res, err != nil, err
 }
 
+// EvalDecimal returns decimal representation of Constant.
+func (c *Constant) EvalDecimal(ctx sessionctx.Context, _ chunk.Row) (*types.MyDecimal, bool, error) {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
+		return nil, true, nil
+	}
+	res, err := dt.ToDecimal(ctx.GetSessionVars().StmtCtx)
+	return res, err != nil, err
+}
+
+// EvalTime returns DATE/DATETIME/TIMESTAMP representation of Constant.
+func (c *Constant) EvalTime(ctx sessionctx.Context, _ chunk.Row) (val types.Time, isNull bool, err error) {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
+		return types.ZeroTime, true, nil
+	}
+	return dt.GetMysqlTime(), false, nil
+}
+
+// EvalDuration returns Duration representation of Constant.
+func (c *Constant) EvalDuration(ctx sessionctx.Context, _ chunk.Row) (val types.Duration, isNull bool, err error) {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
+		return types.Duration{}, true, nil
+	}
+	return dt.GetMysqlDuration(), false, nil
+}
+
+// EvalJSON returns JSON representation of Constant.
+func (c *Constant) EvalJSON(ctx sessionctx.Context, _ chunk.Row) (json.BinaryJSON, bool, error) {
+	dt := c.getDatum()
+	if c.GetType().Tp == mysql.TypeNull || dt.IsNull() {
+		return json.BinaryJSON{}, true, nil
+	}
+	return dt.GetMysqlJSON(), false, nil
+}
+
 // Equal implements Expression interface.
 func (c *Constant) Equal(ctx sessionctx.Context, b Expression) bool {
 	y, ok := b.(*Constant)
 	if !ok {
 		return false
 	}
+	// Parameters of a cached plan may differ in later executions, so they
+	// are only equal to themselves.
+	if c.ParamMarker != nil || y.ParamMarker != nil {
+		return c.ParamMarker != nil && y.ParamMarker != nil && c.ParamMarker.order == y.ParamMarker.order
+	}
 	_, err1 := y.Eval(chunk.Row{})
 	_, err2 := c.Eval(chunk.Row{})
 	if err1 != nil || err2 != nil {
@@ -149,8 +244,9 @@ func (c *Constant) IsCorrelated() bool {
 }
 
 // ConstItem implements Expression interface.
+// A parameter marker of a cached plan is not constant among executions.
 func (c *Constant) ConstItem() bool {
-	return true
+	return c.ParamMarker == nil
 }
 
 // Decorrelate implements Expression interface.
@@ -163,6 +259,11 @@ func (c *Constant) HashCode(sc *stmtctx.StatementContext) []byte {
 	if len(c.hashcode) > 0 {
 		return c.hashcode
 	}
+	if c.ParamMarker != nil {
+		c.hashcode = append(c.hashcode, parameterFlag)
+		c.hashcode = codec.EncodeInt(c.hashcode, int64(c.ParamMarker.order))
+		return c.hashcode
+	}
 	_, err := c.Eval(chunk.Row{})
 	if err != nil {
 		terror.Log(err)
@@ -39,6 +39,12 @@ func foldConstant(expr Expression) Expression {
 		for i := 0; i < len(args); i++ {
 			switch x := args[i].(type) {
 			case *Constant:
+				// A parameter marker of a cached plan can't be folded,
+				// its value changes among executions.
+				if x.ParamMarker != nil {
+					allConstArg = false
+					continue
+				}
 				argIsConst[i] = true
 				hasNullArg = hasNullArg || x.Value.IsNull()
 			default:
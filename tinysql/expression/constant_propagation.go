@@ -64,18 +64,19 @@ func (s *basePropConstSolver) tryToUpdateEQList(col *Column, con *Constant) (boo
 }
 
 // validEqualCond checks if the cond is an expression like [column eq constant].
+// The parameters of a cached plan are not propagated since their values change among executions.
 func validEqualCond(cond Expression) (*Column, *Constant) {
 	if eq, ok := cond.(*ScalarFunction); ok {
 		if eq.FuncName.L != ast.EQ {
 			return nil, nil
 		}
 		if col, colOk := eq.GetArgs()[0].(*Column); colOk {
-			if con, conOk := eq.GetArgs()[1].(*Constant); conOk {
+			if con, conOk := eq.GetArgs()[1].(*Constant); conOk && con.ConstItem() {
 				return col, con
 			}
 		}
 		if col, colOk := eq.GetArgs()[1].(*Column); colOk {
-			if con, conOk := eq.GetArgs()[0].(*Constant); conOk {
+			if con, conOk := eq.GetArgs()[0].(*Constant); conOk && con.ConstItem() {
 				return col, con
 			}
 		}
@@ -245,7 +246,7 @@ func (s *propConstSolver) pickNewEQConds(visited []bool) (retMapper map[int]*Con
 		// Then we check if this CNF item is a false constant. If so, we will set the whole condition to false.
 		var ok bool
 		if col == nil {
-			if con, ok = cond.(*Constant); ok {
+			if con, ok = cond.(*Constant); ok && con.ConstItem() {
 				value, _, err := EvalBool(s.ctx, []Expression{con}, chunk.Row{})
 				if err != nil {
 					terror.Log(err)
@@ -337,7 +338,7 @@ func (s *propOuterJoinConstSolver) pickEQCondsOnOuterCol(retMapper map[int]*Cons
 		// Then we check if this CNF item is a false constant. If so, we will set the whole condition to false.
 		var ok bool
 		if col == nil {
-			if con, ok = cond.(*Constant); ok {
+			if con, ok = cond.(*Constant); ok && con.ConstItem() {
 				value, _, err := EvalBool(s.ctx, []Expression{con}, chunk.Row{})
 				if err != nil {
 					terror.Log(err)
@@ -147,7 +147,7 @@ type constraintPropagateRule func(ctx sessionctx.Context, i, j int, exprs *exprS
 // false, a = 1, b = c ... => false
 func ruleConstantFalse(ctx sessionctx.Context, i, j int, exprs *exprSet) {
 	cond := exprs.data[i]
-	if cons, ok := cond.(*Constant); ok {
+	if cons, ok := cond.(*Constant); ok && cons.ConstItem() {
 		v, isNull, err := cons.EvalInt(ctx, chunk.Row{})
 		if err != nil {
 			logutil.BgLogger().Warn("eval constant", zap.Error(err))
@@ -14,6 +14,7 @@
 package expression
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -126,6 +127,11 @@ func (s *testEvaluatorSuite) TestUnaryOp(c *C) {
 		{int64(1), ast.UnaryMinus, int64(-1)},
 		{int64(1), ast.UnaryMinus, int64(-1)},
 		{uint64(1), ast.UnaryMinus, -int64(1)},
+		// test BitNeg.
+		{nil, ast.BitNeg, nil},
+		{-1, ast.BitNeg, uint64(0)},
+		{uint64(0), ast.BitNeg, uint64(math.MaxUint64)},
+		{"3", ast.BitNeg, uint64(math.MaxUint64 - 3)},
 	}
 	for i, t := range tbl {
 		fc := funcs[t.op]
@@ -136,3 +142,44 @@ func (s *testEvaluatorSuite) TestUnaryOp(c *C) {
 		c.Assert(result, testutil.DatumEquals, types.NewDatum(t.result), Commentf("%d", i))
 	}
 }
+
+func (s *testEvaluatorSuite) TestBinopBitop(c *C) {
+	tbl := []struct {
+		lhs interface{}
+		op  string
+		rhs interface{}
+		ret interface{}
+	}{
+		{1, ast.And, 1, 1},
+		{1, ast.Or, 1, 1},
+		{1, ast.Xor, 1, 0},
+		{6, ast.And, 3, 2},
+		{6, ast.Or, 3, 7},
+		{6, ast.Xor, 3, 5},
+		{-1, ast.And, 1, 1},
+		{1, ast.LeftShift, 3, 8},
+		{8, ast.RightShift, 2, 2},
+		{1, ast.LeftShift, 64, 0},
+		{-1, ast.RightShift, 63, 1},
+		{"12", ast.And, "10", 8},
+		{nil, ast.And, 1, nil},
+		{1, ast.Or, nil, nil},
+		{nil, ast.LeftShift, nil, nil},
+	}
+
+	for _, t := range tbl {
+		fc := funcs[t.op]
+		f, err := fc.getFunction(s.ctx, s.datumsToConstants(types.MakeDatums(t.lhs, t.rhs)))
+		c.Assert(err, IsNil)
+		c.Assert(mysql.HasUnsignedFlag(f.getRetTp().Flag), IsTrue)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		c.Assert(err, IsNil)
+
+		switch x := t.ret.(type) {
+		case nil:
+			c.Assert(v.Kind(), Equals, types.KindNull)
+		case int:
+			c.Assert(v, testutil.DatumEquals, types.NewDatum(uint64(x)))
+		}
+	}
+}
@@ -142,6 +142,33 @@ func (pc *PbConverter) encodeDatum(ft *types.FieldType, d types.Datum) (tipb.Exp
 	case types.KindFloat64:
 		tp = tipb.ExprType_Float64
 		val = codec.EncodeFloat(nil, d.GetFloat64())
+	case types.KindMysqlDecimal:
+		tp = tipb.ExprType_MysqlDecimal
+		var err error
+		val, err = codec.EncodeDecimal(nil, d.GetMysqlDecimal(), d.Length(), d.Frac())
+		if err != nil {
+			logutil.BgLogger().Error("encode decimal", zap.Error(err))
+			return tp, nil, false
+		}
+	case types.KindMysqlDuration:
+		tp = tipb.ExprType_MysqlDuration
+		val = codec.EncodeInt(nil, int64(d.GetMysqlDuration().Duration))
+	case types.KindMysqlTime:
+		tp = tipb.ExprType_MysqlTime
+		var err error
+		val, err = codec.EncodeMySQLTime(pc.sc, d.GetMysqlTime(), ft.Tp, nil)
+		if err != nil {
+			logutil.BgLogger().Error("encode mysql time", zap.Error(err))
+			return tp, nil, false
+		}
+	case types.KindMysqlJSON:
+		tp = tipb.ExprType_MysqlJson
+		var err error
+		val, err = codec.EncodeValue(pc.sc, nil, d)
+		if err != nil {
+			logutil.BgLogger().Error("encode json", zap.Error(err))
+			return tp, nil, false
+		}
 	default:
 		return tp, nil, false
 	}
@@ -284,6 +311,12 @@ func (pc PbConverter) canFuncBePushed(sf *ScalarFunction) bool {
 		ast.LogicAnd,
 		ast.LogicOr,
 		ast.UnaryNot,
+		ast.And,
+		ast.Or,
+		ast.Xor,
+		ast.BitNeg,
+		ast.LeftShift,
+		ast.RightShift,
 
 		// compare functions.
 		ast.LT,
@@ -294,19 +327,108 @@ func (pc PbConverter) canFuncBePushed(sf *ScalarFunction) bool {
 		ast.GT,
 		ast.In,
 		ast.IsNull,
+		ast.Coalesce,
+		ast.Greatest,
+		ast.Least,
 
 		// arithmetical functions.
 		ast.Plus,
 		ast.Minus,
 		ast.Mul,
 		ast.Div,
+		ast.IntDiv,
+		ast.Mod,
+
+		// math functions.
+		ast.Abs,
+		ast.Ceil,
+		ast.Ceiling,
+		ast.Floor,
+		ast.Round,
+		ast.Truncate,
+		ast.Pow,
+		ast.Power,
+		ast.Sqrt,
+		ast.Exp,
+		ast.Ln,
+		ast.Log,
+		ast.Log2,
+		ast.Log10,
+		ast.Sign,
+		ast.PI,
+		ast.Sin,
+		ast.Cos,
+		ast.Tan,
+		ast.Cot,
+		ast.Asin,
+		ast.Acos,
+		ast.Atan,
+		ast.Atan2,
+		ast.Degrees,
+		ast.Radians,
 
 		// control flow functions.
+		ast.Case,
 		ast.If,
 		ast.Ifnull,
 
+		// cast functions.
+		ast.Cast,
+
 		// string functions.
-		ast.Length:
+		ast.Length,
+		ast.Concat,
+		ast.ConcatWS,
+		ast.Substring,
+		ast.Substr,
+		ast.Mid,
+		ast.Left,
+		ast.Right,
+		ast.Upper,
+		ast.Ucase,
+		ast.Lower,
+		ast.Lcase,
+		ast.Trim,
+		ast.LTrim,
+		ast.RTrim,
+		ast.Replace,
+		ast.Locate,
+		ast.Position,
+		ast.Instr,
+		ast.Lpad,
+		ast.Rpad,
+		ast.Reverse,
+		ast.CharLength,
+		ast.CharacterLength,
+		ast.Hex,
+		ast.Unhex,
+		ast.FindInSet,
+
+		// time functions.
+		ast.DateAdd,
+		ast.AddDate,
+		ast.DateSub,
+		ast.SubDate,
+		ast.DateDiff,
+		ast.DateFormat,
+		ast.StrToDate,
+		ast.Year,
+		ast.Month,
+		ast.Day,
+		ast.DayOfMonth,
+		ast.Hour,
+
+		// json functions.
+		ast.JSONType,
+		ast.JSONExtract,
+		ast.JSONUnquote,
+		ast.JSONSet,
+		ast.JSONInsert,
+		ast.JSONReplace,
+		ast.JSONRemove,
+		ast.JSONObject,
+		ast.JSONArray,
+		ast.JSONContains:
 		return true
 	}
 	return false
@@ -22,6 +22,7 @@ var unFoldableFunctions = map[string]struct{}{
 	ast.RowFunc: {},
 	ast.SetVar:  {},
 	ast.GetVar:  {},
+	ast.Rand:    {},
 }
 
 // inequalFunctions stores functions which cannot be propagated from column equal condition.
@@ -34,4 +35,29 @@ var inequalFunctions = map[string]struct{}{
 var mutableEffectsFunctions = map[string]struct{}{
 	ast.SetVar: {},
 	ast.GetVar: {},
+	ast.Rand:   {},
+}
+
+// UnCacheableFunctions stores functions which can not be cached to plan cache.
+var UnCacheableFunctions = map[string]struct{}{
+	ast.Now:              {},
+	ast.CurrentTimestamp: {},
+	ast.Curdate:          {},
+	ast.CurrentDate:      {},
+	ast.UnixTimestamp:    {},
+	ast.Rand:             {},
+	ast.SetVar:           {},
+	ast.GetVar:           {},
+}
+
+// IllegalFunctions4GeneratedColumns stores functions that is illegal for generated columns.
+// See https://github.com/mysql/mysql-server/blob/5.7/mysql-test/suite/gcol/inc/gcol_blocked_sql_funcs_main.inc for details
+var IllegalFunctions4GeneratedColumns = map[string]struct{}{
+	ast.Now:              {},
+	ast.CurrentTimestamp: {},
+	ast.Curdate:          {},
+	ast.CurrentDate:      {},
+	ast.Rand:             {},
+	ast.SetVar:           {},
+	ast.GetVar:           {},
 }
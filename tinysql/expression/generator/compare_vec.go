@@ -49,6 +49,7 @@ const newLine = "\n"
 
 const builtinCompareImports = `import (
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 )
 `
@@ -86,6 +87,14 @@ func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vecEvalInt(in
 		}
 {{- if eq .type.ETName "Real" }}
 		val := types.CompareFloat64(arg0[i], arg1[i])
+{{- else if eq .type.ETName "Decimal" }}
+		val := arg0[i].Compare(&arg1[i])
+{{- else if eq .type.ETName "Datetime" }}
+		val := arg0[i].Compare(arg1[i])
+{{- else if eq .type.ETName "Duration" }}
+		val := types.CompareDuration(arg0[i], arg1[i])
+{{- else if eq .type.ETName "Json" }}
+		val := json.CompareBinary(buf0.GetJSON(i), buf1.GetJSON(i))
 {{- else }}
 		val := types.CompareString(buf0.GetString(i), buf1.GetString(i))
 {{- end }}
@@ -103,6 +112,113 @@ func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vectorized()
 }
 `))
 
+var builtinCoalesceCompareVecTpl = template.Must(template.New("").Parse(`
+// NOTE: Coalesce just return the first non-null item, but vectorization do each item, which would incur additional errors. If this case happen,
+// the vectorization falls back to the scalar execution.
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) fallbackEval{{ .type.TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+{{- if .type.Fixed }}
+	x := result.{{ .type.TypeNameInColumn }}s()
+	for i := 0; i < n; i++ {
+		res, isNull, err := b.eval{{ .type.TypeName }}(input.GetRow(i))
+		if err != nil {
+			return err
+		}
+		result.SetNull(i, isNull)
+		if isNull {
+			continue
+		}
+		x[i] = {{ if eq .type.TypeName "Decimal" }}*{{ end }}res{{ if eq .type.TypeName "Duration" }}.Duration{{ end }}
+	}
+{{- else }}
+	result.Reserve{{ .type.TypeNameInColumn }}(n)
+	for i := 0; i < n; i++ {
+		res, isNull, err := b.eval{{ .type.TypeName }}(input.GetRow(i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.Append{{ .type.TypeNameInColumn }}(res)
+	}
+{{- end }}
+	return nil
+}
+
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vecEval{{ .type.TypeName }}(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+{{- if .type.Fixed }}
+	result.Resize{{ .type.TypeNameInColumn }}(n, true)
+	x := result.{{ .type.TypeNameInColumn }}s()
+	buf1, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf1)
+	sc := b.ctx.GetSessionVars().StmtCtx
+	beforeWarns := sc.WarningCount()
+	for j := 0; j < len(b.args); j++ {
+		err := b.args[j].VecEval{{ .type.TypeName }}(b.ctx, input, buf1)
+		afterWarns := sc.WarningCount()
+		if err != nil || afterWarns > beforeWarns {
+			if afterWarns > beforeWarns {
+				sc.TruncateWarnings(int(beforeWarns))
+			}
+			return b.fallbackEval{{ .type.TypeName }}(input, result)
+		}
+		args := buf1.{{ .type.TypeNameInColumn }}s()
+		for i := 0; i < n; i++ {
+			if !buf1.IsNull(i) && result.IsNull(i) {
+				x[i] = args[i]
+				result.SetNull(i, false)
+			}
+		}
+	}
+{{- else }}
+	argLen := len(b.args)
+
+	bufs := make([]*chunk.Column, argLen)
+	sc := b.ctx.GetSessionVars().StmtCtx
+	beforeWarns := sc.WarningCount()
+	for i := 0; i < argLen; i++ {
+		buf, err := b.bufAllocator.get(types.ET{{ .type.ETName }}, n)
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(buf)
+		err = b.args[i].VecEval{{ .type.TypeName }}(b.ctx, input, buf)
+		afterWarns := sc.WarningCount()
+		if err != nil || afterWarns > beforeWarns {
+			if afterWarns > beforeWarns {
+				sc.TruncateWarnings(int(beforeWarns))
+			}
+			return b.fallbackEval{{ .type.TypeName }}(input, result)
+		}
+		bufs[i] = buf
+	}
+	result.Reserve{{ .type.TypeNameInColumn }}(n)
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < argLen; j++ {
+			if !bufs[j].IsNull(i) {
+				result.Append{{ .type.TypeNameInColumn }}(bufs[j].Get{{ .type.TypeNameInColumn }}(i))
+				break
+			} else if j == argLen-1 && bufs[j].IsNull(i) {
+				result.AppendNull()
+			}
+		}
+	}
+{{- end }}
+	return nil
+}
+
+func (b *builtin{{ .compare.CompareName }}{{ .type.TypeName }}Sig) vectorized() bool {
+	return true
+}
+`))
+
 const builtinCompareVecTestHeader = `import (
 	"testing"
 
@@ -120,6 +236,9 @@ var builtinCompareVecTestFuncHeader = template.Must(template.New("").Parse(`	ast
 var builtinCompareVecTestCase = template.Must(template.New("").Parse(`		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ET{{ .ETName }}, types.ET{{ .ETName }}}},
 `))
 
+var builtinCoalesceCompareVecTestCase = template.Must(template.New("").Parse(`		{retEvalType: types.ET{{ .ETName }}, childrenTypes: []types.EvalType{types.ET{{ .ETName }}, types.ET{{ .ETName }}, types.ET{{ .ETName }}}},
+`))
+
 var builtinCompareVecTestFuncTail = `	},
 `
 
@@ -158,10 +277,18 @@ var comparesMap = []CompareContext{
 	{CompareName: "NE", Operator: "!="},
 }
 
+var coalesceMap = []CompareContext{
+	{CompareName: "Coalesce"},
+}
+
 var typesMap = []TypeContext{
 	TypeInt,
 	TypeReal,
+	TypeDecimal,
 	TypeString,
+	TypeDatetime,
+	TypeDuration,
+	TypeJSON,
 }
 
 func generateDotGo(fileName string, compares []CompareContext, types []TypeContext) (err error) {
@@ -184,6 +311,16 @@ func generateDotGo(fileName string, compares []CompareContext, types []TypeConte
 			}
 		}
 	}
+	for _, compareCtx := range coalesceMap {
+		for _, typeCtx := range types {
+			ctx["compare"] = compareCtx
+			ctx["type"] = typeCtx
+			err := builtinCoalesceCompareVecTpl.Execute(w, ctx)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	data, err := format.Source(w.Bytes())
 	if err != nil {
 		log.Println("[Warn]", fileName+": gofmt failed", err)
@@ -213,6 +350,19 @@ func generateTestDotGo(fileName string, compares []CompareContext, types []TypeC
 		}
 		w.WriteString(builtinCompareVecTestFuncTail)
 	}
+	for _, compareCtx := range coalesceMap {
+		err := builtinCompareVecTestFuncHeader.Execute(w, compareCtx)
+		if err != nil {
+			return err
+		}
+		for _, typeCtx := range types {
+			err := builtinCoalesceCompareVecTestCase.Execute(w, typeCtx)
+			if err != nil {
+				return err
+			}
+		}
+		w.WriteString(builtinCompareVecTestFuncTail)
+	}
 	w.WriteString(builtinCompareVecTestTail)
 
 	data, err := format.Source(w.Bytes())
@@ -49,6 +49,7 @@ const newLine = "\n"
 const builtinOtherImports = `import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 )
 `
@@ -94,6 +95,17 @@ var builtinInTmpl = template.Must(template.New("builtinInTmpl").Parse(`
 					compareResult = 0
 				}
 		}
+	{{- else if eq .Input.TypeName "Decimal" -}}
+		compareResult = 1
+		if arg0.Compare(&arg1) == 0 {
+			compareResult = 0
+		}
+	{{- else if eq .Input.TypeName "Time" -}}
+		compareResult = arg0.Compare(arg1)
+	{{- else if eq .Input.TypeName "Duration" -}}
+		compareResult = types.CompareDuration(arg0, arg1)
+	{{- else if eq .Input.TypeName "JSON" -}}
+		compareResult = json.CompareBinary(arg0, arg1)
 	{{- else -}}
 		compareResult = types.Compare{{ .Input.TypeNameInColumn }}(arg0, arg1)
 	{{- end -}}
@@ -185,10 +197,12 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 )
 
 type inGener struct {
@@ -211,8 +225,20 @@ func (g inGener) gen() interface{} {
 			return -float64(randNum)
 		}
 		return float64(randNum)
+	case types.ETDecimal:
+		if rand.Float64() < 0.5 {
+			return types.NewDecFromInt(-randNum)
+		}
+		return types.NewDecFromInt(randNum)
 	case types.ETString:
 		return fmt.Sprint(randNum)
+	case types.ETDatetime, types.ETTimestamp:
+		gt := types.FromDate(2019, 11, 2, 22, 0, int(randNum), rand.Intn(1000000))
+		return types.NewTime(gt, convertETType(g.eType), 0)
+	case types.ETDuration:
+		return types.Duration{Duration: time.Duration(randNum)}
+	case types.ETJson:
+		return json.CreateBinary(randNum)
 	}
 	return randNum
 }
@@ -269,6 +295,10 @@ var inSigsTmpl = []sig{
 	{SigName: "builtinInIntSig", Input: TypeInt, Output: TypeInt},
 	{SigName: "builtinInStringSig", Input: TypeString, Output: TypeInt},
 	{SigName: "builtinInRealSig", Input: TypeReal, Output: TypeInt},
+	{SigName: "builtinInDecimalSig", Input: TypeDecimal, Output: TypeInt},
+	{SigName: "builtinInTimeSig", Input: TypeDatetime, Output: TypeInt},
+	{SigName: "builtinInDurationSig", Input: TypeDuration, Output: TypeInt},
+	{SigName: "builtinInJSONSig", Input: TypeJSON, Output: TypeInt},
 }
 
 type function struct {
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/util/testkit"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 var _ = Suite(&testIntegrationSuite{})
@@ -200,8 +201,12 @@ func (s *testIntegrationSuite) TestForeignKeyVar(c *C) {
 
 	tk := testkit.NewTestKit(c, s.store)
 
+	tk.MustQuery("select @@foreign_key_checks").Check(testkit.Rows("ON"))
+	tk.MustExec("SET FOREIGN_KEY_CHECKS=0")
+	tk.MustQuery("select @@foreign_key_checks").Check(testkit.Rows("OFF"))
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("select @@foreign_key_checks").Check(testkit.Rows("ON"))
 }
 
 func (s *testIntegrationSuite) TestIssue10804(c *C) {
@@ -298,3 +303,353 @@ func (s *testIntegrationSuite) TestDecodetoChunkReuse(c *C) {
 	c.Assert(count, Equals, 200)
 	rs.Close()
 }
+
+func (s *testIntegrationSuite) TestControlAndCompareBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c varchar(20))")
+	tk.MustExec("insert into t values (1, 2, 'x'), (2, null, 'y'), (null, 3, null)")
+
+	tk.MustQuery("select case a when 1 then 'one' when 2 then 'two' else 'other' end from t").Check(testkit.Rows("one", "two", "other"))
+	tk.MustQuery("select case when a > 1 then a when b > 2 then b end from t").Check(testkit.Rows("<nil>", "2", "3"))
+	tk.MustQuery("select coalesce(a, b), coalesce(c, 'z'), coalesce(null, null) from t").Check(testkit.Rows("1 x <nil>", "2 y <nil>", "3 z <nil>"))
+	tk.MustQuery("select nullif(a, 1), nullif(c, 'y') from t").Check(testkit.Rows("<nil> x", "2 <nil>", "<nil> <nil>"))
+	tk.MustQuery("select greatest(a, b), least(a, b) from t").Check(testkit.Rows("2 1", "<nil> <nil>", "<nil> <nil>"))
+	tk.MustQuery("select greatest(1, 2.5, 0), least('b', 'a', 'c'), greatest(3, 7, 5)").Check(testkit.Rows("2.5 a 7"))
+	tk.MustQuery("select a from t where coalesce(b, 0) > 2").Check(testkit.Rows("<nil>"))
+}
+
+func (s *testIntegrationSuite) TestCastFuncs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b double, c varchar(20))")
+	tk.MustExec("insert into t values (1, 1.5, '12abc'), (-1, -2.5, '3.7'), (null, null, null)")
+
+	tk.MustQuery("select cast(a as unsigned), cast(b as signed), cast(c as signed) from t").Check(testkit.Rows(
+		"1 2 12", "18446744073709551615 -3 3", "<nil> <nil> <nil>"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect INTEGER value: '12abc'",
+		"Warning 1292 Truncated incorrect INTEGER value: '3.7'"))
+	tk.MustQuery("select cast(a as char(1)), convert(b, char), cast(c as double) from t where a = 1").Check(testkit.Rows("1 1.5 12"))
+	tk.MustQuery("select cast('abcdef' as char(3)), cast(12 as binary(4)) = '12\\0\\0', convert('-1', unsigned)").Check(testkit.Rows("abc 1 18446744073709551615"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect CHAR(3) value: 'abcdef'",
+		"Warning 8031 Cast to unsigned converted negative integer to it's positive complement"))
+	tk.MustQuery("select convert('abc', binary(2)), cast(c as char(2)) from t where a = 1").Check(testkit.Rows("ab 12"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect BINARY(2) value: 'abc'",
+		"Warning 1292 Truncated incorrect CHAR(2) value: '12abc'"))
+	tk.MustQuery("select cast('99999999999999999999' as signed)").Check(testkit.Rows("-1"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect INTEGER value: '99999999999999999999'"))
+
+	// Implicit casts are inserted for arguments of mismatched types.
+	tk.MustQuery("select a + c, c > 3 from t where a = -1").Check(testkit.Rows("2.7 1"))
+	tk.MustQuery("select a from t where c = 3.7").Check(testkit.Rows("-1"))
+	tk.MustQuery("select cast(a as decimal), cast(b as decimal(5, 2)), cast(c as decimal(4, 1)) from t").Check(testkit.Rows(
+		"1 1.50 12.0", "-1 -2.50 3.7", "<nil> <nil> <nil>"))
+}
+
+func (s *testIntegrationSuite) TestDecimal(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b decimal(10, 2), c decimal(20, 6), index idx_b(b))")
+	tk.MustExec("insert into t values (1, 1.25, 0.1), (2, -3.5, 0.2), (3, 10.005, 100), (4, null, null)")
+
+	tk.MustQuery("select b, c from t").Check(testkit.Rows("1.25 0.100000", "-3.50 0.200000", "10.01 100.000000", "<nil> <nil>"))
+	tk.MustQuery("select 0.1 + 0.2 = 0.3, 0.1 + 0.2, 1.5 * 2.25, 1 / 3, 10.50 - 0.75, -(0.5)").Check(testkit.Rows("1 0.3 3.375 0.3333 9.75 -0.5"))
+	tk.MustQuery("select b + c, b - a, b * c, b / a, b % 1, b div 1 from t where a < 4").Check(testkit.Rows(
+		"1.350000 0.25 0.12500000 1.250000 0.25 1",
+		"-3.300000 -5.50 -0.70000000 -1.750000 -0.50 -3",
+		"110.010000 7.01 1001.00000000 3.336667 0.01 10"))
+	tk.MustQuery("select abs(b), ceil(b), floor(b), round(b), round(b, 1), truncate(b, 1) from t where a < 3").Check(testkit.Rows(
+		"1.25 2 1 1 1.3 1.2",
+		"3.50 -3 -4 -4 -3.5 -3.5"))
+	tk.MustQuery("select sum(a), sum(b), avg(a), avg(b), max(c), min(b), count(b) from t").Check(testkit.Rows(
+		"10 7.76 2.5000 2.586667 100.000000 -3.50 3"))
+	// The integers are widened to decimal by the aggregation, so the partial sum is pushed down as is.
+	tk.MustQuery("explain select /*+ AGG_TO_COP() */ sum(a), avg(a) from t").Check(testkit.Rows(
+		"StreamAgg_11 1.00 root funcs:sum(Column#7)->Column#5, funcs:avg(Column#8, Column#9)->Column#6",
+		"└─TableReader_12 1.00 root data:StreamAgg_5",
+		"  └─StreamAgg_5 1.00 cop funcs:sum(test.t.a)->Column#7, funcs:count(test.t.a)->Column#8, funcs:sum(test.t.a)->Column#9",
+		"    └─TableScan_10 10000.00 cop table:t, range:[-inf,+inf], keep order:false, stats:pseudo"))
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1(a bigint)")
+	tk.MustExec("insert into t1 values (9223372036854775807), (9223372036854775807), (-1)")
+	tk.MustQuery("select sum(a), avg(a) from t1").Check(testkit.Rows("18446744073709551613 6148914691236517204.3333"))
+	tk.MustQuery("select /*+ AGG_TO_COP() */ sum(a), avg(a) from t1").Check(testkit.Rows("18446744073709551613 6148914691236517204.3333"))
+
+	// Decimal index keys are memcomparable.
+	tk.MustQuery("select a from t use index(idx_b) where b > 0 order by b").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from t use index(idx_b) where b = -3.5").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t where c in (0.1, 100) and b between -10 and 10.01 order by a").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from t where b * 2 > c + 1 order by a").Check(testkit.Rows("1"))
+
+	// The values rounded when they are written to the column are reported by notes.
+	tk.MustExec("insert into t(a, b) values (5, 1.234)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1265 Data truncated for column 'b' at row 1"))
+	tk.MustExec("insert into t(a, b) values (6, 2.5), (7, '3.333'), (8, 4.5e-3)")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Note 1265 Data truncated for column 'b' at row 2",
+		"Note 1265 Data truncated for column 'b' at row 3"))
+	tk.MustQuery("select b from t where a in (5, 6, 7, 8) order by a").Check(testkit.Rows("1.23", "2.50", "3.33", "0.00"))
+	tk.MustQuery("select cast(2.345 as decimal(3, 1)), cast('1.2x' as decimal(3, 1))").Check(testkit.Rows("2.3 1.2"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1292 Truncated incorrect DECIMAL value: '2.345'",
+		"Warning 1292 Truncated incorrect DECIMAL value: '1.2x'"))
+}
+
+func (s *testIntegrationSuite) TestStringBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b varchar(50), c varchar(50))")
+	tk.MustExec("insert into t values (1, '  数据库系统  ', 'abc,数据库'), (2, 'xxTiDBxx', 'TiDB'), (3, null, null)")
+
+	tk.MustQuery("select concat(a, '-', c), concat_ws('|', a, b, c), char_length(b), length(b) from t").Check(testkit.Rows(
+		"1-abc,数据库 1|  数据库系统  |abc,数据库 9 19",
+		"2-TiDB 2|xxTiDBxx|TiDB 8 8",
+		"<nil> 3 <nil> <nil>"))
+	tk.MustQuery("select substring(c, 5), substr(c, -3, 2), mid(c, 2, 2), left(c, 2), right(c, 2) from t where a < 3").Check(testkit.Rows(
+		"数据库 数据 bc ab 据库",
+		" iD iD Ti DB"))
+	tk.MustQuery("select substring(c from 2 for 3) from t where a = 2").Check(testkit.Rows("iDB"))
+	tk.MustQuery("select upper(c), lower(c), ucase('abc'), lcase('ABC'), reverse(c) from t where a < 3").Check(testkit.Rows(
+		"ABC,数据库 abc,数据库 ABC abc 库据数,cba",
+		"TIDB tidb ABC abc BDiT"))
+	tk.MustQuery("select concat('[', trim(b), ']'), concat('[', ltrim(b), ']'), concat('[', rtrim(b), ']') from t where a = 1").Check(testkit.Rows(
+		"[数据库系统] [数据库系统  ] [  数据库系统]"))
+	tk.MustQuery("select trim('x' from b), trim(leading 'x' from b), trim(trailing 'x' from b), trim(both from '  a  ') from t where a = 2").Check(testkit.Rows(
+		"TiDB TiDBxx xxTiDB a"))
+	tk.MustQuery("select replace(c, '数据', 'data'), locate('数据', c), locate('b', c, 3), position('DB' in c), instr(c, 'tidb') from t where a < 3").Check(testkit.Rows(
+		"abc,data库 5 0 0 0",
+		"TiDB 0 4 3 1"))
+	tk.MustQuery("select lpad(c, 6, '?'), rpad(c, 8, '数'), lpad(c, 2, ''), rpad(c, -1, 'x') from t where a < 3").Check(testkit.Rows(
+		"abc,数据 abc,数据库数 ab <nil>",
+		"??TiDB TiDB数数数数 Ti <nil>"))
+	tk.MustQuery("select hex(c), unhex(hex(c)), hex(255), unhex('4D7953514C'), unhex('xyz') from t where a = 2").Check(testkit.Rows(
+		"54694442 TiDB FF MySQL <nil>"))
+	tk.MustQuery("select find_in_set('数据库', c), find_in_set('TiDB', c), find_in_set(null, c) from t where a < 3").Check(testkit.Rows(
+		"2 0 <nil>",
+		"0 1 <nil>"))
+
+	// String functions can be pushed down to the coprocessor.
+	tk.MustQuery("select a from t where char_length(c) = 4 and upper(c) = 'TIDB'").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t where locate('数据', concat(b, c)) > 0 and left(trim(b), 2) = '数据'").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t where find_in_set('abc', c) = 1 or lpad(c, 5, 'x') = 'xTiDB' order by a").Check(testkit.Rows("1", "2"))
+}
+
+func (s *testIntegrationSuite) TestMathBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b double, c bigint unsigned)")
+	tk.MustExec("insert into t values (-7, 2.5, 12), (9, -1.49, 5), (null, null, null)")
+
+	tk.MustQuery("select abs(a), abs(b), ceil(b), floor(b), ceiling(a), round(b), round(b, 1), truncate(b, 1), truncate(a, -1) from t").Check(testkit.Rows(
+		"7 2.5 3 2 -7 3 2.5 2.5 0",
+		"9 1.49 -1 -2 9 -1 -1.5 -1.4 0",
+		"<nil> <nil> <nil> <nil> <nil> <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select a % 4, mod(a, 4), a div 4, b % 2, b div 1, a % 0, a div 0 from t").Check(testkit.Rows(
+		"-3 -3 -1 0.5 2 <nil> <nil>",
+		"1 1 2 -1.49 -1 <nil> <nil>",
+		"<nil> <nil> <nil> <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select pow(2, 10), power(4, 0.5), sqrt(16), sqrt(-1), exp(0), ln(1), log(2, 8), log2(8), log10(1000), log(0)").Check(testkit.Rows(
+		"1024 2 4 <nil> 1 0 3 3 3 <nil>"))
+	tk.MustQuery("select sign(a), sign(b), sign(0), pi(), degrees(pi()), radians(180) = pi() from t where a is not null").Check(testkit.Rows(
+		"-1 1 0 3.141592653589793 180 1",
+		"1 -1 0 3.141592653589793 180 1"))
+	tk.MustQuery("select sin(0), cos(0), tan(0), asin(1) = pi() / 2, acos(1), atan(0), atan2(0, 1), cot(1) = 1 / tan(1)").Check(testkit.Rows(
+		"0 1 0 1 0 0 0 1"))
+	tk.MustQuery("select rand(1), rand(a) = rand(a), rand() < 1 from t where a = 9").Check(testkit.Rows(
+		"0.40540353712197724 1 1"))
+
+	tk.MustQuery("select c & 10, c | 3, c ^ 5, ~c, c << 2, c >> 2, ~0 from t").Check(testkit.Rows(
+		"8 15 9 18446744073709551603 48 3 18446744073709551615",
+		"0 7 0 18446744073709551610 20 1 18446744073709551615",
+		"<nil> <nil> <nil> <nil> <nil> <nil> 18446744073709551615"))
+
+	// Scoring formulas built from math and bitwise functions can be pushed down to the coprocessor.
+	tk.MustQuery("select a from t where round(abs(b) * log(2, c + 4), 1) > 5 and (c & 4) = 4 order by a").Check(testkit.Rows("-7"))
+	tk.MustQuery("select a from t where pow(a, 2) % 10 = 1 or floor(sqrt(c)) = 2 order by a").Check(testkit.Rows("9"))
+}
+
+func (s *testIntegrationSuite) TestTimeBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int, a datetime(3), b date, c timestamp, d time, index idx_a(a), index idx_c(c))")
+	tk.MustExec("insert into t values (1, '2019-12-31 23:59:59.9876', '2019-12-31', '2020-01-01 08:00:00', '12:34:56'), " +
+		"(2, '2020-02-29 00:00:00', '2020-02-29', '2020-03-01 00:00:00', '-01:00:00'), (3, null, null, null, null)")
+
+	tk.MustQuery("select a, b, c, d from t where id < 3").Check(testkit.Rows(
+		"2019-12-31 23:59:59.988 2019-12-31 2020-01-01 08:00:00 12:34:56",
+		"2020-02-29 00:00:00.000 2020-02-29 2020-03-01 00:00:00 -01:00:00"))
+
+	// TIMESTAMP values are shown in the session time zone, the others are not affected.
+	tk.MustExec("set @@time_zone = '+08:00'")
+	tk.MustQuery("select a, c from t where id = 1").Check(testkit.Rows("2019-12-31 23:59:59.988 2020-01-01 16:00:00"))
+	tk.MustQuery("select id from t where c = '2020-03-01 08:00:00'").Check(testkit.Rows("2"))
+	tk.MustExec("set @@time_zone = '+00:00'")
+
+	// Index ranges on time columns.
+	tk.MustQuery("select id from t use index(idx_a) where a > '2019-12-31 23:59:59.987' and a <= '2020-02-29'").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t use index(idx_a) where a = '2019-12-31 23:59:59.988'").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t use index(idx_c) where c between '2020-01-01' and '2020-02-01'").Check(testkit.Rows("1"))
+
+	tk.MustQuery("select year(a), month(a), day(b), dayofmonth(c), hour(d) from t").Check(testkit.Rows(
+		"2019 12 31 1 12",
+		"2020 2 29 1 1",
+		"<nil> <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select date_add(b, interval 1 day), date_sub(a, interval 1 month), adddate(b, 2), b + interval 1 year from t where id = 2").Check(testkit.Rows(
+		"2020-03-01 2020-01-29 00:00:00 2020-03-02 2021-02-28"))
+	tk.MustQuery("select datediff(c, b), date_format(a, '%Y/%m/%d %H:%i:%s.%f'), date_format(b, '%W %M %D %Y') from t where id = 1").Check(testkit.Rows(
+		"1 2019/12/31 23:59:59.988000 Tuesday December 31st 2019"))
+	tk.MustQuery("select str_to_date('2020-01-02 03:04:05', '%Y-%m-%d %H:%i:%s'), str_to_date('01/02/2020', '%m/%d/%Y'), str_to_date('abc', '%Y')").Check(testkit.Rows(
+		"2020-01-02 03:04:05 2020-01-02 <nil>"))
+	tk.MustQuery("select unix_timestamp(c), unix_timestamp('1970-01-01 00:00:01.5'), from_unixtime(1577836800), from_unixtime(0, '%Y-%m-%d') from t where id = 1").Check(testkit.Rows(
+		"1577865600 1.5 2020-01-01 00:00:00 1970-01-01"))
+	tk.MustQuery("select now() = current_timestamp(), curdate() = cast(now() as date), length(now(3))").Check(testkit.Rows("1 1 23"))
+
+	// Time functions can be pushed down to the coprocessor.
+	tk.MustQuery("select id from t where year(a) = 2020 and date_format(b, '%m') = '02'").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where datediff(date_add(b, interval 1 day), b) = 1 order by id").Check(testkit.Rows("1", "2"))
+
+	// Default values of time columns.
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1(id int, a timestamp(3) default current_timestamp(3) on update current_timestamp(3), b datetime default '2020-01-01 00:00:00')")
+	tk.MustExec("insert into t1(id) values (1)")
+	tk.MustQuery("select b, a is not null from t1").Check(testkit.Rows("2020-01-01 00:00:00 1"))
+	_, err := tk.Exec("create table t2(a timestamp(3) default current_timestamp)")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t2(a int on update current_timestamp)")
+	c.Assert(err, NotNil)
+}
+
+func (s *testIntegrationSuite) TestJSONBuiltin(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int, j json)")
+	tk.MustExec(`insert into t values (1, '{"a": 1, "b": [1, "x"], "c": {"d": true}}'), (2, '[1, 2, "3"]'), (3, '"str"'), (4, null)`)
+	_, err := tk.Exec(`insert into t values (5, '{"a": 1')`)
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t1(j json, index idx(j))")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t1(j json default '{}')")
+	c.Assert(err, NotNil)
+
+	tk.MustQuery("select j, json_type(j) from t order by id").Check(testkit.Rows(
+		`{"a": 1, "b": [1, "x"], "c": {"d": true}} OBJECT`,
+		`[1, 2, "3"] ARRAY`,
+		`"str" STRING`,
+		"<nil> <nil>"))
+	tk.MustQuery(`select j->'$.b[1]', j->>'$.b[1]', json_extract(j, '$.a', '$.c.d'), t.j->'$[2]' from t where id < 3 order by id`).Check(testkit.Rows(
+		`"x" x [1, true] <nil>`,
+		`<nil> <nil> <nil> "3"`))
+	tk.MustQuery(`select json_unquote(j), json_unquote('"a\\tb"'), j->>'$' from t where id = 3`).Check(testkit.Rows("str a\tb str"))
+
+	tk.MustQuery(`select json_object('a', 1, 'b', 'x', 'c', null), json_array(1, 'a', null, json_array()), json_object()`).Check(testkit.Rows(
+		`{"a": 1, "b": "x", "c": null} [1, "a", null, []] {}`))
+	tk.MustQuery(`select json_set(j, '$.a', 2, '$.e', 'y'), json_insert(j, '$.a', 3), json_replace(j, '$.e', 3), json_remove(j, '$.b', '$.c') from t where id = 1`).Check(testkit.Rows(
+		`{"a": 2, "b": [1, "x"], "c": {"d": true}, "e": "y"} {"a": 1, "b": [1, "x"], "c": {"d": true}} {"a": 1, "b": [1, "x"], "c": {"d": true}} {"a": 1}`))
+	tk.MustQuery(`select json_contains(j, '1'), json_contains(j, '"x"', '$.b'), json_contains(j, '{"d": true}', '$.c') from t order by id`).Check(testkit.Rows(
+		"0 1 1",
+		"1 <nil> <nil>",
+		"0 <nil> <nil>",
+		"<nil> <nil> <nil>"))
+
+	// JSON values compare with JSON semantics.
+	tk.MustQuery(`select j->'$.a' = 1, j->'$.b' = json_array(1, 'x'), cast('[1, 2, "3"]' as json) = j from t where id < 3 order by id`).Check(testkit.Rows(
+		"1 1 0",
+		"<nil> <nil> 1"))
+	tk.MustQuery(`select id from t where j->'$.a' = 1 or j->>'$[2]' = '3' order by id`).Check(testkit.Rows("1", "2"))
+
+	// Extraction is pushed down to the coprocessor.
+	rows := tk.MustQuery(`explain select id from t where j->>'$.b[1]' = 'x'`).Rows()
+	var pushed bool
+	for _, row := range rows {
+		if strings.Contains(row[0].(string), "Selection") && strings.Contains(row[2].(string), "cop") {
+			pushed = strings.Contains(row[3].(string), "json_extract")
+		}
+	}
+	c.Assert(pushed, IsTrue, Commentf("%v", rows))
+	tk.MustQuery(`select id from t where j->>'$.b[1]' = 'x'`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select id from t where json_contains(j, '2') and json_type(j) = 'ARRAY'`).Check(testkit.Rows("2"))
+}
+
+func (s *testIntegrationSuite) TestEnumSetBit(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	defer s.cleanEnv(c)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int, e enum('c', 'a', 'b'), s set('x', 'y', 'z'), b bit(8))")
+	tk.MustExec(`insert into t values (1, 'b', 'z,x', b'101'), (2, 2, 3, 65), (3, 'A', '', 0x0f), (4, null, null, null)`)
+
+	tk.MustQuery("select e, s, b+0 from t order by id").Check(testkit.Rows(
+		"b x,z 5",
+		"a x,y 65",
+		"a  15",
+		"<nil> <nil> <nil>"))
+	tk.MustQuery("select e+0, s+0, concat(e, s) from t where id < 4 order by id").Check(testkit.Rows(
+		"3 5 bx,z",
+		"2 3 ax,y",
+		"2 0 a"))
+
+	// ENUM and SET compare as strings against strings and as numbers
+	// against numbers.
+	tk.MustQuery("select id from t where e = 'b'").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where e = 2 order by id").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select id from t where s = 'x,z'").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where s = 3").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where b = 65").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where b = b'1111'").Check(testkit.Rows("3"))
+
+	// Sorting uses the element index, not the name.
+	tk.MustQuery("select e from t where id < 4 order by e, id").Check(testkit.Rows("a", "a", "b"))
+	tk.MustQuery("select find_in_set('z', s), find_in_set('y', s), find_in_set('b', 'a,b,c') from t where id < 4 order by id").Check(testkit.Rows(
+		"2 0 2",
+		"0 2 2",
+		"0 0 2"))
+	tk.MustQuery("select b'101' + 0, 0x41, 0x41 + 0, b'1000001' = 'A'").Check(testkit.Rows("5 A 65 1"))
+
+	_, err := tk.Exec("insert into t(id, e) values (5, 'd')")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("insert into t(id, s) values (5, 'x,w')")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[types:1265]Data truncated for column 's' at row 1")
+	_, err = tk.Exec("insert into t(id, e) values (5, 4)")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t1(e enum('a', 'b') default 'c')")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t1(e enum('a', 'a'))")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create table t1(b bit(65))")
+	c.Assert(err, NotNil)
+
+	// Without strict mode a NOT NULL enum falls back to its first element.
+	tk.MustExec("create table t1(id int, e enum('p', 'q') not null, s set('m', 'n') default 'n')")
+	_, err = tk.Exec("insert into t1(id) values (1)")
+	c.Assert(err, NotNil)
+	tk.MustExec("set @@sql_mode = ''")
+	tk.MustExec("insert into t1(id) values (1)")
+	tk.MustExec("insert into t1 values (2, 'r', 'm,o'), (3, 'q', 'o,n')")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1265 Data truncated for column 'e' at row 1",
+		"Warning 1265 Data truncated for column 's' at row 1",
+		"Warning 1265 Data truncated for column 's' at row 2"))
+	tk.MustExec("insert into t1 select 4, 'p', 'n,zz'")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1265 Data truncated for column 's' at row 1"))
+	tk.MustQuery("select e, s from t1 order by id").Check(testkit.Rows("p n", " m", "q n", "p n"))
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// ParseSimpleExprWithTableInfo parses an expression string to Expression.
+// The expression string must only reference the columns of tableInfo.
+func ParseSimpleExprWithTableInfo(ctx sessionctx.Context, exprStr string, tableInfo *model.TableInfo) (Expression, error) {
+	expr, err := ParseSimpleExpr(exprStr)
+	if err != nil {
+		return nil, err
+	}
+	return RewriteSimpleExprWithTableInfo(ctx, tableInfo, expr)
+}
+
+// ParseSimpleExprCastWithTableInfo parses an expression string to Expression
+// like ParseSimpleExprWithTableInfo, the result is cast to targetFt.
+func ParseSimpleExprCastWithTableInfo(ctx sessionctx.Context, exprStr string, tableInfo *model.TableInfo, targetFt *types.FieldType) (Expression, error) {
+	e, err := ParseSimpleExprWithTableInfo(ctx, exprStr, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	return BuildCastFunction(ctx, e, targetFt), nil
+}
+
+// ParseSimpleExpr parses an expression string to an ast expression.
+func ParseSimpleExpr(exprStr string) (ast.ExprNode, error) {
+	stmts, _, err := parser.New().Parse("select "+exprStr, "", "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fields := stmts[0].(*ast.SelectStmt).Fields.Fields
+	return fields[0].Expr, nil
+}
+
+// RewriteSimpleExprWithTableInfo rewrites an ast expression to Expression,
+// the columns in the expression are resolved against the columns of
+// tableInfo, their Index is the offset of the column in the table.
+func RewriteSimpleExprWithTableInfo(ctx sessionctx.Context, tableInfo *model.TableInfo, expr ast.ExprNode) (Expression, error) {
+	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
+	columns, names := ColumnInfos2ColumnsAndNames(ctx, dbName, tableInfo.Name, tableInfo.Columns)
+	e, err := RewriteAstExpr(ctx, expr, NewSchema(columns...), names)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
 	"go.uber.org/zap"
@@ -118,6 +119,45 @@ func extractColumns(result []*Column, expr Expression, filter func(*Column) bool
 	return result
 }
 
+// ExtractDependentColumns extracts all dependent columns from a virtual column.
+func ExtractDependentColumns(expr Expression) []*Column {
+	// Pre-allocate a slice to reduce allocation, 8 doesn't have special meaning.
+	result := make([]*Column, 0, 8)
+	return extractDependentColumns(result, expr)
+}
+
+func extractDependentColumns(result []*Column, expr Expression) []*Column {
+	switch v := expr.(type) {
+	case *Column:
+		result = append(result, v)
+		if v.VirtualExpr != nil {
+			result = extractDependentColumns(result, v.VirtualExpr)
+		}
+	case *ScalarFunction:
+		for _, arg := range v.GetArgs() {
+			result = extractDependentColumns(result, arg)
+		}
+	}
+	return result
+}
+
+// ContainVirtualColumn checks if the expressions contain a virtual column
+func ContainVirtualColumn(exprs []Expression) bool {
+	for _, expr := range exprs {
+		switch v := expr.(type) {
+		case *Column:
+			if v.VirtualExpr != nil {
+				return true
+			}
+		case *ScalarFunction:
+			if ContainVirtualColumn(v.GetArgs()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ExtractColumnSet extracts the different values of `UniqueId` for columns in expressions.
 func ExtractColumnSet(exprs []Expression) *intsets.Sparse {
 	set := &intsets.Sparse{}
@@ -421,6 +461,25 @@ func DatumToConstant(d types.Datum, tp byte) *Constant {
 	return &Constant{Value: d, RetType: types.NewFieldType(tp)}
 }
 
+// ParamMarkerExpression generates a constant for the parameter marker of a prepared statement.
+// If the plan is going to be cached, the constant references the parameter so
+// that each execution of the cached plan evaluates its own parameter.
+func ParamMarkerExpression(ctx sessionctx.Context, v *driver.ParamMarkerExpr) *Constant {
+	tp := types.NewFieldType(mysql.TypeUnspecified)
+	types.DefaultParamTypeForValue(v.GetValue(), tp)
+	value := &Constant{Value: v.Datum, RetType: tp}
+	if ctx.GetSessionVars().StmtCtx.UseCache {
+		value.ParamMarker = &ParamMarker{ctx: ctx, order: v.Order}
+	}
+	return value
+}
+
+// IsBinaryLiteral checks whether an expression is a binary literal
+func IsBinaryLiteral(expr Expression) bool {
+	con, ok := expr.(*Constant)
+	return ok && con.Value.Kind() == types.KindBinaryLiteral
+}
+
 // GetStringFromConstant gets a string value from the Constant expression.
 func GetStringFromConstant(ctx sessionctx.Context, value Expression) (string, bool, error) {
 	con, ok := value.(*Constant)
@@ -72,3 +72,22 @@ func (s *testTableSuite) TestReloadDropDatabase(c *C) {
 	_, ok := is.TableByID(t2.Meta().ID)
 	c.Assert(ok, IsFalse)
 }
+
+func (s *testTableSuite) TestViews(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database test_views")
+	tk.MustExec("use test_views")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create view v1 as select a, b from t where a > 0 with check option")
+	tk.MustExec("create sql security invoker view v2 as select count(*) as cnt from t")
+	tk.MustQuery("select table_name, view_definition, check_option, is_updatable, definer, security_type from information_schema.views where table_schema = 'test_views'").Check(testkit.Rows(
+		"v1 select a, b from t where a > 0 CASCADED YES  DEFINER",
+		"v2 select count(*) as cnt from t NONE NO  INVOKER",
+	))
+	tk.MustQuery("select table_name, table_type, engine, table_comment from information_schema.tables where table_schema = 'test_views' order by table_name").Check(testkit.Rows(
+		"t BASE TABLE InnoDB ",
+		"v1 VIEW <nil> VIEW",
+		"v2 VIEW <nil> VIEW",
+	))
+	tk.MustExec("drop database test_views")
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/store/tikv/oracle"
+	"github.com/pingcap/tidb/util/execdetails"
 )
 
 // Transaction options
@@ -244,6 +245,8 @@ type ResultSubset interface {
 	MemSize() int64
 	// RespTime returns the response time for the request.
 	RespTime() time.Duration
+	// GetExecDetails gets the detail information of the task.
+	GetExecDetails() *execdetails.ExecDetails
 }
 
 // Response represents the response returned from KV layer.
@@ -14,6 +14,7 @@
 package ast
 
 import (
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/types"
 )
@@ -23,6 +24,7 @@ var (
 	_ DDLNode = &CreateDatabaseStmt{}
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
+	_ DDLNode = &CreateViewStmt{}
 	_ DDLNode = &DropDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
@@ -33,6 +35,9 @@ var (
 	_ Node = &ColumnOption{}
 	_ Node = &Constraint{}
 	_ Node = &IndexPartSpecification{}
+	_ Node = &OnDeleteOpt{}
+	_ Node = &OnUpdateOpt{}
+	_ Node = &ReferenceDef{}
 )
 
 // CharsetOpt is used for parsing charset option from SQL.
@@ -129,6 +134,111 @@ func (n *IndexPartSpecification) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ReferenceDef is used for parsing foreign key reference option from SQL.
+// See http://dev.mysql.com/doc/refman/5.7/en/create-table-foreign-keys.html
+type ReferenceDef struct {
+	node
+
+	Table                   *TableName
+	IndexPartSpecifications []*IndexPartSpecification
+	OnDelete                *OnDeleteOpt
+	OnUpdate                *OnUpdateOpt
+}
+
+// Accept implements Node Accept interface.
+func (n *ReferenceDef) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ReferenceDef)
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	for i, val := range n.IndexPartSpecifications {
+		node, ok = val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.IndexPartSpecifications[i] = node.(*IndexPartSpecification)
+	}
+	onDelete, ok := n.OnDelete.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.OnDelete = onDelete.(*OnDeleteOpt)
+	onUpdate, ok := n.OnUpdate.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.OnUpdate = onUpdate.(*OnUpdateOpt)
+	return v.Leave(n)
+}
+
+// ReferOptionType is the type for refer options.
+type ReferOptionType int
+
+// Refer option types.
+const (
+	ReferOptionNoOption ReferOptionType = iota
+	ReferOptionRestrict
+	ReferOptionCascade
+	ReferOptionSetNull
+	ReferOptionNoAction
+	ReferOptionSetDefault
+)
+
+// String implements fmt.Stringer interface.
+func (r ReferOptionType) String() string {
+	switch r {
+	case ReferOptionRestrict:
+		return "RESTRICT"
+	case ReferOptionCascade:
+		return "CASCADE"
+	case ReferOptionSetNull:
+		return "SET NULL"
+	case ReferOptionNoAction:
+		return "NO ACTION"
+	case ReferOptionSetDefault:
+		return "SET DEFAULT"
+	}
+	return ""
+}
+
+// OnDeleteOpt is used for optional on delete clause.
+type OnDeleteOpt struct {
+	node
+	ReferOpt ReferOptionType
+}
+
+// Accept implements Node Accept interface.
+func (n *OnDeleteOpt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OnDeleteOpt)
+	return v.Leave(n)
+}
+
+// OnUpdateOpt is used for optional on update clause.
+type OnUpdateOpt struct {
+	node
+	ReferOpt ReferOptionType
+}
+
+// Accept implements Node Accept interface.
+func (n *OnUpdateOpt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*OnUpdateOpt)
+	return v.Leave(n)
+}
+
 // ColumnOptionType is the type for ColumnOption.
 type ColumnOptionType int
 
@@ -176,6 +286,8 @@ type ColumnOption struct {
 	AutoRandomBitLength int
 	// Enforced is only for Check, default is true.
 	Enforced bool
+	// ConstraintName is only for Check, it is the name given by CONSTRAINT symbol.
+	ConstraintName string
 }
 
 // Accept implements Node Accept interface.
@@ -266,6 +378,10 @@ type Constraint struct {
 	Expr ExprNode // Used for Check
 
 	Enforced bool // Used for Check
+
+	InColumn bool // Used for Check, true if the constraint is defined in a column definition
+
+	Refer *ReferenceDef // Used for foreign key.
 }
 
 // Accept implements Node Accept interface.
@@ -289,6 +405,13 @@ func (n *Constraint) Accept(v Visitor) (Node, bool) {
 		}
 		n.Option = node.(*IndexOption)
 	}
+	if n.Refer != nil {
+		node, ok := n.Refer.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Refer = node.(*ReferenceDef)
+	}
 	return v.Leave(n)
 }
 
@@ -351,6 +474,7 @@ type CreateTableStmt struct {
 	ReferTable  *TableName
 	Cols        []*ColumnDef
 	Constraints []*Constraint
+	Partition   *PartitionOptions
 }
 
 // Accept implements Node Accept interface.
@@ -386,7 +510,128 @@ func (n *CreateTableStmt) Accept(v Visitor) (Node, bool) {
 		}
 		n.Constraints[i] = node.(*Constraint)
 	}
+	if n.Partition != nil {
+		node, ok = n.Partition.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Partition = node.(*PartitionOptions)
+	}
+
+	return v.Leave(n)
+}
+
+// PartitionDefinition defines a single partition.
+type PartitionDefinition struct {
+	node
+
+	Name model.CIStr
+	// LessThan is the upper bound of a RANGE partition, a MAXVALUE bound is
+	// represented by a *MaxValueExpr.
+	LessThan []ExprNode
+	// InValues are the values of a LIST partition.
+	InValues [][]ExprNode
+	Comment  string
+}
+
+// Accept implements Node Accept interface.
+func (n *PartitionDefinition) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PartitionDefinition)
+	for i, expr := range n.LessThan {
+		node, ok := expr.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.LessThan[i] = node.(ExprNode)
+	}
+	for _, values := range n.InValues {
+		for i, expr := range values {
+			node, ok := expr.Accept(v)
+			if !ok {
+				return n, false
+			}
+			values[i] = node.(ExprNode)
+		}
+	}
+	return v.Leave(n)
+}
+
+// PartitionOptions specifies the partition options.
+// See https://dev.mysql.com/doc/refman/5.7/en/partitioning-types.html
+type PartitionOptions struct {
+	node
+
+	Tp model.PartitionType
+	// Expr is the partitioning expression, its Text() is the original text.
+	Expr ExprNode
+	// Num is the number of partitions given by PARTITIONS n.
+	Num         uint64
+	Definitions []*PartitionDefinition
+}
+
+// Accept implements Node Accept interface.
+func (n *PartitionOptions) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*PartitionOptions)
+	if n.Expr != nil {
+		node, ok := n.Expr.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Expr = node.(ExprNode)
+	}
+	for i, def := range n.Definitions {
+		node, ok := def.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Definitions[i] = node.(*PartitionDefinition)
+	}
+	return v.Leave(n)
+}
+
+// CreateViewStmt is a statement to create a View.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-view.html
+type CreateViewStmt struct {
+	ddlNode
 
+	OrReplace   bool
+	ViewName    *TableName
+	Cols        []model.CIStr
+	Select      StmtNode
+	Algorithm   model.ViewAlgorithm
+	Definer     *auth.UserIdentity
+	Security    model.ViewSecurity
+	CheckOption model.ViewCheckOption
+	// SchemaCols are the output column names of Select, they are filled
+	// by the planner.
+	SchemaCols []model.CIStr
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
 	return v.Leave(n)
 }
 
@@ -532,6 +777,9 @@ const (
 	AlterTableImportTablespace
 	AlterTableDiscardTablespace
 	AlterTableIndexInvisible
+	AlterTableAddPartitions
+	AlterTableDropPartition
+	AlterTableTruncatePartition
 	// TODO: Add more actions
 	AlterTableOrderByColumns
 )
@@ -550,20 +798,22 @@ type AlterTableSpec struct {
 
 	NoWriteToBinlog bool
 
-	Tp             AlterTableType
-	Name           string
-	Constraint     *Constraint
-	NewTable       *TableName
-	NewColumns     []*ColumnDef
-	NewConstraints []*Constraint
-	OldColumnName  *ColumnName
-	NewColumnName  *ColumnName
-	Comment        string
-	FromKey        model.CIStr
-	ToKey          model.CIStr
-	WithValidation bool
-	Num            uint64
-	Visibility     IndexVisibility
+	Tp              AlterTableType
+	Name            string
+	Constraint      *Constraint
+	NewTable        *TableName
+	NewColumns      []*ColumnDef
+	NewConstraints  []*Constraint
+	OldColumnName   *ColumnName
+	NewColumnName   *ColumnName
+	Comment         string
+	FromKey         model.CIStr
+	ToKey           model.CIStr
+	WithValidation  bool
+	Num             uint64
+	Visibility      IndexVisibility
+	PartitionNames  []model.CIStr
+	PartDefinitions []*PartitionDefinition
 }
 
 // Accept implements Node Accept interface.
@@ -608,6 +858,13 @@ func (n *AlterTableSpec) Accept(v Visitor) (Node, bool) {
 		}
 		n.OldColumnName = node.(*ColumnName)
 	}
+	for i, def := range n.PartDefinitions {
+		node, ok := def.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.PartDefinitions[i] = node.(*PartitionDefinition)
+	}
 	return v.Leave(n)
 }
 
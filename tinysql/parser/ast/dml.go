@@ -14,6 +14,7 @@
 package ast
 
 import (
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 )
@@ -691,6 +692,9 @@ const (
 	ShowProcessList
 	ShowCreateDatabase
 	ShowErrors
+	ShowBindings
+	ShowGrants
+	ShowCreateView
 )
 
 // ShowStmt is a statement to provide information about databases, tables, columns and so on.
@@ -705,8 +709,10 @@ type ShowStmt struct {
 	IndexName   model.CIStr
 	Flag        int // Some flag parsed from sql, such as FULL.
 	Full        bool
-	IfNotExists bool // Used for `show create database if not exists`
-	Extended    bool // Used for `show extended columns from ...`
+	IfNotExists bool                 // Used for `show create database if not exists`
+	User        *auth.UserIdentity   // Used for show grants.
+	Roles       []*auth.RoleIdentity // Used for show grants .. using
+	Extended    bool                 // Used for `show extended columns from ...`
 
 	// GlobalScope is used by `show variables` and `show bindings`
 	GlobalScope bool
@@ -18,43 +18,146 @@ import (
 	"io"
 
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
 	_ FuncNode = &AggregateFuncExpr{}
 	_ FuncNode = &FuncCallExpr{}
+	_ FuncNode = &FuncCastExpr{}
+	_ ExprNode = &TrimDirectionExpr{}
+	_ ExprNode = &TimeUnitExpr{}
 )
 
 // List scalar function names.
 const (
-	IsNull      = "isnull"
-	Length      = "length"
-	Strcmp      = "strcmp"
-	OctetLength = "octet_length"
-	If          = "if"
-	Ifnull      = "ifnull"
-	LogicAnd    = "and"
-	LogicOr     = "or"
-	GE          = "ge"
-	LE          = "le"
-	EQ          = "eq"
-	NE          = "ne"
-	LT          = "lt"
-	GT          = "gt"
-	Plus        = "plus"
-	Minus       = "minus"
-	Div         = "div"
-	Mul         = "mul"
-	UnaryNot    = "not"
-	UnaryMinus  = "unaryminus"
-	In          = "in"
-	RowFunc     = "row"
-	SetVar      = "setvar"
-	GetVar      = "getvar"
-	Values      = "values"
-	Cutl        = "cutl"
-	BM25CMP     = "bm25cmp"
-	TFIDFCMP    = "tfidfcmp"
+	IsNull          = "isnull"
+	Length          = "length"
+	Strcmp          = "strcmp"
+	OctetLength     = "octet_length"
+	Concat          = "concat"
+	ConcatWS        = "concat_ws"
+	Substring       = "substring"
+	Substr          = "substr"
+	Mid             = "mid"
+	Left            = "left"
+	Right           = "right"
+	Upper           = "upper"
+	Ucase           = "ucase"
+	Lower           = "lower"
+	Lcase           = "lcase"
+	Trim            = "trim"
+	LTrim           = "ltrim"
+	RTrim           = "rtrim"
+	Replace         = "replace"
+	Locate          = "locate"
+	Position        = "position"
+	Instr           = "instr"
+	Lpad            = "lpad"
+	Rpad            = "rpad"
+	Reverse         = "reverse"
+	CharLength      = "char_length"
+	CharacterLength = "character_length"
+	Hex             = "hex"
+	Unhex           = "unhex"
+	FindInSet       = "find_in_set"
+	Abs             = "abs"
+	Ceil            = "ceil"
+	Ceiling         = "ceiling"
+	Floor           = "floor"
+	Round           = "round"
+	Truncate        = "truncate"
+	Pow             = "pow"
+	Power           = "power"
+	Sqrt            = "sqrt"
+	Exp             = "exp"
+	Ln              = "ln"
+	Log             = "log"
+	Log2            = "log2"
+	Log10           = "log10"
+	Sign            = "sign"
+	Rand            = "rand"
+	PI              = "pi"
+	Sin             = "sin"
+	Cos             = "cos"
+	Tan             = "tan"
+	Cot             = "cot"
+	Asin            = "asin"
+	Acos            = "acos"
+	Atan            = "atan"
+	Atan2           = "atan2"
+	Degrees         = "degrees"
+	Radians         = "radians"
+	If              = "if"
+	Ifnull          = "ifnull"
+	Nullif          = "nullif"
+	Case            = "case"
+	Coalesce        = "coalesce"
+	Greatest        = "greatest"
+	Least           = "least"
+	Cast            = "cast"
+	LogicAnd        = "and"
+	LogicOr         = "or"
+	GE              = "ge"
+	LE              = "le"
+	EQ              = "eq"
+	NE              = "ne"
+	LT              = "lt"
+	GT              = "gt"
+	Plus            = "plus"
+	Minus           = "minus"
+	Div             = "div"
+	Mul             = "mul"
+	UnaryNot        = "not"
+	UnaryMinus      = "unaryminus"
+	Mod             = "mod"
+	IntDiv          = "intdiv"
+	And             = "bitand"
+	Or              = "bitor"
+	Xor             = "bitxor"
+	LeftShift       = "leftshift"
+	RightShift      = "rightshift"
+	BitNeg          = "bitneg"
+	In              = "in"
+	RowFunc         = "row"
+	SetVar          = "setvar"
+	GetVar          = "getvar"
+	Values          = "values"
+	Cutl            = "cutl"
+	BM25CMP         = "bm25cmp"
+	TFIDFCMP        = "tfidfcmp"
+
+	// time functions.
+	Now              = "now"
+	CurrentTimestamp = "current_timestamp"
+	Curdate          = "curdate"
+	CurrentDate      = "current_date"
+	DateAdd          = "date_add"
+	DateSub          = "date_sub"
+	AddDate          = "adddate"
+	SubDate          = "subdate"
+	DateDiff         = "datediff"
+	DateFormat       = "date_format"
+	StrToDate        = "str_to_date"
+	UnixTimestamp    = "unix_timestamp"
+	FromUnixTime     = "from_unixtime"
+	Year             = "year"
+	Month            = "month"
+	Day              = "day"
+	DayOfMonth       = "dayofmonth"
+	Hour             = "hour"
+
+	// json functions.
+	JSONType     = "json_type"
+	JSONExtract  = "json_extract"
+	JSONUnquote  = "json_unquote"
+	JSONArray    = "json_array"
+	JSONObject   = "json_object"
+	JSONSet      = "json_set"
+	JSONInsert   = "json_insert"
+	JSONReplace  = "json_replace"
+	JSONRemove   = "json_remove"
+	JSONContains = "json_contains"
 )
 
 // FuncCallExpr is for function expression.
@@ -95,6 +198,226 @@ func (n *FuncCallExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CastFunctionType is the type for cast function.
+type CastFunctionType int
+
+// CastFunction types
+const (
+	CastFunction CastFunctionType = iota + 1
+	CastConvertFunction
+)
+
+// FuncCastExpr is the cast function converting value to another type, e.g, cast(expr AS signed).
+// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html
+type FuncCastExpr struct {
+	funcNode
+	// Expr is the expression to be converted.
+	Expr ExprNode
+	// Tp is the conversion type.
+	Tp *types.FieldType
+	// FunctionType is either Cast or Convert.
+	FunctionType CastFunctionType
+}
+
+// Format the ExprNode into a Writer.
+func (n *FuncCastExpr) Format(w io.Writer) {
+	switch n.FunctionType {
+	case CastFunction:
+		fmt.Fprint(w, "CAST(")
+		n.Expr.Format(w)
+		fmt.Fprint(w, " AS ")
+		n.Tp.FormatAsCastType(w)
+		fmt.Fprint(w, ")")
+	case CastConvertFunction:
+		fmt.Fprint(w, "CONVERT(")
+		n.Expr.Format(w)
+		fmt.Fprint(w, ", ")
+		n.Tp.FormatAsCastType(w)
+		fmt.Fprint(w, ")")
+	}
+}
+
+// Accept implements Node Accept interface.
+func (n *FuncCastExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FuncCastExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// TrimDirectionType is the type for trim direction.
+type TrimDirectionType int
+
+const (
+	// TrimBothDefault trims from both direction by default.
+	TrimBothDefault TrimDirectionType = iota
+	// TrimBoth trims from both direction with explicit notation.
+	TrimBoth
+	// TrimLeading trims from left.
+	TrimLeading
+	// TrimTrailing trims from right.
+	TrimTrailing
+)
+
+// String implements fmt.Stringer interface.
+func (direction TrimDirectionType) String() string {
+	switch direction {
+	case TrimBoth, TrimBothDefault:
+		return "BOTH"
+	case TrimLeading:
+		return "LEADING"
+	case TrimTrailing:
+		return "TRAILING"
+	default:
+		return ""
+	}
+}
+
+// TrimDirectionExpr is an expression representing the trim direction used in the TRIM() function.
+type TrimDirectionExpr struct {
+	exprNode
+	// Direction is the trim direction
+	Direction TrimDirectionType
+}
+
+// Format the ExprNode into a Writer.
+func (n *TrimDirectionExpr) Format(w io.Writer) {
+	fmt.Fprint(w, n.Direction.String())
+}
+
+// Accept implements Node Accept interface.
+func (n *TrimDirectionExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	return v.Leave(newNode)
+}
+
+// TimeUnitType is the type for time and timestamp units.
+type TimeUnitType int
+
+const (
+	// TimeUnitInvalid is a placeholder for an invalid time or timestamp unit
+	TimeUnitInvalid TimeUnitType = iota
+	// TimeUnitMicrosecond is the time or timestamp unit MICROSECOND.
+	TimeUnitMicrosecond
+	// TimeUnitSecond is the time or timestamp unit SECOND.
+	TimeUnitSecond
+	// TimeUnitMinute is the time or timestamp unit MINUTE.
+	TimeUnitMinute
+	// TimeUnitHour is the time or timestamp unit HOUR.
+	TimeUnitHour
+	// TimeUnitDay is the time or timestamp unit DAY.
+	TimeUnitDay
+	// TimeUnitWeek is the time or timestamp unit WEEK.
+	TimeUnitWeek
+	// TimeUnitMonth is the time or timestamp unit MONTH.
+	TimeUnitMonth
+	// TimeUnitQuarter is the time or timestamp unit QUARTER.
+	TimeUnitQuarter
+	// TimeUnitYear is the time or timestamp unit YEAR.
+	TimeUnitYear
+	// TimeUnitSecondMicrosecond is the time unit SECOND_MICROSECOND.
+	TimeUnitSecondMicrosecond
+	// TimeUnitMinuteMicrosecond is the time unit MINUTE_MICROSECOND.
+	TimeUnitMinuteMicrosecond
+	// TimeUnitMinuteSecond is the time unit MINUTE_SECOND.
+	TimeUnitMinuteSecond
+	// TimeUnitHourMicrosecond is the time unit HOUR_MICROSECOND.
+	TimeUnitHourMicrosecond
+	// TimeUnitHourSecond is the time unit HOUR_SECOND.
+	TimeUnitHourSecond
+	// TimeUnitHourMinute is the time unit HOUR_MINUTE.
+	TimeUnitHourMinute
+	// TimeUnitDayMicrosecond is the time unit DAY_MICROSECOND.
+	TimeUnitDayMicrosecond
+	// TimeUnitDaySecond is the time unit DAY_SECOND.
+	TimeUnitDaySecond
+	// TimeUnitDayMinute is the time unit DAY_MINUTE.
+	TimeUnitDayMinute
+	// TimeUnitDayHour is the time unit DAY_HOUR.
+	TimeUnitDayHour
+	// TimeUnitYearMonth is the time unit YEAR_MONTH.
+	TimeUnitYearMonth
+)
+
+// String implements fmt.Stringer interface.
+func (unit TimeUnitType) String() string {
+	switch unit {
+	case TimeUnitMicrosecond:
+		return "MICROSECOND"
+	case TimeUnitSecond:
+		return "SECOND"
+	case TimeUnitMinute:
+		return "MINUTE"
+	case TimeUnitHour:
+		return "HOUR"
+	case TimeUnitDay:
+		return "DAY"
+	case TimeUnitWeek:
+		return "WEEK"
+	case TimeUnitMonth:
+		return "MONTH"
+	case TimeUnitQuarter:
+		return "QUARTER"
+	case TimeUnitYear:
+		return "YEAR"
+	case TimeUnitSecondMicrosecond:
+		return "SECOND_MICROSECOND"
+	case TimeUnitMinuteMicrosecond:
+		return "MINUTE_MICROSECOND"
+	case TimeUnitMinuteSecond:
+		return "MINUTE_SECOND"
+	case TimeUnitHourMicrosecond:
+		return "HOUR_MICROSECOND"
+	case TimeUnitHourSecond:
+		return "HOUR_SECOND"
+	case TimeUnitHourMinute:
+		return "HOUR_MINUTE"
+	case TimeUnitDayMicrosecond:
+		return "DAY_MICROSECOND"
+	case TimeUnitDaySecond:
+		return "DAY_SECOND"
+	case TimeUnitDayMinute:
+		return "DAY_MINUTE"
+	case TimeUnitDayHour:
+		return "DAY_HOUR"
+	case TimeUnitYearMonth:
+		return "YEAR_MONTH"
+	default:
+		return ""
+	}
+}
+
+// TimeUnitExpr is an expression representing a time or timestamp unit.
+type TimeUnitExpr struct {
+	exprNode
+	// Unit is the time or timestamp unit.
+	Unit TimeUnitType
+}
+
+// Format the ExprNode into a Writer.
+func (n *TimeUnitExpr) Format(w io.Writer) {
+	fmt.Fprint(w, n.Unit.String())
+}
+
+// Accept implements Node Accept interface.
+func (n *TimeUnitExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	return v.Leave(newNode)
+}
+
 const (
 	// AggFuncCount is the name of Count function.
 	AggFuncCount = "count"
@@ -108,6 +431,22 @@ const (
 	AggFuncMax = "max"
 	// AggFuncMin is the name of min function.
 	AggFuncMin = "min"
+	// AggFuncGroupConcat is the name of group_concat function.
+	AggFuncGroupConcat = "group_concat"
+	// AggFuncBitOr is the name of bit_or function.
+	AggFuncBitOr = "bit_or"
+	// AggFuncBitXor is the name of bit_xor function.
+	AggFuncBitXor = "bit_xor"
+	// AggFuncBitAnd is the name of bit_and function.
+	AggFuncBitAnd = "bit_and"
+	// AggFuncVarPop is the name of var_pop function.
+	AggFuncVarPop = "var_pop"
+	// AggFuncVarSamp is the name of var_samp function.
+	AggFuncVarSamp = "var_samp"
+	// AggFuncStddevPop is the name of stddev_pop function.
+	AggFuncStddevPop = "stddev_pop"
+	// AggFuncStddevSamp is the name of stddev_samp function.
+	AggFuncStddevSamp = "stddev_samp"
 )
 
 // AggregateFuncExpr represents aggregate function expression.
@@ -117,6 +456,12 @@ type AggregateFuncExpr struct {
 	F string
 	// Args is the function args.
 	Args []ExprNode
+	// Distinct is true, function hence only aggregate distinct values.
+	// For example, column c1 values are "1", "2", "2",  "sum(c1)" is "5",
+	// but "sum(distinct c1)" is "3".
+	Distinct bool
+	// Order is only used in GROUP_CONCAT.
+	Order *OrderByClause
 }
 
 // Format the ExprNode into a Writer.
@@ -138,5 +483,12 @@ func (n *AggregateFuncExpr) Accept(v Visitor) (Node, bool) {
 		}
 		n.Args[i] = node.(ExprNode)
 	}
+	if n.Order != nil {
+		node, ok := n.Order.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Order = node.(*OrderByClause)
+	}
 	return v.Leave(n)
 }
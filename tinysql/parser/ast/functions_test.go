@@ -28,6 +28,8 @@ func (ts *testFunctionsSuite) TestFunctionsVisitorCover(c *C) {
 	stmts := []Node{
 		&AggregateFuncExpr{Args: []ExprNode{valueExpr}},
 		&FuncCallExpr{Args: []ExprNode{valueExpr}},
+		&FuncCastExpr{Expr: valueExpr},
+		&TrimDirectionExpr{},
 	}
 
 	for _, stmt := range stmts {
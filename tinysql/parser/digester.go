@@ -0,0 +1,175 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// Normalize generates the normalized statement text.
+// It removes the general properties of a statement, such as literal values,
+// comments, optimizer hints and index hints, but keeps the specific ones.
+//
+// For example: Normalize("select /*+ HASH_JOIN(t) */ 1 from t use index(a) where a in (1, 2)")
+// => "select ? from t where a in ( ... )"
+func Normalize(sql string) string {
+	d := &sqlDigester{}
+	return d.normalize(sql)
+}
+
+// DigestHash generates the digest of the normalized statement text.
+func DigestHash(sql string) string {
+	_, digest := NormalizeDigest(sql)
+	return digest
+}
+
+// NormalizeDigest combines Normalize and DigestHash into one method.
+func NormalizeDigest(sql string) (normalized, digest string) {
+	normalized = Normalize(sql)
+	hash := sha256.Sum256([]byte(normalized))
+	return normalized, hex.EncodeToString(hash[:])
+}
+
+const (
+	// reducedLit is the placeholder of a literal value.
+	reducedLit = "?"
+	// reducedList is the placeholder of a list of literal values.
+	reducedList = "..."
+)
+
+type digestToken struct {
+	tok int
+	lit string
+}
+
+type sqlDigester struct {
+	tokens []digestToken
+}
+
+func (d *sqlDigester) normalize(sql string) string {
+	scanner := NewScanner(sql)
+	inHint := false
+	for {
+		tok, _, lit := scanner.scan()
+		if tok == 0 || tok == unicode.ReplacementChar {
+			break
+		}
+		switch tok {
+		case hintBegin:
+			inHint = true
+			continue
+		case hintEnd:
+			inHint = false
+			continue
+		}
+		if inHint {
+			continue
+		}
+		d.append(tok, lit)
+	}
+	d.reduceIndexHints()
+	d.reduceLists()
+
+	lits := make([]string, 0, len(d.tokens))
+	for _, token := range d.tokens {
+		lits = append(lits, token.lit)
+	}
+	return strings.Join(lits, " ")
+}
+
+func (d *sqlDigester) append(tok int, lit string) {
+	switch tok {
+	case intLit, floatLit, decLit, stringLit, hexLit, bitLit, paramMarker:
+		// A unary minus before a literal belongs to the literal.
+		if n := len(d.tokens); n > 0 && d.tokens[n-1].lit == "-" && !isOperand(d.tokens[:n-1]) {
+			d.tokens = d.tokens[:n-1]
+		}
+		d.tokens = append(d.tokens, digestToken{tok: paramMarker, lit: reducedLit})
+	case ';':
+		// The trailing semicolon doesn't change the statement.
+	default:
+		d.tokens = append(d.tokens, digestToken{tok: tok, lit: strings.ToLower(lit)})
+	}
+}
+
+// isOperand checks whether the last token of tokens ends an operand, which
+// means the following '-' is a binary operator.
+func isOperand(tokens []digestToken) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	last := tokens[len(tokens)-1]
+	switch last.tok {
+	case paramMarker, quotedIdentifier, ')':
+		return true
+	case identifier:
+		_, isKeyword := tokenMap[strings.ToUpper(last.lit)]
+		return !isKeyword
+	}
+	return false
+}
+
+// reduceIndexHints removes the index hints like "use index(a)", which are
+// regarded as a part of the hints of a statement.
+func (d *sqlDigester) reduceIndexHints() {
+	tokens := d.tokens[:0]
+	for i := 0; i < len(d.tokens); i++ {
+		if i+2 < len(d.tokens) && isIndexHintType(d.tokens[i].lit) &&
+			(d.tokens[i+1].lit == "index" || d.tokens[i+1].lit == "key") {
+			// Skip to the end of the index name list.
+			j := i + 2
+			for j < len(d.tokens) && d.tokens[j].tok != '(' {
+				j++
+			}
+			for j < len(d.tokens) && d.tokens[j].tok != ')' {
+				j++
+			}
+			if j < len(d.tokens) {
+				i = j
+				continue
+			}
+		}
+		tokens = append(tokens, d.tokens[i])
+	}
+	d.tokens = tokens
+}
+
+func isIndexHintType(lit string) bool {
+	return lit == "use" || lit == "ignore" || lit == "force"
+}
+
+// reduceLists replaces the lists only containing literal values like "(1, 2, 3)"
+// with "( ... )", so that the statements only differ in the length of the
+// lists have the same normalized form.
+func (d *sqlDigester) reduceLists() {
+	tokens := d.tokens[:0]
+	for i := 0; i < len(d.tokens); i++ {
+		tokens = append(tokens, d.tokens[i])
+		if d.tokens[i].tok != '(' {
+			continue
+		}
+		j := i + 1
+		for j+1 < len(d.tokens) && d.tokens[j].tok == paramMarker && d.tokens[j+1].tok == ',' {
+			j += 2
+		}
+		if j+1 < len(d.tokens) && d.tokens[j].tok == paramMarker && d.tokens[j+1].tok == ')' {
+			tokens = append(tokens, digestToken{tok: paramMarker, lit: reducedList})
+			i = j
+		}
+	}
+	d.tokens = tokens
+}
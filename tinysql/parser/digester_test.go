@@ -0,0 +1,54 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testSQLDigestSuite{})
+
+type testSQLDigestSuite struct {
+}
+
+func (s *testSQLDigestSuite) TestNormalize(c *C) {
+	tests := []struct {
+		input  string
+		expect string
+	}{
+		{"SELECT 1", "select ?"},
+		{"select * from t where a = 1 and b = 'abc'", "select * from t where a = ? and b = ?"},
+		{"select * from t where a = -1 and b - 1 > 0", "select * from t where a = ? and b - ? > ?"},
+		{"select * from t where a in (1, 2, 3)", "select * from t where a in ( ... )"},
+		{"select * from t where a in (1)", "select * from t where a in ( ... )"},
+		{"select * from `T` where A = ?", "select * from t where a = ?"},
+		{"select /*+ HASH_JOIN(t1) */ * from t t1, t t2 where t1.a = t2.a", "select * from t t1 , t t2 where t1 . a = t2 . a"},
+		{"select * from t use index(a, b) where c > 1", "select * from t where c > ?"},
+		{"select * from t ignore key (a) force index for join (b) where c > 1", "select * from t where c > ?"},
+		{"select * from t /* comment */ where c > 1;", "select * from t where c > ?"},
+		{"insert ignore into t values (1, 2), (3, 4)", "insert ignore into t values ( ... ) , ( ... )"},
+	}
+	for _, test := range tests {
+		normalized := parser.Normalize(test.input)
+		c.Assert(normalized, Equals, test.expect, Commentf("for %s", test.input))
+
+		normalized2, digest := parser.NormalizeDigest(test.input)
+		c.Assert(normalized2, Equals, normalized)
+		c.Assert(parser.DigestHash(test.input), Equals, digest)
+	}
+
+	c.Assert(parser.DigestHash("select * from t where a = 1"), Equals, parser.DigestHash("SELECT * FROM t WHERE a = 2"))
+	c.Assert(parser.DigestHash("select * from t where a = 1"), Not(Equals), parser.DigestHash("select * from t where b = 1"))
+}
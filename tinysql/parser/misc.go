@@ -131,6 +131,7 @@ func init() {
 	initTokenByte('~', int('~'))
 	initTokenByte('\\', int('\\'))
 	initTokenByte('=', eq)
+	initTokenByte('?', paramMarker)
 	initTokenByte('{', int('{'))
 	initTokenByte('}', int('}'))
 
@@ -438,6 +439,7 @@ var tokenMap = map[string]int{
 	"MEMORY":                   memory,
 	"MEMORY_QUOTA":             hintMemoryQuota,
 	"MERGE":                    merge,
+	"MERGE_JOIN":               hintSMJ,
 	"MICROSECOND":              microsecond,
 	"MIN":                      min,
 	"MIN_ROWS":                 minRows,
@@ -775,13 +777,14 @@ var btFuncTokenMap = map[string]int{
 
 // aliases are strings directly map to another string and use the same token.
 var aliases = map[string]string{
-	"SCHEMA":    "DATABASE",
-	"SCHEMAS":   "DATABASES",
-	"DEC":       "DECIMAL",
-	"SUBSTR":    "SUBSTRING",
-	"TIDB_HJ":   "HASH_JOIN",
-	"TIDB_INLJ": "INL_JOIN",
-	"TIDB_SMJ":  "SM_JOIN",
+	"SCHEMA":     "DATABASE",
+	"SCHEMAS":    "DATABASES",
+	"DEC":        "DECIMAL",
+	"SUBSTR":     "SUBSTRING",
+	"TIDB_HJ":    "HASH_JOIN",
+	"TIDB_INLJ":  "INL_JOIN",
+	"TIDB_SMJ":   "SM_JOIN",
+	"MERGE_JOIN": "SM_JOIN",
 }
 
 func (s *Scanner) isTokenIdentifier(lit string, offset int) int {
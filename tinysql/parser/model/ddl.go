@@ -63,6 +63,9 @@ const (
 	ActionUpdateTiFlashReplicaStatus    ActionType = 31
 	ActionAddPrimaryKey                 ActionType = 32
 	ActionDropPrimaryKey                ActionType = 33
+	ActionAddCheckConstraint            ActionType = 34
+	ActionDropCheckConstraint           ActionType = 35
+	ActionAlterCheckConstraint          ActionType = 36
 )
 
 const (
@@ -105,6 +108,9 @@ var actionMap = map[ActionType]string{
 	ActionUpdateTiFlashReplicaStatus:    "update tiflash replica status",
 	ActionAddPrimaryKey:                 AddPrimaryKeyStr,
 	ActionDropPrimaryKey:                "drop primary key",
+	ActionAddCheckConstraint:            "add check constraint",
+	ActionDropCheckConstraint:           "drop check constraint",
+	ActionAlterCheckConstraint:          "alter check constraint",
 }
 
 // String return current ddl action in string
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tipb/go-tipb"
@@ -80,14 +81,17 @@ const (
 
 // ColumnInfo provides meta data describing of a table column.
 type ColumnInfo struct {
-	ID                 int64       `json:"id"`
-	Name               CIStr       `json:"name"`
-	Offset             int         `json:"offset"`
-	OriginDefaultValue interface{} `json:"origin_default"`
-	DefaultValue       interface{} `json:"default"`
-	types.FieldType    `json:"type"`
-	State              SchemaState `json:"state"`
-	Comment            string      `json:"comment"`
+	ID                  int64               `json:"id"`
+	Name                CIStr               `json:"name"`
+	Offset              int                 `json:"offset"`
+	OriginDefaultValue  interface{}         `json:"origin_default"`
+	DefaultValue        interface{}         `json:"default"`
+	GeneratedExprString string              `json:"generated_expr_string"`
+	GeneratedStored     bool                `json:"generated_stored"`
+	Dependences         map[string]struct{} `json:"dependences"`
+	types.FieldType     `json:"type"`
+	State               SchemaState `json:"state"`
+	Comment             string      `json:"comment"`
 	// A hidden column is used internally(expression index) and are not accessible by users.
 	Hidden bool `json:"hidden"`
 	// Version means the version of the column info.
@@ -104,6 +108,11 @@ func (c *ColumnInfo) Clone() *ColumnInfo {
 	return &nc
 }
 
+// IsGenerated returns true if the column is generated column.
+func (c *ColumnInfo) IsGenerated() bool {
+	return len(c.GeneratedExprString) != 0
+}
+
 // SetDefaultValue sets the default value.
 func (c *ColumnInfo) SetDefaultValue(value interface{}) error {
 	c.DefaultValue = value
@@ -192,6 +201,9 @@ type TableInfo struct {
 	AutoIncID   int64         `json:"auto_inc_id"`
 	MaxColumnID int64         `json:"max_col_id"`
 	MaxIndexID  int64         `json:"max_idx_id"`
+	// Constraints are the CHECK constraints of the table.
+	Constraints     []*ConstraintInfo `json:"constraint_info"`
+	MaxConstraintID int64             `json:"max_cst_id"`
 	// UpdateTS is used to record the timestamp of updating the table's schema information.
 	// These changing schema operations don't include 'truncate table' and 'rename table'.
 	UpdateTS uint64 `json:"update_timestamp"`
@@ -224,6 +236,160 @@ type TableInfo struct {
 
 	// TiFlashReplica means the TiFlash replica info.
 	TiFlashReplica *TiFlashReplicaInfo `json:"tiflash_replica"`
+
+	// View is not nil if the table is a view.
+	View *ViewInfo `json:"view"`
+
+	// Partition is not nil if the table is partitioned.
+	Partition *PartitionInfo `json:"partition"`
+}
+
+// ViewAlgorithm is VIEW's SQL ALGORITHM characteristic.
+// See https://dev.mysql.com/doc/refman/5.7/en/view-algorithms.html
+type ViewAlgorithm int
+
+// ViewAlgorithm values.
+const (
+	AlgorithmUndefined ViewAlgorithm = iota
+	AlgorithmMerge
+	AlgorithmTemptable
+)
+
+func (v *ViewAlgorithm) String() string {
+	switch *v {
+	case AlgorithmMerge:
+		return "MERGE"
+	case AlgorithmTemptable:
+		return "TEMPTABLE"
+	case AlgorithmUndefined:
+		return "UNDEFINED"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+// ViewSecurity is VIEW's SQL SECURITY characteristic.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-view.html
+type ViewSecurity int
+
+// ViewSecurity values.
+const (
+	SecurityDefiner ViewSecurity = iota
+	SecurityInvoker
+)
+
+func (v *ViewSecurity) String() string {
+	switch *v {
+	case SecurityInvoker:
+		return "INVOKER"
+	case SecurityDefiner:
+		return "DEFINER"
+	default:
+		return "DEFINER"
+	}
+}
+
+// ViewCheckOption is VIEW's WITH CHECK OPTION clause part.
+// See https://dev.mysql.com/doc/refman/5.7/en/view-check-option.html
+type ViewCheckOption int
+
+// ViewCheckOption values.
+const (
+	CheckOptionNone ViewCheckOption = iota
+	CheckOptionLocal
+	CheckOptionCascaded
+)
+
+func (v *ViewCheckOption) String() string {
+	switch *v {
+	case CheckOptionLocal:
+		return "LOCAL"
+	case CheckOptionCascaded:
+		return "CASCADED"
+	default:
+		return "NONE"
+	}
+}
+
+// ViewInfo provides meta data describing a DB view.
+type ViewInfo struct {
+	Algorithm ViewAlgorithm      `json:"view_algorithm"`
+	Definer   *auth.UserIdentity `json:"view_definer"`
+	Security  ViewSecurity       `json:"view_security"`
+	// SelectStmt is the original text of the view definition. The
+	// unqualified table names in it refer to the schema of the view.
+	SelectStmt  string          `json:"view_select"`
+	CheckOption ViewCheckOption `json:"view_checkoption"`
+	// Cols are the output column names of SelectStmt when the view was
+	// created, they are matched against the select when the view is used.
+	Cols []CIStr `json:"view_cols"`
+}
+
+// PartitionType is the type for PartitionInfo
+type PartitionType int
+
+// Partition types.
+const (
+	PartitionTypeRange PartitionType = 1
+	PartitionTypeHash  PartitionType = 2
+	PartitionTypeList  PartitionType = 3
+)
+
+func (p PartitionType) String() string {
+	switch p {
+	case PartitionTypeRange:
+		return "RANGE"
+	case PartitionTypeHash:
+		return "HASH"
+	case PartitionTypeList:
+		return "LIST"
+	default:
+		return ""
+	}
+}
+
+// PartitionInfo provides table partition info.
+type PartitionInfo struct {
+	Type PartitionType `json:"type"`
+	// Expr is the text of the partitioning expression.
+	Expr        string                `json:"expr"`
+	Definitions []PartitionDefinition `json:"definitions"`
+	Num         uint64                `json:"num"`
+}
+
+// GetNameByID gets the partition name by ID.
+func (pi *PartitionInfo) GetNameByID(id int64) string {
+	for _, def := range pi.Definitions {
+		if id == def.ID {
+			return def.Name.L
+		}
+	}
+	return ""
+}
+
+// FindPartitionDefinitionByName finds the offset of the partition with the
+// given name, it returns -1 if there is no such partition.
+func (pi *PartitionInfo) FindPartitionDefinitionByName(partitionDefinitionName string) int {
+	lowConstrName := strings.ToLower(partitionDefinitionName)
+	for i, def := range pi.Definitions {
+		if def.Name.L == lowConstrName {
+			return i
+		}
+	}
+	return -1
+}
+
+// PartitionDefinition defines a single partition.
+type PartitionDefinition struct {
+	ID   int64 `json:"id"`
+	Name CIStr `json:"name"`
+	// LessThan is the upper bound of a range partition, "MAXVALUE" means
+	// the partition has no upper bound.
+	LessThan []string `json:"less_than"`
+	// InValues are the values of a list partition, a NULL value is stored
+	// as "NULL".
+	InValues [][]string `json:"in_values"`
+	Comment  string     `json:"comment,omitempty"`
 }
 
 // TableLockInfo provides meta data describing a table lock.
@@ -351,6 +517,20 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if len(t.Constraints) > 0 {
+		nt.Constraints = make([]*ConstraintInfo, len(t.Constraints))
+		for i := range t.Constraints {
+			nt.Constraints[i] = t.Constraints[i].Clone()
+		}
+	}
+
+	if t.Partition != nil {
+		pi := *t.Partition
+		pi.Definitions = make([]PartitionDefinition, len(t.Partition.Definitions))
+		copy(pi.Definitions, t.Partition.Definitions)
+		nt.Partition = &pi
+	}
+
 	return &nt
 }
 
@@ -431,6 +611,38 @@ func (t *TableInfo) FindIndexByName(idxName string) *IndexInfo {
 	return nil
 }
 
+// FindConstraintInfoByName finds the CHECK constraint by its name, the name is case insensitive.
+func (t *TableInfo) FindConstraintInfoByName(name string) *ConstraintInfo {
+	lowerName := strings.ToLower(name)
+	for _, c := range t.Constraints {
+		if c.Name.L == lowerName {
+			return c
+		}
+	}
+	return nil
+}
+
+// FindFKInfoByName finds the foreign key by its name, the name is case insensitive.
+func (t *TableInfo) FindFKInfoByName(name string) *FKInfo {
+	lowerName := strings.ToLower(name)
+	for _, fk := range t.ForeignKeys {
+		if fk.Name.L == lowerName {
+			return fk
+		}
+	}
+	return nil
+}
+
+// IsView checks if TableInfo is a view.
+func (t *TableInfo) IsView() bool {
+	return t.View != nil
+}
+
+// GetPartitionInfo returns the partition information.
+func (t *TableInfo) GetPartitionInfo() *PartitionInfo {
+	return t.Partition
+}
+
 // IsLocked checks whether the table was locked.
 func (t *TableInfo) IsLocked() bool {
 	return t.Lock != nil && len(t.Lock.Sessions) > 0
@@ -541,14 +753,15 @@ func (index *IndexInfo) HasPrefixIndex() bool {
 
 // FKInfo provides meta data describing a foreign key constraint.
 type FKInfo struct {
-	ID       int64       `json:"id"`
-	Name     CIStr       `json:"fk_name"`
-	RefTable CIStr       `json:"ref_table"`
-	RefCols  []CIStr     `json:"ref_cols"`
-	Cols     []CIStr     `json:"cols"`
-	OnDelete int         `json:"on_delete"`
-	OnUpdate int         `json:"on_update"`
-	State    SchemaState `json:"state"`
+	ID        int64       `json:"id"`
+	Name      CIStr       `json:"fk_name"`
+	RefSchema CIStr       `json:"ref_schema"`
+	RefTable  CIStr       `json:"ref_table"`
+	RefCols   []CIStr     `json:"ref_cols"`
+	Cols      []CIStr     `json:"cols"`
+	OnDelete  int         `json:"on_delete"`
+	OnUpdate  int         `json:"on_update"`
+	State     SchemaState `json:"state"`
 }
 
 // Clone clones FKInfo.
@@ -563,6 +776,38 @@ func (fk *FKInfo) Clone() *FKInfo {
 	return &nfk
 }
 
+// ConstraintInfo provides meta data describing a CHECK constraint.
+type ConstraintInfo struct {
+	ID             int64       `json:"id"`
+	Name           CIStr       `json:"constraint_name"`
+	Table          CIStr       `json:"tbl_name"`
+	ConstraintCols []CIStr     `json:"constraint_cols"`
+	Enforced       bool        `json:"enforced"`
+	InColumn       bool        `json:"in_column"`
+	ExprString     string      `json:"expr_string"`
+	State          SchemaState `json:"state"`
+}
+
+// Clone clones ConstraintInfo.
+func (ci *ConstraintInfo) Clone() *ConstraintInfo {
+	nci := *ci
+
+	nci.ConstraintCols = make([]CIStr, len(ci.ConstraintCols))
+	copy(nci.ConstraintCols, ci.ConstraintCols)
+
+	return &nci
+}
+
+// HasColumn checks whether the constraint refers to the column.
+func (ci *ConstraintInfo) HasColumn(colName CIStr) bool {
+	for _, col := range ci.ConstraintCols {
+		if col.L == colName.L {
+			return true
+		}
+	}
+	return false
+}
+
 // DBInfo provides meta data describing a DB.
 type DBInfo struct {
 	ID      int64        `json:"id"`      // Database ID
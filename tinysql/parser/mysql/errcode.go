@@ -883,6 +883,7 @@ const (
 	ErrRowInWrongPartition                                          = 1863
 	ErrErrorLast                                                    = 1863
 	ErrMaxExecTimeExceeded                                          = 1907
+	ErrForeignKeyCascadeDepthExceeded                               = 3008
 	ErrInvalidFieldSize                                             = 3013
 	ErrIncorrectType                                                = 3064
 	ErrInvalidJSONData                                              = 3069
@@ -900,12 +901,15 @@ const (
 	ErrInvalidJSONPathWildcard                                      = 3149
 	ErrInvalidJSONContainsPathType                                  = 3150
 	ErrJSONUsedAsKey                                                = 3152
+	ErrJSONDocumentNULLKey                                          = 3158
 	ErrBadUser                                                      = 3162
 	ErrUserAlreadyExists                                            = 3163
 	ErrInvalidJSONPathArrayCell                                     = 3165
 	ErrInvalidEncryptionOption                                      = 3184
+	ErrUnknownAuthID                                                = 3523
 	ErrRoleNotGranted                                               = 3530
 	ErrLockAcquireFailAndNoWaitSet                                  = 3572
+	ErrRoleGrantedToItself                                          = 3573
 	ErrWindowNoSuchWindow                                           = 3579
 	ErrWindowCircularityInWindowGraph                               = 3580
 	ErrWindowNoChildPartitioning                                    = 3581
@@ -927,6 +931,7 @@ const (
 	ErrWindowNoGroupOrderUnused                                     = 3597
 	ErrWindowExplainJson                                            = 3598
 	ErrWindowFunctionIgnoresFrame                                   = 3599
+	ErrFkCannotDropParent                                           = 3730
 	ErrDataTruncatedFunctionalIndex                                 = 3751
 	ErrDataOutOfRangeFunctionalIndex                                = 3752
 	ErrFunctionalIndexOnJsonOrGeometryFunction                      = 3753
@@ -941,11 +946,23 @@ const (
 	ErrFunctionalIndexOnField                                       = 3762
 	ErrFKIncompatibleColumns                                        = 3780
 	ErrFunctionalIndexRowValueIsNotAllowed                          = 3800
+	ErrNonBooleanExprForCheckConstraint                             = 3812
+	ErrColumnCheckConstraintReferencesOtherColumn                   = 3813
+	ErrCheckConstraintNamedFunctionIsNotAllowed                     = 3814
+	ErrCheckConstraintFunctionIsNotAllowed                          = 3815
+	ErrCheckConstraintVariables                                     = 3816
+	ErrCheckConstraintRowValue                                      = 3817
+	ErrCheckConstraintRefersAutoIncrementColumn                     = 3818
+	ErrCheckConstraintViolated                                      = 3819
+	ErrCheckConstraintRefersUnknownColumn                           = 3820
+	ErrCheckConstraintNotFound                                      = 3821
+	ErrCheckConstraintDupName                                       = 3822
 	ErrDependentByFunctionalIndex                                   = 3837
 	ErrInvalidJsonValueForFuncIndex                                 = 3903
 	ErrJsonValueOutOfRangeForFuncIndex                              = 3904
 	ErrFunctionalIndexDataIsTooLong                                 = 3907
 	ErrFunctionalIndexNotApplicable                                 = 3909
+	ErrDependentByCheckConstraint                                   = 3959
 
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed         = 4030
@@ -887,6 +887,7 @@ var MySQLErrName = map[uint16]string{
 	ErrGeneratedColumnNonPrior:                               "Generated column can refer only to generated columns defined prior to it.",
 	ErrDependentByGeneratedColumn:                            "Column '%s' has a generated column dependency.",
 	ErrGeneratedColumnRefAutoInc:                             "Generated column '%s' cannot refer to auto-increment column.",
+	ErrForeignKeyCascadeDepthExceeded:                        "Foreign key cascade delete/update exceeds max depth of %d.",
 	ErrInvalidFieldSize:                                      "Invalid size for column '%s'.",
 	ErrIncorrectType:                                         "Incorrect type for argument %s in function %s.",
 	ErrInvalidJSONData:                                       "Invalid JSON data provided to function %s: %s",
@@ -896,6 +897,7 @@ var MySQLErrName = map[uint16]string{
 	ErrInvalidJSONPathWildcard:                               "In this situation, path expressions may not contain the * and ** tokens.",
 	ErrInvalidJSONContainsPathType:                           "The second argument can only be either 'one' or 'all'.",
 	ErrJSONUsedAsKey:                                         "JSON column '%-.192s' cannot be used in key specification.",
+	ErrJSONDocumentNULLKey:                                   "JSON documents may not contain NULL member names.",
 	ErrBadUser:                                               "User %s does not exist.",
 	ErrUserAlreadyExists:                                     "User %s already exists.",
 	ErrInvalidJSONPathArrayCell:                              "A path expression is not a path to a cell in an array.",
@@ -921,9 +923,12 @@ var MySQLErrName = map[uint16]string{
 	ErrWindowNoGroupOrderUnused:                              "ASC or DESC with GROUP BY isn't allowed with window functions; put ASC or DESC in ORDER BY",
 	ErrWindowExplainJson:                                     "To get information about window functions use EXPLAIN FORMAT=JSON",
 	ErrWindowFunctionIgnoresFrame:                            "Window function '%s' ignores the frame clause of window '%s' and aggregates over the whole partition",
+	ErrUnknownAuthID:                                         "Unknown authorization ID %s",
 	ErrRoleNotGranted:                                        "%s is is not granted to %s",
 	ErrMaxExecTimeExceeded:                                   "Query execution was interrupted, max_execution_time exceeded.",
 	ErrLockAcquireFailAndNoWaitSet:                           "Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.",
+	ErrRoleGrantedToItself:                                   "User account %s is directly or indirectly granted to the role %s. The GRANT would create a loop",
+	ErrFkCannotDropParent:                                    "Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.",
 	ErrDataTruncatedFunctionalIndex:                          "Data truncated for functional index '%s' at row %d",
 	ErrDataOutOfRangeFunctionalIndex:                         "Value is out of range for functional index '%s' at row %d",
 	ErrFunctionalIndexOnJsonOrGeometryFunction:               "Cannot create a functional index on a function that returns a JSON or GEOMETRY value",
@@ -938,11 +943,23 @@ var MySQLErrName = map[uint16]string{
 	ErrFunctionalIndexOnField:                                "Functional index on a column is not supported. Consider using a regular index instead",
 	ErrFKIncompatibleColumns:                                 "Referencing column '%s' in foreign key constraint '%s' are incompatible",
 	ErrFunctionalIndexRowValueIsNotAllowed:                   "Expression of functional index '%s' cannot refer to a row value",
+	ErrNonBooleanExprForCheckConstraint:                      "An expression of non-boolean type specified to a check constraint '%-.192s'.",
+	ErrColumnCheckConstraintReferencesOtherColumn:            "Column check constraint '%-.192s' references other column.",
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              "An expression of a check constraint '%-.192s' contains disallowed function: %s.",
+	ErrCheckConstraintFunctionIsNotAllowed:                   "An expression of a check constraint '%-.192s' contains disallowed function.",
+	ErrCheckConstraintVariables:                              "An expression of a check constraint '%-.192s' cannot refer to a user or system variable.",
+	ErrCheckConstraintRowValue:                               "Check constraint '%-.192s' cannot refer to a row value.",
+	ErrCheckConstraintRefersAutoIncrementColumn:              "Check constraint '%-.192s' cannot refer to an auto-increment column.",
+	ErrCheckConstraintViolated:                               "Check constraint '%-.192s' is violated.",
+	ErrCheckConstraintRefersUnknownColumn:                    "Check constraint '%-.192s' refers to non-existing column '%-.192s'.",
+	ErrCheckConstraintNotFound:                               "Check constraint '%-.192s' is not found in the table.",
+	ErrCheckConstraintDupName:                                "Duplicate check constraint name '%-.192s'.",
 	ErrDependentByFunctionalIndex:                            "Column '%s' has a functional index dependency and cannot be dropped or renamed",
 	ErrInvalidJsonValueForFuncIndex:                          "Invalid JSON value for CAST for functional index '%s'",
 	ErrJsonValueOutOfRangeForFuncIndex:                       "Out of range JSON value for CAST for functional index '%s'",
 	ErrFunctionalIndexDataIsTooLong:                          "Data too long for functional index '%s'",
 	ErrFunctionalIndexNotApplicable:                          "Cannot use functional index '%s' due to type or collation conversion",
+	ErrDependentByCheckConstraint:                            "Check constraint '%-.192s' uses column '%-.192s', hence column cannot be dropped or renamed.",
 
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed:         "Only one DEFAULT partition allowed",
@@ -30,6 +30,7 @@ import (
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/parser/charset"
@@ -684,12 +685,14 @@ import (
 	neq		"!="
 	neqSynonym	"<>"
 	nulleq		"<=>"
+	paramMarker	"?"
 	rsh		">>"
 
 %token not2
 
 %type	<expr>
 	Expression			"expression"
+	MaxValueOrExpression		"MAXVALUE or expression"
 	BoolPri				"boolean primary expression"
 	ExprOrDefault			"expression or default"
 	PredicateExpr			"Predicate expression factor"
@@ -715,23 +718,39 @@ import (
 %type	<statement>
 	AdminStmt			"Check table statement or show ddl statement"
 	AlterTableStmt			"Alter table statement"
+	AlterUserStmt			"Alter user statement"
 	AnalyzeTableStmt		"Analyze table statement"
 	BeginTransactionStmt		"BEGIN TRANSACTION statement"
 
 	CommitStmt			"COMMIT statement"
+	CreateBindingStmt		"CREATE BINDING statement"
 	CreateTableStmt			"CREATE TABLE statement"
 	CreateDatabaseStmt		"Create Database Statement"
 	CreateIndexStmt			"CREATE INDEX statement"
+	CreateUserStmt			"CREATE User statement"
+	CreateRoleStmt			"CREATE Role statement"
+	CreateViewStmt			"CREATE VIEW  statement"
+	DeallocateStmt			"Deallocate prepared statement"
+	DropBindingStmt			"DROP BINDING statement"
 	DropDatabaseStmt		"DROP DATABASE statement"
 	DropIndexStmt			"DROP INDEX statement"
 	DropTableStmt			"DROP TABLE statement"
+	DropUserStmt			"DROP USER"
+	DropRoleStmt			"DROP ROLE"
+	DropViewStmt			"DROP VIEW statement"
 	DeleteFromStmt			"DELETE FROM statement"
 	EmptyStmt			"empty statement"
+	ExecuteStmt			"Execute statement"
 	ExplainStmt			"EXPLAIN statement"
 	ExplainableStmt			"explainable statement"
+	GrantStmt			"Grant statement"
+	GrantRoleStmt			"Grant role statement"
 	InsertIntoStmt			"INSERT INTO statement"
+	PreparedStmt			"PreparedStmt"
 	SelectStmt			"SELECT statement"
 	ReplaceIntoStmt			"REPLACE INTO statement"
+	RevokeStmt			"Revoke statement"
+	RevokeRoleStmt			"Revoke role statement"
 	RollbackStmt			"ROLLBACK statement"
 	SetStmt				"Set variable statement"
 	ShowStmt			"Show engines/databases/tables/user/columns/warnings/status statement"
@@ -741,6 +760,21 @@ import (
 
 %type   <item>
 	AlterTableSpec			"Alter table specification"
+	AuthOption			"User auth option"
+	ObjectType			"Grant statement object type"
+	PrivElem			"Privilege element"
+	PrivElemList			"Privilege element list"
+	PrivLevel			"Privilege scope"
+	PrivType			"Privilege type"
+	Rolename			"Rolename"
+	RolenameList			"RolenameList"
+	SetRoleOpt			"Set role options"
+	SetDefaultRoleOpt		"Set default role options"
+	Username			"Username"
+	UsernameList			"UsernameList"
+	UserSpec			"Username and auth option"
+	UserSpecList			"Username and auth option list"
+	WithGrantOptionOpt		"With Grant Option opt"
 	AlterTableSpecList		"Alter table specification list"
 	AlterTableSpecListOpt		"Alter table specification list optional"
 	AnyOrAll			"Any or All for subquery"
@@ -748,7 +782,12 @@ import (
 	AssignmentList			"assignment list"
 	AssignmentListOpt		"assignment list opt"
 	OptionalBraces			"optional braces"
+	PrepareSQL			"Prepare statement sql string"
+	UserVariableList		"User defined variable name list"
 	CastType			"Cast function target type"
+	TrimDirection			"Trim string direction"
+	TimeUnit			"Time unit for 'DATE_ADD', 'DATE_SUB', 'ADDDATE', 'SUBDATE'"
+	TimestampUnit			"Time unit for 'TIMESTAMPADD' and 'TIMESTAMPDIFF'"
 	CharsetName			"Character set name"
 	CollationName			"Collation name"
 	ColumnDef			"table column definition"
@@ -774,6 +813,7 @@ import (
 	DistinctOpt			"Explicit distinct option"
 	DefaultFalseDistinctOpt		"Distinct option which defaults to false"
 	DefaultTrueDistinctOpt		"Distinct option which defaults to true"
+	ElseOpt				"Optional else clause"
 	EqOpt				"= or empty"
 	EscapedTableRef 		"escaped table reference"
 	ExplainFormatType		"explain format type"
@@ -788,6 +828,14 @@ import (
 	TableRefsClause			"Table references clause"
 	FuncDatetimePrec		"Function datetime precision"
 	GlobalScope			"The scope of variable"
+	OrReplace			"Optional OR REPLACE"
+	IdentList			"Identifier list"
+	ViewAlgorithm			"view algorithm"
+	ViewCheckOption			"view check option"
+	ViewDefiner			"view definer"
+	ViewFieldList			"create view statement field list"
+	ViewName			"view name"
+	ViewSQLSecurity			"view sql security"
 	GroupByClause			"GROUP BY clause"
 	HavingClause			"HAVING clause"
 	IfExists			"If Exists"
@@ -819,6 +867,9 @@ import (
 	LimitClause			"LIMIT clause"
 	LimitOption			"Limit option could be integer or parameter marker."
 	NumLiteral			"Num/Int/Float/Decimal Literal"
+	OnDelete			"ON DELETE clause"
+	OnUpdate			"ON UPDATE clause"
+	OnDeleteUpdateOpt		"optional ON DELETE and UPDATE clause"
 	OptFull				"Full or empty"
 	OptTemporary			"TEMPORARY or empty"
 	Order				"ORDER BY clause optional collation specification"
@@ -829,6 +880,16 @@ import (
 	QuickOptional			"QUICK or empty"
 	QueryBlockOpt			"Query block identifier optional"
 	PriorityOpt			"Statement priority option"
+	PartitionOpt			"Partition option"
+	PartitionMethod			"Partition method"
+	PartitionNumOpt			"PARTITIONS num option"
+	PartitionDefinition		"Partition definition"
+	PartitionDefinitionList		"Partition definition list"
+	PartitionDefinitionListOpt	"Partition definition list option"
+	PartDefValuesOpt		"VALUES LESS THAN or VALUES IN option of a partition definition"
+	PartDefCommentOpt		"Comment option of a partition definition"
+	PartitionNameList		"Partition name list"
+	PartitionNameListOpt		"Partition name list option"
 	OptGConcatSeparator		"optional GROUP_CONCAT SEPARATOR"
 	RowValue			"Row value"
 	SelectStmtCalcFoundRows		"SELECT statement optional SQL_CALC_FOUND_ROWS"
@@ -839,6 +900,8 @@ import (
 	SelectStmtStraightJoin		"SELECT statement optional STRAIGHT_JOIN"
 	SelectStmtFieldList		"SELECT statement field list"
 	SelectStmtLimit			"SELECT statement optional LIMIT clause"
+	ReferDef			"Reference definition"
+	ReferOpt			"reference option"
 	SelectStmtOpts			"Select statement options"
 	SelectStmtBasic			"SELECT statement from constant value"
 	SelectStmtFromDualTable			"SELECT statement from dual table"
@@ -871,6 +934,8 @@ import (
 	ValuesOpt		"values optional"
 	VariableAssignment	"set variable value"
 	VariableAssignmentList	"set variable value list"
+	WhenClause		"When clause"
+	WhenClauseList		"When clause list"
 	WhereClause		"WHERE clause"
 	WhereClauseOptional	"Optional WHERE clause"
 	WithValidation		"with validation"
@@ -925,6 +990,9 @@ import (
 
 %type	<ident>
 	AsOpt			"AS or EmptyString"
+	AuthString		"Password string value"
+	HashString		"Hashed string"
+	PasswordOpt		"Password option"
 	KeyOrIndex		"{KEY|INDEX}"
 	ColumnKeywordOpt	"Column keyword or empty"
 	PrimaryOpt		"Optional primary keyword"
@@ -1137,6 +1205,28 @@ AlterTableSpec:
 			Name: $5.(string),
 		}
 	}
+|	"ADD" "PARTITION" '(' PartitionDefinitionList ')'
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableAddPartitions,
+			PartDefinitions: $4.([]*ast.PartitionDefinition),
+		}
+	}
+|	"DROP" "PARTITION" IfExists PartitionNameList %prec lowerThanComma
+	{
+		$$ = &ast.AlterTableSpec{
+			IfExists: $3.(bool),
+			Tp: ast.AlterTableDropPartition,
+			PartitionNames: $4.([]model.CIStr),
+		}
+	}
+|	"TRUNCATE" "PARTITION" PartitionNameList %prec lowerThanComma
+	{
+		$$ = &ast.AlterTableSpec{
+			Tp: ast.AlterTableTruncatePartition,
+			PartitionNames: $3.([]model.CIStr),
+		}
+	}
 |	"DISABLE" "KEYS"
 	{
 		$$ = &ast.AlterTableSpec{
@@ -1263,30 +1353,26 @@ AlterTableSpec:
 	}
 |	"ALTER" "CHECK" Identifier EnforcedOrNot
 	{
-		// Parse it and ignore it. Just for compatibility.
 		c := &ast.Constraint{
-			Name: $3,
+			Tp:       ast.ConstraintCheck,
+			Name:     $3,
 			Enforced: $4.(bool),
 		}
 		$$ = &ast.AlterTableSpec{
 			Tp:               ast.AlterTableAlterCheck,
 			Constraint:       c,
 		}
-		yylex.AppendError(yylex.Errorf("The ALTER CHECK clause is parsed but not implemented yet."))
-		parser.lastErrorAsWarn()
 	}
 |	"DROP" "CHECK" Identifier
 	{
-		// Parse it and ignore it. Just for compatibility.
 		c := &ast.Constraint{
+			Tp:   ast.ConstraintCheck,
 			Name: $3,
 		}
 		$$ = &ast.AlterTableSpec{
 			Tp:               ast.AlterTableDropCheck,
 			Constraint:       c,
 		}
-		yylex.AppendError(yylex.Errorf("The DROP CHECK clause is parsed but not implemented yet."))
-		parser.lastErrorAsWarn()
 	}
 |	"ALTER" "INDEX" Identifier IndexInvisible
 	{
@@ -1569,15 +1655,21 @@ ColumnOption:
 	}
 |	ConstraintKeywordOpt "CHECK" '(' Expression ')' EnforcedOrNotOrNotNullOpt
 	{
-		// See https://dev.mysql.com/doc/refman/5.7/en/create-table.html
-		// The CHECK clause is parsed but ignored by all storage engines.
+		// See https://dev.mysql.com/doc/refman/8.0/en/create-table-check-constraints.html
 		// See the branch named `EnforcedOrNotOrNotNullOpt`.
+		startOffset := parser.startOffset(&yyS[yypt-2])
+		endOffset := parser.endOffset(&yyS[yypt-1])
+		expr := $4
+		expr.SetText(parser.src[startOffset:endOffset])
 
 		optionCheck := &ast.ColumnOption{
 			Tp: ast.ColumnOptionCheck,
-			Expr: $4,
+			Expr: expr,
 			Enforced: true,
 		}
+		if name := $1; name != nil {
+			optionCheck.ConstraintName = name.(string)
+		}
 		switch $6.(int) {
 		case 0:
 			$$ = []*ast.ColumnOption{optionCheck, {Tp: ast.ColumnOptionNotNull}}
@@ -1589,8 +1681,6 @@ ColumnOption:
 			$$ = optionCheck
 		default:
 		}
-		yylex.AppendError(yylex.Errorf("The CHECK clause is parsed but ignored by all storage engines."))
-		parser.lastErrorAsWarn()
 	}
 |	GeneratedAlways "AS" '(' Expression ')' VirtualOrStored
 	{
@@ -1751,15 +1841,27 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"FOREIGN" "KEY" IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' ReferDef
+	{
+		$$ = &ast.Constraint{
+			IfNotExists:	$3.(bool),
+			Tp:		ast.ConstraintForeignKey,
+			Keys:		$6.([]*ast.IndexPartSpecification),
+			Name:		$4.([]interface{})[0].(string),
+			Refer:		$8.(*ast.ReferenceDef),
+		}
+	}
 |	"CHECK" '(' Expression ')' EnforcedOrNotOpt
 	{
+		startOffset := parser.startOffset(&yyS[yypt-2])
+		endOffset := parser.endOffset(&yyS[yypt-1])
+		expr := $3.(ast.ExprNode)
+		expr.SetText(parser.src[startOffset:endOffset])
 		$$ = &ast.Constraint{
 			Tp:		ast.ConstraintCheck,
-			Expr:		$3.(ast.ExprNode),
+			Expr:		expr,
 			Enforced:	$5.(bool),
 		}
-		yylex.AppendError(yylex.Errorf("The CHECK clause is parsed but ignored by all storage engines."))
-		parser.lastErrorAsWarn()
 	}
 
 /*
@@ -1875,6 +1977,74 @@ CreateIndexStmt:
 		}
 	}
 
+ReferDef:
+	"REFERENCES" TableName '(' IndexPartSpecificationList ')' OnDeleteUpdateOpt
+	{
+		onDeleteUpdate := $6.([2]interface{})
+		$$ = &ast.ReferenceDef{
+			Table:				$2.(*ast.TableName),
+			IndexPartSpecifications:	$4.([]*ast.IndexPartSpecification),
+			OnDelete:			onDeleteUpdate[0].(*ast.OnDeleteOpt),
+			OnUpdate:			onDeleteUpdate[1].(*ast.OnUpdateOpt),
+		}
+	}
+
+OnDelete:
+	"ON" "DELETE" ReferOpt
+	{
+		$$ = &ast.OnDeleteOpt{ReferOpt: $3.(ast.ReferOptionType)}
+	}
+
+OnUpdate:
+	"ON" "UPDATE" ReferOpt
+	{
+		$$ = &ast.OnUpdateOpt{ReferOpt: $3.(ast.ReferOptionType)}
+	}
+
+OnDeleteUpdateOpt:
+	%prec lowerThanOn
+	{
+		$$ = [2]interface{}{&ast.OnDeleteOpt{}, &ast.OnUpdateOpt{}}
+	}
+|	OnDelete %prec lowerThanOn
+	{
+		$$ = [2]interface{}{$1, &ast.OnUpdateOpt{}}
+	}
+|	OnUpdate %prec lowerThanOn
+	{
+		$$ = [2]interface{}{&ast.OnDeleteOpt{}, $1}
+	}
+|	OnDelete OnUpdate
+	{
+		$$ = [2]interface{}{$1, $2}
+	}
+|	OnUpdate OnDelete
+	{
+		$$ = [2]interface{}{$2, $1}
+	}
+
+ReferOpt:
+	"RESTRICT"
+	{
+		$$ = ast.ReferOptionRestrict
+	}
+|	"CASCADE"
+	{
+		$$ = ast.ReferOptionCascade
+	}
+|	"SET" "NULL"
+	{
+		$$ = ast.ReferOptionSetNull
+	}
+|	"NO" "ACTION"
+	{
+		$$ = ast.ReferOptionNoAction
+	}
+|	"SET" "DEFAULT"
+	{
+		$$ = ast.ReferOptionSetDefault
+	}
+
 IndexPartSpecificationListOpt:
 	{
 		$$ = ([]*ast.IndexPartSpecification)(nil)
@@ -1996,12 +2166,15 @@ DatabaseOptionList:
  *******************************************************************/
 
 CreateTableStmt:
-	"CREATE" OptTemporary "TABLE" IfNotExists TableName TableElementListOpt AsOpt
+	"CREATE" OptTemporary "TABLE" IfNotExists TableName TableElementListOpt PartitionOpt AsOpt
 	{
 		stmt := $6.(*ast.CreateTableStmt)
 		stmt.Table = $5.(*ast.TableName)
 		stmt.IfNotExists = $4.(bool)
 		stmt.IsTemporary = $2.(bool)
+		if $7 != nil {
+			stmt.Partition = $7.(*ast.PartitionOptions)
+		}
 		$$ = stmt
 	}
 |	"CREATE" OptTemporary "TABLE" IfNotExists TableName LikeTableWithOrWithoutParen
@@ -2024,6 +2197,140 @@ AsOpt:
 |	"AS"
 	{}
 
+PartitionOpt:
+	{
+		$$ = nil
+	}
+|	"PARTITION" "BY" PartitionMethod PartitionNumOpt PartitionDefinitionListOpt
+	{
+		opt := $3.(*ast.PartitionOptions)
+		opt.Num = $4.(uint64)
+		if $5 != nil {
+			opt.Definitions = $5.([]*ast.PartitionDefinition)
+		}
+		$$ = opt
+	}
+
+PartitionMethod:
+	"RANGE" '(' Expression ')'
+	{
+		startOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.endOffset(&yyS[yypt])
+		expr := $3
+		expr.SetText(parser.src[startOffset:endOffset])
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeRange, Expr: expr}
+	}
+|	"HASH" '(' Expression ')'
+	{
+		startOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.endOffset(&yyS[yypt])
+		expr := $3
+		expr.SetText(parser.src[startOffset:endOffset])
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeHash, Expr: expr}
+	}
+|	"LIST" '(' Expression ')'
+	{
+		startOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.endOffset(&yyS[yypt])
+		expr := $3
+		expr.SetText(parser.src[startOffset:endOffset])
+		$$ = &ast.PartitionOptions{Tp: model.PartitionTypeList, Expr: expr}
+	}
+
+PartitionNumOpt:
+	{
+		$$ = uint64(0)
+	}
+|	"PARTITIONS" LengthNum
+	{
+		$$ = $2
+	}
+
+PartitionDefinitionListOpt:
+	{
+		$$ = nil
+	}
+|	'(' PartitionDefinitionList ')'
+	{
+		$$ = $2
+	}
+
+PartitionDefinitionList:
+	PartitionDefinition
+	{
+		$$ = []*ast.PartitionDefinition{$1.(*ast.PartitionDefinition)}
+	}
+|	PartitionDefinitionList ',' PartitionDefinition
+	{
+		$$ = append($1.([]*ast.PartitionDefinition), $3.(*ast.PartitionDefinition))
+	}
+
+PartitionDefinition:
+	"PARTITION" Identifier PartDefValuesOpt PartDefCommentOpt
+	{
+		def := $3.(*ast.PartitionDefinition)
+		def.Name = model.NewCIStr($2)
+		def.Comment = $4.(string)
+		$$ = def
+	}
+
+PartDefValuesOpt:
+	{
+		$$ = &ast.PartitionDefinition{}
+	}
+|	"VALUES" "LESS" "THAN" "MAXVALUE"
+	{
+		$$ = &ast.PartitionDefinition{LessThan: []ast.ExprNode{&ast.MaxValueExpr{}}}
+	}
+|	"VALUES" "LESS" "THAN" '(' MaxValueOrExpression ')'
+	{
+		$$ = &ast.PartitionDefinition{LessThan: []ast.ExprNode{$5}}
+	}
+|	"VALUES" "IN" '(' ExpressionList ')'
+	{
+		exprs := $4.([]ast.ExprNode)
+		values := make([][]ast.ExprNode, 0, len(exprs))
+		for _, expr := range exprs {
+			values = append(values, []ast.ExprNode{expr})
+		}
+		$$ = &ast.PartitionDefinition{InValues: values}
+	}
+
+MaxValueOrExpression:
+	"MAXVALUE"
+	{
+		$$ = &ast.MaxValueExpr{}
+	}
+|	Expression
+
+PartDefCommentOpt:
+	{
+		$$ = ""
+	}
+|	"COMMENT" EqOpt stringLit
+	{
+		$$ = $3
+	}
+
+PartitionNameList:
+	Identifier
+	{
+		$$ = []model.CIStr{model.NewCIStr($1)}
+	}
+|	PartitionNameList ',' Identifier
+	{
+		$$ = append($1.([]model.CIStr), model.NewCIStr($3))
+	}
+
+PartitionNameListOpt:
+	{
+		$$ = []model.CIStr{}
+	}
+|	"PARTITION" '(' PartitionNameList ')'
+	{
+		$$ = $3
+	}
+
 LikeTableWithOrWithoutParen:
 	"LIKE" TableName
 	{
@@ -2087,12 +2394,187 @@ DropDatabaseStmt:
  *  lock_option:
  *      LOCK [=] {DEFAULT|NONE|SHARED|EXCLUSIVE}
  ******************************************************************/
+/*******************************************************************
+ *
+ *  Create Binding Statement
+ *
+ *  Example:
+ *      CREATE GLOBAL BINDING FOR select Col1,Col2 from table USING select Col1,Col2 from table use index(Col1)
+ *******************************************************************/
+CreateBindingStmt:
+	"CREATE" GlobalScope "BINDING" "FOR" SelectStmt "USING" SelectStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt-2])
+		endOffset := parser.startOffset(&yyS[yypt-1])
+		selStmt := $5.(*ast.SelectStmt)
+		selStmt.SetText(strings.TrimSpace(parser.src[startOffset:endOffset]))
+
+		startOffset = parser.startOffset(&yyS[yypt])
+		hintedSelStmt := $7.(*ast.SelectStmt)
+		hintedSelStmt.SetText(strings.TrimSpace(parser.src[startOffset:]))
+
+		$$ = &ast.CreateBindingStmt{
+			OriginSel:	selStmt,
+			HintedSel:	hintedSelStmt,
+			GlobalScope:	$2.(bool),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Create View Statement
+ *
+ *  Example:
+ *      CREATE VIEW OR REPLACE ALGORITHM = MERGE DEFINER="root@localhost" SQL SECURITY = definer view_name (col1,col2)
+ *          as select Col1,Col2 from table WITH LOCAL CHECK OPTION
+ *******************************************************************/
+CreateViewStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "VIEW" ViewName ViewFieldList "AS" SelectStmt ViewCheckOption
+	{
+		startOffset := parser.startOffset(&yyS[yypt-1])
+		selStmt := $10.(*ast.SelectStmt)
+		selStmt.SetText(strings.TrimSpace(parser.src[startOffset:]))
+		x := &ast.CreateViewStmt{
+			OrReplace:	$2.(bool),
+			ViewName:	$7.(*ast.TableName),
+			Select:		selStmt,
+			Algorithm:	$3.(model.ViewAlgorithm),
+			Definer:	$4.(*auth.UserIdentity),
+			Security:	$5.(model.ViewSecurity),
+		}
+		if $8 != nil {
+			x.Cols = $8.([]model.CIStr)
+		}
+		if $11 != nil {
+			x.CheckOption = $11.(model.ViewCheckOption)
+			endOffset := parser.startOffset(&yyS[yypt])
+			selStmt.SetText(strings.TrimSpace(parser.src[startOffset:endOffset]))
+		} else {
+			x.CheckOption = model.CheckOptionNone
+		}
+		$$ = x
+	}
+
+OrReplace:
+	{
+		$$ = false
+	}
+|	"OR" "REPLACE"
+	{
+		$$ = true
+	}
+
+ViewAlgorithm:
+	/* EMPTY */
+	{
+		$$ = model.AlgorithmUndefined
+	}
+|	"ALGORITHM" "=" "UNDEFINED"
+	{
+		$$ = model.AlgorithmUndefined
+	}
+|	"ALGORITHM" "=" "MERGE"
+	{
+		$$ = model.AlgorithmMerge
+	}
+|	"ALGORITHM" "=" "TEMPTABLE"
+	{
+		$$ = model.AlgorithmTemptable
+	}
+
+ViewDefiner:
+	/* EMPTY */
+	{
+		$$ = &auth.UserIdentity{CurrentUser: true}
+	}
+|	"DEFINER" "=" Username
+	{
+		$$ = $3
+	}
+
+ViewSQLSecurity:
+	/* EMPTY */
+	{
+		$$ = model.SecurityDefiner
+	}
+|	"SQL" "SECURITY" "DEFINER"
+	{
+		$$ = model.SecurityDefiner
+	}
+|	"SQL" "SECURITY" "INVOKER"
+	{
+		$$ = model.SecurityInvoker
+	}
+
+ViewName:
+	TableName
+	{
+		$$ = $1.(*ast.TableName)
+	}
+
+ViewFieldList:
+	/* Empty */
+	{
+		$$ = nil
+	}
+|	'(' IdentList ')'
+	{
+		$$ = $2.([]model.CIStr)
+	}
+
+IdentList:
+	Identifier
+	{
+		$$ = []model.CIStr{model.NewCIStr($1)}
+	}
+|	IdentList ',' Identifier
+	{
+		$$ = append($1.([]model.CIStr), model.NewCIStr($3))
+	}
+
+ViewCheckOption:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"WITH" "CASCADED" "CHECK" "OPTION"
+	{
+		$$ = model.CheckOptionCascaded
+	}
+|	"WITH" "LOCAL" "CHECK" "OPTION"
+	{
+		$$ = model.CheckOptionLocal
+	}
+|	"WITH" "CHECK" "OPTION"
+	{
+		$$ = model.CheckOptionCascaded
+	}
+
+DropViewStmt:
+	"DROP" "VIEW" IfExists TableNameList RestrictOrCascadeOpt
+	{
+		$$ = &ast.DropTableStmt{IfExists: $3.(bool), Tables: $4.([]*ast.TableName), IsView: true}
+	}
+
 DropIndexStmt:
 	"DROP" "INDEX" IfExists Identifier "ON" TableName
 	{
 		$$ = &ast.DropIndexStmt{IfExists: $3.(bool), IndexName: $4, Table: $6.(*ast.TableName)}
 	}
 
+DropBindingStmt:
+	"DROP" GlobalScope "BINDING" "FOR" SelectStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $5.(*ast.SelectStmt)
+		selStmt.SetText(strings.TrimSpace(parser.src[startOffset:]))
+
+		$$ = &ast.DropBindingStmt{
+			GlobalScope:	$2.(bool),
+			OriginSel:	selStmt,
+		}
+	}
+
 DropTableStmt:
 	"DROP" OptTemporary TableOrTables IfExists TableNameList RestrictOrCascadeOpt
 	{
@@ -2152,6 +2634,14 @@ ExplainStmt:
 			Format: $4.(string),
 		}
 	}
+|	ExplainSym "ANALYZE" ExplainableStmt
+	{
+		$$ = &ast.ExplainStmt{
+			Stmt:		$3,
+			Format:		"row",
+			Analyze:	true,
+		}
+	}
 
 ExplainFormatType:
 	"TRADITIONAL"
@@ -2962,10 +3452,32 @@ BitExpr:
 	{
 		$$ = &ast.BinaryOperationExpr{Op: opcode.Minus, L: $1, R: $3}
 	}
-|	BitExpr '*' BitExpr %prec '*'
+|	BitExpr '+' "INTERVAL" Expression TimeUnit %prec '+'
 	{
-		$$ = &ast.BinaryOperationExpr{Op: opcode.Mul, L: $1, R: $3}
-	}
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr("DATE_ADD"),
+			Args: []ast.ExprNode{
+				$1,
+				$4,
+				&ast.TimeUnitExpr{Unit: $5.(ast.TimeUnitType)},
+			},
+		}
+	}
+|	BitExpr '-' "INTERVAL" Expression TimeUnit %prec '+'
+	{
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr("DATE_SUB"),
+			Args: []ast.ExprNode{
+				$1,
+				$4,
+				&ast.TimeUnitExpr{Unit: $5.(ast.TimeUnitType)},
+			},
+		}
+	}
+|	BitExpr '*' BitExpr %prec '*'
+	{
+		$$ = &ast.BinaryOperationExpr{Op: opcode.Mul, L: $1, R: $3}
+	}
 |	BitExpr '/' BitExpr %prec '/'
 	{
 		$$ = &ast.BinaryOperationExpr{Op: opcode.Div, L: $1, R: $3}
@@ -3020,6 +3532,17 @@ SimpleIdent:
 
 SimpleExpr:
 	SimpleIdent
+|	SimpleIdent jss stringLit
+	{
+		expr := ast.NewValueExpr($3)
+		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONExtract), Args: []ast.ExprNode{$1, expr}}
+	}
+|	SimpleIdent juss stringLit
+	{
+		expr := ast.NewValueExpr($3)
+		extract := &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONExtract), Args: []ast.ExprNode{$1, expr}}
+		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONUnquote), Args: []ast.ExprNode{extract}}
+	}
 |	FunctionCallKeyword
 |	FunctionCallNonKeyword
 |	FunctionCallGeneric
@@ -3029,6 +3552,10 @@ SimpleExpr:
 		$$ = $1
 	}
 |	Literal
+|	paramMarker
+	{
+		$$ = ast.NewParamMarkerExpr(yyS[yypt].offset)
+	}
 |	Variable
 |	SumExpr
 |	'!' SimpleExpr %prec neg
@@ -3068,6 +3595,51 @@ SimpleExpr:
 		values := append($3.([]ast.ExprNode), $5)
 		$$ = &ast.RowExpr{Values: values}
 	}
+|	"CASE" ExpressionOpt WhenClauseList ElseOpt "END"
+	{
+		x := &ast.CaseExpr{WhenClauses: $3.([]*ast.WhenClause)}
+		if $2 != nil {
+			x.Value = $2
+		}
+		if $4 != nil {
+			x.ElseClause = $4.(ast.ExprNode)
+		}
+		$$ = x
+	}
+|	builtinCast '(' Expression "AS" CastType ')'
+	{
+		/* See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_cast */
+		tp := $5.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
+		if tp.Flen == types.UnspecifiedLength {
+			tp.Flen = defaultFlen
+		}
+		if tp.Decimal == types.UnspecifiedLength {
+			tp.Decimal = defaultDecimal
+		}
+		$$ = &ast.FuncCastExpr{
+			Expr:         $3,
+			Tp:           tp,
+			FunctionType: ast.CastFunction,
+		}
+	}
+|	"CONVERT" '(' Expression ',' CastType ')'
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_convert
+		tp := $5.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
+		if tp.Flen == types.UnspecifiedLength {
+			tp.Flen = defaultFlen
+		}
+		if tp.Decimal == types.UnspecifiedLength {
+			tp.Decimal = defaultDecimal
+		}
+		$$ = &ast.FuncCastExpr{
+			Expr:         $3,
+			Tp:           tp,
+			FunctionType: ast.CastConvertFunction,
+		}
+	}
 |	"CONVERT" '(' Expression "USING" CharsetName ')'
 	{
 		// See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_convert
@@ -3086,6 +3658,35 @@ SimpleExpr:
 		$$ = &ast.ValuesExpr{Column: $3.(*ast.ColumnNameExpr)}
 	}
 
+WhenClauseList:
+	WhenClause
+	{
+		$$ = []*ast.WhenClause{$1.(*ast.WhenClause)}
+	}
+|	WhenClauseList WhenClause
+	{
+		$$ = append($1.([]*ast.WhenClause), $2.(*ast.WhenClause))
+	}
+
+WhenClause:
+	"WHEN" Expression "THEN" Expression
+	{
+		$$ = &ast.WhenClause{
+			Expr: $2,
+			Result: $4,
+		}
+	}
+
+ElseOpt:
+	/* empty */
+	{
+		$$ = nil
+	}
+|	"ELSE" Expression
+	{
+		$$ = $2
+	}
+
 DistinctKwd:
 	"DISTINCT"
 |	"DISTINCTROW"
@@ -3201,6 +3802,39 @@ FunctionCallNonKeyword:
 	{
 		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr($1), Args: $3.([]ast.ExprNode)}
 	}
+|	FunctionNameDateArithMultiForms '(' Expression ',' Expression ')'
+	{
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr($1),
+			Args: []ast.ExprNode{
+				$3,
+				$5,
+				&ast.TimeUnitExpr{Unit: ast.TimeUnitDay},
+			},
+		}
+	}
+|	FunctionNameDateArithMultiForms '(' Expression ',' "INTERVAL" Expression TimeUnit ')'
+	{
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr($1),
+			Args: []ast.ExprNode{
+				$3,
+				$6,
+				&ast.TimeUnitExpr{Unit: $7.(ast.TimeUnitType)},
+			},
+		}
+	}
+|	FunctionNameDateArith '(' Expression ',' "INTERVAL" Expression TimeUnit ')'
+	{
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr($1),
+			Args: []ast.ExprNode{
+				$3,
+				$6,
+				&ast.TimeUnitExpr{Unit: $7.(ast.TimeUnitType)},
+			},
+		}
+	}
 |	builtinPosition '(' BitExpr "IN" Expression ')'
 	{
 		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr($1), Args: []ast.ExprNode{$3, $5}}
@@ -3247,6 +3881,122 @@ FunctionCallNonKeyword:
 			Args: []ast.ExprNode{$5, $3},
 		}
 	}
+|	builtinTrim '(' TrimDirection "FROM" Expression ')'
+	{
+		spaceVal := ast.NewValueExpr(" ")
+		direction := &ast.TrimDirectionExpr{Direction: $3.(ast.TrimDirectionType)}
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr($1),
+			Args: []ast.ExprNode{$5, spaceVal, direction},
+		}
+	}
+|	builtinTrim '(' TrimDirection Expression "FROM" Expression ')'
+	{
+		direction := &ast.TrimDirectionExpr{Direction: $3.(ast.TrimDirectionType)}
+		$$ = &ast.FuncCallExpr{
+			FnName: model.NewCIStr($1),
+			Args: []ast.ExprNode{$6, $4, direction},
+		}
+	}
+
+TrimDirection:
+	"BOTH"
+	{
+		$$ = ast.TrimBoth
+	}
+|	"LEADING"
+	{
+		$$ = ast.TrimLeading
+	}
+|	"TRAILING"
+	{
+		$$ = ast.TrimTrailing
+	}
+
+TimeUnit:
+	TimestampUnit
+|	"SECOND_MICROSECOND"
+	{
+		$$ = ast.TimeUnitSecondMicrosecond
+	}
+|	"MINUTE_MICROSECOND"
+	{
+		$$ = ast.TimeUnitMinuteMicrosecond
+	}
+|	"MINUTE_SECOND"
+	{
+		$$ = ast.TimeUnitMinuteSecond
+	}
+|	"HOUR_MICROSECOND"
+	{
+		$$ = ast.TimeUnitHourMicrosecond
+	}
+|	"HOUR_SECOND"
+	{
+		$$ = ast.TimeUnitHourSecond
+	}
+|	"HOUR_MINUTE"
+	{
+		$$ = ast.TimeUnitHourMinute
+	}
+|	"DAY_MICROSECOND"
+	{
+		$$ = ast.TimeUnitDayMicrosecond
+	}
+|	"DAY_SECOND"
+	{
+		$$ = ast.TimeUnitDaySecond
+	}
+|	"DAY_MINUTE"
+	{
+		$$ = ast.TimeUnitDayMinute
+	}
+|	"DAY_HOUR"
+	{
+		$$ = ast.TimeUnitDayHour
+	}
+|	"YEAR_MONTH"
+	{
+		$$ = ast.TimeUnitYearMonth
+	}
+
+TimestampUnit:
+	"MICROSECOND"
+	{
+		$$ = ast.TimeUnitMicrosecond
+	}
+|	"SECOND"
+	{
+		$$ = ast.TimeUnitSecond
+	}
+|	"MINUTE"
+	{
+		$$ = ast.TimeUnitMinute
+	}
+|	"HOUR"
+	{
+		$$ = ast.TimeUnitHour
+	}
+|	"DAY"
+	{
+		$$ = ast.TimeUnitDay
+	}
+|	"WEEK"
+	{
+		$$ = ast.TimeUnitWeek
+	}
+|	"MONTH"
+	{
+		$$ = ast.TimeUnitMonth
+	}
+|	"QUARTER"
+	{
+		$$ = ast.TimeUnitQuarter
+	}
+|	"YEAR"
+	{
+		$$ = ast.TimeUnitYear
+	}
 
 FunctionNameDateArith:
 	builtinDateAdd
@@ -3258,9 +4008,29 @@ FunctionNameDateArithMultiForms:
 |	builtinSubDate
 
 SumExpr:
-	"AVG" '(' Expression ')'
+	"AVG" '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
+	}
+|	builtinBitAnd '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncBitAnd, Args: []ast.ExprNode{$3}}
+	}
+|	builtinBitOr '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncBitOr, Args: []ast.ExprNode{$3}}
+	}
+|	builtinBitXor '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncBitXor, Args: []ast.ExprNode{$3}}
+	}
+|	builtinCount '(' DistinctKwd ExpressionList ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+	}
+|	builtinCount '(' "ALL" Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 	}
 |	builtinCount '(' Expression ')'
 	{
@@ -3271,17 +4041,43 @@ SumExpr:
 		args := []ast.ExprNode{ast.NewValueExpr(1)}
 		$$ = &ast.AggregateFuncExpr{F: $1, Args: args,}
 	}
-|	builtinMax '(' Expression ')'
+|	builtinGroupConcat '(' DefaultFalseDistinctOpt ExpressionList OrderByOptional OptGConcatSeparator ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		args := $4.([]ast.ExprNode)
+		args = append(args, $6.(ast.ExprNode))
+		agg := &ast.AggregateFuncExpr{F: ast.AggFuncGroupConcat, Args: args, Distinct: $3.(bool)}
+		if $5 != nil {
+			agg.Order = $5.(*ast.OrderByClause)
+		}
+		$$ = agg
 	}
-|	builtinMin '(' Expression ')'
+|	builtinMax '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
 	}
-|	builtinSum '(' Expression ')'
+|	builtinMin '(' DefaultFalseDistinctOpt Expression ')'
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
+	}
+|	builtinSum '(' DefaultFalseDistinctOpt Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}, Distinct: $3.(bool)}
+	}
+|	builtinStddevPop '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncStddevPop, Args: []ast.ExprNode{$3}}
+	}
+|	builtinStddevSamp '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncStddevSamp, Args: []ast.ExprNode{$3}}
+	}
+|	builtinVarPop '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncVarPop, Args: []ast.ExprNode{$3}}
+	}
+|	builtinVarSamp '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: ast.AggFuncVarSamp, Args: []ast.ExprNode{$3}}
 	}
 
 OptGConcatSeparator:
@@ -3693,11 +4489,12 @@ TableRef:
 	}
 
 TableFactor:
-	TableName TableAsNameOpt IndexHintListOpt
+	TableName PartitionNameListOpt TableAsNameOpt IndexHintListOpt
 	{
 		tn := $1.(*ast.TableName)
-		tn.IndexHints = $3.([]*ast.IndexHint)
-		$$ = &ast.TableSource{Source: tn, AsName: $2.(model.CIStr)}
+		tn.PartitionNames = $2.([]model.CIStr)
+		tn.IndexHints = $4.([]*ast.IndexHint)
+		$$ = &ast.TableSource{Source: tn, AsName: $3.(model.CIStr)}
 	}
 |	'(' SelectStmt ')' TableAsName
 	{
@@ -3864,6 +4661,10 @@ LimitOption:
 	{
 		$$ = ast.NewValueExpr($1)
 	}
+|	paramMarker
+	{
+		$$ = ast.NewParamMarkerExpr(yyS[yypt].offset)
+	}
 
 SelectStmtLimit:
 	{
@@ -3983,6 +4784,10 @@ TableOptimizerHintOpt:
 	{
 		$$ = &ast.TableOptimizerHint{HintName: model.NewCIStr($1), QBName: $3.(model.CIStr), Tables: $4.([]ast.HintTable)}
 	}
+|	"LEADING" '(' QueryBlockOpt HintTableList ')'
+	{
+		$$ = &ast.TableOptimizerHint{HintName: model.NewCIStr($1), QBName: $3.(model.CIStr), Tables: $4.([]ast.HintTable)}
+	}
 |	hintINLJ '(' QueryBlockOpt HintTableList ')'
 	{
 		$$ = &ast.TableOptimizerHint{HintName: model.NewCIStr($1), QBName: $3.(model.CIStr), Tables: $4.([]ast.HintTable)}
@@ -4245,6 +5050,57 @@ SetStmt:
 	{
 		$$ = &ast.SetStmt{Variables: $2.([]*ast.VariableAssignment)}
 	}
+|	"SET" "PASSWORD" eq PasswordOpt
+	{
+		$$ = &ast.SetPwdStmt{Password: $4}
+	}
+|	"SET" "PASSWORD" "FOR" Username eq PasswordOpt
+	{
+		$$ = &ast.SetPwdStmt{User: $4.(*auth.UserIdentity), Password: $6}
+	}
+|	"SET" "ROLE" SetRoleOpt
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/set-role.html
+		$$ = $3.(*ast.SetRoleStmt)
+	}
+|	"SET" "DEFAULT" "ROLE" SetDefaultRoleOpt "TO" UsernameList
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/set-default-role.html
+		tmp := $4.(*ast.SetRoleStmt)
+		$$ = &ast.SetDefaultRoleStmt{
+			SetRoleOpt: tmp.SetRoleOpt,
+			RoleList: tmp.RoleList,
+			UserList: $6.([]*auth.UserIdentity),
+		}
+	}
+
+SetDefaultRoleOpt:
+	"NONE"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleNone, RoleList: nil}
+	}
+|	"ALL"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAll, RoleList: nil}
+	}
+|	RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleRegular, RoleList: $1.([]*auth.RoleIdentity)}
+	}
+
+SetRoleOpt:
+	"ALL" "EXCEPT" RolenameList
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleAllExcept, RoleList: $3.([]*auth.RoleIdentity)}
+	}
+|	SetDefaultRoleOpt
+	{
+		$$ = $1
+	}
+|	"DEFAULT"
+	{
+		$$ = &ast.SetRoleStmt{SetRoleOpt: ast.SetRoleDefault, RoleList: nil}
+	}
 
 SetExpr:
 	"ON"
@@ -4385,6 +5241,67 @@ UserVariable:
 		$$ = &ast.VariableExpr{Name: v, IsGlobal: false, IsSystem: false}
 	}
 
+/****************************** Prepared Statement *******************************/
+PreparedStmt:
+	"PREPARE" Identifier "FROM" PrepareSQL
+	{
+		var sqlText string
+		var sqlVar *ast.VariableExpr
+		switch x := $4.(type) {
+		case string:
+			sqlText = x
+		case *ast.VariableExpr:
+			sqlVar = x
+		}
+		$$ = &ast.PrepareStmt{
+			Name:    $2,
+			SQLText: sqlText,
+			SQLVar:  sqlVar,
+		}
+	}
+
+PrepareSQL:
+	stringLit
+	{
+		$$ = $1
+	}
+|	UserVariable
+	{
+		$$ = $1
+	}
+
+ExecuteStmt:
+	"EXECUTE" Identifier
+	{
+		$$ = &ast.ExecuteStmt{Name: $2}
+	}
+|	"EXECUTE" Identifier "USING" UserVariableList
+	{
+		$$ = &ast.ExecuteStmt{
+			Name:      $2,
+			UsingVars: $4.([]ast.ExprNode),
+		}
+	}
+
+UserVariableList:
+	UserVariable
+	{
+		$$ = []ast.ExprNode{$1}
+	}
+|	UserVariableList ',' UserVariable
+	{
+		$$ = append($1.([]ast.ExprNode), $3)
+	}
+
+DeallocateStmt:
+	DeallocateSym "PREPARE" Identifier
+	{
+		$$ = &ast.DeallocateStmt{Name: $3}
+	}
+
+DeallocateSym:
+	"DEALLOCATE" | "DROP"
+
 /****************************Admin Statement*******************************/
 AdminStmt:
 	"ADMIN" "SHOW" "DDL"
@@ -4428,6 +5345,13 @@ ShowStmt:
 			Table:	$4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "VIEW" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowCreateView,
+			Table:	$4.(*ast.TableName),
+		}
+	}
 |	"SHOW" "CREATE" "DATABASE" IfNotExists DBName
 	{
 		$$ = &ast.ShowStmt{
@@ -4443,6 +5367,26 @@ ShowStmt:
 			Full:	$2.(bool),
 		}
 	}
+|	"SHOW" "GRANTS"
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/show-grants.html
+		$$ = &ast.ShowStmt{Tp: ast.ShowGrants}
+	}
+|	"SHOW" "GRANTS" "FOR" Username
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowGrants,
+			User:	$4.(*auth.UserIdentity),
+		}
+	}
+|	"SHOW" "GRANTS" "FOR" Username "USING" RolenameList
+	{
+		$$ = &ast.ShowStmt{
+			Tp:	ast.ShowGrants,
+			User:	$4.(*auth.UserIdentity),
+			Roles:	$6.([]*auth.RoleIdentity),
+		}
+	}
 
 ShowIndexKwd:
 	"INDEX"
@@ -4480,6 +5424,13 @@ ShowTargetFilterable:
 			GlobalScope: $1.(bool),
 		}
 	}
+|	GlobalScope "BINDINGS"
+	{
+		$$ = &ast.ShowStmt{
+			Tp: ast.ShowBindings,
+			GlobalScope: $1.(bool),
+		}
+	}
 
 ShowLikeOrWhereOpt:
 	{
@@ -4542,20 +5493,36 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterTableStmt
+|	AlterUserStmt
 |	AnalyzeTableStmt
 |	BeginTransactionStmt
 |	CommitStmt
+|	CreateBindingStmt
 |	DeleteFromStmt
+|	ExecuteStmt
 |	ExplainStmt
 |	CreateDatabaseStmt
 |	CreateIndexStmt
 |	CreateTableStmt
+|	CreateUserStmt
+|	CreateRoleStmt
+|	CreateViewStmt
+|	DeallocateStmt
+|	DropBindingStmt
 |	DropDatabaseStmt
 |	DropIndexStmt
 |	DropTableStmt
+|	DropUserStmt
+|	DropRoleStmt
+|	DropViewStmt
+|	GrantStmt
+|	GrantRoleStmt
 |	InsertIntoStmt
+|	PreparedStmt
 |	RollbackStmt
 |	ReplaceIntoStmt
+|	RevokeStmt
+|	RevokeRoleStmt
 |	SelectStmt
 |	SetStmt
 |	ShowStmt
@@ -4567,6 +5534,7 @@ ExplainableStmt:
 |	DeleteFromStmt
 |	InsertIntoStmt
 |	ReplaceIntoStmt
+|	ExecuteStmt
 
 StatementList:
 	Statement
@@ -5282,6 +6250,413 @@ StringList:
 		$$ = append($1.([]string), $3)
 	}
 
+Username:
+	StringName
+	{
+		$$ = &auth.UserIdentity{Username: $1.(string), Hostname: "%"}
+	}
+|	StringName singleAtIdentifier
+	{
+		$$ = &auth.UserIdentity{Username: $1.(string), Hostname: strings.TrimPrefix($2, "@")}
+	}
+|	"CURRENT_USER" OptionalBraces
+	{
+		$$ = &auth.UserIdentity{CurrentUser: true}
+	}
+
+UsernameList:
+	Username
+	{
+		$$ = []*auth.UserIdentity{$1.(*auth.UserIdentity)}
+	}
+|	UsernameList ',' Username
+	{
+		$$ = append($1.([]*auth.UserIdentity), $3.(*auth.UserIdentity))
+	}
+
+/* Role names which are keywords must be quoted, so that they don't conflict
+ * with the privilege types in GRANT and REVOKE statements. */
+Rolename:
+	identifier
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: "%"}
+	}
+|	stringLit
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: "%"}
+	}
+|	identifier singleAtIdentifier
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: strings.TrimPrefix($2, "@")}
+	}
+|	stringLit singleAtIdentifier
+	{
+		$$ = &auth.RoleIdentity{Username: $1, Hostname: strings.TrimPrefix($2, "@")}
+	}
+
+RolenameList:
+	Rolename
+	{
+		$$ = []*auth.RoleIdentity{$1.(*auth.RoleIdentity)}
+	}
+|	RolenameList ',' Rolename
+	{
+		$$ = append($1.([]*auth.RoleIdentity), $3.(*auth.RoleIdentity))
+	}
+
+PasswordOpt:
+	stringLit
+	{
+		$$ = $1
+	}
+|	"PASSWORD" '(' AuthString ')'
+	{
+		$$ = $3
+	}
+
+/*******************************************************************
+ *
+ *  Create User Statement
+ *
+ *	CREATE USER [IF NOT EXISTS]
+ *	    user [auth_option] [, user [auth_option]] ...
+ *	auth_option: {
+ *	    IDENTIFIED BY 'auth_string'
+ *	  | IDENTIFIED BY PASSWORD 'hash_string'
+ *	}
+ *******************************************************************/
+CreateUserStmt:
+	"CREATE" "USER" IfNotExists UserSpecList
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/create-user.html
+		$$ = &ast.CreateUserStmt{
+			IfNotExists: $3.(bool),
+			Specs: $4.([]*ast.UserSpec),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Create Role Statement
+ *
+ *	CREATE ROLE [IF NOT EXISTS] role [, role] ...
+ *******************************************************************/
+CreateRoleStmt:
+	"CREATE" "ROLE" IfNotExists RolenameList
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/create-role.html
+		roles := $4.([]*auth.RoleIdentity)
+		specs := make([]*ast.UserSpec, 0, len(roles))
+		for _, role := range roles {
+			specs = append(specs, &ast.UserSpec{
+				User: &auth.UserIdentity{Username: role.Username, Hostname: role.Hostname},
+			})
+		}
+		$$ = &ast.CreateUserStmt{
+			IsCreateRole: true,
+			IfNotExists: $3.(bool),
+			Specs: specs,
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Alter User Statement
+ *
+ *******************************************************************/
+AlterUserStmt:
+	"ALTER" "USER" IfExists UserSpecList
+	{
+		// See https://dev.mysql.com/doc/refman/5.7/en/alter-user.html
+		$$ = &ast.AlterUserStmt{
+			IfExists: $3.(bool),
+			Specs: $4.([]*ast.UserSpec),
+		}
+	}
+|	"ALTER" "USER" IfExists "USER" '(' ')' "IDENTIFIED" "BY" AuthString
+	{
+		auth := &ast.AuthOption{
+			AuthString: $9,
+			ByAuthString: true,
+		}
+		$$ = &ast.AlterUserStmt{
+			IfExists: $3.(bool),
+			CurrentAuth: auth,
+		}
+	}
+
+UserSpec:
+	Username AuthOption
+	{
+		userSpec := &ast.UserSpec{
+			User: $1.(*auth.UserIdentity),
+		}
+		if $2 != nil {
+			userSpec.AuthOpt = $2.(*ast.AuthOption)
+		}
+		$$ = userSpec
+	}
+
+UserSpecList:
+	UserSpec
+	{
+		$$ = []*ast.UserSpec{$1.(*ast.UserSpec)}
+	}
+|	UserSpecList ',' UserSpec
+	{
+		$$ = append($1.([]*ast.UserSpec), $3.(*ast.UserSpec))
+	}
+
+AuthOption:
+	{
+		$$ = nil
+	}
+|	"IDENTIFIED" "BY" AuthString
+	{
+		$$ = &ast.AuthOption {
+			AuthString: $3,
+			ByAuthString: true,
+		}
+	}
+|	"IDENTIFIED" "BY" "PASSWORD" HashString
+	{
+		$$ = &ast.AuthOption{
+			HashString: $4,
+		}
+	}
+
+HashString:
+	stringLit
+
+AuthString:
+	stringLit
+
+/*******************************************************************
+ *
+ *  Drop User Statement
+ *
+ *******************************************************************/
+DropUserStmt:
+	"DROP" "USER" IfExists UsernameList
+	{
+		$$ = &ast.DropUserStmt{IfExists: $3.(bool), UserList: $4.([]*auth.UserIdentity)}
+	}
+
+DropRoleStmt:
+	"DROP" "ROLE" IfExists RolenameList
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/drop-role.html
+		roles := $4.([]*auth.RoleIdentity)
+		users := make([]*auth.UserIdentity, 0, len(roles))
+		for _, role := range roles {
+			users = append(users, &auth.UserIdentity{Username: role.Username, Hostname: role.Hostname})
+		}
+		$$ = &ast.DropUserStmt{IfExists: $3.(bool), IsDropRole: true, UserList: users}
+	}
+
+/*************************************Grant Statement*************************************/
+GrantStmt:
+	 "GRANT" PrivElemList "ON" ObjectType PrivLevel "TO" UserSpecList WithGrantOptionOpt
+	 {
+		$$ = &ast.GrantStmt{
+			Privs: $2.([]*ast.PrivElem),
+			ObjectType: $4.(ast.ObjectTypeType),
+			Level: $5.(*ast.GrantLevel),
+			Users: $7.([]*ast.UserSpec),
+			WithGrant: $8.(bool),
+		}
+	 }
+
+GrantRoleStmt:
+	"GRANT" RolenameList "TO" UsernameList
+	{
+		$$ = &ast.GrantRoleStmt{
+			Roles: $2.([]*auth.RoleIdentity),
+			Users: $4.([]*auth.UserIdentity),
+		}
+	}
+
+WithGrantOptionOpt:
+	{
+		$$ = false
+	}
+|	"WITH" "GRANT" "OPTION"
+	{
+		$$ = true
+	}
+
+PrivElem:
+	PrivType
+	{
+		$$ = &ast.PrivElem{
+			Priv: $1.(mysql.PrivilegeType),
+		}
+	}
+
+PrivElemList:
+	PrivElem
+	{
+		$$ = []*ast.PrivElem{$1.(*ast.PrivElem)}
+	}
+|	PrivElemList ',' PrivElem
+	{
+		$$ = append($1.([]*ast.PrivElem), $3.(*ast.PrivElem))
+	}
+
+PrivType:
+	"ALL"
+	{
+		$$ = mysql.AllPriv
+	}
+|	"ALL" "PRIVILEGES"
+	{
+		$$ = mysql.AllPriv
+	}
+|	"ALTER"
+	{
+		$$ = mysql.AlterPriv
+	}
+|	"CREATE"
+	{
+		$$ = mysql.CreatePriv
+	}
+|	"CREATE" "USER"
+	{
+		$$ = mysql.CreateUserPriv
+	}
+|	"CREATE" "VIEW"
+	{
+		$$ = mysql.CreateViewPriv
+	}
+|	"SHOW" "VIEW"
+	{
+		$$ = mysql.ShowViewPriv
+	}
+|	"TRIGGER"
+	{
+		$$ = mysql.TriggerPriv
+	}
+|	"DELETE"
+	{
+		$$ = mysql.DeletePriv
+	}
+|	"DROP"
+	{
+		$$ = mysql.DropPriv
+	}
+|	"PROCESS"
+	{
+		$$ = mysql.ProcessPriv
+	}
+|	"EXECUTE"
+	{
+		$$ = mysql.ExecutePriv
+	}
+|	"INDEX"
+	{
+		$$ = mysql.IndexPriv
+	}
+|	"INSERT"
+	{
+		$$ = mysql.InsertPriv
+	}
+|	"SELECT"
+	{
+		$$ = mysql.SelectPriv
+	}
+|	"SUPER"
+	{
+		$$ = mysql.SuperPriv
+	}
+|	"SHOW" "DATABASES"
+	{
+		$$ = mysql.ShowDBPriv
+	}
+|	"UPDATE"
+	{
+		$$ = mysql.UpdatePriv
+	}
+|	"GRANT" "OPTION"
+	{
+		$$ = mysql.GrantPriv
+	}
+|	"REFERENCES"
+	{
+		$$ = mysql.ReferencesPriv
+	}
+|	"SHUTDOWN"
+	{
+		$$ = mysql.ShutdownPriv
+	}
+
+ObjectType:
+	{
+		$$ = ast.ObjectTypeNone
+	}
+|	"TABLE"
+	{
+		$$ = ast.ObjectTypeTable
+	}
+
+PrivLevel:
+	'*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelDB,
+		}
+	}
+|	'*' '.' '*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelGlobal,
+		}
+	}
+|	Identifier '.' '*'
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelDB,
+			DBName: $1,
+		}
+	}
+|	Identifier '.' Identifier
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelTable,
+			DBName: $1,
+			TableName: $3,
+		}
+	}
+|	Identifier
+	{
+		$$ = &ast.GrantLevel {
+			Level: ast.GrantLevelTable,
+			TableName: $1,
+		}
+	}
+
+/**************************************RevokeStmt*******************************************
+ * See https://dev.mysql.com/doc/refman/5.7/en/revoke.html
+ *******************************************************************************************/
+RevokeStmt:
+	"REVOKE" PrivElemList "ON" ObjectType PrivLevel "FROM" UserSpecList
+	{
+		$$ = &ast.RevokeStmt{
+			Privs: $2.([]*ast.PrivElem),
+			ObjectType: $4.(ast.ObjectTypeType),
+			Level: $5.(*ast.GrantLevel),
+			Users: $7.([]*ast.UserSpec),
+		}
+	}
+
+RevokeRoleStmt:
+	"REVOKE" RolenameList "FROM" UsernameList
+	{
+		$$ = &ast.RevokeRoleStmt{
+			Roles: $2.([]*auth.RoleIdentity),
+			Users: $4.([]*auth.UserIdentity),
+		}
+	}
+
 StringName:
 	stringLit
 	{
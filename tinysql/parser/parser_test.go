@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/types"
@@ -591,7 +592,32 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{"SELECT SUBSTRING('Quadratically' FROM 5);", true, "SELECT SUBSTRING('Quadratically', 5)"},
 		{"SELECT SUBSTRING('Quadratically' FROM 5 FOR 3);", true, "SELECT SUBSTRING('Quadratically', 5, 3)"},
 
+		{"SELECT TRIM('  bar   ');", true, "SELECT TRIM('  bar   ')"},
+		{"SELECT TRIM('x' FROM 'xxxbarxxx');", true, "SELECT TRIM('xxxbarxxx', 'x')"},
+		{"SELECT TRIM(BOTH FROM '  bar  ');", true, "SELECT TRIM('  bar  ', ' ', BOTH)"},
+		{"SELECT TRIM(LEADING 'x' FROM 'xxxbarxxx');", true, "SELECT TRIM('xxxbarxxx', 'x', LEADING)"},
+		{"SELECT TRIM(TRAILING 'xyz' FROM 'barxxyz');", true, "SELECT TRIM('barxxyz', 'xyz', TRAILING)"},
+		{"SELECT TRIM(LEADING FROM);", false, ""},
+
 		{"SELECT LEAST(), LEAST(1, 2, 3);", true, "SELECT LEAST(),LEAST(1, 2, 3)"},
+		{"SELECT GREATEST(1, 2, 3), COALESCE(NULL, 1), NULLIF(1, 2);", true, "SELECT GREATEST(1, 2, 3),COALESCE(NULL, 1),NULLIF(1, 2)"},
+		// control flow
+		{"SELECT CASE 1 WHEN 1 THEN 'a' WHEN 2 THEN 'b' END;", true, "SELECT CASE 1 WHEN 1 THEN 'a' WHEN 2 THEN 'b' END"},
+		{"SELECT CASE WHEN 1 > 0 THEN 'a' ELSE 'b' END;", true, "SELECT CASE WHEN 1>0 THEN 'a' ELSE 'b' END"},
+		{"SELECT CASE 1 ELSE 'b' END;", false, ""},
+		{"SELECT CASE WHEN 1 THEN 2;", false, ""},
+
+		// for cast and convert
+		{"SELECT CAST(1 AS SIGNED);", true, "SELECT CAST(1 AS SIGNED)"},
+		{"SELECT CAST(1 AS SIGNED INTEGER);", true, "SELECT CAST(1 AS SIGNED)"},
+		{"SELECT CAST(-1 AS UNSIGNED);", true, "SELECT CAST(-1 AS UNSIGNED)"},
+		{"SELECT CAST('abc' AS CHAR(2));", true, "SELECT CAST('abc' AS CHAR(2))"},
+		{"SELECT CAST('abc' AS BINARY);", true, "SELECT CAST('abc' AS BINARY)"},
+		{"SELECT CAST('1.5' AS DOUBLE);", true, "SELECT CAST('1.5' AS DOUBLE)"},
+		{"SELECT CONVERT('123', SIGNED);", true, "SELECT CONVERT('123', SIGNED)"},
+		{"SELECT CONVERT(1, CHAR);", true, "SELECT CONVERT(1, CHAR)"},
+		{"SELECT CAST(1 AS);", false, ""},
+		{"SELECT CONVERT(1);", false, ""},
 		// information functions
 		{"SELECT DATABASE();", true, "SELECT DATABASE()"},
 		{"SELECT SCHEMA();", true, "SELECT SCHEMA()"},
@@ -673,11 +699,30 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{"select current_time(null)", false, ""},
 		{"select curtime()", true, "SELECT CURTIME()"},
 		{"select curtime(6)", true, "SELECT CURTIME(6)"},
+
+		// for date arithmetic
+		{"select date_add('2011-11-11 10:10:10', interval 10 day)", true, "SELECT DATE_ADD('2011-11-11 10:10:10', INTERVAL 10 DAY)"},
+		{"select date_sub('2011-11-11 10:10:10', interval '1 1' day_hour)", true, "SELECT DATE_SUB('2011-11-11 10:10:10', INTERVAL '1 1' DAY_HOUR)"},
+		{"select adddate('2011-11-11', 10)", true, "SELECT ADDDATE('2011-11-11', INTERVAL 10 DAY)"},
+		{"select subdate('2011-11-11', interval 2 year_month)", true, "SELECT SUBDATE('2011-11-11', INTERVAL 2 YEAR_MONTH)"},
+		{"select '2011-11-11' + interval 1 second_microsecond", true, "SELECT DATE_ADD('2011-11-11', INTERVAL 1 SECOND_MICROSECOND)"},
+		{"select c - interval 3 week from t", true, "SELECT DATE_SUB(`c`, INTERVAL 3 WEEK) FROM `t`"},
+		{"select date_add('2011-11-11', 10)", false, ""},
+		{"select date_add('2011-11-11', interval 10 days)", false, ""},
 		{"select curtime(-1)", false, ""},
 		{"select curtime(1.0)", false, ""},
 		{"select curtime('1')", false, ""},
 		{"select curtime(null)", false, ""},
 
+		// for json functions
+		{"select json_extract(a, '$.b') from t", true, "SELECT JSON_EXTRACT(`a`, '$.b') FROM `t`"},
+		{"select a->'$.b' from t", true, "SELECT JSON_EXTRACT(`a`, '$.b') FROM `t`"},
+		{"select t.a->>'$.b' from t", true, "SELECT JSON_UNQUOTE(JSON_EXTRACT(`t`.`a`, '$.b')) FROM `t`"},
+		{"select json_object('a', 1, 'b', json_array(1, 2))", true, "SELECT JSON_OBJECT('a', 1, 'b', JSON_ARRAY(1, 2))"},
+		{"select json_set(a, '$.b', 1), json_remove(a, '$.c') from t", true, "SELECT JSON_SET(`a`, '$.b', 1), JSON_REMOVE(`a`, '$.c') FROM `t`"},
+		{"select a->b from t", false, ""},
+		{"select 1->'$.b'", false, ""},
+
 		// select utc_timestamp
 		{"select utc_timestamp", true, "SELECT UTC_TIMESTAMP()"},
 		{"select utc_timestamp()", true, "SELECT UTC_TIMESTAMP()"},
@@ -864,6 +909,16 @@ func (s *testParserSuite) TestBuiltin(c *C) {
 		{`select count(c1) from t;`, true, "SELECT COUNT(`c1`) FROM `t`"},
 		{`select count(*) from t;`, true, "SELECT COUNT(1) FROM `t`"},
 		{`select count(c1, c2) from t;`, false, ""},
+		{`select count(distinct c1, c2) from t;`, true, "SELECT COUNT(DISTINCT `c1`, `c2`) FROM `t`"},
+		{`select count(all c1) from t;`, true, "SELECT COUNT(`c1`) FROM `t`"},
+		{`select sum(distinct c1), avg(distinctrow c2), max(distinct c1) from t;`, true, ""},
+		{`select group_concat(c1) from t;`, true, ""},
+		{`select group_concat(distinct c1, c2 order by c1 desc, 2 separator ';') from t;`, true, ""},
+		{`select group_concat(c1 separator) from t;`, false, ""},
+		{`select bit_and(c1), bit_or(c1), bit_xor(c1) from t;`, true, ""},
+		{`select bit_and(distinct c1) from t;`, false, ""},
+		{`select std(c1), stddev(c1), stddev_pop(c1), stddev_samp(c1) from t;`, true, ""},
+		{`select variance(c1), var_pop(c1), var_samp(c1) from t;`, true, ""},
 	}
 	s.RunTest(c, table)
 }
@@ -1209,6 +1264,17 @@ func (s *testParserSuite) TestDDL(c *C) {
 		{"ALTER TABLE t ADD (a1 int, CONSTRAINT PRIMARY KEY (a1))", true, "ALTER TABLE `t` ADD COLUMN (`a1` INT, PRIMARY KEY(`a1`))"},
 		{"ALTER TABLE t ADD (a1 int, a2 int, PRIMARY KEY (a1), UNIQUE (a2))", true, "ALTER TABLE `t` ADD COLUMN (`a1` INT, `a2` INT, PRIMARY KEY(`a1`), UNIQUE(`a2`))"},
 		{"ALTER TABLE t ADD (a1 int, a2 int, PRIMARY KEY (a1), CONSTRAINT b2 UNIQUE (a2))", true, "ALTER TABLE `t` ADD COLUMN (`a1` INT, `a2` INT, PRIMARY KEY(`a1`), UNIQUE `b2`(`a2`))"},
+		// for foreign key
+		{"create table t (a int, foreign key (a) references p(id))", true, "CREATE TABLE `t` (`a` INT,FOREIGN KEY(`a`) REFERENCES `p`(`id`))"},
+		{"create table t (a int, b int, constraint fk foreign key fk_idx (a, b) references test.p(id1, id2) on delete cascade)", true, "CREATE TABLE `t` (`a` INT,`b` INT,CONSTRAINT `fk` FOREIGN KEY `fk_idx`(`a`, `b`) REFERENCES `test`.`p`(`id1`, `id2`) ON DELETE CASCADE)"},
+		{"create table t (a int, foreign key (a) references p(id) on delete set null on update restrict)", true, "CREATE TABLE `t` (`a` INT,FOREIGN KEY(`a`) REFERENCES `p`(`id`) ON DELETE SET NULL ON UPDATE RESTRICT)"},
+		{"create table t (a int, foreign key (a) references p(id) on update cascade on delete no action)", true, "CREATE TABLE `t` (`a` INT,FOREIGN KEY(`a`) REFERENCES `p`(`id`) ON DELETE NO ACTION ON UPDATE CASCADE)"},
+		{"create table t (a int, foreign key (a) references p(id) on delete set default)", true, "CREATE TABLE `t` (`a` INT,FOREIGN KEY(`a`) REFERENCES `p`(`id`) ON DELETE SET DEFAULT)"},
+		{"create table t (a int, foreign key (a) references p)", false, ""},
+		{"create table t (a int, foreign key (a) references p(id) on delete)", false, ""},
+		{"alter table t add constraint fk foreign key (a) references p(id) on delete restrict", true, "ALTER TABLE `t` ADD CONSTRAINT `fk` FOREIGN KEY(`a`) REFERENCES `p`(`id`) ON DELETE RESTRICT"},
+		{"alter table t drop foreign key fk", true, "ALTER TABLE `t` DROP FOREIGN KEY `fk`"},
+
 		// for issue 537
 		{"CREATE TABLE IF NOT EXISTS table_ident (a SQL_TSI_YEAR(4), b SQL_TSI_YEAR);", true, "CREATE TABLE IF NOT EXISTS `table_ident` (`a` YEAR(4),`b` YEAR)"},
 		{`CREATE TABLE IF NOT EXISTS table_ident (ident1 BOOL COMMENT "text_string" unique, ident2 SQL_TSI_YEAR(4) ZEROFILL);`, true, "CREATE TABLE IF NOT EXISTS `table_ident` (`ident1` TINYINT(1) COMMENT 'text_string' UNIQUE KEY,`ident2` YEAR(4))"},
@@ -1331,6 +1397,272 @@ func (s *testParserSuite) TestSQLNoCache(c *C) {
 	}
 }
 
+func (s *testParserSuite) TestOptimizerHints(c *C) {
+	parser := parser.New()
+	stmt, _, err := parser.Parse("select /*+ MERGE_JOIN(t1), hash_join(t1, t2) LEADING(t2, t1) */ * from t1, t2", "", "")
+	c.Assert(err, IsNil)
+	hints := stmt[0].(*ast.SelectStmt).TableHints
+	c.Assert(hints, HasLen, 3)
+	c.Assert(hints[0].HintName.L, Equals, "merge_join")
+	c.Assert(hints[0].Tables, HasLen, 1)
+	c.Assert(hints[1].HintName.L, Equals, "hash_join")
+	c.Assert(hints[1].Tables, HasLen, 2)
+	c.Assert(hints[2].HintName.L, Equals, "leading")
+	c.Assert(hints[2].Tables, HasLen, 2)
+	c.Assert(hints[2].Tables[0].TableName.L, Equals, "t2")
+	c.Assert(hints[2].Tables[1].TableName.L, Equals, "t1")
+
+	stmt, _, err = parser.Parse("select /*+ USE_INDEX(t, idx1, idx2) IGNORE_INDEX(t, idx3) HASH_AGG() STREAM_AGG() AGG_TO_COP() READ_FROM_STORAGE(TIKV[t]) */ count(*) from t", "", "")
+	c.Assert(err, IsNil)
+	hints = stmt[0].(*ast.SelectStmt).TableHints
+	c.Assert(hints, HasLen, 6)
+	c.Assert(hints[0].HintName.L, Equals, "use_index")
+	c.Assert(hints[0].Indexes, HasLen, 2)
+	c.Assert(hints[1].HintName.L, Equals, "ignore_index")
+	c.Assert(hints[2].HintName.L, Equals, "hash_agg")
+	c.Assert(hints[3].HintName.L, Equals, "stream_agg")
+	c.Assert(hints[4].HintName.L, Equals, "agg_to_cop")
+	c.Assert(hints[5].HintName.L, Equals, "read_from_storage")
+	c.Assert(hints[5].StoreType.L, Equals, "tikv")
+
+	// The hint names are not reserved.
+	table := []testCase{
+		{"create table merge_join (leading_col int, sm_join int)", true, ""},
+		{"select merge_join, hash_agg from t", true, ""},
+	}
+	s.RunTest(c, table)
+}
+
+func (s *testParserSuite) TestPrivilege(c *C) {
+	table := []testCase{
+		// for create user
+		{`CREATE USER 'ttt' REQUIRE X509;`, false, ""},
+		{`CREATE USER 'test'`, true, ""},
+		{`CREATE USER test@localhost`, true, ""},
+		{`CREATE USER 'test'@'localhost' IDENTIFIED BY 'new-password'`, true, ""},
+		{`CREATE USER 'test'@'localhost' IDENTIFIED BY PASSWORD 'hashstring'`, true, ""},
+		{`CREATE USER IF NOT EXISTS 'root'@'localhost' IDENTIFIED BY 'new-password', 'root'@'127.0.0.1' IDENTIFIED BY PASSWORD 'hashstring'`, true, ""},
+		{`ALTER USER IF EXISTS 'root'@'localhost' IDENTIFIED BY 'new-password'`, true, ""},
+		{`ALTER USER 'root'@'localhost' IDENTIFIED BY 'new-password', 'root'@'127.0.0.1' IDENTIFIED BY PASSWORD 'hashstring'`, true, ""},
+		{`ALTER USER USER() IDENTIFIED BY 'new-password'`, true, ""},
+		{`DROP USER 'root'@'localhost', 'root1'@'localhost'`, true, ""},
+		{`DROP USER IF EXISTS 'root'@'localhost'`, true, ""},
+
+		// for set password
+		{`SET PASSWORD = 'password'`, true, ""},
+		{`SET PASSWORD = PASSWORD('password')`, true, ""},
+		{`SET PASSWORD FOR 'root'@'localhost' = 'password'`, true, ""},
+		{`SET PASSWORD FOR 'root'@'localhost' = PASSWORD('password')`, true, ""},
+		{`SET password = 'abc'`, true, ""},
+
+		// for grant statement
+		{"GRANT ALL ON db1.* TO 'jeffrey'@'localhost';", true, ""},
+		{"GRANT ALL ON db1.* TO 'jeffrey'@'localhost' WITH GRANT OPTION;", true, ""},
+		{"GRANT SELECT ON db2.invoice TO 'jeffrey'@'localhost';", true, ""},
+		{"GRANT ALL ON *.* TO 'someuser'@'somehost';", true, ""},
+		{"GRANT SELECT, INSERT ON *.* TO 'someuser'@'somehost';", true, ""},
+		{"GRANT CREATE VIEW, SHOW VIEW ON db1.* TO 'someuser'@'somehost';", true, ""},
+		{"GRANT ALL ON mydb.* TO 'someuser'@'somehost';", true, ""},
+		{"GRANT SELECT, INSERT, CREATE USER, SHOW DATABASES ON mydb.* TO 'someuser'@'somehost';", true, ""},
+		{"GRANT ALL ON mydb.mytbl TO 'someuser'@'somehost';", true, ""},
+		{"GRANT SELECT, INSERT ON TABLE mydb.mytbl TO 'someuser'@'somehost';", true, ""},
+		{"GRANT ALL PRIVILEGES ON * TO 'someuser'@'somehost', 'user2' IDENTIFIED BY 'pwd';", true, ""},
+		{"GRANT SELECT (col1) ON mydb.mytbl TO 'someuser'@'somehost';", false, ""},
+
+		// for revoke statement
+		{"REVOKE ALL ON db1.* FROM 'jeffrey'@'localhost';", true, ""},
+		{"REVOKE SELECT ON db2.invoice FROM 'jeffrey'@'localhost';", true, ""},
+		{"REVOKE ALL ON *.* FROM 'someuser'@'somehost';", true, ""},
+		{"REVOKE SELECT, INSERT, GRANT OPTION ON mydb.mytbl FROM 'someuser'@'somehost', 'user2';", true, ""},
+
+		// for show grants
+		{"SHOW GRANTS", true, ""},
+		{"SHOW GRANTS FOR 'test'@'localhost'", true, ""},
+		{"SHOW GRANTS FOR current_user()", true, ""},
+		{"SHOW GRANTS FOR current_user", true, ""},
+		{"SHOW GRANTS FOR 'u1'@'localhost' USING 'r1', r2@'%'", true, ""},
+
+		// for roles
+		{"CREATE ROLE 'admin', 'developer'", true, ""},
+		{"CREATE ROLE IF NOT EXISTS 'admin'@'localhost', app_read", true, ""},
+		{"DROP ROLE 'admin', app_read@'%'", true, ""},
+		{"DROP ROLE IF EXISTS 'admin'", true, ""},
+		{"GRANT 'r1', r2@'localhost' TO 'u1'@'localhost', u2", true, ""},
+		{"REVOKE 'r1' FROM 'u1'@'localhost'", true, ""},
+		{"SET ROLE DEFAULT", true, ""},
+		{"SET ROLE NONE", true, ""},
+		{"SET ROLE ALL", true, ""},
+		{"SET ROLE ALL EXCEPT 'r1', r2", true, ""},
+		{"SET ROLE 'r1', r2@'%'", true, ""},
+		{"SET DEFAULT ROLE NONE TO 'u1'@'localhost'", true, ""},
+		{"SET DEFAULT ROLE ALL TO 'u1', u2", true, ""},
+		{"SET DEFAULT ROLE 'r1', r2 TO 'u1'@'localhost'", true, ""},
+		{"SET DEFAULT ROLE DEFAULT TO 'u1'", false, ""},
+	}
+	s.RunTest(c, table)
+
+	roleStmt, err := parser.New().ParseOneStmt("SET ROLE ALL EXCEPT r1, 'r2'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	setRole := roleStmt.(*ast.SetRoleStmt)
+	c.Assert(setRole.SetRoleOpt, Equals, ast.SetRoleAllExcept)
+	c.Assert(setRole.RoleList, HasLen, 2)
+	c.Assert(setRole.RoleList[1].String(), Equals, "`r2`@`localhost`")
+
+	parser := parser.New()
+	stmt, err := parser.ParseOneStmt("CREATE USER 'test'@'localhost' IDENTIFIED BY 'pwd', test2", "", "")
+	c.Assert(err, IsNil)
+	createStmt := stmt.(*ast.CreateUserStmt)
+	c.Assert(createStmt.Specs, HasLen, 2)
+	c.Assert(createStmt.Specs[0].User.String(), Equals, "test@localhost")
+	pwd, ok := createStmt.Specs[0].EncodedPassword()
+	c.Assert(ok, IsTrue)
+	c.Assert(pwd, Equals, "*975B2CD4FF9AE554FE8AD33168FBFC326D2021DD")
+	c.Assert(createStmt.Specs[1].User.String(), Equals, "test2@%")
+	c.Assert(createStmt.Specs[1].AuthOpt, IsNil)
+
+	stmt, err = parser.ParseOneStmt("GRANT SELECT, INSERT ON test.* TO u1@'%' WITH GRANT OPTION", "", "")
+	c.Assert(err, IsNil)
+	grantStmt := stmt.(*ast.GrantStmt)
+	c.Assert(grantStmt.Privs, HasLen, 2)
+	c.Assert(grantStmt.Privs[0].Priv, Equals, mysql.SelectPriv)
+	c.Assert(grantStmt.Privs[1].Priv, Equals, mysql.InsertPriv)
+	c.Assert(grantStmt.Level.Level, Equals, ast.GrantLevelDB)
+	c.Assert(grantStmt.Level.DBName, Equals, "test")
+	c.Assert(grantStmt.Users[0].User.String(), Equals, "u1@%")
+	c.Assert(grantStmt.WithGrant, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("SHOW GRANTS FOR 'test'@'localhost'", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.ShowStmt).User.String(), Equals, "test@localhost")
+}
+
+func (s *testParserSuite) TestBinding(c *C) {
+	table := []testCase{
+		{"create global binding for select * from t using select * from t use index(idx)", true, ""},
+		{"create session binding for select * from t where a = 1 using select /*+ HASH_AGG() */ * from t where a = 1", true, ""},
+		{"create binding for select * from t using select * from t", true, ""},
+		{"create global binding for update t set a = 1 using update t set a = 1", false, ""},
+		{"drop global binding for select * from t", true, ""},
+		{"drop session binding for select * from t", true, ""},
+		{"drop binding for select * from t", true, ""},
+		{"show global bindings", true, ""},
+		{"show session bindings", true, ""},
+		{"show bindings where original_sql = 'select * from t'", true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	sql := "create global binding for select * from t where a = 1 using select * from t use index(idx) where a = 1"
+	stmt, err := parser.ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	bindStmt := stmt.(*ast.CreateBindingStmt)
+	c.Assert(bindStmt.GlobalScope, IsTrue)
+	c.Assert(bindStmt.OriginSel.Text(), Equals, "select * from t where a = 1")
+	c.Assert(bindStmt.HintedSel.Text(), Equals, "select * from t use index(idx) where a = 1")
+
+	stmt, err = parser.ParseOneStmt("drop session binding for select * from t where a = 1", "", "")
+	c.Assert(err, IsNil)
+	dropStmt := stmt.(*ast.DropBindingStmt)
+	c.Assert(dropStmt.GlobalScope, IsFalse)
+	c.Assert(dropStmt.OriginSel.Text(), Equals, "select * from t where a = 1")
+}
+
+func (s *testParserSuite) TestView(c *C) {
+	table := []testCase{
+		{"create view v as select * from t", true, ""},
+		{"create or replace view v as select * from t", true, ""},
+		{"create algorithm = merge definer = 'root'@'localhost' sql security invoker view test.v (a, b) as select c, d from t", true, ""},
+		{"create algorithm = temptable view v as select * from t with local check option", true, ""},
+		{"create view v as select * from t with cascaded check option", true, ""},
+		{"create view v as select * from t with check", false, ""},
+		{"create view v (a,) as select * from t", false, ""},
+		{"create view v as update t set a = 1", false, ""},
+		{"drop view v", true, ""},
+		{"drop view if exists v1, test.v2 cascade", true, ""},
+		{"show create view v", true, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	sql := "create or replace algorithm = merge definer = 'root'@'localhost' sql security invoker view v (a, b) as select c, d from t where c > 1 with local check option"
+	stmt, err := parser.ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	v, ok := stmt.(*ast.CreateViewStmt)
+	c.Assert(ok, IsTrue)
+	c.Assert(v.OrReplace, IsTrue)
+	c.Assert(v.Algorithm, Equals, model.AlgorithmMerge)
+	c.Assert(v.Definer.Username, Equals, "root")
+	c.Assert(v.Definer.Hostname, Equals, "localhost")
+	c.Assert(v.Security, Equals, model.SecurityInvoker)
+	c.Assert(v.Cols, DeepEquals, []model.CIStr{model.NewCIStr("a"), model.NewCIStr("b")})
+	c.Assert(v.Select.Text(), Equals, "select c, d from t where c > 1")
+	c.Assert(v.CheckOption, Equals, model.CheckOptionLocal)
+
+	stmt, err = parser.ParseOneStmt("create view v as select c from t", "", "")
+	c.Assert(err, IsNil)
+	v = stmt.(*ast.CreateViewStmt)
+	c.Assert(v.Definer.CurrentUser, IsTrue)
+	c.Assert(v.Security, Equals, model.SecurityDefiner)
+	c.Assert(v.CheckOption, Equals, model.CheckOptionNone)
+	c.Assert(v.Select.Text(), Equals, "select c from t")
+
+	stmt, err = parser.ParseOneStmt("drop view v", "", "")
+	c.Assert(err, IsNil)
+	c.Assert(stmt.(*ast.DropTableStmt).IsView, IsTrue)
+}
+
+func (s *testParserSuite) TestPartition(c *C) {
+	table := []testCase{
+		{"CREATE TABLE t (a int) PARTITION BY RANGE (a) (PARTITION p0 VALUES LESS THAN (10), PARTITION p1 VALUES LESS THAN MAXVALUE)", true, ""},
+		{"CREATE TABLE t (a int) PARTITION BY RANGE (a) (PARTITION p0 VALUES LESS THAN (10) COMMENT = 'x', PARTITION p1 VALUES LESS THAN (MAXVALUE))", true, ""},
+		{"CREATE TABLE t (a datetime) PARTITION BY RANGE (to_days(a)) (PARTITION p0 VALUES LESS THAN (to_days('2020-01-01')))", true, ""},
+		{"CREATE TABLE t (a int) PARTITION BY HASH (a) PARTITIONS 4", true, ""},
+		{"CREATE TABLE t (a int) PARTITION BY LIST (a) (PARTITION p0 VALUES IN (1, 3, NULL), PARTITION p1 VALUES IN (2))", true, ""},
+		{"CREATE TABLE t (a int) PARTITION BY RANGE (a)", true, ""},
+		{"CREATE TABLE t (a int) PARTITION BY KEY (a)", false, ""},
+		{"CREATE TABLE t (a int) PARTITION BY RANGE (a) (PARTITION VALUES LESS THAN (10))", false, ""},
+		{"ALTER TABLE t ADD PARTITION (PARTITION p2 VALUES LESS THAN (20))", true, ""},
+		{"ALTER TABLE t DROP PARTITION p0, p1", true, ""},
+		{"ALTER TABLE t DROP PARTITION IF EXISTS p0", true, ""},
+		{"ALTER TABLE t TRUNCATE PARTITION p0", true, ""},
+		{"ALTER TABLE t DROP PARTITION", false, ""},
+		{"SELECT * FROM t PARTITION (p0, p1) AS t1 WHERE a = 1", true, ""},
+		{"SELECT * FROM t PARTITION ()", false, ""},
+	}
+	s.RunTest(c, table)
+
+	parser := parser.New()
+	sql := "create table t (a datetime) partition by range ( to_days(a) ) (partition p0 values less than (10) comment 'old', partition p1 values less than maxvalue)"
+	stmt, err := parser.ParseOneStmt(sql, "", "")
+	c.Assert(err, IsNil)
+	opt := stmt.(*ast.CreateTableStmt).Partition
+	c.Assert(opt, NotNil)
+	c.Assert(opt.Tp, Equals, model.PartitionTypeRange)
+	c.Assert(opt.Expr.Text(), Equals, "to_days(a)")
+	c.Assert(opt.Definitions, HasLen, 2)
+	c.Assert(opt.Definitions[0].Name.O, Equals, "p0")
+	c.Assert(opt.Definitions[0].Comment, Equals, "old")
+	_, ok := opt.Definitions[1].LessThan[0].(*ast.MaxValueExpr)
+	c.Assert(ok, IsTrue)
+
+	stmt, err = parser.ParseOneStmt("create table t (a int) partition by hash (a) partitions 3", "", "")
+	c.Assert(err, IsNil)
+	opt = stmt.(*ast.CreateTableStmt).Partition
+	c.Assert(opt.Tp, Equals, model.PartitionTypeHash)
+	c.Assert(opt.Num, Equals, uint64(3))
+
+	stmt, err = parser.ParseOneStmt("alter table t drop partition p0, p1", "", "")
+	c.Assert(err, IsNil)
+	spec := stmt.(*ast.AlterTableStmt).Specs[0]
+	c.Assert(spec.Tp, Equals, ast.AlterTableDropPartition)
+	c.Assert(spec.PartitionNames, DeepEquals, []model.CIStr{model.NewCIStr("p0"), model.NewCIStr("p1")})
+
+	stmt, err = parser.ParseOneStmt("select * from t partition (p1)", "", "")
+	c.Assert(err, IsNil)
+	tn := stmt.(*ast.SelectStmt).From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName)
+	c.Assert(tn.PartitionNames, DeepEquals, []model.CIStr{model.NewCIStr("p1")})
+}
+
 func (s *testParserSuite) TestEscape(c *C) {
 	table := []testCase{
 		{`select """;`, false, ""},
@@ -1365,8 +1697,50 @@ func (s *testParserSuite) TestExplain(c *C) {
 		{"EXPLAIN SELECT 1", true, "EXPLAIN FORMAT = 'row' SELECT 1"},
 		{"EXPLAIN FORMAT = JSON SELECT 1", true, "EXPLAIN FORMAT = 'json' SELECT 1"},
 		{"EXPLAIN FORMAT = 'hint' SELECT 1", true, "EXPLAIN FORMAT = 'hint' SELECT 1"},
+		{"EXPLAIN ANALYZE SELECT 1", true, "EXPLAIN ANALYZE SELECT 1"},
+		{"EXPLAIN ANALYZE select c1 from t1", true, "EXPLAIN ANALYZE SELECT `c1` FROM `t1`"},
+		{"EXPLAIN ANALYZE FORMAT = 'dot' SELECT 1", false, ""},
+	}
+	s.RunTest(c, table)
+}
+
+func (s *testParserSuite) TestPrepare(c *C) {
+	table := []testCase{
+		{"PREPARE pname FROM 'SELECT ?'", true, ""},
+		{"PREPARE pname FROM @test", true, ""},
+		{"PREPARE `` FROM @test", true, ""},
+		{"PREPARE pname FROM ?", false, ""},
+		{"EXECUTE stmt", true, ""},
+		{"EXECUTE stmt USING @a, @b", true, ""},
+		{"EXECUTE stmt USING 1", false, ""},
+		{"DEALLOCATE PREPARE stmt", true, ""},
+		{"DROP PREPARE stmt", true, ""},
+		{"SELECT * FROM t WHERE a > ? AND b = ? LIMIT ?, ?", true, ""},
+		{"INSERT INTO t VALUES (?, ?)", true, ""},
+		{"SELECT ? + 1", true, ""},
 	}
 	s.RunTest(c, table)
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("PREPARE pname FROM 'SELECT ? FROM t WHERE a = ?'", "", "")
+	c.Assert(err, IsNil)
+	prepare := stmt.(*ast.PrepareStmt)
+	c.Assert(prepare.Name, Equals, "pname")
+	c.Assert(prepare.SQLText, Equals, "SELECT ? FROM t WHERE a = ?")
+	c.Assert(prepare.SQLVar, IsNil)
+
+	stmt, err = p.ParseOneStmt("EXECUTE pname USING @a, @b", "", "")
+	c.Assert(err, IsNil)
+	execute := stmt.(*ast.ExecuteStmt)
+	c.Assert(execute.Name, Equals, "pname")
+	c.Assert(execute.UsingVars, HasLen, 2)
+	c.Assert(execute.UsingVars[1].(*ast.VariableExpr).Name, Equals, "b")
+
+	stmt, err = p.ParseOneStmt("SELECT a FROM t WHERE a = ? AND b > ?", "", "")
+	c.Assert(err, IsNil)
+	where := stmt.(*ast.SelectStmt).Where.(*ast.BinaryOperationExpr)
+	marker := where.R.(*ast.BinaryOperationExpr).R.(*driver.ParamMarkerExpr)
+	c.Assert(marker.Offset, Equals, 36)
 }
 
 func (s *testParserSuite) TestSQLModeANSIQuotes(c *C) {
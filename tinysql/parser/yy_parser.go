@@ -106,6 +106,7 @@ type stmtTexter interface {
 // New returns a Parser object.
 func New() *Parser {
 	if ast.NewValueExpr == nil ||
+		ast.NewParamMarkerExpr == nil ||
 		ast.NewHexLiteral == nil ||
 		ast.NewBitLiteral == nil {
 		panic("no parser driver (forgotten import?) https://github.com/pingcap/tidb/parser/issues/43")
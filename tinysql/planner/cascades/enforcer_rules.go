@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb/planner/implementation"
 	"github.com/pingcap/tidb/planner/memo"
 	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/planner/util"
 )
 
 // Enforcer defines the interface for enforcer rules.
@@ -62,10 +63,10 @@ func (e *OrderEnforcer) NewProperty(prop *property.PhysicalProperty) (newProp *p
 func (e *OrderEnforcer) OnEnforce(reqProp *property.PhysicalProperty, child memo.Implementation) (impl memo.Implementation) {
 	childPlan := child.GetPlan()
 	sort := plannercore.PhysicalSort{
-		ByItems: make([]*plannercore.ByItems, 0, len(reqProp.Items)),
+		ByItems: make([]*util.ByItems, 0, len(reqProp.Items)),
 	}.Init(childPlan.SCtx(), childPlan.Stats(), &property.PhysicalProperty{ExpectedCnt: math.MaxFloat64})
 	for _, item := range reqProp.Items {
-		item := &plannercore.ByItems{
+		item := &util.ByItems{
 			Expr: item.Col,
 			Desc: item.Desc,
 		}
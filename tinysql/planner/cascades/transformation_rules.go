@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/tidb/expression/aggregation"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/planner/memo"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/util/ranger"
 )
 
@@ -802,9 +803,9 @@ func (r *PushTopNDownProjection) OnTransform(old *memo.ExprIter) (newExprs []*me
 		Count:  topN.Count,
 	}.Init(topN.SCtx())
 
-	newTopN.ByItems = make([]*plannercore.ByItems, 0, len(topN.ByItems))
+	newTopN.ByItems = make([]*util.ByItems, 0, len(topN.ByItems))
 	for _, by := range topN.ByItems {
-		newTopN.ByItems = append(newTopN.ByItems, &plannercore.ByItems{
+		newTopN.ByItems = append(newTopN.ByItems, &util.ByItems{
 			Expr: expression.ColumnSubstitute(by.Expr, old.Children[0].Group.Prop.Schema, proj.Exprs),
 			Desc: by.Desc,
 		})
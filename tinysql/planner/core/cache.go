@@ -0,0 +1,119 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+	"github.com/pingcap/tidb/util/kvcache"
+)
+
+var (
+	// preparedPlanCacheEnabledValue stores the global config "prepared-plan-cache-enabled".
+	// The value is set to 1 when the plan cache is enabled, 0 otherwise.
+	preparedPlanCacheEnabledValue int32
+	// PreparedPlanCacheCapacity stores the global config "prepared-plan-cache-capacity".
+	PreparedPlanCacheCapacity uint = 100
+)
+
+const (
+	preparedPlanCacheEnabled = 1
+	preparedPlanCacheUnable  = 0
+)
+
+// SetPreparedPlanCache sets isEnabled to true, then prepared plan cache is enabled.
+func SetPreparedPlanCache(isEnabled bool) {
+	if isEnabled {
+		atomic.StoreInt32(&preparedPlanCacheEnabledValue, preparedPlanCacheEnabled)
+	} else {
+		atomic.StoreInt32(&preparedPlanCacheEnabledValue, preparedPlanCacheUnable)
+	}
+}
+
+// PreparedPlanCacheEnabled returns whether the prepared plan cache is enabled.
+func PreparedPlanCacheEnabled() bool {
+	isEnabled := atomic.LoadInt32(&preparedPlanCacheEnabledValue)
+	return isEnabled == preparedPlanCacheEnabled
+}
+
+type pstmtPlanCacheKey struct {
+	database       string
+	pstmtID        uint32
+	schemaVersion  int64
+	sqlMode        mysql.SQLMode
+	timezoneOffset int
+	paramTypes     []byte
+
+	hash []byte
+}
+
+// Hash implements Key interface.
+func (key *pstmtPlanCacheKey) Hash() []byte {
+	if len(key.hash) == 0 {
+		if key.hash == nil {
+			key.hash = make([]byte, 0, len(key.database)+len(key.paramTypes)+32)
+		}
+		key.hash = append(key.hash, hack.Slice(key.database)...)
+		key.hash = codec.EncodeInt(key.hash, int64(key.pstmtID))
+		key.hash = codec.EncodeInt(key.hash, key.schemaVersion)
+		key.hash = codec.EncodeInt(key.hash, int64(key.sqlMode))
+		key.hash = codec.EncodeInt(key.hash, int64(key.timezoneOffset))
+		key.hash = append(key.hash, key.paramTypes...)
+	}
+	return key.hash
+}
+
+// NewPSTMTPlanCacheKey creates a new pstmtPlanCacheKey object. The parameter
+// types are part of the key, so a plan built for an integer parameter is never
+// reused for a string one.
+func NewPSTMTPlanCacheKey(sessionVars *variable.SessionVars, pstmtID uint32, schemaVersion int64) kvcache.Key {
+	timezoneOffset := 0
+	if sessionVars.TimeZone != nil {
+		_, timezoneOffset = time.Now().In(sessionVars.TimeZone).Zone()
+	}
+	paramTypes := make([]byte, 0, 2*len(sessionVars.PreparedParams))
+	for _, param := range sessionVars.PreparedParams {
+		tp := types.NewFieldType(mysql.TypeUnspecified)
+		types.DefaultParamTypeForValue(param.GetValue(), tp)
+		paramTypes = append(paramTypes, tp.Tp, byte(tp.Flag&mysql.UnsignedFlag))
+	}
+	return &pstmtPlanCacheKey{
+		database:       sessionVars.CurrentDB,
+		pstmtID:        pstmtID,
+		schemaVersion:  schemaVersion,
+		sqlMode:        sessionVars.SQLMode,
+		timezoneOffset: timezoneOffset,
+		paramTypes:     paramTypes,
+	}
+}
+
+// PSTMTPlanCacheValue stores the cached Statement and StmtNode.
+type PSTMTPlanCacheValue struct {
+	Plan        Plan
+	OutPutNames []*types.FieldName
+}
+
+// NewPSTMTPlanCacheValue creates a PSTMTPlanCacheValue.
+func NewPSTMTPlanCacheValue(plan Plan, names []*types.FieldName) *PSTMTPlanCacheValue {
+	return &PSTMTPlanCacheValue{
+		Plan:        plan,
+		OutPutNames: names,
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser"
+)
+
+var _ = Suite(&testCacheableSuite{})
+
+type testCacheableSuite struct {
+}
+
+func (s *testCacheableSuite) TestCacheable(c *C) {
+	tests := []struct {
+		sql       string
+		cacheable bool
+	}{
+		{"select * from t where a = ?", true},
+		{"select * from t where a > ? and b in (?, ?)", true},
+		{"insert into t values (?, ?)", true},
+		{"delete from t where a = ?", true},
+		{"select * from t where a = @a", false},
+		{"select * from t where a < now()", false},
+		{"select * from t where a > rand()", false},
+		{"select * from t order by ?", false},
+		{"select a from t group by ?", false},
+		{"select * from t limit ?", false},
+		{"select * from t limit 1, ?", false},
+		{"select * from t limit 1", true},
+		{"select * from t where a cutl (?)", false},
+		{"set @a = 1", false},
+		{"show tables", false},
+	}
+	p := parser.New()
+	for _, tt := range tests {
+		stmt, err := p.ParseOneStmt(tt.sql, "", "")
+		c.Assert(err, IsNil, Commentf("sql: %s", tt.sql))
+		c.Assert(Cacheable(stmt), Equals, tt.cacheable, Commentf("sql: %s", tt.sql))
+	}
+}
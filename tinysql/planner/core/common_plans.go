@@ -15,17 +15,26 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/ranger"
 )
 
 // ShowDDL is for showing DDL information.
@@ -33,6 +42,234 @@ type ShowDDL struct {
 	baseSchemaProducer
 }
 
+// Prepare represents prepare plan.
+type Prepare struct {
+	baseSchemaProducer
+
+	Name    string
+	SQLText string
+}
+
+// Execute represents prepare plan.
+type Execute struct {
+	baseSchemaProducer
+
+	Name          string
+	UsingVars     []expression.Expression
+	PrepareParams []types.Datum
+	ExecID        uint32
+	Stmt          ast.StmtNode
+	Plan          Plan
+}
+
+// OptimizePreparedPlan optimizes the prepared statement.
+func (e *Execute) OptimizePreparedPlan(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema) error {
+	vars := sctx.GetSessionVars()
+	if e.Name != "" {
+		e.ExecID = vars.PreparedStmtNameToID[e.Name]
+	}
+	prepared, ok := vars.PreparedStmts[e.ExecID]
+	if !ok {
+		return errors.Trace(ErrStmtNotFound)
+	}
+
+	// Because the array of `PreparedParams` is reused, it should be cleared first.
+	vars.PreparedParams = vars.PreparedParams[:0]
+	if e.PrepareParams != nil {
+		// The parameters are sent by the binary protocol.
+		if len(prepared.Params) != len(e.PrepareParams) {
+			return errors.Trace(ErrWrongParamCount)
+		}
+		vars.PreparedParams = append(vars.PreparedParams, e.PrepareParams...)
+	} else {
+		if len(prepared.Params) != len(e.UsingVars) {
+			return errors.Trace(ErrWrongParamCount)
+		}
+		for _, usingVar := range e.UsingVars {
+			val, err := usingVar.Eval(chunk.Row{})
+			if err != nil {
+				return err
+			}
+			vars.PreparedParams = append(vars.PreparedParams, val)
+		}
+	}
+	for i, val := range vars.PreparedParams {
+		param := prepared.Params[i].(*driver.ParamMarkerExpr)
+		param.Datum = val
+		param.InExecute = true
+	}
+
+	if prepared.SchemaVersion != is.SchemaMetaVersion() {
+		// If the schema version has changed we need to preprocess it again,
+		// if this time it failed, the real reason for the error is schema changed.
+		err := Preprocess(sctx, prepared.Stmt, is, InPrepare)
+		if err != nil {
+			return ErrSchemaChanged.GenWithStack("Schema change caused error: %s", err.Error())
+		}
+		prepared.SchemaVersion = is.SchemaMetaVersion()
+	}
+	p, names, err := e.getPhysicalPlan(ctx, sctx, is, prepared)
+	if err != nil {
+		return err
+	}
+	e.Stmt = prepared.Stmt
+	e.Plan = p
+	e.SetSchema(p.Schema())
+	e.SetOutputNames(names)
+	return nil
+}
+
+func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, prepared *ast.Prepared) (Plan, []*types.FieldName, error) {
+	var cacheKey kvcache.Key
+	sessVars := sctx.GetSessionVars()
+	sessVars.StmtCtx.UseCache = prepared.UseCache && sctx.PreparedPlanCache() != nil
+	if sessVars.StmtCtx.UseCache {
+		// The plan of a statement in a dirty transaction contains a UnionScan
+		// to read the buffered modifications, such a plan can not be shared.
+		txn, err := sctx.Txn(false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if txn.Valid() && !txn.IsReadOnly() {
+			sessVars.StmtCtx.UseCache = false
+		}
+	}
+	if sessVars.StmtCtx.UseCache {
+		cacheKey = NewPSTMTPlanCacheKey(sessVars, e.ExecID, prepared.SchemaVersion)
+		if cacheValue, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
+			cachedVal := cacheValue.(*PSTMTPlanCacheValue)
+			if err := checkPreparedPriv(sctx, prepared); err != nil {
+				return nil, nil, err
+			}
+			if err := e.rebuildRange(cachedVal.Plan); err != nil {
+				return nil, nil, err
+			}
+			sessVars.FoundInPlanCache = true
+			return cachedVal.Plan, cachedVal.OutPutNames, nil
+		}
+	}
+	p, names, err := OptimizeAstNode(ctx, sctx, prepared.Stmt, is)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The ranges of the inner side of an index join are built at runtime from
+	// the outer rows, and can not be rebuilt from the parameters.
+	if sessVars.StmtCtx.UseCache && !containsIndexJoin(p) {
+		sctx.PreparedPlanCache().Put(cacheKey, NewPSTMTPlanCacheValue(p, names))
+	}
+	return p, names, nil
+}
+
+// checkPreparedPriv checks the privileges of the prepared statement, since a
+// cached plan is used without building the statement again.
+func checkPreparedPriv(sctx sessionctx.Context, prepared *ast.Prepared) error {
+	pm := privilege.GetPrivilegeManager(sctx)
+	vs, ok := prepared.VisitInfos.([]visitInfo)
+	if pm == nil || !ok {
+		return nil
+	}
+	return CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, vs)
+}
+
+func containsIndexJoin(p Plan) bool {
+	switch x := p.(type) {
+	case *PhysicalIndexJoin:
+		return true
+	case *Insert:
+		if x.SelectPlan != nil {
+			return containsIndexJoin(x.SelectPlan)
+		}
+	case *Delete:
+		if x.SelectPlan != nil {
+			return containsIndexJoin(x.SelectPlan)
+		}
+	case PhysicalPlan:
+		for _, child := range x.Children() {
+			if containsIndexJoin(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rebuildRange rebuilds the ranges of the readers in a cached plan, the
+// access conditions stay the same but the parameters they refer to may
+// have changed.
+func (e *Execute) rebuildRange(p Plan) error {
+	sctx := p.SCtx()
+	sc := sctx.GetSessionVars().StmtCtx
+	var err error
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		ts := x.TablePlans[0].(*PhysicalTableScan)
+		ts.Ranges, err = e.buildTableRange(ts, sc)
+		if err != nil {
+			return err
+		}
+	case *PhysicalIndexReader:
+		is := x.IndexPlans[0].(*PhysicalIndexScan)
+		is.Ranges, err = e.buildIndexRange(sctx, is)
+		if err != nil {
+			return err
+		}
+	case *PhysicalIndexLookUpReader:
+		is := x.IndexPlans[0].(*PhysicalIndexScan)
+		is.Ranges, err = e.buildIndexRange(sctx, is)
+		if err != nil {
+			return err
+		}
+	case *Insert:
+		if x.SelectPlan != nil {
+			return e.rebuildRange(x.SelectPlan)
+		}
+	case *Delete:
+		if x.SelectPlan != nil {
+			return e.rebuildRange(x.SelectPlan)
+		}
+	case PhysicalPlan:
+		for _, child := range x.Children() {
+			err = e.rebuildRange(child)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Execute) buildTableRange(ts *PhysicalTableScan, sc *stmtctx.StatementContext) ([]*ranger.Range, error) {
+	var pkColInfo *model.ColumnInfo
+	if ts.Table.PKIsHandle {
+		pkColInfo = ts.Table.GetPkColInfo()
+	}
+	if pkColInfo == nil {
+		return ranger.FullIntRange(false), nil
+	}
+	if len(ts.AccessCondition) == 0 {
+		return ranger.FullIntRange(mysql.HasUnsignedFlag(pkColInfo.Flag)), nil
+	}
+	return ranger.BuildTableRange(ts.AccessCondition, sc, &pkColInfo.FieldType)
+}
+
+func (e *Execute) buildIndexRange(sctx sessionctx.Context, is *PhysicalIndexScan) ([]*ranger.Range, error) {
+	if len(is.AccessCondition) == 0 {
+		return ranger.FullRange(), nil
+	}
+	res, err := ranger.DetachCondAndBuildRangeForIndex(sctx, is.AccessCondition, is.IdxCols, is.IdxColLens)
+	if err != nil {
+		return nil, err
+	}
+	return res.Ranges, nil
+}
+
+// Deallocate represents deallocate plan.
+type Deallocate struct {
+	baseSchemaProducer
+
+	Name string
+}
+
 // Set represents a plan for set stmt.
 type Set struct {
 	baseSchemaProducer
@@ -47,6 +284,30 @@ type Simple struct {
 	Statement ast.StmtNode
 }
 
+// SQLBindOpType repreents the SQL bind type
+type SQLBindOpType int
+
+const (
+	// OpSQLBindCreate represents the operation to create a SQL bind.
+	OpSQLBindCreate SQLBindOpType = iota
+	// OpSQLBindDrop represents the operation to drop a SQL bind.
+	OpSQLBindDrop
+)
+
+// SQLBindPlan represents a plan for SQL bind.
+type SQLBindPlan struct {
+	baseSchemaProducer
+
+	SQLBindOp    SQLBindOpType
+	NormdOrigSQL string
+	BindSQL      string
+	IsGlobal     bool
+	BindStmt     ast.StmtNode
+	Db           string
+	Charset      string
+	Collation    string
+}
+
 // Insert represents an insert plan.
 type Insert struct {
 	baseSchemaProducer
@@ -66,6 +327,37 @@ type Insert struct {
 	SelectPlan PhysicalPlan
 
 	AllAssignmentsAreConstant bool
+
+	// ViewChecks are the conditions of WITH CHECK OPTION the rows inserted
+	// through a view must satisfy.
+	ViewChecks []*ViewCheck
+
+	// CheckConstraints are the enforced CHECK constraints of the table.
+	CheckConstraints []*CheckConstraint
+
+	GenCols InsertGeneratedColumns
+}
+
+// InsertGeneratedColumns is for completing generated columns in Insert.
+// We resolve generation expressions in plan, and eval those in executor.
+type InsertGeneratedColumns struct {
+	Columns []*ast.ColumnName
+	Exprs   []expression.Expression
+}
+
+// ViewCheck is a condition of WITH CHECK OPTION, it is evaluated on the rows
+// of the base table of the view.
+type ViewCheck struct {
+	DBName   model.CIStr
+	ViewName model.CIStr
+	Expr     expression.Expression
+}
+
+// CheckConstraint is a CHECK constraint of the table, it is evaluated on the
+// rows written to the table.
+type CheckConstraint struct {
+	Name model.CIStr
+	Expr expression.Expression
 }
 
 // Delete represents a delete plan.
@@ -120,6 +412,7 @@ type Explain struct {
 
 	TargetPlan Plan
 	Format     string
+	Analyze    bool
 	ExecStmt   ast.StmtNode
 
 	Rows           [][]string
@@ -132,8 +425,10 @@ func (e *Explain) prepareSchema() error {
 	format := strings.ToLower(e.Format)
 
 	switch {
-	case format == ast.ExplainFormatROW:
+	case format == ast.ExplainFormatROW && !e.Analyze:
 		fieldNames = []string{"id", "count", "task", "operator info"}
+	case format == ast.ExplainFormatROW && e.Analyze:
+		fieldNames = []string{"id", "count", "task", "operator info", "execution info", "memory"}
 	case format == ast.ExplainFormatDOT:
 		fieldNames = []string{"dot contents"}
 	default:
@@ -281,6 +576,7 @@ func PrettyIdentifier(id, indent string, isLastChild bool) string {
 
 // prepareOperatorInfo generates the following information for every plan:
 // operator id, task type, operator info, and the estemated row count.
+// For EXPLAIN ANALYZE, the actual execution info and memory usage are appended.
 func (e *Explain) prepareOperatorInfo(p Plan, taskType string, indent string, isLastChild bool) {
 	operatorInfo := p.ExplainInfo()
 	count := "N/A"
@@ -289,9 +585,58 @@ func (e *Explain) prepareOperatorInfo(p Plan, taskType string, indent string, is
 	}
 	explainID := p.ExplainID().String()
 	row := []string{PrettyIdentifier(explainID, indent, isLastChild), count, taskType, operatorInfo}
+	if e.Analyze {
+		row = append(row, e.runtimeInfo(p, explainID, taskType), e.memoryInfo(explainID))
+	}
 	e.Rows = append(e.Rows, row)
 }
 
+// runtimeInfo returns the actual execution info of the plan collected while
+// running the statement for EXPLAIN ANALYZE.
+func (e *Explain) runtimeInfo(p Plan, explainID, taskType string) string {
+	runtimeStatsColl := e.ctx.GetSessionVars().StmtCtx.RuntimeStatsColl
+	if runtimeStatsColl == nil {
+		return "N/A"
+	}
+	if taskType == "cop" {
+		if !runtimeStatsColl.ExistsCopStats(explainID) {
+			return "time:0s, loops:0, rows:0"
+		}
+		return runtimeStatsColl.GetCopStats(explainID).String()
+	}
+	if !runtimeStatsColl.ExistsRootStats(explainID) {
+		return "time:0s, loops:0, rows:0"
+	}
+	info := runtimeStatsColl.GetRootStats(explainID).String()
+	readerIDs := []string{explainID}
+	switch p.(type) {
+	case *PhysicalTableReader, *PhysicalIndexReader:
+	case *PhysicalIndexLookUpReader:
+		// The table side of an IndexLookUp is read by a separate reader.
+		readerIDs = append(readerIDs, explainID+"_tableReader")
+	default:
+		return info
+	}
+	for _, id := range readerIDs {
+		if runtimeStatsColl.ExistsReaderStats(id) {
+			info += ", " + runtimeStatsColl.GetReaderStats(id).String()
+		}
+	}
+	return info
+}
+
+// memoryInfo returns the max memory consumed by the executor of the plan.
+func (e *Explain) memoryInfo(explainID string) string {
+	memTracker := e.ctx.GetSessionVars().StmtCtx.MemTracker
+	if memTracker == nil {
+		return "N/A"
+	}
+	if tracker := memTracker.SearchTracker(explainID); tracker != nil {
+		return tracker.BytesToString(tracker.MaxConsumed())
+	}
+	return "N/A"
+}
+
 func (e *Explain) prepareDotInfo(p PhysicalPlan) {
 	buffer := bytes.NewBufferString("")
 	fmt.Fprintf(buffer, "\ndigraph %s {\n", p.ExplainID())
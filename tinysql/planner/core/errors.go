@@ -56,6 +56,10 @@ var (
 	ErrViewNoExplain                   = terror.ClassOptimizer.New(mysql.ErrViewNoExplain, mysql.MySQLErrName[mysql.ErrViewNoExplain])
 	ErrWrongValueCountOnRow            = terror.ClassOptimizer.New(mysql.ErrWrongValueCountOnRow, mysql.MySQLErrName[mysql.ErrWrongValueCountOnRow])
 	ErrViewInvalid                     = terror.ClassOptimizer.New(mysql.ErrViewInvalid, mysql.MySQLErrName[mysql.ErrViewInvalid])
+	ErrViewRecursive                   = terror.ClassOptimizer.New(mysql.ErrViewRecursive, mysql.MySQLErrName[mysql.ErrViewRecursive])
+	ErrViewNonupdCheck                 = terror.ClassOptimizer.New(mysql.ErrViewNonupdCheck, mysql.MySQLErrName[mysql.ErrViewNonupdCheck])
+	ErrNonInsertableTable              = terror.ClassOptimizer.New(mysql.ErrNonInsertableTable, mysql.MySQLErrName[mysql.ErrNonInsertableTable])
+	ErrNonupdateableColumn             = terror.ClassOptimizer.New(mysql.ErrNonupdateableColumn, mysql.MySQLErrName[mysql.ErrNonupdateableColumn])
 	ErrNoSuchThread                    = terror.ClassOptimizer.New(mysql.ErrNoSuchThread, mysql.MySQLErrName[mysql.ErrNoSuchThread])
 	ErrUnknownColumn                   = terror.ClassOptimizer.New(mysql.ErrBadField, mysql.MySQLErrName[mysql.ErrBadField])
 	ErrCartesianProductUnsupported     = terror.ClassOptimizer.New(mysql.ErrCartesianProductUnsupported, mysql.MySQLErrName[mysql.ErrCartesianProductUnsupported])
@@ -68,6 +72,10 @@ var (
 func init() {
 	mysqlErrCodeMap := map[terror.ErrCode]uint16{
 		mysql.ErrViewInvalid:                         mysql.ErrViewInvalid,
+		mysql.ErrViewRecursive:                       mysql.ErrViewRecursive,
+		mysql.ErrViewNonupdCheck:                     mysql.ErrViewNonupdCheck,
+		mysql.ErrNonInsertableTable:                  mysql.ErrNonInsertableTable,
+		mysql.ErrNonupdateableColumn:                 mysql.ErrNonupdateableColumn,
 		mysql.ErrUnknown:                             mysql.ErrUnknown,
 		mysql.ErrTablenameNotAllowedHere:             mysql.ErrTablenameNotAllowedHere,
 		mysql.ErrUnsupportedType:                     mysql.ErrUnsupportedType,
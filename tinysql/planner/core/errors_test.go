@@ -64,6 +64,11 @@ func (s testErrorSuite) TestError(c *C) {
 		ErrCartesianProductUnsupported,
 		ErrStmtNotFound,
 		ErrAmbiguous,
+		ErrViewInvalid,
+		ErrViewRecursive,
+		ErrViewNonupdCheck,
+		ErrNonInsertableTable,
+		ErrNonupdateableColumn,
 	}
 	for _, err := range kvErrs {
 		code := err.ToSQLError().Code
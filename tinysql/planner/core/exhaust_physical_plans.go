@@ -14,11 +14,20 @@
 package core
 
 import (
+	"bytes"
+	"fmt"
 	"math"
 
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/set"
+	"go.uber.org/zap"
 )
 
 func (p *LogicalUnionScan) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
@@ -274,6 +283,558 @@ func (p *LogicalJoin) getHashJoin(prop *property.PhysicalProperty, innerIdx int)
 	return hashJoin
 }
 
+// When inner plan is TableReader, the parameter `ranges` will be nil. Because pk only have one column. So all of its range
+// is generated during execution time.
+func (p *LogicalJoin) constructIndexJoin(prop *property.PhysicalProperty, outerIdx int, innerTask task,
+	ranges []*ranger.Range, keyOff2IdxOff []int, lens []int) []PhysicalPlan {
+	var (
+		innerJoinKeys []*expression.Column
+		outerJoinKeys []*expression.Column
+	)
+	if outerIdx == 0 {
+		outerJoinKeys = p.LeftJoinKeys
+		innerJoinKeys = p.RightJoinKeys
+	} else {
+		innerJoinKeys = p.LeftJoinKeys
+		outerJoinKeys = p.RightJoinKeys
+	}
+	all, _ := prop.AllSameOrder()
+	// If the order by columns are not all from outer child, index join cannot promise the order.
+	if !prop.AllColsFromSchema(p.children[outerIdx].Schema()) || !all {
+		return nil
+	}
+	chReqProps := make([]*property.PhysicalProperty, 2)
+	chReqProps[outerIdx] = &property.PhysicalProperty{TaskTp: property.RootTaskType, ExpectedCnt: math.MaxFloat64, Items: prop.Items}
+	if prop.ExpectedCnt < p.stats.RowCount {
+		expCnt := p.children[outerIdx].statsInfo().RowCount * prop.ExpectedCnt / p.stats.RowCount
+		chReqProps[outerIdx].ExpectedCnt = expCnt
+	}
+	newInnerKeys := make([]*expression.Column, 0, len(innerJoinKeys))
+	newOuterKeys := make([]*expression.Column, 0, len(outerJoinKeys))
+	newKeyOff := make([]int, 0, len(keyOff2IdxOff))
+	newOtherConds := make([]expression.Expression, len(p.OtherConditions), len(p.OtherConditions)+len(p.EqualConditions))
+	copy(newOtherConds, p.OtherConditions)
+	for keyOff, idxOff := range keyOff2IdxOff {
+		if keyOff2IdxOff[keyOff] < 0 {
+			newOtherConds = append(newOtherConds, p.EqualConditions[keyOff])
+			continue
+		}
+		newInnerKeys = append(newInnerKeys, innerJoinKeys[keyOff])
+		newOuterKeys = append(newOuterKeys, outerJoinKeys[keyOff])
+		newKeyOff = append(newKeyOff, idxOff)
+	}
+	baseJoin := basePhysicalJoin{
+		InnerChildIdx:   1 - outerIdx,
+		LeftConditions:  p.LeftConditions,
+		RightConditions: p.RightConditions,
+		OtherConditions: newOtherConds,
+		JoinType:        p.JoinType,
+		OuterJoinKeys:   newOuterKeys,
+		InnerJoinKeys:   newInnerKeys,
+		DefaultValues:   p.DefaultValues,
+	}
+	join := PhysicalIndexJoin{
+		basePhysicalJoin: baseJoin,
+		innerTask:        innerTask,
+		KeyOff2IdxOff:    newKeyOff,
+		Ranges:           ranges,
+		IdxColLens:       lens,
+	}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), chReqProps...)
+	join.SetSchema(p.schema)
+	return []PhysicalPlan{join}
+}
+
+// getIndexJoinByOuterIdx will generate index join by outerIndex. OuterIdx points out the outer child.
+// First of all, we'll check whether the inner child is DataSource.
+// Then, we will extract the join keys of p's equal conditions. Then check whether all of them are just the primary key
+// or match some part of on index. If so we will choose the best one and construct a index join.
+func (p *LogicalJoin) getIndexJoinByOuterIdx(prop *property.PhysicalProperty, outerIdx int) []PhysicalPlan {
+	innerChild := p.children[1-outerIdx]
+	var (
+		innerJoinKeys []*expression.Column
+		outerJoinKeys []*expression.Column
+	)
+	if outerIdx == 0 {
+		outerJoinKeys = p.LeftJoinKeys
+		innerJoinKeys = p.RightJoinKeys
+	} else {
+		innerJoinKeys = p.LeftJoinKeys
+		outerJoinKeys = p.RightJoinKeys
+	}
+	ds, isDataSource := innerChild.(*DataSource)
+	us, isUnionScan := innerChild.(*LogicalUnionScan)
+	if !isDataSource && !isUnionScan {
+		return nil
+	}
+	if isUnionScan {
+		ds, isDataSource = us.Children()[0].(*DataSource)
+		if !isDataSource {
+			return nil
+		}
+	}
+	var tblPath *util.AccessPath
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath {
+			tblPath = path
+			break
+		}
+	}
+	if pkCol := ds.getPKIsHandleCol(); pkCol != nil && tblPath != nil {
+		keyOff2IdxOff := make([]int, len(innerJoinKeys))
+		var pkOuterKey *expression.Column
+		for i, key := range innerJoinKeys {
+			if !key.Equal(nil, pkCol) {
+				keyOff2IdxOff[i] = -1
+				continue
+			}
+			if pkOuterKey == nil {
+				pkOuterKey = outerJoinKeys[i]
+			}
+			keyOff2IdxOff[i] = 0
+		}
+		if pkOuterKey != nil {
+			innerTask := p.constructInnerTableScanTask(ds, pkCol, pkOuterKey, us)
+			// Since the primary key means one value corresponding to exact one row, this will always be a no worse one
+			// comparing to other index.
+			return p.constructIndexJoin(prop, outerIdx, innerTask, nil, keyOff2IdxOff, nil)
+		}
+	}
+	helper := &indexJoinBuildHelper{join: p}
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsTablePath {
+			continue
+		}
+		emptyRange, err := helper.analyzeLookUpFilters(path, ds, innerJoinKeys)
+		if emptyRange {
+			return nil
+		}
+		if err != nil {
+			logutil.BgLogger().Warn("build index join failed", zap.Error(err))
+		}
+	}
+	if helper.chosenPath != nil {
+		keyOff2IdxOff := make([]int, len(innerJoinKeys))
+		for i := range keyOff2IdxOff {
+			keyOff2IdxOff[i] = -1
+		}
+		for idxOff, keyOff := range helper.idxOff2KeyOff {
+			if keyOff != -1 {
+				keyOff2IdxOff[keyOff] = idxOff
+			}
+		}
+		rangeInfo := helper.buildRangeDecidedByInformation(helper.chosenPath.IdxCols, outerJoinKeys)
+		innerTask := p.constructInnerIndexScanTask(ds, helper.chosenPath, helper.chosenRemained, us, rangeInfo)
+		return p.constructIndexJoin(prop, outerIdx, innerTask, helper.chosenRanges, keyOff2IdxOff, helper.chosenPath.IdxColLens)
+	}
+	return nil
+}
+
+type indexJoinBuildHelper struct {
+	join *LogicalJoin
+
+	chosenPath     *util.AccessPath
+	maxUsedCols    int
+	chosenAccess   []expression.Expression
+	chosenRemained []expression.Expression
+	idxOff2KeyOff  []int
+	chosenRanges   []*ranger.Range
+
+	curPossibleUsedKeys []*expression.Column
+	curNotUsedIndexCols []*expression.Column
+	curNotUsedColLens   []int
+	curIdxOff2KeyOff    []int
+}
+
+func (ijHelper *indexJoinBuildHelper) buildRangeDecidedByInformation(idxCols []*expression.Column, outerJoinKeys []*expression.Column) string {
+	buffer := bytes.NewBufferString("[")
+	isFirst := true
+	for idxOff, keyOff := range ijHelper.idxOff2KeyOff {
+		if keyOff == -1 {
+			continue
+		}
+		if !isFirst {
+			buffer.WriteString(" ")
+		} else {
+			isFirst = false
+		}
+		buffer.WriteString(fmt.Sprintf("eq(%v, %v)", idxCols[idxOff], outerJoinKeys[keyOff]))
+	}
+	for _, access := range ijHelper.chosenAccess {
+		if !isFirst {
+			buffer.WriteString(" ")
+		} else {
+			isFirst = false
+		}
+		buffer.WriteString(fmt.Sprintf("%v", access))
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
+
+// constructInnerTableScanTask is specially used to construct the inner plan for PhysicalIndexJoin.
+func (p *LogicalJoin) constructInnerTableScanTask(ds *DataSource, pk *expression.Column, outerKey *expression.Column, us *LogicalUnionScan) task {
+	ranges := ranger.FullIntRange(mysql.HasUnsignedFlag(pk.RetType.Flag))
+	ts := PhysicalTableScan{
+		Table:           ds.tableInfo,
+		Columns:         ds.Columns,
+		TableAsName:     ds.TableAsName,
+		DBName:          ds.DBName,
+		isPartition:     ds.isPartition,
+		physicalTableID: ds.physicalTableID,
+		filterCondition: ds.pushedDownConds,
+		Ranges:          ranges,
+		rangeDecidedBy:  []*expression.Column{outerKey},
+	}.Init(ds.ctx)
+	ts.SetSchema(ds.schema.Clone())
+	// The pk is unique, so a lookup returns one row at most.
+	ts.stats = ds.tableStats.ScaleByExpectCnt(1)
+	rowSize := ds.TblColHists.GetTableAvgRowSize(ds.TblCols)
+	sessVars := ds.ctx.GetSessionVars()
+	copTask := &copTask{
+		tablePlan:         ts,
+		indexPlanFinished: true,
+		cst:               sessVars.ScanFactor * rowSize * ts.stats.RowCount,
+		tblColHists:       ds.TblColHists,
+	}
+	selStats := ts.stats.Scale(selectionFactor)
+	ts.addPushedDownSelection(copTask, selStats)
+	t := finishCopTask(ds.ctx, copTask).(*rootTask)
+	reader := t.p
+	t.p = p.constructInnerUnionScan(us, reader)
+	return t
+}
+
+func (p *LogicalJoin) constructInnerUnionScan(us *LogicalUnionScan, reader PhysicalPlan) PhysicalPlan {
+	if us == nil {
+		return reader
+	}
+	// Use `reader.stats` instead of `us.stats` because it should be more accurate. No need to specify
+	// childrenReqProps now since we have got reader already.
+	physicalUnionScan := PhysicalUnionScan{
+		Conditions: us.conditions,
+		HandleCol:  us.handleCol,
+	}.Init(us.ctx, reader.statsInfo(), nil)
+	physicalUnionScan.SetChildren(reader)
+	return physicalUnionScan
+}
+
+// constructInnerIndexScanTask is specially used to construct the inner plan for PhysicalIndexJoin.
+func (p *LogicalJoin) constructInnerIndexScanTask(ds *DataSource, path *util.AccessPath, filterConds []expression.Expression,
+	us *LogicalUnionScan, rangeInfo string) task {
+	is := PhysicalIndexScan{
+		Table:            ds.tableInfo,
+		TableAsName:      ds.TableAsName,
+		DBName:           ds.DBName,
+		isPartition:      ds.isPartition,
+		physicalTableID:  ds.physicalTableID,
+		Columns:          ds.Columns,
+		Index:            path.Index,
+		IdxCols:          path.IdxCols,
+		IdxColLens:       path.IdxColLens,
+		dataSourceSchema: ds.schema,
+		Ranges:           ranger.FullRange(),
+		rangeInfo:        rangeInfo,
+	}.Init(ds.ctx)
+
+	var rowCount float64
+	idxHist, ok := ds.statisticTable.Indices[path.Index.ID]
+	if ok && !ds.statisticTable.Pseudo {
+		rowCount = idxHist.AvgCountPerNotNullValue(ds.statisticTable.Count)
+	} else {
+		rowCount = ds.statisticTable.PseudoAvgCountPerValue()
+	}
+	is.stats = ds.tableStats.ScaleByExpectCnt(rowCount)
+
+	cop := &copTask{
+		indexPlan:   is,
+		tblColHists: ds.TblColHists,
+		tblCols:     ds.TblCols,
+	}
+	if !isCoveringIndex(ds.schema.Columns, path.FullIdxCols, path.FullIdxColLens, ds.tableInfo.PKIsHandle) {
+		// On this way, it's double read case.
+		ts := PhysicalTableScan{
+			Columns:         ds.Columns,
+			Table:           is.Table,
+			TableAsName:     ds.TableAsName,
+			isPartition:     ds.isPartition,
+			physicalTableID: ds.physicalTableID,
+		}.Init(ds.ctx)
+		ts.SetSchema(ds.schema.Clone())
+		cop.tablePlan = ts
+	}
+	is.initSchema(path.Index, path.FullIdxCols, cop.tablePlan != nil)
+	rowSize := is.indexScanRowSize(path.Index, ds, true)
+	sessVars := ds.ctx.GetSessionVars()
+	cop.cst = rowCount * rowSize * sessVars.ScanFactor
+	indexConds, tblConds := splitIndexFilterConditions(filterConds, path.FullIdxCols, path.FullIdxColLens, ds.tableInfo)
+	tmpPath := &util.AccessPath{
+		IndexFilters:     indexConds,
+		TableFilters:     tblConds,
+		CountAfterAccess: rowCount,
+	}
+	// Assume equal conditions used by index join and other conditions are independent.
+	if len(indexConds) > 0 {
+		selectivity, err := ds.tableStats.HistColl.Selectivity(ds.ctx, indexConds, nil)
+		if err != nil {
+			logutil.BgLogger().Debug("calculate selectivity failed, use selection factor", zap.Error(err))
+			selectivity = selectionFactor
+		}
+		tmpPath.CountAfterIndex = rowCount * selectivity
+	}
+	finalStats := ds.stats.ScaleByExpectCnt(rowCount)
+	if ds.tableStats.RowCount > 0 {
+		selectivity := ds.stats.RowCount / ds.tableStats.RowCount
+		finalStats = ds.stats.ScaleByExpectCnt(selectivity * rowCount)
+	}
+	is.addPushedDownSelection(cop, ds, tmpPath, finalStats)
+	t := finishCopTask(ds.ctx, cop).(*rootTask)
+	reader := t.p
+	t.p = p.constructInnerUnionScan(us, reader)
+	return t
+}
+
+// resetContextForIndex resets the context of the helper for the given index, it records which index columns are
+// matched by the inner join keys.
+func (ijHelper *indexJoinBuildHelper) resetContextForIndex(innerKeys []*expression.Column, idxCols []*expression.Column, colLens []int) {
+	tmpSchema := expression.NewSchema(innerKeys...)
+	ijHelper.curIdxOff2KeyOff = make([]int, len(idxCols))
+	ijHelper.curNotUsedIndexCols = make([]*expression.Column, 0, len(idxCols))
+	ijHelper.curNotUsedColLens = make([]int, 0, len(idxCols))
+	for i, idxCol := range idxCols {
+		ijHelper.curIdxOff2KeyOff[i] = tmpSchema.ColumnIndex(idxCol)
+		if ijHelper.curIdxOff2KeyOff[i] >= 0 {
+			continue
+		}
+		ijHelper.curNotUsedIndexCols = append(ijHelper.curNotUsedIndexCols, idxCol)
+		ijHelper.curNotUsedColLens = append(ijHelper.curNotUsedColLens, colLens[i])
+	}
+}
+
+// removeUselessEqAndInFunc removes the useless eq/in conditions. It's designed for the following case:
+//   t1 join t2 on t1.a=t2.a and t1.c=t2.c where t1.b > t2.b-10 and t1.b < t2.b+10 there's index(a, b, c) on t1.
+//   In this case the curIdxOff2KeyOff is [0 -1 1] and the notKeyEqAndIn is [].
+//   It's clearly that the column c cannot be used to access data. So we need to remove it and reset the IdxOff2KeyOff to
+//   [0 -1 -1].
+//   So that we can use t1.a=t2.a to build ranges then access data.
+func (ijHelper *indexJoinBuildHelper) removeUselessEqAndInFunc(
+	idxCols []*expression.Column,
+	notKeyEqAndIn []expression.Expression) (
+	usefulEqAndIn, uselessOnes []expression.Expression,
+) {
+	ijHelper.curPossibleUsedKeys = make([]*expression.Column, 0, len(idxCols))
+	for idxColPos, notKeyColPos := 0, 0; idxColPos < len(idxCols); idxColPos++ {
+		if ijHelper.curIdxOff2KeyOff[idxColPos] != -1 {
+			ijHelper.curPossibleUsedKeys = append(ijHelper.curPossibleUsedKeys, idxCols[idxColPos])
+			continue
+		}
+		if notKeyColPos < len(notKeyEqAndIn) && ijHelper.curNotUsedIndexCols[notKeyColPos].Equal(nil, idxCols[idxColPos]) {
+			notKeyColPos++
+			continue
+		}
+		for i := idxColPos + 1; i < len(idxCols); i++ {
+			ijHelper.curIdxOff2KeyOff[i] = -1
+		}
+		remained := make([]expression.Expression, 0, len(notKeyEqAndIn)-notKeyColPos)
+		remained = append(remained, notKeyEqAndIn[notKeyColPos:]...)
+		notKeyEqAndIn = notKeyEqAndIn[:notKeyColPos]
+		return notKeyEqAndIn, remained
+	}
+	return notKeyEqAndIn, nil
+}
+
+// analyzeLookUpFilters tries to build the template ranges of the index with the join keys and the eq/in conditions
+// of the inner side. The positions occupied by join keys are filled by the outer rows at execution time.
+func (ijHelper *indexJoinBuildHelper) analyzeLookUpFilters(path *util.AccessPath, innerPlan *DataSource, innerJoinKeys []*expression.Column) (emptyRange bool, err error) {
+	idxCols, colLens := path.IdxCols, path.IdxColLens
+	if len(idxCols) == 0 {
+		return false, nil
+	}
+	ijHelper.resetContextForIndex(innerJoinKeys, idxCols, colLens)
+	notKeyEqAndIn, prefixFilters, remained, emptyRange := ranger.ExtractEqAndInCondition(
+		innerPlan.ctx, innerPlan.pushedDownConds,
+		ijHelper.curNotUsedIndexCols,
+		ijHelper.curNotUsedColLens,
+	)
+	if emptyRange {
+		return true, nil
+	}
+	var remainedEqAndIn []expression.Expression
+	notKeyEqAndIn, remainedEqAndIn = ijHelper.removeUselessEqAndInFunc(idxCols, notKeyEqAndIn)
+	matchedKeyCnt := len(ijHelper.curPossibleUsedKeys)
+	// If no join key is matched, index join cannot reduce the rows to be read.
+	if matchedKeyCnt <= 0 {
+		return false, nil
+	}
+	remained = append(remained, remainedEqAndIn...)
+	// The prefix index columns only decide the ranges partially, so they should be evaluated again.
+	for _, filter := range prefixFilters {
+		if !expression.Contains(remained, filter) {
+			remained = append(remained, filter)
+		}
+	}
+	accesses := make([]expression.Expression, 0, len(notKeyEqAndIn)+1)
+	accesses = append(accesses, notKeyEqAndIn...)
+	var nextColRange []*ranger.Range
+	lastColPos := matchedKeyCnt + len(notKeyEqAndIn)
+	// We can use the range conditions on the column next to the eq/in columns.
+	if lastColPos < len(idxCols) && colLens[lastColPos] == types.UnspecifiedLength {
+		nextCol := idxCols[lastColPos]
+		colAccesses, colRemained := ranger.DetachCondsForColumn(innerPlan.ctx, remained, nextCol)
+		if len(colAccesses) > 0 {
+			sc := innerPlan.ctx.GetSessionVars().StmtCtx
+			nextColRange, err = ranger.BuildColumnRange(colAccesses, sc, nextCol.RetType, types.UnspecifiedLength)
+			if err != nil {
+				return false, err
+			}
+			if len(nextColRange) == 0 {
+				return true, nil
+			}
+			accesses = append(accesses, colAccesses...)
+			remained = colRemained
+		}
+	}
+	ranges, emptyRange, err := ijHelper.buildTemplateRange(matchedKeyCnt, notKeyEqAndIn, nextColRange)
+	if err != nil {
+		return false, err
+	}
+	if emptyRange {
+		return true, nil
+	}
+	ijHelper.updateBestChoice(ranges, path, accesses, remained)
+	return false, nil
+}
+
+func (ijHelper *indexJoinBuildHelper) updateBestChoice(ranges []*ranger.Range, path *util.AccessPath, accesses,
+	remained []expression.Expression) {
+	// We choose the index by the number of used columns of the range, the much the better.
+	// Notice that there may be the cases like `t1.a=t2.a and b > 2 and b < 1`. So ranges can be nil though the conditions are valid.
+	// But obviously when the range is nil, we don't need index join.
+	if len(ranges) > 0 && len(ranges[0].LowVal) > ijHelper.maxUsedCols {
+		ijHelper.chosenPath = path
+		ijHelper.maxUsedCols = len(ranges[0].LowVal)
+		ijHelper.chosenRanges = ranges
+		ijHelper.chosenAccess = accesses
+		ijHelper.chosenRemained = remained
+		ijHelper.idxOff2KeyOff = ijHelper.curIdxOff2KeyOff
+	}
+}
+
+// buildTemplateRange builds the ranges whose positions of join keys are left empty. They are filled by the
+// values of outer rows at execution time.
+func (ijHelper *indexJoinBuildHelper) buildTemplateRange(matchedKeyCnt int, eqAndInFuncs []expression.Expression,
+	nextColRange []*ranger.Range) (ranges []*ranger.Range, emptyRange bool, err error) {
+	pointLength := matchedKeyCnt + len(eqAndInFuncs)
+	ranges = append(ranges, &ranger.Range{
+		LowVal:  make([]types.Datum, pointLength, pointLength+1),
+		HighVal: make([]types.Datum, pointLength, pointLength+1),
+	})
+	sc := ijHelper.join.ctx.GetSessionVars().StmtCtx
+	for i, j := 0, 0; j < len(eqAndInFuncs); i++ {
+		// This position is occupied by join key.
+		if ijHelper.curIdxOff2KeyOff[i] != -1 {
+			continue
+		}
+		oneColumnRan, err := ranger.BuildColumnRange([]expression.Expression{eqAndInFuncs[j]}, sc, ijHelper.curNotUsedIndexCols[j].RetType, ijHelper.curNotUsedColLens[j])
+		if err != nil {
+			return nil, false, err
+		}
+		if len(oneColumnRan) == 0 {
+			return nil, true, nil
+		}
+		for _, ran := range ranges {
+			ran.LowVal[i] = oneColumnRan[0].LowVal[0]
+			ran.HighVal[i] = oneColumnRan[0].HighVal[0]
+		}
+		curRangeLen := len(ranges)
+		for ranIdx := 1; ranIdx < len(oneColumnRan); ranIdx++ {
+			for oldRangeIdx := 0; oldRangeIdx < curRangeLen; oldRangeIdx++ {
+				newRange := ranges[oldRangeIdx].Clone()
+				newRange.LowVal[i] = oneColumnRan[ranIdx].LowVal[0]
+				newRange.HighVal[i] = oneColumnRan[ranIdx].HighVal[0]
+				ranges = append(ranges, newRange)
+			}
+		}
+		j++
+	}
+	if len(nextColRange) > 0 {
+		newRanges := make([]*ranger.Range, 0, len(ranges)*len(nextColRange))
+		for _, colRan := range nextColRange {
+			for _, ran := range ranges {
+				newRange := ran.Clone()
+				newRange.LowVal = append(newRange.LowVal, colRan.LowVal[0])
+				newRange.HighVal = append(newRange.HighVal, colRan.HighVal[0])
+				newRange.LowExclude = colRan.LowExclude
+				newRange.HighExclude = colRan.HighExclude
+				newRanges = append(newRanges, newRange)
+			}
+		}
+		ranges = newRanges
+	}
+	return ranges, false, nil
+}
+
+// tryToGetIndexJoin will get index join by hints. If we can generate a valid index join by hint, the second return value
+// will be true, which means we force to choose this index join. Otherwise we will select a join algorithm with min-cost.
+func (p *LogicalJoin) tryToGetIndexJoin(prop *property.PhysicalProperty) (indexJoins []PhysicalPlan, forced bool) {
+	rightOuter := (p.preferJoinType & preferLeftAsIndexInner) > 0
+	leftOuter := (p.preferJoinType & preferRightAsIndexInner) > 0
+	hasIndexJoinHint := leftOuter || rightOuter
+
+	defer func() {
+		if !forced && hasIndexJoinHint {
+			// Construct warning message prefix.
+			errMsg := "Optimizer Hint INL_JOIN or TIDB_INLJ is inapplicable"
+			if p.hintInfo != nil {
+				errMsg = fmt.Sprintf("Optimizer Hint %s or %s is inapplicable",
+					restore2JoinHint(HintINLJ, p.hintInfo.indexNestedLoopJoinTables),
+					restore2JoinHint(TiDBIndexNestedLoopJoin, p.hintInfo.indexNestedLoopJoinTables))
+			}
+			// Append inapplicable reason.
+			if len(p.EqualConditions) == 0 {
+				errMsg += " without column equal ON condition"
+			}
+			// Generate warning message to client.
+			warning := ErrInternal.GenWithStack(errMsg)
+			p.ctx.GetSessionVars().StmtCtx.AppendWarning(warning)
+		}
+	}()
+
+	switch p.JoinType {
+	case LeftOuterJoin:
+		join := p.getIndexJoinByOuterIdx(prop, 0)
+		return join, join != nil && leftOuter
+	case RightOuterJoin:
+		join := p.getIndexJoinByOuterIdx(prop, 1)
+		return join, join != nil && rightOuter
+	case InnerJoin:
+		lhsCardinality := p.Children()[0].statsInfo().Count()
+		rhsCardinality := p.Children()[1].statsInfo().Count()
+
+		leftJoins := p.getIndexJoinByOuterIdx(prop, 0)
+		if leftJoins != nil && leftOuter && !rightOuter {
+			return leftJoins, true
+		}
+
+		rightJoins := p.getIndexJoinByOuterIdx(prop, 1)
+		if rightJoins != nil && rightOuter && !leftOuter {
+			return rightJoins, true
+		}
+
+		if leftJoins != nil && lhsCardinality < rhsCardinality {
+			return leftJoins, hasIndexJoinHint
+		}
+
+		if rightJoins != nil && rhsCardinality < lhsCardinality {
+			return rightJoins, hasIndexJoinHint
+		}
+
+		joins := append(leftJoins, rightJoins...)
+		return joins, hasIndexJoinHint && len(joins) != 0
+	}
+
+	return nil, false
+}
+
 // LogicalJoin can generates hash join, index join and sort merge join.
 // Firstly we check the hint, if hint is figured by user, we force to choose the corresponding physical plan.
 // If the hint is not matched, it will get other candidates.
@@ -283,9 +844,15 @@ func (p *LogicalJoin) exhaustPhysicalPlans(prop *property.PhysicalProperty) []Ph
 	if (p.preferJoinType & preferMergeJoin) > 0 {
 		return mergeJoins
 	}
-	joins := make([]PhysicalPlan, 0, 3)
+	joins := make([]PhysicalPlan, 0, 5)
 	joins = append(joins, mergeJoins...)
 
+	indexJoins, forced := p.tryToGetIndexJoin(prop)
+	if forced {
+		return indexJoins
+	}
+	joins = append(joins, indexJoins...)
+
 	hashJoins := p.getHashJoins(prop)
 	if (p.preferJoinType & preferHashJoin) > 0 {
 		return hashJoins
@@ -357,7 +924,7 @@ func (lt *LogicalTopN) getPhysLimits() []PhysicalPlan {
 }
 
 // MatchItems checks if this prop's columns can match by items totally.
-func MatchItems(p *property.PhysicalProperty, items []*ByItems) bool {
+func MatchItems(p *property.PhysicalProperty, items []*util.ByItems) bool {
 	if len(items) < len(p.Items) {
 		return false
 	}
@@ -382,6 +949,49 @@ func (p *baseLogicalPlan) exhaustPhysicalPlans(_ *property.PhysicalProperty) []P
 	panic("baseLogicalPlan.exhaustPhysicalPlans() should never be called.")
 }
 
+func (la *LogicalAggregation) getStreamAggs(prop *property.PhysicalProperty) []PhysicalPlan {
+	all, desc := prop.AllSameOrder()
+	if !all {
+		return nil
+	}
+	for _, aggFunc := range la.AggFuncs {
+		if aggFunc.Mode == aggregation.FinalMode {
+			return nil
+		}
+	}
+	// group by a + b is not interested in any order.
+	if len(la.GetGroupByCols()) != len(la.GroupByItems) {
+		return nil
+	}
+
+	streamAggs := make([]PhysicalPlan, 0, len(la.possibleProperties)*2)
+	childProp := &property.PhysicalProperty{
+		ExpectedCnt: math.Max(prop.ExpectedCnt*la.inputCount/la.stats.RowCount, prop.ExpectedCnt),
+	}
+
+	for _, possibleChildProperty := range la.possibleProperties {
+		childProp.Items = property.ItemsFromCols(possibleChildProperty[:len(la.groupByCols)], desc)
+		if !prop.IsPrefix(childProp) {
+			continue
+		}
+		// The table read of "CopDoubleReadTaskType" can't promises the sort
+		// property that the stream aggregation required, no need to consider.
+		for _, taskTp := range []property.TaskType{property.CopSingleReadTaskType, property.RootTaskType} {
+			copiedChildProperty := new(property.PhysicalProperty)
+			*copiedChildProperty = *childProp // It's ok to not deep copy the "cols" field.
+			copiedChildProperty.TaskTp = taskTp
+
+			agg := basePhysicalAgg{
+				GroupByItems: la.GroupByItems,
+				AggFuncs:     la.AggFuncs,
+			}.initForStream(la.ctx, la.stats.ScaleByExpectCnt(prop.ExpectedCnt), copiedChildProperty)
+			agg.SetSchema(la.schema.Clone())
+			streamAggs = append(streamAggs, agg)
+		}
+	}
+	return streamAggs
+}
+
 func (la *LogicalAggregation) getHashAggs(prop *property.PhysicalProperty) []PhysicalPlan {
 	if !prop.IsEmpty() {
 		return nil
@@ -396,8 +1006,107 @@ func (la *LogicalAggregation) getHashAggs(prop *property.PhysicalProperty) []Phy
 	return hashAggs
 }
 
+// getEnforcedStreamAggs generates stream aggregations whose child is required to
+// be sorted on the group-by columns, so a sort will be enforced if necessary.
+// It is only used when the STREAM_AGG hint is specified.
+func (la *LogicalAggregation) getEnforcedStreamAggs(prop *property.PhysicalProperty) []PhysicalPlan {
+	if !prop.IsEmpty() {
+		return nil
+	}
+	for _, aggFunc := range la.AggFuncs {
+		if aggFunc.Mode == aggregation.FinalMode {
+			return nil
+		}
+	}
+	groupByCols := la.GetGroupByCols()
+	if len(groupByCols) != len(la.GroupByItems) {
+		return nil
+	}
+	childProp := property.NewPhysicalProperty(property.RootTaskType, groupByCols, false, math.MaxFloat64, len(groupByCols) > 0)
+	agg := basePhysicalAgg{
+		GroupByItems: la.GroupByItems,
+		AggFuncs:     la.AggFuncs,
+	}.initForStream(la.ctx, la.stats.ScaleByExpectCnt(prop.ExpectedCnt), childProp)
+	agg.SetSchema(la.schema.Clone())
+	return []PhysicalPlan{agg}
+}
+
+// canPushToCop checks whether the aggregation can be pushed down to the coprocessor.
+func (la *LogicalAggregation) canPushToCop() bool {
+	if _, ok := la.children[0].(*DataSource); !ok {
+		return false
+	}
+	return CheckAggCanPushCop(la.ctx, la.AggFuncs, la.GroupByItems)
+}
+
+// filterAggsByTaskType only keeps the aggregations whose child task type is in taskTypes.
+func filterAggsByTaskType(aggs []PhysicalPlan, taskTypes ...property.TaskType) []PhysicalPlan {
+	ret := aggs[:0]
+	for _, agg := range aggs {
+		childTp := agg.GetChildReqProps(0).TaskTp
+		for _, tp := range taskTypes {
+			if childTp == tp {
+				ret = append(ret, agg)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// LogicalAggregation can generates stream aggregation and hash aggregation.
+// If the HASH_AGG or STREAM_AGG hint is specified, we force to choose the
+// corresponding physical plan. If AGG_TO_COP is specified, only the plans
+// which push the aggregation down to the coprocessor are considered.
 func (la *LogicalAggregation) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
-	return la.getHashAggs(prop)
+	if la.aggHints.preferAggToCop && !la.canPushToCop() {
+		la.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+			"Optimizer Hint AGG_TO_COP is inapplicable"))
+		la.aggHints.preferAggToCop = false
+	}
+	preferHash := (la.aggHints.preferAggType & preferHashAgg) > 0
+	preferStream := (la.aggHints.preferAggType & preferStreamAgg) > 0
+
+	streamAggs := la.getStreamAggs(prop)
+	if preferStream && !la.aggHints.preferAggToCop {
+		streamAggs = append(streamAggs, la.getEnforcedStreamAggs(prop)...)
+	}
+	hashAggs := la.getHashAggs(prop)
+	if la.aggHints.preferAggToCop {
+		streamAggs = filterAggsByTaskType(streamAggs, property.CopSingleReadTaskType)
+		hashAggs = filterAggsByTaskType(hashAggs, property.CopSingleReadTaskType, property.CopDoubleReadTaskType)
+	}
+
+	if preferHash && len(hashAggs) > 0 {
+		return hashAggs
+	}
+	if preferStream {
+		if len(streamAggs) > 0 {
+			return streamAggs
+		}
+		if prop.IsEmpty() {
+			la.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"Optimizer Hint STREAM_AGG is inapplicable"))
+		}
+	}
+	aggs := make([]PhysicalPlan, 0, len(streamAggs)+len(hashAggs))
+	aggs = append(aggs, streamAggs...)
+	aggs = append(aggs, hashAggs...)
+	return aggs
+}
+
+func (p *LogicalUnionAll) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
+	// UnionAll can not keep any order of its children.
+	if !prop.IsEmpty() {
+		return nil
+	}
+	chReqProps := make([]*property.PhysicalProperty, 0, len(p.children))
+	for range p.children {
+		chReqProps = append(chReqProps, &property.PhysicalProperty{ExpectedCnt: prop.ExpectedCnt})
+	}
+	ua := PhysicalUnionAll{}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), chReqProps...)
+	ua.SetSchema(p.Schema())
+	return []PhysicalPlan{ua}
 }
 
 func (p *LogicalSelection) exhaustPhysicalPlans(prop *property.PhysicalProperty) []PhysicalPlan {
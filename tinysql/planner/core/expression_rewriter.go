@@ -51,6 +51,26 @@ func evalAstExpr(sctx sessionctx.Context, expr ast.ExprNode) (types.Datum, error
 	return newExpr.Eval(chunk.Row{})
 }
 
+// rewriteAstExpr rewrites ast expr to expression.Expression, the columns in
+// expr are resolved against schema and names.
+func rewriteAstExpr(sctx sessionctx.Context, expr ast.ExprNode, schema *expression.Schema, names types.NameSlice) (expression.Expression, error) {
+	var is infoschema.InfoSchema
+	if sctx.GetSessionVars().TxnCtx.InfoSchema != nil {
+		is = sctx.GetSessionVars().TxnCtx.InfoSchema.(infoschema.InfoSchema)
+	}
+	b := NewPlanBuilder(sctx, is)
+	fakePlan := LogicalTableDual{}.Init(sctx)
+	if schema != nil {
+		fakePlan.schema = schema
+		fakePlan.names = names
+	}
+	newExpr, _, err := b.rewrite(context.TODO(), expr, fakePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	return newExpr, nil
+}
+
 // rewrite function rewrites ast expr to expression.Expression.
 // aggMapper maps ast.AggregateFuncExpr to the columns offset in p's output schema.
 // asScalar means whether this expression must be treated as a scalar expression.
@@ -301,10 +321,13 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		inNode = er.preprocess(inNode)
 	}
 	switch v := inNode.(type) {
-	case *ast.AggregateFuncExpr, *ast.ColumnNameExpr, *ast.ParenthesesExpr, *ast.ValuesExpr:
+	case *ast.AggregateFuncExpr, *ast.ColumnNameExpr, *ast.ParenthesesExpr, *ast.WhenClause, *ast.ValuesExpr:
 	case *driver.ValueExpr:
 		value := &expression.Constant{Value: v.Datum, RetType: &v.Type}
 		er.ctxStackAppend(value, types.EmptyName)
+	case *driver.ParamMarkerExpr:
+		value := expression.ParamMarkerExpression(er.sctx, v)
+		er.ctxStackAppend(value, types.EmptyName)
 	case *ast.VariableExpr:
 		er.rewriteVariable(v)
 	case *ast.FuncCallExpr:
@@ -317,6 +340,30 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.binaryOpToExpression(v)
 	case *ast.BetweenExpr:
 		er.betweenToExpression(v)
+	case *ast.CaseExpr:
+		er.caseWhenToExpression(v)
+	case *ast.FuncCastExpr:
+		arg := er.ctxStack[len(er.ctxStack)-1]
+		er.err = expression.CheckArgsNotMultiColumnRow(arg)
+		if er.err != nil {
+			return retNode, false
+		}
+		if !er.checkCastType(arg, v.Tp) {
+			return retNode, false
+		}
+		castFunction := expression.BuildCastFunction(er.sctx, arg, v.Tp)
+		er.ctxStack[len(er.ctxStack)-1] = castFunction
+		er.ctxNameStk[len(er.ctxNameStk)-1] = types.EmptyName
+	case *ast.TrimDirectionExpr:
+		er.ctxStackAppend(&expression.Constant{
+			Value:   types.NewIntDatum(int64(v.Direction)),
+			RetType: types.NewFieldType(mysql.TypeTiny),
+		}, types.EmptyName)
+	case *ast.TimeUnitExpr:
+		er.ctxStackAppend(&expression.Constant{
+			Value:   types.NewStringDatum(v.Unit.String()),
+			RetType: types.NewFieldType(mysql.TypeVarchar),
+		}, types.EmptyName)
 	case *ast.RowExpr:
 		er.rowToScalarFunc(v)
 	case *ast.PatternInExpr:
@@ -407,6 +454,8 @@ func (er *expressionRewriter) unaryOpToExpression(v *ast.UnaryOperationExpr) {
 		op = ast.UnaryMinus
 	case opcode.Not:
 		op = ast.UnaryNot
+	case opcode.BitNeg:
+		op = ast.BitNeg
 	default:
 		er.err = errors.Errorf("Unknown Unary Op %T", v.Op)
 		return
@@ -640,11 +689,112 @@ func (er *expressionRewriter) rewriteFuncCall(v *ast.FuncCallExpr) bool {
 		}
 
 		return false
+	case ast.Nullif:
+		if len(v.Args) != 2 {
+			er.err = expression.ErrIncorrectParameterCount.GenWithStackByArgs(v.FnName.O)
+			return true
+		}
+		stackLen := len(er.ctxStack)
+		param1 := er.ctxStack[stackLen-2]
+		param2 := er.ctxStack[stackLen-1]
+		// param1 = param2
+		funcCompare, err := er.constructBinaryOpFunction(param1, param2, ast.EQ)
+		if err != nil {
+			er.err = err
+			return true
+		}
+		// NULL
+		nullTp := types.NewFieldType(mysql.TypeNull)
+		nullTp.Flen, nullTp.Decimal = mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeNull)
+		paramNull := &expression.Constant{
+			Value:   types.NewDatum(nil),
+			RetType: nullTp,
+		}
+		// if(param1 = param2, NULL, param1)
+		funcIf, err := er.newFunction(ast.If, &v.Type, funcCompare, paramNull, param1)
+		if err != nil {
+			er.err = err
+			return true
+		}
+		er.ctxStackPop(len(v.Args))
+		er.ctxStackAppend(funcIf, types.EmptyName)
+		return true
 	default:
 		return false
 	}
 }
 
+// checkCastType reports an error if CAST between the two types is not supported yet.
+func (er *expressionRewriter) checkCastType(arg expression.Expression, tp *types.FieldType) bool {
+	argTp := arg.GetType().EvalType()
+	switch argTp {
+	case types.ETInt, types.ETReal, types.ETDecimal, types.ETString,
+		types.ETDatetime, types.ETTimestamp, types.ETDuration, types.ETJson:
+	default:
+		er.err = ErrUnsupportedType.GenWithStack("Unsupported type %s in CAST", arg.GetType())
+		return false
+	}
+	switch tp.EvalType() {
+	case types.ETInt, types.ETReal, types.ETDecimal, types.ETString,
+		types.ETDatetime, types.ETTimestamp, types.ETDuration, types.ETJson:
+	default:
+		er.err = ErrUnsupportedType.GenWithStack("Unsupported CAST target type %s", tp)
+		return false
+	}
+	return true
+}
+
+func (er *expressionRewriter) caseWhenToExpression(v *ast.CaseExpr) {
+	stkLen := len(er.ctxStack)
+	argsLen := 2 * len(v.WhenClauses)
+	if v.ElseClause != nil {
+		argsLen++
+	}
+	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[stkLen-argsLen:]...)
+	if er.err != nil {
+		return
+	}
+
+	// value                          -> ctxStack[stkLen-argsLen-1]
+	// when clause(condition, result) -> ctxStack[stkLen-argsLen:stkLen-1];
+	// else clause                    -> ctxStack[stkLen-1]
+	var args []expression.Expression
+	if v.Value != nil {
+		// args:  eq scalar func(args: value, condition1), result1,
+		//        eq scalar func(args: value, condition2), result2,
+		//        ...
+		//        else clause
+		value := er.ctxStack[stkLen-argsLen-1]
+		args = make([]expression.Expression, 0, argsLen)
+		for i := stkLen - argsLen; i < stkLen-1; i += 2 {
+			arg, err := er.newFunction(ast.EQ, types.NewFieldType(mysql.TypeTiny), value, er.ctxStack[i])
+			if err != nil {
+				er.err = err
+				return
+			}
+			args = append(args, arg)
+			args = append(args, er.ctxStack[i+1])
+		}
+		if v.ElseClause != nil {
+			args = append(args, er.ctxStack[stkLen-1])
+		}
+		argsLen++ // for trimming the value element later
+	} else {
+		// args:  condition1, result1,
+		//        condition2, result2,
+		//        ...
+		//        else clause
+		args = er.ctxStack[stkLen-argsLen:]
+	}
+	function, err := er.newFunction(ast.Case, &v.Type, args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(argsLen)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	stackLen := len(er.ctxStack)
 	args := er.ctxStack[stackLen-len(v.Args):]
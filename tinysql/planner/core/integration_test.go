@@ -88,3 +88,77 @@ func (s *testIntegrationSuite) TestPushLimitDownIndexLookUpReader(c *C) {
 		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
 	}
 }
+
+func (s *testIntegrationSuite) TestRefineCmpConstant(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b int, key idx_b(b))")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+
+	tk.MustExec("insert into t values (-2, -2), (-1, -1), (1, 1), (2, 2), (3, 3)")
+	tk.MustQuery("select a from t where a < 1.5").Check(testkit.Rows("-2", "-1", "1"))
+	tk.MustQuery("select a from t where a > -1.5").Check(testkit.Rows("-1", "1", "2", "3"))
+	tk.MustQuery("select b from t where b = 2.0").Check(testkit.Rows("2"))
+	tk.MustQuery("select b from t where b <= '2.5'").Check(testkit.Rows("-2", "-1", "1", "2"))
+	tk.MustQuery("select b from t where b = 2.5").Check(testkit.Rows())
+	tk.MustQuery("select a from t where 1.5 > a").Check(testkit.Rows("-2", "-1", "1"))
+}
+
+func (s *testIntegrationSuite) TestIndexRangeForDecimal(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a decimal(10, 2), b decimal(20, 6), key idx_a(a), key idx_b(b))")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+}
+
+func (s *testIntegrationSuite) TestIndexRangeForTime(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(d date, dt datetime, ts timestamp null, key idx_d(d), key idx_dt(dt), key idx_ts(ts))")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+}
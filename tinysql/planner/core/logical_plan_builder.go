@@ -26,11 +26,14 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
@@ -44,14 +47,34 @@ const (
 	TiDBMergeJoin = "tidb_smj"
 	// HintSMJ is hint enforce merge join.
 	HintSMJ = "sm_join"
+	// HintMergeJoin is hint enforce merge join.
+	HintMergeJoin = "merge_join"
 	// TiDBHashJoin is hint enforce hash join.
 	TiDBHashJoin = "tidb_hj"
 	// HintHJ is hint enforce hash join.
 	HintHJ = "hash_join"
+	// TiDBIndexNestedLoopJoin is hint enforce index nested loop join.
+	TiDBIndexNestedLoopJoin = "tidb_inlj"
+	// HintINLJ is hint enforce index nested loop join.
+	HintINLJ = "inl_join"
 	// HintUseIndex is hint enforce using some indexes.
 	HintUseIndex = "use_index"
 	// HintIgnoreIndex is hint enforce ignoring some indexes.
 	HintIgnoreIndex = "ignore_index"
+	// HintLeading is hint enforce joining some tables first in the specified order.
+	HintLeading = "leading"
+	// HintHashAgg is hint enforce hash aggregation.
+	HintHashAgg = "hash_agg"
+	// HintStreamAgg is hint enforce stream aggregation.
+	HintStreamAgg = "stream_agg"
+	// HintAggToCop is hint enforce pushing aggregation to coprocessor.
+	HintAggToCop = "agg_to_cop"
+	// HintReadFromStorage is hint enforce some tables read from specific type of storage.
+	HintReadFromStorage = "read_from_storage"
+	// HintTiKV is a label represents the tikv storage type.
+	HintTiKV = "tikv"
+	// HintTiFlash is a label represents the tiflash storage type.
+	HintTiFlash = "tiflash"
 )
 
 func (la *LogicalAggregation) collectGroupByColumns() {
@@ -76,6 +99,9 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 	b.optFlag = b.optFlag | flagEliminateProjection
 
 	plan4Agg := LogicalAggregation{AggFuncs: make([]*aggregation.AggFuncDesc, 0, len(aggFuncList))}.Init(b.ctx)
+	if hint := b.TableHints(); hint != nil {
+		plan4Agg.aggHints = hint.aggHints
+	}
 	schema4Agg := expression.NewSchema(make([]*expression.Column, 0, len(aggFuncList)+p.Schema().Len())...)
 	names := make(types.NameSlice, 0, len(aggFuncList)+p.Schema().Len())
 	// aggIdxMap maps the old index to new index after applying common aggregation functions elimination.
@@ -91,10 +117,20 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 			p = np
 			newArgList = append(newArgList, newArg)
 		}
-		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, aggFunc.F, newArgList)
+		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, aggFunc.F, newArgList, aggFunc.Distinct)
 		if err != nil {
 			return nil, nil, err
 		}
+		if aggFunc.Order != nil {
+			for _, byItem := range aggFunc.Order.Items {
+				newByItem, np, err := b.rewrite(ctx, byItem.Expr, p, nil, true)
+				if err != nil {
+					return nil, nil, err
+				}
+				p = np
+				newFunc.OrderByItems = append(newFunc.OrderByItems, &util.ByItems{Expr: newByItem, Desc: byItem.Desc})
+			}
+		}
 		combined := false
 		for j, oldFunc := range plan4Agg.AggFuncs {
 			if oldFunc.Equal(b.ctx, newFunc) {
@@ -115,7 +151,7 @@ func (b *PlanBuilder) buildAggregation(ctx context.Context, p LogicalPlan, aggFu
 		}
 	}
 	for i, col := range p.Schema().Columns {
-		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+		newFunc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -223,11 +259,13 @@ func (p *LogicalJoin) ExtractOnCondition(
 	rightCond []expression.Expression, otherCond []expression.Expression) {
 	for _, expr := range conditions {
 		binop, ok := expr.(*expression.ScalarFunction)
+		isColumnPair := false
 		if ok && len(binop.GetArgs()) == 2 {
 			ctx := binop.GetCtx()
 			arg0, lOK := binop.GetArgs()[0].(*expression.Column)
 			arg1, rOK := binop.GetArgs()[1].(*expression.Column)
-			if lOK && rOK {
+			isColumnPair = lOK && rOK
+			if isColumnPair {
 				leftCol := leftSchema.RetrieveColumn(arg0)
 				rightCol := rightSchema.RetrieveColumn(arg1)
 				if leftCol == nil || rightCol == nil {
@@ -292,6 +330,20 @@ func (p *LogicalJoin) ExtractOnCondition(
 					rightCond = append(rightCond, rightRelaxedCond)
 				}
 			}
+			// Columns compared with different types are wrapped in casts, e.g. `cast(t1.a) = cast(t2.c_str)`,
+			// the not null conditions of the plain columns are derived above.
+			if !isColumnPair {
+				if deriveLeft {
+					if notNullExpr := deriveNotNullExpr(expr, leftSchema); notNullExpr != nil {
+						leftCond = append(leftCond, notNullExpr)
+					}
+				}
+				if deriveRight {
+					if notNullExpr := deriveNotNullExpr(expr, rightSchema); notNullExpr != nil {
+						rightCond = append(rightCond, notNullExpr)
+					}
+				}
+			}
 			otherCond = append(otherCond, expr)
 		}
 	}
@@ -327,9 +379,15 @@ func (p *LogicalJoin) setPreferredJoinType(hintInfo *tableHintInfo) {
 	if hintInfo.ifPreferHashJoin(lhsAlias, rhsAlias) {
 		p.preferJoinType |= preferHashJoin
 	}
+	if hintInfo.ifPreferINLJ(lhsAlias) {
+		p.preferJoinType |= preferLeftAsIndexInner
+	}
+	if hintInfo.ifPreferINLJ(rhsAlias) {
+		p.preferJoinType |= preferRightAsIndexInner
+	}
 
 	// set hintInfo for further usage if this hint info can be used.
-	if p.preferJoinType != 0 {
+	if p.preferJoinType != 0 || len(hintInfo.leadingJoinOrder) > 0 {
 		p.hintInfo = hintInfo
 	}
 
@@ -397,6 +455,9 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		b.optFlag = b.optFlag | flagJoinReOrder
 		joinPlan.JoinType = InnerJoin
 	}
+	if hint := b.TableHints(); hint != nil && len(hint.leadingJoinOrder) > 0 {
+		b.optFlag = b.optFlag | flagJoinReOrder
+	}
 
 	// Set preferred join algorithm if some join hints is specified by user.
 	joinPlan.setPreferredJoinType(b.TableHints())
@@ -441,7 +502,7 @@ func (b *PlanBuilder) buildSelection(ctx context.Context, p LogicalPlan, where a
 		}
 		cnfItems := expression.SplitCNFItems(expr)
 		for _, item := range cnfItems {
-			if con, ok := item.(*expression.Constant); ok {
+			if con, ok := item.(*expression.Constant); ok && con.ConstItem() {
 				ret, _, err := expression.EvalBool(b.ctx, expression.CNFExprs{con}, chunk.Row{})
 				if err != nil || ret {
 					continue
@@ -608,9 +669,12 @@ func (b *PlanBuilder) buildDistinct(child LogicalPlan, length int) (*LogicalAggr
 		AggFuncs:     make([]*aggregation.AggFuncDesc, 0, child.Schema().Len()),
 		GroupByItems: expression.Column2Exprs(child.Schema().Clone().Columns[:length]),
 	}.Init(b.ctx)
+	if hint := b.TableHints(); hint != nil {
+		plan4Agg.aggHints = hint.aggHints
+	}
 	plan4Agg.collectGroupByColumns()
 	for _, col := range child.Schema().Columns {
-		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col})
+		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
 		if err != nil {
 			return nil, err
 		}
@@ -627,29 +691,10 @@ func (b *PlanBuilder) buildDistinct(child LogicalPlan, length int) (*LogicalAggr
 	return plan4Agg, nil
 }
 
-// ByItems wraps a "by" item.
-type ByItems struct {
-	Expr expression.Expression
-	Desc bool
-}
-
-// String implements fmt.Stringer interface.
-func (by *ByItems) String() string {
-	if by.Desc {
-		return fmt.Sprintf("%s true", by.Expr)
-	}
-	return by.Expr.String()
-}
-
-// Clone makes a copy of ByItems.
-func (by *ByItems) Clone() *ByItems {
-	return &ByItems{Expr: by.Expr.Clone(), Desc: by.Desc}
-}
-
 func (b *PlanBuilder) buildSort(ctx context.Context, p LogicalPlan, byItems []*ast.ByItem, aggMapper map[*ast.AggregateFuncExpr]int) (*LogicalSort, error) {
 	b.curClause = orderByClause
 	sort := LogicalSort{}.Init(b.ctx)
-	exprs := make([]*ByItems, 0, len(byItems))
+	exprs := make([]*util.ByItems, 0, len(byItems))
 	for _, item := range byItems {
 		it, np, err := b.rewriteWithPreprocess(ctx, item.Expr, p, aggMapper, true, nil)
 		if err != nil {
@@ -657,7 +702,7 @@ func (b *PlanBuilder) buildSort(ctx context.Context, p LogicalPlan, byItems []*a
 		}
 
 		p = np
-		exprs = append(exprs, &ByItems{Expr: it, Desc: item.Desc})
+		exprs = append(exprs, &util.ByItems{Expr: it, Desc: item.Desc})
 	}
 	sort.ByItems = exprs
 	sort.SetChildren(p)
@@ -671,6 +716,12 @@ func getUintFromNode(ctx sessionctx.Context, n ast.Node) (uVal uint64, isNull bo
 	switch v := n.(type) {
 	case *driver.ValueExpr:
 		val = v.GetValue()
+	case *driver.ParamMarkerExpr:
+		// The value is unknown when the statement is being prepared.
+		if !v.InExecute {
+			return 0, false, true
+		}
+		val = v.GetValue()
 	default:
 		return 0, false, false
 	}
@@ -1094,13 +1145,19 @@ func (b *PlanBuilder) unfoldWildStar(p LogicalPlan, selectFields []*ast.SelectFi
 
 func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint) {
 	var (
-		sortMergeTables, hashJoinTables []hintTableInfo
-		indexHintList                   []indexHintInfo
+		sortMergeTables, INLJTables, hashJoinTables []hintTableInfo
+		indexHintList                               []indexHintInfo
+		leadingJoinOrder                            []hintTableInfo
+		tikvTables, tiflashTables                   []hintTableInfo
+		aggHints                                    aggHintInfo
+		leadingHintCnt                              int
 	)
 	for _, hint := range hints {
 		switch hint.HintName.L {
-		case TiDBMergeJoin, HintSMJ:
+		case TiDBMergeJoin, HintSMJ, HintMergeJoin:
 			sortMergeTables = append(sortMergeTables, tableNames2HintTableInfo(b.ctx, hint.Tables)...)
+		case TiDBIndexNestedLoopJoin, HintINLJ:
+			INLJTables = append(INLJTables, tableNames2HintTableInfo(b.ctx, hint.Tables)...)
 		case TiDBHashJoin, HintHJ:
 			hashJoinTables = append(hashJoinTables, tableNames2HintTableInfo(b.ctx, hint.Tables)...)
 		case HintUseIndex:
@@ -1135,25 +1192,82 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint) {
 					},
 				})
 			}
-
+		case HintLeading:
+			if leadingHintCnt == 0 {
+				leadingJoinOrder = tableNames2HintTableInfo(b.ctx, hint.Tables)
+			}
+			leadingHintCnt++
+		case HintHashAgg:
+			aggHints.preferAggType |= preferHashAgg
+		case HintStreamAgg:
+			aggHints.preferAggType |= preferStreamAgg
+		case HintAggToCop:
+			aggHints.preferAggToCop = true
+		case HintReadFromStorage:
+			switch hint.StoreType.L {
+			case HintTiKV:
+				tikvTables = append(tikvTables, tableNames2HintTableInfo(b.ctx, hint.Tables)...)
+			case HintTiFlash:
+				tiflashTables = append(tiflashTables, tableNames2HintTableInfo(b.ctx, hint.Tables)...)
+			}
 		default:
 			// ignore hints that not implemented
 		}
 	}
+	if leadingHintCnt > 1 {
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack("We can only use one leading hint at most, when multiple leading hints are used, only the first one takes effect"))
+	}
+	if aggHints.preferAggType == preferHashAgg|preferStreamAgg {
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack("Optimizer aggregation hints are conflicted"))
+		aggHints.preferAggType = 0
+	}
 	b.tableHintInfo = append(b.tableHintInfo, tableHintInfo{
-		sortMergeJoinTables: sortMergeTables,
-		hashJoinTables:      hashJoinTables,
-		indexHintList:       indexHintList,
+		sortMergeJoinTables:       sortMergeTables,
+		indexNestedLoopJoinTables: INLJTables,
+		hashJoinTables:            hashJoinTables,
+		indexHintList:             indexHintList,
+		leadingJoinOrder:          leadingJoinOrder,
+		tikvTables:                tikvTables,
+		tiflashTables:             tiflashTables,
+		aggHints:                  aggHints,
 	})
 }
 
 func (b *PlanBuilder) popTableHints() {
 	hintInfo := b.tableHintInfo[len(b.tableHintInfo)-1]
-	b.appendUnmatchedJoinHintWarning(HintSMJ, TiDBMergeJoin, hintInfo.sortMergeJoinTables)
+	b.appendUnmatchedJoinHintWarning(HintINLJ, TiDBIndexNestedLoopJoin, hintInfo.indexNestedLoopJoinTables)
+	b.appendUnmatchedJoinHintWarning(HintMergeJoin, TiDBMergeJoin, hintInfo.sortMergeJoinTables)
 	b.appendUnmatchedJoinHintWarning(HintHJ, TiDBHashJoin, hintInfo.hashJoinTables)
+	b.appendUnmatchedIndexHintWarning(hintInfo.indexHintList)
+	b.appendUnmatchedStorageHintWarning(hintInfo.tikvTables, hintInfo.tiflashTables)
 	b.tableHintInfo = b.tableHintInfo[:len(b.tableHintInfo)-1]
 }
 
+func (b *PlanBuilder) appendUnmatchedIndexHintWarning(indexHints []indexHintInfo) {
+	for _, hint := range indexHints {
+		if hint.matched {
+			continue
+		}
+		hintName := HintUseIndex
+		if hint.indexHint.HintType == ast.HintIgnore {
+			hintName = HintIgnoreIndex
+		}
+		errMsg := fmt.Sprintf("There are no matching table names for (%s) in optimizer hint %s. Maybe you can use the table alias name",
+			hint.tblName.O, restore2IndexHint(hintName, hint))
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+	}
+}
+
+func (b *PlanBuilder) appendUnmatchedStorageHintWarning(tikvTables, tiflashTables []hintTableInfo) {
+	unMatchedTables := append(extractUnmatchedTables(tikvTables), extractUnmatchedTables(tiflashTables)...)
+	if len(unMatchedTables) == 0 {
+		return
+	}
+	errMsg := fmt.Sprintf("There are no matching table names for (%s) in optimizer hint %s. Maybe you can use the table alias name",
+		strings.Join(unMatchedTables, ", "), restore2StorageHint(tikvTables, tiflashTables))
+	b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+}
+
 func (b *PlanBuilder) appendUnmatchedJoinHintWarning(joinType string, joinTypeAlias string, hintTables []hintTableInfo) {
 	unMatchedTables := extractUnmatchedTables(hintTables)
 	if len(unMatchedTables) == 0 {
@@ -1299,6 +1413,138 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	return p, nil
 }
 
+// BuildDataSourceFromView is used to build LogicalPlan from view.
+// The select of the view is built as a sub plan, and a projection upon it
+// renames its output columns to the columns of the view.
+func (b *PlanBuilder) BuildDataSourceFromView(ctx context.Context, dbName model.CIStr, tableInfo *model.TableInfo) (LogicalPlan, error) {
+	selectNode, err := b.parseViewSelect(dbName, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer b.popBuildingView()
+
+	originalVisitInfo := b.visitInfo
+	b.visitInfo = make([]visitInfo, 0)
+	curClause := b.curClause
+	selectLogicalPlan, err := b.buildSelect(ctx, selectNode)
+	b.curClause = curClause
+	if err != nil {
+		if ErrUnknownColumn.Equal(err) {
+			err = ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+		}
+		return nil, err
+	}
+	if err = b.checkViewDefinerPrivilege(dbName, tableInfo); err != nil {
+		return nil, err
+	}
+	b.visitInfo = append(originalVisitInfo, b.visitInfo...)
+
+	outputNames := selectLogicalPlan.OutputNames()
+	if len(outputNames) < len(tableInfo.View.Cols) {
+		return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+	}
+	cols := tableInfo.Cols()
+	projSchema := expression.NewSchema(make([]*expression.Column, 0, len(tableInfo.View.Cols))...)
+	projExprs := make([]expression.Expression, 0, len(tableInfo.View.Cols))
+	projNames := make([]*types.FieldName, 0, len(tableInfo.View.Cols))
+	for i, name := range tableInfo.View.Cols {
+		origColName := outputNames[i].ColName
+		if origColName.L != name.L {
+			return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+		}
+		col := selectLogicalPlan.Schema().Columns[i]
+		projSchema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  col.GetType(),
+		})
+		projExprs = append(projExprs, col)
+		projNames = append(projNames, &types.FieldName{
+			DBName:      dbName,
+			TblName:     tableInfo.Name,
+			OrigTblName: tableInfo.Name,
+			ColName:     cols[i].Name,
+			OrigColName: origColName,
+		})
+	}
+	projUponView := LogicalProjection{Exprs: projExprs}.Init(b.ctx)
+	projUponView.names = projNames
+	projUponView.SetChildren(selectLogicalPlan)
+	projUponView.SetSchema(projSchema)
+	return projUponView, nil
+}
+
+// parseViewSelect parses the select of a view and resolves the table names
+// in it, the unqualified ones refer to the schema of the view. The view is
+// pushed to the views being built, the caller must pop it when done.
+func (b *PlanBuilder) parseViewSelect(dbName model.CIStr, tableInfo *model.TableInfo) (*ast.SelectStmt, error) {
+	if err := b.pushBuildingView(dbName, tableInfo.Name); err != nil {
+		return nil, err
+	}
+	vars := b.ctx.GetSessionVars()
+	charset, collation := vars.GetCharsetInfo()
+	viewParser := parser.New()
+	viewParser.SetSQLMode(vars.SQLMode)
+	node, err := viewParser.ParseOneStmt(tableInfo.View.SelectStmt, charset, collation)
+	if err != nil {
+		b.popBuildingView()
+		return nil, err
+	}
+	selectNode, ok := node.(*ast.SelectStmt)
+	if !ok {
+		b.popBuildingView()
+		return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+	}
+	currentDB := vars.CurrentDB
+	vars.CurrentDB = dbName.O
+	err = Preprocess(b.ctx, selectNode, b.is)
+	vars.CurrentDB = currentDB
+	if err != nil {
+		b.popBuildingView()
+		if infoschema.ErrTableNotExists.Equal(err) {
+			err = ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+		}
+		return nil, err
+	}
+	return selectNode, nil
+}
+
+// pushBuildingView records a view being expanded, a view that is met again
+// while it is being expanded refers to itself.
+func (b *PlanBuilder) pushBuildingView(dbName, viewName model.CIStr) error {
+	viewKey := dbName.L + "." + viewName.L
+	for _, key := range b.buildingViews {
+		if key == viewKey {
+			return ErrViewRecursive.GenWithStackByArgs(dbName.O, viewName.O)
+		}
+	}
+	b.buildingViews = append(b.buildingViews, viewKey)
+	return nil
+}
+
+func (b *PlanBuilder) popBuildingView() {
+	b.buildingViews = b.buildingViews[:len(b.buildingViews)-1]
+}
+
+// checkViewDefinerPrivilege checks the privileges collected when building a
+// SQL SECURITY DEFINER view against its definer, the invoker only needs the
+// privileges on the view itself. The views created by the internal sessions
+// have no definer and are checked against the invoker.
+func (b *PlanBuilder) checkViewDefinerPrivilege(dbName model.CIStr, tableInfo *model.TableInfo) error {
+	definer := tableInfo.View.Definer
+	if tableInfo.View.Security != model.SecurityDefiner || definer == nil || definer.Username == "" {
+		return nil
+	}
+	if pm := privilege.GetPrivilegeManager(b.ctx); pm != nil {
+		for _, v := range b.visitInfo {
+			if !pm.RequestVerificationWithUser(v.db, v.table, v.column, v.privilege, definer) {
+				return ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+			}
+		}
+	}
+	b.visitInfo = b.visitInfo[:0]
+	return nil
+}
+
 func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	b.handleHelper.pushMap(nil)
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx)
@@ -1360,6 +1606,12 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 
 	tableInfo := tbl.Meta()
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, dbName.L, tableInfo.Name.L, "",
+		b.tableAccessDenied("SELECT", tableInfo.Name.L))
+
+	if tableInfo.IsView() {
+		return b.BuildDataSourceFromView(ctx, dbName, tableInfo)
+	}
 
 	if tbl.Type().IsVirtualTable() {
 		return b.buildMemTable(ctx, dbName, tableInfo)
@@ -1373,6 +1625,22 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	if err != nil {
 		return nil, err
 	}
+	if hints := b.TableHints(); hints != nil {
+		alias := &hintTableInfo{dbName: dbName, tblName: tblName}
+		// TiKV is the only storage engine, so the READ_FROM_STORAGE(TIKV[...]) hint
+		// is always satisfied and TIFLASH[...] falls back to TiKV.
+		hints.ifPreferTiKV(alias)
+		if hints.ifPreferTiFlash(alias) {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"No TiFlash replica is available for table %s, read it from TiKV instead", tblName.O))
+		}
+	}
+
+	if tableInfo.GetPartitionInfo() != nil {
+		b.optFlag = b.optFlag | flagPartitionProcessor
+	} else if len(tn.PartitionNames) != 0 {
+		return nil, ErrPartitionClauseOnNonpartitioned
+	}
 
 	columns := tbl.Cols()
 	ds := DataSource{
@@ -1383,6 +1651,8 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		statisticTable:      getStatsTable(b.ctx, tbl.Meta(), tbl.Meta().ID),
 		indexHints:          tn.IndexHints,
 		possibleAccessPaths: possiblePaths,
+		physicalTableID:     tableInfo.ID,
+		partitionNames:      tn.PartitionNames,
 		Columns:             make([]*model.ColumnInfo, 0, len(columns)),
 		TblCols:             make([]*expression.Column, 0, len(columns)),
 	}.Init(b.ctx)
@@ -1437,6 +1707,18 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	ds.SetSchema(schema)
 	ds.names = names
 
+	// The virtual generated columns are not stored, they are evaluated from
+	// the other columns when the rows are read.
+	for i, colExpr := range ds.schema.Columns {
+		if i < len(columns) && columns[i].IsGenerated() && !columns[i].GeneratedStored {
+			expr, _, err := b.rewrite(ctx, columns[i].GeneratedExpr, ds, nil, true)
+			if err != nil {
+				return nil, err
+			}
+			colExpr.VirtualExpr = expr
+		}
+	}
+
 	// Init FullIdxCols, FullIdxColLens for accessPaths.
 	for _, path := range ds.possibleAccessPaths {
 		if !path.IsTablePath {
@@ -1595,6 +1877,12 @@ func extractDefaultExpr(node ast.ExprNode) *ast.DefaultExpr {
 }
 
 func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (Plan, error) {
+	// The rows of a view have no handles to delete by.
+	for _, tn := range extractTableList(delete.TableRefs.TableRefs, nil) {
+		if tn.TableInfo != nil && tn.TableInfo.IsView() {
+			return nil, ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+		}
+	}
 	p, err := b.buildResultSetNode(ctx, delete.TableRefs.TableRefs)
 	if err != nil {
 		return nil, err
@@ -1630,6 +1918,10 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 	p = proj
 
 	del := Delete{}.Init(b.ctx)
+	for _, tn := range extractTableList(delete.TableRefs.TableRefs, nil) {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, tn.Schema.L, tn.Name.L, "",
+			b.tableAccessDenied("DELETE", tn.Name.L))
+	}
 
 	del.names = p.OutputNames()
 	del.SelectPlan, err = DoOptimize(ctx, b.optFlag, p)
@@ -1649,6 +1941,20 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 	return del, err
 }
 
+// extractTableList extracts all the TableNames from node.
+func extractTableList(node ast.ResultSetNode, input []*ast.TableName) []*ast.TableName {
+	switch x := node.(type) {
+	case *ast.Join:
+		input = extractTableList(x.Left, input)
+		input = extractTableList(x.Right, input)
+	case *ast.TableSource:
+		if s, ok := x.Source.(*ast.TableName); ok {
+			input = append(input, s)
+		}
+	}
+	return input
+}
+
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]*expression.Column, schema *expression.Schema) (map[int64][]*expression.Column, error) {
 	newMap := make(map[int64][]*expression.Column, len(tblID2Handle))
 	for i, cols := range tblID2Handle {
@@ -1676,5 +1982,7 @@ func getInnerFromParenthesesAndUnaryPlus(expr ast.ExprNode) ast.ExprNode {
 // containDifferentJoinTypes checks whether `preferJoinType` contains different
 // join types.
 func containDifferentJoinTypes(preferJoinType uint) bool {
-	return bits.OnesCount(preferJoinType) > 1
+	inlMask := preferRightAsIndexInner ^ preferLeftAsIndexInner
+	onesCount := bits.OnesCount(preferJoinType & ^inlMask)
+	return onesCount > 1 || onesCount == 1 && preferJoinType&inlMask > 0
 }
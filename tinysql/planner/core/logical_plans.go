@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/property"
@@ -75,8 +76,12 @@ func (tp JoinType) String() string {
 }
 
 const (
-	preferHashJoin uint = 1 << iota
+	preferLeftAsIndexInner uint = 1 << iota
+	preferRightAsIndexInner
+	preferHashJoin
 	preferMergeJoin
+	preferHashAgg
+	preferStreamAgg
 )
 
 // LogicalJoin is the logical join plan.
@@ -138,6 +143,9 @@ type LogicalAggregation struct {
 
 	possibleProperties [][]*expression.Column
 	inputCount         float64 // inputCount is the input count of this plan.
+
+	// aggHints stores aggregation hint information.
+	aggHints aggHintInfo
 }
 
 // IsPartialModeAgg returns if all of the AggFuncs are partialMode.
@@ -181,6 +189,12 @@ type LogicalMemTable struct {
 	tableInfo *model.TableInfo
 }
 
+// LogicalUnionAll represents LogicalUnionAll plan, it reads the partitions
+// of a partitioned table one by one.
+type LogicalUnionAll struct {
+	logicalSchemaProducer
+}
+
 // LogicalUnionScan is only used in non read-only txn.
 type LogicalUnionScan struct {
 	baseLogicalPlan
@@ -210,6 +224,13 @@ type DataSource struct {
 	statisticTable *statistics.Table
 	tableStats     *property.StatsInfo
 
+	// isPartition is true if the DataSource reads a partition of the table,
+	// physicalTableID is the ID of the partition then, or the table ID otherwise.
+	isPartition     bool
+	physicalTableID int64
+	// partitionNames are the partitions selected by the PARTITION clause of the table reference.
+	partitionNames []model.CIStr
+
 	// possibleAccessPaths stores all the possible access path for physical plan, including table scan.
 	possibleAccessPaths []*util.AccessPath
 
@@ -548,14 +569,14 @@ func (ds *DataSource) TableInfo() *model.TableInfo {
 type LogicalSort struct {
 	baseLogicalPlan
 
-	ByItems []*ByItems
+	ByItems []*util.ByItems
 }
 
 // LogicalTopN represents a top-n plan.
 type LogicalTopN struct {
 	baseLogicalPlan
 
-	ByItems []*ByItems
+	ByItems []*util.ByItems
 	Offset  uint64
 	Count   uint64
 }
@@ -582,7 +603,9 @@ type ShowContents struct {
 	IndexName   model.CIStr
 	Flag        int // Some flag parsed from sql, such as FULL.
 	Full        bool
-	IfNotExists bool // Used for `show create database if not exists`.
+	IfNotExists bool                 // Used for `show create database if not exists`.
+	User        *auth.UserIdentity   // Used for show grants.
+	Roles       []*auth.RoleIdentity // Used for show grants.
 
 	GlobalScope bool // Used by show variables.
 }
@@ -20,7 +20,9 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 )
@@ -36,6 +38,7 @@ const (
 	flagMaxMinEliminate
 	flagPredicatePushDown
 	flagEliminateOuterJoin
+	flagPartitionProcessor
 	flagPushDownAgg
 	flagPushDownTopN
 	flagJoinReOrder
@@ -49,6 +52,7 @@ var optRuleList = []logicalOptRule{
 	&maxMinEliminator{},
 	&ppdSolver{},
 	&outerJoinEliminator{},
+	&partitionProcessor{},
 	&aggregationPushDownSolver{},
 	&pushDownTopNOptimizer{},
 	&joinReOrderSolver{},
@@ -72,6 +76,19 @@ func BuildLogicalPlan(ctx context.Context, sctx sessionctx.Context, node ast.Nod
 	return p, p.OutputNames(), err
 }
 
+// CheckPrivilege checks the privilege for a user.
+func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs []visitInfo) error {
+	for _, v := range vs {
+		if !pm.RequestVerification(activeRoles, v.db, v.table, v.column, v.privilege) {
+			if v.err == nil {
+				return ErrPrivilegeCheckFail
+			}
+			return v.err
+		}
+	}
+	return nil
+}
+
 // DoOptimize optimizes a logical plan to a physical plan.
 func DoOptimize(ctx context.Context, flag uint64, logic LogicalPlan) (PhysicalPlan, error) {
 	logic, err := logicalOptimize(ctx, flag, logic)
@@ -135,4 +152,5 @@ func physicalOptimize(logic LogicalPlan) (PhysicalPlan, error) {
 
 func init() {
 	expression.EvalAstExpr = evalAstExpr
+	expression.RewriteAstExpr = rewriteAstExpr
 }
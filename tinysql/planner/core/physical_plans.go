@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/ranger"
@@ -37,8 +38,10 @@ var (
 	_ PhysicalPlan = &PhysicalIndexReader{}
 	_ PhysicalPlan = &PhysicalIndexLookUpReader{}
 	_ PhysicalPlan = &PhysicalHashAgg{}
+	_ PhysicalPlan = &PhysicalStreamAgg{}
 	_ PhysicalPlan = &PhysicalHashJoin{}
 	_ PhysicalPlan = &PhysicalMergeJoin{}
+	_ PhysicalPlan = &PhysicalIndexJoin{}
 	_ PhysicalPlan = &PhysicalUnionScan{}
 )
 
@@ -92,7 +95,7 @@ func (p *PhysicalIndexReader) SetSchema(_ *expression.Schema) {
 	if p.indexPlan != nil {
 		p.IndexPlans = flattenPushDownPlan(p.indexPlan)
 		switch p.indexPlan.(type) {
-		case *PhysicalHashAgg:
+		case *PhysicalHashAgg, *PhysicalStreamAgg:
 			p.schema = p.indexPlan.Schema()
 		default:
 			is := p.IndexPlans[0].(*PhysicalIndexScan)
@@ -139,10 +142,18 @@ type PhysicalIndexScan struct {
 
 	TableAsName *model.CIStr
 
+	// isPartition is true if the index scan reads a partition, physicalTableID
+	// is the ID of the partition then.
+	isPartition     bool
+	physicalTableID int64
+
 	// dataSourceSchema is the original schema of DataSource. The schema of index scan in KV and index reader in TiDB
 	// will be different. The schema of index scan will decode all columns of index but the TiDB only need some of them.
 	dataSourceSchema *expression.Schema
 
+	// rangeInfo is used for index join. It records the columns that decide the ranges at runtime.
+	rangeInfo string
+
 	Desc      bool
 	KeepOrder bool
 	// DoubleRead means if the index executor will read kv two times.
@@ -150,6 +161,11 @@ type PhysicalIndexScan struct {
 	DoubleRead bool
 }
 
+// IsPartition returns true and partition ID if it works on a partition.
+func (p *PhysicalIndexScan) IsPartition() (bool, int64) {
+	return p.isPartition, p.physicalTableID
+}
+
 // PhysicalMemTable reads memory table.
 type PhysicalMemTable struct {
 	physicalSchemaProducer
@@ -175,13 +191,26 @@ type PhysicalTableScan struct {
 
 	TableAsName *model.CIStr
 
+	// isPartition is true if the table scan reads a partition, physicalTableID
+	// is the ID of the partition then.
+	isPartition     bool
+	physicalTableID int64
+
 	HandleIdx int
 
+	// rangeDecidedBy is used for index join. The ranges of the inner table scan are decided by these outer columns.
+	rangeDecidedBy []*expression.Column
+
 	// KeepOrder is true, if sort data by scanning pkcol,
 	KeepOrder bool
 	Desc      bool
 }
 
+// IsPartition returns true and partition ID if it's actually a partition.
+func (ts *PhysicalTableScan) IsPartition() (bool, int64) {
+	return ts.isPartition, ts.physicalTableID
+}
+
 // PhysicalProjection is the physical operator of projection.
 type PhysicalProjection struct {
 	physicalSchemaProducer
@@ -193,7 +222,7 @@ type PhysicalProjection struct {
 type PhysicalTopN struct {
 	basePhysicalPlan
 
-	ByItems []*ByItems
+	ByItems []*util.ByItems
 	Offset  uint64
 	Count   uint64
 }
@@ -243,6 +272,20 @@ func NewPhysicalHashJoin(p *LogicalJoin, innerIdx int, newStats *property.StatsI
 	return hashJoin
 }
 
+// PhysicalIndexJoin represents the plan of index look up join.
+type PhysicalIndexJoin struct {
+	basePhysicalJoin
+
+	innerTask task
+
+	// Ranges stores the IndexRanges when the inner plan is index scan.
+	Ranges []*ranger.Range
+	// KeyOff2IdxOff maps the offsets in join key to the offsets in the index.
+	KeyOff2IdxOff []int
+	// IdxColLens stores the length of each index column.
+	IdxColLens []int
+}
+
 // PhysicalMergeJoin represents merge join implementation of LogicalJoin.
 type PhysicalMergeJoin struct {
 	basePhysicalJoin
@@ -280,6 +323,15 @@ func (p *basePhysicalAgg) getAggFuncCostFactor() (factor float64) {
 	return
 }
 
+func (p *basePhysicalAgg) numDistinctFunc() (num int) {
+	for _, fun := range p.AggFuncs {
+		if fun.HasDistinct {
+			num++
+		}
+	}
+	return
+}
+
 // PhysicalHashAgg is hash operator of aggregate.
 type PhysicalHashAgg struct {
 	basePhysicalAgg
@@ -294,11 +346,16 @@ func NewPhysicalHashAgg(la *LogicalAggregation, newStats *property.StatsInfo, pr
 	return agg
 }
 
+// PhysicalStreamAgg is stream operator of aggregate.
+type PhysicalStreamAgg struct {
+	basePhysicalAgg
+}
+
 // PhysicalSort is the physical operator of sort, which implements a memory sort.
 type PhysicalSort struct {
 	basePhysicalPlan
 
-	ByItems []*ByItems
+	ByItems []*util.ByItems
 }
 
 // NominalSort asks sort properties for its child. It is a fake operator that will not
@@ -307,6 +364,11 @@ type NominalSort struct {
 	basePhysicalPlan
 }
 
+// PhysicalUnionAll is the physical operator of UnionAll.
+type PhysicalUnionAll struct {
+	physicalSchemaProducer
+}
+
 // PhysicalUnionScan represents a union scan operator.
 type PhysicalUnionScan struct {
 	basePhysicalPlan
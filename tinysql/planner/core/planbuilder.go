@@ -20,9 +20,12 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -35,9 +38,19 @@ import (
 )
 
 type tableHintInfo struct {
-	sortMergeJoinTables []hintTableInfo
-	hashJoinTables      []hintTableInfo
-	indexHintList       []indexHintInfo
+	indexNestedLoopJoinTables []hintTableInfo
+	sortMergeJoinTables       []hintTableInfo
+	hashJoinTables            []hintTableInfo
+	indexHintList             []indexHintInfo
+	leadingJoinOrder          []hintTableInfo
+	tikvTables                []hintTableInfo
+	tiflashTables             []hintTableInfo
+	aggHints                  aggHintInfo
+}
+
+type aggHintInfo struct {
+	preferAggType  uint
+	preferAggToCop bool
 }
 
 type hintTableInfo struct {
@@ -46,10 +59,19 @@ type hintTableInfo struct {
 	matched bool
 }
 
+type visitInfo struct {
+	privilege mysql.PrivilegeType
+	db        string
+	table     string
+	column    string
+	err       error
+}
+
 type indexHintInfo struct {
 	dbName    model.CIStr
 	tblName   model.CIStr
 	indexHint *ast.IndexHint
+	matched   bool
 }
 
 func tableNames2HintTableInfo(ctx sessionctx.Context, hintTables []ast.HintTable) []hintTableInfo {
@@ -72,10 +94,22 @@ func (info *tableHintInfo) ifPreferMergeJoin(tableNames ...*hintTableInfo) bool
 	return info.matchTableName(tableNames, info.sortMergeJoinTables)
 }
 
+func (info *tableHintInfo) ifPreferINLJ(tableNames ...*hintTableInfo) bool {
+	return info.matchTableName(tableNames, info.indexNestedLoopJoinTables)
+}
+
 func (info *tableHintInfo) ifPreferHashJoin(tableNames ...*hintTableInfo) bool {
 	return info.matchTableName(tableNames, info.hashJoinTables)
 }
 
+func (info *tableHintInfo) ifPreferTiKV(tableName *hintTableInfo) bool {
+	return info.matchTableName([]*hintTableInfo{tableName}, info.tikvTables)
+}
+
+func (info *tableHintInfo) ifPreferTiFlash(tableName *hintTableInfo) bool {
+	return info.matchTableName([]*hintTableInfo{tableName}, info.tiflashTables)
+}
+
 // matchTableName checks whether the hint hit the need.
 // Only need either side matches one on the list.
 // Even though you can put 2 tables on the list,
@@ -115,6 +149,50 @@ func restore2JoinHint(hintType string, hintTables []hintTableInfo) string {
 	return buffer.String()
 }
 
+func restore2IndexHint(hintType string, hintIndex indexHintInfo) string {
+	buffer := bytes.NewBufferString("/*+ ")
+	buffer.WriteString(strings.ToUpper(hintType))
+	buffer.WriteString("(")
+	buffer.WriteString(hintIndex.tblName.L)
+	for _, indexName := range hintIndex.indexHint.IndexNames {
+		buffer.WriteString(", ")
+		buffer.WriteString(indexName.L)
+	}
+	buffer.WriteString(") */")
+	return buffer.String()
+}
+
+func restore2StorageHint(tikvTables, tiflashTables []hintTableInfo) string {
+	buffer := bytes.NewBufferString("/*+ ")
+	buffer.WriteString(strings.ToUpper(HintReadFromStorage))
+	buffer.WriteString("(")
+	if len(tikvTables) > 0 {
+		buffer.WriteString("tikv[")
+		for i, table := range tikvTables {
+			buffer.WriteString(table.tblName.L)
+			if i < len(tikvTables)-1 {
+				buffer.WriteString(", ")
+			}
+		}
+		buffer.WriteString("]")
+		if len(tiflashTables) > 0 {
+			buffer.WriteString(", ")
+		}
+	}
+	if len(tiflashTables) > 0 {
+		buffer.WriteString("tiflash[")
+		for i, table := range tiflashTables {
+			buffer.WriteString(table.tblName.L)
+			if i < len(tiflashTables)-1 {
+				buffer.WriteString(", ")
+			}
+		}
+		buffer.WriteString("]")
+	}
+	buffer.WriteString(") */")
+	return buffer.String()
+}
+
 func extractUnmatchedTables(hintTables []hintTableInfo) []string {
 	var tableNames []string
 	for _, table := range hintTables {
@@ -166,6 +244,9 @@ type PlanBuilder struct {
 
 	curClause clauseCode
 
+	// visitInfo is used for privilege check.
+	visitInfo []visitInfo
+
 	// rewriterPool stores the expressionRewriter we have created to reuse it if it has been released.
 	// rewriterCounter counts how many rewriter is being used.
 	rewriterPool    []*expressionRewriter
@@ -184,6 +265,10 @@ type PlanBuilder struct {
 	//   If it's a join, we pop its children's out then merge them and push the new map to stack.
 	//   If we meet a subquery, it's clearly that it's a independent problem so we just pop one map out when we finish building the subquery.
 	handleHelper *handleColHelper
+
+	// buildingViews records the views being expanded, it is used to detect
+	// the view recursion.
+	buildingViews []string
 }
 
 type handleColHelper struct {
@@ -229,6 +314,11 @@ func (b *PlanBuilder) GetOptFlag() uint64 {
 	return b.optFlag
 }
 
+// GetVisitInfo gets the visitInfo of the PlanBuilder.
+func (b *PlanBuilder) GetVisitInfo() []visitInfo {
+	return b.visitInfo
+}
+
 // NewPlanBuilder creates a new PlanBuilder.
 func NewPlanBuilder(sctx sessionctx.Context, is infoschema.InfoSchema) *PlanBuilder {
 	return &PlanBuilder{
@@ -245,12 +335,18 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 	switch x := node.(type) {
 	case *ast.AdminStmt:
 		return b.buildAdmin(ctx, x)
+	case *ast.DeallocateStmt:
+		return &Deallocate{Name: x.Name}, nil
 	case *ast.DeleteStmt:
 		return b.buildDelete(ctx, x)
+	case *ast.ExecuteStmt:
+		return b.buildExecute(ctx, x)
 	case *ast.ExplainStmt:
 		return b.buildExplain(ctx, x)
 	case *ast.InsertStmt:
 		return b.buildInsert(ctx, x)
+	case *ast.PrepareStmt:
+		return b.buildPrepare(x), nil
 	case *ast.SelectStmt:
 		return b.buildSelect(ctx, x)
 	case *ast.ShowStmt:
@@ -259,7 +355,13 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		return b.buildSet(ctx, x)
 	case *ast.AnalyzeTableStmt:
 		return b.buildAnalyze(x)
-	case *ast.UseStmt, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+	case *ast.CreateBindingStmt:
+		return b.buildCreateBindPlan(x)
+	case *ast.DropBindingStmt:
+		return b.buildDropBindPlan(x)
+	case *ast.UseStmt, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateUserStmt,
+		*ast.AlterUserStmt, *ast.DropUserStmt, *ast.SetPwdStmt, *ast.GrantStmt, *ast.RevokeStmt,
+		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt:
 		return b.buildSimple(node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -267,6 +369,77 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 	return nil, ErrUnsupportedType.GenWithStack("Unsupported type %T", node)
 }
 
+func (b *PlanBuilder) buildCreateBindPlan(v *ast.CreateBindingStmt) (Plan, error) {
+	charSet, collation := b.ctx.GetSessionVars().GetCharsetInfo()
+	normdOrigSQL := parser.Normalize(v.OriginSel.Text())
+	if normdHintedSQL := parser.Normalize(v.HintedSel.Text()); normdOrigSQL != normdHintedSQL {
+		return nil, errors.Errorf("hinted sql and original sql don't match when hinted sql erase the hint info, after erase hint info, originSQL:%s, hintedSQL:%s",
+			normdOrigSQL, normdHintedSQL)
+	}
+	p := &SQLBindPlan{
+		SQLBindOp:    OpSQLBindCreate,
+		NormdOrigSQL: normdOrigSQL,
+		BindSQL:      v.HintedSel.Text(),
+		IsGlobal:     v.GlobalScope,
+		BindStmt:     v.HintedSel,
+		Db:           b.ctx.GetSessionVars().CurrentDB,
+		Charset:      charSet,
+		Collation:    collation,
+	}
+	if v.GlobalScope {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
+	}
+	return p, nil
+}
+
+func (b *PlanBuilder) buildDropBindPlan(v *ast.DropBindingStmt) (Plan, error) {
+	p := &SQLBindPlan{
+		SQLBindOp:    OpSQLBindDrop,
+		NormdOrigSQL: parser.Normalize(v.OriginSel.Text()),
+		IsGlobal:     v.GlobalScope,
+		Db:           b.ctx.GetSessionVars().CurrentDB,
+	}
+	if v.GlobalScope {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
+	}
+	return p, nil
+}
+
+func (b *PlanBuilder) buildExecute(ctx context.Context, v *ast.ExecuteStmt) (Plan, error) {
+	vars := make([]expression.Expression, 0, len(v.UsingVars))
+	for _, expr := range v.UsingVars {
+		newExpr, _, err := b.rewrite(ctx, expr, nil, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, newExpr)
+	}
+	exe := &Execute{Name: v.Name, UsingVars: vars, ExecID: v.ExecID}
+	if v.BinaryArgs != nil {
+		exe.PrepareParams = v.BinaryArgs.([]types.Datum)
+	}
+	return exe, nil
+}
+
+func (b *PlanBuilder) buildPrepare(x *ast.PrepareStmt) Plan {
+	p := &Prepare{
+		Name: x.Name,
+	}
+	if x.SQLVar != nil {
+		sessionVars := b.ctx.GetSessionVars()
+		sessionVars.UsersLock.RLock()
+		if v, ok := sessionVars.Users[strings.ToLower(x.SQLVar.Name)]; ok {
+			p.SQLText = v
+		} else {
+			p.SQLText = "NULL"
+		}
+		sessionVars.UsersLock.RUnlock()
+	} else {
+		p.SQLText = x.SQLText
+	}
+	return p
+}
+
 func (b *PlanBuilder) buildSet(ctx context.Context, v *ast.SetStmt) (Plan, error) {
 	p := &Set{}
 	for _, vars := range v.Variables {
@@ -275,6 +448,9 @@ func (b *PlanBuilder) buildSet(ctx context.Context, v *ast.SetStmt) (Plan, error
 			IsGlobal: vars.IsGlobal,
 			IsSystem: vars.IsSystem,
 		}
+		if vars.IsGlobal && vars.IsSystem {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
+		}
 		if _, ok := vars.Value.(*ast.DefaultExpr); !ok {
 			if cn, ok2 := vars.Value.(*ast.ColumnNameExpr); ok2 && cn.Name.Table.L == "" {
 				// Convert column name expression to string value expression.
@@ -357,9 +533,10 @@ func (b *PlanBuilder) getPossibleAccessPaths(indexHints []*ast.IndexHint, tbl ta
 	// Extract comment-style index hint like /*+ INDEX(t, idx1, idx2) */.
 	indexHintsLen := len(indexHints)
 	if hints := b.TableHints(); hints != nil {
-		for _, hint := range hints.indexHintList {
+		for i, hint := range hints.indexHintList {
 			if hint.dbName.L == dbName.L && hint.tblName.L == tblName.L {
 				indexHints = append(indexHints, hint.indexHint)
+				hints.indexHintList[i].matched = true
 			}
 		}
 	}
@@ -456,6 +633,9 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 	default:
 		return nil, ErrUnsupportedType.GenWithStack("Unsupported ast.AdminStmt(%T) for buildAdmin", as)
 	}
+
+	// Admin command can only be executed by administrator.
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
 	return ret, nil
 }
 
@@ -463,6 +643,10 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 func getColsInfo(tn *ast.TableName) (indicesInfo []*model.IndexInfo, colsInfo []*model.ColumnInfo, pkCol *model.ColumnInfo) {
 	tbl := tn.TableInfo
 	for _, col := range tbl.Columns {
+		// The virtual generated columns are not stored, we skip them.
+		if col.IsGenerated() && !col.GeneratedStored {
+			continue
+		}
 		if tbl.PKIsHandle && mysql.HasPriKeyFlag(col.Flag) {
 			pkCol = col
 		} else {
@@ -480,28 +664,50 @@ func getColsInfo(tn *ast.TableName) (indicesInfo []*model.IndexInfo, colsInfo []
 func (b *PlanBuilder) buildAnalyze(as *ast.AnalyzeTableStmt) (Plan, error) {
 	p := &Analyze{}
 	for _, tbl := range as.TableNames {
+		if tbl.TableInfo.IsView() {
+			return nil, ddl.ErrWrongObject.GenWithStackByArgs(tbl.Schema.O, tbl.Name.O, "BASE TABLE")
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tbl.Schema.L, tbl.Name.L, "",
+			b.tableAccessDenied("INSERT", tbl.Name.L))
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, tbl.Schema.L, tbl.Name.L, "",
+			b.tableAccessDenied("SELECT", tbl.Name.L))
 		idxInfo, colInfo, pkInfo := getColsInfo(tbl)
-		for _, idx := range idxInfo {
-			info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: tbl.TableInfo.ID}
-			p.IdxTasks = append(p.IdxTasks, AnalyzeIndexTask{
-				IndexInfo:   idx,
-				analyzeInfo: info,
-				TblInfo:     tbl.TableInfo,
-			})
-		}
-		if len(colInfo) > 0 || pkInfo != nil {
-			info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: tbl.TableInfo.ID}
-			p.ColTasks = append(p.ColTasks, AnalyzeColumnsTask{
-				PKInfo:      pkInfo,
-				ColsInfo:    colInfo,
-				analyzeInfo: info,
-				TblInfo:     tbl.TableInfo,
-			})
+		// A partitioned table is analyzed partition by partition.
+		for _, physicalID := range getPhysicalIDs(tbl.TableInfo) {
+			for _, idx := range idxInfo {
+				info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: physicalID}
+				p.IdxTasks = append(p.IdxTasks, AnalyzeIndexTask{
+					IndexInfo:   idx,
+					analyzeInfo: info,
+					TblInfo:     tbl.TableInfo,
+				})
+			}
+			if len(colInfo) > 0 || pkInfo != nil {
+				info := analyzeInfo{DBName: tbl.Schema.O, TableName: tbl.Name.O, PhysicalTableID: physicalID}
+				p.ColTasks = append(p.ColTasks, AnalyzeColumnsTask{
+					PKInfo:      pkInfo,
+					ColsInfo:    colInfo,
+					analyzeInfo: info,
+					TblInfo:     tbl.TableInfo,
+				})
+			}
 		}
 	}
 	return p, nil
 }
 
+func getPhysicalIDs(tblInfo *model.TableInfo) []int64 {
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		return []int64{tblInfo.ID}
+	}
+	ids := make([]int64, 0, len(pi.Definitions))
+	for _, def := range pi.Definitions {
+		ids = append(ids, def.ID)
+	}
+	return ids
+}
+
 func buildShowDDLFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(6)
 	schema.Append(buildColumnWithName("", "SCHEMA_VER", mysql.TypeLonglong, 4))
@@ -603,6 +809,8 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			Flag:        show.Flag,
 			Full:        show.Full,
 			IfNotExists: show.IfNotExists,
+			User:        show.User,
+			Roles:       show.Roles,
 			GlobalScope: show.GlobalScope,
 		},
 	}.Init(b.ctx)
@@ -611,6 +819,21 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 		if p.DBName == "" {
 			return nil, ErrNoDB
 		}
+	case ast.ShowCreateTable:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AllPrivMask, show.Table.Schema.L, show.Table.Name.L, "",
+			b.tableAccessDenied("SHOW", show.Table.Name.L))
+	case ast.ShowCreateView:
+		if !show.Table.TableInfo.IsView() {
+			return nil, ddl.ErrWrongObject.GenWithStackByArgs(show.Table.Schema.O, show.Table.Name.O, "VIEW")
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, show.Table.Schema.L, show.Table.Name.L, "",
+			b.tableAccessDenied("SELECT", show.Table.Name.L))
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShowViewPriv, show.Table.Schema.L, show.Table.Name.L, "",
+			b.tableAccessDenied("SHOW VIEW", show.Table.Name.L))
+	case ast.ShowGrants:
+		if show.User != nil && !show.User.CurrentUser && !b.isCurrentUser(show.User) {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, mysql.SystemDB, "", "", nil)
+		}
 	}
 	schema, names := buildShowSchema(show)
 	p.SetSchema(schema)
@@ -654,10 +877,134 @@ func (b *PlanBuilder) buildSimple(node ast.StmtNode) (Plan, error) {
 		if raw.DBName == "" {
 			return nil, ErrNoDB
 		}
+	case *ast.CreateUserStmt:
+		if raw.IsCreateRole {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRolePriv|mysql.CreateUserPriv, "", "", "",
+				ErrSpecificAccessDenied.GenWithStackByArgs("CREATE ROLE or CREATE USER"))
+			break
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER"))
+	case *ast.DropUserStmt:
+		if raw.IsDropRole {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropRolePriv|mysql.CreateUserPriv, "", "", "",
+				ErrSpecificAccessDenied.GenWithStackByArgs("DROP ROLE or CREATE USER"))
+			break
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER"))
+	case *ast.AlterUserStmt:
+		if raw.CurrentAuth != nil {
+			// Everyone can change the password of the current user.
+			break
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER"))
+	case *ast.SetPwdStmt:
+		if raw.User != nil && !raw.User.CurrentUser && !b.isCurrentUser(raw.User) {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER"))
+		}
+	case *ast.GrantStmt:
+		b.visitInfo = b.collectVisitInfoFromGrantStmt(b.visitInfo, raw.Privs, raw.Level)
+	case *ast.RevokeStmt:
+		b.visitInfo = b.collectVisitInfoFromGrantStmt(b.visitInfo, raw.Privs, raw.Level)
+	case *ast.GrantRoleStmt, *ast.RevokeRoleStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
+	case *ast.SetDefaultRoleStmt:
+		// Everyone can set the default roles of the current user.
+		for _, user := range raw.UserList {
+			if !user.CurrentUser && !b.isCurrentUser(user) {
+				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateUserPriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("CREATE USER"))
+				break
+			}
+		}
 	}
 	return p, nil
 }
 
+// collectVisitInfoFromGrantStmt collects the privileges needed by GRANT and
+// REVOKE. To use them, you must have the GRANT OPTION privilege, and you must
+// have the privileges that you are granting or revoking.
+func (b *PlanBuilder) collectVisitInfoFromGrantStmt(vi []visitInfo, privs []*ast.PrivElem, level *ast.GrantLevel) []visitInfo {
+	dbName, tableName := level.DBName, level.TableName
+	if dbName == "" && level.Level != ast.GrantLevelGlobal {
+		dbName = b.ctx.GetSessionVars().CurrentDB
+	}
+	appendPriv := func(priv mysql.PrivilegeType) {
+		vi = appendVisitInfo(vi, priv, dbName, tableName, "", b.grantAccessDenied(priv, level.Level, dbName, tableName))
+	}
+	appendPriv(mysql.GrantPriv)
+
+	for _, item := range privs {
+		if item.Priv != mysql.AllPriv {
+			appendPriv(item.Priv)
+			continue
+		}
+		var allPrivs []mysql.PrivilegeType
+		switch level.Level {
+		case ast.GrantLevelGlobal:
+			allPrivs = mysql.AllGlobalPrivs
+		case ast.GrantLevelDB:
+			allPrivs = mysql.AllDBPrivs
+		case ast.GrantLevelTable:
+			allPrivs = mysql.AllTablePrivs
+		}
+		for _, priv := range allPrivs {
+			appendPriv(priv)
+		}
+	}
+	return vi
+}
+
+// grantAccessDenied returns the error reported when the current user can't
+// grant or revoke the privilege on the level.
+func (b *PlanBuilder) grantAccessDenied(priv mysql.PrivilegeType, level ast.GrantLevelType, db, table string) error {
+	switch level {
+	case ast.GrantLevelTable:
+		cmd := "GRANT"
+		if priv != mysql.GrantPriv {
+			cmd = strings.ToUpper(mysql.Priv2Str[priv])
+		}
+		return b.tableAccessDenied(cmd, table)
+	case ast.GrantLevelDB:
+		return b.dbAccessDenied(db)
+	}
+	return ErrSpecificAccessDenied.GenWithStackByArgs(strings.ToUpper(mysql.Priv2Str[priv]))
+}
+
+// isCurrentUser checks whether the user is the one the session logged in as.
+func (b *PlanBuilder) isCurrentUser(user *auth.UserIdentity) bool {
+	current := b.ctx.GetSessionVars().User
+	return current != nil && current.AuthUsername == user.Username && current.AuthHostname == user.Hostname
+}
+
+// tableAccessDenied returns the error reported when the current user has no
+// privilege to run the command on the table.
+func (b *PlanBuilder) tableAccessDenied(cmd, table string) error {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return nil
+	}
+	return ErrTableaccessDenied.GenWithStackByArgs(cmd, user.Username, user.Hostname, table)
+}
+
+// dbAccessDenied returns the error reported when the current user has no
+// privilege on the database.
+func (b *PlanBuilder) dbAccessDenied(db string) error {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return nil
+	}
+	return ErrDBaccessDenied.GenWithStackByArgs(user.Username, user.Hostname, db)
+}
+
+func appendVisitInfo(vi []visitInfo, priv mysql.PrivilegeType, db, tbl, col string, err error) []visitInfo {
+	return append(vi, visitInfo{
+		privilege: priv,
+		db:        db,
+		table:     tbl,
+		column:    col,
+		err:       err,
+	})
+}
+
 func (b *PlanBuilder) getDefaultValue(col *table.Column) (*expression.Constant, error) {
 	value, err := table.GetColDefaultValue(b.ctx, col.ToInfo())
 	if err != nil {
@@ -684,6 +1031,15 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if !ok {
 		return nil, infoschema.ErrTableNotExists.GenWithStackByArgs()
 	}
+	b.appendInsertVisitInfo(tn, insert.IsReplace)
+	var viewChecks []*viewCondition
+	if tn.TableInfo.IsView() {
+		var err error
+		insert, tn, viewChecks, err = b.resolveInsertIntoView(insert, tn)
+		if err != nil {
+			return nil, err
+		}
+	}
 	tableInfo := tn.TableInfo
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names := expression.TableInfo2SchemaAndNames(b.ctx, tn.Schema, tableInfo)
@@ -735,10 +1091,96 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 	}
 
-	err := insertPlan.ResolveIndices()
+	// Calculate generated columns.
+	var err error
+	insertPlan.GenCols, err = b.resolveGeneratedColumns(ctx, insertPlan.Table.Cols(), mockTablePlan)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, check := range viewChecks {
+		expr, _, err := b.rewrite(ctx, check.expr, mockTablePlan, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		insertPlan.ViewChecks = append(insertPlan.ViewChecks, &ViewCheck{
+			DBName:   check.dbName,
+			ViewName: check.viewName,
+			Expr:     expr,
+		})
+	}
+
+	insertPlan.CheckConstraints, err = b.buildCheckConstraints(ctx, tableInfo, mockTablePlan)
+	if err != nil {
+		return nil, err
+	}
+
+	err = insertPlan.ResolveIndices()
 	return insertPlan, err
 }
 
+// buildCheckConstraints builds the enforced CHECK constraints of the table, the constraints
+// being added are enforced too, so that the existing rows can be verified later.
+func (b *PlanBuilder) buildCheckConstraints(ctx context.Context, tableInfo *model.TableInfo, mockPlan LogicalPlan) ([]*CheckConstraint, error) {
+	var constraints []*CheckConstraint
+	for _, constraint := range tableInfo.Constraints {
+		if !constraint.Enforced || constraint.State == model.StateNone {
+			continue
+		}
+		node, err := expression.ParseSimpleExpr(constraint.ExprString)
+		if err != nil {
+			return nil, err
+		}
+		expr, _, err := b.rewrite(ctx, node, mockPlan, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, &CheckConstraint{
+			Name: constraint.Name,
+			Expr: expr,
+		})
+	}
+	return constraints, nil
+}
+
+// resolveGeneratedColumns resolves generated columns with their generation
+// expressions respectively.
+func (b *PlanBuilder) resolveGeneratedColumns(ctx context.Context, columns []*table.Column, mockPlan LogicalPlan) (igc InsertGeneratedColumns, err error) {
+	for _, column := range columns {
+		if !column.IsGenerated() {
+			continue
+		}
+		columnName := &ast.ColumnName{Name: column.Name}
+		columnName.SetText(column.Name.O)
+
+		idx, err := expression.FindFieldName(mockPlan.OutputNames(), columnName)
+		if err != nil {
+			return igc, err
+		}
+		colExpr := mockPlan.Schema().Columns[idx]
+
+		expr, _, err := b.rewrite(ctx, column.GeneratedExpr, mockPlan, nil, true)
+		if err != nil {
+			return igc, err
+		}
+		expr = expression.BuildCastFunction(b.ctx, expr, colExpr.GetType())
+
+		igc.Columns = append(igc.Columns, columnName)
+		igc.Exprs = append(igc.Exprs, expr)
+	}
+	return igc, nil
+}
+
+func (b *PlanBuilder) appendInsertVisitInfo(tn *ast.TableName, isReplace bool) {
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tn.Schema.L, tn.Name.L, "",
+		b.tableAccessDenied("INSERT", tn.Name.L))
+	// REPLACE deletes the old rows which conflict with the new ones.
+	if isReplace {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, tn.Schema.L, tn.Name.L, "",
+			b.tableAccessDenied("DELETE", tn.Name.L))
+	}
+}
+
 func (b *PlanBuilder) getAffectCols(insertStmt *ast.InsertStmt, insertPlan *Insert) (affectedValuesCols []*table.Column, err error) {
 	if len(insertStmt.Columns) > 0 {
 		// This branch is for the following scenarios:
@@ -777,12 +1219,32 @@ func (b *PlanBuilder) buildSetValuesOfInsert(ctx context.Context, insert *ast.In
 		exprCols = append(exprCols, insertPlan.tableSchema.Columns[idx])
 	}
 
+	// Check whether the column to be updated is the generated column.
+	tCols, err := table.FindCols(insertPlan.Table.Cols(), colNames, insertPlan.Table.Meta().PKIsHandle)
+	if err != nil {
+		return err
+	}
+	generatedColumns := make(map[string]struct{}, len(tCols))
+	for _, tCol := range tCols {
+		if tCol.IsGenerated() {
+			generatedColumns[tCol.Name.L] = struct{}{}
+		}
+	}
+
 	insertPlan.AllAssignmentsAreConstant = true
 	for i, assign := range insert.Setlist {
 		defaultExpr := extractDefaultExpr(assign.Expr)
 		if defaultExpr != nil {
 			defaultExpr.Name = assign.Column
 		}
+		// Note: For INSERT, REPLACE, and UPDATE, if a generated column is inserted into, replaced, or updated explicitly, the only permitted value is DEFAULT.
+		// see https://dev.mysql.com/doc/refman/8.0/en/create-table-generated-columns.html
+		if _, ok := generatedColumns[assign.Column.Name.L]; ok {
+			if defaultExpr != nil {
+				continue
+			}
+			return ErrBadGeneratedColumn.GenWithStackByArgs(assign.Column.Name.O, insertPlan.Table.Meta().Name.O)
+		}
 		expr, _, err := b.rewriteWithPreprocess(ctx, assign.Expr, mockTablePlan, nil, true, checkRefColumn)
 		if err != nil {
 			return err
@@ -832,6 +1294,16 @@ func (b *PlanBuilder) buildValuesListOfInsert(ctx context.Context, insert *ast.I
 		for j, valueItem := range valuesItem {
 			var expr expression.Expression
 			var err error
+			col := affectedValuesCols[j]
+			if col.IsGenerated() {
+				// Note: For INSERT, REPLACE, and UPDATE, if a generated column is inserted into, replaced, or updated explicitly, the only permitted value is DEFAULT.
+				// The value is computed by the executor, so a NULL placeholder is kept here.
+				if x, ok := valueItem.(*ast.DefaultExpr); !ok || x.Name != nil {
+					return ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, insertPlan.Table.Meta().Name.O)
+				}
+				exprList = append(exprList, expression.Null.Clone())
+				continue
+			}
 			switch x := valueItem.(type) {
 			case *ast.DefaultExpr:
 				if x.Name != nil {
@@ -875,6 +1347,11 @@ func (b *PlanBuilder) buildSelectPlanOfInsert(ctx context.Context, insert *ast.I
 	if selectPlan.Schema().Len() != len(affectedValuesCols) {
 		return ErrWrongValueCountOnRow.GenWithStackByArgs(1)
 	}
+	for _, col := range affectedValuesCols {
+		if col.IsGenerated() {
+			return ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, insertPlan.Table.Meta().Name.O)
+		}
+	}
 
 	names := selectPlan.OutputNames()
 	insertPlan.SelectPlan, err = DoOptimize(ctx, b.optFlag, selectPlan.(LogicalPlan))
@@ -907,14 +1384,307 @@ func (b *PlanBuilder) buildSelectPlanOfInsert(ctx context.Context, insert *ast.I
 }
 
 func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, error) {
+	switch v := node.(type) {
+	case *ast.AlterTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("ALTER", v.Table.Name.L))
+	case *ast.CreateDatabaseStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name, "", "", b.dbAccessDenied(v.Name))
+	case *ast.CreateIndexStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.IndexPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("INDEX", v.Table.Name.L))
+	case *ast.CreateTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("CREATE", v.Table.Name.L))
+		if v.ReferTable != nil {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, v.ReferTable.Schema.L, v.ReferTable.Name.L, "",
+				b.tableAccessDenied("SELECT", v.ReferTable.Name.L))
+		}
+	case *ast.CreateViewStmt:
+		if err := b.buildCreateView(ctx, v); err != nil {
+			return nil, err
+		}
+	case *ast.DropDatabaseStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.Name, "", "", b.dbAccessDenied(v.Name))
+	case *ast.DropIndexStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.IndexPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("INDEX", v.Table.Name.L))
+	case *ast.DropTableStmt:
+		for _, tableVal := range v.Tables {
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, tableVal.Schema.L, tableVal.Name.L, "",
+				b.tableAccessDenied("DROP", tableVal.Name.L))
+		}
+	case *ast.TruncateTableStmt:
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.Table.Schema.L, v.Table.Name.L, "",
+			b.tableAccessDenied("DROP", v.Table.Name.L))
+	}
 	p := &DDL{Statement: node}
 	return p, nil
 }
 
-func (b *PlanBuilder) buildExplainPlan(targetPlan Plan, format string, execStmt ast.StmtNode) (Plan, error) {
+// buildCreateView builds the select of the view to check it and to name the
+// columns of the view, the select privileges of it are required as well.
+func (b *PlanBuilder) buildCreateView(ctx context.Context, v *ast.CreateViewStmt) error {
+	sel, ok := v.Select.(*ast.SelectStmt)
+	if !ok {
+		return ErrUnsupportedType.GenWithStackByArgs(v.Select)
+	}
+	// A view is not allowed to read itself through the view it replaces.
+	if err := b.pushBuildingView(v.ViewName.Schema, v.ViewName.Name); err != nil {
+		return err
+	}
+	p, err := b.buildSelect(ctx, sel)
+	b.popBuildingView()
+	if err != nil {
+		return err
+	}
+	names := p.OutputNames()
+	v.SchemaCols = make([]model.CIStr, 0, len(names))
+	for _, name := range names {
+		v.SchemaCols = append(v.SchemaCols, name.ColName)
+	}
+	if v.Cols == nil {
+		v.Cols = append([]model.CIStr(nil), v.SchemaCols...)
+	}
+	if len(v.Cols) != len(v.SchemaCols) {
+		return ddl.ErrViewWrongList
+	}
+	if v.CheckOption != model.CheckOptionNone && !util.IsUpdatableSelect(sel) {
+		return ErrViewNonupdCheck.GenWithStackByArgs(v.ViewName.Schema.O, v.ViewName.Name.O)
+	}
+
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateViewPriv, v.ViewName.Schema.L, v.ViewName.Name.L, "",
+		b.tableAccessDenied("CREATE VIEW", v.ViewName.Name.L))
+	if v.OrReplace {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L, v.ViewName.Name.L, "",
+			b.tableAccessDenied("DROP", v.ViewName.Name.L))
+	}
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		// The views created by the internal sessions have no definer.
+		if v.Definer.CurrentUser {
+			v.Definer = &auth.UserIdentity{}
+		}
+		return nil
+	}
+	current := &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	if v.Definer.CurrentUser {
+		v.Definer = current
+	} else if v.Definer.Username != current.Username || v.Definer.Hostname != current.Hostname {
+		// Only SUPER can create a view on behalf of another user.
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "",
+			ErrSpecificAccessDenied.GenWithStackByArgs("SUPER"))
+	}
+	return nil
+}
+
+// viewCondition is a WHERE condition of a view, the columns in it refer to the
+// base table the view is updated through.
+type viewCondition struct {
+	dbName   model.CIStr
+	viewName model.CIStr
+	expr     ast.ExprNode
+}
+
+// updatableView describes how an updatable view maps to its base table.
+type updatableView struct {
+	baseTable *ast.TableName
+	// colNames are the columns of the view in order, cols maps them to the
+	// expressions over the columns of the base table.
+	colNames []string
+	cols     map[string]ast.ExprNode
+	// conds are the WHERE conditions of the view and the views under it.
+	conds []*viewCondition
+	// checks are the conditions WITH CHECK OPTION enforces on the new rows.
+	checks []*viewCondition
+}
+
+// resolveUpdatableView maps the columns and the conditions of a view to the
+// base table under it, the views it is defined on are resolved recursively.
+func (b *PlanBuilder) resolveUpdatableView(dbName model.CIStr, tableInfo *model.TableInfo) (*updatableView, error) {
+	sel, err := b.parseViewSelect(dbName, tableInfo)
+	if err != nil {
+		return nil, err
+	}
+	defer b.popBuildingView()
+	if tableInfo.View.Algorithm == model.AlgorithmTemptable || !util.IsUpdatableSelect(sel) {
+		return nil, nil
+	}
+	tn := sel.From.TableRefs.Left.(*ast.TableSource).Source.(*ast.TableName)
+	var inner *updatableView
+	if tn.TableInfo.IsView() {
+		inner, err = b.resolveUpdatableView(tn.Schema, tn.TableInfo)
+		if err != nil || inner == nil {
+			return nil, err
+		}
+	} else {
+		inner = &updatableView{baseTable: tn, cols: make(map[string]ast.ExprNode)}
+		for _, col := range tn.TableInfo.Cols() {
+			inner.colNames = append(inner.colNames, col.Name.L)
+			inner.cols[col.Name.L] = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: col.Name}}
+		}
+	}
+
+	viewCols := tableInfo.Cols()
+	view := &updatableView{
+		baseTable: inner.baseTable,
+		colNames:  make([]string, 0, len(viewCols)),
+		cols:      make(map[string]ast.ExprNode, len(viewCols)),
+		conds:     inner.conds,
+	}
+	addCol := func(expr ast.ExprNode) {
+		// The columns added to the table under the view after it was created
+		// are not a part of the view.
+		if len(view.colNames) < len(viewCols) {
+			name := viewCols[len(view.colNames)].Name.L
+			view.colNames = append(view.colNames, name)
+			view.cols[name] = expr
+		}
+	}
+	for _, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			for _, name := range inner.colNames {
+				addCol(inner.cols[name])
+			}
+			continue
+		}
+		expr, err := substituteViewColumns(field.Expr, inner.cols)
+		if err != nil {
+			return nil, err
+		}
+		addCol(expr)
+	}
+	if len(view.colNames) != len(viewCols) {
+		return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+	}
+	if sel.Where != nil {
+		expr, err := substituteViewColumns(sel.Where, inner.cols)
+		if err != nil {
+			return nil, err
+		}
+		view.conds = append(append([]*viewCondition(nil), inner.conds...),
+			&viewCondition{dbName: dbName, viewName: tableInfo.Name, expr: expr})
+	}
+
+	switch tableInfo.View.CheckOption {
+	case model.CheckOptionCascaded:
+		// The conditions of the views under it are checked as well, in the
+		// name of this view.
+		for _, cond := range view.conds {
+			view.checks = append(view.checks, &viewCondition{dbName: dbName, viewName: tableInfo.Name, expr: cond.expr})
+		}
+	case model.CheckOptionLocal:
+		view.checks = inner.checks
+		if sel.Where != nil {
+			view.checks = append(append([]*viewCondition(nil), inner.checks...), view.conds[len(view.conds)-1])
+		}
+	default:
+		view.checks = inner.checks
+	}
+	return view, nil
+}
+
+// resolveInsertIntoView rewrites an insert into an updatable view to the one
+// into its base table, the statement itself is left untouched since it may be
+// built again. The privileges on the base table are required from the definer
+// of a SQL SECURITY DEFINER view and from the invoker otherwise.
+func (b *PlanBuilder) resolveInsertIntoView(insert *ast.InsertStmt, tn *ast.TableName) (*ast.InsertStmt, *ast.TableName, []*viewCondition, error) {
+	view, err := b.resolveUpdatableView(tn.Schema, tn.TableInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if view == nil {
+		return nil, nil, nil, ErrNonInsertableTable.GenWithStackByArgs(tn.Name.O, "INSERT")
+	}
+	toBaseColumn := func(name *ast.ColumnName) (*ast.ColumnName, error) {
+		expr, ok := view.cols[name.Name.L]
+		if !ok {
+			return nil, ErrUnknownColumn.GenWithStackByArgs(name.Name.O, "field list")
+		}
+		col, ok := expr.(*ast.ColumnNameExpr)
+		if !ok {
+			return nil, ErrNonupdateableColumn.GenWithStackByArgs(name.Name.O)
+		}
+		return &ast.ColumnName{Name: col.Name.Name}, nil
+	}
+
+	newInsert := *insert
+	if len(insert.Setlist) > 0 {
+		newInsert.Setlist = make([]*ast.Assignment, 0, len(insert.Setlist))
+		for _, assign := range insert.Setlist {
+			col, err := toBaseColumn(assign.Column)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			newInsert.Setlist = append(newInsert.Setlist, &ast.Assignment{Column: col, Expr: assign.Expr})
+		}
+	} else {
+		columns := insert.Columns
+		if len(columns) == 0 {
+			for _, name := range view.colNames {
+				columns = append(columns, &ast.ColumnName{Name: model.NewCIStr(name)})
+			}
+		}
+		newInsert.Columns = make([]*ast.ColumnName, 0, len(columns))
+		for _, name := range columns {
+			col, err := toBaseColumn(name)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			newInsert.Columns = append(newInsert.Columns, col)
+		}
+	}
+
+	originalVisitInfo := b.visitInfo
+	b.visitInfo = make([]visitInfo, 0)
+	b.appendInsertVisitInfo(view.baseTable, insert.IsReplace)
+	if err = b.checkViewDefinerPrivilege(tn.Schema, tn.TableInfo); err != nil {
+		return nil, nil, nil, err
+	}
+	b.visitInfo = append(originalVisitInfo, b.visitInfo...)
+	return &newInsert, view.baseTable, view.checks, nil
+}
+
+// viewColumnSubstitutor replaces the columns of the relation under a view with
+// the expressions over the base table they stand for.
+type viewColumnSubstitutor struct {
+	cols map[string]ast.ExprNode
+	err  error
+}
+
+// Enter implements ast.Visitor interface.
+func (s *viewColumnSubstitutor) Enter(in ast.Node) (ast.Node, bool) {
+	return in, s.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (s *viewColumnSubstitutor) Leave(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok {
+		return in, true
+	}
+	expr, ok := s.cols[col.Name.Name.L]
+	if !ok {
+		s.err = ErrUnknownColumn.GenWithStackByArgs(col.Name.Name.O, "field list")
+		return in, false
+	}
+	return expr, true
+}
+
+func substituteViewColumns(expr ast.ExprNode, cols map[string]ast.ExprNode) (ast.ExprNode, error) {
+	s := &viewColumnSubstitutor{cols: cols}
+	node, _ := expr.Accept(s)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return node.(ast.ExprNode), nil
+}
+
+func (b *PlanBuilder) buildExplainPlan(targetPlan Plan, format string, analyze bool, execStmt ast.StmtNode) (Plan, error) {
 	p := &Explain{
 		TargetPlan: targetPlan,
 		Format:     format,
+		Analyze:    analyze,
 		ExecStmt:   execStmt,
 	}
 	p.ctx = b.ctx
@@ -929,8 +1699,14 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 	if err != nil {
 		return nil, err
 	}
+	if exec, ok := targetPlan.(*Execute); ok {
+		targetPlan = exec.Plan
+		if b.ctx.GetSessionVars().FoundInPlanCache {
+			b.ctx.GetSessionVars().StmtCtx.AppendNote(errors.New("Use the plan from the plan cache"))
+		}
+	}
 
-	return b.buildExplainPlan(targetPlan, explain.Format, explain.Stmt)
+	return b.buildExplainPlan(targetPlan, explain.Format, explain.Analyze, explain.Stmt)
 }
 
 func buildShowWarningsSchema() (*expression.Schema, types.NameSlice) {
@@ -959,9 +1735,25 @@ func buildShowSchema(s *ast.ShowStmt) (schema *expression.Schema, outputNames []
 	case ast.ShowVariables:
 		names = []string{"Variable_name", "Value"}
 	case ast.ShowCreateTable:
-		names = []string{"Table", "Create Table"}
+		if s.Table != nil && s.Table.TableInfo != nil && s.Table.TableInfo.IsView() {
+			names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+		} else {
+			names = []string{"Table", "Create Table"}
+		}
+	case ast.ShowCreateView:
+		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
+	case ast.ShowGrants:
+		if s.User != nil && !s.User.CurrentUser {
+			names = []string{fmt.Sprintf("Grants for %s", s.User)}
+		} else {
+			// Don't know the name yet, so just say "user".
+			names = []string{"Grants for User"}
+		}
+	case ast.ShowBindings:
+		names = []string{"Original_sql", "Bind_sql", "Default_db", "Status", "Create_time", "Update_time", "Charset", "Collation"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar}
 	}
 
 	schema = expression.NewSchema(make([]*expression.Column, 0, len(names))...)
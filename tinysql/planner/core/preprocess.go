@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/ddl"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -43,11 +44,18 @@ func Preprocess(ctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema,
 	return errors.Trace(v.err)
 }
 
+// InPrepare is a PreprocessOpt that indicates preprocess is executing under prepare statement.
+func InPrepare(p *preprocessor) {
+	p.flag |= inPrepare
+}
+
 type preprocessorFlag uint8
 
 const (
+	// inPrepare is set when visiting in prepare statement.
+	inPrepare preprocessorFlag = 1 << iota
 	// inCreateOrDropTable is set when visiting create/drop table statement.
-	inCreateOrDropTable preprocessorFlag = 1 << iota
+	inCreateOrDropTable
 	// parentIsJoin is set when visiting node's parent is join.
 	parentIsJoin
 )
@@ -70,6 +78,10 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 	case *ast.CreateTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.checkCreateTableGrammar(node)
+	case *ast.CreateViewStmt:
+		p.checkCreateViewGrammar(node)
+		p.resolveCreateViewStmt(node)
+		return in, true
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.checkDropTableGrammar(node)
@@ -86,6 +98,10 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.resolveShowStmt(node)
 	case *ast.Join:
 		p.checkNonUniqTableAlias(node)
+	case *driver.ParamMarkerExpr:
+		if p.flag&inPrepare == 0 {
+			p.err = parser.ErrSyntax.GenWithStack("syntax error, unexpected '?'")
+		}
 	default:
 		p.flag &= ^parentIsJoin
 	}
@@ -211,6 +227,41 @@ func (p *preprocessor) checkDropDatabaseGrammar(stmt *ast.DropDatabaseStmt) {
 	}
 }
 
+func (p *preprocessor) checkCreateViewGrammar(stmt *ast.CreateViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if isIncorrectName(vName) {
+		p.err = ddl.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if isIncorrectName(col.String()) {
+			p.err = ddl.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+}
+
+// resolveCreateViewStmt resolves the view name and the select of the view.
+// The unqualified table names in the select refer to the schema of the view,
+// the same as when the view is expanded.
+func (p *preprocessor) resolveCreateViewStmt(node *ast.CreateViewStmt) {
+	if p.err != nil {
+		return
+	}
+	vars := p.ctx.GetSessionVars()
+	if node.ViewName.Schema.L == "" {
+		if vars.CurrentDB == "" {
+			p.err = errors.Trace(ErrNoDB)
+			return
+		}
+		node.ViewName.Schema = model.NewCIStr(vars.CurrentDB)
+	}
+	currentDB := vars.CurrentDB
+	vars.CurrentDB = node.ViewName.Schema.O
+	node.Select.Accept(p)
+	vars.CurrentDB = currentDB
+}
+
 func (p *preprocessor) checkCreateTableGrammar(stmt *ast.CreateTableStmt) {
 	tName := stmt.Table.Name.String()
 	if isIncorrectName(tName) {
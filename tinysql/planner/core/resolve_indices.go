@@ -156,6 +156,49 @@ func (p *PhysicalMergeJoin) ResolveIndices() (err error) {
 	return
 }
 
+// ResolveIndices implements Plan interface.
+func (p *PhysicalIndexJoin) ResolveIndices() (err error) {
+	err = p.physicalSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	lSchema := p.children[0].Schema()
+	rSchema := p.children[1].Schema()
+	innerSchema := p.children[p.InnerChildIdx].Schema()
+	outerSchema := p.children[1-p.InnerChildIdx].Schema()
+	for i := range p.InnerJoinKeys {
+		newOuterKey, err := p.OuterJoinKeys[i].ResolveIndices(outerSchema)
+		if err != nil {
+			return err
+		}
+		p.OuterJoinKeys[i] = newOuterKey.(*expression.Column)
+		newInnerKey, err := p.InnerJoinKeys[i].ResolveIndices(innerSchema)
+		if err != nil {
+			return err
+		}
+		p.InnerJoinKeys[i] = newInnerKey.(*expression.Column)
+	}
+	for i, expr := range p.LeftConditions {
+		p.LeftConditions[i], err = expr.ResolveIndices(lSchema)
+		if err != nil {
+			return err
+		}
+	}
+	for i, expr := range p.RightConditions {
+		p.RightConditions[i], err = expr.ResolveIndices(rSchema)
+		if err != nil {
+			return err
+		}
+	}
+	for i, expr := range p.OtherConditions {
+		p.OtherConditions[i], err = expr.ResolveIndices(expression.MergeSchema(lSchema, rSchema))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ResolveIndices implements Plan interface.
 func (p *PhysicalUnionScan) ResolveIndices() (err error) {
 	err = p.basePhysicalPlan.ResolveIndices()
@@ -168,6 +211,10 @@ func (p *PhysicalUnionScan) ResolveIndices() (err error) {
 			return err
 		}
 	}
+	err = resolveIndicesForVirtualColumn(p.Schema().Columns, p.children[0].Schema())
+	if err != nil {
+		return err
+	}
 	resolvedHandleCol, err := p.HandleCol.ResolveIndices(p.children[0].Schema())
 	if err != nil {
 		return err
@@ -178,9 +225,28 @@ func (p *PhysicalUnionScan) ResolveIndices() (err error) {
 
 // ResolveIndices implements Plan interface.
 func (p *PhysicalTableReader) ResolveIndices() error {
+	err := resolveIndicesForVirtualColumn(p.tablePlan.Schema().Columns, p.schema)
+	if err != nil {
+		return err
+	}
 	return p.tablePlan.ResolveIndices()
 }
 
+// resolveIndicesForVirtualColumn resolves the columns in the expressions of the
+// virtual columns against the schema of the rows read from the storage.
+func resolveIndicesForVirtualColumn(result []*expression.Column, schema *expression.Schema) error {
+	for _, col := range result {
+		if col.VirtualExpr != nil {
+			newExpr, err := col.VirtualExpr.ResolveIndices(schema)
+			if err != nil {
+				return err
+			}
+			col.VirtualExpr = newExpr
+		}
+	}
+	return nil
+}
+
 // ResolveIndices implements Plan interface.
 func (p *PhysicalIndexReader) ResolveIndices() (err error) {
 	err = p.physicalSchemaProducer.ResolveIndices()
@@ -203,6 +269,10 @@ func (p *PhysicalIndexReader) ResolveIndices() (err error) {
 
 // ResolveIndices implements Plan interface.
 func (p *PhysicalIndexLookUpReader) ResolveIndices() (err error) {
+	err = resolveIndicesForVirtualColumn(p.tablePlan.Schema().Columns, p.schema)
+	if err != nil {
+		return err
+	}
 	err = p.tablePlan.ResolveIndices()
 	if err != nil {
 		return err
@@ -249,6 +319,12 @@ func (p *basePhysicalAgg) ResolveIndices() (err error) {
 				return err
 			}
 		}
+		for _, byItem := range aggFun.OrderByItems {
+			byItem.Expr, err = byItem.Expr.ResolveIndices(p.children[0].Schema())
+			if err != nil {
+				return err
+			}
+		}
 	}
 	for i, item := range p.GroupByItems {
 		p.GroupByItems[i], err = item.ResolveIndices(p.children[0].Schema())
@@ -306,6 +382,24 @@ func (p *Insert) ResolveIndices() (err error) {
 			return err
 		}
 	}
+	for _, check := range p.ViewChecks {
+		check.Expr, err = check.Expr.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+	}
+	for _, constraint := range p.CheckConstraints {
+		constraint.Expr, err = constraint.Expr.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+	}
+	for i, expr := range p.GenCols.Exprs {
+		p.GenCols.Exprs[i], err = expr.ResolveIndices(p.tableSchema)
+		if err != nil {
+			return err
+		}
+	}
 	return
 }
 
@@ -15,6 +15,8 @@ package core
 
 import (
 	"context"
+	"math"
+
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
@@ -35,6 +37,14 @@ type aggregationEliminateChecker struct {
 // For count(expr), sum(expr), avg(expr), count(distinct expr, [expr...]) we may need to rewrite the expr. Details are shown below.
 // If we can eliminate agg successful, we return a projection. Else we return a nil pointer.
 func (a *aggregationEliminateChecker) tryToEliminateAggregation(agg *LogicalAggregation) *LogicalProjection {
+	for _, af := range agg.AggFuncs {
+		// GROUP_CONCAT has to truncate its result and the variance functions
+		// have no plain per-row equivalent, so they are kept as aggregations.
+		switch af.Name {
+		case ast.AggFuncGroupConcat, ast.AggFuncVarPop, ast.AggFuncVarSamp, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
+			return nil
+		}
+	}
 	schemaByGroupby := expression.NewSchema(agg.groupByCols...)
 	coveredByUniqueKey := false
 	for _, key := range agg.children[0].Schema().Keys {
@@ -74,6 +84,8 @@ func (a *aggregationEliminateChecker) rewriteExpr(ctx sessionctx.Context, aggFun
 		return a.rewriteCount(ctx, aggFunc.Args, aggFunc.RetTp)
 	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin:
 		return aggFunc.Args[0]
+	case ast.AggFuncBitAnd, ast.AggFuncBitOr, ast.AggFuncBitXor:
+		return a.rewriteBitFunc(ctx, aggFunc.Name, aggFunc.Args[0], aggFunc.RetTp)
 	default:
 		panic("Unsupported function")
 	}
@@ -98,6 +110,17 @@ func (a *aggregationEliminateChecker) rewriteCount(ctx sessionctx.Context, exprs
 	return newExpr
 }
 
+// rewriteBitFunc rewrites bit_and(expr), bit_or(expr) and bit_xor(expr) of a
+// single row to ifnull(expr, default), where default is the result of the
+// function on no rows.
+func (a *aggregationEliminateChecker) rewriteBitFunc(ctx sessionctx.Context, funcType string, arg expression.Expression, targetTp *types.FieldType) expression.Expression {
+	defaultVal := types.NewUintDatum(0)
+	if funcType == ast.AggFuncBitAnd {
+		defaultVal = types.NewUintDatum(math.MaxUint64)
+	}
+	return expression.NewFunctionInternal(ctx, ast.Ifnull, targetTp, arg, &expression.Constant{Value: defaultVal, RetType: targetTp})
+}
+
 func (a *aggregationEliminator) optimize(ctx context.Context, p LogicalPlan) (LogicalPlan, error) {
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
@@ -106,10 +106,13 @@ func (la *LogicalAggregation) PruneColumns(parentUsedCols []*expression.Column)
 	var selfUsedCols []*expression.Column
 	for _, aggrFunc := range la.AggFuncs {
 		selfUsedCols = expression.ExtractColumnsFromExpressions(selfUsedCols, aggrFunc.Args, nil)
+		for _, byItem := range aggrFunc.OrderByItems {
+			selfUsedCols = append(selfUsedCols, expression.ExtractColumns(byItem.Expr)...)
+		}
 	}
 	if len(la.AggFuncs) == 0 {
 		// If all the aggregate functions are pruned, we should add an aggregate function to keep the correctness.
-		one, err := aggregation.NewAggFuncDesc(la.ctx, ast.AggFuncFirstRow, []expression.Expression{expression.One})
+		one, err := aggregation.NewAggFuncDesc(la.ctx, ast.AggFuncFirstRow, []expression.Expression{expression.One}, false)
 		if err != nil {
 			return err
 		}
@@ -160,6 +163,19 @@ func (ls *LogicalSort) PruneColumns(parentUsedCols []*expression.Column) error {
 	return child.PruneColumns(parentUsedCols)
 }
 
+// PruneColumns implements LogicalPlan interface.
+func (p *LogicalUnionAll) PruneColumns(parentUsedCols []*expression.Column) error {
+	for _, child := range p.Children() {
+		err := child.PruneColumns(parentUsedCols)
+		if err != nil {
+			return err
+		}
+	}
+	// All the children read the same columns, so the schema follows the first one.
+	p.schema = expression.NewSchema(p.children[0].Schema().Columns...)
+	return nil
+}
+
 // PruneColumns implements LogicalPlan interface.
 func (p *LogicalUnionScan) PruneColumns(parentUsedCols []*expression.Column) error {
 	parentUsedCols = append(parentUsedCols, p.handleCol)
@@ -168,6 +184,12 @@ func (p *LogicalUnionScan) PruneColumns(parentUsedCols []*expression.Column) err
 
 // PruneColumns implements LogicalPlan interface.
 func (ds *DataSource) PruneColumns(parentUsedCols []*expression.Column) error {
+	// The columns which the used virtual columns depend on are used too.
+	for _, col := range parentUsedCols {
+		if col.VirtualExpr != nil {
+			parentUsedCols = append(parentUsedCols, expression.ExtractDependentColumns(col.VirtualExpr)...)
+		}
+	}
 	used := getUsedList(parentUsedCols, ds.schema)
 
 	var (
@@ -49,22 +49,53 @@ func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression
 type joinReOrderSolver struct {
 }
 
+// leadingHint records whether a LEADING hint has been applied to some join group.
+type leadingHint struct {
+	tables  []hintTableInfo
+	applied bool
+}
+
+// extractLeadingHint returns the tables of the LEADING hint of the join group
+// rooted at p, or nil if there is none.
+func extractLeadingHint(p LogicalPlan) []hintTableInfo {
+	join, isJoin := p.(*LogicalJoin)
+	if !isJoin || join.hintInfo == nil {
+		return nil
+	}
+	return join.hintInfo.leadingJoinOrder
+}
+
 type jrNode struct {
 	p       LogicalPlan
 	cumCost float64
 }
 
 func (s *joinReOrderSolver) optimize(ctx context.Context, p LogicalPlan) (LogicalPlan, error) {
-	return s.optimizeRecursive(p.SCtx(), p)
+	// The hint infos of the joins in one query block may be copies of each
+	// other, so the LEADING hints are identified by their first table.
+	leadingHints := make(map[*hintTableInfo]*leadingHint)
+	p, err := s.optimizeRecursive(p.SCtx(), p, leadingHints)
+	if err != nil {
+		return nil, err
+	}
+	for _, hint := range leadingHints {
+		if !hint.applied {
+			p.SCtx().GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"Optimizer Hint %s is inapplicable, check if the leading hint table is valid",
+				restore2JoinHint(HintLeading, hint.tables)))
+		}
+	}
+	return p, nil
 }
 
 // optimizeRecursive recursively collects join groups and applies join reorder algorithm for each group.
-func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan) (LogicalPlan, error) {
+func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan, leadingHints map[*hintTableInfo]*leadingHint) (LogicalPlan, error) {
 	var err error
+	leadingTables := extractLeadingHint(p)
 	curJoinGroup, eqEdges, otherConds := extractJoinGroup(p)
 	if len(curJoinGroup) > 1 {
 		for i := range curJoinGroup {
-			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i])
+			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i], leadingHints)
 			if err != nil {
 				return nil, err
 			}
@@ -73,7 +104,22 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 			ctx:        ctx,
 			otherConds: otherConds,
 		}
-		if len(curJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
+		if len(leadingTables) > 0 {
+			// The join order specified by the LEADING hint is built by the greedy solver.
+			groupSolver := &joinReorderGreedySolver{
+				baseSingleGroupJoinOrderSolver: baseGroupSolver,
+				eqEdges:                        eqEdges,
+				leadingTables:                  leadingTables,
+			}
+			var applied bool
+			p, applied, err = groupSolver.solveWithLeading(curJoinGroup)
+			hint, ok := leadingHints[&leadingTables[0]]
+			if !ok {
+				hint = &leadingHint{tables: leadingTables}
+				leadingHints[&leadingTables[0]] = hint
+			}
+			hint.applied = hint.applied || applied
+		} else if len(curJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
 			groupSolver := &joinReorderGreedySolver{
 				baseSingleGroupJoinOrderSolver: baseGroupSolver,
 				eqEdges:                        eqEdges,
@@ -93,7 +139,7 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 	}
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
-		newChild, err := s.optimizeRecursive(ctx, child)
+		newChild, err := s.optimizeRecursive(ctx, child, leadingHints)
 		if err != nil {
 			return nil, err
 		}
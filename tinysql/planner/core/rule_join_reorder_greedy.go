@@ -24,6 +24,8 @@ import (
 type joinReorderGreedySolver struct {
 	*baseSingleGroupJoinOrderSolver
 	eqEdges []*expression.ScalarFunction
+	// leadingTables is the join order specified by the LEADING hint.
+	leadingTables []hintTableInfo
 }
 
 // solve reorders the join nodes in the group based on a greedy algorithm.
@@ -41,19 +43,103 @@ type joinReorderGreedySolver struct {
 // For the nodes and join trees which don't have a join equal condition to
 // connect them, we make a bushy join tree to do the cartesian joins finally.
 func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan) (LogicalPlan, error) {
+	if err := s.initJoinGroup(joinNodePlans); err != nil {
+		return nil, err
+	}
+	return s.solveGroup(nil)
+}
+
+// solveWithLeading first joins the nodes listed in the LEADING hint in the
+// specified order, then joins the remaining nodes greedily. If some table of
+// the hint can't be found in the group, the hint is ignored and false is returned.
+func (s *joinReorderGreedySolver) solveWithLeading(joinNodePlans []LogicalPlan) (_ LogicalPlan, applied bool, err error) {
+	if err = s.initJoinGroup(joinNodePlans); err != nil {
+		return nil, false, err
+	}
+	leadingNode, err := s.buildLeadingJoinTree()
+	if err != nil {
+		return nil, false, err
+	}
+	p, err := s.solveGroup(leadingNode)
+	return p, leadingNode != nil, err
+}
+
+func (s *joinReorderGreedySolver) initJoinGroup(joinNodePlans []LogicalPlan) error {
 	for _, node := range joinNodePlans {
 		_, err := node.recursiveDeriveStats()
 		if err != nil {
-			return nil, err
+			return err
 		}
 		s.curJoinGroup = append(s.curJoinGroup, &jrNode{
 			p:       node,
 			cumCost: s.baseNodeCumCost(node),
 		})
 	}
+	return nil
+}
+
+// buildLeadingJoinTree joins the nodes in the order of leadingTables and
+// removes them from the current join group. It returns nil if some of the
+// tables can't be found in the group.
+func (s *joinReorderGreedySolver) buildLeadingJoinTree() (*jrNode, error) {
+	leadingIdx := make([]int, 0, len(s.leadingTables))
+	for _, table := range s.leadingTables {
+		idx := -1
+		for i, node := range s.curJoinGroup {
+			alias := extractTableAlias(node.p)
+			if alias != nil && alias.dbName.L == table.dbName.L && alias.tblName.L == table.tblName.L {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return nil, nil
+		}
+		for _, used := range leadingIdx {
+			if used == idx {
+				return nil, nil
+			}
+		}
+		leadingIdx = append(leadingIdx, idx)
+	}
+	curJoinTree := s.curJoinGroup[leadingIdx[0]]
+	for _, idx := range leadingIdx[1:] {
+		node := s.curJoinGroup[idx]
+		newJoin, remainOthers := s.checkConnectionAndMakeJoin(curJoinTree.p, node.p)
+		if newJoin == nil {
+			cartesianJoin := s.newCartesianJoin(curJoinTree.p, node.p)
+			s.otherConds, cartesianJoin.OtherConditions = expression.FilterOutInPlace(s.otherConds, func(expr expression.Expression) bool {
+				return expression.ExprFromSchema(expr, cartesianJoin.schema)
+			})
+			newJoin, remainOthers = cartesianJoin, s.otherConds
+		}
+		_, err := newJoin.recursiveDeriveStats()
+		if err != nil {
+			return nil, err
+		}
+		curJoinTree = &jrNode{
+			p:       newJoin,
+			cumCost: s.calcJoinCumCost(newJoin, curJoinTree, node),
+		}
+		s.otherConds = remainOthers
+	}
+	sort.Ints(leadingIdx)
+	for i := len(leadingIdx) - 1; i >= 0; i-- {
+		idx := leadingIdx[i]
+		s.curJoinGroup = append(s.curJoinGroup[:idx], s.curJoinGroup[idx+1:]...)
+	}
+	return curJoinTree, nil
+}
+
+// solveGroup joins the nodes of the current join group greedily. If
+// leadingNode is not nil, it is always the first node to start with.
+func (s *joinReorderGreedySolver) solveGroup(leadingNode *jrNode) (LogicalPlan, error) {
 	sort.SliceStable(s.curJoinGroup, func(i, j int) bool {
 		return s.curJoinGroup[i].cumCost < s.curJoinGroup[j].cumCost
 	})
+	if leadingNode != nil {
+		s.curJoinGroup = append([]*jrNode{leadingNode}, s.curJoinGroup...)
+	}
 
 	var cartesianGroup []LogicalPlan
 	for len(s.curJoinGroup) > 0 {
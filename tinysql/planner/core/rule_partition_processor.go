@@ -0,0 +1,324 @@
+// Copyright 2018 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+// partitionProcessor rewrites the ast for table partition.
+//
+// For a table created by
+//
+//	create table t (id int) partition by range (id)
+//	(partition p1 values less than (10),
+//	 partition p2 values less than (20),
+//	 partition p3 values less than (30))
+//
+// select * from t is equal to
+//
+//	select * from (union all
+//	  select * from p1 where id < 10
+//	  select * from p2 where id < 20
+//	  select * from p3 where id < 30)
+//
+// partitionProcessor is here because it's easier to prune partition after predicate push down.
+type partitionProcessor struct{}
+
+func (s *partitionProcessor) optimize(ctx context.Context, lp LogicalPlan) (LogicalPlan, error) {
+	return s.rewriteDataSource(lp)
+}
+
+func (s *partitionProcessor) rewriteDataSource(lp LogicalPlan) (LogicalPlan, error) {
+	// Assert there will not be sel -> sel in the ast.
+	switch p := lp.(type) {
+	case *DataSource:
+		return s.prune(p)
+	case *LogicalUnionScan:
+		ds := p.Children()[0]
+		ds, err := s.prune(ds.(*DataSource))
+		if err != nil {
+			return nil, err
+		}
+		if ua, ok := ds.(*LogicalUnionAll); ok {
+			// Adjust the UnionScan->Union->DataSource1, DataSource2 ... to
+			// Union->(UnionScan->DataSource1), (UnionScan->DataSource2)
+			children := make([]LogicalPlan, 0, len(ua.Children()))
+			for _, child := range ua.Children() {
+				us := LogicalUnionScan{
+					conditions: p.conditions,
+					handleCol:  p.handleCol,
+				}.Init(ua.ctx)
+				us.SetChildren(child)
+				children = append(children, us)
+			}
+			ua.SetChildren(children...)
+			return ua, nil
+		}
+		// Only one partition, no union all.
+		p.SetChildren(ds)
+		return p, nil
+	default:
+		children := lp.Children()
+		for i, child := range children {
+			newChild, err := s.rewriteDataSource(child)
+			if err != nil {
+				return nil, err
+			}
+			children[i] = newChild
+		}
+	}
+
+	return lp, nil
+}
+
+// partitionTable is for those tables which implement partition.
+type partitionTable interface {
+	PartitionExpr() *tables.PartitionExpr
+}
+
+func (s *partitionProcessor) prune(ds *DataSource) (LogicalPlan, error) {
+	pi := ds.tableInfo.GetPartitionInfo()
+	if pi == nil {
+		return ds, nil
+	}
+	pt, ok := ds.table.(partitionTable)
+	if !ok {
+		return ds, nil
+	}
+	for _, name := range ds.partitionNames {
+		if pi.FindPartitionDefinitionByName(name.L) < 0 {
+			return nil, table.ErrUnknownPartition.GenWithStackByArgs(name.O, ds.tableInfo.Name.O)
+		}
+	}
+
+	used := s.pruneByConds(ds, pi, pt.PartitionExpr())
+	children := make([]LogicalPlan, 0, len(used))
+	for _, i := range used {
+		// This is for `table partition (p0,p1)` syntax, only union the specified partition if has specified partitions.
+		if len(ds.partitionNames) != 0 && !s.findByName(ds.partitionNames, pi.Definitions[i].Name.L) {
+			continue
+		}
+		children = append(children, s.newPartitionDataSource(ds, pi.Definitions[i].ID))
+	}
+
+	if len(children) == 0 {
+		// No result after table pruning.
+		tableDual := LogicalTableDual{RowCount: 0}.Init(ds.SCtx())
+		tableDual.schema = ds.Schema()
+		tableDual.names = ds.names
+		return tableDual, nil
+	}
+	if len(children) == 1 {
+		// No need for the union all.
+		return children[0], nil
+	}
+	unionAll := LogicalUnionAll{}.Init(ds.SCtx())
+	unionAll.SetChildren(children...)
+	unionAll.SetSchema(ds.schema.Clone())
+	unionAll.names = ds.names
+	return unionAll, nil
+}
+
+// newPartitionDataSource copies the DataSource to read the partition whose
+// physical table ID is pid.
+func (s *partitionProcessor) newPartitionDataSource(ds *DataSource, pid int64) *DataSource {
+	newDataSource := *ds
+	newDataSource.baseLogicalPlan = newBaseLogicalPlan(ds.SCtx(), TypeTableScan, &newDataSource)
+	// There are many expression nodes in the plan tree use the original datasource
+	// id as FromID. So we set the id of the newDataSource with the original one to
+	// avoid traversing the whole plan tree to update the references.
+	newDataSource.id = ds.id
+	newDataSource.isPartition = true
+	newDataSource.physicalTableID = pid
+	newDataSource.statisticTable = getStatsTable(ds.SCtx(), ds.tableInfo, pid)
+	newDataSource.schema = ds.schema.Clone()
+	newDataSource.Columns = make([]*model.ColumnInfo, len(ds.Columns))
+	copy(newDataSource.Columns, ds.Columns)
+	newDataSource.possibleAccessPaths = make([]*util.AccessPath, len(ds.possibleAccessPaths))
+	for i := range ds.possibleAccessPaths {
+		newPath := *ds.possibleAccessPaths[i]
+		newDataSource.possibleAccessPaths[i] = &newPath
+	}
+	return &newDataSource
+}
+
+func (s *partitionProcessor) findByName(partitionNames []model.CIStr, partitionName string) bool {
+	for _, s := range partitionNames {
+		if s.L == partitionName {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneByConds returns the offsets of the partitions that may contain rows
+// satisfying the filters on the DataSource. The ranges of the partitioning
+// column are built by ranger: a point range is mapped to its partition for
+// all partitioning types, an interval is mapped to a span of RANGE partitions
+// when the partitioning expression is monotonic.
+func (s *partitionProcessor) pruneByConds(ds *DataSource, pi *model.PartitionInfo, pe *tables.PartitionExpr) []int {
+	all := make([]int, 0, len(pi.Definitions))
+	for i := range pi.Definitions {
+		all = append(all, i)
+	}
+	if pe.Column == nil || len(ds.allConds) == 0 {
+		return all
+	}
+	var col *expression.Column
+	for _, c := range ds.schema.Columns {
+		if c.ID == pe.Column.ID {
+			col = c
+			break
+		}
+	}
+	if col == nil {
+		return all
+	}
+	sctx := ds.SCtx()
+	sc := sctx.GetSessionVars().StmtCtx
+	accessConds, _ := ranger.DetachCondsForColumn(sctx, ds.allConds, col)
+	if len(accessConds) == 0 {
+		return all
+	}
+	ranges, err := ranger.BuildColumnRange(accessConds, sc, col.RetType, types.UnspecifiedLength)
+	if err != nil {
+		return all
+	}
+
+	used := make(map[int]struct{}, len(pi.Definitions))
+	for _, ran := range ranges {
+		low, high := ran.LowVal[0], ran.HighVal[0]
+		if low.IsNull() && high.IsNull() {
+			// The range of `col is null`.
+			if idx, err := pe.LocatePartition(pi, 0, true); err == nil {
+				used[idx] = struct{}{}
+			}
+			continue
+		}
+		if ran.IsPoint(sc) {
+			v, isNull, ok := s.evalPartitionExpr(ds, pe, low)
+			if !ok {
+				return all
+			}
+			if idx, err := pe.LocatePartition(pi, v, isNull); err == nil {
+				used[idx] = struct{}{}
+			}
+			continue
+		}
+		if pi.Type != model.PartitionTypeRange || !isMonotonicPartitionExpr(pe) || mysql.HasUnsignedFlag(col.RetType.Flag) {
+			return all
+		}
+		start, end, ok := s.locateRangeInterval(ds, pe, ran)
+		if !ok {
+			return all
+		}
+		for i := start; i <= end; i++ {
+			used[i] = struct{}{}
+		}
+	}
+
+	ret := make([]int, 0, len(used))
+	for i := range used {
+		ret = append(ret, i)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// locateRangeInterval returns the span of RANGE partitions which an interval
+// of the partitioning column falls into, the span may be empty.
+func (s *partitionProcessor) locateRangeInterval(ds *DataSource, pe *tables.PartitionExpr, ran *ranger.Range) (start, end int, ok bool) {
+	_, isColumn := pe.Expr.(*expression.Column)
+	low, high := ran.LowVal[0], ran.HighVal[0]
+	start, end = 0, len(pe.UpperBounds)-1
+	// NULL is stored in the first partition, so a range starting from NULL or
+	// -inf starts from the first partition.
+	if low.Kind() != types.KindNull && low.Kind() != types.KindMinNotNull {
+		v, isNull, ok := s.evalPartitionExpr(ds, pe, low)
+		if !ok || isNull {
+			return 0, 0, false
+		}
+		if ran.LowExclude && isColumn {
+			v++
+		}
+		start = pe.LocateRange(v)
+	}
+	if high.Kind() != types.KindMaxValue {
+		v, isNull, ok := s.evalPartitionExpr(ds, pe, high)
+		if !ok || isNull {
+			return 0, 0, false
+		}
+		if ran.HighExclude && isColumn {
+			v--
+		}
+		if idx := pe.LocateRange(v); idx < end {
+			end = idx
+		}
+	}
+	return start, end, true
+}
+
+// evalPartitionExpr evaluates the partitioning expression with the partitioning
+// column set to d.
+func (s *partitionProcessor) evalPartitionExpr(ds *DataSource, pe *tables.PartitionExpr, d types.Datum) (int64, bool, bool) {
+	row := make([]types.Datum, len(ds.tableInfo.Columns))
+	row[pe.Column.Index] = d
+	v, isNull, err := pe.Expr.EvalInt(ds.SCtx(), chunk.MutRowFromDatums(row).ToRow())
+	if err != nil {
+		return 0, false, false
+	}
+	return v, isNull, true
+}
+
+// monotonicPartitionFuncs are the non-decreasing functions of their only
+// argument, an interval of the argument is mapped to an interval of the result.
+var monotonicPartitionFuncs = map[string]struct{}{
+	ast.Year:          {},
+	ast.UnixTimestamp: {},
+}
+
+func isMonotonicPartitionExpr(pe *tables.PartitionExpr) bool {
+	switch x := pe.Expr.(type) {
+	case *expression.Column:
+		return true
+	case *expression.ScalarFunction:
+		if _, ok := monotonicPartitionFuncs[x.FuncName.L]; !ok {
+			return false
+		}
+		args := x.GetArgs()
+		if len(args) != 1 {
+			return false
+		}
+		_, ok := args[0].(*expression.Column)
+		return ok
+	}
+	return false
+}
+
+func (*partitionProcessor) name() string {
+	return "partition_processor"
+}
@@ -87,6 +87,17 @@ func (p *LogicalSelection) PredicatePushDown(predicates []expression.Expression)
 	return nil, child
 }
 
+// PredicatePushDown implements LogicalPlan PredicatePushDown interface.
+func (p *LogicalUnionAll) PredicatePushDown(predicates []expression.Expression) ([]expression.Expression, LogicalPlan) {
+	for i, child := range p.children {
+		newExprs := make([]expression.Expression, 0, len(predicates))
+		newExprs = append(newExprs, predicates...)
+		retCond, newChild := child.PredicatePushDown(newExprs)
+		addSelection(p, newChild, retCond, i)
+	}
+	return nil, p
+}
+
 // PredicatePushDown implements LogicalPlan PredicatePushDown interface.
 func (p *LogicalUnionScan) PredicatePushDown(predicates []expression.Expression) ([]expression.Expression, LogicalPlan) {
 	retainedPredicates, _ := p.children[0].PredicatePushDown(predicates)
@@ -318,7 +329,7 @@ func isNullRejected(ctx sessionctx.Context, schema *expression.Schema, expr expr
 	result := expression.EvaluateExprWithNull(ctx, schema, expr)
 	sc.InNullRejectCheck = false
 	x, ok := result.(*expression.Constant)
-	if !ok {
+	if !ok || !x.ConstItem() {
 		return false
 	}
 	if x.Value.IsNull() {
@@ -428,16 +439,16 @@ func deriveOtherConditions(p *LogicalJoin, deriveLeft bool, deriveRight bool) (l
 }
 
 // deriveNotNullExpr generates a new expression `not(isnull(col))` given `col1 op col2`,
-// in which `col` is in specified schema. Caller guarantees that only one of `col1` or
-// `col2` is in schema.
+// in which `col` is in specified schema. Either column may be wrapped in a cast. Caller
+// guarantees that only one of `col1` or `col2` is in schema.
 func deriveNotNullExpr(expr expression.Expression, schema *expression.Schema) expression.Expression {
 	binop, ok := expr.(*expression.ScalarFunction)
 	if !ok || len(binop.GetArgs()) != 2 {
 		return nil
 	}
 	ctx := binop.GetCtx()
-	arg0, lOK := binop.GetArgs()[0].(*expression.Column)
-	arg1, rOK := binop.GetArgs()[1].(*expression.Column)
+	arg0, lOK := castedColumn(binop.GetArgs()[0])
+	arg1, rOK := castedColumn(binop.GetArgs()[1])
 	if !lOK || !rOK {
 		return nil
 	}
@@ -451,13 +462,22 @@ func deriveNotNullExpr(expr expression.Expression, schema *expression.Schema) ex
 	return nil
 }
 
+// castedColumn returns the column of `col` or `cast(col)`.
+func castedColumn(expr expression.Expression) (*expression.Column, bool) {
+	if sf, ok := expr.(*expression.ScalarFunction); ok && sf.FuncName.L == ast.Cast {
+		expr = sf.GetArgs()[0]
+	}
+	col, ok := expr.(*expression.Column)
+	return col, ok
+}
+
 // Conds2TableDual builds a LogicalTableDual if cond is constant false or null.
 func Conds2TableDual(p LogicalPlan, conds []expression.Expression) LogicalPlan {
 	if len(conds) != 1 {
 		return nil
 	}
 	con, ok := conds[0].(*expression.Constant)
-	if !ok {
+	if !ok || !con.ConstItem() {
 		return nil
 	}
 	sc := p.SCtx().GetSessionVars().StmtCtx
@@ -18,6 +18,7 @@ import (
 
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/planner/util"
 )
 
 // pushDownTopNOptimizer pushes down the topN or limit. In the future we will remove the limit from `requiredProperty` in CBO phase.
@@ -129,7 +130,7 @@ func (p *LogicalJoin) pushDownTopNToChild(topN *LogicalTopN, idx int) LogicalPla
 
 	newTopN := LogicalTopN{
 		Count:   topN.Count + topN.Offset,
-		ByItems: make([]*ByItems, len(topN.ByItems)),
+		ByItems: make([]*util.ByItems, len(topN.ByItems)),
 	}.Init(topN.ctx)
 	for i := range topN.ByItems {
 		newTopN.ByItems[i] = topN.ByItems[i].Clone()
@@ -289,6 +289,20 @@ func (lt *LogicalTopN) DeriveStats(childStats []*property.StatsInfo, selfSchema
 
 // getCardinality will return the Cardinality of a couple of columns. We simply return the max one, because we cannot know
 // the Cardinality for multi-dimension attributes properly. This is a simple and naive scheme of Cardinality estimation.
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalUnionAll) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema) (*property.StatsInfo, error) {
+	p.stats = &property.StatsInfo{
+		Cardinality: make([]float64, selfSchema.Len()),
+	}
+	for _, childProfile := range childStats {
+		p.stats.RowCount += childProfile.RowCount
+		for i := range p.stats.Cardinality {
+			p.stats.Cardinality[i] += childProfile.Cardinality[i]
+		}
+	}
+	return p.stats, nil
+}
+
 func getCardinality(cols []*expression.Column, schema *expression.Schema, profile *property.StatsInfo) float64 {
 	cardinality := 1.0
 	indices := schema.ColumnsIndices(cols)
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/types"
@@ -142,6 +143,62 @@ func (p *basePhysicalPlan) attach2Task(tasks ...task) task {
 	return attachPlan2Task(p.self, t)
 }
 
+func (p *PhysicalIndexJoin) attach2Task(tasks ...task) task {
+	innerTask := p.innerTask
+	outerTask := finishCopTask(p.ctx, tasks[1-p.InnerChildIdx].copy())
+	if p.InnerChildIdx == 1 {
+		p.SetChildren(outerTask.plan(), innerTask.plan())
+	} else {
+		p.SetChildren(innerTask.plan(), outerTask.plan())
+	}
+	p.schema = BuildPhysicalJoinSchema(p.JoinType, p)
+	return &rootTask{
+		p:   p,
+		cst: p.GetCost(outerTask, innerTask),
+	}
+}
+
+// GetCost computes the cost of index join operator and its children.
+func (p *PhysicalIndexJoin) GetCost(outerTask, innerTask task) float64 {
+	var cpuCost float64
+	outerCnt, innerCnt := outerTask.count(), innerTask.count()
+	sessVars := p.ctx.GetSessionVars()
+	// Add the cost of evaluating outer filter, since inner filter of index join
+	// is always empty, we can simply tell whether outer filter is empty using the
+	// summed length of left/right conditions.
+	if len(p.LeftConditions)+len(p.RightConditions) > 0 {
+		cpuCost += sessVars.CPUFactor * outerCnt
+		outerCnt *= selectionFactor
+	}
+	// Cost of extracting lookup keys.
+	innerCPUCost := sessVars.CPUFactor * outerCnt
+	// Cost of sorting and removing duplicate lookup keys:
+	// (outerCnt / batchSize) * (batchSize * Log2(batchSize) + batchSize) * CPUFactor
+	batchSize := math.Min(float64(sessVars.IndexJoinBatchSize), outerCnt)
+	if batchSize > 2 {
+		innerCPUCost += outerCnt * (math.Log2(batchSize) + 1) * sessVars.CPUFactor
+	}
+	// Add cost of building inner executors. CPU cost of building copTasks:
+	// (outerCnt / batchSize) * (batchSize * distinctFactor) * CPUFactor
+	// Since we don't know the number of copTasks built, ignore these network cost now.
+	innerCPUCost += outerCnt * distinctFactor * sessVars.CPUFactor
+	// CPU cost of building hash table for inner results:
+	// (outerCnt / batchSize) * (batchSize * distinctFactor) * innerCnt * CPUFactor
+	innerCPUCost += outerCnt * distinctFactor * innerCnt * sessVars.CPUFactor
+	innerConcurrency := float64(sessVars.IndexLookupJoinConcurrency)
+	cpuCost += innerCPUCost / innerConcurrency
+	// Cost of probing hash table in main thread.
+	probeCost := outerCnt * innerCnt * sessVars.CPUFactor
+	// Cost of additional concurrent goroutines.
+	cpuCost += probeCost + (innerConcurrency+1.0)*sessVars.ConcurrencyFactor
+	// Memory cost of hash tables for inner rows. The computed result is the upper bound,
+	// since the executor is pipelined and not all workers are always in full load.
+	memoryCost := innerConcurrency * (batchSize * distinctFactor) * innerCnt * sessVars.MemoryFactor
+	// Cost of inner child plan, i.e, mainly I/O and network cost.
+	innerPlanCost := outerCnt * innerTask.cost()
+	return outerTask.cost() + innerPlanCost + cpuCost + memoryCost
+}
+
 // GetCost computes cost of hash join operator itself.
 func (p *PhysicalHashJoin) GetCost(lCnt, rCnt float64) float64 {
 	buildCnt, probeCnt := lCnt, rCnt
@@ -244,6 +301,9 @@ func (p *PhysicalMergeJoin) attach2Task(tasks ...task) task {
 // Now it's only used for TableReader.
 func splitCopAvg2CountAndSum(p PhysicalPlan) {
 	var baseAgg *basePhysicalAgg
+	if agg, ok := p.(*PhysicalStreamAgg); ok {
+		baseAgg = &agg.basePhysicalAgg
+	}
 	if agg, ok := p.(*PhysicalHashAgg); ok {
 		baseAgg = &agg.basePhysicalAgg
 	}
@@ -394,7 +454,8 @@ func (p *PhysicalLimit) attach2Task(tasks ...task) task {
 	if cop, ok := t.(*copTask); ok {
 		// For double read which requires order being kept, the limit cannot be pushed down to the table side,
 		// because handles would be reordered before being sent to table scan.
-		if !cop.keepOrder || !cop.indexPlanFinished || cop.indexPlan == nil {
+		// The limit can't be pushed down either if some conditions are still to be evaluated in the root task.
+		if (!cop.keepOrder || !cop.indexPlanFinished || cop.indexPlan == nil) && len(cop.rootTaskConds) == 0 {
 			// When limit is pushed down, we should remove its offset.
 			newCount := p.Offset + p.Count
 			childProfile := cop.plan().statsInfo()
@@ -438,6 +499,9 @@ func (p *PhysicalTopN) canPushDown() bool {
 	for _, item := range p.ByItems {
 		exprs = append(exprs, item.Expr)
 	}
+	if expression.ContainVirtualColumn(exprs) {
+		return false
+	}
 	_, _, remained := expression.ExpressionsToPB(p.ctx.GetSessionVars().StmtCtx, exprs, p.ctx.GetClient())
 	return len(remained) == 0
 }
@@ -471,7 +535,7 @@ func (p *NominalSort) attach2Task(tasks ...task) task {
 }
 
 func (p *PhysicalTopN) getPushedDownTopN(childPlan PhysicalPlan) *PhysicalTopN {
-	newByItems := make([]*ByItems, 0, len(p.ByItems))
+	newByItems := make([]*util.ByItems, 0, len(p.ByItems))
 	for _, expr := range p.ByItems {
 		newByItems = append(newByItems, expr.Clone())
 	}
@@ -491,7 +555,7 @@ func (p *PhysicalTopN) getPushedDownTopN(childPlan PhysicalPlan) *PhysicalTopN {
 func (p *PhysicalTopN) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
 	inputCount := t.count()
-	if copTask, ok := t.(*copTask); ok && p.canPushDown() {
+	if copTask, ok := t.(*copTask); ok && len(copTask.rootTaskConds) == 0 && p.canPushDown() {
 		// If all columns in topN are from index plan, we push it to index plan, otherwise we finish the index plan and
 		// push it to table plan.
 		var pushedDownTopN *PhysicalTopN
@@ -535,6 +599,19 @@ func (p *PhysicalProjection) attach2Task(tasks ...task) task {
 	return t
 }
 
+func (p *PhysicalUnionAll) attach2Task(tasks ...task) task {
+	t := &rootTask{p: p}
+	childPlans := make([]PhysicalPlan, 0, len(tasks))
+	for _, task := range tasks {
+		task = finishCopTask(p.ctx, task)
+		// The children of UnionExec are executed one by one.
+		t.cst += task.cost()
+		childPlans = append(childPlans, task.plan())
+	}
+	p.SetChildren(childPlans...)
+	return t
+}
+
 func (sel *PhysicalSelection) attach2Task(tasks ...task) task {
 	sessVars := sel.ctx.GetSessionVars()
 	t := finishCopTask(sel.ctx, tasks[0].copy())
@@ -549,11 +626,18 @@ func CheckAggCanPushCop(sctx sessionctx.Context, aggFuncs []*aggregation.AggFunc
 	sc := sctx.GetSessionVars().StmtCtx
 	client := sctx.GetClient()
 	for _, aggFunc := range aggFuncs {
+		// The values of virtual columns are evaluated in TiDB.
+		if expression.ContainVirtualColumn(aggFunc.Args) {
+			return false
+		}
 		pb := aggregation.AggFuncToPBExpr(sc, client, aggFunc)
 		if pb == nil {
 			return false
 		}
 	}
+	if expression.ContainVirtualColumn(groupByItems) {
+		return false
+	}
 	_, _, remained := expression.ExpressionsToPB(sc, groupByItems, client)
 	if len(remained) > 0 {
 		return false
@@ -594,6 +678,21 @@ func BuildFinalModeAggregation(
 			args = append(args, partialSchema.Columns[partialCursor])
 			partialCursor++
 		}
+		if aggregation.NeedVariance(finalAggFunc.Name) {
+			ft := types.NewFieldType(mysql.TypeDouble)
+			ft.Flen, ft.Decimal = mysql.MaxRealWidth, types.UnspecifiedLength
+			ft.Charset, ft.Collate = charset.CharsetBin, charset.CollationBin
+			partialSchema.Append(&expression.Column{
+				UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
+				RetType:  ft,
+			})
+			args = append(args, partialSchema.Columns[partialCursor])
+			partialCursor++
+		}
+		if finalAggFunc.Name == ast.AggFuncGroupConcat {
+			// The separator is not computed by the partial aggregation.
+			args = append(args, aggFunc.Args[len(aggFunc.Args)-1])
+		}
 		finalAggFunc.Args = args
 		finalAggFunc.Mode = aggregation.FinalMode
 		finalAggFunc.RetTp = aggFunc.RetTp
@@ -629,6 +728,15 @@ func (p *basePhysicalAgg) newPartialAggregate() (partial, final PhysicalPlan) {
 	p.schema = partialSchema
 	partialAgg := p.self
 	// Create physical "final" aggregation.
+	if p.tp == TypeStreamAgg {
+		finalAgg := basePhysicalAgg{
+			AggFuncs:     finalAggFuncs,
+			GroupByItems: finalGbyItems,
+		}.initForStream(p.ctx, p.stats)
+		finalAgg.schema = finalSchema
+		return partialAgg, finalAgg
+	}
+
 	finalAgg := basePhysicalAgg{
 		AggFuncs:     finalAggFuncs,
 		GroupByItems: finalGbyItems,
@@ -674,11 +782,66 @@ func RemoveUnnecessaryFirstRow(
 		if aggregation.NeedValue(aggFunc.Name) {
 			partialCursor++
 		}
+		if aggregation.NeedVariance(aggFunc.Name) {
+			partialCursor++
+		}
 		newAggFuncs = append(newAggFuncs, aggFunc)
 	}
 	return newAggFuncs
 }
 
+func (p *PhysicalStreamAgg) attach2Task(tasks ...task) task {
+	t := tasks[0].copy()
+	inputRows := t.count()
+	if cop, ok := t.(*copTask); ok {
+		// We should not push agg down across double read, since the data of second read is ordered by handle instead of index.
+		// The `extraHandleCol` is added if the double read needs to keep order. So we just use it to decided
+		// whether the following plan is double read with order reserved.
+		if cop.extraHandleCol != nil || len(cop.rootTaskConds) > 0 {
+			t = finishCopTask(p.ctx, cop)
+			inputRows = t.count()
+			attachPlan2Task(p, t)
+		} else {
+			partialAgg, finalAgg := p.newPartialAggregate()
+			if partialAgg != nil {
+				if cop.tablePlan != nil {
+					cop.finishIndexPlan()
+					partialAgg.SetChildren(cop.tablePlan)
+					cop.tablePlan = partialAgg
+				} else {
+					partialAgg.SetChildren(cop.indexPlan)
+					cop.indexPlan = partialAgg
+				}
+				cop.addCost(p.GetCost(inputRows, false))
+			}
+			t = finishCopTask(p.ctx, cop)
+			inputRows = t.count()
+			attachPlan2Task(finalAgg, t)
+		}
+	} else {
+		attachPlan2Task(p, t)
+	}
+	t.addCost(p.GetCost(inputRows, true))
+	return t
+}
+
+// GetCost computes cost of stream aggregation considering CPU/memory.
+func (p *PhysicalStreamAgg) GetCost(inputRows float64, isRoot bool) float64 {
+	aggFuncFactor := p.getAggFuncCostFactor()
+	var cpuCost float64
+	sessVars := p.ctx.GetSessionVars()
+	if isRoot {
+		cpuCost = inputRows * sessVars.CPUFactor * aggFuncFactor
+	} else {
+		cpuCost = inputRows * sessVars.CopCPUFactor * aggFuncFactor
+	}
+	// Only the rows of the current group are kept in memory, and only the
+	// DISTINCT functions need to remember them.
+	rowsPerGroup := inputRows / p.statsInfo().RowCount
+	memoryCost := rowsPerGroup * distinctFactor * sessVars.MemoryFactor * float64(p.numDistinctFunc())
+	return cpuCost + memoryCost
+}
+
 // cpuCostDivisor computes the concurrency to which we would amortize CPU cost
 // for hash aggregation.
 func (p *PhysicalHashAgg) cpuCostDivisor() (float64, float64) {
@@ -698,27 +861,33 @@ func (p *PhysicalHashAgg) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
 	inputRows := t.count()
 	if cop, ok := t.(*copTask); ok {
-		partialAgg, finalAgg := p.newPartialAggregate()
-		if partialAgg != nil {
-			if cop.tablePlan != nil {
-				cop.finishIndexPlan()
-				partialAgg.SetChildren(cop.tablePlan)
-				cop.tablePlan = partialAgg
-			} else {
-				partialAgg.SetChildren(cop.indexPlan)
-				cop.indexPlan = partialAgg
+		if len(cop.rootTaskConds) == 0 {
+			partialAgg, finalAgg := p.newPartialAggregate()
+			if partialAgg != nil {
+				if cop.tablePlan != nil {
+					cop.finishIndexPlan()
+					partialAgg.SetChildren(cop.tablePlan)
+					cop.tablePlan = partialAgg
+				} else {
+					partialAgg.SetChildren(cop.indexPlan)
+					cop.indexPlan = partialAgg
+				}
+				cop.addCost(p.GetCost(inputRows, false))
 			}
-			cop.addCost(p.GetCost(inputRows, false))
+			// In `newPartialAggregate`, we are using stats of final aggregation as stats
+			// of `partialAgg`, so the network cost of transferring result rows of `partialAgg`
+			// to TiDB is normally under-estimated for hash aggregation, since the group-by
+			// column may be independent of the column used for region distribution, so a closer
+			// estimation of network cost for hash aggregation may multiply the number of
+			// regions involved in the `partialAgg`, which is unknown however.
+			t = finishCopTask(p.ctx, cop)
+			inputRows = t.count()
+			attachPlan2Task(finalAgg, t)
+		} else {
+			t = finishCopTask(p.ctx, cop)
+			inputRows = t.count()
+			attachPlan2Task(p, t)
 		}
-		// In `newPartialAggregate`, we are using stats of final aggregation as stats
-		// of `partialAgg`, so the network cost of transferring result rows of `partialAgg`
-		// to TiDB is normally under-estimated for hash aggregation, since the group-by
-		// column may be independent of the column used for region distribution, so a closer
-		// estimation of network cost for hash aggregation may multiply the number of
-		// regions involved in the `partialAgg`, which is unknown however.
-		t = finishCopTask(p.ctx, cop)
-		inputRows = t.count()
-		attachPlan2Task(finalAgg, t)
 	} else {
 		attachPlan2Task(p, t)
 	}
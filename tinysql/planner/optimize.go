@@ -15,11 +15,17 @@ package planner
 
 import (
 	"context"
+	"strings"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/bindinfo"
+	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/planner/cascades"
 	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 )
@@ -29,6 +35,68 @@ import (
 func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) (plannercore.Plan, types.NameSlice, error) {
 	sctx.PrepareTxnFuture(ctx)
 
+	selStmt, normalizedSQL := extractSelectAndNormalize(node)
+	if selStmt == nil || sctx.GetSessionVars().InRestrictedSQL {
+		return optimize(ctx, sctx, node, is)
+	}
+	bindRecord := getBindRecord(sctx, normalizedSQL)
+	if bindRecord == nil {
+		return optimize(ctx, sctx, node, is)
+	}
+
+	// Apply the hints of the binding, and restore the original hints of the
+	// statement after optimizing, since the ast may be cached and reused.
+	oldHints := bindinfo.BindHint(selStmt, bindRecord.HintsSet)
+	plan, names, err := optimize(ctx, sctx, node, is)
+	bindinfo.BindHint(selStmt, oldHints)
+	if err == nil {
+		return plan, names, nil
+	}
+	// The binding may be out of date, fall back to the original statement.
+	sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("Failed to use the binding of %s: %s", normalizedSQL, err.Error()))
+	return optimize(ctx, sctx, node, is)
+}
+
+// extractSelectAndNormalize extracts the select statement which can be bound
+// from the node, and returns it with its normalized text.
+func extractSelectAndNormalize(node ast.Node) (*ast.SelectStmt, string) {
+	switch x := node.(type) {
+	case *ast.SelectStmt:
+		return x, parser.Normalize(x.Text())
+	case *ast.ExplainStmt:
+		selStmt, ok := x.Stmt.(*ast.SelectStmt)
+		if !ok {
+			return nil, ""
+		}
+		// The text of the explained statement is not recorded, so cut it from
+		// the normalized text of the explain statement.
+		normalizedSQL := parser.Normalize(x.Text())
+		idx := strings.Index(normalizedSQL, "select")
+		if idx < 0 {
+			return nil, ""
+		}
+		return selStmt, normalizedSQL[idx:]
+	}
+	return nil, ""
+}
+
+// getBindRecord finds the binding of the normalized sql, the session bindings
+// take precedence over the global ones.
+func getBindRecord(sctx sessionctx.Context, normalizedSQL string) *bindinfo.BindRecord {
+	db := sctx.GetSessionVars().CurrentDB
+	if sessionHandle, ok := sctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle); ok {
+		if record := sessionHandle.GetBindRecord(normalizedSQL, db); record != nil {
+			return record
+		}
+	}
+	dom := domain.GetDomain(sctx)
+	if dom == nil || dom.BindHandle() == nil {
+		return nil
+	}
+	return dom.BindHandle().GetBindRecord(normalizedSQL, db)
+}
+
+func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) (plannercore.Plan, types.NameSlice, error) {
 	// build logical plan
 	sctx.GetSessionVars().PlanID = 0
 	sctx.GetSessionVars().PlanColumnID = 0
@@ -38,6 +106,20 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		return nil, nil, err
 	}
 
+	// Maybe it's better to move this to Preprocess, but check privilege need table
+	// information, which is collected into visitInfo during logical plan builder.
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		if err := plannercore.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, builder.GetVisitInfo()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Handle the execute statement.
+	if execPlan, ok := p.(*plannercore.Execute); ok {
+		err := execPlan.OptimizePreparedPlan(ctx, sctx, is)
+		return p, p.OutputNames(), err
+	}
+
 	names := p.OutputNames()
 
 	// Handle the non-logical plan statement.
@@ -0,0 +1,42 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+// IsUpdatableSelect checks whether the rows of a select map one to one to the
+// rows of a single table, which is what makes a view over it updatable.
+func IsUpdatableSelect(sel *ast.SelectStmt) bool {
+	if sel.Distinct || sel.GroupBy != nil || sel.Having != nil {
+		return false
+	}
+	if sel.From == nil || sel.From.TableRefs.Right != nil {
+		return false
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if _, ok = ts.Source.(*ast.TableName); !ok {
+		return false
+	}
+	for _, field := range sel.Fields.Fields {
+		if field.Expr != nil && ast.HasAggFlag(field.Expr) {
+			return false
+		}
+	}
+	return true
+}
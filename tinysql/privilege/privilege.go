@@ -0,0 +1,81 @@
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privilege
+
+import (
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+)
+
+type keyType int
+
+func (k keyType) String() string {
+	return "privilege-key"
+}
+
+// Manager is the interface for providing privilege related operations.
+type Manager interface {
+	// ShowGrants shows granted privileges for user, the privileges of the
+	// roles are merged into the ones of the user.
+	ShowGrants(ctx sessionctx.Context, user *auth.UserIdentity, roles []*auth.RoleIdentity) ([]string, error)
+
+	// RequestVerification verifies user privilege for the request.
+	// If table is "", only check global/db scope privileges.
+	// If table is not "", check global/db/table scope privileges.
+	// The privileges of the active roles are checked too.
+	RequestVerification(activeRole []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool
+
+	// RequestVerificationWithUser verifies specific user privilege for the request.
+	RequestVerificationWithUser(db, table, column string, priv mysql.PrivilegeType, user *auth.UserIdentity) bool
+
+	// ConnectionVerification verifies user privilege for connection.
+	// It returns the user name and host of the matched account if the
+	// verification succeeds.
+	ConnectionVerification(user, host string, auth, salt []byte) (string, string, bool)
+
+	// DBIsVisible returns true is the database is visible to current user.
+	DBIsVisible(activeRole []*auth.RoleIdentity, db string) bool
+
+	// ActiveRoles activates the roles for the current session, it returns
+	// false and the first role which is not granted to the user if any.
+	ActiveRoles(ctx sessionctx.Context, roleList []*auth.RoleIdentity) (bool, string)
+
+	// FindEdge checks whether the role is granted to the user, the
+	// mandatory roles are granted to all the users.
+	FindEdge(ctx sessionctx.Context, role *auth.RoleIdentity, user *auth.UserIdentity) bool
+
+	// GetDefaultRoles returns the roles activated when the user logs in,
+	// including the mandatory roles.
+	GetDefaultRoles(ctx sessionctx.Context, user, host string) []*auth.RoleIdentity
+
+	// GetAllRoles returns all the roles granted to the user, including the
+	// mandatory roles.
+	GetAllRoles(ctx sessionctx.Context, user, host string) []*auth.RoleIdentity
+}
+
+const key keyType = 0
+
+// BindPrivilegeManager binds Manager to context.
+func BindPrivilegeManager(ctx sessionctx.Context, pc Manager) {
+	ctx.SetValue(key, pc)
+}
+
+// GetPrivilegeManager gets Checker from context.
+func GetPrivilegeManager(ctx sessionctx.Context) Manager {
+	if v, ok := ctx.Value(key).(Manager); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,633 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+var (
+	// userTablePrivs are the privileges stored in the columns of mysql.user.
+	userTablePrivs = append(append([]mysql.PrivilegeType{}, mysql.AllGlobalPrivs...), mysql.GrantPriv)
+	// dbTablePrivs are the privileges stored in the columns of mysql.db.
+	dbTablePrivs = append(append([]mysql.PrivilegeType{}, mysql.AllDBPrivs...), mysql.GrantPriv)
+
+	userTablePrivilegeMask = computePrivMask(mysql.AllGlobalPrivs)
+	dbTablePrivilegeMask   = computePrivMask(mysql.AllDBPrivs)
+	tablePrivMask          = computePrivMask(mysql.AllTablePrivs)
+)
+
+func computePrivMask(privs []mysql.PrivilegeType) mysql.PrivilegeType {
+	var mask mysql.PrivilegeType
+	for _, p := range privs {
+		mask |= p
+	}
+	return mask
+}
+
+// baseRecord is the common part of the records in the privilege tables.
+type baseRecord struct {
+	Host string // max length 60, primary key
+	User string // max length 32, primary key
+
+	// patChars is compiled from Host, cached for pattern match performance.
+	patChars []byte
+	patTypes []byte
+}
+
+// UserRecord is used to represent a user record in privilege cache.
+type UserRecord struct {
+	baseRecord
+
+	AuthenticationString string
+	Privileges           mysql.PrivilegeType
+	// AccountLocked is true for the roles, which can't log in.
+	AccountLocked bool
+}
+
+type dbRecord struct {
+	baseRecord
+
+	DB         string
+	Privileges mysql.PrivilegeType
+}
+
+type tablesPrivRecord struct {
+	baseRecord
+
+	DB        string
+	TableName string
+	Grantor   string
+	TablePriv mysql.PrivilegeType
+}
+
+type defaultRoleRecord struct {
+	baseRecord
+
+	DefaultRoleUser string
+	DefaultRoleHost string
+}
+
+// roleGraphEdgesTable is the set of the roles granted to a user.
+type roleGraphEdgesTable struct {
+	roleList map[string]*auth.RoleIdentity
+}
+
+// Find checks whether the role is in the set.
+func (g roleGraphEdgesTable) Find(user, host string) bool {
+	if host == "" {
+		host = "%"
+	}
+	if g.roleList == nil {
+		return false
+	}
+	_, ok := g.roleList[roleKey(user, host)]
+	return ok
+}
+
+func roleKey(user, host string) string {
+	return fmt.Sprintf("`%s`@`%s`", user, host)
+}
+
+// MySQLPrivilege is the in-memory cache of mysql privilege tables.
+type MySQLPrivilege struct {
+	User         []UserRecord
+	DB           []dbRecord
+	TablesPriv   []tablesPrivRecord
+	DefaultRoles []defaultRoleRecord
+	// RoleGraph maps the users to the roles granted to them.
+	RoleGraph map[string]roleGraphEdgesTable
+}
+
+// LoadAll loads the tables from database to memory.
+func (p *MySQLPrivilege) LoadAll(ctx sessionctx.Context) error {
+	if err := p.LoadUserTable(ctx); err != nil {
+		return err
+	}
+	if err := p.LoadDBTable(ctx); err != nil {
+		return err
+	}
+	if err := p.LoadTablesPrivTable(ctx); err != nil {
+		return err
+	}
+	if err := p.LoadDefaultRoles(ctx); err != nil {
+		return err
+	}
+	return p.LoadRoleGraph(ctx)
+}
+
+func privColumns(privs []mysql.PrivilegeType) string {
+	cols := make([]string, 0, len(privs))
+	for _, priv := range privs {
+		cols = append(cols, mysql.Priv2UserCol[priv])
+	}
+	return strings.Join(cols, ", ")
+}
+
+// decodePrivColumns decodes the 'Y'/'N' privilege columns starting at offset.
+func decodePrivColumns(row chunk.Row, offset int, privs []mysql.PrivilegeType) mysql.PrivilegeType {
+	var value mysql.PrivilegeType
+	for i, priv := range privs {
+		if row.GetString(offset+i) == "Y" {
+			value |= priv
+		}
+	}
+	return value
+}
+
+func newBaseRecord(host, user string) baseRecord {
+	patChars, patTypes := stringutil.CompilePattern(strings.ToLower(host), '\\')
+	return baseRecord{
+		Host:     host,
+		User:     user,
+		patChars: patChars,
+		patTypes: patTypes,
+	}
+}
+
+// LoadUserTable loads the mysql.user table from database.
+func (p *MySQLPrivilege) LoadUserTable(ctx sessionctx.Context) error {
+	sql := fmt.Sprintf("SELECT HIGH_PRIORITY Host, User, authentication_string, Account_locked, %s FROM %s.%s",
+		privColumns(userTablePrivs), mysql.SystemDB, mysql.UserTable)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return err
+	}
+	p.User = make([]UserRecord, 0, len(rows))
+	for _, row := range rows {
+		p.User = append(p.User, UserRecord{
+			baseRecord:           newBaseRecord(row.GetString(0), row.GetString(1)),
+			AuthenticationString: row.GetString(2),
+			AccountLocked:        row.GetString(3) == "Y",
+			Privileges:           decodePrivColumns(row, 4, userTablePrivs),
+		})
+	}
+	// The more specific records are matched first.
+	sort.SliceStable(p.User, func(i, j int) bool {
+		return compareBaseRecord(&p.User[i].baseRecord, &p.User[j].baseRecord)
+	})
+	return nil
+}
+
+// LoadDBTable loads the mysql.db table from database.
+func (p *MySQLPrivilege) LoadDBTable(ctx sessionctx.Context) error {
+	sql := fmt.Sprintf("SELECT HIGH_PRIORITY Host, DB, User, %s FROM %s.%s",
+		privColumns(dbTablePrivs), mysql.SystemDB, mysql.DBTable)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return err
+	}
+	p.DB = make([]dbRecord, 0, len(rows))
+	for _, row := range rows {
+		p.DB = append(p.DB, dbRecord{
+			baseRecord: newBaseRecord(row.GetString(0), row.GetString(2)),
+			DB:         row.GetString(1),
+			Privileges: decodePrivColumns(row, 3, dbTablePrivs),
+		})
+	}
+	sort.SliceStable(p.DB, func(i, j int) bool {
+		return compareBaseRecord(&p.DB[i].baseRecord, &p.DB[j].baseRecord)
+	})
+	return nil
+}
+
+// LoadTablesPrivTable loads the mysql.tables_priv table from database.
+func (p *MySQLPrivilege) LoadTablesPrivTable(ctx sessionctx.Context) error {
+	sql := fmt.Sprintf("SELECT HIGH_PRIORITY Host, DB, User, Table_name, Grantor, Table_priv FROM %s.%s",
+		mysql.SystemDB, mysql.TablePrivTable)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return err
+	}
+	p.TablesPriv = make([]tablesPrivRecord, 0, len(rows))
+	for _, row := range rows {
+		p.TablesPriv = append(p.TablesPriv, tablesPrivRecord{
+			baseRecord: newBaseRecord(row.GetString(0), row.GetString(2)),
+			DB:         row.GetString(1),
+			TableName:  row.GetString(3),
+			Grantor:    row.GetString(4),
+			TablePriv:  decodeSetToPrivilege(row.GetString(5)),
+		})
+	}
+	sort.SliceStable(p.TablesPriv, func(i, j int) bool {
+		return compareBaseRecord(&p.TablesPriv[i].baseRecord, &p.TablesPriv[j].baseRecord)
+	})
+	return nil
+}
+
+// LoadDefaultRoles loads the mysql.default_roles table from database.
+func (p *MySQLPrivilege) LoadDefaultRoles(ctx sessionctx.Context) error {
+	sql := fmt.Sprintf("SELECT HIGH_PRIORITY HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER FROM %s.%s",
+		mysql.SystemDB, mysql.DefaultRoleTable)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return err
+	}
+	p.DefaultRoles = make([]defaultRoleRecord, 0, len(rows))
+	for _, row := range rows {
+		p.DefaultRoles = append(p.DefaultRoles, defaultRoleRecord{
+			baseRecord:      newBaseRecord(row.GetString(0), row.GetString(1)),
+			DefaultRoleHost: row.GetString(2),
+			DefaultRoleUser: row.GetString(3),
+		})
+	}
+	return nil
+}
+
+// LoadRoleGraph loads the mysql.role_edges table from database.
+func (p *MySQLPrivilege) LoadRoleGraph(ctx sessionctx.Context) error {
+	sql := fmt.Sprintf("SELECT HIGH_PRIORITY FROM_USER, FROM_HOST, TO_USER, TO_HOST FROM %s.%s",
+		mysql.SystemDB, mysql.RoleEdgeTable)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(sql)
+	if err != nil {
+		return err
+	}
+	p.RoleGraph = make(map[string]roleGraphEdgesTable)
+	for _, row := range rows {
+		fromUser, fromHost := row.GetString(0), row.GetString(1)
+		toKey := roleKey(row.GetString(2), row.GetString(3))
+		edges, ok := p.RoleGraph[toKey]
+		if !ok {
+			edges = roleGraphEdgesTable{roleList: make(map[string]*auth.RoleIdentity)}
+			p.RoleGraph[toKey] = edges
+		}
+		edges.roleList[roleKey(fromUser, fromHost)] = &auth.RoleIdentity{Username: fromUser, Hostname: fromHost}
+	}
+	return nil
+}
+
+// decodeSetToPrivilege decodes the comma separated privilege list stored in
+// the Table_priv column.
+func decodeSetToPrivilege(s string) mysql.PrivilegeType {
+	var ret mysql.PrivilegeType
+	if s == "" {
+		return ret
+	}
+	for _, str := range strings.Split(s, ",") {
+		ret |= mysql.SetStr2Priv[str]
+	}
+	return ret
+}
+
+// EncodePrivilegeToSet encodes the privileges to the comma separated list
+// stored in the Table_priv column.
+func EncodePrivilegeToSet(priv mysql.PrivilegeType) string {
+	strs := make([]string, 0, len(mysql.AllTablePrivs)+1)
+	for _, p := range append(append([]mysql.PrivilegeType{}, mysql.AllTablePrivs...), mysql.GrantPriv) {
+		if priv&p != 0 {
+			strs = append(strs, mysql.Priv2SetStr[p])
+		}
+	}
+	return strings.Join(strs, ",")
+}
+
+func compareBaseRecord(x, y *baseRecord) bool {
+	// Compare two item by user's host first.
+	c1 := compareHost(x.Host, y.Host)
+	if c1 != 0 {
+		return c1 < 0
+	}
+	// Then, compare item by user's name value, the anonymous user is the
+	// least specific.
+	return x.User > y.User
+}
+
+// compareHost compares two host strings, the more specific one is smaller.
+// Hosts without wildcards are the most specific, '%' is the least specific.
+func compareHost(x, y string) int {
+	rx, ry := hostRank(x), hostRank(y)
+	if rx != ry {
+		return rx - ry
+	}
+	// Hosts with the same kind of pattern, the longer one is more specific,
+	// e.g. 192.168.1.% is more specific than 192.168.%.
+	if len(x) != len(y) {
+		return len(y) - len(x)
+	}
+	return strings.Compare(x, y)
+}
+
+func hostRank(host string) int {
+	switch {
+	case host == "" || host == "%":
+		return 2
+	case strings.ContainsAny(host, "%_"):
+		return 1
+	}
+	return 0
+}
+
+func (record *baseRecord) match(user, host string) bool {
+	return record.User == user && stringutil.DoMatch(strings.ToLower(host), record.patChars, record.patTypes)
+}
+
+func (record *baseRecord) fullyMatch(user, host string) bool {
+	return record.User == user && strings.EqualFold(record.Host, host)
+}
+
+func (p *MySQLPrivilege) matchUser(user, host string) *UserRecord {
+	for i := 0; i < len(p.User); i++ {
+		record := &p.User[i]
+		if record.match(user, host) {
+			return record
+		}
+	}
+	return nil
+}
+
+func (p *MySQLPrivilege) matchDB(user, host, db string) *dbRecord {
+	for i := 0; i < len(p.DB); i++ {
+		record := &p.DB[i]
+		if record.match(user, host) && strings.EqualFold(record.DB, db) {
+			return record
+		}
+	}
+	return nil
+}
+
+func (p *MySQLPrivilege) matchTables(user, host, db, table string) *tablesPrivRecord {
+	for i := 0; i < len(p.TablesPriv); i++ {
+		record := &p.TablesPriv[i]
+		if record.match(user, host) && strings.EqualFold(record.DB, db) &&
+			strings.EqualFold(record.TableName, table) {
+			return record
+		}
+	}
+	return nil
+}
+
+// FindRole checks whether the role is granted to the user directly.
+func (p *MySQLPrivilege) FindRole(user string, host string, role *auth.RoleIdentity) bool {
+	return p.RoleGraph[roleKey(user, host)].Find(role.Username, role.Hostname)
+}
+
+// FindAllRole returns the roles and all the roles granted to them
+// transitively. Every role is visited only once, so that the cycles in the
+// role graph don't matter.
+func (p *MySQLPrivilege) FindAllRole(activeRoles []*auth.RoleIdentity) []*auth.RoleIdentity {
+	queue := make([]*auth.RoleIdentity, 0, len(activeRoles))
+	visited := make(map[string]bool)
+	for _, role := range activeRoles {
+		key := roleKey(role.Username, role.Hostname)
+		if !visited[key] {
+			visited[key] = true
+			queue = append(queue, role)
+		}
+	}
+	for head := 0; head < len(queue); head++ {
+		role := queue[head]
+		for key, granted := range p.RoleGraph[roleKey(role.Username, role.Hostname)].roleList {
+			if !visited[key] {
+				visited[key] = true
+				queue = append(queue, granted)
+			}
+		}
+	}
+	return queue
+}
+
+// getDefaultRoles returns the default roles of the user.
+func (p *MySQLPrivilege) getDefaultRoles(user, host string) []*auth.RoleIdentity {
+	ret := make([]*auth.RoleIdentity, 0)
+	for i := 0; i < len(p.DefaultRoles); i++ {
+		record := &p.DefaultRoles[i]
+		if record.fullyMatch(user, host) {
+			ret = append(ret, &auth.RoleIdentity{Username: record.DefaultRoleUser, Hostname: record.DefaultRoleHost})
+		}
+	}
+	return ret
+}
+
+// getAllRoles returns the roles granted to the user directly.
+func (p *MySQLPrivilege) getAllRoles(user, host string) []*auth.RoleIdentity {
+	edges := p.RoleGraph[roleKey(user, host)].roleList
+	ret := make([]*auth.RoleIdentity, 0, len(edges))
+	for _, role := range edges {
+		ret = append(ret, role)
+	}
+	sortRoles(ret)
+	return ret
+}
+
+func sortRoles(roles []*auth.RoleIdentity) {
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].String() < roles[j].String()
+	})
+}
+
+// RequestVerification checks whether the user have sufficient privileges to do the operation.
+// The privileges of the active roles are granted to the user.
+func (p *MySQLPrivilege) RequestVerification(activeRoles []*auth.RoleIdentity, user, host, db, table, column string, priv mysql.PrivilegeType) bool {
+	if p.requestVerification(user, host, db, table, priv) {
+		return true
+	}
+	for _, role := range p.FindAllRole(activeRoles) {
+		if p.requestVerification(role.Username, role.Hostname, db, table, priv) {
+			return true
+		}
+	}
+	return priv == 0
+}
+
+func (p *MySQLPrivilege) requestVerification(user, host, db, table string, priv mysql.PrivilegeType) bool {
+	if record := p.matchUser(user, host); record != nil && record.Privileges&priv > 0 {
+		return true
+	}
+	if record := p.matchDB(user, host, db); record != nil && record.Privileges&priv > 0 {
+		return true
+	}
+	if record := p.matchTables(user, host, db, table); record != nil && record.TablePriv&priv > 0 {
+		return true
+	}
+	return false
+}
+
+// DBIsVisible checks whether the user can see the db.
+func (p *MySQLPrivilege) DBIsVisible(activeRoles []*auth.RoleIdentity, user, host, db string) bool {
+	// INFORMATION_SCHEMA is visible to all users.
+	if strings.EqualFold(db, "INFORMATION_SCHEMA") {
+		return true
+	}
+	if p.dbIsVisible(user, host, db) {
+		return true
+	}
+	for _, role := range p.FindAllRole(activeRoles) {
+		if p.dbIsVisible(role.Username, role.Hostname, db) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *MySQLPrivilege) dbIsVisible(user, host, db string) bool {
+	if record := p.matchUser(user, host); record != nil && record.Privileges != 0 {
+		return true
+	}
+	if record := p.matchDB(user, host, db); record != nil && record.Privileges > 0 {
+		return true
+	}
+	for i := 0; i < len(p.TablesPriv); i++ {
+		record := &p.TablesPriv[i]
+		if record.match(user, host) && strings.EqualFold(record.DB, db) && record.TablePriv != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// UserExists checks whether the account of user@host exists.
+func (p *MySQLPrivilege) UserExists(user, host string) bool {
+	for i := 0; i < len(p.User); i++ {
+		if p.User[i].fullyMatch(user, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// showGrants returns the GRANT statements of the user, the privileges of the
+// roles are merged into the ones of the user.
+func (p *MySQLPrivilege) showGrants(user, host string, roles []*auth.RoleIdentity) []string {
+	var userRecord *UserRecord
+	for i := 0; i < len(p.User); i++ {
+		if p.User[i].fullyMatch(user, host) {
+			userRecord = &p.User[i]
+			break
+		}
+	}
+	if userRecord == nil {
+		return nil
+	}
+
+	identities := []*auth.RoleIdentity{{Username: user, Hostname: host}}
+	identities = append(identities, p.FindAllRole(roles)...)
+	globalPriv := userRecord.Privileges
+	dbPrivs := make(map[string]mysql.PrivilegeType)
+	tablePrivs := make(map[string]mysql.PrivilegeType)
+	for _, identity := range identities {
+		u, h := identity.Username, identity.Hostname
+		if identity != identities[0] {
+			for i := 0; i < len(p.User); i++ {
+				if p.User[i].fullyMatch(u, h) {
+					globalPriv |= p.User[i].Privileges
+				}
+			}
+		}
+		for i := 0; i < len(p.DB); i++ {
+			if record := &p.DB[i]; record.fullyMatch(u, h) {
+				dbPrivs[fmt.Sprintf("`%s`.*", record.DB)] |= record.Privileges
+			}
+		}
+		for i := 0; i < len(p.TablesPriv); i++ {
+			if record := &p.TablesPriv[i]; record.fullyMatch(u, h) {
+				tablePrivs[fmt.Sprintf("`%s`.`%s`", record.DB, record.TableName)] |= record.TablePriv
+			}
+		}
+	}
+
+	gs := []string{grantString(globalPriv, userTablePrivilegeMask, mysql.AllGlobalPrivs, "*.*", user, host)}
+	for _, object := range sortedObjects(dbPrivs) {
+		gs = append(gs, grantString(dbPrivs[object], dbTablePrivilegeMask, mysql.AllDBPrivs, object, user, host))
+	}
+	for _, object := range sortedObjects(tablePrivs) {
+		gs = append(gs, grantString(tablePrivs[object], tablePrivMask, mysql.AllTablePrivs, object, user, host))
+	}
+
+	// The roles granted to the user.
+	if granted := p.getAllRoles(user, host); len(granted) > 0 {
+		strs := make([]string, 0, len(granted))
+		for _, role := range granted {
+			strs = append(strs, role.String())
+		}
+		gs = append(gs, fmt.Sprintf("GRANT %s TO '%s'@'%s'", strings.Join(strs, ","), user, host))
+	}
+	return gs
+}
+
+// sortedObjects returns the objects which have privileges in order.
+func sortedObjects(privs map[string]mysql.PrivilegeType) []string {
+	objects := make([]string, 0, len(privs))
+	for object, priv := range privs {
+		if priv != 0 {
+			objects = append(objects, object)
+		}
+	}
+	sort.Strings(objects)
+	return objects
+}
+
+// grantString builds the GRANT statement which grants priv on the object.
+func grantString(priv, allPrivMask mysql.PrivilegeType, allPrivs []mysql.PrivilegeType, object, user, host string) string {
+	var privStr string
+	switch {
+	case priv&allPrivMask == allPrivMask:
+		privStr = "ALL PRIVILEGES"
+	case priv&allPrivMask == 0:
+		privStr = "USAGE"
+	default:
+		privStr = privToString(priv, allPrivs)
+	}
+	s := fmt.Sprintf("GRANT %s ON %s TO '%s'@'%s'", privStr, object, user, host)
+	if priv&mysql.GrantPriv != 0 {
+		s += " WITH GRANT OPTION"
+	}
+	return s
+}
+
+func privToString(priv mysql.PrivilegeType, allPrivs []mysql.PrivilegeType) string {
+	pstrs := make([]string, 0, len(allPrivs))
+	for _, p := range allPrivs {
+		if priv&p != 0 {
+			pstrs = append(pstrs, mysql.Priv2Str[p])
+		}
+	}
+	return strings.Join(pstrs, ",")
+}
+
+// Handle wraps MySQLPrivilege providing thread safe access.
+type Handle struct {
+	priv atomic.Value
+}
+
+// NewHandle returns a Handle.
+func NewHandle() *Handle {
+	h := &Handle{}
+	h.priv.Store(&MySQLPrivilege{})
+	return h
+}
+
+// Get the MySQLPrivilege for read.
+func (h *Handle) Get() *MySQLPrivilege {
+	return h.priv.Load().(*MySQLPrivilege)
+}
+
+// Update loads all the privilege info from kv storage.
+func (h *Handle) Update(ctx sessionctx.Context) error {
+	var priv MySQLPrivilege
+	if err := priv.LoadAll(ctx); err != nil {
+		return err
+	}
+	h.priv.Store(&priv)
+	return nil
+}
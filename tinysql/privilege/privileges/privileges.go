@@ -0,0 +1,260 @@
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges
+
+import (
+	"strings"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// SkipWithGrant causes the server to start without using the privilege system at all.
+var SkipWithGrant = false
+
+var errNonexistingGrant = terror.ClassPrivilege.New(mysql.ErrNonexistingGrant, mysql.MySQLErrName[mysql.ErrNonexistingGrant])
+
+// UserPrivileges implements privilege.Manager interface.
+// This is used to check privilege for the current user.
+type UserPrivileges struct {
+	user string
+	host string
+	*Handle
+}
+
+var _ privilege.Manager = (*UserPrivileges)(nil)
+
+// RequestVerification implements the Manager interface.
+func (p *UserPrivileges) RequestVerification(activeRoles []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool {
+	if SkipWithGrant {
+		return true
+	}
+	// The sessions without a user are internal ones, which have all the
+	// privileges.
+	if p.user == "" && p.host == "" {
+		return true
+	}
+	// Skip check for INFORMATION_SCHEMA database.
+	if strings.EqualFold(db, "INFORMATION_SCHEMA") {
+		return true
+	}
+	return p.Handle.Get().RequestVerification(activeRoles, p.user, p.host, db, table, column, priv)
+}
+
+// RequestVerificationWithUser implements the Manager interface.
+func (p *UserPrivileges) RequestVerificationWithUser(db, table, column string, priv mysql.PrivilegeType, user *auth.UserIdentity) bool {
+	if SkipWithGrant {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	// Skip check for INFORMATION_SCHEMA database.
+	if strings.EqualFold(db, "INFORMATION_SCHEMA") {
+		return true
+	}
+	return p.Handle.Get().RequestVerification(nil, user.Username, user.Hostname, db, table, column, priv)
+}
+
+// ConnectionVerification implements the Manager interface.
+func (p *UserPrivileges) ConnectionVerification(user, host string, authentication, salt []byte) (u string, h string, success bool) {
+	if SkipWithGrant {
+		p.user = user
+		p.host = host
+		return user, host, true
+	}
+
+	record := p.Handle.Get().matchUser(user, host)
+	if record == nil {
+		logutil.BgLogger().Error("get user privilege record fail",
+			zap.String("user", user), zap.String("host", host))
+		return
+	}
+	// The roles are locked accounts, they can't log in.
+	if record.AccountLocked {
+		logutil.BgLogger().Error("try to login a locked account",
+			zap.String("user", user), zap.String("host", host))
+		return
+	}
+
+	pwd := record.AuthenticationString
+	if len(pwd) != 0 && len(pwd) != mysql.PWDHashLen+1 {
+		logutil.BgLogger().Error("user password from system DB not like sha1sum", zap.String("user", user))
+		return
+	}
+
+	// If the user has no password, the client must not send one either.
+	if len(pwd) == 0 || len(authentication) == 0 {
+		if len(pwd) != 0 || len(authentication) != 0 {
+			return
+		}
+	} else {
+		hpwd, err := auth.DecodePassword(pwd)
+		if err != nil {
+			logutil.BgLogger().Error("decode password string failed", zap.Error(err))
+			return
+		}
+		if !auth.CheckScrambledPassword(salt, hpwd, authentication) {
+			return
+		}
+	}
+
+	p.user = user
+	p.host = record.Host
+	return record.User, record.Host, true
+}
+
+// DBIsVisible implements the Manager interface.
+func (p *UserPrivileges) DBIsVisible(activeRoles []*auth.RoleIdentity, db string) bool {
+	if SkipWithGrant {
+		return true
+	}
+	if p.user == "" && p.host == "" {
+		return true
+	}
+	return p.Handle.Get().DBIsVisible(activeRoles, p.user, p.host, db)
+}
+
+// ShowGrants implements privilege.Manager ShowGrants interface.
+func (p *UserPrivileges) ShowGrants(ctx sessionctx.Context, user *auth.UserIdentity, roles []*auth.RoleIdentity) (grants []string, err error) {
+	u, h := user.Username, user.Hostname
+	if len(user.AuthUsername) > 0 || len(user.AuthHostname) > 0 {
+		u, h = user.AuthUsername, user.AuthHostname
+	}
+	grants = p.Handle.Get().showGrants(u, h, roles)
+	if len(grants) == 0 {
+		err = errNonexistingGrant.GenWithStackByArgs(u, h)
+	}
+	return
+}
+
+// ActiveRoles implements privilege.Manager ActiveRoles interface.
+func (p *UserPrivileges) ActiveRoles(ctx sessionctx.Context, roleList []*auth.RoleIdentity) (bool, string) {
+	if SkipWithGrant {
+		return true, ""
+	}
+	user := ctx.GetSessionVars().User
+	if user == nil {
+		return true, ""
+	}
+	for _, role := range roleList {
+		if !p.FindEdge(ctx, role, user) {
+			return false, role.String()
+		}
+	}
+	ctx.GetSessionVars().ActiveRoles = roleList
+	return true, ""
+}
+
+// FindEdge implements privilege.Manager FindEdge interface.
+func (p *UserPrivileges) FindEdge(ctx sessionctx.Context, role *auth.RoleIdentity, user *auth.UserIdentity) bool {
+	u, h := user.Username, user.Hostname
+	if len(user.AuthUsername) > 0 || len(user.AuthHostname) > 0 {
+		u, h = user.AuthUsername, user.AuthHostname
+	}
+	mysqlPrivilege := p.Handle.Get()
+	if mysqlPrivilege.FindRole(u, h, role) {
+		return true
+	}
+	for _, mandatory := range mandatoryRoles(ctx, mysqlPrivilege) {
+		if mandatory.Username == role.Username && mandatory.Hostname == role.Hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDefaultRoles implements privilege.Manager GetDefaultRoles interface.
+func (p *UserPrivileges) GetDefaultRoles(ctx sessionctx.Context, user, host string) []*auth.RoleIdentity {
+	mysqlPrivilege := p.Handle.Get()
+	return mergeRoles(mysqlPrivilege.getDefaultRoles(user, host), mandatoryRoles(ctx, mysqlPrivilege))
+}
+
+// GetAllRoles implements privilege.Manager GetAllRoles interface.
+func (p *UserPrivileges) GetAllRoles(ctx sessionctx.Context, user, host string) []*auth.RoleIdentity {
+	if SkipWithGrant {
+		return nil
+	}
+	mysqlPrivilege := p.Handle.Get()
+	return mergeRoles(mysqlPrivilege.getAllRoles(user, host), mandatoryRoles(ctx, mysqlPrivilege))
+}
+
+// mandatoryRoles returns the existing roles in the mandatory_roles variable.
+func mandatoryRoles(ctx sessionctx.Context, mysqlPrivilege *MySQLPrivilege) []*auth.RoleIdentity {
+	value, err := variable.GetGlobalSystemVar(ctx.GetSessionVars(), variable.MandatoryRoles)
+	if err != nil {
+		logutil.BgLogger().Warn("get mandatory roles failed", zap.Error(err))
+		return nil
+	}
+	var roles []*auth.RoleIdentity
+	for _, role := range ParseRoleList(value) {
+		if mysqlPrivilege.UserExists(role.Username, role.Hostname) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// ParseRoleList parses a comma separated list of roles, such as
+// "r1,`r2`@`%`,'r3'@'localhost'". The host of a role defaults to '%'.
+func ParseRoleList(s string) []*auth.RoleIdentity {
+	var roles []*auth.RoleIdentity
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, host := item, "%"
+		if pos := strings.LastIndex(item, "@"); pos >= 0 {
+			name, host = item[:pos], item[pos+1:]
+		}
+		roles = append(roles, &auth.RoleIdentity{Username: unquoteName(name), Hostname: unquoteName(host)})
+	}
+	return roles
+}
+
+func unquoteName(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '`' || s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// mergeRoles returns the roles in x and y without duplication.
+func mergeRoles(x, y []*auth.RoleIdentity) []*auth.RoleIdentity {
+	ret := make([]*auth.RoleIdentity, 0, len(x)+len(y))
+	seen := make(map[string]bool)
+	for _, roles := range [][]*auth.RoleIdentity{x, y} {
+		for _, role := range roles {
+			if key := role.String(); !seen[key] {
+				seen[key] = true
+				ret = append(ret, role)
+			}
+		}
+	}
+	return ret
+}
+
+func init() {
+	terror.ErrClassToMySQLCodes[terror.ClassPrivilege] = map[terror.ErrCode]uint16{
+		mysql.ErrNonexistingGrant: mysql.ErrNonexistingGrant,
+	}
+}
@@ -0,0 +1,452 @@
+// Copyright 2015 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privileges_test
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/util/testkit"
+	"github.com/pingcap/tidb/util/testleak"
+)
+
+func TestT(t *testing.T) {
+	CustomVerboseFlag = true
+	TestingT(t)
+}
+
+var _ = Suite(&testPrivilegeSuite{})
+
+type testPrivilegeSuite struct {
+	store kv.Storage
+	dom   *domain.Domain
+}
+
+func (s *testPrivilegeSuite) SetUpSuite(c *C) {
+	testleak.BeforeTest()
+	var err error
+	s.store, err = mockstore.NewMockTikvStore()
+	c.Assert(err, IsNil)
+	session.SetSchemaLease(0)
+	session.DisableStats4Test()
+	s.dom, err = session.BootstrapSession(s.store)
+	c.Assert(err, IsNil)
+}
+
+func (s *testPrivilegeSuite) TearDownSuite(c *C) {
+	s.dom.Close()
+	s.store.Close()
+	testleak.AfterTest(c)()
+}
+
+// newSession creates a session authenticated as user@localhost.
+func (s *testPrivilegeSuite) newSession(c *C, user string) *testkit.TestKit {
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: user, Hostname: "localhost"}, nil, nil), IsTrue)
+	tk := testkit.NewTestKit(c, s.store)
+	tk.Se = se
+	return tk
+}
+
+// showsDB checks whether SHOW DATABASES lists the database.
+func (s *testPrivilegeSuite) showsDB(tk *testkit.TestKit, db string) bool {
+	for _, row := range tk.MustQuery("show databases").Rows() {
+		if row[0] == db {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *testPrivilegeSuite) TestCheckDBPrivilege(c *C) {
+	rootTk := testkit.NewTestKit(c, s.store)
+	rootTk.MustExec("create user 'testcheck'@'localhost'")
+	rootTk.MustExec("create user 'testcheck_tmp'@'localhost'")
+
+	tk := s.newSession(c, "testcheck")
+	pc := privilege.GetPrivilegeManager(tk.Se)
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.SelectPriv), IsFalse)
+
+	rootTk.MustExec("grant select on *.* to 'testcheck'@'localhost'")
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.SelectPriv), IsTrue)
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.UpdatePriv), IsFalse)
+
+	rootTk.MustExec("grant update on test.* to 'testcheck'@'localhost'")
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.UpdatePriv), IsTrue)
+	c.Assert(pc.RequestVerification(nil, "mysql", "", "", mysql.UpdatePriv), IsFalse)
+
+	rootTk.MustExec("revoke select on *.* from 'testcheck'@'localhost'")
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.SelectPriv), IsFalse)
+
+	// Another user doesn't get the privileges.
+	tk = s.newSession(c, "testcheck_tmp")
+	pc = privilege.GetPrivilegeManager(tk.Se)
+	c.Assert(pc.RequestVerification(nil, "test", "", "", mysql.UpdatePriv), IsFalse)
+}
+
+func (s *testPrivilegeSuite) TestCheckTablePrivilege(c *C) {
+	rootTk := testkit.NewTestKit(c, s.store)
+	rootTk.MustExec("use test")
+	rootTk.MustExec("drop table if exists t_priv")
+	rootTk.MustExec("create table t_priv (a int)")
+	rootTk.MustExec("create user 'test1'@'localhost'")
+
+	tk := s.newSession(c, "test1")
+	pc := privilege.GetPrivilegeManager(tk.Se)
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.SelectPriv), IsFalse)
+
+	rootTk.MustExec("grant select, insert on test.t_priv to 'test1'@'localhost'")
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.SelectPriv), IsTrue)
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.InsertPriv), IsTrue)
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.DeletePriv), IsFalse)
+	c.Assert(pc.RequestVerification(nil, "test", "t_other", "", mysql.SelectPriv), IsFalse)
+
+	rootTk.MustExec("revoke insert on test.t_priv from 'test1'@'localhost'")
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.SelectPriv), IsTrue)
+	c.Assert(pc.RequestVerification(nil, "test", "t_priv", "", mysql.InsertPriv), IsFalse)
+
+	_, err := rootTk.Exec("revoke delete on test.t_priv from 'test1'@'%'")
+	c.Assert(err, NotNil)
+	_, err = rootTk.Exec("grant select on test.t_no_exists to 'test1'@'localhost'")
+	c.Assert(err, NotNil)
+}
+
+func (s *testPrivilegeSuite) TestShowGrants(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_show")
+	tk.MustExec("create table t_show (a int)")
+	tk.MustExec("create user 'show'@'localhost'")
+	tk.MustQuery("show grants for 'show'@'localhost'").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'show'@'localhost'"))
+
+	tk.MustExec("grant select, insert on *.* to 'show'@'localhost'")
+	tk.MustExec("grant all on test.* to 'show'@'localhost'")
+	tk.MustExec("grant select on test.t_show to 'show'@'localhost' with grant option")
+	tk.MustQuery("show grants for 'show'@'localhost'").Check(testkit.Rows(
+		"GRANT Select,Insert ON *.* TO 'show'@'localhost'",
+		"GRANT ALL PRIVILEGES ON `test`.* TO 'show'@'localhost'",
+		"GRANT Select ON `test`.`t_show` TO 'show'@'localhost' WITH GRANT OPTION",
+	))
+
+	tk.MustExec("grant all on *.* to 'show'@'localhost' with grant option")
+	c.Assert(tk.MustQuery("show grants for 'show'@'localhost'").Rows()[0][0], Equals,
+		"GRANT ALL PRIVILEGES ON *.* TO 'show'@'localhost' WITH GRANT OPTION")
+
+	// The user sees its own grants.
+	se := s.newSession(c, "show")
+	c.Assert(len(se.MustQuery("show grants").Rows()), Equals, 3)
+
+	err := tk.QueryToErr("show grants for 'no_exists'@'localhost'")
+	c.Assert(err, NotNil)
+}
+
+func (s *testPrivilegeSuite) TestUserStatements(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create user 'u1'@'localhost' identified by '123'")
+	tk.MustQuery("select authentication_string from mysql.user where User = 'u1'").Check(testkit.Rows(
+		auth.EncodePassword("123")))
+	_, err := tk.Exec("create user 'u1'@'localhost'")
+	c.Assert(err, NotNil)
+	tk.MustExec("create user if not exists 'u1'@'localhost'")
+
+	tk.MustExec("alter user 'u1'@'localhost' identified by '456'")
+	tk.MustQuery("select authentication_string from mysql.user where User = 'u1'").Check(testkit.Rows(
+		auth.EncodePassword("456")))
+	tk.MustExec("set password for 'u1'@'localhost' = '789'")
+	tk.MustQuery("select authentication_string from mysql.user where User = 'u1'").Check(testkit.Rows(
+		auth.EncodePassword("789")))
+	_, err = tk.Exec("alter user 'u_no_exists'@'localhost' identified by '1'")
+	c.Assert(err, NotNil)
+
+	tk.MustExec("grant select on test.* to 'u1'@'localhost'")
+	tk.MustExec("drop user 'u1'@'localhost'")
+	tk.MustQuery("select count(*) from mysql.user where User = 'u1'").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from mysql.db where User = 'u1'").Check(testkit.Rows("0"))
+	_, err = tk.Exec("drop user 'u1'@'localhost'")
+	c.Assert(err, NotNil)
+	tk.MustExec("drop user if exists 'u1'@'localhost'")
+}
+
+func (s *testPrivilegeSuite) TestConnectionVerification(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create user 'conn'@'localhost' identified by '123'")
+	tk.MustExec("create user 'conn_nopwd'@'%'")
+
+	salt := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	scramble := func(pwd string) []byte {
+		// The client computes SHA1(pwd) XOR SHA1(salt + SHA1(SHA1(pwd))).
+		stage1 := auth.Sha1Hash([]byte(pwd))
+		stage2 := auth.Sha1Hash(stage1)
+		res := auth.Sha1Hash(append(append([]byte{}, salt...), stage2...))
+		for i := range res {
+			res[i] ^= stage1[i]
+		}
+		return res
+	}
+
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn", Hostname: "localhost"}, scramble("123"), salt), IsTrue)
+	c.Assert(se.GetSessionVars().User.AuthUsername, Equals, "conn")
+	c.Assert(se.GetSessionVars().User.AuthHostname, Equals, "localhost")
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn", Hostname: "localhost"}, scramble("456"), salt), IsFalse)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn", Hostname: "localhost"}, nil, salt), IsFalse)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn", Hostname: "10.0.0.1"}, scramble("123"), salt), IsFalse)
+
+	// The wildcard host matches any host.
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn_nopwd", Hostname: "10.0.0.1"}, nil, salt), IsTrue)
+	c.Assert(se.GetSessionVars().User.AuthHostname, Equals, "%")
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "conn_nopwd", Hostname: "10.0.0.1"}, scramble("123"), salt), IsFalse)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "no_exists", Hostname: "localhost"}, nil, salt), IsFalse)
+}
+
+func (s *testPrivilegeSuite) TestStatementPrivilege(c *C) {
+	rootTk := testkit.NewTestKit(c, s.store)
+	rootTk.MustExec("create database if not exists priv_db")
+	rootTk.MustExec("use priv_db")
+	rootTk.MustExec("drop table if exists t")
+	rootTk.MustExec("create table t (a int)")
+	rootTk.MustExec("insert into t values (1)")
+	rootTk.MustExec("create user 'stmt'@'localhost'")
+
+	tk := s.newSession(c, "stmt")
+	_, err := tk.Exec("use priv_db")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("select * from priv_db.t")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("drop database priv_db")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("create user 'other'@'localhost'")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("grant select on priv_db.* to 'stmt'@'localhost'")
+	c.Assert(err.Error(), Equals, "[planner:1044]Access denied for user 'stmt'@'localhost' to database 'priv_db'")
+	_, err = tk.Exec("grant select on priv_db.t to 'stmt'@'localhost'")
+	c.Assert(err.Error(), Equals, "[planner:1142]GRANT command denied to user 'stmt'@'localhost' for table 't'")
+	_, err = tk.Exec("revoke select on *.* from 'stmt'@'localhost'")
+	c.Assert(err.Error(), Equals, "[planner:1227]Access denied; you need (at least one of) the GRANT OPTION privilege(s) for this operation")
+	c.Assert(s.showsDB(tk, "priv_db"), IsFalse)
+
+	rootTk.MustExec("grant select on priv_db.t to 'stmt'@'localhost'")
+	tk.MustExec("use priv_db")
+	c.Assert(s.showsDB(tk, "priv_db"), IsTrue)
+	tk.MustQuery("show tables").Check(testkit.Rows("t"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1"))
+	_, err = tk.Exec("insert into t values (2)")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("delete from t")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("drop table t")
+	c.Assert(err, NotNil)
+
+	// The cached plan of a prepared statement is checked again.
+	tk.MustExec("prepare stmt from 'select * from t where a = ?'")
+	tk.MustExec("set @a = 1")
+	tk.MustQuery("execute stmt using @a").Check(testkit.Rows("1"))
+	rootTk.MustExec("revoke select on priv_db.t from 'stmt'@'localhost'")
+	_, err = tk.Exec("execute stmt using @a")
+	c.Assert(err, NotNil)
+
+	rootTk.MustExec("grant insert, delete, drop on priv_db.* to 'stmt'@'localhost'")
+	tk.MustExec("insert into t values (2)")
+	tk.MustExec("drop table t")
+
+	// The privileges being granted are required besides the GRANT OPTION.
+	rootTk.MustExec("create table t (a int)")
+	rootTk.MustExec("create user 'other'@'localhost'")
+	rootTk.MustExec("create role r_stmt")
+	rootTk.MustExec("grant select on priv_db.t to 'stmt'@'localhost' with grant option")
+	tk.MustExec("grant select on priv_db.t to 'other'@'localhost'")
+	_, err = tk.Exec("grant update on priv_db.t to 'other'@'localhost'")
+	c.Assert(err.Error(), Equals, "[planner:1142]UPDATE command denied to user 'stmt'@'localhost' for table 't'")
+	_, err = tk.Exec("grant r_stmt to 'other'@'localhost'")
+	c.Assert(err.Error(), Equals, "[planner:1227]Access denied; you need (at least one of) the SUPER privilege(s) for this operation")
+}
+
+func (s *testPrivilegeSuite) TestRoles(c *C) {
+	rootTk := testkit.NewTestKit(c, s.store)
+	rootTk.MustExec("create database if not exists role_db")
+	rootTk.MustExec("use role_db")
+	rootTk.MustExec("drop table if exists t")
+	rootTk.MustExec("create table t (a int)")
+	rootTk.MustExec("create role r_read, r_write, r_admin")
+	rootTk.MustExec("create user 'r_user'@'localhost'")
+	rootTk.MustExec("grant select on role_db.* to r_read")
+	rootTk.MustExec("grant insert on role_db.t to r_write")
+	rootTk.MustExec("grant create user on *.* to r_admin")
+
+	// The roles can't log in.
+	se, err := session.CreateSession4Test(s.store)
+	c.Assert(err, IsNil)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "r_read", Hostname: "localhost"}, nil, nil), IsFalse)
+	c.Assert(se.Auth(&auth.UserIdentity{Username: "r_admin", Hostname: "localhost"}, nil, nil), IsFalse)
+
+	// The roles granted to the user are not active until SET ROLE.
+	rootTk.MustExec("grant r_read, r_write to 'r_user'@'localhost'")
+	tk := s.newSession(c, "r_user")
+	_, err = tk.Exec("select * from role_db.t")
+	c.Assert(err, NotNil)
+	tk.MustExec("set role r_read")
+	tk.MustQuery("select * from role_db.t").Check(testkit.Rows())
+	_, err = tk.Exec("insert into role_db.t values (1)")
+	c.Assert(err, NotNil)
+	tk.MustExec("set role all")
+	tk.MustExec("insert into role_db.t values (1)")
+	tk.MustExec("set role all except r_read")
+	_, err = tk.Exec("select * from role_db.t")
+	c.Assert(err, NotNil)
+	tk.MustExec("set role none")
+	_, err = tk.Exec("insert into role_db.t values (1)")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("set role r_admin")
+	c.Assert(err, NotNil)
+
+	// The default roles are activated when the user logs in.
+	tk.MustExec("set default role r_read to 'r_user'@'localhost'")
+	_, err = tk.Exec("set default role r_admin to 'r_user'@'localhost'")
+	c.Assert(err, NotNil)
+	tk = s.newSession(c, "r_user")
+	tk.MustQuery("select * from role_db.t").Check(testkit.Rows("1"))
+	tk.MustExec("set role none")
+	_, err = tk.Exec("select * from role_db.t")
+	c.Assert(err, NotNil)
+	tk.MustExec("set role default")
+	tk.MustQuery("select * from role_db.t").Check(testkit.Rows("1"))
+
+	// A role granted to a role is granted to the user too.
+	rootTk.MustExec("grant r_admin to r_write")
+	tk.MustExec("set role r_write")
+	tk.MustExec("create user 'r_other'@'localhost'")
+
+	// Revoking the role removes it from the default roles.
+	rootTk.MustExec("revoke r_read from 'r_user'@'localhost'")
+	tk = s.newSession(c, "r_user")
+	_, err = tk.Exec("select * from role_db.t")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("set role r_read")
+	c.Assert(err, NotNil)
+
+	// Dropping the role removes its edges.
+	rootTk.MustExec("drop role r_write")
+	rootTk.MustQuery("select count(*) from mysql.role_edges where FROM_USER = 'r_write' or TO_USER = 'r_write'").Check(testkit.Rows("0"))
+	_, err = rootTk.Exec("grant r_no_exists to 'r_user'@'localhost'")
+	c.Assert(err, NotNil)
+}
+
+func (s *testPrivilegeSuite) TestRoleCycle(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create role c1, c2, c3")
+	tk.MustExec("grant c1 to c2")
+	tk.MustExec("grant c2 to c3")
+	_, err := tk.Exec("grant c3 to c1")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*would create a loop")
+	_, err = tk.Exec("grant c1 to c1")
+	c.Assert(err, NotNil)
+	tk.MustQuery("select count(*) from mysql.role_edges where TO_USER = 'c1'").Check(testkit.Rows("0"))
+	tk.MustExec("grant c1 to c3")
+}
+
+func (s *testPrivilegeSuite) TestShowGrantsWithRoles(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create role 'g_r1', 'g_r2'")
+	tk.MustExec("create user 'g_user'@'localhost'")
+	tk.MustExec("grant select on test.* to g_r1")
+	tk.MustExec("grant insert on test.* to g_r2")
+	tk.MustExec("grant g_r2 to g_r1")
+	tk.MustExec("grant g_r1 to 'g_user'@'localhost'")
+	tk.MustQuery("show grants for 'g_user'@'localhost'").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'g_user'@'localhost'",
+		"GRANT `g_r1`@`%` TO 'g_user'@'localhost'",
+	))
+	// The privileges of the roles are merged, including the ones granted to
+	// the roles.
+	tk.MustQuery("show grants for 'g_user'@'localhost' using g_r1").Check(testkit.Rows(
+		"GRANT USAGE ON *.* TO 'g_user'@'localhost'",
+		"GRANT Select,Insert ON `test`.* TO 'g_user'@'localhost'",
+		"GRANT `g_r1`@`%` TO 'g_user'@'localhost'",
+	))
+	err := tk.QueryToErr("show grants for 'g_user'@'localhost' using g_r2")
+	c.Assert(err, NotNil)
+
+	// SHOW GRANTS of the current user uses the active roles.
+	se := s.newSession(c, "g_user")
+	c.Assert(len(se.MustQuery("show grants").Rows()), Equals, 2)
+	se.MustExec("set role g_r1")
+	c.Assert(len(se.MustQuery("show grants").Rows()), Equals, 3)
+}
+
+func (s *testPrivilegeSuite) TestMandatoryRoles(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("create database if not exists mandatory_db")
+	tk.MustExec("create role m_role")
+	tk.MustExec("create user 'm_user'@'localhost'")
+	tk.MustExec("grant select on mandatory_db.* to m_role")
+	tk.MustExec("set @@global.mandatory_roles = 'm_role, `m_no_exists`@`%`'")
+	defer tk.MustExec("set @@global.mandatory_roles = ''")
+
+	// The mandatory roles are granted to all the users and activated when
+	// they log in.
+	se := s.newSession(c, "m_user")
+	se.MustExec("use mandatory_db")
+	se.MustExec("set role none")
+	_, err := se.Exec("use mandatory_db")
+	c.Assert(err, NotNil)
+	se.MustExec("set role m_role")
+	se.MustExec("use mandatory_db")
+	_, err = se.Exec("set role m_no_exists")
+	c.Assert(err, NotNil)
+}
+
+func (s *testPrivilegeSuite) TestViewSecurity(c *C) {
+	rootTk := testkit.NewTestKit(c, s.store)
+	rootTk.MustExec("create database if not exists priv_view")
+	rootTk.MustExec("use priv_view")
+	rootTk.MustExec("create table t (a int)")
+	rootTk.MustExec("insert into t values (1)")
+	rootTk.MustExec("create user 'definer'@'localhost', 'invoker'@'localhost'")
+	rootTk.MustExec("grant select, create view on priv_view.* to 'definer'@'localhost'")
+
+	definerTk := s.newSession(c, "definer")
+	definerTk.MustExec("use priv_view")
+	definerTk.MustExec("create view v_def as select a from t")
+	definerTk.MustExec("create sql security invoker view v_inv as select a from t")
+	_, err := definerTk.Exec("create definer = 'root'@'%' view v_root as select a from t")
+	c.Assert(err, NotNil)
+
+	rootTk.MustExec("grant select, insert on priv_view.v_def to 'invoker'@'localhost'")
+	rootTk.MustExec("grant select on priv_view.v_inv to 'invoker'@'localhost'")
+	tk := s.newSession(c, "invoker")
+	tk.MustExec("use priv_view")
+	tk.MustQuery("select * from v_def").Check(testkit.Rows("1"))
+	_, err = tk.Exec("select * from v_inv")
+	c.Assert(err, NotNil)
+	_, err = tk.Exec("select * from t")
+	c.Assert(err, NotNil)
+
+	// The definer has no INSERT on the base table yet.
+	_, err = tk.Exec("insert into v_def values (2)")
+	c.Assert(err, NotNil)
+	rootTk.MustExec("grant insert on priv_view.t to 'definer'@'localhost'")
+	tk.MustExec("insert into v_def values (2)")
+	tk.MustQuery("select * from v_def").Check(testkit.Rows("1", "2"))
+}
@@ -0,0 +1,222 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+func (ts *ConnTestSuite) TestParseExecArgs(c *C) {
+	type args struct {
+		args        []types.Datum
+		boundParams [][]byte
+		nullBitmap  []byte
+		paramTypes  []byte
+		paramValues []byte
+	}
+	tests := []struct {
+		args   args
+		err    error
+		expect interface{}
+	}{
+		// Tests for int overflow
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{1, 0},
+				[]byte{0xff},
+			},
+			nil,
+			int64(-1),
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{2, 0},
+				[]byte{0xff, 0xff},
+			},
+			nil,
+			int64(-1),
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{3, 0},
+				[]byte{0xff, 0xff, 0xff, 0xff},
+			},
+			nil,
+			int64(-1),
+		},
+		// Tests for unsigned int
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{8, 0x80},
+				[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+			},
+			nil,
+			uint64(0xffffffffffffffff),
+		},
+		// Tests for date/datetime/timestamp
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{12, 0},
+				[]byte{0x0b, 0xda, 0x07, 0x0a, 0x11, 0x13, 0x1b, 0x1e, 0x01, 0x00, 0x00, 0x00},
+			},
+			nil,
+			"2010-10-17 19:27:30.000001",
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{10, 0},
+				[]byte{0x04, 0xda, 0x07, 0x0a, 0x11},
+			},
+			nil,
+			"2010-10-17",
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{10, 0},
+				[]byte{0x04, 0xda, 0x07},
+			},
+			mysql.ErrMalformPacket,
+			nil,
+		},
+		// Tests for time
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{0x0b, 0},
+				[]byte{0x0c, 0x01, 0x78, 0x00, 0x00, 0x00, 0x13, 0x1b, 0x1e, 0x01, 0x00, 0x00, 0x00},
+			},
+			nil,
+			"-120 19:27:30.000001",
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{0x0b, 0},
+				[]byte{0x08, 0x01, 0x78, 0x00, 0x00, 0x00, 0x13, 0x1b, 0x1e},
+			},
+			nil,
+			"-120 19:27:30",
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{0x0b, 0},
+				[]byte{0x05, 0x01, 0x78, 0x00, 0x00, 0x00},
+			},
+			mysql.ErrMalformPacket,
+			nil,
+		},
+		// Tests for string and NULL
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x0},
+				[]byte{0xfd, 0},
+				[]byte{0x03, 'a', 'b', 'c'},
+			},
+			nil,
+			"abc",
+		},
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{nil},
+				[]byte{0x1},
+				[]byte{0xfd, 0},
+				[]byte{},
+			},
+			nil,
+			nil,
+		},
+		// Tests for long data sent by COM_STMT_SEND_LONG_DATA
+		{
+			args{
+				make([]types.Datum, 1),
+				[][]byte{[]byte("long")},
+				[]byte{0x1},
+				[]byte{0xfd, 0},
+				[]byte{},
+			},
+			nil,
+			[]byte("long"),
+		},
+	}
+	for i, tt := range tests {
+		err := parseExecArgs(tt.args.args, tt.args.boundParams, tt.args.nullBitmap, tt.args.paramTypes, tt.args.paramValues)
+		c.Assert(terrorEqual(err, tt.err), IsTrue, Commentf("case %d: %v", i, err))
+		if tt.err == nil {
+			c.Assert(tt.args.args[0].GetValue(), DeepEquals, tt.expect, Commentf("case %d", i))
+		}
+	}
+}
+
+func terrorEqual(err1, err2 error) bool {
+	if err1 == nil || err2 == nil {
+		return err1 == err2
+	}
+	return err1.Error() == err2.Error()
+}
+
+func (ts *ConnTestSuite) TestParseStmtFetchCmd(c *C) {
+	tests := []struct {
+		arg       []byte
+		stmtID    uint32
+		fetchSize uint32
+		err       error
+	}{
+		{[]byte{3, 0, 0, 0, 50, 0, 0, 0}, 3, 50, nil},
+		{[]byte{5, 0, 0, 0, 232, 3, 0, 0}, 5, 1000, nil},
+		{[]byte{5, 0, 0, 0, 0, 8, 0, 0}, 5, maxFetchSize, nil},
+		{[]byte{5, 0, 0}, 0, 0, mysql.ErrMalformPacket},
+		{[]byte{1, 0, 0, 0, 3, 2, 0, 0, 3, 5, 6}, 0, 0, mysql.ErrMalformPacket},
+		{[]byte{}, 0, 0, mysql.ErrMalformPacket},
+	}
+
+	for _, t := range tests {
+		stmtID, fetchSize, err := parseStmtFetchCmd(t.arg)
+		c.Assert(stmtID, Equals, t.stmtID)
+		c.Assert(fetchSize, Equals, t.fetchSize)
+		c.Assert(err, Equals, t.err)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
@@ -66,6 +67,15 @@ type QueryCtx interface {
 	// FieldList returns columns of a table.
 	FieldList(tableName string) (columns []*ColumnInfo, err error)
 
+	// Auth verifies user's authentication.
+	Auth(user *auth.UserIdentity, auth []byte, salt []byte) bool
+
+	// Prepare prepares a statement.
+	Prepare(sql string) (statement PreparedStatement, columns, params []*ColumnInfo, err error)
+
+	// GetStatement gets PreparedStatement by statement ID.
+	GetStatement(stmtID int) PreparedStatement
+
 	// Close closes the QueryCtx.
 	Close() error
 
@@ -107,12 +107,18 @@ type DBTest struct {
 	db *sql.DB
 }
 
-func (dbt *DBTest) mustExec(query string) (res sql.Result) {
-	res, err := dbt.db.Exec(query)
+func (dbt *DBTest) mustExec(query string, args ...interface{}) (res sql.Result) {
+	res, err := dbt.db.Exec(query, args...)
 	dbt.Assert(err, IsNil, Commentf("Exec %s", query))
 	return res
 }
 
+func (dbt *DBTest) mustQuery(query string, args ...interface{}) (rows *sql.Rows) {
+	rows, err := dbt.db.Query(query, args...)
+	dbt.Assert(err, IsNil, Commentf("Query %s", query))
+	return rows
+}
+
 func runTestIssue3662(c *C) {
 	db, err := sql.Open("mysql", getDSN(func(config *mysql.Config) {
 		config.DBName = "non_existing_schema"
@@ -148,6 +154,52 @@ func runTestResultFieldTableIsNull(c *C) {
 	})
 }
 
+func runTestPreparedString(c *C) {
+	runTestsOnNewDB(c, nil, "PreparedString", func(dbt *DBTest) {
+		dbt.mustExec("create table test (a char(10), b char(10))")
+		dbt.mustExec("insert test values (?, ?)", "abcdeabcde", "abcde")
+		rows := dbt.mustQuery("select * from test where 1 = ?", 1)
+		if rows.Next() {
+			var outA, outB string
+			err := rows.Scan(&outA, &outB)
+			dbt.Check(err, IsNil)
+			dbt.Check(outA, Equals, "abcdeabcde")
+			dbt.Check(outB, Equals, "abcde")
+		} else {
+			dbt.Error("no data")
+		}
+		rows.Close()
+	})
+}
+
+func runTestPreparedTypes(c *C) {
+	runTestsOnNewDB(c, nil, "PreparedTypes", func(dbt *DBTest) {
+		dbt.mustExec("create table test (a int, b bigint unsigned, c double, d decimal(10, 2), e datetime, f varchar(10))")
+		stmt, err := dbt.db.Prepare("insert test values (?, ?, ?, ?, ?, ?)")
+		dbt.Assert(err, IsNil)
+		_, err = stmt.Exec(-1, uint64(1<<63), 1.5, "12.34", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), nil)
+		dbt.Assert(err, IsNil)
+		dbt.Assert(stmt.Close(), IsNil)
+
+		var (
+			a int
+			b uint64
+			c float64
+			d string
+			e string
+			f sql.NullString
+		)
+		err = dbt.db.QueryRow("select * from test where a = ? and f is null", -1).Scan(&a, &b, &c, &d, &e, &f)
+		dbt.Assert(err, IsNil)
+		dbt.Check(a, Equals, -1)
+		dbt.Check(b, Equals, uint64(1<<63))
+		dbt.Check(c, Equals, 1.5)
+		dbt.Check(d, Equals, "12.34")
+		dbt.Check(e, Equals, "2020-01-02 03:04:05")
+		dbt.Check(f.Valid, IsFalse)
+	})
+}
+
 const retryTime = 100
 
 func waitUntilServerOnline(statusPort uint) {
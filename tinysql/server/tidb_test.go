@@ -82,6 +82,16 @@ func (ts *TidbTestSuite) TestResultFieldTableIsNull(c *C) {
 	runTestResultFieldTableIsNull(c)
 }
 
+func (ts *TidbTestSuite) TestPreparedString(c *C) {
+	c.Parallel()
+	runTestPreparedString(c)
+}
+
+func (ts *TidbTestSuite) TestPreparedTypes(c *C) {
+	c.Parallel()
+	runTestPreparedTypes(c)
+}
+
 func (ts *TidbTestSuite) TestShowTablesFlen(c *C) {
 	qctx, err := ts.tidbdrv.OpenCtx(uint64(0), 0, uint8(tmysql.DefaultCollationID), "test", nil)
 	c.Assert(err, IsNil)
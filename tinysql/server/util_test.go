@@ -14,14 +14,18 @@
 package server
 
 import (
+	"time"
+
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/testleak"
 )
@@ -130,6 +134,67 @@ func (s *testUtilSuite) TestDumpTextValue(c *C) {
 	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{year}).ToRow())
 	c.Assert(err, IsNil)
 	c.Assert(mustDecodeStr(c, bs), Equals, "1984")
+
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC}
+	t, err := types.ParseTime(sc, "2017-01-05 23:59:59.575601", mysql.TypeDatetime, 3)
+	c.Assert(err, IsNil)
+	columns[0].Type = mysql.TypeDatetime
+	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{types.NewTimeDatum(t)}).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(mustDecodeStr(c, bs), Equals, "2017-01-05 23:59:59.576")
+
+	d, err := types.ParseDuration(sc, "-10:10:10.123", 2)
+	c.Assert(err, IsNil)
+	columns[0].Type = mysql.TypeDuration
+	columns[0].Decimal = 2
+	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{types.NewDurationDatum(d)}).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(mustDecodeStr(c, bs), Equals, "-10:10:10.12")
+
+	j, err := json.ParseBinaryFromString(`{"b": [1, "x"], "a": null}`)
+	c.Assert(err, IsNil)
+	columns[0].Type = mysql.TypeJSON
+	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{types.NewDatum(j)}).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(mustDecodeStr(c, bs), Equals, `{"a": null, "b": [1, "x"]}`)
+
+	columns[0].Type = mysql.TypeEnum
+	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{types.NewMysqlEnumDatum(types.Enum{Name: "b", Value: 2})}).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(mustDecodeStr(c, bs), Equals, "b")
+
+	columns[0].Type = mysql.TypeSet
+	bs, err = dumpTextRow(nil, columns, chunk.MutRowFromDatums([]types.Datum{types.NewMysqlSetDatum(types.Set{Name: "x,z", Value: 5})}).ToRow())
+	c.Assert(err, IsNil)
+	c.Assert(mustDecodeStr(c, bs), Equals, "x,z")
+}
+
+func (s *testUtilSuite) TestDumpBinaryTime(c *C) {
+	sc := &stmtctx.StatementContext{TimeZone: time.UTC}
+	t, err := types.ParseTimestamp(sc, "0000-00-00 00:00:00.000000")
+	c.Assert(err, IsNil)
+	d := dumpBinaryDateTime(nil, t)
+	c.Assert(d, DeepEquals, []byte{0})
+
+	t, err = types.ParseDatetime(sc, "2017-01-05 23:59:59.575601")
+	c.Assert(err, IsNil)
+	d = dumpBinaryDateTime(nil, t)
+	c.Assert(d, DeepEquals, []byte{11, 225, 7, 1, 5, 23, 59, 59, 113, 200, 8, 0})
+
+	t, err = types.ParseDate(sc, "2017-01-05")
+	c.Assert(err, IsNil)
+	d = dumpBinaryDateTime(nil, t)
+	c.Assert(d, DeepEquals, []byte{4, 225, 7, 1, 5})
+
+	myDuration, err := types.ParseDuration(sc, "0000-00-00 00:00:00.000000", 6)
+	c.Assert(err, IsNil)
+	d = dumpBinaryTime(myDuration.Duration)
+	c.Assert(d, DeepEquals, []byte{0})
+
+	myDuration, err = types.ParseDuration(sc, "-1 10:20:30.000001", 6)
+	c.Assert(err, IsNil)
+	d = dumpBinaryTime(myDuration.Duration)
+	c.Assert(d, DeepEquals, []byte{12, 1, 1, 0, 0, 0, 10, 20, 30, 1, 0, 0, 0})
 }
 
 func mustDecodeStr(c *C, b []byte) string {
@@ -35,6 +35,87 @@ import (
 )
 
 const (
+	// CreateUserTable is the SQL statement creates User table in system db.
+	// The privilege columns hold 'Y' or 'N'.
+	CreateUserTable = `CREATE TABLE if not exists mysql.user (
+		Host CHAR(64),
+		User CHAR(32),
+		authentication_string TEXT,
+		Select_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Insert_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Update_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Delete_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Drop_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Process_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Grant_priv CHAR(1) NOT NULL DEFAULT 'N',
+		References_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Alter_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Show_db_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Super_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Execute_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Index_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_user_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Trigger_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_view_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Show_view_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_role_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Drop_role_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_tmp_table_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Lock_tables_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_routine_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Alter_routine_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Event_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Shutdown_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Account_locked CHAR(1) NOT NULL DEFAULT 'N',
+		PRIMARY KEY (Host, User));`
+	// CreateDBPrivTable is the SQL statement creates DB scope privilege table in system db.
+	CreateDBPrivTable = `CREATE TABLE if not exists mysql.db (
+		Host CHAR(64),
+		DB CHAR(64),
+		User CHAR(32),
+		Select_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Insert_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Update_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Delete_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Drop_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Grant_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Alter_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Execute_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Index_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Create_view_priv CHAR(1) NOT NULL DEFAULT 'N',
+		Show_view_priv CHAR(1) NOT NULL DEFAULT 'N',
+		PRIMARY KEY (Host, DB, User));`
+	// CreateTablePrivTable is the SQL statement creates table scope privilege table in system db.
+	// Table_priv is a comma separated list of the granted privileges.
+	CreateTablePrivTable = `CREATE TABLE if not exists mysql.tables_priv (
+		Host CHAR(64),
+		DB CHAR(64),
+		User CHAR(32),
+		Table_name CHAR(64),
+		Grantor CHAR(77),
+		Timestamp DATETIME,
+		Table_priv VARCHAR(255) NOT NULL DEFAULT '',
+		PRIMARY KEY (Host, DB, User, Table_name));`
+	// CreateRoleEdgesTable is the SQL statement creates the role graph table
+	// in system db, each row grants the role FROM_USER@FROM_HOST to the user
+	// TO_USER@TO_HOST.
+	CreateRoleEdgesTable = `CREATE TABLE if not exists mysql.role_edges (
+		FROM_HOST CHAR(64),
+		FROM_USER CHAR(32),
+		TO_HOST CHAR(64),
+		TO_USER CHAR(32),
+		WITH_ADMIN_OPTION CHAR(1) NOT NULL DEFAULT 'N',
+		PRIMARY KEY (FROM_HOST, FROM_USER, TO_HOST, TO_USER));`
+	// CreateDefaultRolesTable is the SQL statement creates the table of the
+	// roles activated when the users log in.
+	CreateDefaultRolesTable = `CREATE TABLE if not exists mysql.default_roles (
+		HOST CHAR(64),
+		USER CHAR(32),
+		DEFAULT_ROLE_HOST CHAR(64) NOT NULL DEFAULT '%',
+		DEFAULT_ROLE_USER CHAR(32),
+		PRIMARY KEY (HOST, USER, DEFAULT_ROLE_HOST, DEFAULT_ROLE_USER));`
 	// CreateGloablVariablesTable is the SQL statement creates global variable table in system db.
 	// TODO: MySQL puts GLOBAL_VARIABLES table in INFORMATION_SCHEMA db.
 	// INFORMATION_SCHEMA is a virtual db in TiDB. So we put this table in system db.
@@ -120,6 +201,20 @@ const (
 		count bigint(64) UNSIGNED NOT NULL,
 		index tbl(table_id, is_index, hist_id)
 	);`
+
+	// CreateBindInfoTable stores the sql bind info which is used to update globalBindCache.
+	CreateBindInfoTable = `CREATE TABLE IF NOT EXISTS mysql.bind_info (
+		original_sql text NOT NULL,
+		bind_sql text NOT NULL,
+		default_db text NOT NULL,
+		status text NOT NULL,
+		create_time datetime(3) NOT NULL,
+		update_time datetime(3) NOT NULL,
+		charset text NOT NULL,
+		collation text NOT NULL,
+		INDEX sql_index(original_sql(1024), default_db(1024)) COMMENT "accelerate the speed when add global binding query",
+		INDEX time_index(update_time) COMMENT "accelerate the speed when querying with last update time"
+	);`
 )
 
 // bootstrap initiates system DB for a store.
@@ -215,6 +310,14 @@ func doDDLWorks(s Session) {
 	mustExecute(s, "CREATE DATABASE IF NOT EXISTS test")
 	// Create system db.
 	mustExecute(s, fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s;", mysql.SystemDB))
+	// Create user table.
+	mustExecute(s, CreateUserTable)
+	// Create privilege tables.
+	mustExecute(s, CreateDBPrivTable)
+	mustExecute(s, CreateTablePrivTable)
+	// Create role tables.
+	mustExecute(s, CreateRoleEdgesTable)
+	mustExecute(s, CreateDefaultRolesTable)
 	// Create global system variable table.
 	mustExecute(s, CreateGloablVariablesTable)
 	// Create TiDB table.
@@ -231,6 +334,8 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateGCDeleteRangeDoneTable)
 	// Create stats_topn_store table.
 	mustExecute(s, CreateStatsTopNTable)
+	// Create bind_info table.
+	mustExecute(s, CreateBindInfoTable)
 }
 
 // doDMLWorks executes DML statements in bootstrap stage.
@@ -238,8 +343,16 @@ func doDDLWorks(s Session) {
 func doDMLWorks(s Session) {
 	mustExecute(s, "BEGIN")
 
+	// Insert a default user with empty password.
+	values := make([]string, 0, len(mysql.AllGlobalPrivs)+1)
+	for range mysql.AllGlobalPrivs {
+		values = append(values, `"Y"`)
+	}
+	mustExecute(s, fmt.Sprintf(`INSERT HIGH_PRIORITY INTO mysql.user (Host, User, authentication_string, Grant_priv, %s) VALUES ("%%", "root", "", "Y", %s)`,
+		userPrivColumns(), strings.Join(values, ", ")))
+
 	// Init global system variables table.
-	values := make([]string, 0, len(variable.SysVars))
+	values = make([]string, 0, len(variable.SysVars))
 	for k, v := range variable.SysVars {
 		// Session only variable should not be inserted.
 		if v.Scope != variable.ScopeSession {
@@ -276,6 +389,15 @@ func doDMLWorks(s Session) {
 	}
 }
 
+// userPrivColumns returns the columns of the global privileges in mysql.user.
+func userPrivColumns() string {
+	cols := make([]string, 0, len(mysql.AllGlobalPrivs))
+	for _, priv := range mysql.AllGlobalPrivs {
+		cols = append(cols, mysql.Priv2UserCol[priv])
+	}
+	return strings.Join(cols, ", ")
+}
+
 func mustExecute(s Session, sql string) {
 	_, err := s.Execute(context.Background(), sql)
 	if err != nil {
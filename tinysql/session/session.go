@@ -22,6 +22,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -30,6 +31,7 @@ import (
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/bindinfo"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/infoschema"
@@ -38,16 +40,21 @@ import (
 	"github.com/pingcap/tidb/owner"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/privileges"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/sqlexec"
 	"go.uber.org/zap"
@@ -61,6 +68,12 @@ type Session interface {
 	AffectedRows() uint64                                         // Affected rows by latest executed stmt.
 	Execute(context.Context, string) ([]sqlexec.RecordSet, error) // Execute a sql statement.
 	String() string                                               // String is used to debug.
+	// PrepareStmt executes prepare statement in binary protocol.
+	PrepareStmt(sql string) (stmtID uint32, paramCount int, fields []*ast.ResultField, err error)
+	// ExecutePreparedStmt executes a prepared statement.
+	ExecutePreparedStmt(ctx context.Context, stmtID uint32, param []types.Datum) (sqlexec.RecordSet, error)
+	// DropPreparedStmt drops a prepared statement.
+	DropPreparedStmt(stmtID uint32) error
 	CommitTxn(context.Context) error
 	RollbackTxn(context.Context)
 	SetClientCapability(uint32) // Set client capability flags.
@@ -73,6 +86,9 @@ type Session interface {
 	PrepareTxnCtx(context.Context)
 	// FieldList returns fields list of a table.
 	FieldList(tableName string) (fields []*ast.ResultField, err error)
+	// Auth verifies the user and password in the handshake, and sets the
+	// user of the session if it passes.
+	Auth(user *auth.UserIdentity, auth []byte, salt []byte) bool
 }
 
 var (
@@ -122,6 +138,9 @@ type session struct {
 
 	// shared coprocessor client per session
 	client kv.Client
+
+	// preparedPlanCache caches the physical plans of the prepared statements.
+	preparedPlanCache *kvcache.SimpleLRUCache
 }
 
 // DDLOwnerChecker returns s.ddlOwnerChecker.
@@ -129,6 +148,11 @@ func (s *session) DDLOwnerChecker() owner.DDLOwnerChecker {
 	return s.ddlOwnerChecker
 }
 
+// PreparedPlanCache returns the cache of the physical plan.
+func (s *session) PreparedPlanCache() *kvcache.SimpleLRUCache {
+	return s.preparedPlanCache
+}
+
 func (s *session) getMembufCap() int {
 	return kv.DefaultTxnMembufCap
 }
@@ -204,6 +228,48 @@ func (s *session) FieldList(tableName string) ([]*ast.ResultField, error) {
 	return fields, nil
 }
 
+// Auth implements the Session Auth interface.
+func (s *session) Auth(user *auth.UserIdentity, authentication []byte, salt []byte) bool {
+	pm := privilege.GetPrivilegeManager(s)
+
+	// Check IP or localhost.
+	var success bool
+	user.AuthUsername, user.AuthHostname, success = pm.ConnectionVerification(user.Username, user.Hostname, authentication, salt)
+	if success {
+		s.sessionVars.User = user
+		s.sessionVars.ActiveRoles = pm.GetDefaultRoles(s, user.AuthUsername, user.AuthHostname)
+		return true
+	}
+
+	// Check Hostname.
+	for _, addr := range getHostByIP(user.Hostname) {
+		u, h, success := pm.ConnectionVerification(user.Username, addr, authentication, salt)
+		if success {
+			s.sessionVars.User = &auth.UserIdentity{
+				Username:     user.Username,
+				Hostname:     addr,
+				AuthUsername: u,
+				AuthHostname: h,
+			}
+			s.sessionVars.ActiveRoles = pm.GetDefaultRoles(s, u, h)
+			return true
+		}
+	}
+	return false
+}
+
+func getHostByIP(ip string) []string {
+	if ip == "127.0.0.1" {
+		return []string{"localhost"}
+	}
+	if net.ParseIP(ip) == nil {
+		return nil
+	}
+	addrs, err := net.LookupAddr(ip)
+	terror.Log(errors.Trace(err))
+	return addrs
+}
+
 func (s *session) doCommit(ctx context.Context) error {
 	if !s.txn.Valid() {
 		return nil
@@ -656,6 +722,59 @@ func (s *session) execute(ctx context.Context, sql string) (recordSets []sqlexec
 	return recordSets, nil
 }
 
+// PrepareStmt is used for executing prepare statement in binary protocol
+func (s *session) PrepareStmt(sql string) (stmtID uint32, paramCount int, fields []*ast.ResultField, err error) {
+	if s.sessionVars.TxnCtx.InfoSchema == nil {
+		// We don't need to create a transaction for prepare statement, just get information schema will do.
+		s.sessionVars.TxnCtx.InfoSchema = domain.GetDomain(s).InfoSchema()
+	}
+	err = s.loadCommonGlobalVariablesIfNeeded()
+	if err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	inTxn := s.GetSessionVars().InTxn()
+	// NewPrepareExec may need startTS to build the executor, for example prepare statement has subquery in int.
+	// So we have to call PrepareTxnCtx here.
+	s.PrepareTxnCtx(ctx)
+	prepareExec := executor.NewPrepareExec(s, infoschema.GetInfoSchema(s), sql)
+	err = prepareExec.Next(ctx, nil)
+	if err != nil {
+		return
+	}
+	if !inTxn {
+		// We could start a transaction to build the prepare executor before, we should rollback it here.
+		s.RollbackTxn(ctx)
+	}
+	return prepareExec.ID, prepareExec.ParamCount, prepareExec.Fields, nil
+}
+
+// ExecutePreparedStmt executes a prepared statement.
+func (s *session) ExecutePreparedStmt(ctx context.Context, stmtID uint32, args []types.Datum) (sqlexec.RecordSet, error) {
+	s.PrepareTxnCtx(ctx)
+	s.sessionVars.StartTime = time.Now()
+	st, err := executor.CompileExecutePreparedStmt(ctx, s, stmtID, args)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := runStmt(ctx, s, st)
+	if err != nil {
+		s.sessionVars.StmtCtx.AppendError(err)
+	}
+	return rs, err
+}
+
+// DropPreparedStmt drops a prepared statement.
+func (s *session) DropPreparedStmt(stmtID uint32) error {
+	vars := s.sessionVars
+	if _, ok := vars.PreparedStmts[stmtID]; !ok {
+		return plannercore.ErrStmtNotFound
+	}
+	vars.RemovePreparedStmt(stmtID)
+	return nil
+}
+
 // rollbackOnError makes sure the next statement starts a new transaction with the latest InfoSchema.
 func (s *session) rollbackOnError(ctx context.Context) {
 	if !s.sessionVars.InTxn() {
@@ -744,6 +863,7 @@ func (s *session) ClearValue(key fmt.Stringer) {
 
 // Close function does some clean work when session end.
 func (s *session) Close() {
+	s.sessionVars.WithdrawAllPreparedStmt()
 	ctx := context.TODO()
 	s.RollbackTxn(ctx)
 }
@@ -796,6 +916,22 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 	if err != nil {
 		return nil, err
 	}
+	se2, err := createSession(store)
+	if err != nil {
+		return nil, err
+	}
+	err = dom.LoadBindInfoLoop(se2)
+	if err != nil {
+		return nil, err
+	}
+	se3, err := createSession(store)
+	if err != nil {
+		return nil, err
+	}
+	err = dom.LoadPrivilegeLoop(se3)
+	if err != nil {
+		return nil, err
+	}
 
 	return dom, err
 }
@@ -838,8 +974,13 @@ func createSession(store kv.Storage) (*session, error) {
 		ddlOwnerChecker: dom.DDL().OwnerManager(),
 		client:          store.GetClient(),
 	}
+	if plannercore.PreparedPlanCacheEnabled() {
+		s.preparedPlanCache = kvcache.NewSimpleLRUCache(plannercore.PreparedPlanCacheCapacity)
+	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
+	s.SetValue(bindinfo.SessionBindInfoKeyType, bindinfo.NewSessionBindHandle())
 	domain.BindDomain(s, dom)
+	privilege.BindPrivilegeManager(s, &privileges.UserPrivileges{Handle: dom.PrivilegeHandle()})
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
 	s.txn.init()
@@ -857,8 +998,13 @@ func CreateSessionWithDomain(store kv.Storage, dom *domain.Domain) (*session, er
 		sessionVars: variable.NewSessionVars(),
 		client:      store.GetClient(),
 	}
+	if plannercore.PreparedPlanCacheEnabled() {
+		s.preparedPlanCache = kvcache.NewSimpleLRUCache(plannercore.PreparedPlanCacheCapacity)
+	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
+	s.SetValue(bindinfo.SessionBindInfoKeyType, bindinfo.NewSessionBindHandle())
 	domain.BindDomain(s, dom)
+	privilege.BindPrivilegeManager(s, &privileges.UserPrivileges{Handle: dom.PrivilegeHandle()})
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
 	s.txn.init()
@@ -940,6 +1086,7 @@ var builtinGlobalVariable = []string{
 	variable.NetWriteTimeout,
 	variable.MaxExecutionTime,
 	variable.InnodbLockWaitTimeout,
+	variable.ForeignKeyChecks,
 
 	/* TiDB specific global variables: */
 	variable.TiDBSkipUTF8Check,
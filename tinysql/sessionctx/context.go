@@ -20,6 +20,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/owner"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/kvcache"
 )
 
 // Context is an interface for transaction and executive args environment.
@@ -71,6 +72,8 @@ type Context interface {
 	DDLOwnerChecker() owner.DDLOwnerChecker
 	// PrepareTxnFuture uses to prepare txn by future.
 	PrepareTxnFuture(ctx context.Context)
+	// PreparedPlanCache returns the cache of the physical plan
+	PreparedPlanCache() *kvcache.SimpleLRUCache
 }
 
 type basicCtxType int
@@ -20,6 +20,8 @@ import (
 	"time"
 
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/execdetails"
+	"github.com/pingcap/tidb/util/memory"
 	"go.uber.org/zap"
 )
 
@@ -62,6 +64,8 @@ type StatementContext struct {
 	BatchCheck             bool
 	InNullRejectCheck      bool
 	AllowInvalidDate       bool
+	// UseCache indicates that the plan of the statement is built for the prepared plan cache.
+	UseCache bool
 	// CastStrToIntStrict is used to control the way we cast float format string to int.
 	// If ConvertStrToIntStrict is false, we convert it to a valid float string first,
 	// then cast the float string to int string. Otherwise, we cast string to integer
@@ -116,6 +120,13 @@ type StatementContext struct {
 	nowTs          time.Time // use this variable for now/current_timestamp calculation/cache for one stmt
 	stmtTimeCached bool
 	StmtType       string
+	// MemTracker tracks the memory used by the executors of this statement.
+	MemTracker *memory.Tracker
+	// DiskTracker tracks the disk used by the executors of this statement.
+	DiskTracker *memory.Tracker
+	// RuntimeStatsColl collects the execution info of the executors, it is
+	// only set for EXPLAIN ANALYZE.
+	RuntimeStatsColl *execdetails.RuntimeStatsColl
 }
 
 // StmtHints are SessionVars related sql hints.
@@ -277,6 +288,20 @@ func (sc *StatementContext) SetWarnings(warns []SQLWarn) {
 	sc.mu.Unlock()
 }
 
+// TruncateWarnings truncates warnings begin from start and returns the truncated warnings.
+func (sc *StatementContext) TruncateWarnings(start int) []SQLWarn {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sz := len(sc.mu.warnings) - start
+	if sz <= 0 {
+		return nil
+	}
+	ret := make([]SQLWarn, sz)
+	copy(ret, sc.mu.warnings[start:])
+	sc.mu.warnings = sc.mu.warnings[:start]
+	return ret
+}
+
 // AppendWarning appends a warning with level 'Warning'.
 func (sc *StatementContext) AppendWarning(warn error) {
 	sc.mu.Lock()
@@ -25,12 +25,16 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/rowcodec"
+	"github.com/pingcap/tidb/util/stringutil"
 )
 
 // Error instances.
@@ -39,6 +43,10 @@ var (
 	ErrSnapshotTooOld = terror.ClassVariable.New(mysql.ErrSnapshotTooOld, mysql.MySQLErrName[mysql.ErrSnapshotTooOld])
 )
 
+// PreparedStmtCount is the number of prepared statements of all the sessions,
+// it is limited by the global variable "max_prepared_stmt_count".
+var PreparedStmtCount int64
+
 // TransactionContext is used to store variables that has transaction scope.
 type TransactionContext struct {
 	forUpdateTS   uint64
@@ -127,11 +135,23 @@ func (ib *WriteStmtBufs) clean() {
 // SessionVars is to handle user-defined or global variables in the current session.
 type SessionVars struct {
 	Concurrency
+	MemQuota
 	BatchSize
 	// UsersLock is a lock for user defined variables.
 	UsersLock sync.RWMutex
 	// Users are user defined variables.
 	Users map[string]string
+	// PreparedStmts stores prepared statement.
+	PreparedStmts        map[uint32]*ast.Prepared
+	PreparedStmtNameToID map[string]uint32
+	// preparedStmtID is id of prepared statement.
+	preparedStmtID uint32
+	// PreparedParams params for prepared statements
+	PreparedParams []types.Datum
+	// FoundInPlanCache indicates whether this statement was found in plan cache.
+	FoundInPlanCache bool
+	// PrevFoundInPlanCache indicates whether the last statement was found in plan cache.
+	PrevFoundInPlanCache bool
 	// systems variables, don't modify it directly, use GetSystemVar/SetSystemVar method.
 	systems map[string]string
 	// SysWarningCount is the system variable "warning_count", because it is on the hot path, so we extract it from the systems
@@ -172,6 +192,13 @@ type SessionVars struct {
 	// PlanColumnID is the unique id for column when building plan.
 	PlanColumnID int64
 
+	// User is the user identity with which the session login.
+	User *auth.UserIdentity
+
+	// ActiveRoles are the roles activated in the session, their privileges
+	// are granted to the user.
+	ActiveRoles []*auth.RoleIdentity
+
 	// CurrentDB is the default database of this session.
 	CurrentDB string
 
@@ -193,6 +220,10 @@ type SessionVars struct {
 	// StmtCtx holds variables for current executing statement.
 	StmtCtx *stmtctx.StatementContext
 
+	// MemTracker tracks the memory used by the statements of this session.
+	// The memory tracker of every statement is attached to it.
+	MemTracker *memory.Tracker
+
 	// AllowAggPushDown can be set to false to forbid aggregation push down.
 	AllowAggPushDown bool
 
@@ -307,6 +338,10 @@ type SessionVars struct {
 	// AllowRemoveAutoInc indicates whether a user can drop the auto_increment column attribute or not.
 	AllowRemoveAutoInc bool
 
+	// ForeignKeyChecks indicates whether the foreign key constraints are checked on writes and DDLs.
+	// See https://dev.mysql.com/doc/refman/5.7/en/server-system-variables.html#sysvar_foreign_key_checks
+	ForeignKeyChecks bool
+
 	// Unexported fields should be accessed and set through interfaces like GetReplicaRead() and SetReplicaRead().
 
 	// allowInSubqToJoinAndAgg can be set to false to forbid rewriting the semi join to inner join with agg.
@@ -343,12 +378,16 @@ type ConnectionInfo struct {
 func NewSessionVars() *SessionVars {
 	vars := &SessionVars{
 		Users:                       make(map[string]string),
+		PreparedStmts:               make(map[uint32]*ast.Prepared),
+		PreparedStmtNameToID:        make(map[string]uint32),
+		PreparedParams:              make([]types.Datum, 0, 10),
 		systems:                     make(map[string]string),
 		TxnCtx:                      &TransactionContext{},
 		KVVars:                      kv.NewVariables(),
 		StrictSQLMode:               true,
 		Status:                      mysql.ServerStatusAutocommit,
 		StmtCtx:                     new(stmtctx.StatementContext),
+		MemTracker:                  memory.NewTracker(sessionMemLabel, -1),
 		AllowAggPushDown:            false,
 		DDLReorgPriority:            kv.PriorityLow,
 		allowInSubqToJoinAndAgg:     DefOptInSubqToJoinAndAgg,
@@ -372,6 +411,7 @@ func NewSessionVars() *SessionVars {
 		EnableNoopFuncs:             DefTiDBEnableNoopFuncs,
 		replicaRead:                 kv.ReplicaReadLeader,
 		AllowRemoveAutoInc:          DefTiDBAllowRemoveAutoInc,
+		ForeignKeyChecks:            DefForeignKeyChecks,
 	}
 	vars.Concurrency = Concurrency{
 		IndexLookupConcurrency:     DefIndexLookupConcurrency,
@@ -383,10 +423,15 @@ func NewSessionVars() *SessionVars {
 		HashAggPartialConcurrency:  DefTiDBHashAggPartialConcurrency,
 		HashAggFinalConcurrency:    DefTiDBHashAggFinalConcurrency,
 	}
+	vars.MemQuota = MemQuota{
+		MemQuotaQuery: DefTiDBMemQuotaQuery,
+		MemQuotaSort:  DefTiDBMemQuotaSort,
+	}
 	vars.BatchSize = BatchSize{
-		IndexLookupSize: DefIndexLookupSize,
-		InitChunkSize:   DefInitChunkSize,
-		MaxChunkSize:    DefMaxChunkSize,
+		IndexJoinBatchSize: DefIndexJoinBatchSize,
+		IndexLookupSize:    DefIndexLookupSize,
+		InitChunkSize:      DefInitChunkSize,
+		MaxChunkSize:       DefMaxChunkSize,
 	}
 	return vars
 }
@@ -438,6 +483,51 @@ func (s *SessionVars) AllocPlanColumnID() int64 {
 	return s.PlanColumnID
 }
 
+// GetNextPreparedStmtID generates and returns the next session scope prepared statement id.
+func (s *SessionVars) GetNextPreparedStmtID() uint32 {
+	s.preparedStmtID++
+	return s.preparedStmtID
+}
+
+// AddPreparedStmt adds prepareStmt to current session and count in global.
+func (s *SessionVars) AddPreparedStmt(stmtID uint32, stmt *ast.Prepared) error {
+	if _, exists := s.PreparedStmts[stmtID]; !exists {
+		valStr, _ := s.GetSystemVar(MaxPreparedStmtCount)
+		maxPreparedStmtCount, err := strconv.ParseInt(valStr, 10, 64)
+		if err != nil {
+			maxPreparedStmtCount = DefMaxPreparedStmtCount
+		}
+		newPreparedStmtCount := atomic.AddInt64(&PreparedStmtCount, 1)
+		if maxPreparedStmtCount >= 0 && newPreparedStmtCount > maxPreparedStmtCount {
+			atomic.AddInt64(&PreparedStmtCount, -1)
+			return ErrMaxPreparedStmtCountReached.GenWithStackByArgs(maxPreparedStmtCount)
+		}
+	}
+	s.PreparedStmts[stmtID] = stmt
+	return nil
+}
+
+// RemovePreparedStmt removes preparedStmt from current session and decrease count in global.
+func (s *SessionVars) RemovePreparedStmt(stmtID uint32) {
+	_, exists := s.PreparedStmts[stmtID]
+	if !exists {
+		return
+	}
+	delete(s.PreparedStmts, stmtID)
+	atomic.AddInt64(&PreparedStmtCount, -1)
+}
+
+// WithdrawAllPreparedStmt remove all preparedStmt in current session and decrease count in global.
+func (s *SessionVars) WithdrawAllPreparedStmt() {
+	psCount := len(s.PreparedStmts)
+	if psCount == 0 {
+		return
+	}
+	atomic.AddInt64(&PreparedStmtCount, -int64(psCount))
+	s.PreparedStmts = make(map[uint32]*ast.Prepared)
+	s.PreparedStmtNameToID = make(map[string]uint32)
+}
+
 // GetCharsetInfo gets charset and collation for current context.
 // What character set should the server translate a statement to after receiving it?
 // For this, the server uses the character_set_connection and collation_connection system variables.
@@ -553,6 +643,12 @@ func (s *SessionVars) SetSystemVar(name string, val string) error {
 		s.StrictSQLMode = sqlMode.HasStrictMode()
 		s.SQLMode = sqlMode
 		s.SetStatusFlag(mysql.ServerStatusNoBackslashEscaped, sqlMode.HasNoBackslashEscapesMode())
+	case TimeZone:
+		tz, err := parseTimeZone(val)
+		if err != nil {
+			return err
+		}
+		s.TimeZone = tz
 	case AutoCommit:
 		isAutocommit := TiDBOptOn(val)
 		s.SetStatusFlag(mysql.ServerStatusAutocommit, isAutocommit)
@@ -562,6 +658,8 @@ func (s *SessionVars) SetSystemVar(name string, val string) error {
 	case MaxExecutionTime:
 		timeoutMS := tidbOptPositiveInt32(val, 0)
 		s.MaxExecutionTime = uint64(timeoutMS)
+	case ForeignKeyChecks:
+		s.ForeignKeyChecks = TiDBOptOn(val)
 	case TiDBSkipUTF8Check:
 		s.SkipUTF8Check = TiDBOptOn(val)
 	case TiDBOptAggPushDown:
@@ -598,6 +696,8 @@ func (s *SessionVars) SetSystemVar(name string, val string) error {
 		s.IndexLookupJoinConcurrency = tidbOptPositiveInt32(val, DefIndexLookupJoinConcurrency)
 	case TiDBIndexLookupSize:
 		s.IndexLookupSize = tidbOptPositiveInt32(val, DefIndexLookupSize)
+	case TiDBIndexJoinBatchSize:
+		s.IndexJoinBatchSize = tidbOptPositiveInt32(val, DefIndexJoinBatchSize)
 	case TiDBHashJoinConcurrency:
 		s.HashJoinConcurrency = tidbOptPositiveInt32(val, DefTiDBHashJoinConcurrency)
 	case TiDBProjectionConcurrency:
@@ -616,12 +716,16 @@ func (s *SessionVars) SetSystemVar(name string, val string) error {
 		s.KVVars.BackOffWeight = tidbOptPositiveInt32(val, kv.DefBackOffWeight)
 	case TiDBConstraintCheckInPlace:
 		s.ConstraintCheckInPlace = TiDBOptOn(val)
-	case TiDBCurrentTS, TiDBConfig:
+	case TiDBCurrentTS, TiDBConfig, TiDBFoundInPlanCache:
 		return ErrReadOnly
 	case TiDBMaxChunkSize:
 		s.MaxChunkSize = tidbOptPositiveInt32(val, DefMaxChunkSize)
 	case TiDBInitChunkSize:
 		s.InitChunkSize = tidbOptPositiveInt32(val, DefInitChunkSize)
+	case TiDBMemQuotaQuery:
+		s.MemQuotaQuery = tidbOptInt64(val, DefTiDBMemQuotaQuery)
+	case TiDBMemQuotaSort:
+		s.MemQuotaSort = tidbOptInt64(val, DefTiDBMemQuotaSort)
 	case TiDBGeneralLog:
 		atomic.StoreUint32(&ProcessGeneralLog, uint32(tidbOptPositiveInt32(val, DefTiDBGeneralLog)))
 	case TiDBEnableCascadesPlanner:
@@ -733,8 +837,21 @@ type Concurrency struct {
 	IndexSerialScanConcurrency int
 }
 
+var sessionMemLabel = stringutil.StringerStr("session")
+
+// MemQuota defines memory quota values.
+type MemQuota struct {
+	// MemQuotaQuery defines the memory quota for a query.
+	MemQuotaQuery int64
+	// MemQuotaSort defines the memory threshold for a sort executor to spill to disk.
+	// The sort spills when MemQuotaQuery is exceeded anyway, it takes effect only if it's positive.
+	MemQuotaSort int64
+}
+
 // BatchSize defines batch size values.
 type BatchSize struct {
+	// IndexJoinBatchSize is the batch size of a index lookup join.
+	IndexJoinBatchSize int
 
 	// IndexLookupSize is the number of handles for an index lookup task in index double read executor.
 	IndexLookupSize int
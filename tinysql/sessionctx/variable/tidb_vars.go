@@ -50,6 +50,10 @@ const (
 	// tidb_config is a read-only variable that shows the config of the current server.
 	TiDBConfig = "tidb_config"
 
+	// TiDBFoundInPlanCache indicates whether the last statement was found in plan cache.
+	// It is read-only.
+	TiDBFoundInPlanCache = "last_plan_from_cache"
+
 	// tidb_general_log is used to log every query in the server in info level.
 	TiDBGeneralLog = "tidb_general_log"
 
@@ -118,6 +122,12 @@ const (
 	// Set this value higher may reduce the latency but consumes more system resource.
 	TiDBIndexLookupConcurrency = "tidb_index_lookup_concurrency"
 
+	// tidb_index_join_batch_size is used to set the batch size of a index lookup join.
+	// The index lookup join fetches batches of data from outer executor and constructs ranges for inner executor.
+	// This value controls how much of data in a batch to do the index join.
+	// Large value may reduce the latency but consumes more system resource.
+	TiDBIndexJoinBatchSize = "tidb_index_join_batch_size"
+
 	// tidb_index_lookup_join_concurrency is used for index lookup join executor.
 	// IndexLookUpJoin starts "tidb_index_lookup_join_concurrency" inner workers
 	// to fetch inner rows and join the matched (outer, inner) row pairs.
@@ -133,6 +143,19 @@ const (
 	// TiDBInitChunkSize is used to control the init chunk size during query execution.
 	TiDBInitChunkSize = "tidb_init_chunk_size"
 
+	// tidb_mem_quota_query is used to set the memory quota of a query in bytes.
+	// When the memory usage of a query exceeds it, the executors spill data to disk
+	// or the query is cancelled, according to the "oom-action" config.
+	// A value not larger than 0 means no limit.
+	TiDBMemQuotaQuery = "tidb_mem_quota_query"
+
+	// tidb_mem_quota_sort is used to set the memory threshold of a sort executor in bytes.
+	// When the rows buffered by a sort exceed it and "oom-use-tmp-storage" is enabled,
+	// the sorted rows are spilled to temporary files and merged at the end.
+	// The sort always spills when tidb_mem_quota_query is exceeded, 0 means the sort
+	// has no threshold of its own.
+	TiDBMemQuotaSort = "tidb_mem_quota_sort"
+
 	// tidb_enable_cascades_planner is used to control whether to enable the cascades planner.
 	TiDBEnableCascadesPlanner = "tidb_enable_cascades_planner"
 
@@ -220,6 +243,9 @@ const (
 	DefIndexLookupJoinConcurrency    = 4
 	DefIndexSerialScanConcurrency    = 1
 	DefIndexLookupSize               = 20000
+	DefIndexJoinBatchSize            = 25000
+	DefTiDBMemQuotaQuery             = 1 << 30 // 1GB.
+	DefTiDBMemQuotaSort              = 0       // No limit, the sort spills when the query quota is exceeded.
 	DefDistSQLScanConcurrency        = 15
 	DefBuildStatsConcurrency         = 4
 	DefSkipUTF8Check                 = false
@@ -264,6 +290,8 @@ const (
 	DefTiDBEnableNoopFuncs           = false
 	DefTiDBAllowRemoveAutoInc        = false
 	DefInnodbLockWaitTimeout         = 50 // 50s
+	DefForeignKeyChecks              = true
+	DefTiDBFoundInPlanCache          = false
 )
 
 // Process global variables.
@@ -118,6 +118,8 @@ func GetSessionOnlySysVars(s *SessionVars, key string) (string, bool, error) {
 			return "", false, err
 		}
 		return string(j), true, nil
+	case TiDBFoundInPlanCache:
+		return BoolToIntStr(s.PrevFoundInPlanCache), true, nil
 	}
 	sVal, ok := s.GetSystemVar(key)
 	if ok {
@@ -277,10 +279,7 @@ func ValidateSetSystemVar(vars *SessionVars, name string, value string) (string,
 		return checkUInt64SystemVar(name, value, 0, secondsPerYear, vars)
 	case ForeignKeyChecks:
 		if strings.EqualFold(value, "ON") || value == "1" {
-			// TiDB does not yet support foreign keys.
-			// For now, resist the change and show a warning.
-			vars.StmtCtx.AppendWarning(ErrUnsupportedValueForVar.GenWithStackByArgs(name, value))
-			return "OFF", nil
+			return "ON", nil
 		} else if strings.EqualFold(value, "OFF") || value == "0" {
 			return "OFF", nil
 		}
@@ -415,7 +414,7 @@ func ValidateSetSystemVar(vars *SessionVars, name string, value string) (string,
 		return checkUInt64SystemVar(name, value, uint64(MinDDLReorgBatchSize), uint64(MaxDDLReorgBatchSize), vars)
 	case TiDBDDLErrorCountLimit:
 		return checkUInt64SystemVar(name, value, uint64(0), math.MaxInt64, vars)
-	case TiDBIndexLookupConcurrency, TiDBIndexLookupJoinConcurrency,
+	case TiDBIndexLookupConcurrency, TiDBIndexLookupJoinConcurrency, TiDBIndexJoinBatchSize,
 		TiDBIndexLookupSize,
 		TiDBHashJoinConcurrency,
 		TiDBHashAggPartialConcurrency,
@@ -509,6 +508,11 @@ func ValidateSetSystemVar(vars *SessionVars, name string, value string) (string,
 			return value, errors.Errorf("tidb_init_chunk_size(%d) cannot be bigger than %d", v, initChunkSizeUpperBound)
 		}
 		return value, nil
+	case TiDBMemQuotaQuery, TiDBMemQuotaSort:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return value, ErrWrongTypeForVar.GenWithStackByArgs(name)
+		}
+		return value, nil
 	case TiDBMaxChunkSize:
 		v, err := strconv.Atoi(value)
 		if err != nil {
@@ -541,6 +545,12 @@ func ValidateSetSystemVar(vars *SessionVars, name string, value string) (string,
 			return "leader", nil
 		}
 		return value, ErrWrongValueForVar.GenWithStackByArgs(name, value)
+	case TimeZone:
+		if strings.EqualFold(value, "SYSTEM") {
+			return "SYSTEM", nil
+		}
+		_, err := parseTimeZone(value)
+		return value, err
 	case TiDBAllowRemoveAutoInc:
 		switch {
 		case strings.EqualFold(value, "ON") || value == "1":
@@ -553,6 +563,44 @@ func ValidateSetSystemVar(vars *SessionVars, name string, value string) (string,
 	return value, nil
 }
 
+// parseTimeZone parses the value of time_zone. It accepts "SYSTEM", a named zone
+// like "Asia/Shanghai", or an offset from UTC like "+08:00".
+func parseTimeZone(s string) (*time.Location, error) {
+	if strings.EqualFold(s, "SYSTEM") {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(s)
+	if err == nil {
+		return loc, nil
+	}
+
+	// The value can be given as a string indicating an offset from UTC, such as '+10:00' or '-6:00'.
+	// The time zone's value should in [-12:59,+14:00].
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		d, err := types.ParseDuration(nil, s[1:], 0)
+		if err == nil {
+			if s[0] == '-' {
+				if d.Duration > 12*time.Hour+59*time.Minute {
+					return nil, ErrUnknownTimeZone.GenWithStackByArgs(s)
+				}
+			} else {
+				if d.Duration > 14*time.Hour {
+					return nil, ErrUnknownTimeZone.GenWithStackByArgs(s)
+				}
+			}
+
+			ofst := int(d.Duration / time.Second)
+			if s[0] == '-' {
+				ofst = -ofst
+			}
+			return time.FixedZone("", ofst), nil
+		}
+	}
+
+	return nil, ErrUnknownTimeZone.GenWithStackByArgs(s)
+}
+
 // TiDBOptOn could be used for all tidb session variable options, we use "ON"/1 to turn on those options.
 func TiDBOptOn(opt string) bool {
 	return strings.EqualFold(opt, "ON") || opt == "1"
@@ -62,6 +62,8 @@ func (s *testVarsutilSuite) TestNewSessionVars(c *C) {
 	c.Assert(vars.HashAggFinalConcurrency, Equals, DefTiDBHashAggFinalConcurrency)
 	c.Assert(vars.DistSQLScanConcurrency, Equals, DefDistSQLScanConcurrency)
 	c.Assert(vars.MaxChunkSize, Equals, DefMaxChunkSize)
+	c.Assert(vars.MemQuotaQuery, Equals, int64(DefTiDBMemQuotaQuery))
+	c.Assert(vars.MemQuotaSort, Equals, int64(DefTiDBMemQuotaSort))
 	c.Assert(vars.EnableRadixJoin, Equals, DefTiDBUseRadixJoin)
 	c.Assert(vars.AllowWriteRowID, Equals, DefOptWriteRowID)
 	c.Assert(vars.TiDBOptJoinReorderThreshold, Equals, DefTiDBOptJoinReorderThreshold)
@@ -109,6 +111,13 @@ func (s *testVarsutilSuite) TestValidate(c *C) {
 		{DelayKeyWrite, "OFF", false},
 		{DelayKeyWrite, "ALL", false},
 		{DelayKeyWrite, "3", true},
+		{TimeZone, "SYSTEM", false},
+		{TimeZone, "UTC", false},
+		{TimeZone, "+08:00", false},
+		{TimeZone, "-12:59", false},
+		{TimeZone, "+14:01", true},
+		{TimeZone, "-13:00", true},
+		{TimeZone, "not_a_zone", true},
 		{ForeignKeyChecks, "3", true},
 		{MaxSpRecursionDepth, "256", false},
 		{SessionTrackGtids, "OFF", false},
@@ -153,6 +162,10 @@ func (s *testVarsutilSuite) TestValidate(c *C) {
 		{TiDBInitChunkSize, "-1", true},
 		{TiDBMaxChunkSize, "a", true},
 		{TiDBMaxChunkSize, "-1", true},
+		{TiDBMemQuotaQuery, "a", true},
+		{TiDBMemQuotaQuery, "-1", false},
+		{TiDBMemQuotaSort, "a", true},
+		{TiDBMemQuotaSort, "1024", false},
 		{TiDBOptJoinReorderThreshold, "a", true},
 		{TiDBOptJoinReorderThreshold, "-1", true},
 		{TiDBReplicaRead, "invalid", true},
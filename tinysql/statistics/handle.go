@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/store/tikv/oracle"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/logutil"
@@ -133,7 +134,7 @@ func (h *Handle) Update(is infoschema.InfoSchema) error {
 		modifyCount := row.GetInt64(2)
 		count := row.GetInt64(3)
 		lastVersion = version
-		table, ok := is.TableByID(physicalID)
+		table, ok := getTableByPhysicalID(is, physicalID)
 		if !ok {
 			logutil.BgLogger().Debug("unknown physical ID in stats meta table, maybe it has been dropped", zap.Int64("ID", physicalID))
 			deletedTableIDs = append(deletedTableIDs, physicalID)
@@ -160,6 +161,23 @@ func (h *Handle) Update(is infoschema.InfoSchema) error {
 	return nil
 }
 
+// getTableByPhysicalID returns the table of a physical ID, which is the ID of
+// a non-partitioned table or the ID of a partition.
+func getTableByPhysicalID(is infoschema.InfoSchema, physicalID int64) (table.Table, bool) {
+	if tbl, ok := is.TableByID(physicalID); ok {
+		return tbl, true
+	}
+	for _, db := range is.AllSchemas() {
+		for _, tbl := range is.SchemaTables(db.Name) {
+			pi := tbl.Meta().GetPartitionInfo()
+			if pi != nil && pi.GetNameByID(physicalID) != "" {
+				return tbl, true
+			}
+		}
+	}
+	return nil, false
+}
+
 func getFullTableName(is infoschema.InfoSchema, tblInfo *model.TableInfo) string {
 	for _, schema := range is.AllSchemas() {
 		if t, err := is.TableByName(schema.Name, tblInfo.Name); err == nil {
@@ -15,6 +15,8 @@ package mocktikv
 
 import (
 	"context"
+	"time"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
@@ -41,7 +43,8 @@ type hashAggExec struct {
 	currGroupIdx      int
 	count             int64
 
-	src executor
+	src        executor
+	execDetail *execDetail
 }
 
 func (e *hashAggExec) SetSrcExec(exec executor) {
@@ -52,6 +55,10 @@ func (e *hashAggExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *hashAggExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *hashAggExec) ResetCounts() {
 	e.src.ResetCounts()
 }
@@ -76,6 +83,9 @@ func (e *hashAggExec) innerNext(ctx context.Context) (bool, error) {
 }
 
 func (e *hashAggExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	e.count++
 	if e.aggCtxsMap == nil {
 		e.aggCtxsMap = make(aggCtxsMapper)
@@ -180,3 +190,159 @@ func (e *hashAggExec) getContexts(groupKey []byte) []*aggregation.AggEvaluateCon
 	}
 	return aggCtxs
 }
+
+var _ executor = &streamAggExec{}
+
+type streamAggExec struct {
+	evalCtx           *evalContext
+	aggExprs          []aggregation.Aggregation
+	aggCtxs           []*aggregation.AggEvaluateContext
+	groupByExprs      []expression.Expression
+	relatedColOffsets []int
+	row               []types.Datum
+	// currGroupByRow is the group by values of the group being aggregated.
+	currGroupByRow []types.Datum
+	// nextGroupByRow is the group by values of the row that starts a new group.
+	nextGroupByRow []types.Datum
+	executed       bool
+	hasData        bool
+	count          int64
+
+	src        executor
+	execDetail *execDetail
+}
+
+func (e *streamAggExec) SetSrcExec(exec executor) {
+	e.src = exec
+}
+
+func (e *streamAggExec) GetSrcExec() executor {
+	return e.src
+}
+
+func (e *streamAggExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
+func (e *streamAggExec) ResetCounts() {
+	e.src.ResetCounts()
+}
+
+func (e *streamAggExec) Counts() []int64 {
+	return e.src.Counts()
+}
+
+// getPartialResult returns the partial results and the group by values of
+// the current group, and resets the aggregate contexts for the next group.
+func (e *streamAggExec) getPartialResult() ([][]byte, error) {
+	value := make([][]byte, 0, len(e.groupByExprs)+2*len(e.aggExprs))
+	for i, agg := range e.aggExprs {
+		partialResults := agg.GetPartialResult(e.aggCtxs[i])
+		for _, result := range partialResults {
+			data, err := codec.EncodeValue(e.evalCtx.sc, nil, result)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value = append(value, data)
+		}
+		e.aggCtxs[i] = agg.CreateContext(e.evalCtx.sc)
+	}
+	for _, d := range e.currGroupByRow {
+		data, err := codec.EncodeValue(e.evalCtx.sc, nil, d)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		value = append(value, data)
+	}
+	e.currGroupByRow = e.nextGroupByRow
+	return value, nil
+}
+
+// meetNewGroup evaluates the group by items on the current row and reports
+// whether the row starts a new group. The first row is not treated as a new
+// group.
+func (e *streamAggExec) meetNewGroup() (bool, error) {
+	if len(e.groupByExprs) == 0 {
+		return false, nil
+	}
+	groupByRow := make([]types.Datum, 0, len(e.groupByExprs))
+	matched := e.currGroupByRow != nil
+	for i, item := range e.groupByExprs {
+		d, err := item.Eval(chunk.MutRowFromDatums(e.row).ToRow())
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if matched {
+			c, err := d.CompareDatum(e.evalCtx.sc, &e.currGroupByRow[i])
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			matched = c == 0
+		}
+		groupByRow = append(groupByRow, *d.Copy())
+	}
+	if e.currGroupByRow == nil {
+		e.currGroupByRow = groupByRow
+		return false, nil
+	}
+	if matched {
+		return false, nil
+	}
+	e.nextGroupByRow = groupByRow
+	return true, nil
+}
+
+func (e *streamAggExec) Next(ctx context.Context) (retRow [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, retRow)
+	}(time.Now())
+	e.count++
+	if e.executed {
+		return nil, nil
+	}
+	if e.aggCtxs == nil {
+		e.aggCtxs = make([]*aggregation.AggEvaluateContext, 0, len(e.aggExprs))
+		for _, agg := range e.aggExprs {
+			e.aggCtxs = append(e.aggCtxs, agg.CreateContext(e.evalCtx.sc))
+		}
+	}
+
+	for {
+		values, err := e.src.Next(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if values == nil {
+			e.executed = true
+			if !e.hasData {
+				return nil, nil
+			}
+			return e.getPartialResult()
+		}
+
+		e.hasData = true
+		err = e.evalCtx.decodeRelatedColumnVals(e.relatedColOffsets, values, e.row)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		newGroup, err := e.meetNewGroup()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if newGroup {
+			retRow, err = e.getPartialResult()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		for i, agg := range e.aggExprs {
+			err = agg.Update(e.aggCtxs[i], e.evalCtx.sc, chunk.MutRowFromDatums(e.row).ToRow())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if newGroup {
+			return retRow, nil
+		}
+	}
+}
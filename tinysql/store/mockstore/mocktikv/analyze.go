@@ -70,11 +70,12 @@ func (h *rpcHandler) handleAnalyzeIndexReq(req *coprocessor.Request, analyzeReq
 		startTS = analyzeReq.GetStartTsFallback()
 	}
 	e := &indexScanExec{
-		colsLen:   int(analyzeReq.IdxReq.NumColumns),
-		kvRanges:  ranges,
-		startTS:   startTS,
-		mvccStore: h.mvccStore,
-		IndexScan: &tipb.IndexScan{Desc: false},
+		colsLen:    int(analyzeReq.IdxReq.NumColumns),
+		kvRanges:   ranges,
+		startTS:    startTS,
+		mvccStore:  h.mvccStore,
+		IndexScan:  &tipb.IndexScan{Desc: false},
+		execDetail: new(execDetail),
 	}
 	statsBuilder := statistics.NewSortedBuilder(flagsToStatementContext(analyzeReq.Flags), analyzeReq.IdxReq.BucketSize, 0, types.NewFieldType(mysql.TypeBlob))
 	var cms *statistics.CMSketch
@@ -162,12 +163,13 @@ func (h *rpcHandler) handleAnalyzeColumnsReq(req *coprocessor.Request, analyzeRe
 	rd := rowcodec.NewByteDecoder(colInfos, -1, defVal, nil)
 	e := &analyzeColumnsExec{
 		tblExec: &tableScanExec{
-			TableScan: &tipb.TableScan{Columns: columns},
-			kvRanges:  ranges,
-			colIDs:    evalCtx.colIDs,
-			startTS:   startTS,
-			mvccStore: h.mvccStore,
-			rd:        rd,
+			TableScan:  &tipb.TableScan{Columns: columns},
+			kvRanges:   ranges,
+			colIDs:     evalCtx.colIDs,
+			startTS:    startTS,
+			mvccStore:  h.mvccStore,
+			rd:         rd,
+			execDetail: new(execDetail),
 		},
 	}
 	e.fields = make([]*ast.ResultField, len(columns))
@@ -17,6 +17,7 @@ import (
 	"bytes"
 	"context"
 	"sort"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
@@ -43,6 +44,32 @@ type executor interface {
 	ResetCounts()
 	Counts() []int64
 	Next(ctx context.Context) ([][]byte, error)
+	// ExecDetails returns its and its children's execution details.
+	// The order is same as DAGRequest.Executors, which children are in front of parents.
+	ExecDetails() []*execDetail
+}
+
+// execDetail records the time cost, produced rows and the Next calls of an
+// executor, they are returned to TiDB as the execution summaries.
+type execDetail struct {
+	timeProcessed   time.Duration
+	numProducedRows int
+	numIterations   int
+}
+
+func (e *execDetail) update(begin time.Time, row [][]byte) {
+	e.timeProcessed += time.Since(begin)
+	e.numIterations++
+	if row != nil {
+		e.numProducedRows++
+	}
+}
+
+func collectExecDetails(src executor, detail *execDetail) []*execDetail {
+	if src == nil {
+		return []*execDetail{detail}
+	}
+	return append(src.ExecDetails(), detail)
 }
 
 type tableScanExec struct {
@@ -56,7 +83,8 @@ type tableScanExec struct {
 	start     int
 	counts    []int64
 
-	src executor
+	execDetail *execDetail
+	src        executor
 
 	rd *rowcodec.BytesDecoder
 }
@@ -69,6 +97,10 @@ func (e *tableScanExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *tableScanExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *tableScanExec) ResetCounts() {
 	if e.counts != nil {
 		e.start = e.cursor
@@ -87,6 +119,9 @@ func (e *tableScanExec) Counts() []int64 {
 }
 
 func (e *tableScanExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	for e.cursor < len(e.kvRanges) {
 		ran := e.kvRanges[e.cursor]
 		if ran.IsPoint() {
@@ -200,7 +235,8 @@ type indexScanExec struct {
 	start     int
 	counts    []int64
 
-	src executor
+	execDetail *execDetail
+	src        executor
 }
 
 func (e *indexScanExec) SetSrcExec(exec executor) {
@@ -211,6 +247,10 @@ func (e *indexScanExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *indexScanExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *indexScanExec) ResetCounts() {
 	if e.counts != nil {
 		e.start = e.cursor
@@ -233,6 +273,9 @@ func (e *indexScanExec) isUnique() bool {
 }
 
 func (e *indexScanExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	for e.cursor < len(e.kvRanges) {
 		ran := e.kvRanges[e.cursor]
 		if ran.IsPoint() && e.isUnique() {
@@ -324,6 +367,7 @@ type selectionExec struct {
 	relatedColOffsets []int
 	row               []types.Datum
 	evalCtx           *evalContext
+	execDetail        *execDetail
 	src               executor
 }
 
@@ -335,6 +379,10 @@ func (e *selectionExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *selectionExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *selectionExec) ResetCounts() {
 	e.src.ResetCounts()
 }
@@ -366,6 +414,9 @@ func evalBool(exprs []expression.Expression, row []types.Datum, ctx *stmtctx.Sta
 }
 
 func (e *selectionExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	for {
 		value, err = e.src.Next(ctx)
 		if err != nil {
@@ -398,7 +449,8 @@ type topNExec struct {
 	cursor            int
 	executed          bool
 
-	src executor
+	execDetail *execDetail
+	src        executor
 }
 
 func (e *topNExec) SetSrcExec(src executor) {
@@ -409,6 +461,10 @@ func (e *topNExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *topNExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *topNExec) ResetCounts() {
 	e.src.ResetCounts()
 }
@@ -433,6 +489,9 @@ func (e *topNExec) innerNext(ctx context.Context) (bool, error) {
 }
 
 func (e *topNExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	if !e.executed {
 		for {
 			hasMore, err := e.innerNext(ctx)
@@ -482,7 +541,8 @@ type limitExec struct {
 	limit  uint64
 	cursor uint64
 
-	src executor
+	execDetail *execDetail
+	src        executor
 }
 
 func (e *limitExec) SetSrcExec(src executor) {
@@ -493,6 +553,10 @@ func (e *limitExec) GetSrcExec() executor {
 	return e.src
 }
 
+func (e *limitExec) ExecDetails() []*execDetail {
+	return collectExecDetails(e.src, e.execDetail)
+}
+
 func (e *limitExec) ResetCounts() {
 	e.src.ResetCounts()
 }
@@ -502,6 +566,9 @@ func (e *limitExec) Counts() []int64 {
 }
 
 func (e *limitExec) Next(ctx context.Context) (value [][]byte, err error) {
+	defer func(begin time.Time) {
+		e.execDetail.update(begin, value)
+	}(time.Now())
 	if e.cursor >= e.limit {
 		return nil, nil
 	}
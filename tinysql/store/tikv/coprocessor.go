@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/store/tikv/tikvrpc"
+	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/logutil"
 	"go.uber.org/zap"
 )
@@ -369,6 +370,7 @@ type copIteratorTaskSender struct {
 
 type copResponse struct {
 	pbResp   *coprocessor.Response
+	detail   *execdetails.ExecDetails
 	err      error
 	respSize int64
 	respTime time.Duration
@@ -397,6 +399,11 @@ func (rs *copResponse) RespTime() time.Duration {
 	return rs.respTime
 }
 
+// GetExecDetails returns the ExecDetails of the copResponse.
+func (rs *copResponse) GetExecDetails() *execdetails.ExecDetails {
+	return rs.detail
+}
+
 const minLogCopTaskTime = 300 * time.Millisecond
 
 // run is a worker function that get a copTask from channel, handle it and
@@ -621,7 +628,14 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 		worker.logTimeCopTask(costTime, task, bo, resp)
 	}
 
-	return worker.handleCopResponse(bo, rpcCtx, &copResponse{pbResp: resp.Resp.(*coprocessor.Response)}, task, ch)
+	return worker.handleCopResponse(bo, rpcCtx, &copResponse{
+		pbResp:   resp.Resp.(*coprocessor.Response),
+		respTime: costTime,
+		detail: &execdetails.ExecDetails{
+			CalleeAddress: task.storeAddr,
+			RegionID:      task.region.id,
+		},
+	}, task, ch)
 }
 
 type minCommitTSPushed struct {
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"go.uber.org/zap"
@@ -140,10 +141,25 @@ func handleWrongUtf8Value(ctx sessionctx.Context, col *model.ColumnInfo, casted
 // CastValue casts a value based on column type.
 // TODO: change the third arg to TypeField. Not pass ColumnInfo.
 func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo) (casted types.Datum, err error) {
+	return castValue(ctx, val, col, -1)
+}
+
+// CastValueForRow casts a value to be written to the rowIdx-th row of the statement, the rounded DECIMAL
+// values and the invalid ENUM and SET values are reported with the column and the row like MySQL.
+func CastValueForRow(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, rowIdx int) (casted types.Datum, err error) {
+	return castValue(ctx, val, col, rowIdx)
+}
+
+func castValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, rowIdx int) (casted types.Datum, err error) {
 	sc := ctx.GetSessionVars().StmtCtx
+	if col.Tp == mysql.TypeNewDecimal && rowIdx >= 0 {
+		val = roundDecimalValue(sc, val, col, rowIdx)
+	}
 	casted, err = val.ConvertTo(sc, &col.FieldType)
 	// TODO: make sure all truncate errors are handled by ConvertTo.
-	if types.ErrTruncated.Equal(err) {
+	if types.ErrTruncated.Equal(err) && rowIdx >= 0 && (col.Tp == mysql.TypeEnum || col.Tp == mysql.TypeSet) {
+		err = sc.HandleTruncate(types.ErrTruncated.GenWithStackByArgs(col.Name.O, rowIdx+1))
+	} else if types.ErrTruncated.Equal(err) {
 		str, err1 := val.ToString()
 		if err1 != nil {
 			logutil.BgLogger().Warn("Datum ToString failed", zap.Stringer("Datum", val), zap.Error(err1))
@@ -189,6 +205,36 @@ func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo) (
 	return casted, err
 }
 
+// roundDecimalValue rounds a value written to the rowIdx-th row to the scale of the DECIMAL column.
+// Like MySQL, the rounding is reported by a note with the column and the row.
+func roundDecimalValue(sc *stmtctx.StatementContext, val types.Datum, col *model.ColumnInfo, rowIdx int) types.Datum {
+	if col.Decimal == types.UnspecifiedLength {
+		return val
+	}
+	dec := new(types.MyDecimal)
+	switch val.Kind() {
+	case types.KindMysqlDecimal:
+		dec = val.GetMysqlDecimal()
+	case types.KindFloat32, types.KindFloat64:
+		if dec.FromFloat64(val.GetFloat64()) != nil {
+			return val
+		}
+	case types.KindString, types.KindBytes:
+		if dec.FromString(val.GetBytes()) != nil {
+			// The invalid value is reported by ConvertTo.
+			return val
+		}
+	default:
+		return val
+	}
+	rounded := new(types.MyDecimal)
+	if dec.Round(rounded, col.Decimal, types.ModeHalfUp) != nil || rounded.Compare(dec) == 0 {
+		return val
+	}
+	sc.AppendNote(types.ErrTruncated.GenWithStackByArgs(col.Name.O, rowIdx+1))
+	return types.NewDecimalDatum(rounded)
+}
+
 // ColDesc describes column information like MySQL desc and show columns do.
 type ColDesc struct {
 	Field string
@@ -329,6 +375,11 @@ func getColDefaultValue(ctx sessionctx.Context, col *model.ColumnInfo, defaultVa
 		return getColDefaultValueFromNil(ctx, col)
 	}
 
+	if col.Tp == mysql.TypeTimestamp || col.Tp == mysql.TypeDatetime {
+		if s, ok := defaultVal.(string); ok && strings.EqualFold(s, ast.CurrentTimestamp) {
+			return getCurrentTimestampValue(ctx, col)
+		}
+	}
 	value, err := CastValue(ctx, types.NewDatum(defaultVal), col)
 	if err != nil {
 		return types.Datum{}, err
@@ -336,6 +387,20 @@ func getColDefaultValue(ctx sessionctx.Context, col *model.ColumnInfo, defaultVa
 	return value, nil
 }
 
+// getCurrentTimestampValue gets the value of the CURRENT_TIMESTAMP default in the session time zone,
+// rounded to the fsp of the column.
+func getCurrentTimestampValue(ctx sessionctx.Context, col *model.ColumnInfo) (types.Datum, error) {
+	vars := ctx.GetSessionVars()
+	sc := vars.StmtCtx
+	nowTs := sc.GetNowTsCached().In(vars.Location())
+	t := types.NewTime(types.FromGoTime(nowTs), col.Tp, types.MaxFsp)
+	t, err := t.RoundFrac(sc, int8(col.Decimal))
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return types.NewTimeDatum(t), nil
+}
+
 func getColDefaultValueFromNil(ctx sessionctx.Context, col *model.ColumnInfo) (types.Datum, error) {
 	if !mysql.HasNotNullFlag(col.Flag) {
 		return types.Datum{}, nil
@@ -371,6 +436,10 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		d.SetFloat32(0)
 	case mysql.TypeDouble:
 		d.SetFloat64(0)
+	case mysql.TypeNewDecimal:
+		d.SetLength(col.Flen)
+		d.SetFrac(col.Decimal)
+		d.SetMysqlDecimal(new(types.MyDecimal))
 	case mysql.TypeString:
 		if col.Flen > 0 && col.Charset == charset.CharsetBin {
 			d.SetBytes(make([]byte, col.Flen))
@@ -381,6 +450,27 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		d.SetString("")
 	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 		d.SetBytes([]byte{})
+	case mysql.TypeDuration:
+		d.SetMysqlDuration(types.ZeroDuration)
+	case mysql.TypeDate:
+		d.SetMysqlTime(types.ZeroDate)
+	case mysql.TypeTimestamp:
+		d.SetMysqlTime(types.ZeroTimestamp)
+	case mysql.TypeDatetime:
+		d.SetMysqlTime(types.ZeroDatetime)
+	case mysql.TypeBit:
+		d.SetMysqlBit(types.ZeroBinaryLiteral)
+	case mysql.TypeSet:
+		d.SetMysqlSet(types.Set{})
+	case mysql.TypeEnum:
+		// The implicit default of a NOT NULL enum is its first element.
+		if len(col.Elems) > 0 {
+			d.SetMysqlEnum(types.Enum{Name: col.Elems[0], Value: 1})
+		} else {
+			d.SetMysqlEnum(types.Enum{})
+		}
+	case mysql.TypeJSON:
+		d.SetMysqlJSON(json.CreateBinary(nil))
 	}
 	return d
 }
@@ -91,6 +91,14 @@ var (
 	ErrNoPartitionForGivenValue = terror.ClassTable.New(mysql.ErrNoPartitionForGivenValue, mysql.MySQLErrName[mysql.ErrNoPartitionForGivenValue])
 	// ErrLockOrActiveTransaction returns when execute unsupported statement in a lock session or an active transaction.
 	ErrLockOrActiveTransaction = terror.ClassTable.New(mysql.ErrLockOrActiveTransaction, mysql.MySQLErrName[mysql.ErrLockOrActiveTransaction])
+	// ErrCheckConstraintViolated returns when a row doesn't satisfy a CHECK constraint of the table.
+	ErrCheckConstraintViolated = terror.ClassTable.New(mysql.ErrCheckConstraintViolated, mysql.MySQLErrName[mysql.ErrCheckConstraintViolated])
+	// ErrNoReferencedRow2 returns when a row refers a parent row that doesn't exist by a foreign key.
+	ErrNoReferencedRow2 = terror.ClassTable.New(mysql.ErrNoReferencedRow2, mysql.MySQLErrName[mysql.ErrNoReferencedRow2])
+	// ErrRowIsReferenced2 returns when a row is deleted while the rows of a child table still refer it.
+	ErrRowIsReferenced2 = terror.ClassTable.New(mysql.ErrRowIsReferenced2, mysql.MySQLErrName[mysql.ErrRowIsReferenced2])
+	// ErrForeignKeyCascadeDepthExceeded returns when the cascading foreign key actions are nested too deeply.
+	ErrForeignKeyCascadeDepthExceeded = terror.ClassTable.New(mysql.ErrForeignKeyCascadeDepthExceeded, mysql.MySQLErrName[mysql.ErrForeignKeyCascadeDepthExceeded])
 )
 
 // RecordIterFunc is used for low-level record iteration.
@@ -216,6 +224,15 @@ type PhysicalTable interface {
 	GetPhysicalID() int64
 }
 
+// PartitionedTable is a Table, and it has a GetPartition() method.
+// GetPartition() gets the partition from a partition table by a physical table ID,
+// GetPartitionByRow() locates the partition that a row belongs to.
+type PartitionedTable interface {
+	Table
+	GetPartition(physicalID int64) PhysicalTable
+	GetPartitionByRow(sessionctx.Context, []types.Datum) (PhysicalTable, error)
+}
+
 // TableFromMeta builds a table.Table from *model.TableInfo.
 // Currently, it is assigned to tables.TableFromMeta in tidb package's init function.
 var TableFromMeta func(alloc autoid.Allocator, tblInfo *model.TableInfo) (Table, error)
@@ -236,23 +253,27 @@ func (s Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 func init() {
 	tableMySQLErrCodes := map[terror.ErrCode]uint16{
-		mysql.ErrBadNull:                     mysql.ErrBadNull,
-		mysql.ErrBadField:                    mysql.ErrBadField,
-		mysql.ErrFieldSpecifiedTwice:         mysql.ErrFieldSpecifiedTwice,
-		mysql.ErrNoDefaultForField:           mysql.ErrNoDefaultForField,
-		mysql.ErrTruncatedWrongValueForField: mysql.ErrTruncatedWrongValueForField,
-		mysql.ErrUnknownPartition:            mysql.ErrUnknownPartition,
-		mysql.ErrNoPartitionForGivenValue:    mysql.ErrNoPartitionForGivenValue,
-		mysql.ErrLockOrActiveTransaction:     mysql.ErrLockOrActiveTransaction,
-		mysql.ErrIndexOutBound:               mysql.ErrIndexOutBound,
-		mysql.ErrColumnStateNonPublic:        mysql.ErrColumnStateNonPublic,
-		mysql.ErrFieldGetDefaultFailed:       mysql.ErrFieldGetDefaultFailed,
-		mysql.ErrUnsupportedOp:               mysql.ErrUnsupportedOp,
-		mysql.ErrRowNotFound:                 mysql.ErrRowNotFound,
-		mysql.ErrTableStateCantNone:          mysql.ErrTableStateCantNone,
-		mysql.ErrColumnStateCantNone:         mysql.ErrColumnStateCantNone,
-		mysql.ErrIndexStateCantNone:          mysql.ErrIndexStateCantNone,
-		mysql.ErrInvalidRecordKey:            mysql.ErrInvalidRecordKey,
+		mysql.ErrBadNull:                        mysql.ErrBadNull,
+		mysql.ErrBadField:                       mysql.ErrBadField,
+		mysql.ErrFieldSpecifiedTwice:            mysql.ErrFieldSpecifiedTwice,
+		mysql.ErrNoDefaultForField:              mysql.ErrNoDefaultForField,
+		mysql.ErrTruncatedWrongValueForField:    mysql.ErrTruncatedWrongValueForField,
+		mysql.ErrUnknownPartition:               mysql.ErrUnknownPartition,
+		mysql.ErrNoPartitionForGivenValue:       mysql.ErrNoPartitionForGivenValue,
+		mysql.ErrLockOrActiveTransaction:        mysql.ErrLockOrActiveTransaction,
+		mysql.ErrIndexOutBound:                  mysql.ErrIndexOutBound,
+		mysql.ErrColumnStateNonPublic:           mysql.ErrColumnStateNonPublic,
+		mysql.ErrFieldGetDefaultFailed:          mysql.ErrFieldGetDefaultFailed,
+		mysql.ErrUnsupportedOp:                  mysql.ErrUnsupportedOp,
+		mysql.ErrRowNotFound:                    mysql.ErrRowNotFound,
+		mysql.ErrTableStateCantNone:             mysql.ErrTableStateCantNone,
+		mysql.ErrColumnStateCantNone:            mysql.ErrColumnStateCantNone,
+		mysql.ErrIndexStateCantNone:             mysql.ErrIndexStateCantNone,
+		mysql.ErrInvalidRecordKey:               mysql.ErrInvalidRecordKey,
+		mysql.ErrCheckConstraintViolated:        mysql.ErrCheckConstraintViolated,
+		mysql.ErrNoReferencedRow2:               mysql.ErrNoReferencedRow2,
+		mysql.ErrRowIsReferenced2:               mysql.ErrRowIsReferenced2,
+		mysql.ErrForeignKeyCascadeDepthExceeded: mysql.ErrForeignKeyCascadeDepthExceeded,
 	}
 	terror.ErrClassToMySQLCodes[terror.ClassTable] = tableMySQLErrCodes
 }
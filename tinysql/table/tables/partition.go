@@ -0,0 +1,303 @@
+// Copyright 2018 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+)
+
+// Both partition and partitionedTable implement the table.Table interface.
+var _ table.PhysicalTable = &partition{}
+var _ table.Table = &partitionedTable{}
+
+// partitionedTable implements the table.PartitionedTable interface.
+var _ table.PartitionedTable = &partitionedTable{}
+
+// partition is a feature from MySQL:
+// See https://dev.mysql.com/doc/refman/8.0/en/partitioning.html
+// A partition table may contain many partitions, each partition has a unique partition
+// id. The underlying representation of a partition and a normal table (a table with no
+// partitions) is basically the same.
+// partition also implements the table.Table interface.
+type partition struct {
+	TableCommon
+}
+
+// GetPhysicalID implements table.Table GetPhysicalID interface.
+func (p *partition) GetPhysicalID() int64 {
+	return p.physicalTableID
+}
+
+// partitionedTable implements the table.PartitionedTable interface.
+// partitionedTable is a table, it contains many Partitions.
+type partitionedTable struct {
+	TableCommon
+	partitionExpr *PartitionExpr
+	partitions    map[int64]*partition
+}
+
+func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
+	ret := &partitionedTable{TableCommon: *tbl}
+	partitionExpr, err := newPartitionExpr(tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret.partitionExpr = partitionExpr
+
+	pi := tblInfo.GetPartitionInfo()
+	partitions := make(map[int64]*partition, len(pi.Definitions))
+	for _, p := range pi.Definitions {
+		var t partition
+		err := initTableCommonWithIndices(&t.TableCommon, tblInfo, p.ID, tbl.Columns, tbl.alloc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		partitions[p.ID] = &t
+	}
+	ret.partitions = partitions
+	return ret, nil
+}
+
+// PartitionExpr is the partition definition expressions.
+type PartitionExpr struct {
+	// Expr is the partitioning expression, the Index of the columns in it is
+	// the offset of the column in the table.
+	Expr expression.Expression
+	// Column is the only column in Expr, it is nil if Expr has more than one
+	// column.
+	Column *expression.Column
+	// UpperBounds are the upper bounds of the RANGE partitions, the last one
+	// is math.MaxInt64 if MaxValue is true.
+	UpperBounds []int64
+	// MaxValue is true if the last RANGE partition is a MAXVALUE partition.
+	MaxValue bool
+	// InValues maps the values of the LIST partitions to their offsets.
+	InValues map[int64]int
+	// NullIdx is the offset of the LIST partition containing NULL, -1 if
+	// there is no such partition.
+	NullIdx int
+}
+
+func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
+	pi := tblInfo.GetPartitionInfo()
+	ctx := mock.NewContext()
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, pi.Expr, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ret := &PartitionExpr{Expr: expr, NullIdx: -1}
+	if cols := expression.ExtractColumns(expr); len(cols) == 1 {
+		ret.Column = cols[0]
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		ret.UpperBounds = make([]int64, 0, len(pi.Definitions))
+		for _, def := range pi.Definitions {
+			if strings.EqualFold(def.LessThan[0], "MAXVALUE") {
+				ret.MaxValue = true
+				ret.UpperBounds = append(ret.UpperBounds, math.MaxInt64)
+				continue
+			}
+			v, err := strconv.ParseInt(def.LessThan[0], 10, 64)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ret.UpperBounds = append(ret.UpperBounds, v)
+		}
+	case model.PartitionTypeList:
+		ret.InValues = make(map[int64]int)
+		for i, def := range pi.Definitions {
+			for _, vs := range def.InValues {
+				if strings.EqualFold(vs[0], "NULL") {
+					ret.NullIdx = i
+					continue
+				}
+				v, err := strconv.ParseInt(vs[0], 10, 64)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ret.InValues[v] = i
+			}
+		}
+	}
+	return ret, nil
+}
+
+// LocateRange returns the offset of the RANGE partition that contains v, it
+// returns len(UpperBounds) if there is no such partition.
+func (pe *PartitionExpr) LocateRange(v int64) int {
+	return sort.Search(len(pe.UpperBounds), func(i int) bool {
+		if pe.MaxValue && i == len(pe.UpperBounds)-1 {
+			return true
+		}
+		return v < pe.UpperBounds[i]
+	})
+}
+
+// locatePartition returns the offset of the partition that r belongs to.
+func (pe *PartitionExpr) locatePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
+	v, isNull, err := pe.Expr.EvalInt(ctx, chunk.MutRowFromDatums(r).ToRow())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return pe.LocatePartition(pi, v, isNull)
+}
+
+// LocatePartition returns the offset of the partition that the value of the
+// partitioning expression belongs to.
+func (pe *PartitionExpr) LocatePartition(pi *model.PartitionInfo, v int64, isNull bool) (int, error) {
+	switch pi.Type {
+	case model.PartitionTypeRange:
+		// NULL is less than any value, it goes to the first partition.
+		if isNull {
+			return 0, nil
+		}
+		idx := pe.LocateRange(v)
+		if idx >= len(pe.UpperBounds) {
+			return 0, table.ErrNoPartitionForGivenValue.GenWithStackByArgs(strconv.FormatInt(v, 10))
+		}
+		return idx, nil
+	case model.PartitionTypeHash:
+		if isNull {
+			return 0, nil
+		}
+		return HashPartitionOffset(v, len(pi.Definitions)), nil
+	case model.PartitionTypeList:
+		if isNull {
+			if pe.NullIdx < 0 {
+				return 0, table.ErrNoPartitionForGivenValue.GenWithStackByArgs("NULL")
+			}
+			return pe.NullIdx, nil
+		}
+		idx, ok := pe.InValues[v]
+		if !ok {
+			return 0, table.ErrNoPartitionForGivenValue.GenWithStackByArgs(strconv.FormatInt(v, 10))
+		}
+		return idx, nil
+	}
+	return 0, errors.Errorf("unknown partition type %v", pi.Type)
+}
+
+// HashPartitionOffset returns the offset of the HASH partition that the
+// value of the partitioning expression v belongs to.
+func HashPartitionOffset(v int64, num int) int {
+	ret := v % int64(num)
+	if ret < 0 {
+		ret = -ret
+	}
+	return int(ret)
+}
+
+// PartitionExpr returns the partition expression.
+func (t *partitionedTable) PartitionExpr() *PartitionExpr {
+	return t.partitionExpr
+}
+
+func (t *partitionedTable) locatePartition(ctx sessionctx.Context, r []types.Datum) (int64, error) {
+	pi := t.meta.GetPartitionInfo()
+	idx, err := t.partitionExpr.locatePartition(ctx, pi, r)
+	if err != nil {
+		return 0, err
+	}
+	return pi.Definitions[idx].ID, nil
+}
+
+// GetPartition returns a Table, which is actually a partition.
+func (t *partitionedTable) GetPartition(pid int64) table.PhysicalTable {
+	// Attention, can't simply use `return t.partitions[pid]` here.
+	// Because A nil of type *partition is a kind of `table.PhysicalTable`
+	p, ok := t.partitions[pid]
+	if !ok {
+		return nil
+	}
+	return p
+}
+
+// GetPartitionByRow returns a Table, which is actually a Partition.
+func (t *partitionedTable) GetPartitionByRow(ctx sessionctx.Context, r []types.Datum) (table.PhysicalTable, error) {
+	pid, err := t.locatePartition(ctx, r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return t.partitions[pid], nil
+}
+
+// AddRecord implements the AddRecord method for the table.Table interface.
+func (t *partitionedTable) AddRecord(ctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (recordID int64, err error) {
+	pid, err := t.locatePartition(ctx, r)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return t.partitions[pid].AddRecord(ctx, r, opts...)
+}
+
+// RemoveRecord implements table.Table RemoveRecord interface.
+func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h int64, r []types.Datum) error {
+	pid, err := t.locatePartition(ctx, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return t.partitions[pid].RemoveRecord(ctx, h, r)
+}
+
+// UpdateRecord implements table.Table UpdateRecord interface.
+// `touched` means which columns are really modified, used for secondary indices.
+// Length of `oldData` and `newData` equals to length of `t.WritableCols()`.
+func (t *partitionedTable) UpdateRecord(ctx sessionctx.Context, h int64, currData, newData []types.Datum, touched []bool) error {
+	from, err := t.locatePartition(ctx, currData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	to, err := t.locatePartition(ctx, newData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The old and new data locate in different partitions.
+	// Remove record from old partition and add record to new partition.
+	if from != to {
+		_, err = t.partitions[to].AddRecord(ctx, newData, table.IsUpdate)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// UpdateRecord should be side effect free, but there're two steps here.
+		// What would happen if step1 succeed but step2 meets error? It's hard
+		// to rollback.
+		// So this special order is chosen: add record first, errors such as
+		// 'Key Already Exists' will generally happen during step1, errors are
+		// unlikely to happen in step2.
+		err = t.partitions[from].RemoveRecord(ctx, h, currData)
+		return errors.Trace(err)
+	}
+	return t.partitions[to].UpdateRecord(ctx, h, currData, newData, touched)
+}
+
+// initTableCommonWithIndices initializes a TableCommon struct with indices.
+func initTableCommonWithIndices(t *TableCommon, tblInfo *model.TableInfo, physicalTableID int64, cols []*table.Column, alloc autoid.Allocator) error {
+	initTableCommon(t, tblInfo, physicalTableID, cols, alloc)
+	return initTableIndices(t)
+}
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/model"
@@ -95,15 +96,26 @@ func TableFromMeta(alloc autoid.Allocator, tblInfo *model.TableInfo) (table.Tabl
 		}
 
 		col := table.ToColumn(colInfo)
+		if col.IsGenerated() {
+			expr, err := expression.ParseSimpleExpr(colInfo.GeneratedExprString)
+			if err != nil {
+				return nil, err
+			}
+			col.GeneratedExpr = expr
+		}
 		columns = append(columns, col)
 	}
 
 	var t TableCommon
 	initTableCommon(&t, tblInfo, tblInfo.ID, columns, alloc)
-	if err := initTableIndices(&t); err != nil {
-		return nil, err
+	if tblInfo.GetPartitionInfo() == nil {
+		if err := initTableIndices(&t); err != nil {
+			return nil, err
+		}
+		return &t, nil
 	}
-	return &t, nil
+
+	return newPartitionedTable(&t, tblInfo)
 }
 
 // initTableCommon initializes a TableCommon struct.
@@ -635,6 +647,10 @@ func DecodeRawRowData(ctx sessionctx.Context, meta *model.TableInfo, h int64, co
 			v[i] = ri
 			continue
 		}
+		if col.IsGenerated() && !col.GeneratedStored {
+			// The virtual generated column is not stored, the caller evaluates it.
+			continue
+		}
 		v[i], err = GetColDefaultValue(ctx, col, defaultVals)
 		if err != nil {
 			return nil, rowMap, err
@@ -941,6 +957,9 @@ func CanSkip(info *model.TableInfo, col *table.Column, value types.Datum) bool {
 	if col.GetDefaultValue() == nil && value.IsNull() {
 		return true
 	}
+	if col.IsGenerated() && !col.GeneratedStored {
+		return true
+	}
 	return false
 }
 
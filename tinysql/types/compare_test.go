@@ -85,6 +85,16 @@ func (s *testCompareSuite) TestCompare(c *C) {
 		{[]byte("abc"), []byte("ab"), 1},
 		{[]byte("123"), 1234, -1},
 		{[]byte{}, nil, 1},
+
+		{Enum{Name: "a", Value: 1}, "a", 0},
+		{Enum{Name: "a", Value: 1}, 1, 0},
+		{Enum{Name: "b", Value: 2}, "a", 1},
+		{Enum{Name: "b", Value: 2}, 3, -1},
+		{Enum{Name: "a", Value: 1}, nil, 1},
+		{Set{Name: "a,b", Value: 3}, "a,b", 0},
+		{Set{Name: "a,b", Value: 3}, 3, 0},
+		{Set{Name: "b", Value: 2}, uint64(1), 1},
+		{Set{Name: "a", Value: 1}, Enum{Name: "a", Value: 1}, 0},
 	}
 
 	for i, t := range cmpTbl {
@@ -25,6 +25,8 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/hack"
 )
 
 func truncateStr(str string, flen int) string {
@@ -45,7 +47,7 @@ func IntergerUnsignedUpperBound(intType byte) uint64 {
 		return mysql.MaxUint24
 	case mysql.TypeLong:
 		return math.MaxUint32
-	case mysql.TypeLonglong:
+	case mysql.TypeLonglong, mysql.TypeBit:
 		return math.MaxUint64
 	default:
 		panic("Input byte is not a mysql type")
@@ -173,6 +175,28 @@ func ConvertFloatToUint(sc *stmtctx.StatementContext, fval float64, upperBound u
 	return uint64(val), nil
 }
 
+// ConvertDecimalToUint converts a decimal to a uint by rounding it to an integer first,
+// so that no precision is lost for the values greater than 2^53.
+func ConvertDecimalToUint(sc *stmtctx.StatementContext, d *MyDecimal, upperBound uint64, tp byte) (uint64, error) {
+	var to MyDecimal
+	err := d.Round(&to, 0, ModeHalfUp)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if to.IsNegative() {
+		if sc.ShouldClipToZero() || to.IsZero() {
+			return 0, overflow(d, tp)
+		}
+		val, _ := to.ToInt()
+		return uint64(val), overflow(d, tp)
+	}
+	val, err := to.ToUint()
+	if err != nil || val > upperBound {
+		return upperBound, overflow(d, tp)
+	}
+	return val, nil
+}
+
 // convertScientificNotation converts a decimal string with scientific notation to a normal decimal string.
 // 1E6 => 1000000, .12345E+5 => 12345
 func convertScientificNotation(str string) (string, error) {
@@ -503,3 +527,78 @@ func ToString(value interface{}) (string, error) {
 		return "", errors.Errorf("cannot convert %v(type %T) to string", value, value)
 	}
 }
+
+// ConvertJSONToInt casts JSON into int64.
+func ConvertJSONToInt(sc *stmtctx.StatementContext, j json.BinaryJSON, unsigned bool) (int64, error) {
+	switch j.TypeCode {
+	case json.TypeCodeObject, json.TypeCodeArray:
+		return 0, nil
+	case json.TypeCodeLiteral:
+		switch j.Value[0] {
+		case json.LiteralNil, json.LiteralFalse:
+			return 0, nil
+		default:
+			return 1, nil
+		}
+	case json.TypeCodeInt64, json.TypeCodeUint64:
+		return j.GetInt64(), nil
+	case json.TypeCodeFloat64:
+		f := j.GetFloat64()
+		if !unsigned {
+			lBound := IntergerSignedLowerBound(mysql.TypeLonglong)
+			uBound := IntergerSignedUpperBound(mysql.TypeLonglong)
+			return ConvertFloatToInt(f, lBound, uBound, mysql.TypeLonglong)
+		}
+		bound := IntergerUnsignedUpperBound(mysql.TypeLonglong)
+		u, err := ConvertFloatToUint(sc, f, bound, mysql.TypeLonglong)
+		return int64(u), errors.Trace(err)
+	case json.TypeCodeString:
+		str := string(hack.String(j.GetString()))
+		if !unsigned {
+			return StrToInt(sc, str)
+		}
+		u, err := StrToUint(sc, str)
+		return int64(u), errors.Trace(err)
+	}
+	return 0, errors.New("Unknown type code in JSON")
+}
+
+// ConvertJSONToFloat casts JSON into float64.
+func ConvertJSONToFloat(sc *stmtctx.StatementContext, j json.BinaryJSON) (float64, error) {
+	switch j.TypeCode {
+	case json.TypeCodeObject, json.TypeCodeArray:
+		return 0, nil
+	case json.TypeCodeLiteral:
+		switch j.Value[0] {
+		case json.LiteralNil, json.LiteralFalse:
+			return 0, nil
+		default:
+			return 1, nil
+		}
+	case json.TypeCodeInt64:
+		return float64(j.GetInt64()), nil
+	case json.TypeCodeUint64:
+		return float64(j.GetUint64()), nil
+	case json.TypeCodeFloat64:
+		return j.GetFloat64(), nil
+	case json.TypeCodeString:
+		str := string(hack.String(j.GetString()))
+		return StrToFloat(sc, str)
+	}
+	return 0, errors.New("Unknown type code in JSON")
+}
+
+// ConvertJSONToDecimal casts JSON into decimal.
+func ConvertJSONToDecimal(sc *stmtctx.StatementContext, j json.BinaryJSON) (*MyDecimal, error) {
+	res := new(MyDecimal)
+	if j.TypeCode != json.TypeCodeString {
+		f64, err := ConvertJSONToFloat(sc, j)
+		if err != nil {
+			return res, errors.Trace(err)
+		}
+		err = res.FromFloat64(f64)
+		return res, errors.Trace(err)
+	}
+	err := sc.HandleTruncate(res.FromString(j.GetString()))
+	return res, errors.Trace(err)
+}
@@ -158,6 +158,41 @@ func (s *testTypeConvertSuite) TestConvertType(c *C) {
 	v, err = Convert("100", ft)
 	c.Assert(err, IsNil)
 	c.Assert(v, Equals, uint64(100))
+
+	// For TypeEnum
+	ft = NewFieldType(mysql.TypeEnum)
+	ft.Elems = []string{"a", "b", "c"}
+	v, err = Convert("B", ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Enum{Name: "b", Value: 2})
+	v, err = Convert(3, ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Enum{Name: "c", Value: 3})
+	v, err = Convert("2", ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Enum{Name: "b", Value: 2})
+	v, err = Convert("d", ft)
+	c.Assert(ErrTruncated.Equal(err), IsTrue)
+	c.Assert(v, Equals, Enum{})
+	_, err = Convert(4, ft)
+	c.Assert(ErrTruncated.Equal(err), IsTrue)
+
+	// For TypeSet
+	ft = NewFieldType(mysql.TypeSet)
+	ft.Elems = []string{"a", "b", "c"}
+	v, err = Convert("c,A", ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Set{Name: "a,c", Value: 5})
+	v, err = Convert(3, ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Set{Name: "a,b", Value: 3})
+	v, err = Convert("", ft)
+	c.Assert(err, IsNil)
+	c.Assert(v, Equals, Set{})
+	_, err = Convert("a,d", ft)
+	c.Assert(ErrTruncated.Equal(err), IsTrue)
+	_, err = Convert(8, ft)
+	c.Assert(ErrTruncated.Equal(err), IsTrue)
 }
 
 func testStrToInt(c *C, str string, expect int64, truncateAsErr bool, expectErr error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/hack"
 )
 
@@ -39,7 +40,10 @@ const (
 	KindFloat64       byte = 4
 	KindString        byte = 5
 	KindBytes         byte = 6
-	KindBinaryLiteral byte = 7  // Used for BIT / HEX literals.
+	KindBinaryLiteral byte = 7 // Used for BIT / HEX literals.
+	KindMysqlDecimal  byte = 8
+	KindMysqlDuration byte = 9
+	KindMysqlEnum     byte = 10
 	KindMysqlBit      byte = 11 // Used for BIT table column values.
 	KindMysqlSet      byte = 12
 	KindMysqlTime     byte = 13
@@ -69,6 +73,10 @@ func (d *Datum) Copy() *Datum {
 		ret.b = make([]byte, len(d.b))
 		copy(ret.b, d.b)
 	}
+	if d.k == KindMysqlDecimal {
+		dec := *d.GetMysqlDecimal()
+		ret.x = &dec
+	}
 	return &ret
 }
 
@@ -234,6 +242,78 @@ func (d *Datum) SetMysqlBit(b BinaryLiteral) {
 	d.b = b
 }
 
+// GetMysqlDecimal gets Decimal value
+func (d *Datum) GetMysqlDecimal() *MyDecimal {
+	return d.x.(*MyDecimal)
+}
+
+// SetMysqlDecimal sets Decimal value
+func (d *Datum) SetMysqlDecimal(b *MyDecimal) {
+	d.k = KindMysqlDecimal
+	d.x = b
+}
+
+// GetMysqlEnum gets Enum value
+func (d *Datum) GetMysqlEnum() Enum {
+	str := string(hack.String(d.b))
+	return Enum{Value: uint64(d.i), Name: str}
+}
+
+// SetMysqlEnum sets Enum value
+func (d *Datum) SetMysqlEnum(b Enum) {
+	d.k = KindMysqlEnum
+	d.i = int64(b.Value)
+	d.b = hack.Slice(b.Name)
+}
+
+// GetMysqlSet gets Set value
+func (d *Datum) GetMysqlSet() Set {
+	str := string(hack.String(d.b))
+	return Set{Value: uint64(d.i), Name: str}
+}
+
+// SetMysqlSet sets Set value
+func (d *Datum) SetMysqlSet(b Set) {
+	d.k = KindMysqlSet
+	d.i = int64(b.Value)
+	d.b = hack.Slice(b.Name)
+}
+
+// GetMysqlDuration gets Duration value
+func (d *Datum) GetMysqlDuration() Duration {
+	return Duration{Duration: time.Duration(d.i), Fsp: int8(d.decimal)}
+}
+
+// SetMysqlDuration sets Duration value
+func (d *Datum) SetMysqlDuration(b Duration) {
+	d.k = KindMysqlDuration
+	d.i = int64(b.Duration)
+	d.decimal = uint16(b.Fsp)
+}
+
+// GetMysqlTime gets types.Time value
+func (d *Datum) GetMysqlTime() Time {
+	return d.x.(Time)
+}
+
+// SetMysqlTime sets types.Time value
+func (d *Datum) SetMysqlTime(b Time) {
+	d.k = KindMysqlTime
+	d.x = b
+}
+
+// GetMysqlJSON gets json.BinaryJSON value
+func (d *Datum) GetMysqlJSON() json.BinaryJSON {
+	return json.BinaryJSON{TypeCode: byte(d.i), Value: d.b}
+}
+
+// SetMysqlJSON sets json.BinaryJSON value
+func (d *Datum) SetMysqlJSON(b json.BinaryJSON) {
+	d.k = KindMysqlJSON
+	d.i = int64(b.TypeCode)
+	d.b = b.Value
+}
+
 // SetRaw sets raw value.
 func (d *Datum) SetRaw(b []byte) {
 	d.k = KindRaw
@@ -274,6 +354,12 @@ func (d Datum) String() string {
 		t = "KindBytes"
 	case KindBinaryLiteral:
 		t = "KindBinaryLiteral"
+	case KindMysqlDecimal:
+		t = "KindMysqlDecimal"
+	case KindMysqlDuration:
+		t = "KindMysqlDuration"
+	case KindMysqlEnum:
+		t = "KindMysqlEnum"
 	case KindMysqlBit:
 		t = "KindMysqlBit"
 	case KindMysqlSet:
@@ -309,6 +395,16 @@ func (d *Datum) GetValue() interface{} {
 		return d.GetBytes()
 	case KindBinaryLiteral, KindMysqlBit:
 		return d.GetBinaryLiteral()
+	case KindMysqlDuration:
+		return d.GetMysqlDuration()
+	case KindMysqlEnum:
+		return d.GetMysqlEnum()
+	case KindMysqlSet:
+		return d.GetMysqlSet()
+	case KindMysqlTime:
+		return d.GetMysqlTime()
+	case KindMysqlJSON:
+		return d.GetMysqlJSON()
 	default:
 		return d.GetInterface()
 	}
@@ -345,6 +441,18 @@ func (d *Datum) SetValue(val interface{}) {
 		d.SetBinaryLiteral(BinaryLiteral(x))
 	case HexLiteral:
 		d.SetBinaryLiteral(BinaryLiteral(x))
+	case *MyDecimal:
+		d.SetMysqlDecimal(x)
+	case Duration:
+		d.SetMysqlDuration(x)
+	case Enum:
+		d.SetMysqlEnum(x)
+	case Set:
+		d.SetMysqlSet(x)
+	case Time:
+		d.SetMysqlTime(x)
+	case json.BinaryJSON:
+		d.SetMysqlJSON(x)
 	default:
 		d.SetInterface(x)
 	}
@@ -385,8 +493,20 @@ func (d *Datum) CompareDatum(sc *stmtctx.StatementContext, ad *Datum) (int, erro
 		return d.compareString(sc, ad.GetString())
 	case KindBytes:
 		return d.compareBytes(sc, ad.GetBytes())
+	case KindMysqlDecimal:
+		return d.compareMysqlDecimal(sc, ad.GetMysqlDecimal())
 	case KindBinaryLiteral, KindMysqlBit:
 		return d.compareBinaryLiteral(sc, ad.GetBinaryLiteral())
+	case KindMysqlDuration:
+		return d.compareMysqlDuration(sc, ad.GetMysqlDuration())
+	case KindMysqlEnum:
+		return d.compareMysqlEnum(sc, ad.GetMysqlEnum())
+	case KindMysqlSet:
+		return d.compareMysqlSet(sc, ad.GetMysqlSet())
+	case KindMysqlTime:
+		return d.compareMysqlTime(sc, ad.GetMysqlTime())
+	case KindMysqlJSON:
+		return d.compareMysqlJSON(sc, ad.GetMysqlJSON())
 	default:
 		return 0, nil
 	}
@@ -403,6 +523,8 @@ func (d *Datum) compareInt64(sc *stmtctx.StatementContext, i int64) (int, error)
 			return 1, nil
 		}
 		return CompareInt64(d.i, i), nil
+	case KindMysqlDecimal:
+		return d.GetMysqlDecimal().Compare(NewDecFromInt(i)), nil
 	default:
 		return d.compareFloat64(sc, float64(i))
 	}
@@ -419,6 +541,8 @@ func (d *Datum) compareUint64(sc *stmtctx.StatementContext, u uint64) (int, erro
 		return CompareInt64(d.i, int64(u)), nil
 	case KindUint64:
 		return CompareUint64(d.GetUint64(), u), nil
+	case KindMysqlDecimal:
+		return d.GetMysqlDecimal().Compare(NewDecFromUint(u)), nil
 	default:
 		return d.compareFloat64(sc, float64(u))
 	}
@@ -439,10 +563,25 @@ func (d *Datum) compareFloat64(sc *stmtctx.StatementContext, f float64) (int, er
 	case KindString, KindBytes:
 		fVal, err := StrToFloat(sc, d.GetString())
 		return CompareFloat64(fVal, f), errors.Trace(err)
+	case KindMysqlDecimal:
+		fVal, err := d.GetMysqlDecimal().ToFloat64()
+		return CompareFloat64(fVal, f), errors.Trace(err)
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err := d.GetBinaryLiteral().ToInt(sc)
 		fVal := float64(val)
 		return CompareFloat64(fVal, f), errors.Trace(err)
+	case KindMysqlDuration:
+		fVal := d.GetMysqlDuration().Seconds()
+		return CompareFloat64(fVal, f), nil
+	case KindMysqlEnum:
+		fVal := d.GetMysqlEnum().ToNumber()
+		return CompareFloat64(fVal, f), nil
+	case KindMysqlSet:
+		fVal := d.GetMysqlSet().ToNumber()
+		return CompareFloat64(fVal, f), nil
+	case KindMysqlTime:
+		fVal, err := d.GetMysqlTime().ToNumber().ToFloat64()
+		return CompareFloat64(fVal, f), errors.Trace(err)
 	default:
 		return -1, nil
 	}
@@ -458,6 +597,20 @@ func (d *Datum) compareString(sc *stmtctx.StatementContext, s string) (int, erro
 		return CompareString(d.GetString(), s), nil
 	case KindBinaryLiteral, KindMysqlBit:
 		return CompareString(d.GetBinaryLiteral().ToString(), s), nil
+	case KindMysqlDecimal:
+		dec := new(MyDecimal)
+		err := sc.HandleTruncate(dec.FromString(hack.Slice(s)))
+		return d.GetMysqlDecimal().Compare(dec), errors.Trace(err)
+	case KindMysqlTime:
+		dt, err := ParseDatetime(sc, s)
+		return d.GetMysqlTime().Compare(dt), errors.Trace(err)
+	case KindMysqlDuration:
+		dur, err := ParseDuration(sc, s, MaxFsp)
+		return d.GetMysqlDuration().Compare(dur), errors.Trace(err)
+	case KindMysqlEnum:
+		return CompareString(d.GetMysqlEnum().String(), s), nil
+	case KindMysqlSet:
+		return CompareString(d.GetMysqlSet().String(), s), nil
 	default:
 		fVal, err := StrToFloat(sc, s)
 		if err != nil {
@@ -467,6 +620,89 @@ func (d *Datum) compareString(sc *stmtctx.StatementContext, s string) (int, erro
 	}
 }
 
+func (d *Datum) compareMysqlDecimal(sc *stmtctx.StatementContext, dec *MyDecimal) (int, error) {
+	switch d.k {
+	case KindNull, KindMinNotNull:
+		return -1, nil
+	case KindMaxValue:
+		return 1, nil
+	case KindMysqlDecimal:
+		return d.GetMysqlDecimal().Compare(dec), nil
+	case KindString, KindBytes:
+		dDec := new(MyDecimal)
+		err := sc.HandleTruncate(dDec.FromString(d.GetBytes()))
+		return dDec.Compare(dec), errors.Trace(err)
+	default:
+		dVal, err := d.ConvertTo(sc, NewFieldType(mysql.TypeNewDecimal))
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return dVal.GetMysqlDecimal().Compare(dec), nil
+	}
+}
+
+func (d *Datum) compareMysqlDuration(sc *stmtctx.StatementContext, dur Duration) (int, error) {
+	switch d.k {
+	case KindMysqlDuration:
+		return d.GetMysqlDuration().Compare(dur), nil
+	case KindString, KindBytes:
+		dDur, err := ParseDuration(sc, d.GetString(), MaxFsp)
+		return dDur.Compare(dur), errors.Trace(err)
+	default:
+		return d.compareFloat64(sc, dur.Seconds())
+	}
+}
+
+func (d *Datum) compareMysqlEnum(sc *stmtctx.StatementContext, enum Enum) (int, error) {
+	switch d.k {
+	case KindNull, KindMinNotNull:
+		return -1, nil
+	case KindMaxValue:
+		return 1, nil
+	case KindString, KindBytes:
+		return CompareString(d.GetString(), enum.String()), nil
+	default:
+		return d.compareFloat64(sc, enum.ToNumber())
+	}
+}
+
+func (d *Datum) compareMysqlSet(sc *stmtctx.StatementContext, set Set) (int, error) {
+	switch d.k {
+	case KindNull, KindMinNotNull:
+		return -1, nil
+	case KindMaxValue:
+		return 1, nil
+	case KindString, KindBytes:
+		return CompareString(d.GetString(), set.String()), nil
+	default:
+		return d.compareFloat64(sc, set.ToNumber())
+	}
+}
+
+func (d *Datum) compareMysqlTime(sc *stmtctx.StatementContext, time Time) (int, error) {
+	switch d.k {
+	case KindString, KindBytes:
+		dt, err := ParseDatetime(sc, d.GetString())
+		return dt.Compare(time), errors.Trace(err)
+	case KindMysqlTime:
+		return d.GetMysqlTime().Compare(time), nil
+	default:
+		fVal, err := time.ToNumber().ToFloat64()
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return d.compareFloat64(sc, fVal)
+	}
+}
+
+func (d *Datum) compareMysqlJSON(sc *stmtctx.StatementContext, target json.BinaryJSON) (int, error) {
+	origin, err := d.ToMysqlJSON()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return json.CompareBinary(origin, target), nil
+}
+
 func (d *Datum) compareBytes(sc *stmtctx.StatementContext, b []byte) (int, error) {
 	str := string(hack.String(b))
 	return d.compareString(sc, str)
@@ -505,12 +741,22 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
 		mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString:
 		return d.convertToString(sc, target)
+	case mysql.TypeNewDecimal:
+		return d.convertToMysqlDecimal(sc, target)
 	case mysql.TypeBit:
 		return d.convertToMysqlBit(sc, target)
 	case mysql.TypeNull:
 		return Datum{}, nil
-	case mysql.TypeTimestamp, mysql.TypeDatetime:
-		return d.convertToTimeStamp(sc, target)
+	case mysql.TypeDatetime, mysql.TypeDate, mysql.TypeNewDate, mysql.TypeTimestamp:
+		return d.convertToMysqlTime(sc, target)
+	case mysql.TypeDuration:
+		return d.convertToMysqlDuration(sc, target)
+	case mysql.TypeEnum:
+		return d.convertToMysqlEnum(sc, target)
+	case mysql.TypeSet:
+		return d.convertToMysqlSet(sc, target)
+	case mysql.TypeJSON:
+		return d.convertToMysqlJSON(sc, target)
 	default:
 		panic(fmt.Sprintf("target.tp = %d should never happen", target.Tp))
 	}
@@ -533,9 +779,21 @@ func (d *Datum) convertToFloat(sc *stmtctx.StatementContext, target *FieldType)
 		f = d.GetFloat64()
 	case KindString, KindBytes:
 		f, err = StrToFloat(sc, d.GetString())
+	case KindMysqlTime:
+		f, err = d.GetMysqlTime().ToNumber().ToFloat64()
+	case KindMysqlDuration:
+		f, err = d.GetMysqlDuration().ToNumber().ToFloat64()
+	case KindMysqlDecimal:
+		f, err = d.GetMysqlDecimal().ToFloat64()
+	case KindMysqlSet:
+		f = d.GetMysqlSet().ToNumber()
+	case KindMysqlEnum:
+		f = d.GetMysqlEnum().ToNumber()
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err1 := d.GetBinaryLiteral().ToInt(sc)
 		f, err = float64(val), err1
+	case KindMysqlJSON:
+		f, err = ConvertJSONToFloat(sc, d.GetMysqlJSON())
 	default:
 		return invalidConv(d, target.Tp)
 	}
@@ -568,50 +826,120 @@ func ProduceFloatWithSpecifiedTp(f float64, target *FieldType, sc *stmtctx.State
 	return f, nil
 }
 
-func (d *Datum) convertToTimeStamp(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
-	var layouts = []string{"2006-01-02 15:04:05", "2006-01-01 15:04"}
+func (d *Datum) convertToMysqlTime(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	// Try to convert to Time.
+	tp := target.Tp
+	if tp == mysql.TypeNewDate {
+		tp = mysql.TypeDate
+	}
+	fsp := DefaultFsp
+	if target.Decimal != UnspecifiedLength {
+		fsp = int8(target.Decimal)
+	}
+	var (
+		ret Datum
+		t   Time
+		err error
+	)
+	switch d.k {
+	case KindMysqlTime:
+		t, err = d.GetMysqlTime().Convert(sc, tp)
+		if err != nil {
+			ret.SetMysqlTime(t)
+			return ret, errors.Trace(err)
+		}
+		t, err = t.RoundFrac(sc, fsp)
+	case KindMysqlDuration:
+		t, err = d.GetMysqlDuration().ConvertToTime(sc, tp)
+		if err != nil {
+			ret.SetMysqlTime(t)
+			return ret, errors.Trace(err)
+		}
+		t, err = t.RoundFrac(sc, fsp)
+	case KindString, KindBytes:
+		t, err = ParseTime(sc, d.GetString(), tp, fsp)
+	case KindInt64:
+		t, err = ParseTimeFromNum(sc, d.GetInt64(), tp, fsp)
+	case KindUint64:
+		t, err = ParseTimeFromNum(sc, int64(d.GetUint64()), tp, fsp)
+	case KindFloat32, KindFloat64:
+		s := strconv.FormatFloat(d.GetFloat64(), 'f', -1, 64)
+		t, err = ParseTimeFromFloatString(sc, s, tp, fsp)
+	case KindMysqlDecimal:
+		t, err = ParseTimeFromFloatString(sc, d.GetMysqlDecimal().String(), tp, fsp)
+	default:
+		return invalidConv(d, tp)
+	}
+	if tp == mysql.TypeDate {
+		// Truncate hh:mm:ss part if the type is Date.
+		t.SetCoreTime(FromDate(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0))
+	}
+	ret.SetMysqlTime(t)
+	if err != nil {
+		return ret, errors.Trace(err)
+	}
+	return ret, nil
+}
+
+func (d *Datum) convertToMysqlDuration(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	tp := target.Tp
+	fsp := DefaultFsp
+	if target.Decimal != UnspecifiedLength {
+		fsp = int8(target.Decimal)
+	}
 	var ret Datum
 	switch d.k {
-	case KindString:
-		var err error
-		for _, layout := range layouts {
-			var stamp time.Time
-			stamp, err = time.ParseInLocation(layout, d.GetString(), time.Local)
-			if err != nil {
-				continue
-			}
-			ret.k = KindMysqlTime
-			ret.i = stamp.Unix()
-			break
+	case KindMysqlTime:
+		dur, err := d.GetMysqlTime().ConvertToDuration()
+		if err != nil {
+			ret.SetMysqlDuration(dur)
+			return ret, errors.Trace(err)
+		}
+		dur, err = dur.RoundFrac(fsp)
+		ret.SetMysqlDuration(dur)
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+	case KindMysqlDuration:
+		dur, err := d.GetMysqlDuration().RoundFrac(fsp)
+		ret.SetMysqlDuration(dur)
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+	case KindInt64, KindFloat32, KindFloat64, KindMysqlDecimal:
+		// TODO: We need a ParseDurationFromNum to avoid the cost of converting a num to string.
+		timeStr, err := d.ToString()
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+		timeNum, err := d.ToInt64(sc)
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+		// For huge numbers(>'0001-00-00 00-00-00') try full DATETIME in ParseDuration.
+		if timeNum > MaxDuration && timeNum < 10000000000 {
+			// mysql return max in no strict sql mode.
+			ret.SetMysqlDuration(Duration{Duration: MaxTime, Fsp: 0})
+			return ret, ErrWrongValue.GenWithStackByArgs(TimeStr, timeStr)
+		}
+		if timeNum < -MaxDuration {
+			return ret, ErrWrongValue.GenWithStackByArgs(TimeStr, timeStr)
+		}
+		t, err := ParseDuration(sc, timeStr, fsp)
+		ret.SetMysqlDuration(t)
+		if err != nil {
+			return ret, errors.Trace(err)
 		}
+	case KindString, KindBytes:
+		t, err := ParseDuration(sc, d.GetString(), fsp)
+		ret.SetMysqlDuration(t)
 		if err != nil {
-			return invalidConv(d, target.Tp)
+			return ret, errors.Trace(err)
 		}
 	default:
-		return invalidConv(d, target.Tp)
+		return invalidConv(d, tp)
 	}
 	return ret, nil
-	//var (
-	//	ret Datum
-	//	t   Time
-	//	err error
-	//)
-	//fsp := DefaultFsp
-	//if target.Decimal != UnspecifiedLength {
-	//	fsp = target.Decimal
-	//}
-	//switch d.k {
-	//case KindString, KindBytes:
-	//	t, err = ParseTime(sc, d.GetString(), mysql.TypeTimestamp, fsp)
-	//default:
-	//	return invalidConv(d, mysql.TypeTimestamp)
-	//}
-	//t.SetType(mysql.TypeTimestamp)
-	//ret.SetMysqlTime(t)
-	//if err != nil {
-	//	return ret, errors.Trace(err)
-	//}
-	//return ret, nil
 }
 
 func (d *Datum) convertToString(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
@@ -628,8 +956,20 @@ func (d *Datum) convertToString(sc *stmtctx.StatementContext, target *FieldType)
 		s = strconv.FormatFloat(d.GetFloat64(), 'f', -1, 64)
 	case KindString, KindBytes:
 		s = d.GetString()
+	case KindMysqlDecimal:
+		s = d.GetMysqlDecimal().String()
+	case KindMysqlTime:
+		s = d.GetMysqlTime().String()
+	case KindMysqlDuration:
+		s = d.GetMysqlDuration().String()
+	case KindMysqlEnum:
+		s = d.GetMysqlEnum().String()
+	case KindMysqlSet:
+		s = d.GetMysqlSet().String()
 	case KindBinaryLiteral, KindMysqlBit:
 		s = d.GetBinaryLiteral().ToString()
+	case KindMysqlJSON:
+		s = d.GetMysqlJSON().String()
 	default:
 		return invalidConv(d, target.Tp)
 	}
@@ -644,6 +984,30 @@ func (d *Datum) convertToString(sc *stmtctx.StatementContext, target *FieldType)
 // ProduceStrWithSpecifiedTp produces a new string according to `flen` and `chs`. Param `padZero` indicates
 // whether we should pad `\0` for `binary(flen)` type.
 func ProduceStrWithSpecifiedTp(s string, tp *FieldType, sc *stmtctx.StatementContext, padZero bool) (_ string, err error) {
+	res, dataLen, truncated := produceStrWithSpecifiedTp(s, tp, padZero)
+	if truncated {
+		err = ErrDataTooLong.GenWithStack("Data Too Long, field len %d, data len %d", tp.Flen, dataLen)
+	}
+	return res, errors.Trace(sc.HandleTruncate(err))
+}
+
+// ProduceStrForCast is like ProduceStrWithSpecifiedTp, but it's used by CAST/CONVERT, whose truncation
+// is reported like "Truncated incorrect CHAR(2) value: 'abc'" as MySQL does.
+func ProduceStrForCast(s string, tp *FieldType, sc *stmtctx.StatementContext, padZero bool) (_ string, err error) {
+	res, _, truncated := produceStrWithSpecifiedTp(s, tp, padZero)
+	if truncated {
+		tpName := "CHAR"
+		if tp.Charset == charset.CharsetBin {
+			tpName = "BINARY"
+		}
+		err = ErrTruncatedWrongVal.GenWithStackByArgs(fmt.Sprintf("%s(%d)", tpName, tp.Flen), s)
+	}
+	return res, errors.Trace(sc.HandleTruncate(err))
+}
+
+// produceStrWithSpecifiedTp truncates or pads s according to tp, it returns the length of s in the
+// unit of tp.Flen and whether s is truncated.
+func produceStrWithSpecifiedTp(s string, tp *FieldType, padZero bool) (_ string, dataLen int, truncated bool) {
 	flen, chs := tp.Flen, tp.Charset
 	if flen >= 0 {
 		// Flen is the rune length, not binary length, for UTF8 charset, we need to calculate the
@@ -666,18 +1030,16 @@ func ProduceStrWithSpecifiedTp(s string, tp *FieldType, sc *stmtctx.StatementCon
 					}
 					runeCount++
 				}
-				err = ErrDataTooLong.GenWithStack("Data Too Long, field len %d, data len %d", flen, characterLen)
-				s = truncateStr(s, truncateLen)
+				return truncateStr(s, truncateLen), characterLen, true
 			}
 		} else if len(s) > flen {
-			err = ErrDataTooLong.GenWithStack("Data Too Long, field len %d, data len %d", flen, len(s))
-			s = truncateStr(s, flen)
+			return truncateStr(s, flen), len(s), true
 		} else if tp.Tp == mysql.TypeString && IsBinaryStr(tp) && len(s) < flen && padZero {
 			padding := make([]byte, flen-len(s))
 			s = string(append([]byte(s), padding...))
 		}
 	}
-	return s, errors.Trace(sc.HandleTruncate(err))
+	return s, len(s), false
 }
 
 func (d *Datum) convertToInt(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
@@ -710,8 +1072,36 @@ func (d *Datum) convertToUint(sc *stmtctx.StatementContext, target *FieldType) (
 			return ret, errors.Trace(err)
 		}
 		err = err1
+	case KindMysqlTime:
+		dec := d.GetMysqlTime().ToNumber()
+		err = dec.Round(dec, 0, ModeHalfUp)
+		ival, err1 := dec.ToInt()
+		if err == nil {
+			err = err1
+		}
+		val, err1 = ConvertIntToUint(sc, ival, upperBound, tp)
+		if err == nil {
+			err = err1
+		}
+	case KindMysqlDuration:
+		dec := d.GetMysqlDuration().ToNumber()
+		err = dec.Round(dec, 0, ModeHalfUp)
+		ival, err1 := dec.ToInt()
+		if err1 == nil {
+			val, err = ConvertIntToUint(sc, ival, upperBound, tp)
+		}
+	case KindMysqlDecimal:
+		val, err = ConvertDecimalToUint(sc, d.GetMysqlDecimal(), upperBound, tp)
+	case KindMysqlEnum:
+		val, err = ConvertUintToUint(d.GetMysqlEnum().Value, upperBound, tp)
+	case KindMysqlSet:
+		val, err = ConvertUintToUint(d.GetMysqlSet().Value, upperBound, tp)
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err = d.GetBinaryLiteral().ToInt(sc)
+	case KindMysqlJSON:
+		var i64 int64
+		i64, err = ConvertJSONToInt(sc, d.GetMysqlJSON(), true)
+		val = uint64(i64)
 	default:
 		return invalidConv(d, target.Tp)
 	}
@@ -722,6 +1112,104 @@ func (d *Datum) convertToUint(sc *stmtctx.StatementContext, target *FieldType) (
 	return ret, nil
 }
 
+func (d *Datum) convertToMysqlDecimal(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	var ret Datum
+	ret.SetLength(target.Flen)
+	ret.SetFrac(target.Decimal)
+	var dec = &MyDecimal{}
+	var err error
+	switch d.k {
+	case KindInt64:
+		dec.FromInt(d.GetInt64())
+	case KindUint64:
+		dec.FromUint(d.GetUint64())
+	case KindFloat32, KindFloat64:
+		err = dec.FromFloat64(d.GetFloat64())
+	case KindString, KindBytes:
+		err = dec.FromString(d.GetBytes())
+	case KindMysqlDecimal:
+		*dec = *d.GetMysqlDecimal()
+	case KindMysqlTime:
+		dec = d.GetMysqlTime().ToNumber()
+	case KindMysqlDuration:
+		dec = d.GetMysqlDuration().ToNumber()
+	case KindMysqlEnum:
+		dec.FromUint(d.GetMysqlEnum().Value)
+	case KindMysqlSet:
+		dec.FromUint(d.GetMysqlSet().Value)
+	case KindBinaryLiteral, KindMysqlBit:
+		val, err1 := d.GetBinaryLiteral().ToInt(sc)
+		err = err1
+		dec.FromUint(val)
+	case KindMysqlJSON:
+		f, err1 := ConvertJSONToFloat(sc, d.GetMysqlJSON())
+		if err1 != nil {
+			return ret, errors.Trace(err1)
+		}
+		err = dec.FromFloat64(f)
+	default:
+		return invalidConv(d, target.Tp)
+	}
+	if err != nil {
+		str, err1 := d.ToString()
+		if err1 != nil {
+			return ret, errors.Trace(err1)
+		}
+		err = sc.HandleTruncate(ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", str))
+	}
+	var err1 error
+	dec, err1 = ProduceDecWithSpecifiedTp(dec, target, sc)
+	if err == nil && err1 != nil {
+		err = err1
+	}
+	ret.SetMysqlDecimal(dec)
+	return ret, errors.Trace(err)
+}
+
+// ProduceDecWithSpecifiedTp produces a new decimal according to `flen` and `decimal`.
+func ProduceDecWithSpecifiedTp(dec *MyDecimal, tp *FieldType, sc *stmtctx.StatementContext) (_ *MyDecimal, err error) {
+	flen, decimal := tp.Flen, tp.Decimal
+	if flen != UnspecifiedLength && decimal != UnspecifiedLength {
+		if flen < decimal {
+			return nil, ErrMBiggerThanD.GenWithStackByArgs("")
+		}
+		_, frac := dec.PrecisionAndFrac()
+		if frac != decimal {
+			old := *dec
+			err = dec.Round(dec, decimal, ModeHalfUp)
+			if err != nil {
+				return nil, err
+			}
+			if !dec.IsZero() && frac > decimal && dec.Compare(&old) != 0 {
+				if sc.InInsertStmt || sc.InDeleteStmt {
+					// Rounding never fails INSERT and DELETE, the values written to
+					// DECIMAL columns are reported by a note in table.CastValueForRow.
+					sc.AppendWarning(ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", &old))
+					err = nil
+				} else {
+					err = sc.HandleTruncate(ErrTruncatedWrongVal.GenWithStackByArgs("DECIMAL", &old))
+				}
+			}
+		}
+		prec, frac := dec.PrecisionAndFrac()
+		if !dec.IsZero() && prec-frac > flen-decimal {
+			dec = NewMaxOrMinDec(dec.IsNegative(), flen, decimal)
+			// select (cast 111 as decimal(1)) causes a warning in MySQL.
+			err = ErrOverflow.GenWithStackByArgs("DECIMAL", fmt.Sprintf("(%d, %d)", flen, decimal))
+		}
+	}
+
+	if ErrOverflow.Equal(err) {
+		// TODO: warnings (overflow) should be handled according to the strict mode
+		err = sc.HandleOverflow(err, err)
+	}
+	unsigned := mysql.HasUnsignedFlag(tp.Flag)
+	if unsigned && dec.IsNegative() {
+		dec = dec.FromUint(0)
+	}
+	return dec, err
+}
+
 func (d *Datum) convertToMysqlBit(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
 	var ret Datum
 	var uintValue uint64
@@ -745,6 +1233,113 @@ func (d *Datum) convertToMysqlBit(sc *stmtctx.StatementContext, target *FieldTyp
 	return ret, errors.Trace(err)
 }
 
+func (d *Datum) convertToMysqlEnum(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	var (
+		ret Datum
+		e   Enum
+		err error
+	)
+	switch d.k {
+	case KindString, KindBytes:
+		e, err = ParseEnumName(target.Elems, d.GetString())
+	default:
+		var uintDatum Datum
+		uintDatum, err = d.convertToUint(sc, NewFieldType(mysql.TypeLonglong))
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+		e, err = ParseEnumValue(target.Elems, uintDatum.GetUint64())
+	}
+	if err != nil {
+		err = errors.Trace(ErrTruncated)
+	}
+	ret.SetMysqlEnum(e)
+	return ret, err
+}
+
+func (d *Datum) convertToMysqlSet(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	var (
+		ret Datum
+		s   Set
+		err error
+	)
+	switch d.k {
+	case KindString, KindBytes:
+		s, err = ParseSetName(target.Elems, d.GetString())
+	default:
+		var uintDatum Datum
+		uintDatum, err = d.convertToUint(sc, NewFieldType(mysql.TypeLonglong))
+		if err != nil {
+			return ret, errors.Trace(err)
+		}
+		s, err = ParseSetValue(target.Elems, uintDatum.GetUint64())
+	}
+	if err != nil {
+		err = errors.Trace(ErrTruncated)
+	}
+	ret.SetMysqlSet(s)
+	return ret, err
+}
+
+func (d *Datum) convertToMysqlJSON(sc *stmtctx.StatementContext, target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+		var j json.BinaryJSON
+		if j, err = json.ParseBinaryFromString(d.GetString()); err == nil {
+			ret.SetMysqlJSON(j)
+		}
+	case KindInt64:
+		ret.SetMysqlJSON(json.CreateBinary(d.GetInt64()))
+	case KindUint64:
+		ret.SetMysqlJSON(json.CreateBinary(d.GetUint64()))
+	case KindFloat32, KindFloat64:
+		ret.SetMysqlJSON(json.CreateBinary(d.GetFloat64()))
+	case KindMysqlDecimal:
+		var f float64
+		if f, err = d.GetMysqlDecimal().ToFloat64(); err == nil {
+			ret.SetMysqlJSON(json.CreateBinary(f))
+		}
+	case KindMysqlJSON:
+		ret = *d
+	default:
+		var s string
+		if s, err = d.ToString(); err == nil {
+			ret.SetMysqlJSON(json.CreateBinary(s))
+		}
+	}
+	return ret, errors.Trace(err)
+}
+
+// ToMysqlJSON converts the datum to a json.BinaryJSON. Strings are not
+// parsed, they become JSON strings.
+func (d *Datum) ToMysqlJSON() (j json.BinaryJSON, err error) {
+	var in interface{}
+	switch d.Kind() {
+	case KindMysqlJSON:
+		return d.GetMysqlJSON(), nil
+	case KindInt64:
+		in = d.GetInt64()
+	case KindUint64:
+		in = d.GetUint64()
+	case KindFloat32, KindFloat64:
+		in = d.GetFloat64()
+	case KindMysqlDecimal:
+		in, err = d.GetMysqlDecimal().ToFloat64()
+	case KindString, KindBytes:
+		in = d.GetString()
+	case KindBinaryLiteral, KindMysqlBit:
+		in = d.GetBinaryLiteral().ToString()
+	case KindNull:
+		in = nil
+	default:
+		in, err = d.ToString()
+	}
+	if err != nil {
+		return j, errors.Trace(err)
+	}
+	return json.CreateBinary(in), nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(sc *stmtctx.StatementContext) (int64, error) {
@@ -759,12 +1354,25 @@ func (d *Datum) ToBool(sc *stmtctx.StatementContext) (int64, error) {
 		isZero = RoundFloat(d.GetFloat64()) == 0
 	case KindFloat64:
 		isZero = RoundFloat(d.GetFloat64()) == 0
+	case KindMysqlDecimal:
+		isZero = d.GetMysqlDecimal().IsZero()
+	case KindMysqlTime:
+		isZero = d.GetMysqlTime().IsZero()
+	case KindMysqlDuration:
+		isZero = d.GetMysqlDuration().Duration == 0
+	case KindMysqlEnum:
+		isZero = d.GetMysqlEnum().Value == 0
+	case KindMysqlSet:
+		isZero = d.GetMysqlSet().Value == 0
 	case KindString, KindBytes:
 		iVal, err1 := StrToInt(sc, d.GetString())
 		isZero, err = iVal == 0, err1
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err1 := d.GetBinaryLiteral().ToInt(sc)
 		isZero, err = val == 0, err1
+	case KindMysqlJSON:
+		val, err1 := ConvertJSONToFloat(sc, d.GetMysqlJSON())
+		isZero, err = val == 0, err1
 	default:
 		return 0, errors.Errorf("cannot convert %v(type %T) to bool", d.GetValue(), d.GetValue())
 	}
@@ -804,9 +1412,53 @@ func (d *Datum) toSignedInteger(sc *stmtctx.StatementContext, tp byte) (int64, e
 			err = err2
 		}
 		return iVal, errors.Trace(err)
+	case KindMysqlDecimal:
+		var to MyDecimal
+		err := d.GetMysqlDecimal().Round(&to, 0, ModeHalfUp)
+		ival, err1 := to.ToInt()
+		if err == nil {
+			err = err1
+		}
+		ival, err2 := ConvertIntToInt(ival, lowerBound, upperBound, tp)
+		if err == nil {
+			err = err2
+		}
+		return ival, errors.Trace(err)
+	case KindMysqlTime:
+		// 2011-11-10 11:11:11.999999 -> 20111110111112
+		// 2011-11-10 11:59:59.999999 -> 20111110120000
+		t, err := d.GetMysqlTime().RoundFrac(sc, DefaultFsp)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		ival, err := t.ToNumber().ToInt()
+		ival, err2 := ConvertIntToInt(ival, lowerBound, upperBound, tp)
+		if err == nil {
+			err = err2
+		}
+		return ival, errors.Trace(err)
+	case KindMysqlDuration:
+		// 11:11:11.999999 -> 111112
+		// 11:59:59.999999 -> 120000
+		dur, err := d.GetMysqlDuration().RoundFrac(DefaultFsp)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		ival, err := dur.ToNumber().ToInt()
+		ival, err2 := ConvertIntToInt(ival, lowerBound, upperBound, tp)
+		if err == nil {
+			err = err2
+		}
+		return ival, errors.Trace(err)
+	case KindMysqlEnum:
+		return ConvertUintToInt(d.GetMysqlEnum().Value, upperBound, tp)
+	case KindMysqlSet:
+		return ConvertUintToInt(d.GetMysqlSet().Value, upperBound, tp)
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err := d.GetBinaryLiteral().ToInt(sc)
 		return int64(val), errors.Trace(err)
+	case KindMysqlJSON:
+		return ConvertJSONToInt(sc, d.GetMysqlJSON(), false)
 	default:
 		return 0, errors.Errorf("cannot convert %v(type %T) to int64", d.GetValue(), d.GetValue())
 	}
@@ -827,9 +1479,23 @@ func (d *Datum) ToFloat64(sc *stmtctx.StatementContext) (float64, error) {
 		return StrToFloat(sc, d.GetString())
 	case KindBytes:
 		return StrToFloat(sc, string(d.GetBytes()))
+	case KindMysqlDecimal:
+		return d.GetMysqlDecimal().ToFloat64()
+	case KindMysqlTime:
+		f, err := d.GetMysqlTime().ToNumber().ToFloat64()
+		return f, errors.Trace(err)
+	case KindMysqlDuration:
+		f, err := d.GetMysqlDuration().ToNumber().ToFloat64()
+		return f, errors.Trace(err)
+	case KindMysqlEnum:
+		return d.GetMysqlEnum().ToNumber(), nil
+	case KindMysqlSet:
+		return d.GetMysqlSet().ToNumber(), nil
 	case KindBinaryLiteral, KindMysqlBit:
 		val, err := d.GetBinaryLiteral().ToInt(sc)
 		return float64(val), errors.Trace(err)
+	case KindMysqlJSON:
+		return ConvertJSONToFloat(sc, d.GetMysqlJSON())
 	default:
 		return 0, errors.Errorf("cannot convert %v(type %T) to float64", d.GetValue(), d.GetValue())
 	}
@@ -850,13 +1516,34 @@ func (d *Datum) ToString() (string, error) {
 		return d.GetString(), nil
 	case KindBytes:
 		return d.GetString(), nil
+	case KindMysqlTime:
+		return d.GetMysqlTime().String(), nil
+	case KindMysqlDuration:
+		return d.GetMysqlDuration().String(), nil
+	case KindMysqlDecimal:
+		return d.GetMysqlDecimal().String(), nil
+	case KindMysqlEnum:
+		return d.GetMysqlEnum().String(), nil
+	case KindMysqlSet:
+		return d.GetMysqlSet().String(), nil
 	case KindBinaryLiteral, KindMysqlBit:
 		return d.GetBinaryLiteral().ToString(), nil
+	case KindMysqlJSON:
+		return d.GetMysqlJSON().String(), nil
 	default:
 		return "", errors.Errorf("cannot convert %v(type %T) to string", d.GetValue(), d.GetValue())
 	}
 }
 
+// ToDecimal converts to a decimal.
+func (d *Datum) ToDecimal(sc *stmtctx.StatementContext) (*MyDecimal, error) {
+	converted, err := d.ConvertTo(sc, NewFieldType(mysql.TypeNewDecimal))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return converted.GetMysqlDecimal(), nil
+}
+
 // ToBytes gets the bytes representation of the datum.
 func (d *Datum) ToBytes() ([]byte, error) {
 	switch d.k {
@@ -922,6 +1609,42 @@ func NewFloat32Datum(f float32) (d Datum) {
 	return d
 }
 
+// NewDecimalDatum creates a new Datum from a MyDecimal value.
+func NewDecimalDatum(dec *MyDecimal) (d Datum) {
+	d.SetMysqlDecimal(dec)
+	return d
+}
+
+// NewDurationDatum creates a new Datum from a Duration value.
+func NewDurationDatum(dur Duration) (d Datum) {
+	d.SetMysqlDuration(dur)
+	return d
+}
+
+// NewTimeDatum creates a new Time from a Time value.
+func NewTimeDatum(t Time) (d Datum) {
+	d.SetMysqlTime(t)
+	return d
+}
+
+// NewMysqlEnumDatum creates a new MysqlEnum Datum for a Enum value.
+func NewMysqlEnumDatum(e Enum) (d Datum) {
+	d.SetMysqlEnum(e)
+	return d
+}
+
+// NewMysqlSetDatum creates a new MysqlSet Datum for a Set value.
+func NewMysqlSetDatum(e Set) (d Datum) {
+	d.SetMysqlSet(e)
+	return d
+}
+
+// NewJSONDatum creates a new Datum from a BinaryJSON value
+func NewJSONDatum(j json.BinaryJSON) (d Datum) {
+	d.SetMysqlJSON(j)
+	return d
+}
+
 // MakeDatums creates datum slice from interfaces.
 func MakeDatums(args ...interface{}) []Datum {
 	datums := make([]Datum, len(args))
@@ -14,6 +14,7 @@
 package types
 
 import (
+	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/opcode"
 )
@@ -50,6 +51,15 @@ func ComputePlus(a, b Datum) (d Datum, err error) {
 			d.SetFloat64(r)
 			return d, nil
 		}
+	case KindMysqlDecimal:
+		switch b.Kind() {
+		case KindMysqlDecimal:
+			r := new(MyDecimal)
+			err = DecimalAdd(a.GetMysqlDecimal(), b.GetMysqlDecimal(), r)
+			d.SetMysqlDecimal(r)
+			d.SetFrac(mathutil.Max(a.Frac(), b.Frac()))
+			return d, err
+		}
 	}
 	_, err = InvOp2(a.GetValue(), b.GetValue(), opcode.Plus)
 	return d, err
@@ -52,6 +52,22 @@ func IsTypePrefixable(tp byte) bool {
 	return IsTypeBlob(tp) || IsTypeChar(tp)
 }
 
+// IsTypeTime returns a boolean indicating
+// whether the tp is time type like datetime, date or timestamp type.
+func IsTypeTime(tp byte) bool {
+	return tp == mysql.TypeDatetime || tp == mysql.TypeDate || tp == mysql.TypeTimestamp
+}
+
+// IsTypeTemporal checks if a type is a temporal type.
+func IsTypeTemporal(tp byte) bool {
+	switch tp {
+	case mysql.TypeDuration, mysql.TypeDatetime, mysql.TypeTimestamp,
+		mysql.TypeDate, mysql.TypeNewDate:
+		return true
+	}
+	return false
+}
+
 // IsTypeNumeric returns a boolean indicating whether the tp is numeric type.
 func IsTypeNumeric(tp byte) bool {
 	switch tp {
@@ -84,17 +100,24 @@ func IsString(tp byte) bool {
 }
 
 var kind2Str = map[byte]string{
-	KindNull:       "null",
-	KindInt64:      "bigint",
-	KindUint64:     "unsigned bigint",
-	KindFloat32:    "float",
-	KindFloat64:    "double",
-	KindString:     "char",
-	KindBytes:      "bytes",
-	KindInterface:  "interface",
-	KindMinNotNull: "min_not_null",
-	KindMaxValue:   "max_value",
-	KindRaw:        "raw",
+	KindNull:          "null",
+	KindInt64:         "bigint",
+	KindUint64:        "unsigned bigint",
+	KindFloat32:       "float",
+	KindFloat64:       "double",
+	KindMysqlDecimal:  "decimal",
+	KindMysqlDuration: "time",
+	KindMysqlEnum:     "enum",
+	KindMysqlSet:      "set",
+	KindMysqlBit:      "bit",
+	KindMysqlTime:     "datetime",
+	KindMysqlJSON:     "json",
+	KindString:        "char",
+	KindBytes:         "bytes",
+	KindInterface:     "interface",
+	KindMinNotNull:    "min_not_null",
+	KindMaxValue:      "max_value",
+	KindRaw:           "raw",
 }
 
 // TypeStr converts tp to a string.
@@ -109,6 +132,7 @@ func KindStr(kind byte) (r string) {
 // It is used for converting Text to Blob,
 // or converting Char to Binary.
 // Args:
+//
 //	tp: type enum
 //	cs: charset
 var TypeToStr = ast.TypeToStr
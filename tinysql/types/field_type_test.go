@@ -187,6 +187,11 @@ func (s *testFieldTypeSuite) TestDefaultTypeForValue(c *C) {
 		{"abc", mysql.TypeVarString, 3, UnspecifiedLength, charset.CharsetUTF8MB4, charset.CollationUTF8MB4, 0},
 		{1.1, mysql.TypeDouble, 3, -1, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag},
 		{[]byte("abc"), mysql.TypeBlob, 3, UnspecifiedLength, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag},
+		{BitLiteral{0x05}, mysql.TypeVarString, 1, 0, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag},
+		{HexLiteral{0x41, 0x42}, mysql.TypeVarString, 6, 0, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag | mysql.UnsignedFlag},
+		{BinaryLiteral{0x01, 0x02}, mysql.TypeBit, 16, 0, charset.CharsetBin, charset.CharsetBin, mysql.UnsignedFlag},
+		{Enum{Name: "a", Value: 1}, mysql.TypeEnum, 1, UnspecifiedLength, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag},
+		{Set{Name: "a,b", Value: 3}, mysql.TypeSet, 3, UnspecifiedLength, charset.CharsetBin, charset.CharsetBin, mysql.BinaryFlag},
 	}
 	for _, tt := range tests {
 		var ft FieldType
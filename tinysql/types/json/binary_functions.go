@@ -0,0 +1,514 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"math"
+	"sort"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+// Extract receives several path expressions as arguments, matches them in bj, and returns:
+//  ret: target JSON matched any path expressions. maybe autowrapped as an array.
+//  found: true if any path expressions matched.
+func (bj BinaryJSON) Extract(pathExprList []PathExpression) (ret BinaryJSON, found bool) {
+	buf := make([]BinaryJSON, 0, 1)
+	for _, pathExpr := range pathExprList {
+		buf = bj.extractTo(buf, pathExpr)
+	}
+	if len(buf) == 0 {
+		found = false
+	} else if len(pathExprList) == 1 && len(buf) == 1 && !pathExprList[0].ContainsAnyAsterisk() {
+		// If pathExpr contains asterisks, the result is always wrapped as an array.
+		found = true
+		ret = buf[0]
+	} else {
+		found = true
+		ret = buildBinaryArray(buf)
+	}
+	return
+}
+
+func (bj BinaryJSON) extractTo(buf []BinaryJSON, pathExpr PathExpression) []BinaryJSON {
+	if len(pathExpr.legs) == 0 {
+		return append(buf, bj)
+	}
+	currentLeg, subPathExpr := pathExpr.popOneLeg()
+	if currentLeg.typ == pathLegIndex {
+		if bj.TypeCode != TypeCodeArray {
+			// A non-array value is treated as an array with only one element.
+			if currentLeg.arrayIndex == 0 || currentLeg.arrayIndex == arrayIndexAsterisk {
+				buf = bj.extractTo(buf, subPathExpr)
+			}
+			return buf
+		}
+		elemCount := bj.GetElemCount()
+		if currentLeg.arrayIndex == arrayIndexAsterisk {
+			for i := 0; i < elemCount; i++ {
+				buf = bj.arrayGetElem(i).extractTo(buf, subPathExpr)
+			}
+		} else if currentLeg.arrayIndex < elemCount {
+			buf = bj.arrayGetElem(currentLeg.arrayIndex).extractTo(buf, subPathExpr)
+		}
+	} else if currentLeg.typ == pathLegKey && bj.TypeCode == TypeCodeObject {
+		elemCount := bj.GetElemCount()
+		if currentLeg.dotKey == "*" {
+			for i := 0; i < elemCount; i++ {
+				buf = bj.objectGetVal(i).extractTo(buf, subPathExpr)
+			}
+		} else {
+			child, ok := bj.objectSearchKey(hack.Slice(currentLeg.dotKey))
+			if ok {
+				buf = child.extractTo(buf, subPathExpr)
+			}
+		}
+	} else if currentLeg.typ == pathLegDoubleAsterisk {
+		buf = bj.extractTo(buf, subPathExpr)
+		if bj.TypeCode == TypeCodeArray {
+			elemCount := bj.GetElemCount()
+			for i := 0; i < elemCount; i++ {
+				buf = bj.arrayGetElem(i).extractTo(buf, pathExpr)
+			}
+		} else if bj.TypeCode == TypeCodeObject {
+			elemCount := bj.GetElemCount()
+			for i := 0; i < elemCount; i++ {
+				buf = bj.objectGetVal(i).extractTo(buf, pathExpr)
+			}
+		}
+	}
+	return buf
+}
+
+func buildBinaryArray(elems []BinaryJSON) BinaryJSON {
+	totalSize := headerSize + len(elems)*valEntrySize
+	for _, elem := range elems {
+		if elem.TypeCode != TypeCodeLiteral {
+			totalSize += len(elem.Value)
+		}
+	}
+	buf := make([]byte, headerSize+len(elems)*valEntrySize, totalSize)
+	endian.PutUint32(buf, uint32(len(elems)))
+	endian.PutUint32(buf[dataSizeOff:], uint32(totalSize))
+	buf = buildBinaryElements(buf, headerSize, elems)
+	return BinaryJSON{TypeCode: TypeCodeArray, Value: buf}
+}
+
+func buildBinaryElements(buf []byte, entryStart int, elems []BinaryJSON) []byte {
+	for i, elem := range elems {
+		buf[entryStart+i*valEntrySize] = elem.TypeCode
+		if elem.TypeCode == TypeCodeLiteral {
+			buf[entryStart+i*valEntrySize+valTypeSize] = elem.Value[0]
+		} else {
+			endian.PutUint32(buf[entryStart+i*valEntrySize+valTypeSize:], uint32(len(buf)))
+			buf = append(buf, elem.Value...)
+		}
+	}
+	return buf
+}
+
+func buildBinaryObject(keys [][]byte, elems []BinaryJSON) BinaryJSON {
+	totalSize := headerSize + len(elems)*(keyEntrySize+valEntrySize)
+	for i, elem := range elems {
+		if elem.TypeCode != TypeCodeLiteral {
+			totalSize += len(elem.Value)
+		}
+		totalSize += len(keys[i])
+	}
+	buf := make([]byte, headerSize+len(elems)*(keyEntrySize+valEntrySize), totalSize)
+	endian.PutUint32(buf, uint32(len(elems)))
+	endian.PutUint32(buf[dataSizeOff:], uint32(totalSize))
+	for i, key := range keys {
+		endian.PutUint32(buf[headerSize+i*keyEntrySize:], uint32(len(buf)))
+		endian.PutUint16(buf[headerSize+i*keyEntrySize+keyLenOff:], uint16(len(key)))
+		buf = append(buf, key...)
+	}
+	entryStart := headerSize + len(elems)*keyEntrySize
+	buf = buildBinaryElements(buf, entryStart, elems)
+	return BinaryJSON{TypeCode: TypeCodeObject, Value: buf}
+}
+
+// Modify modifies a JSON object by insert, replace or set.
+// All path expressions cannot contain * or ** wildcard.
+// If any error occurs, the input won't be changed.
+func (bj BinaryJSON) Modify(pathExprList []PathExpression, values []BinaryJSON, mt ModifyType) (retj BinaryJSON, err error) {
+	if len(pathExprList) != len(values) {
+		return retj, errors.New("Incorrect parameter count")
+	}
+	for _, pathExpr := range pathExprList {
+		if pathExpr.flags.containsAnyAsterisk() {
+			return retj, ErrInvalidJSONPathWildcard
+		}
+	}
+	for i := 0; i < len(pathExprList); i++ {
+		pathExpr, value := pathExprList[i], values[i]
+		modifier := &binaryModifier{bj: bj}
+		switch mt {
+		case ModifyInsert:
+			bj = modifier.insert(pathExpr, value)
+		case ModifyReplace:
+			bj = modifier.replace(pathExpr, value)
+		case ModifySet:
+			bj = modifier.set(pathExpr, value)
+		}
+	}
+	return bj, nil
+}
+
+// Remove removes the elements indicated by pathExprList from JSON.
+func (bj BinaryJSON) Remove(pathExprList []PathExpression) (BinaryJSON, error) {
+	for _, pathExpr := range pathExprList {
+		if len(pathExpr.legs) == 0 {
+			return bj, ErrInvalidJSONPath.GenWithStackByArgs(pathExpr.String())
+		}
+		if pathExpr.flags.containsAnyAsterisk() {
+			return bj, ErrInvalidJSONPathWildcard
+		}
+		modifer := &binaryModifier{bj: bj}
+		bj = modifer.remove(pathExpr)
+	}
+	return bj, nil
+}
+
+// binaryModifier rebuilds a BinaryJSON with one of its values replaced.
+// The value to replace is identified by the address of its first byte.
+type binaryModifier struct {
+	bj          BinaryJSON
+	modifyPtr   *byte
+	modifyValue BinaryJSON
+}
+
+func (bm *binaryModifier) set(path PathExpression, newBj BinaryJSON) BinaryJSON {
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, path)
+	if len(result) > 0 {
+		bm.modifyPtr = &result[0].Value[0]
+		bm.modifyValue = newBj
+		return bm.rebuild()
+	}
+	bm.doInsert(path, newBj)
+	return bm.rebuild()
+}
+
+func (bm *binaryModifier) replace(path PathExpression, newBj BinaryJSON) BinaryJSON {
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, path)
+	if len(result) == 0 {
+		return bm.bj
+	}
+	bm.modifyPtr = &result[0].Value[0]
+	bm.modifyValue = newBj
+	return bm.rebuild()
+}
+
+func (bm *binaryModifier) insert(path PathExpression, newBj BinaryJSON) BinaryJSON {
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, path)
+	if len(result) > 0 {
+		return bm.bj
+	}
+	bm.doInsert(path, newBj)
+	return bm.rebuild()
+}
+
+// doInsert inserts the newBj to its parent, and builds the new parent.
+func (bm *binaryModifier) doInsert(path PathExpression, newBj BinaryJSON) {
+	parentPath, lastLeg := path.popOneLastLeg()
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, parentPath)
+	if len(result) == 0 {
+		return
+	}
+	parentBj := result[0]
+	if lastLeg.typ == pathLegIndex {
+		bm.modifyPtr = &parentBj.Value[0]
+		if parentBj.TypeCode != TypeCodeArray {
+			bm.modifyValue = buildBinaryArray([]BinaryJSON{parentBj, newBj})
+			return
+		}
+		elemCount := parentBj.GetElemCount()
+		elems := make([]BinaryJSON, 0, elemCount+1)
+		for i := 0; i < elemCount; i++ {
+			elems = append(elems, parentBj.arrayGetElem(i))
+		}
+		elems = append(elems, newBj)
+		bm.modifyValue = buildBinaryArray(elems)
+		return
+	}
+	if parentBj.TypeCode != TypeCodeObject {
+		return
+	}
+	bm.modifyPtr = &parentBj.Value[0]
+	elemCount := parentBj.GetElemCount()
+	insertKey := hack.Slice(lastLeg.dotKey)
+	insertIdx := sort.Search(elemCount, func(i int) bool {
+		k := parentBj.objectGetKey(i)
+		return bytes.Compare(k, insertKey) >= 0
+	})
+	keys := make([][]byte, 0, elemCount+1)
+	elems := make([]BinaryJSON, 0, elemCount+1)
+	for i := 0; i < elemCount; i++ {
+		if i == insertIdx {
+			keys = append(keys, insertKey)
+			elems = append(elems, newBj)
+		}
+		keys = append(keys, parentBj.objectGetKey(i))
+		elems = append(elems, parentBj.objectGetVal(i))
+	}
+	if insertIdx == elemCount {
+		keys = append(keys, insertKey)
+		elems = append(elems, newBj)
+	}
+	bm.modifyValue = buildBinaryObject(keys, elems)
+}
+
+func (bm *binaryModifier) remove(path PathExpression) BinaryJSON {
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, path)
+	if len(result) == 0 {
+		return bm.bj
+	}
+	bm.doRemove(path)
+	return bm.rebuild()
+}
+
+func (bm *binaryModifier) doRemove(path PathExpression) {
+	parentPath, lastLeg := path.popOneLastLeg()
+	result := make([]BinaryJSON, 0, 1)
+	result = bm.bj.extractTo(result, parentPath)
+	if len(result) == 0 {
+		return
+	}
+	parentBj := result[0]
+	if lastLeg.typ == pathLegIndex {
+		if parentBj.TypeCode != TypeCodeArray {
+			return
+		}
+		bm.modifyPtr = &parentBj.Value[0]
+		elemCount := parentBj.GetElemCount()
+		elems := make([]BinaryJSON, 0, elemCount-1)
+		for i := 0; i < elemCount; i++ {
+			if i != lastLeg.arrayIndex {
+				elems = append(elems, parentBj.arrayGetElem(i))
+			}
+		}
+		bm.modifyValue = buildBinaryArray(elems)
+		return
+	}
+	if parentBj.TypeCode != TypeCodeObject {
+		return
+	}
+	bm.modifyPtr = &parentBj.Value[0]
+	elemCount := parentBj.GetElemCount()
+	removeKey := hack.Slice(lastLeg.dotKey)
+	keys := make([][]byte, 0, elemCount)
+	elems := make([]BinaryJSON, 0, elemCount)
+	for i := 0; i < elemCount; i++ {
+		key := parentBj.objectGetKey(i)
+		if !bytes.Equal(key, removeKey) {
+			keys = append(keys, key)
+			elems = append(elems, parentBj.objectGetVal(i))
+		}
+	}
+	bm.modifyValue = buildBinaryObject(keys, elems)
+}
+
+// rebuild merges the old and the modified JSON into a new BinaryJSON
+func (bm *binaryModifier) rebuild() BinaryJSON {
+	buf := make([]byte, 0, len(bm.bj.Value)+len(bm.modifyValue.Value))
+	value, tpCode := bm.rebuildTo(buf)
+	return BinaryJSON{TypeCode: tpCode, Value: value}
+}
+
+func (bm *binaryModifier) rebuildTo(buf []byte) ([]byte, TypeCode) {
+	if bm.modifyPtr == &bm.bj.Value[0] {
+		bm.modifyPtr = nil
+		return append(buf, bm.modifyValue.Value...), bm.modifyValue.TypeCode
+	} else if bm.modifyPtr == nil {
+		return append(buf, bm.bj.Value...), bm.bj.TypeCode
+	}
+	bj := bm.bj
+	switch bj.TypeCode {
+	case TypeCodeLiteral, TypeCodeInt64, TypeCodeUint64, TypeCodeFloat64, TypeCodeString:
+		return append(buf, bj.Value...), bj.TypeCode
+	}
+	docOff := len(buf)
+	elemCount := bj.GetElemCount()
+	var valEntryStart int
+	if bj.TypeCode == TypeCodeArray {
+		copySize := headerSize + elemCount*valEntrySize
+		valEntryStart = headerSize
+		buf = append(buf, bj.Value[:copySize]...)
+	} else {
+		copySize := headerSize + elemCount*(keyEntrySize+valEntrySize)
+		valEntryStart = headerSize + elemCount*keyEntrySize
+		buf = append(buf, bj.Value[:copySize]...)
+		if elemCount > 0 {
+			firstKeyOff := int(endian.Uint32(bj.Value[headerSize:]))
+			lastKeyOff := int(endian.Uint32(bj.Value[headerSize+(elemCount-1)*keyEntrySize:]))
+			lastKeyLen := int(endian.Uint16(bj.Value[headerSize+(elemCount-1)*keyEntrySize+keyLenOff:]))
+			buf = append(buf, bj.Value[firstKeyOff:lastKeyOff+lastKeyLen]...)
+		}
+	}
+	for i := 0; i < elemCount; i++ {
+		valEntryOff := valEntryStart + i*valEntrySize
+		elem := bj.valEntryGet(valEntryOff)
+		bm.bj = elem
+		var tpCode TypeCode
+		valOff := len(buf) - docOff
+		buf, tpCode = bm.rebuildTo(buf)
+		buf[docOff+valEntryOff] = tpCode
+		if tpCode == TypeCodeLiteral {
+			lastIdx := len(buf) - 1
+			endian.PutUint32(buf[docOff+valEntryOff+valTypeSize:], uint32(buf[lastIdx]))
+			buf = buf[:lastIdx]
+		} else {
+			endian.PutUint32(buf[docOff+valEntryOff+valTypeSize:], uint32(valOff))
+		}
+	}
+	endian.PutUint32(buf[docOff+dataSizeOff:], uint32(len(buf)-docOff))
+	return buf, bj.TypeCode
+}
+
+// CompareBinary compares two binary json objects. Returns -1 if left < right,
+// 0 if left == right, else returns 1.
+func CompareBinary(left, right BinaryJSON) int {
+	precedence1 := jsonTypePrecedences[left.Type()]
+	precedence2 := jsonTypePrecedences[right.Type()]
+	if precedence1 != precedence2 {
+		return compareInt(precedence1, precedence2)
+	}
+	var cmp int
+	switch left.TypeCode {
+	case TypeCodeLiteral:
+		// false is less than true, null only equals to null.
+		cmp = compareInt(int(right.Value[0]), int(left.Value[0]))
+	case TypeCodeInt64, TypeCodeUint64, TypeCodeFloat64:
+		cmp = compareNumber(left, right)
+	case TypeCodeString:
+		cmp = bytes.Compare(left.GetString(), right.GetString())
+	case TypeCodeArray:
+		leftCount := left.GetElemCount()
+		rightCount := right.GetElemCount()
+		for i := 0; i < leftCount && i < rightCount; i++ {
+			cmp = CompareBinary(left.arrayGetElem(i), right.arrayGetElem(i))
+			if cmp != 0 {
+				return cmp
+			}
+		}
+		cmp = compareInt(leftCount, rightCount)
+	case TypeCodeObject:
+		// Only equal is defined on two json objects.
+		// larger and smaller are not defined.
+		cmp = bytes.Compare(left.Value, right.Value)
+	}
+	return cmp
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// compareNumber compares two JSON numbers. Integers of the same signedness
+// are compared exactly, the others are compared as float64.
+func compareNumber(left, right BinaryJSON) int {
+	switch {
+	case left.TypeCode == TypeCodeInt64 && right.TypeCode == TypeCodeInt64:
+		l, r := left.GetInt64(), right.GetInt64()
+		if l < r {
+			return -1
+		} else if l > r {
+			return 1
+		}
+		return 0
+	case left.TypeCode == TypeCodeUint64 && right.TypeCode == TypeCodeUint64:
+		l, r := left.GetUint64(), right.GetUint64()
+		if l < r {
+			return -1
+		} else if l > r {
+			return 1
+		}
+		return 0
+	}
+	l, r := numberAsFloat64(left), numberAsFloat64(right)
+	if l < r {
+		return -1
+	} else if l > r {
+		return 1
+	}
+	return 0
+}
+
+func numberAsFloat64(bj BinaryJSON) float64 {
+	switch bj.TypeCode {
+	case TypeCodeInt64:
+		return float64(bj.GetInt64())
+	case TypeCodeUint64:
+		return float64(bj.GetUint64())
+	}
+	f := bj.GetFloat64()
+	if math.IsNaN(f) {
+		return 0
+	}
+	return f
+}
+
+// ContainsBinary check whether JSON document contains specific target according the following rules:
+// 1) object contains a target object if and only if every key is contained in source object and the value associated with the target key is contained in the value associated with the source key;
+// 2) array contains a target nonarray if and only if the target is contained in some element of the array;
+// 3) array contains a target array if and only if every element is contained in some element of the array;
+// 4) scalar contains a target scalar if and only if they are comparable and are equal;
+func ContainsBinary(obj, target BinaryJSON) bool {
+	switch obj.TypeCode {
+	case TypeCodeObject:
+		if target.TypeCode == TypeCodeObject {
+			elemCount := target.GetElemCount()
+			for i := 0; i < elemCount; i++ {
+				key := target.objectGetKey(i)
+				val := target.objectGetVal(i)
+				if exp, exists := obj.objectSearchKey(key); !exists || !ContainsBinary(exp, val) {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	case TypeCodeArray:
+		if target.TypeCode == TypeCodeArray {
+			elemCount := target.GetElemCount()
+			for i := 0; i < elemCount; i++ {
+				if !ContainsBinary(obj, target.arrayGetElem(i)) {
+					return false
+				}
+			}
+			return true
+		}
+		elemCount := obj.GetElemCount()
+		for i := 0; i < elemCount; i++ {
+			if ContainsBinary(obj.arrayGetElem(i), target) {
+				return true
+			}
+		}
+		return false
+	default:
+		return CompareBinary(obj, target) == 0
+	}
+}
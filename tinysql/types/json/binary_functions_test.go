@@ -0,0 +1,178 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	. "github.com/pingcap/check"
+)
+
+func mustParsePaths(c *C, paths ...string) []PathExpression {
+	pes := make([]PathExpression, 0, len(paths))
+	for _, p := range paths {
+		pe, err := ParseJSONPathExpr(p)
+		c.Assert(err, IsNil)
+		pes = append(pes, pe)
+	}
+	return pes
+}
+
+func (s *testJSONSuite) TestBinaryJSONExtract(c *C) {
+	bj1 := mustParseBinaryFromString(c, `{"\"hello\"": "world", "a": [1, "2", {"aa": "bb"}, 4.0, {"aa": "cc"}], "b": true, "c": ["d"]}`)
+	bj2 := mustParseBinaryFromString(c, `[{"a": 1, "b": true}, 3, 3.5, "hello, world", null, true]`)
+
+	var tests = []struct {
+		bj     BinaryJSON
+		paths  []string
+		found  bool
+		result string
+	}{
+		{bj1, []string{"$"}, true, bj1.String()},
+		{bj1, []string{"$.a"}, true, `[1, "2", {"aa": "bb"}, 4, {"aa": "cc"}]`},
+		{bj1, []string{"$.a[0]"}, true, `1`},
+		{bj1, []string{"$.a[5]"}, false, ``},
+		{bj1, []string{"$.a[*].aa"}, true, `["bb", "cc"]`},
+		{bj1, []string{`$."\"hello\""`}, true, `"world"`},
+		{bj1, []string{"$.b[0]"}, true, `true`},
+		{bj1, []string{"$.b[1]"}, false, ``},
+		{bj1, []string{"$**.aa"}, true, `["bb", "cc"]`},
+		{bj1, []string{"$.a[2]", "$.c"}, true, `[{"aa": "bb"}, ["d"]]`},
+		{bj1, []string{"$.d", "$.e"}, false, ``},
+		{bj2, []string{"$[0].a"}, true, `1`},
+		{bj2, []string{"$[*].b"}, true, `[true]`},
+		{bj2, []string{"$.a"}, false, ``},
+	}
+	for _, tt := range tests {
+		result, found := tt.bj.Extract(mustParsePaths(c, tt.paths...))
+		c.Assert(found, Equals, tt.found, Commentf("%v", tt.paths))
+		if found {
+			c.Assert(result.String(), Equals, tt.result, Commentf("%v", tt.paths))
+		}
+	}
+}
+
+func (s *testJSONSuite) TestBinaryJSONModify(c *C) {
+	var tests = []struct {
+		base     string
+		setField string
+		setValue string
+		mt       ModifyType
+		expected string
+	}{
+		{`null`, "$", `{}`, ModifySet, `{}`},
+		{`{}`, "$.a", `3`, ModifySet, `{"a": 3}`},
+		{`{"a": 3}`, "$.a", `[]`, ModifyReplace, `{"a": []}`},
+		{`{"a": 3}`, "$.b", `3`, ModifyReplace, `{"a": 3}`},
+		{`{"a": []}`, "$.a[0]", `3`, ModifySet, `{"a": [3]}`},
+		{`{"a": [3]}`, "$.a[1]", `4`, ModifyInsert, `{"a": [3, 4]}`},
+		{`{"a": [3]}`, "$[0]", `4`, ModifySet, `4`},
+		{`{"a": [3]}`, "$[1]", `4`, ModifySet, `[{"a": [3]}, 4]`},
+		{`{"b": 1, "d": "x"}`, "$.c", `true`, ModifyInsert, `{"b": 1, "c": true, "d": "x"}`},
+		{`{"b": 1, "d": "x"}`, "$.a", `null`, ModifyInsert, `{"a": null, "b": 1, "d": "x"}`},
+		{`{"b": 1, "d": "x"}`, "$.b", `"y"`, ModifyInsert, `{"b": 1, "d": "x"}`},
+		{`{"b": {"c": [1, 2]}, "d": "x"}`, "$.b.c[1]", `"z"`, ModifySet, `{"b": {"c": [1, "z"]}, "d": "x"}`},
+		{`{"b": 1}`, "$.a.b", `1`, ModifySet, `{"b": 1}`},
+	}
+	for _, tt := range tests {
+		base := mustParseBinaryFromString(c, tt.base)
+		value := mustParseBinaryFromString(c, tt.setValue)
+		obtain, err := base.Modify(mustParsePaths(c, tt.setField), []BinaryJSON{value}, tt.mt)
+		c.Assert(err, IsNil)
+		c.Assert(obtain.String(), Equals, tt.expected, Commentf("%v", tt))
+		// The input must not be changed.
+		c.Assert(base.String(), Equals, mustParseBinaryFromString(c, tt.base).String())
+	}
+
+	base := mustParseBinaryFromString(c, `{"a": 1}`)
+	_, err := base.Modify(mustParsePaths(c, "$.*"), []BinaryJSON{base}, ModifySet)
+	c.Assert(ErrInvalidJSONPathWildcard.Equal(err), IsTrue)
+	_, err = base.Modify(mustParsePaths(c, "$.a"), nil, ModifySet)
+	c.Assert(err, NotNil)
+}
+
+func (s *testJSONSuite) TestBinaryJSONRemove(c *C) {
+	var tests = []struct {
+		base     string
+		paths    []string
+		expected string
+	}{
+		{`{"a": 1, "b": [1, 2, 3]}`, []string{"$.a"}, `{"b": [1, 2, 3]}`},
+		{`{"a": 1, "b": [1, 2, 3]}`, []string{"$.b[1]"}, `{"a": 1, "b": [1, 3]}`},
+		{`{"a": 1, "b": [1, 2, 3]}`, []string{"$.c"}, `{"a": 1, "b": [1, 2, 3]}`},
+		{`{"a": 1, "b": [1, 2, 3]}`, []string{"$.a", "$.b[0]"}, `{"b": [2, 3]}`},
+		{`[1, {"a": true}]`, []string{"$[1].a"}, `[1, {}]`},
+	}
+	for _, tt := range tests {
+		base := mustParseBinaryFromString(c, tt.base)
+		obtain, err := base.Remove(mustParsePaths(c, tt.paths...))
+		c.Assert(err, IsNil)
+		c.Assert(obtain.String(), Equals, tt.expected)
+	}
+
+	base := mustParseBinaryFromString(c, `{"a": 1}`)
+	_, err := base.Remove(mustParsePaths(c, "$"))
+	c.Assert(ErrInvalidJSONPath.Equal(err), IsTrue)
+	_, err = base.Remove(mustParsePaths(c, "$[*]"))
+	c.Assert(ErrInvalidJSONPathWildcard.Equal(err), IsTrue)
+}
+
+func (s *testJSONSuite) TestCompareBinary(c *C) {
+	var tests = []struct {
+		left   string
+		right  string
+		result int
+	}{
+		{`null`, `null`, 0},
+		{`null`, `1`, -1},
+		{`false`, `true`, -1},
+		{`true`, `true`, 0},
+		{`1`, `1.0`, 0},
+		{`1`, `2`, -1},
+		{`18446744073709551615`, `-1`, 1},
+		{`1.5`, `1`, 1},
+		{`"a"`, `"b"`, -1},
+		{`"a"`, `1`, 1},
+		{`[1, 2]`, `[1, 3]`, -1},
+		{`[1, 2]`, `[1, 2, 3]`, -1},
+		{`[1, 2]`, `[1, 2]`, 0},
+		{`{"a": 1}`, `{"a": 1}`, 0},
+		{`true`, `[1]`, 1},
+	}
+	for _, tt := range tests {
+		left := mustParseBinaryFromString(c, tt.left)
+		right := mustParseBinaryFromString(c, tt.right)
+		c.Assert(CompareBinary(left, right), Equals, tt.result, Commentf("%s %s", tt.left, tt.right))
+	}
+}
+
+func (s *testJSONSuite) TestContainsBinary(c *C) {
+	obj := mustParseBinaryFromString(c, `{"a": [1, "2", {"aa": "bb"}, 4.0], "b": {"c": 1, "d": [2]}}`)
+	var tests = []struct {
+		target   string
+		contains bool
+	}{
+		{`{"a": 1}`, true},
+		{`{"a": [1, 4]}`, true},
+		{`{"a": {"aa": "bb"}}`, true},
+		{`{"b": {"d": 2}}`, true},
+		{`{"b": {"c": 2}}`, false},
+		{`{"c": 1}`, false},
+		{`1`, false},
+	}
+	for _, tt := range tests {
+		target := mustParseBinaryFromString(c, tt.target)
+		c.Assert(ContainsBinary(obj, target), Equals, tt.contains, Commentf("%s", tt.target))
+	}
+	c.Assert(ContainsBinary(mustParseBinaryFromString(c, `[1, [2, 3]]`), mustParseBinaryFromString(c, `[3, 1]`)), IsTrue)
+	c.Assert(ContainsBinary(mustParseBinaryFromString(c, `"a"`), mustParseBinaryFromString(c, `"a"`)), IsTrue)
+}
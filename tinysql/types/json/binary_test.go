@@ -0,0 +1,129 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	. "github.com/pingcap/check"
+)
+
+func TestT(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testJSONSuite{})
+
+type testJSONSuite struct{}
+
+func mustParseBinaryFromString(c *C, s string) BinaryJSON {
+	bj, err := ParseBinaryFromString(s)
+	c.Assert(err, IsNil)
+	return bj
+}
+
+func (s *testJSONSuite) TestBinaryJSONMarshalUnmarshal(c *C) {
+	strs := []string{
+		`{"a": [1, "2", {"aa": "bb"}, 4, null], "b": true, "c": null}`,
+		`{"aaaaaaaaaaa": [1, "2", {"aa": "bb"}, 4.1], "bbbbbbbbbb": true, "ccccccccc": "d"}`,
+		`[{"a": 1, "b": true}, 3, 3.5, "hello, world", null, true]`,
+		`{"a": "\"quoted\"\n", "b": -9223372036854775808, "c": 18446744073709551615}`,
+		`"tab\tbackslash\\"`,
+		`1e+21`,
+		`[]`,
+		`{}`,
+	}
+	for _, str := range strs {
+		bj := mustParseBinaryFromString(c, str)
+		c.Assert(bj.String(), Equals, str)
+	}
+
+	_, err := ParseBinaryFromString("")
+	c.Assert(ErrInvalidJSONText.Equal(err), IsTrue)
+	_, err = ParseBinaryFromString(`{"a": }`)
+	c.Assert(ErrInvalidJSONText.Equal(err), IsTrue)
+}
+
+func (s *testJSONSuite) TestBinaryJSONType(c *C) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{`{"a": "b"}`, "OBJECT"},
+		{`["a", "b"]`, "ARRAY"},
+		{`3`, "INTEGER"},
+		{`18446744073709551615`, "UNSIGNED INTEGER"},
+		{`3.0`, "DOUBLE"},
+		{`null`, "NULL"},
+		{`true`, "BOOLEAN"},
+		{`"abc"`, "STRING"},
+	}
+	for _, tt := range tests {
+		bj := mustParseBinaryFromString(c, tt.in)
+		c.Assert(bj.Type(), Equals, tt.out)
+	}
+}
+
+func (s *testJSONSuite) TestBinaryJSONUnquote(c *C) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{`"a"`, `a`},
+		{`"\"a\""`, `"a"`},
+		{`"你好"`, "你好"},
+		{`"a\tb\nc"`, "a\tb\nc"},
+		{`[1, "a"]`, `[1, "a"]`},
+		{`null`, `null`},
+	}
+	for _, tt := range tests {
+		bj := mustParseBinaryFromString(c, tt.in)
+		unquoted, err := bj.Unquote()
+		c.Assert(err, IsNil)
+		c.Assert(unquoted, Equals, tt.out)
+	}
+
+	_, err := UnquoteString(`"\u4f6"`)
+	c.Assert(err, NotNil)
+}
+
+func (s *testJSONSuite) TestPeekBytesAsJSON(c *C) {
+	strs := []string{`{"a": [1, 2]}`, `[1, "2"]`, `"abc"`, `1`, `1.5`, `true`}
+	for _, str := range strs {
+		bj := mustParseBinaryFromString(c, str)
+		buf := append([]byte{bj.TypeCode}, bj.Value...)
+		n, err := PeekBytesAsJSON(append(buf, 0xff, 0xff))
+		c.Assert(err, IsNil)
+		c.Assert(n, Equals, len(buf))
+	}
+	_, err := PeekBytesAsJSON(nil)
+	c.Assert(err, NotNil)
+}
+
+func (s *testJSONSuite) TestCreateBinary(c *C) {
+	bj := CreateBinary(int64(1 << 62))
+	c.Assert(bj.TypeCode, Equals, TypeCodeInt64)
+	c.Assert(bj.GetInt64(), Equals, int64(1<<62))
+
+	bj = CreateBinary([]interface{}{"a", true, nil, float64(1.5)})
+	c.Assert(bj.String(), Equals, `["a", true, null, 1.5]`)
+
+	bj = CreateBinary(map[string]interface{}{"b": uint64(1), "a": "x"})
+	c.Assert(bj.String(), Equals, `{"a": "x", "b": 1}`)
+
+	defer func() {
+		c.Assert(recover(), NotNil)
+	}()
+	CreateBinary(struct{}{})
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+)
+
+// TypeCode indicates JSON type.
+type TypeCode = byte
+
+const (
+	// TypeCodeObject indicates the JSON is an object.
+	TypeCodeObject TypeCode = 0x01
+	// TypeCodeArray indicates the JSON is an array.
+	TypeCodeArray TypeCode = 0x03
+	// TypeCodeLiteral indicates the JSON is a literal.
+	TypeCodeLiteral TypeCode = 0x04
+	// TypeCodeInt64 indicates the JSON is a signed integer.
+	TypeCodeInt64 TypeCode = 0x09
+	// TypeCodeUint64 indicates the JSON is a unsigned integer.
+	TypeCodeUint64 TypeCode = 0x0a
+	// TypeCodeFloat64 indicates the JSON is a double float number.
+	TypeCodeFloat64 TypeCode = 0x0b
+	// TypeCodeString indicates the JSON is a string.
+	TypeCodeString TypeCode = 0x0c
+)
+
+const (
+	// LiteralNil represents JSON null.
+	LiteralNil byte = 0x00
+	// LiteralTrue represents JSON true.
+	LiteralTrue byte = 0x01
+	// LiteralFalse represents JSON false.
+	LiteralFalse byte = 0x02
+)
+
+// ModifyType is for modify a JSON. There are three valid values:
+// ModifyInsert, ModifyReplace and ModifySet.
+type ModifyType byte
+
+const (
+	// ModifyInsert is for insert a new element into a JSON.
+	ModifyInsert ModifyType = 0x01
+	// ModifyReplace is for replace an old elemList from a JSON.
+	ModifyReplace ModifyType = 0x02
+	// ModifySet = ModifyInsert | ModifyReplace
+	ModifySet ModifyType = 0x03
+)
+
+var endian = binary.LittleEndian
+
+const (
+	headerSize   = 8 // element size + data size.
+	dataSizeOff  = 4
+	keyEntrySize = 6 // offset uint32 + length uint16.
+	valEntrySize = 5 // type byte + offset-or-inlined-value uint32.
+	valTypeSize  = 1
+	keyLenOff    = 4
+)
+
+// jsonTypePrecedences is for comparing two json.
+// See: https://dev.mysql.com/doc/refman/5.7/en/json.html#json-comparison
+var jsonTypePrecedences = map[string]int{
+	"BLOB":             -1,
+	"BIT":              -2,
+	"OPAQUE":           -3,
+	"DATETIME":         -4,
+	"TIME":             -5,
+	"DATE":             -6,
+	"BOOLEAN":          -7,
+	"ARRAY":            -8,
+	"OBJECT":           -9,
+	"STRING":           -10,
+	"INTEGER":          -11,
+	"UNSIGNED INTEGER": -11,
+	"DOUBLE":           -11,
+	"NULL":             -12,
+}
+
+// isSafeChar reports whether the ASCII byte b can be written into a
+// JSON string without escaping.
+func isSafeChar(b byte) bool {
+	return b >= 0x20 && b < utf8.RuneSelf && b != '"' && b != '\\'
+}
+
+const hexChars = "0123456789abcdef"
+
+var (
+	// ErrInvalidJSONText means invalid JSON text.
+	ErrInvalidJSONText = terror.ClassJSON.New(mysql.ErrInvalidJSONText, mysql.MySQLErrName[mysql.ErrInvalidJSONText])
+	// ErrInvalidJSONPath means invalid JSON path.
+	ErrInvalidJSONPath = terror.ClassJSON.New(mysql.ErrInvalidJSONPath, mysql.MySQLErrName[mysql.ErrInvalidJSONPath])
+	// ErrInvalidJSONData means invalid JSON data.
+	ErrInvalidJSONData = terror.ClassJSON.New(mysql.ErrInvalidJSONData, mysql.MySQLErrName[mysql.ErrInvalidJSONData])
+	// ErrInvalidJSONPathWildcard means invalid JSON path that contain wildcard characters.
+	ErrInvalidJSONPathWildcard = terror.ClassJSON.New(mysql.ErrInvalidJSONPathWildcard, mysql.MySQLErrName[mysql.ErrInvalidJSONPathWildcard])
+	// ErrInvalidJSONContainsPathType means invalid JSON contains path type.
+	ErrInvalidJSONContainsPathType = terror.ClassJSON.New(mysql.ErrInvalidJSONContainsPathType, mysql.MySQLErrName[mysql.ErrInvalidJSONContainsPathType])
+	// ErrJSONDocumentNULLKey means that json's key is null.
+	ErrJSONDocumentNULLKey = terror.ClassJSON.New(mysql.ErrJSONDocumentNULLKey, mysql.MySQLErrName[mysql.ErrJSONDocumentNULLKey])
+)
+
+func init() {
+	terror.ErrClassToMySQLCodes[terror.ClassJSON] = map[terror.ErrCode]uint16{
+		mysql.ErrInvalidJSONText:             mysql.ErrInvalidJSONText,
+		mysql.ErrInvalidJSONPath:             mysql.ErrInvalidJSONPath,
+		mysql.ErrInvalidJSONData:             mysql.ErrInvalidJSONData,
+		mysql.ErrInvalidJSONPathWildcard:     mysql.ErrInvalidJSONPathWildcard,
+		mysql.ErrInvalidJSONContainsPathType: mysql.ErrInvalidJSONContainsPathType,
+		mysql.ErrJSONDocumentNULLKey:         mysql.ErrJSONDocumentNULLKey,
+	}
+}
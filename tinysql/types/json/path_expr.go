@@ -0,0 +1,269 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+/*
+	From MySQL 5.7, JSON path expression grammar:
+		pathExpression ::= scope (pathLeg)*
+		scope ::= [ columnReference ] '$'
+		columnReference ::= // omit...
+		pathLeg ::= member | arrayLocation | '**'
+		member ::= '.' (keyName | '*')
+		arrayLocation ::= '[' (non-negative-integer | '*') ']'
+		keyName ::= ECMAScript-identifier | ECMAScript-string-literal
+
+	And some implementation limits in MySQL 5.7:
+		1) columnReference in scope must be empty now;
+		2) double asterisk(**) could not be last leg;
+
+	Examples:
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.a') -> "b"
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.c') -> [1, "2"]
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.a', '$.c') -> ["b", [1, "2"]]
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.c[0]') -> 1
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.c[2]') -> NULL
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.c[*]') -> [1, "2"]
+		select json_extract('{"a": "b", "c": [1, "2"]}', '$.*') -> ["b", [1, "2"]]
+*/
+
+type pathLegType byte
+
+const (
+	// pathLegKey indicates the path leg with '.key'.
+	pathLegKey pathLegType = 0x01
+	// pathLegIndex indicates the path leg with form '[number]'.
+	pathLegIndex pathLegType = 0x02
+	// pathLegDoubleAsterisk indicates the path leg with form '**'.
+	pathLegDoubleAsterisk pathLegType = 0x03
+)
+
+// pathLeg is only used by PathExpression.
+type pathLeg struct {
+	typ        pathLegType
+	arrayIndex int    // if typ is pathLegIndex, the value should be parsed into here.
+	dotKey     string // if typ is pathLegKey, the key should be parsed into here.
+}
+
+// arrayIndexAsterisk is for parsing `*` into a number.
+// we need this number represent "all".
+const arrayIndexAsterisk = -1
+
+// pathExpressionFlag holds attributes of PathExpression
+type pathExpressionFlag byte
+
+const (
+	pathExpressionContainsAsterisk       pathExpressionFlag = 0x01
+	pathExpressionContainsDoubleAsterisk pathExpressionFlag = 0x02
+)
+
+// containsAnyAsterisk returns true if pef contains any asterisk.
+func (pef pathExpressionFlag) containsAnyAsterisk() bool {
+	pef &= pathExpressionContainsAsterisk | pathExpressionContainsDoubleAsterisk
+	return byte(pef) != 0
+}
+
+// PathExpression is for JSON path expression.
+type PathExpression struct {
+	legs  []pathLeg
+	flags pathExpressionFlag
+}
+
+// popOneLeg returns a pathLeg, and a child PathExpression without that leg.
+func (pe PathExpression) popOneLeg() (pathLeg, PathExpression) {
+	newPe := PathExpression{
+		legs:  pe.legs[1:],
+		flags: 0,
+	}
+	for _, leg := range newPe.legs {
+		if leg.typ == pathLegIndex && leg.arrayIndex == -1 {
+			newPe.flags |= pathExpressionContainsAsterisk
+		} else if leg.typ == pathLegKey && leg.dotKey == "*" {
+			newPe.flags |= pathExpressionContainsAsterisk
+		} else if leg.typ == pathLegDoubleAsterisk {
+			newPe.flags |= pathExpressionContainsDoubleAsterisk
+		}
+	}
+	return pe.legs[0], newPe
+}
+
+// popOneLastLeg returns the parent PathExpression and the last pathLeg
+func (pe PathExpression) popOneLastLeg() (PathExpression, pathLeg) {
+	lastLegIdx := len(pe.legs) - 1
+	lastLeg := pe.legs[lastLegIdx]
+	// It is used only in modification, it has been checked that there is no asterisks.
+	return PathExpression{legs: pe.legs[:lastLegIdx]}, lastLeg
+}
+
+// ContainsAnyAsterisk returns true if pe contains any asterisk.
+func (pe PathExpression) ContainsAnyAsterisk() bool {
+	return pe.flags.containsAnyAsterisk()
+}
+
+// String implements fmt.Stringer interface.
+func (pe PathExpression) String() string {
+	var s strings.Builder
+	s.WriteString("$")
+	for _, leg := range pe.legs {
+		switch leg.typ {
+		case pathLegIndex:
+			if leg.arrayIndex == arrayIndexAsterisk {
+				s.WriteString("[*]")
+			} else {
+				s.WriteString("[")
+				s.WriteString(strconv.Itoa(leg.arrayIndex))
+				s.WriteString("]")
+			}
+		case pathLegKey:
+			s.WriteString(".")
+			if leg.dotKey == "*" {
+				s.WriteString(leg.dotKey)
+			} else {
+				s.Write(marshalStringTo(nil, []byte(leg.dotKey)))
+			}
+		case pathLegDoubleAsterisk:
+			s.WriteString("**")
+		}
+	}
+	return s.String()
+}
+
+// ParseJSONPathExpr parses a JSON path expression. Returns a PathExpression
+// object which can be used in JSON_EXTRACT, JSON_SET and so on.
+func ParseJSONPathExpr(pathExpr string) (PathExpression, error) {
+	p := &pathParser{s: pathExpr}
+	p.skipWhiteSpace()
+	if !p.consume('$') {
+		return PathExpression{}, ErrInvalidJSONPath.GenWithStackByArgs(pathExpr)
+	}
+	pe := PathExpression{legs: make([]pathLeg, 0, 16)}
+	for {
+		p.skipWhiteSpace()
+		if p.eof() {
+			break
+		}
+		leg, ok := p.parseLeg()
+		if !ok {
+			return PathExpression{}, ErrInvalidJSONPath.GenWithStackByArgs(pathExpr)
+		}
+		switch {
+		case leg.typ == pathLegDoubleAsterisk:
+			pe.flags |= pathExpressionContainsDoubleAsterisk
+		case leg.typ == pathLegIndex && leg.arrayIndex == arrayIndexAsterisk,
+			leg.typ == pathLegKey && leg.dotKey == "*":
+			pe.flags |= pathExpressionContainsAsterisk
+		}
+		pe.legs = append(pe.legs, leg)
+	}
+	// The double asterisk could not be the last leg.
+	if n := len(pe.legs); n > 0 && pe.legs[n-1].typ == pathLegDoubleAsterisk {
+		return PathExpression{}, ErrInvalidJSONPath.GenWithStackByArgs(pathExpr)
+	}
+	return pe, nil
+}
+
+type pathParser struct {
+	s   string
+	pos int
+}
+
+func (p *pathParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *pathParser) consume(c byte) bool {
+	if !p.eof() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) skipWhiteSpace() {
+	for !p.eof() && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *pathParser) parseLeg() (pathLeg, bool) {
+	switch {
+	case p.consume('.'):
+		p.skipWhiteSpace()
+		if p.consume('*') {
+			return pathLeg{typ: pathLegKey, dotKey: "*"}, true
+		}
+		key, ok := p.parseKey()
+		return pathLeg{typ: pathLegKey, dotKey: key}, ok
+	case p.consume('['):
+		p.skipWhiteSpace()
+		leg := pathLeg{typ: pathLegIndex, arrayIndex: arrayIndexAsterisk}
+		if !p.consume('*') {
+			start := p.pos
+			for !p.eof() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+				p.pos++
+			}
+			index, err := strconv.Atoi(p.s[start:p.pos])
+			if err != nil {
+				return leg, false
+			}
+			leg.arrayIndex = index
+		}
+		p.skipWhiteSpace()
+		return leg, p.consume(']')
+	case p.consume('*'):
+		return pathLeg{typ: pathLegDoubleAsterisk}, p.consume('*')
+	}
+	return pathLeg{}, false
+}
+
+// parseKey parses a key name, which is either an identifier or a double
+// quoted string literal.
+func (p *pathParser) parseKey() (string, bool) {
+	if p.eof() {
+		return "", false
+	}
+	if p.s[p.pos] == '"' {
+		start := p.pos
+		p.pos++
+		for !p.eof() && p.s[p.pos] != '"' {
+			if p.s[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if !p.consume('"') {
+			return "", false
+		}
+		key, err := UnquoteString(p.s[start:p.pos])
+		return key, err == nil
+	}
+	start := p.pos
+	for !p.eof() {
+		c := p.s[p.pos]
+		if c == '.' || c == '[' || c == '*' || c == '"' || unicode.IsSpace(rune(c)) {
+			break
+		}
+		p.pos++
+	}
+	key := p.s[start:p.pos]
+	if len(key) == 0 || (key[0] >= '0' && key[0] <= '9') {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	. "github.com/pingcap/check"
+)
+
+func (s *testJSONSuite) TestContainsAnyAsterisk(c *C) {
+	var tests = []struct {
+		exprString        string
+		containsAsterisks bool
+	}{
+		{"$.a[1]", false},
+		{"$.a[*]", true},
+		{"$.*[1]", true},
+		{"$**.a[1]", true},
+	}
+	for _, tt := range tests {
+		pe, err := ParseJSONPathExpr(tt.exprString)
+		c.Assert(err, IsNil)
+		c.Assert(pe.ContainsAnyAsterisk(), Equals, tt.containsAsterisks)
+	}
+}
+
+func (s *testJSONSuite) TestValidatePathExpr(c *C) {
+	var tests = []struct {
+		exprString string
+		success    bool
+		legs       int
+	}{
+		{`   $  `, true, 0},
+		{"   $ .   key1  [  3  ]\t[*].*.key3", true, 5},
+		{"   $ .   key1  [  3  ]**[*].*.key3", true, 6},
+		{`$."key1 string"[  3  ][*].*.key3`, true, 5},
+		{`$."hello \"escaped quotes\" world\\n"[3][*].*.key3`, true, 5},
+		{`$[1].a`, true, 2},
+
+		{`$.hello \"escaped quotes\" world\\n"[3][*].*.key3`, false, 0},
+		{`$.\"escaped quotes\" world\\n"[3][*].*.key3`, false, 0},
+		{`$.1key`, false, 0},
+		{`$[-1]`, false, 0},
+		{`$**`, false, 0},
+		{`.a`, false, 0},
+		{`$.a[`, false, 0},
+	}
+	for _, tt := range tests {
+		pe, err := ParseJSONPathExpr(tt.exprString)
+		if tt.success {
+			c.Assert(err, IsNil, Commentf("%s", tt.exprString))
+			c.Assert(len(pe.legs), Equals, tt.legs)
+		} else {
+			c.Assert(ErrInvalidJSONPath.Equal(err), IsTrue, Commentf("%s", tt.exprString))
+		}
+	}
+}
+
+func (s *testJSONSuite) TestPathExpressionString(c *C) {
+	var tests = []struct {
+		exprString string
+		out        string
+	}{
+		{"$.a[2]", `$."a"[2]`},
+		{"$.*[*]", `$.*[*]`},
+		{`$**."b c"`, `$**."b c"`},
+	}
+	for _, tt := range tests {
+		pe, err := ParseJSONPathExpr(tt.exprString)
+		c.Assert(err, IsNil)
+		c.Assert(pe.String(), Equals, tt.out)
+	}
+}
@@ -0,0 +1,577 @@
+// Copyright 2016 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"strings"
+
+	. "github.com/pingcap/check"
+)
+
+var _ = Suite(&testMyDecimalSuite{})
+
+type testMyDecimalSuite struct {
+}
+
+func (s *testMyDecimalSuite) TestFromInt(c *C) {
+	tests := []struct {
+		input  int64
+		output string
+	}{
+		{-12345, "-12345"},
+		{-1, "-1"},
+		{1, "1"},
+		{-9223372036854775807, "-9223372036854775807"},
+		{-9223372036854775808, "-9223372036854775808"},
+	}
+	for _, tt := range tests {
+		dec := NewDecFromInt(tt.input)
+		c.Check(string(dec.ToString()), Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestFromUint(c *C) {
+	tests := []struct {
+		input  uint64
+		output string
+	}{
+		{12345, "12345"},
+		{0, "0"},
+		{18446744073709551615, "18446744073709551615"},
+	}
+	for _, tt := range tests {
+		var dec MyDecimal
+		dec.FromUint(tt.input)
+		c.Check(string(dec.ToString()), Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestToInt(c *C) {
+	tests := []struct {
+		input  string
+		output int64
+		err    error
+	}{
+		{"18446744073709551615", 9223372036854775807, ErrOverflow},
+		{"-1", -1, nil},
+		{"1", 1, nil},
+		{"-1.23", -1, ErrTruncated},
+		{"-9223372036854775807", -9223372036854775807, nil},
+		{"-9223372036854775808", -9223372036854775808, nil},
+		{"9223372036854775808", 9223372036854775807, ErrOverflow},
+		{"-9223372036854775809", -9223372036854775808, ErrOverflow},
+	}
+	for _, tt := range tests {
+		var dec MyDecimal
+		c.Assert(dec.FromString([]byte(tt.input)), IsNil)
+		result, ec := dec.ToInt()
+		c.Check(ec, Equals, tt.err)
+		c.Check(result, Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestToUint(c *C) {
+	tests := []struct {
+		input  string
+		output uint64
+		err    error
+	}{
+		{"12345", 12345, nil},
+		{"0", 0, nil},
+		{"18446744073709551615", 18446744073709551615, nil},
+		{"18446744073709551616", 18446744073709551615, ErrOverflow},
+		{"-1", 0, ErrOverflow},
+		{"1.23", 1, ErrTruncated},
+	}
+	for _, tt := range tests {
+		var dec MyDecimal
+		c.Assert(dec.FromString([]byte(tt.input)), IsNil)
+		result, ec := dec.ToUint()
+		c.Check(ec, Equals, tt.err)
+		c.Check(result, Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestFloat64(c *C) {
+	tests := []struct {
+		s string
+		f float64
+	}{
+		{"12345", 12345},
+		{"123.45", 123.45},
+		{"-123.45", -123.45},
+		{"0.00012345000098765", 0.00012345000098765},
+		{"1234500009876.5", 1234500009876.5},
+	}
+	for _, tt := range tests {
+		dec := new(MyDecimal)
+		c.Assert(dec.FromFloat64(tt.f), IsNil)
+		c.Check(dec.String(), Equals, tt.s)
+		f, err := dec.ToFloat64()
+		c.Assert(err, IsNil)
+		c.Check(f, Equals, tt.f)
+	}
+}
+
+func (s *testMyDecimalSuite) TestFromString(c *C) {
+	tests := []struct {
+		input  string
+		output string
+		err    error
+	}{
+		{"12345", "12345", nil},
+		{"12345.", "12345", nil},
+		{"123.45.", "123.45", ErrTruncated},
+		{"-123.45.", "-123.45", ErrTruncated},
+		{".00012345000098765", "0.00012345000098765", nil},
+		{".12345000098765", "0.12345000098765", nil},
+		{"-.000000012345000098765", "-0.000000012345000098765", nil},
+		{"1234500009876.5", "1234500009876.5", nil},
+		{"123E5", "12300000", nil},
+		{"123E-2", "1.23", nil},
+		{"1e1073741823", "999999999999999999999999999999999999999999999999999999999999999999999999999999999", ErrOverflow},
+		{"-1e1073741823", "-999999999999999999999999999999999999999999999999999999999999999999999999999999999", ErrOverflow},
+		{"1e18446744073709551620", "0", ErrBadNumber},
+		{"1e", "1", ErrTruncated},
+		{"1e001", "10", nil},
+		{"1e00", "1", nil},
+		{"-0", "0", nil},
+		{"  12.5  ", "12.5", nil},
+		{"abc", "0", ErrBadNumber},
+	}
+	for _, tt := range tests {
+		var dec MyDecimal
+		err := dec.FromString([]byte(tt.input))
+		c.Check(err, Equals, tt.err, Commentf("%s", tt.input))
+		c.Check(string(dec.ToString()), Equals, tt.output, Commentf("%s", tt.input))
+	}
+}
+
+func (s *testMyDecimalSuite) TestShift(c *C) {
+	tests := []struct {
+		input  string
+		shift  int
+		output string
+	}{
+		{"123.123", 1, "1231.23"},
+		{"123457189.123123456789000", 1, "1234571891.23123456789"},
+		{"123.123", -3, "0.123123"},
+		{"123.123", -6, "0.000123123"},
+		{"-123.45", 2, "-12345"},
+		{"1", 20, "100000000000000000000"},
+		{"0", 5, "0"},
+	}
+	for _, tt := range tests {
+		dec := NewDecFromStringForTest(tt.input)
+		c.Assert(dec.Shift(tt.shift), IsNil)
+		c.Check(string(dec.ToString()), Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestRound(c *C) {
+	tests := []struct {
+		input  string
+		scale  int
+		output string
+		err    error
+	}{
+		{"123456789.987654321", 1, "123456790.0", nil},
+		{"15.1", 0, "15", nil},
+		{"15.5", 0, "16", nil},
+		{"15.9", 0, "16", nil},
+		{"-15.1", 0, "-15", nil},
+		{"-15.5", 0, "-16", nil},
+		{"-15.9", 0, "-16", nil},
+		{"15.1", 1, "15.1", nil},
+		{"-15.1", 1, "-15.1", nil},
+		{"15.17", 1, "15.2", nil},
+		{"15.4", -1, "20", nil},
+		{"-15.4", -1, "-20", nil},
+		{"5.4", -1, "10", nil},
+		{".999", 0, "1", nil},
+		{"999999999", -9, "1000000000", nil},
+		{"0.49", 0, "0", nil},
+		{"99.99", 1, "100.0", nil},
+		{"1.005", 2, "1.01", nil},
+	}
+	for _, tt := range tests {
+		var dec MyDecimal
+		c.Assert(dec.FromString([]byte(tt.input)), IsNil)
+		var rounded MyDecimal
+		err := dec.Round(&rounded, tt.scale, ModeHalfUp)
+		c.Check(err, Equals, tt.err)
+		c.Check(string(rounded.ToString()), Equals, tt.output, Commentf("%s round %d", tt.input, tt.scale))
+	}
+
+	truncTests := []struct {
+		input  string
+		scale  int
+		output string
+	}{
+		{"15.99", 1, "15.9"},
+		{"-15.99", 0, "-15"},
+		{"15.99", -1, "10"},
+	}
+	for _, tt := range truncTests {
+		dec := NewDecFromStringForTest(tt.input)
+		var rounded MyDecimal
+		c.Assert(dec.Round(&rounded, tt.scale, ModeTruncate), IsNil)
+		c.Check(string(rounded.ToString()), Equals, tt.output)
+	}
+}
+
+func (s *testMyDecimalSuite) TestToBinFromBin(c *C) {
+	tests := []struct {
+		input     string
+		precision int
+		frac      int
+		output    string
+		err       error
+	}{
+		{"-10.55", 4, 2, "-10.55", nil},
+		{"0.0123456789012345678912345", 30, 25, "0.0123456789012345678912345", nil},
+		{"12345", 5, 0, "12345", nil},
+		{"12345", 10, 3, "12345.000", nil},
+		{"123.45", 10, 3, "123.450", nil},
+		{"-123.45", 20, 10, "-123.4500000000", nil},
+		{".00012345000098765", 15, 14, "0.00012345000098", ErrTruncated},
+		{".00012345000098765", 22, 20, "0.00012345000098765000", nil},
+		{".12345000098765", 30, 20, "0.12345000098765000000", nil},
+		{"-.000000012345000098765", 30, 20, "-0.00000001234500009876", ErrTruncated},
+		{"1234500009876.5", 30, 5, "1234500009876.50000", nil},
+		{"111111111.11", 10, 2, "11111111.11", ErrOverflow},
+		{"000000000.01", 7, 3, "0.010", nil},
+		{"123.4", 10, 2, "123.40", nil},
+		{"1000", 3, 0, "0", ErrOverflow},
+		{"0", 1, 0, "0", nil},
+	}
+	for _, tt := range tests {
+		dec := NewDecFromStringForTest(tt.input)
+		buf, err := dec.ToBin(tt.precision, tt.frac)
+		c.Check(err, Equals, tt.err, Commentf("%v", tt))
+		c.Check(len(buf), Equals, DecimalBinSize(tt.precision, tt.frac))
+		var dec2 MyDecimal
+		size, err := dec2.FromBin(buf, tt.precision, tt.frac)
+		c.Assert(err, IsNil)
+		c.Check(size, Equals, len(buf))
+		c.Check(dec2.String(), Equals, tt.output, Commentf("%v", tt))
+	}
+
+	// The binary representation of the same precision and frac is memory comparable.
+	strs := []string{"-1234567890.1234", "-10.5", "-0.0001", "0", "0.0001", "1", "10.49", "10.5", "1234567890.1234"}
+	var prev []byte
+	for _, str := range strs {
+		buf, err := NewDecFromStringForTest(str).ToBin(14, 4)
+		c.Assert(err, IsNil)
+		if prev != nil {
+			c.Check(bytes.Compare(prev, buf), Equals, -1, Commentf("%s", str))
+		}
+		prev = buf
+	}
+	buf, err := NewDecFromStringForTest("1234567890.1234").ToBin(14, 4)
+	c.Assert(err, IsNil)
+	c.Check(buf, DeepEquals, []byte{0x81, 0x0D, 0xFB, 0x38, 0xD2, 0x04, 0xD2})
+	buf, err = NewDecFromStringForTest("-1234567890.1234").ToBin(14, 4)
+	c.Assert(err, IsNil)
+	c.Check(buf, DeepEquals, []byte{0x7E, 0xF2, 0x04, 0xC7, 0x2D, 0xFB, 0x2D})
+}
+
+func (s *testMyDecimalSuite) TestToHashKey(c *C) {
+	tests := []struct {
+		numbers []string
+	}{
+		{[]string{"1.1", "1.1000", "1.1000000", "1.10000000000", "01.1", "0001.1", "001.1000000"}},
+		{[]string{"-1.1", "-1.1000", "-1.1000000", "-1.10000000000", "-01.1", "-0001.1", "-001.1000000"}},
+		{[]string{".1", "0.1", "000000.1", ".10000", "0000.10000", "000000000000000000.1"}},
+		{[]string{"0", "0000", ".0", ".00000", "00000.00000", "-0", "-0000", "-.0", "-.00000", "-00000.00000"}},
+		{[]string{"123123123", "123123123.0", "123123123.0000", "0123123123", "0000123123123.00000"}},
+		{[]string{"1230", "1230.0", "1230.00", "001230.000"}},
+	}
+	for _, ca := range tests {
+		keys := make([]string, 0, len(ca.numbers))
+		for _, num := range ca.numbers {
+			var dec MyDecimal
+			c.Check(dec.FromString([]byte(num)), IsNil)
+			key, err := dec.ToHashKey()
+			c.Check(err, IsNil)
+			keys = append(keys, string(key))
+		}
+		for i := 1; i < len(keys); i++ {
+			c.Check(keys[0], Equals, keys[i])
+		}
+	}
+}
+
+func (s *testMyDecimalSuite) TestCompare(c *C) {
+	tests := []struct {
+		a   string
+		b   string
+		cmp int
+	}{
+		{"12", "13", -1},
+		{"13", "12", 1},
+		{"-10", "10", -1},
+		{"10", "-10", 1},
+		{"-12", "-13", 1},
+		{"0", "12", -1},
+		{"-10", "0", -1},
+		{"4", "4", 0},
+		{"-1.1", "-1.2", 1},
+		{"1.2", "1.1", 1},
+		{"1.1", "1.2", -1},
+		{"1.10", "1.1", 0},
+		{"1000000000.1", "999999999.9", 1},
+	}
+	for _, tt := range tests {
+		var a, b MyDecimal
+		c.Assert(a.FromString([]byte(tt.a)), IsNil)
+		c.Assert(b.FromString([]byte(tt.b)), IsNil)
+		c.Check(a.Compare(&b), Equals, tt.cmp)
+	}
+}
+
+func (s *testMyDecimalSuite) TestAddSub(c *C) {
+	type tcase struct {
+		a   string
+		b   string
+		sum string
+		sub string
+	}
+	tests := []tcase{
+		{".00012345000098765", "123.45", "123.45012345000098765", "-123.44987654999901235"},
+		{".1", ".45", "0.55", "-0.35"},
+		{"1234500009876.5", ".00012345000098765", "1234500009876.50012345000098765", "1234500009876.49987654999901235"},
+		{"9999909999999.5", ".555", "9999910000000.055", "9999909999998.945"},
+		{"99999999", "1", "100000000", "99999998"},
+		{"989999999", "1", "990000000", "989999998"},
+		{"999999999", "1", "1000000000", "999999998"},
+		{"12345", "123.45", "12468.45", "12221.55"},
+		{"-12345", "-123.45", "-12468.45", "-12221.55"},
+		{"-12345", "123.45", "-12221.55", "-12468.45"},
+		{"12345", "-123.45", "12221.55", "12468.45"},
+		{"-123.45", "12345", "12221.55", "-12468.45"},
+		{"5", "-6.0", "-1.0", "11.0"},
+		{"2" + strings.Repeat("1", 71), strings.Repeat("8", 81), "8888888890" + strings.Repeat("9", 71), "-888888888677" + strings.Repeat("7", 69)},
+		{"1234.5", "1234.5", "2469.0", "0.0"},
+	}
+	for _, tt := range tests {
+		a := NewDecFromStringForTest(tt.a)
+		b := NewDecFromStringForTest(tt.b)
+		var sum, sub MyDecimal
+		c.Check(DecimalAdd(a, b, &sum), IsNil)
+		c.Check(DecimalSub(a, b, &sub), IsNil)
+		c.Check(sum.String(), Equals, tt.sum, Commentf("%v", tt))
+		c.Check(sub.String(), Equals, tt.sub, Commentf("%v", tt))
+	}
+}
+
+func (s *testMyDecimalSuite) TestMulDiv(c *C) {
+	mulTests := []struct {
+		a      string
+		b      string
+		result string
+		err    error
+	}{
+		{"12", "10", "120", nil},
+		{"-123.456", "98765.4321", "-12193185.1853376", nil},
+		{"-123456000000", "98765432100000", "-12193185185337600000000000", nil},
+		{"123456", "987654321", "121931851853376", nil},
+		{"123456", "9876543210", "1219318518533760", nil},
+		{"123", "0.01", "1.23", nil},
+		{"123", "0", "0", nil},
+		{"-0.0000000000000000000000000000000000000000000000000017382578996420603", "-13890436710184412000000000000000000000000000000000000000000000000000000000000", "0.000000000000000000000000000000", ErrTruncated},
+		{"1" + strings.Repeat("0", 60), "1" + strings.Repeat("0", 60), "0", ErrOverflow},
+	}
+	for _, tt := range mulTests {
+		var a, b, product MyDecimal
+		c.Assert(a.FromString([]byte(tt.a)), IsNil)
+		c.Assert(b.FromString([]byte(tt.b)), IsNil)
+		err := DecimalMul(&a, &b, &product)
+		c.Check(err, Equals, tt.err, Commentf("%v", tt))
+		if err != ErrOverflow {
+			c.Check(product.String(), Equals, tt.result, Commentf("%v", tt))
+		}
+	}
+
+	divTests := []struct {
+		a      string
+		b      string
+		result string
+		err    error
+	}{
+		{"120", "10", "12.000000000", nil},
+		{"123", "0.01", "12300.000000000", nil},
+		{"120", "100000000000.00000", "0.000000001200000000", nil},
+		{"123", "0", "", ErrDivByZero},
+		{"-12193185.1853376", "98765.4321", "-123.456000000000000000", nil},
+		{"121931851853376", "987654321", "123456.000000000", nil},
+		{"0", "987", "0", nil},
+		{"1", "3", "0.333333333", nil},
+		{"1.000000000000", "3", "0.333333333333333333", nil},
+		{"1", "1", "1.000000000", nil},
+		{"0.0123456789012345678912345", "9999999999", "0.000000000001234567890246913578148141", nil},
+		{"10.333000000", "12.34500", "0.837019036046982584042122316", nil},
+		{"10.000000000060", "2", "5.000000000030000000", nil},
+		{"51", "0.003430", "14868.804664723032069970", nil},
+	}
+	for _, tt := range divTests {
+		var a, b, to MyDecimal
+		c.Assert(a.FromString([]byte(tt.a)), IsNil)
+		c.Assert(b.FromString([]byte(tt.b)), IsNil)
+		err := doDivMod(&a, &b, &to, nil, 5)
+		c.Check(err, Equals, tt.err, Commentf("%v", tt))
+		if tt.err == ErrDivByZero {
+			continue
+		}
+		c.Check(string(to.ToString()), Equals, tt.result, Commentf("%v", tt))
+	}
+
+	modTests := []struct {
+		a      string
+		b      string
+		result string
+		err    error
+	}{
+		{"234", "10", "4", nil},
+		{"234.567", "10.555", "2.357", nil},
+		{"-234.567", "10.555", "-2.357", nil},
+		{"234.567", "-10.555", "2.357", nil},
+		{"99999999999999999999999999999999999999", "3", "0", nil},
+		{"51", "0.003430", "0.002760", nil},
+		{"0.0000000001", "1.0", "0.0000000001", nil},
+		{"1", "0", "", ErrDivByZero},
+	}
+	for _, tt := range modTests {
+		var a, b, to MyDecimal
+		c.Assert(a.FromString([]byte(tt.a)), IsNil)
+		c.Assert(b.FromString([]byte(tt.b)), IsNil)
+		err := DecimalMod(&a, &b, &to)
+		c.Check(err, Equals, tt.err, Commentf("%v", tt))
+		if tt.err == ErrDivByZero {
+			continue
+		}
+		c.Check(to.String(), Equals, tt.result, Commentf("%v", tt))
+	}
+}
+
+func (s *testMyDecimalSuite) TestMaxOrMinDecimal(c *C) {
+	c.Check(NewMaxOrMinDec(false, 5, 2).String(), Equals, "999.99")
+	c.Check(NewMaxOrMinDec(true, 5, 2).String(), Equals, "-999.99")
+	c.Check(NewMaxOrMinDec(false, 10, 0).String(), Equals, "9999999999")
+	c.Check(NewMaxOrMinDec(true, 12, 12).String(), Equals, "-0.999999999999")
+}
+
+func (s *testMyDecimalSuite) TestPrecisionAndFrac(c *C) {
+	tests := []struct {
+		input     string
+		precision int
+		frac      int
+	}{
+		{"0", 1, 0},
+		{"0.00", 2, 2},
+		{"123.45", 5, 2},
+		{"-0.001", 3, 3},
+		{"1000000000", 10, 0},
+	}
+	for _, tt := range tests {
+		prec, frac := NewDecFromStringForTest(tt.input).PrecisionAndFrac()
+		c.Check(prec, Equals, tt.precision)
+		c.Check(frac, Equals, tt.frac)
+	}
+}
+
+// randDecimalString generates a random decimal string with at most 20 integer
+// digits and 15 fraction digits, so that the products fit in a MyDecimal.
+func randDecimalString(r *rand.Rand) string {
+	var buf []byte
+	if r.Intn(2) == 0 {
+		buf = append(buf, '-')
+	}
+	intLen := r.Intn(21)
+	for i := 0; i < intLen; i++ {
+		buf = append(buf, byte('0'+r.Intn(10)))
+	}
+	if intLen == 0 {
+		buf = append(buf, '0')
+	}
+	fracLen := r.Intn(16)
+	if fracLen > 0 {
+		buf = append(buf, '.')
+		for i := 0; i < fracLen; i++ {
+			buf = append(buf, byte('0'+r.Intn(10)))
+		}
+	}
+	return string(buf)
+}
+
+func ratString(r *big.Rat, frac int) string {
+	str := r.FloatString(frac)
+	if strings.TrimLeft(str, "-0.") == "" {
+		str = strings.TrimPrefix(str, "-")
+	}
+	return str
+}
+
+func (s *testMyDecimalSuite) TestRandomArithmetic(c *C) {
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 2000; i++ {
+		s1, s2 := randDecimalString(r), randDecimalString(r)
+		a, b := NewDecFromStringForTest(s1), NewDecFromStringForTest(s2)
+		ra, _ := new(big.Rat).SetString(s1)
+		rb, _ := new(big.Rat).SetString(s2)
+		frac := int(myMaxInt8(a.digitsFrac, b.digitsFrac))
+		comment := Commentf("%s, %s", s1, s2)
+
+		c.Check(a.Compare(b), Equals, ra.Cmp(rb), comment)
+
+		var to MyDecimal
+		c.Assert(DecimalAdd(a, b, &to), IsNil, comment)
+		c.Check(to.String(), Equals, ratString(new(big.Rat).Add(ra, rb), frac), comment)
+		c.Assert(DecimalSub(a, b, &to), IsNil, comment)
+		c.Check(to.String(), Equals, ratString(new(big.Rat).Sub(ra, rb), frac), comment)
+		c.Assert(DecimalMul(a, b, &to), IsNil, comment)
+		c.Check(to.String(), Equals, ratString(new(big.Rat).Mul(ra, rb), int(a.digitsFrac+b.digitsFrac)), comment)
+
+		if rb.Sign() == 0 {
+			continue
+		}
+		c.Assert(DecimalDiv(a, b, &to, DivFracIncr), IsNil, comment)
+		// The quotient is truncated, compare it with the exact result.
+		quo := new(big.Rat).Quo(ra, rb)
+		got, _ := new(big.Rat).SetString(string(to.ToString()))
+		diff := new(big.Rat).Sub(quo, got)
+		ulp := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to.digitsFrac)), nil))
+		c.Check(diff.Abs(diff).Cmp(ulp), Equals, -1, comment)
+
+		c.Assert(DecimalMod(a, b, &to), IsNil, comment)
+		got, _ = new(big.Rat).SetString(string(to.ToString()))
+		// a = k * b + mod, where k is an integer.
+		k := new(big.Rat).Quo(new(big.Rat).Sub(ra, got), rb)
+		c.Check(k.IsInt(), IsTrue, comment)
+		c.Check(got.Sign() == 0 || got.Sign() == ra.Sign(), IsTrue, comment)
+		c.Check(new(big.Rat).Abs(got).Cmp(new(big.Rat).Abs(rb)), Equals, -1, comment)
+
+		// Round trip with the binary format.
+		prec, fr := a.PrecisionAndFrac()
+		bin, err := a.ToBin(prec, fr)
+		c.Assert(err, IsNil, comment)
+		var dec MyDecimal
+		_, err = dec.FromBin(bin, prec, fr)
+		c.Assert(err, IsNil, comment)
+		c.Check(dec.Compare(a), Equals, 0, comment)
+	}
+}
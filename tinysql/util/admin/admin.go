@@ -80,7 +80,7 @@ func IsJobRollbackable(job *model.Job) bool {
 			job.SchemaState == model.StateDeleteReorganization {
 			return false
 		}
-	case model.ActionDropSchema, model.ActionDropTable:
+	case model.ActionDropSchema, model.ActionDropTable, model.ActionDropView:
 		// To simplify the rollback logic, cannot be canceled in the following states.
 		if job.SchemaState == model.StateWriteOnly ||
 			job.SchemaState == model.StateDeleteOnly {
@@ -88,9 +88,11 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionDropColumn, model.ActionModifyColumn,
 		model.ActionDropTablePartition, model.ActionAddTablePartition,
+		model.ActionTruncateTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionModifyTableCharsetAndCollate,
-		model.ActionModifySchemaCharsetAndCollate:
+		model.ActionModifySchemaCharsetAndCollate,
+		model.ActionDropCheckConstraint, model.ActionDropForeignKey:
 		return job.SchemaState == model.StateNone
 	}
 	return true
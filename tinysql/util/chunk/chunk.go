@@ -20,6 +20,7 @@ import (
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 )
 
 var msgErrSelNotNil = "The selection vector of Chunk is not nil. Please file a bug to the TiDB Team"
@@ -518,6 +519,12 @@ func (c *Chunk) AppendFloat64(colIdx int, f float64) {
 	c.columns[colIdx].AppendFloat64(f)
 }
 
+// AppendMyDecimal appends a MyDecimal value to the chunk.
+func (c *Chunk) AppendMyDecimal(colIdx int, dec *types.MyDecimal) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendMyDecimal(dec)
+}
+
 // AppendString appends a string value to the chunk.
 func (c *Chunk) AppendString(colIdx int, str string) {
 	c.appendSel(colIdx)
@@ -536,6 +543,36 @@ func (c *Chunk) appendSel(colIdx int) {
 	}
 }
 
+// AppendTime appends a Time value to the chunk.
+func (c *Chunk) AppendTime(colIdx int, t types.Time) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendTime(t)
+}
+
+// AppendDuration appends a Duration value to the chunk.
+func (c *Chunk) AppendDuration(colIdx int, dur types.Duration) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendDuration(dur)
+}
+
+// AppendEnum appends an Enum value to the chunk.
+func (c *Chunk) AppendEnum(colIdx int, enum types.Enum) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendEnum(enum)
+}
+
+// AppendSet appends a Set value to the chunk.
+func (c *Chunk) AppendSet(colIdx int, set types.Set) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendSet(set)
+}
+
+// AppendJSON appends a JSON value to the chunk.
+func (c *Chunk) AppendJSON(colIdx int, j json.BinaryJSON) {
+	c.appendSel(colIdx)
+	c.columns[colIdx].AppendJSON(j)
+}
+
 // AppendDatum appends a datum into the chunk.
 func (c *Chunk) AppendDatum(colIdx int, d *types.Datum) {
 	switch d.Kind() {
@@ -549,8 +586,20 @@ func (c *Chunk) AppendDatum(colIdx int, d *types.Datum) {
 		c.AppendFloat32(colIdx, d.GetFloat32())
 	case types.KindFloat64:
 		c.AppendFloat64(colIdx, d.GetFloat64())
-	case types.KindString, types.KindBytes:
+	case types.KindString, types.KindBytes, types.KindBinaryLiteral, types.KindMysqlBit:
 		c.AppendBytes(colIdx, d.GetBytes())
+	case types.KindMysqlDecimal:
+		c.AppendMyDecimal(colIdx, d.GetMysqlDecimal())
+	case types.KindMysqlEnum:
+		c.AppendEnum(colIdx, d.GetMysqlEnum())
+	case types.KindMysqlSet:
+		c.AppendSet(colIdx, d.GetMysqlSet())
+	case types.KindMysqlDuration:
+		c.AppendDuration(colIdx, d.GetMysqlDuration())
+	case types.KindMysqlTime:
+		c.AppendTime(colIdx, d.GetMysqlTime())
+	case types.KindMysqlJSON:
+		c.AppendJSON(colIdx, d.GetMysqlJSON())
 	}
 }
 
@@ -172,9 +172,13 @@ func getFixedLen(colType *types.FieldType) int {
 	switch colType.Tp {
 	case mysql.TypeFloat:
 		return 4
-	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeTimestamp,
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong,
 		mysql.TypeLonglong, mysql.TypeDouble, mysql.TypeYear, mysql.TypeDuration:
 		return 8
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		return sizeTime
+	case mysql.TypeNewDecimal:
+		return types.MyDecimalStructSize
 	default:
 		return varElemLen
 	}
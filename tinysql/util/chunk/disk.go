@@ -0,0 +1,245 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunk
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+const (
+	writeBufSize = 128 * 1024
+	// tmpFilePrefix is the prefix of the temporary files created by ListInDisk.
+	tmpFilePrefix = "tinysql-list-"
+)
+
+var (
+	diskListLabel        = stringutil.StringerStr("chunk.ListInDisk")
+	diskListOffsetsLabel = stringutil.StringerStr("chunk.ListInDisk.offsets")
+)
+
+// ListInDisk represents a slice of chunks storing in temporary disk.
+// Every row is encoded by Codec as a chunk of one row and appended to the
+// temporary file. The offset of every row is kept in memory, so a single row
+// can be read back and decoded without reading the whole chunk.
+type ListInDisk struct {
+	fieldTypes []*types.FieldType
+	codec      *Codec
+	// offsets stores the offsets of the rows in the disk file,
+	// offsets[chkIdx][rowIdx] is the offset of the row at RowPtr{chkIdx, rowIdx}.
+	offsets [][]int64
+	// offWrite is the current offset for writing, it's also the total length of the written data.
+	offWrite int64
+	length   int
+
+	disk      *os.File
+	bufWriter *bufio.Writer
+	// memTracker tracks the memory of offsets.
+	memTracker  *memory.Tracker
+	diskTracker *memory.Tracker
+	// bufFlushMutex protects the flush of bufWriter, since GetRow may be
+	// called by multiple goroutines concurrently.
+	bufFlushMutex sync.RWMutex
+}
+
+// NewListInDisk creates a new ListInDisk with field types.
+func NewListInDisk(fieldTypes []*types.FieldType) *ListInDisk {
+	l := &ListInDisk{
+		fieldTypes:  fieldTypes,
+		codec:       NewCodec(fieldTypes),
+		memTracker:  memory.NewTracker(diskListOffsetsLabel, -1),
+		diskTracker: memory.NewTracker(diskListLabel, -1),
+	}
+	return l
+}
+
+func (l *ListInDisk) initDiskFile() (err error) {
+	tmpDir := config.GetGlobalConfig().TmpStoragePath
+	err = os.MkdirAll(tmpDir, 0755)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	l.disk, err = ioutil.TempFile(tmpDir, tmpFilePrefix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	l.bufWriter = bufio.NewWriterSize(l.disk, writeBufSize)
+	return nil
+}
+
+// Len returns the number of rows in ListInDisk.
+func (l *ListInDisk) Len() int {
+	return l.length
+}
+
+// NumChunks returns the number of chunks in ListInDisk.
+func (l *ListInDisk) NumChunks() int {
+	return len(l.offsets)
+}
+
+// NumRowsOfChunk returns the number of rows of a chunk in ListInDisk.
+func (l *ListInDisk) NumRowsOfChunk(chkIdx int) int {
+	return len(l.offsets[chkIdx])
+}
+
+// GetMemTracker returns the memory tracker of this ListInDisk, it tracks
+// the memory of the row offsets.
+func (l *ListInDisk) GetMemTracker() *memory.Tracker {
+	return l.memTracker
+}
+
+// GetDiskTracker returns the disk tracker of this ListInDisk.
+func (l *ListInDisk) GetDiskTracker() *memory.Tracker {
+	return l.diskTracker
+}
+
+// Add adds a chunk to the ListInDisk. Caller must make sure the input chk
+// is not empty and has the same field types.
+// Warning: do not mix Add and GetRow (always use GetRow after you have added all the chunks), and do not use Add concurrently.
+func (l *ListInDisk) Add(chk *Chunk) (err error) {
+	if chk.NumRows() == 0 {
+		return errors.New("chunk appended to ListInDisk should have at least 1 row")
+	}
+	if l.disk == nil {
+		if err = l.initDiskFile(); err != nil {
+			return err
+		}
+	}
+	start := l.offWrite
+	offsets := make([]int64, 0, chk.NumRows())
+	rowChk := NewChunkWithCapacity(l.fieldTypes, 1)
+	var buf []byte
+	for rowIdx := 0; rowIdx < chk.NumRows(); rowIdx++ {
+		offsets = append(offsets, l.offWrite)
+		rowChk.Reset()
+		rowChk.AppendRow(chk.GetRow(rowIdx))
+		buf = buf[:0]
+		for _, col := range rowChk.columns {
+			buf = l.codec.encodeColumn(buf, col)
+		}
+		n, err := l.bufWriter.Write(buf)
+		l.offWrite += int64(n)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	l.offsets = append(l.offsets, offsets)
+	l.length += chk.NumRows()
+	l.memTracker.Consume(int64(len(offsets)) * 8)
+	l.diskTracker.Consume(l.offWrite - start)
+	return nil
+}
+
+// flush writes the buffered data to the disk file. The data is only buffered
+// after Add and before the first read, so the read lock is enough mostly.
+func (l *ListInDisk) flush() (err error) {
+	l.bufFlushMutex.RLock()
+	buffered := l.bufWriter.Buffered() > 0
+	l.bufFlushMutex.RUnlock()
+	if !buffered {
+		return nil
+	}
+	l.bufFlushMutex.Lock()
+	if l.bufWriter.Buffered() > 0 {
+		err = l.bufWriter.Flush()
+	}
+	l.bufFlushMutex.Unlock()
+	return errors.Trace(err)
+}
+
+// readAt reads the data between the offsets start and end from the disk file.
+func (l *ListInDisk) readAt(start, end int64) ([]byte, error) {
+	if err := l.flush(); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, end-start)
+	if _, err := l.disk.ReadAt(buf, start); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf, nil
+}
+
+// endOfChunk returns the offset of the end of the chunk chkIdx in the disk file.
+func (l *ListInDisk) endOfChunk(chkIdx int) int64 {
+	if chkIdx+1 < len(l.offsets) {
+		return l.offsets[chkIdx+1][0]
+	}
+	return l.offWrite
+}
+
+// GetChunk gets a Chunk from the ListInDisk by chkIdx.
+// The returned chunk is decoded from the disk file and can be used freely
+// by the caller.
+func (l *ListInDisk) GetChunk(chkIdx int) (*Chunk, error) {
+	buf, err := l.readAt(l.offsets[chkIdx][0], l.endOfChunk(chkIdx))
+	if err != nil {
+		return nil, err
+	}
+	chk := NewChunkWithCapacity(l.fieldTypes, len(l.offsets[chkIdx]))
+	for len(buf) > 0 {
+		var rowChk *Chunk
+		rowChk, buf = l.decodeRow(buf)
+		chk.AppendRow(rowChk.GetRow(0))
+	}
+	return chk, nil
+}
+
+// GetRow gets a Row from the ListInDisk by RowPtr. Only the row is read from
+// the disk file, so it can be called by multiple goroutines concurrently.
+func (l *ListInDisk) GetRow(ptr RowPtr) (row Row, err error) {
+	offsets := l.offsets[ptr.ChkIdx]
+	end := l.endOfChunk(int(ptr.ChkIdx))
+	if int(ptr.RowIdx)+1 < len(offsets) {
+		end = offsets[ptr.RowIdx+1]
+	}
+	buf, err := l.readAt(offsets[ptr.RowIdx], end)
+	if err != nil {
+		return row, err
+	}
+	rowChk, _ := l.decodeRow(buf)
+	return rowChk.GetRow(0), nil
+}
+
+// decodeRow decodes the chunk of one row at the beginning of buf, return the
+// remained unused bytes. The returned chunk refers to buf.
+func (l *ListInDisk) decodeRow(buf []byte) (*Chunk, []byte) {
+	rowChk := &Chunk{columns: make([]*Column, len(l.fieldTypes))}
+	for colIdx := range rowChk.columns {
+		rowChk.columns[colIdx] = &Column{}
+	}
+	return rowChk, l.codec.DecodeToChunk(buf, rowChk)
+}
+
+// Close releases the disk resource.
+func (l *ListInDisk) Close() error {
+	if l.disk != nil {
+		l.memTracker.Consume(-l.memTracker.BytesConsumed())
+		l.diskTracker.Consume(-l.diskTracker.BytesConsumed())
+		terr := l.disk.Close()
+		if err := os.Remove(l.disk.Name()); err != nil {
+			return errors.Trace(err)
+		}
+		l.disk = nil
+		return errors.Trace(terr)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunk
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/pingcap/check"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+func (s *testChunkSuite) TestListInDisk(c *check.C) {
+	fields := []*types.FieldType{
+		types.NewFieldType(mysql.TypeLonglong),
+		types.NewFieldType(mysql.TypeVarchar),
+	}
+	l := NewListInDisk(fields)
+	defer func() {
+		c.Assert(l.Close(), check.IsNil)
+	}()
+	c.Assert(l.Add(NewChunkWithCapacity(fields, 1)), check.NotNil)
+
+	numChk, numRow := 3, 5
+	for i := 0; i < numChk; i++ {
+		chk := NewChunkWithCapacity(fields, numRow)
+		for j := 0; j < numRow; j++ {
+			if j == 0 {
+				chk.AppendNull(0)
+			} else {
+				chk.AppendInt64(0, int64(i*numRow+j))
+			}
+			chk.AppendString(1, fmt.Sprintf("%d-%d", i, j))
+		}
+		c.Assert(l.Add(chk), check.IsNil)
+	}
+	c.Assert(l.NumChunks(), check.Equals, numChk)
+	c.Assert(l.Len(), check.Equals, numChk*numRow)
+	c.Assert(l.NumRowsOfChunk(1), check.Equals, numRow)
+	c.Assert(l.GetDiskTracker().BytesConsumed(), check.Greater, int64(0))
+	c.Assert(l.GetMemTracker().BytesConsumed(), check.Equals, int64(numChk*numRow*8))
+
+	for i := numChk - 1; i >= 0; i-- {
+		for j := 0; j < numRow; j++ {
+			row, err := l.GetRow(RowPtr{ChkIdx: uint32(i), RowIdx: uint32(j)})
+			c.Assert(err, check.IsNil)
+			if j == 0 {
+				c.Assert(row.IsNull(0), check.IsTrue)
+			} else {
+				c.Assert(row.GetInt64(0), check.Equals, int64(i*numRow+j))
+			}
+			c.Assert(row.GetString(1), check.Equals, fmt.Sprintf("%d-%d", i, j))
+		}
+	}
+
+	// GetRow can be called concurrently.
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for w := range errs {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < numChk*numRow; k++ {
+				i, j := (k+w)%numChk, k%numRow
+				row, err := l.GetRow(RowPtr{ChkIdx: uint32(i), RowIdx: uint32(j)})
+				if err == nil && row.GetString(1) != fmt.Sprintf("%d-%d", i, j) {
+					err = fmt.Errorf("unexpected row %s at (%d, %d)", row.GetString(1), i, j)
+				}
+				if err != nil {
+					errs[w] = err
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		c.Assert(err, check.IsNil)
+	}
+
+	chk, err := l.GetChunk(2)
+	c.Assert(err, check.IsNil)
+	c.Assert(chk.NumRows(), check.Equals, numRow)
+	c.Assert(chk.GetRow(0).IsNull(0), check.IsTrue)
+	c.Assert(chk.GetRow(numRow-1).GetInt64(0), check.Equals, int64(3*numRow-1))
+	c.Assert(chk.GetRow(numRow-1).GetString(1), check.Equals, fmt.Sprintf("2-%d", numRow-1))
+
+	name := l.disk.Name()
+	c.Assert(l.Close(), check.IsNil)
+	_, err = os.Stat(name)
+	c.Assert(os.IsNotExist(err), check.IsTrue)
+	c.Assert(l.GetDiskTracker().BytesConsumed(), check.Equals, int64(0))
+	c.Assert(l.GetMemTracker().BytesConsumed(), check.Equals, int64(0))
+}
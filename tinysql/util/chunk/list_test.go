@@ -115,6 +115,31 @@ func (s *testChunkSuite) TestListPrePreAlloc4RowAndInsert(c *check.C) {
 	}
 }
 
+func (s *testChunkSuite) TestListMemoryUsage(c *check.C) {
+	fields := []*types.FieldType{
+		types.NewFieldType(mysql.TypeLonglong),
+	}
+	l := NewList(fields, 2, 2)
+	srcChunk := NewChunkWithCapacity(fields, 2)
+	srcChunk.AppendInt64(0, 1)
+	srcRow := srcChunk.GetRow(0)
+
+	// The last chunk is not tracked until a new chunk is allocated.
+	l.AppendRow(srcRow)
+	c.Assert(l.GetMemTracker().BytesConsumed(), check.Equals, int64(0))
+	l.AppendRow(srcRow)
+	l.AppendRow(srcRow)
+	c.Assert(l.GetMemTracker().BytesConsumed(), check.Equals, l.GetChunk(0).MemoryUsage())
+
+	// Reset keeps the chunks in the freelist, so they are still tracked.
+	l.Reset()
+	consumed := l.GetMemTracker().BytesConsumed()
+	c.Assert(consumed, check.Greater, int64(0))
+
+	l.Add(srcChunk)
+	c.Assert(l.GetMemTracker().BytesConsumed(), check.Equals, consumed+srcChunk.MemoryUsage())
+}
+
 func BenchmarkPreAllocList(b *testing.B) {
 	fieldTypes := make([]*types.FieldType, 0, 1)
 	fieldTypes = append(fieldTypes, &types.FieldType{Tp: mysql.TypeLonglong})
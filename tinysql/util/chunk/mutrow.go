@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/hack"
 )
 
@@ -82,6 +83,24 @@ func zeroValForType(tp *types.FieldType) interface{} {
 		return ""
 	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 		return []byte{}
+	case mysql.TypeNewDecimal:
+		return types.NewDecFromInt(0)
+	case mysql.TypeDate:
+		return types.ZeroDate
+	case mysql.TypeDatetime:
+		return types.ZeroDatetime
+	case mysql.TypeTimestamp:
+		return types.ZeroTimestamp
+	case mysql.TypeDuration:
+		return types.ZeroDuration
+	case mysql.TypeBit:
+		return types.BinaryLiteral{}
+	case mysql.TypeEnum:
+		return types.Enum{}
+	case mysql.TypeSet:
+		return types.Set{}
+	case mysql.TypeJSON:
+		return json.CreateBinary(nil)
 	default:
 		return nil
 	}
@@ -109,6 +128,27 @@ func makeMutRowColumn(in interface{}) *Column {
 		return makeMutRowBytesColumn(hack.Slice(x))
 	case []byte:
 		return makeMutRowBytesColumn(x)
+	case types.BinaryLiteral:
+		return makeMutRowBytesColumn(x)
+	case *types.MyDecimal:
+		col := newMutRowFixedLenColumn(types.MyDecimalStructSize)
+		*(*types.MyDecimal)(unsafe.Pointer(&col.data[0])) = *x
+		return col
+	case types.Time:
+		col := newMutRowFixedLenColumn(sizeTime)
+		*(*types.Time)(unsafe.Pointer(&col.data[0])) = x
+		return col
+	case types.Duration:
+		return makeMutRowUint64Column(uint64(x.Duration))
+	case types.Enum:
+		return makeMutRowNameValueColumn(x.Name, x.Value)
+	case types.Set:
+		return makeMutRowNameValueColumn(x.Name, x.Value)
+	case json.BinaryJSON:
+		col := newMutRowVarLenColumn(len(x.Value) + 1)
+		col.data[0] = x.TypeCode
+		copy(col.data[1:], x.Value)
+		return col
 	default:
 		return nil
 	}
@@ -151,6 +191,13 @@ func makeMutRowBytesColumn(bin []byte) *Column {
 	return col
 }
 
+func makeMutRowNameValueColumn(name string, val uint64) *Column {
+	col := newMutRowVarLenColumn(len(name) + 8)
+	*(*uint64)(unsafe.Pointer(&col.data[0])) = val
+	copy(col.data[8:], name)
+	return col
+}
+
 // SetRow sets the MutRow with Row.
 func (mr MutRow) SetRow(row Row) {
 	for colIdx, rCol := range row.c.columns {
@@ -198,6 +245,20 @@ func (mr MutRow) SetValue(colIdx int, val interface{}) {
 		setMutRowBytes(col, hack.Slice(x))
 	case []byte:
 		setMutRowBytes(col, x)
+	case types.BinaryLiteral:
+		setMutRowBytes(col, x)
+	case *types.MyDecimal:
+		*(*types.MyDecimal)(unsafe.Pointer(&col.data[0])) = *x
+	case types.Time:
+		*(*types.Time)(unsafe.Pointer(&col.data[0])) = x
+	case types.Duration:
+		*(*int64)(unsafe.Pointer(&col.data[0])) = int64(x.Duration)
+	case types.Enum:
+		setMutRowNameValue(col, x.Name, x.Value)
+	case types.Set:
+		setMutRowNameValue(col, x.Name, x.Value)
+	case json.BinaryJSON:
+		setMutRowJSON(col, x)
 	}
 	col.nullBitmap[0] = 1
 }
@@ -221,8 +282,22 @@ func (mr MutRow) SetDatum(colIdx int, d types.Datum) {
 		binary.LittleEndian.PutUint64(mr.c.columns[colIdx].data, d.GetUint64())
 	case types.KindFloat32:
 		binary.LittleEndian.PutUint32(mr.c.columns[colIdx].data, math.Float32bits(d.GetFloat32()))
-	case types.KindString, types.KindBytes:
+	case types.KindString, types.KindBytes, types.KindBinaryLiteral, types.KindMysqlBit:
 		setMutRowBytes(col, d.GetBytes())
+	case types.KindMysqlDecimal:
+		*(*types.MyDecimal)(unsafe.Pointer(&col.data[0])) = *d.GetMysqlDecimal()
+	case types.KindMysqlTime:
+		*(*types.Time)(unsafe.Pointer(&col.data[0])) = d.GetMysqlTime()
+	case types.KindMysqlDuration:
+		*(*int64)(unsafe.Pointer(&col.data[0])) = int64(d.GetMysqlDuration().Duration)
+	case types.KindMysqlEnum:
+		e := d.GetMysqlEnum()
+		setMutRowNameValue(col, e.Name, e.Value)
+	case types.KindMysqlSet:
+		s := d.GetMysqlSet()
+		setMutRowNameValue(col, s.Name, s.Value)
+	case types.KindMysqlJSON:
+		setMutRowJSON(col, d.GetMysqlJSON())
 	default:
 		mr.c.columns[colIdx] = makeMutRowColumn(d.GetValue())
 	}
@@ -241,6 +316,36 @@ func setMutRowBytes(col *Column, bin []byte) {
 	col.offsets[1] = int64(len(bin))
 }
 
+func setMutRowNameValue(col *Column, name string, val uint64) {
+	dataLen := len(name) + 8
+	if len(col.data) >= dataLen {
+		col.data = col.data[:dataLen]
+	} else {
+		buf := make([]byte, dataLen+1)
+		col.data = buf[:dataLen]
+		col.nullBitmap = buf[dataLen:]
+	}
+	*(*uint64)(unsafe.Pointer(&col.data[0])) = val
+	copy(col.data[8:], name)
+	col.offsets[1] = int64(dataLen)
+}
+
+func setMutRowJSON(col *Column, j json.BinaryJSON) {
+	dataLen := len(j.Value) + 1
+	if len(col.data) >= dataLen {
+		col.data = col.data[:dataLen]
+	} else {
+		// In MutRow, there always exists 1 data in every Column,
+		// we should allocate one more byte for null bitmap.
+		buf := make([]byte, dataLen+1)
+		col.data = buf[:dataLen]
+		col.nullBitmap = buf[dataLen:]
+	}
+	col.data[0] = j.TypeCode
+	copy(col.data[1:], j.Value)
+	col.offsets[1] = int64(dataLen)
+}
+
 // ShallowCopyPartialRow shallow copies the data of `row` to MutRow.
 func (mr MutRow) ShallowCopyPartialRow(colIdx int, row Row) {
 	for i, srcCol := range row.c.columns {
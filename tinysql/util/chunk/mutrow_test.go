@@ -18,6 +18,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"testing"
 )
 
@@ -48,6 +49,7 @@ var allTypes = []*types.FieldType{
 	types.NewFieldType(mysql.TypeTimestamp),
 	types.NewFieldType(mysql.TypeDuration),
 	types.NewFieldType(mysql.TypeNewDecimal),
+	types.NewFieldType(mysql.TypeJSON),
 	{
 		Tp:      mysql.TypeSet,
 		Flen:    types.UnspecifiedLength,
@@ -107,6 +109,41 @@ func (s *testChunkSuite) TestMutRow(c *check.C) {
 	row = mutRow.ToRow()
 	c.Assert(row.IsNull(0), check.IsTrue)
 	c.Assert(row.IsNull(1), check.IsFalse)
+
+	j, err := json.ParseBinaryFromString(`{"a": [1, "b"]}`)
+	c.Assert(err, check.IsNil)
+	mutRow = MutRowFromValues(j)
+	c.Assert(mutRow.ToRow().GetJSON(0).String(), check.Equals, `{"a": [1, "b"]}`)
+	mutRow.SetDatums(types.NewJSONDatum(json.CreateBinary("a longer json string value")))
+	c.Assert(mutRow.ToRow().GetJSON(0).String(), check.Equals, `"a longer json string value"`)
+
+	chk := NewChunkWithCapacity([]*types.FieldType{types.NewFieldType(mysql.TypeJSON)}, 2)
+	chk.AppendJSON(0, j)
+	chk.AppendNull(0)
+	c.Assert(chk.GetRow(0).GetJSON(0).String(), check.Equals, j.String())
+	c.Assert(chk.GetRow(1).IsNull(0), check.IsTrue)
+	c.Assert(GetCompareFunc(types.NewFieldType(mysql.TypeJSON))(chk.GetRow(0), 0, mutRow.ToRow(), 0), check.Equals, 1)
+
+	enumTp, setTp := allTypes[len(allTypes)-2], allTypes[len(allTypes)-3]
+	mutRow = MutRowFromValues(types.Enum{Name: "b", Value: 2}, types.Set{Name: "a,b", Value: 3})
+	row = mutRow.ToRow()
+	c.Assert(row.GetEnum(0), check.Equals, types.Enum{Name: "b", Value: 2})
+	d := row.GetDatum(1, setTp)
+	c.Assert(d.GetMysqlSet(), check.Equals, types.Set{Name: "a,b", Value: 3})
+	mutRow.SetDatums(types.NewMysqlEnumDatum(types.Enum{Name: "a", Value: 1}), types.NewMysqlSetDatum(types.Set{}))
+	row = mutRow.ToRow()
+	d = row.GetDatum(0, enumTp)
+	c.Assert(d.GetMysqlEnum(), check.Equals, types.Enum{Name: "a", Value: 1})
+	c.Assert(row.GetSet(1), check.Equals, types.Set{})
+
+	chk = NewChunkWithCapacity([]*types.FieldType{enumTp}, 2)
+	chk.AppendEnum(0, types.Enum{Name: "b", Value: 2})
+	chk.AppendNull(0)
+	c.Assert(chk.GetRow(0).GetEnum(0).String(), check.Equals, "b")
+	c.Assert(chk.GetRow(1).IsNull(0), check.IsTrue)
+	c.Assert(GetCompareFunc(enumTp)(chk.GetRow(0), 0, mutRow.ToRow(), 0), check.Equals, 1)
+	d = types.NewMysqlEnumDatum(types.Enum{Name: "b", Value: 2})
+	c.Assert(Compare(chk.GetRow(0), 0, &d), check.Equals, 0)
 }
 
 func BenchmarkMutRowSetDatums(b *testing.B) {
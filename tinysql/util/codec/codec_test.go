@@ -24,8 +24,10 @@ import (
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/testleak"
 )
@@ -441,6 +443,192 @@ func (s *testCodecSuite) TestFloatCodec(c *C) {
 	}
 }
 
+func (s *testCodecSuite) TestDecimal(c *C) {
+	defer testleak.AfterTest(c)()
+	tbl := []string{
+		"1234.00",
+		"1234",
+		"12.34",
+		"12.340",
+		"0.1234",
+		"0.0",
+		"0",
+		"-0.0",
+		"-0.0000",
+		"-1234.00",
+		"-1234",
+		"-12.34",
+		"-12.340",
+		"-0.1234",
+	}
+
+	for _, t := range tbl {
+		dec := new(types.MyDecimal)
+		err := dec.FromString([]byte(t))
+		c.Assert(err, IsNil)
+		b, err := EncodeKey(nil, nil, types.NewDatum(dec))
+		c.Assert(err, IsNil)
+		v, err := Decode(b, 1)
+		c.Assert(err, IsNil)
+		c.Assert(v, HasLen, 1)
+		vv := v[0].GetMysqlDecimal()
+		c.Assert(vv.Compare(dec), Equals, 0)
+	}
+
+	tblCmp := []struct {
+		Arg1 interface{}
+		Arg2 interface{}
+		Ret  int
+	}{
+		// Test for float type decimal.
+		{"1234", "123400", -1},
+		{"12340", "123400", -1},
+		{"1234", "1234.5", -1},
+		{"1234", "1234.0000", 0},
+		{"1234", "12.34", 1},
+		{"12.34", "12.35", -1},
+		{"0.12", "0.1234", -1},
+		{"0.1234", "12.3400", -1},
+		{"0.1234", "0.1235", -1},
+		{"0.123400", "12.34", -1},
+		{"12.34000", "12.34", 0},
+		{"0.01234", "0.01235", -1},
+		{"0.1234", "0", 1},
+		{"0.0000", "0", 0},
+		{"0.0001", "0", 1},
+		{"0.0001", "0.0000", 1},
+		{"0", "-0.0000", 0},
+		{"-0.0001", "0", -1},
+		{"-0.1234", "0", -1},
+		{"-0.1234", "-0.12", -1},
+		{"-0.12", "-0.1234", 1},
+		{"-0.12", "-0.1200", 0},
+		{"-0.1234", "0.1234", -1},
+		{"-1.234", "-12.34", 1},
+		{"-0.1234", "-12.34", 1},
+		{"-12.34", "1234", -1},
+		{"-12.34", "-12.35", 1},
+		{"-0.01234", "-0.01235", 1},
+		{"-1234", "-123400", 1},
+		{"-12340", "-123400", 1},
+
+		// Test for int type decimal.
+		{int64(-1), int64(1), -1},
+		{int64(math.MaxInt64), int64(math.MinInt64), 1},
+		{int64(math.MaxInt64), int64(math.MaxInt32), 1},
+		{int64(math.MinInt32), int64(math.MaxInt16), -1},
+		{int64(math.MinInt64), int64(math.MaxInt8), -1},
+		{int64(0), int64(math.MaxInt8), -1},
+		{int64(math.MinInt8), int64(0), -1},
+		{int64(math.MinInt16), int64(math.MaxInt16), -1},
+		{int64(1), int64(-1), 1},
+		{int64(1), int64(0), 1},
+		{int64(-1), int64(0), -1},
+		{int64(0), int64(0), 0},
+		{int64(math.MaxInt16), int64(math.MaxInt16), 0},
+
+		// Test for uint type decimal.
+		{uint64(0), uint64(0), 0},
+		{uint64(1), uint64(0), 1},
+		{uint64(0), uint64(1), -1},
+		{uint64(math.MaxInt8), uint64(math.MaxInt16), -1},
+		{uint64(math.MaxUint32), uint64(math.MaxInt32), 1},
+		{uint64(math.MaxUint8), uint64(math.MaxInt8), 1},
+		{uint64(math.MaxUint16), uint64(math.MaxInt32), -1},
+		{uint64(math.MaxUint64), uint64(math.MaxInt64), 1},
+		{uint64(math.MaxInt64), uint64(math.MaxUint32), 1},
+		{uint64(math.MaxUint64), uint64(0), 1},
+		{uint64(0), uint64(math.MaxUint64), -1},
+	}
+	sc := &stmtctx.StatementContext{TimeZone: time.Local}
+	for _, t := range tblCmp {
+		d1 := types.NewDatum(t.Arg1)
+		dec1, err := d1.ToDecimal(sc)
+		c.Assert(err, IsNil)
+		d1.SetMysqlDecimal(dec1)
+		d2 := types.NewDatum(t.Arg2)
+		dec2, err := d2.ToDecimal(sc)
+		c.Assert(err, IsNil)
+		d2.SetMysqlDecimal(dec2)
+
+		d1.SetLength(30)
+		d1.SetFrac(6)
+		d2.SetLength(30)
+		d2.SetFrac(6)
+		b1, err := EncodeKey(sc, nil, d1)
+		c.Assert(err, IsNil)
+		b2, err := EncodeKey(sc, nil, d2)
+		c.Assert(err, IsNil)
+
+		ret := bytes.Compare(b1, b2)
+		c.Assert(ret, Equals, t.Ret, Commentf("%v %x %x", t, b1, b2))
+	}
+
+	floats := []float64{-123.45, -123.40, -23.45, -1.43, -0.93, -0.4333, -0.068,
+		-0.0099, 0, 0.001, 0.0012, 0.12, 1.2, 1.23, 123.3, 2424.242424}
+	decs := make([][]byte, 0, len(floats))
+	for i := range floats {
+		dec := types.NewDecFromFloatForTest(floats[i])
+		var d types.Datum
+		d.SetLength(20)
+		d.SetFrac(6)
+		d.SetMysqlDecimal(dec)
+		b, err := EncodeDecimal(nil, d.GetMysqlDecimal(), d.Length(), d.Frac())
+		c.Assert(err, IsNil)
+		decs = append(decs, b)
+	}
+	for i := 0; i < len(decs)-1; i++ {
+		cmp := bytes.Compare(decs[i], decs[i+1])
+		c.Assert(cmp, LessEqual, 0)
+	}
+
+	d := types.NewDecFromStringForTest("-123.123456789")
+	_, err := EncodeDecimal(nil, d, 20, 5)
+	c.Assert(terror.ErrorEqual(err, types.ErrTruncated), IsTrue, Commentf("err %v", err))
+	_, err = EncodeDecimal(nil, d, 12, 10)
+	c.Assert(terror.ErrorEqual(err, types.ErrOverflow), IsTrue, Commentf("err %v", err))
+}
+
+func (s *testCodecSuite) TestJSON(c *C) {
+	defer testleak.AfterTest(c)()
+	tbl := []string{
+		"1234.00",
+		`{"a": "b"}`,
+		`[1, {"x": [true, null]}, "c"]`,
+	}
+
+	datums := make([]types.Datum, 0, len(tbl))
+	for _, t := range tbl {
+		var d types.Datum
+		j, err := json.ParseBinaryFromString(t)
+		c.Assert(err, IsNil)
+		d.SetMysqlJSON(j)
+		datums = append(datums, d)
+	}
+
+	bytes := make([]byte, 0, 4096)
+	bytes, err := encode(nil, bytes, datums, false)
+	c.Assert(err, IsNil)
+
+	datums1, err := Decode(bytes, 2)
+	c.Assert(err, IsNil)
+	c.Assert(datums1, HasLen, len(datums))
+	for i := range datums1 {
+		lhs := datums[i].GetMysqlJSON().String()
+		rhs := datums1[i].GetMysqlJSON().String()
+		c.Assert(lhs, Equals, rhs)
+	}
+
+	for _, d := range datums {
+		b, err := EncodeValue(nil, nil, d)
+		c.Assert(err, IsNil)
+		data, remain, err := CutOne(b)
+		c.Assert(err, IsNil)
+		c.Assert(data, HasLen, len(b))
+		c.Assert(remain, HasLen, 0)
+	}
+}
+
 func (s *testCodecSuite) TestBytes(c *C) {
 	defer testleak.AfterTest(c)()
 	tblBytes := [][]byte{
@@ -641,6 +829,7 @@ func datumsForTest(sc *stmtctx.StatementContext) ([]types.Datum, []*types.FieldT
 		{[]byte("abc"), types.NewFieldType(mysql.TypeMediumBlob)},
 		{[]byte("abc"), types.NewFieldType(mysql.TypeLongBlob)},
 		{int64(1), types.NewFieldType(mysql.TypeYear)},
+		{json.CreateBinary("abc"), types.NewFieldType(mysql.TypeJSON)},
 	}
 
 	datums := make([]types.Datum, 0, len(table)+2)
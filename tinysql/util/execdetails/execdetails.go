@@ -0,0 +1,235 @@
+// Copyright 2018 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execdetails
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// ExecDetails contains execution detail information of a coprocessor task.
+type ExecDetails struct {
+	// CalleeAddress is the address of the store which handled the task.
+	CalleeAddress string
+	// RegionID is the region the task was sent to.
+	RegionID uint64
+}
+
+// RuntimeStatsColl collects executors's execution info.
+type RuntimeStatsColl struct {
+	mu          sync.Mutex
+	rootStats   map[string]*RuntimeStats
+	copStats    map[string]*CopRuntimeStats
+	readerStats map[string]*ReaderRuntimeStats
+}
+
+// RuntimeStats collects one executor's execution info.
+type RuntimeStats struct {
+	// executor's Next() called times.
+	loop int32
+	// executor consume time.
+	consume int64
+	// executor return row count.
+	rows int64
+}
+
+// CopRuntimeStats collects cop tasks' execution info.
+type CopRuntimeStats struct {
+	sync.Mutex
+
+	// stats stores the runtime statistics of coprocessor tasks.
+	// The key of the map is the store address. Because a store can have many
+	// region leaders, several coprocessor tasks can be sent to the same store.
+	// We have to use a list to maintain all tasks executed on each store.
+	stats map[string][]*RuntimeStats
+}
+
+// ReaderRuntimeStats collects the coprocessor tasks issued by a reader
+// executor, with the processing time reported by the stores.
+type ReaderRuntimeStats struct {
+	sync.Mutex
+
+	copTasks    int
+	regions     map[uint64]struct{}
+	processTime time.Duration
+	maxProcess  time.Duration
+}
+
+// NewRuntimeStatsColl creates new executor collector.
+func NewRuntimeStatsColl() *RuntimeStatsColl {
+	return &RuntimeStatsColl{
+		rootStats:   make(map[string]*RuntimeStats),
+		copStats:    make(map[string]*CopRuntimeStats),
+		readerStats: make(map[string]*ReaderRuntimeStats),
+	}
+}
+
+// GetRootStats gets execStat for a executor.
+func (e *RuntimeStatsColl) GetRootStats(planID string) *RuntimeStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	runtimeStats, exists := e.rootStats[planID]
+	if !exists {
+		runtimeStats = &RuntimeStats{}
+		e.rootStats[planID] = runtimeStats
+	}
+	return runtimeStats
+}
+
+// GetCopStats gets the CopRuntimeStats specified by planID.
+func (e *RuntimeStatsColl) GetCopStats(planID string) *CopRuntimeStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	copStats, ok := e.copStats[planID]
+	if !ok {
+		copStats = &CopRuntimeStats{stats: make(map[string][]*RuntimeStats)}
+		e.copStats[planID] = copStats
+	}
+	return copStats
+}
+
+// GetReaderStats gets the ReaderRuntimeStats specified by planID.
+func (e *RuntimeStatsColl) GetReaderStats(planID string) *ReaderRuntimeStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	readerStats, ok := e.readerStats[planID]
+	if !ok {
+		readerStats = &ReaderRuntimeStats{regions: make(map[uint64]struct{})}
+		e.readerStats[planID] = readerStats
+	}
+	return readerStats
+}
+
+// RecordOneCopTask records a specific cop tasks's execution detail.
+func (e *RuntimeStatsColl) RecordOneCopTask(planID, address string, summary *tipb.ExecutorExecutionSummary) {
+	copStats := e.GetCopStats(planID)
+	copStats.RecordOneCopTask(address, summary)
+}
+
+// ExistsRootStats checks if the planID exists in the rootStats collection.
+func (e *RuntimeStatsColl) ExistsRootStats(planID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, exists := e.rootStats[planID]
+	return exists
+}
+
+// ExistsCopStats checks if the planID exists in the copStats collection.
+func (e *RuntimeStatsColl) ExistsCopStats(planID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, exists := e.copStats[planID]
+	return exists
+}
+
+// ExistsReaderStats checks if the planID exists in the readerStats collection.
+func (e *RuntimeStatsColl) ExistsReaderStats(planID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, exists := e.readerStats[planID]
+	return exists
+}
+
+// Record records executor's execution.
+func (e *RuntimeStats) Record(d time.Duration, rowNum int) {
+	atomic.AddInt32(&e.loop, 1)
+	atomic.AddInt64(&e.consume, int64(d))
+	atomic.AddInt64(&e.rows, int64(rowNum))
+}
+
+// Loops returns the number of the executor's Next calls.
+func (e *RuntimeStats) Loops() int32 {
+	return atomic.LoadInt32(&e.loop)
+}
+
+// Rows returns the number of rows produced by the executor.
+func (e *RuntimeStats) Rows() int64 {
+	return atomic.LoadInt64(&e.rows)
+}
+
+func (e *RuntimeStats) String() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("time:%v, loops:%d, rows:%d", time.Duration(atomic.LoadInt64(&e.consume)), atomic.LoadInt32(&e.loop), atomic.LoadInt64(&e.rows))
+}
+
+// RecordOneCopTask records a specific cop tasks's execution detail.
+func (crs *CopRuntimeStats) RecordOneCopTask(address string, summary *tipb.ExecutorExecutionSummary) {
+	crs.Lock()
+	defer crs.Unlock()
+	crs.stats[address] = append(crs.stats[address],
+		&RuntimeStats{loop: int32(summary.GetNumIterations()),
+			consume: int64(summary.GetTimeProcessedNs()),
+			rows:    int64(summary.GetNumProducedRows())})
+}
+
+func (crs *CopRuntimeStats) String() string {
+	crs.Lock()
+	defer crs.Unlock()
+	if len(crs.stats) == 0 {
+		return ""
+	}
+
+	var totalRows, totalTasks int64
+	var totalIters int32
+	procTimes := make([]time.Duration, 0, 32)
+	for _, instanceStats := range crs.stats {
+		for _, stat := range instanceStats {
+			procTimes = append(procTimes, time.Duration(stat.consume))
+			totalRows += stat.rows
+			totalIters += stat.loop
+			totalTasks++
+		}
+	}
+
+	if totalTasks == 1 {
+		return fmt.Sprintf("time:%v, loops:%d, rows:%d", procTimes[0], totalIters, totalRows)
+	}
+
+	n := len(procTimes)
+	sort.Slice(procTimes, func(i, j int) bool { return procTimes[i] < procTimes[j] })
+	return fmt.Sprintf("proc max:%v, min:%v, p80:%v, p95:%v, loops:%d, rows:%d, tasks:%d",
+		procTimes[n-1], procTimes[0], procTimes[n*4/5], procTimes[n*19/20], totalIters, totalRows, totalTasks)
+}
+
+// RecordCopTask records a coprocessor task issued by the reader and the time
+// the store spent on processing it.
+func (rrs *ReaderRuntimeStats) RecordCopTask(detail *ExecDetails, processTime time.Duration) {
+	rrs.Lock()
+	defer rrs.Unlock()
+	rrs.copTasks++
+	if detail != nil {
+		rrs.regions[detail.RegionID] = struct{}{}
+	}
+	rrs.processTime += processTime
+	if processTime > rrs.maxProcess {
+		rrs.maxProcess = processTime
+	}
+}
+
+func (rrs *ReaderRuntimeStats) String() string {
+	rrs.Lock()
+	defer rrs.Unlock()
+	if rrs.copTasks == 0 {
+		return ""
+	}
+	return fmt.Sprintf("cop_task: {num: %d, regions: %d, process: %v, max_process: %v}",
+		rrs.copTasks, len(rrs.regions), rrs.processTime, rrs.maxProcess)
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execdetails
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+func TestT(t *testing.T) {
+	TestingT(t)
+}
+
+var _ = Suite(&testExecDetailsSuite{})
+
+type testExecDetailsSuite struct{}
+
+func mockExecutorExecutionSummary(timeProcessedNs, numProducedRows, numIterations uint64) *tipb.ExecutorExecutionSummary {
+	return &tipb.ExecutorExecutionSummary{TimeProcessedNs: &timeProcessedNs, NumProducedRows: &numProducedRows,
+		NumIterations: &numIterations}
+}
+
+func (s *testExecDetailsSuite) TestRootRuntimeStats(c *C) {
+	stats := NewRuntimeStatsColl()
+	c.Assert(stats.ExistsRootStats("1"), IsFalse)
+	rootStats := stats.GetRootStats("1")
+	rootStats.Record(time.Second, 10)
+	rootStats.Record(time.Second, 0)
+	c.Assert(stats.ExistsRootStats("1"), IsTrue)
+	c.Assert(rootStats.Loops(), Equals, int32(2))
+	c.Assert(rootStats.Rows(), Equals, int64(10))
+	c.Assert(rootStats.String(), Equals, "time:2s, loops:2, rows:10")
+}
+
+func (s *testExecDetailsSuite) TestCopRuntimeStats(c *C) {
+	stats := NewRuntimeStatsColl()
+	stats.RecordOneCopTask("8", "8.8.8.8", mockExecutorExecutionSummary(1, 1, 1))
+	c.Assert(stats.ExistsCopStats("8"), IsTrue)
+	c.Assert(stats.GetCopStats("8").String(), Equals, "time:1ns, loops:1, rows:1")
+
+	stats.RecordOneCopTask("8", "8.8.8.9", mockExecutorExecutionSummary(2, 2, 2))
+	stats.RecordOneCopTask("8", "8.8.8.9", mockExecutorExecutionSummary(3, 3, 3))
+	c.Assert(stats.GetCopStats("8").String(), Equals,
+		"proc max:3ns, min:1ns, p80:3ns, p95:3ns, loops:6, rows:6, tasks:3")
+}
+
+func (s *testExecDetailsSuite) TestReaderRuntimeStats(c *C) {
+	stats := NewRuntimeStatsColl()
+	c.Assert(stats.ExistsReaderStats("1"), IsFalse)
+	readerStats := stats.GetReaderStats("1")
+	c.Assert(readerStats.String(), Equals, "")
+	readerStats.RecordCopTask(&ExecDetails{CalleeAddress: "8.8.8.8", RegionID: 2}, time.Millisecond)
+	readerStats.RecordCopTask(&ExecDetails{CalleeAddress: "8.8.8.8", RegionID: 2}, 3*time.Millisecond)
+	readerStats.RecordCopTask(&ExecDetails{CalleeAddress: "8.8.8.9", RegionID: 4}, 2*time.Millisecond)
+	c.Assert(readerStats.String(), Equals, "cop_task: {num: 3, regions: 2, process: 6ms, max_process: 3ms}")
+}
@@ -54,3 +54,16 @@ func (s *testMath) TestStrLenOfUint64Fast_ManualTestCases(c *C) {
 		c.Assert(actual, Equals, expected)
 	}
 }
+
+func (s *testMath) TestMysqlRng(c *C) {
+	// The expected values are the results of RAND(0) and RAND(1) in MySQL.
+	rng := NewWithSeed(0)
+	c.Assert(rng.Gen(), Equals, 0.15522042769493574)
+	c.Assert(rng.Gen(), Equals, 0.620881741513388)
+	c.Assert(rng.Gen(), Equals, 0.6387474552157777)
+
+	rng = NewWithSeed(1)
+	c.Assert(rng.Gen(), Equals, 0.40540353712197724)
+	c.Assert(rng.Gen(), Equals, 0.8716141803857071)
+	c.Assert(rng.Gen(), Equals, 0.1418603212962489)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/sqlexec"
 )
 
@@ -39,6 +40,7 @@ type Context struct {
 	sessionVars *variable.SessionVars
 	ctx         context.Context
 	cancel      context.CancelFunc
+	pcache      *kvcache.SimpleLRUCache
 }
 
 type wrapTxn struct {
@@ -220,6 +222,11 @@ func (c *Context) HasLockedTables() bool {
 func (c *Context) PrepareTxnFuture(ctx context.Context) {
 }
 
+// PreparedPlanCache implements the sessionctx.Context interface.
+func (c *Context) PreparedPlanCache() *kvcache.SimpleLRUCache {
+	return c.pcache
+}
+
 // Close implements the sessionctx.Context interface.
 func (c *Context) Close() {
 }
@@ -613,6 +613,121 @@ func (s *testRangerSuite) TestIndexRangeForUnsignedInt(c *C) {
 	}
 }
 
+func (s *testRangerSuite) TestIndexRangeForTime(c *C) {
+	defer testleak.AfterTest(c)()
+	dom, store, err := newDomainStoreWithBootstrap(c)
+	defer func() {
+		dom.Close()
+		store.Close()
+	}()
+	c.Assert(err, IsNil)
+	testKit := testkit.NewTestKit(c, store)
+	testKit.MustExec("use test")
+	testKit.MustExec("drop table if exists t")
+	testKit.MustExec("create table t (a datetime, b date, c timestamp(3), d time, key(a), key(b), key(c), key(d))")
+
+	tests := []struct {
+		indexPos    int
+		exprStr     string
+		accessConds string
+		filterConds string
+		resultStr   string
+	}{
+		{
+			indexPos:    0,
+			exprStr:     `a = '2019-01-01 10:00:00'`,
+			accessConds: "[eq(test.t.a, 2019-01-01 10:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[[2019-01-01 10:00:00,2019-01-01 10:00:00]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a >= '2019-01-01' and a < '2019-01-02'`,
+			accessConds: "[ge(test.t.a, 2019-01-01 00:00:00.000000) lt(test.t.a, 2019-01-02 00:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[[2019-01-01 00:00:00,2019-01-02 00:00:00)]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a > '2019-01-01 10:00:00.4'`,
+			accessConds: "[gt(test.t.a, 2019-01-01 10:00:00.400000)]",
+			filterConds: "[]",
+			resultStr:   "[(2019-01-01 10:00:00,+inf]]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a = '2019-01-01 10:00:00.4'`,
+			accessConds: "[eq(test.t.a, 2019-01-01 10:00:00.400000)]",
+			filterConds: "[]",
+			resultStr:   "[]",
+		},
+		{
+			indexPos:    0,
+			exprStr:     `a in ('2019-01-01', 20190102)`,
+			accessConds: "[in(test.t.a, 2019-01-01 00:00:00.000000, 2019-01-02 00:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[[2019-01-01 00:00:00,2019-01-01 00:00:00] [2019-01-02 00:00:00,2019-01-02 00:00:00]]",
+		},
+		{
+			indexPos:    1,
+			exprStr:     `b >= '2019-01-01 10:00:00' and b <= '2019-01-03 10:00:00'`,
+			accessConds: "[ge(test.t.b, 2019-01-01 10:00:00.000000) le(test.t.b, 2019-01-03 10:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[(2019-01-01,2019-01-03]]",
+		},
+		{
+			indexPos:    2,
+			exprStr:     `c between '2019-01-01 10:00:00' and '2019-01-01 11:00:00.1234'`,
+			accessConds: "[ge(test.t.c, 2019-01-01 10:00:00.000000) le(test.t.c, 2019-01-01 11:00:00.123400)]",
+			filterConds: "[]",
+			resultStr:   "[[2019-01-01 10:00:00.000,2019-01-01 11:00:00.123]]",
+		},
+		{
+			indexPos:    3,
+			exprStr:     `d > '10:00:00' and d < '-1 10:00:00'`,
+			accessConds: "[gt(test.t.d, 10:00:00.000000) lt(test.t.d, -34:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[]",
+		},
+		{
+			indexPos:    3,
+			exprStr:     `d >= '10:00:00' and d <= '12:00:00'`,
+			accessConds: "[ge(test.t.d, 10:00:00.000000) le(test.t.d, 12:00:00.000000)]",
+			filterConds: "[]",
+			resultStr:   "[[10:00:00,12:00:00]]",
+		},
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		sql := "select * from t where " + tt.exprStr
+		sctx := testKit.Se.(sessionctx.Context)
+		stmts, err := session.Parse(sctx, sql)
+		c.Assert(err, IsNil, Commentf("error %v, for expr %s", err, tt.exprStr))
+		c.Assert(stmts, HasLen, 1)
+		is := domain.GetDomain(sctx).InfoSchema()
+		err = plannercore.Preprocess(sctx, stmts[0], is)
+		c.Assert(err, IsNil, Commentf("error %v, for resolve name, expr %s", err, tt.exprStr))
+		p, _, err := plannercore.BuildLogicalPlan(ctx, sctx, stmts[0], is)
+		c.Assert(err, IsNil, Commentf("error %v, for build plan, expr %s", err, tt.exprStr))
+		selection := p.(plannercore.LogicalPlan).Children()[0].(*plannercore.LogicalSelection)
+		tbl := selection.Children()[0].(*plannercore.DataSource).TableInfo()
+		c.Assert(selection, NotNil, Commentf("expr:%v", tt.exprStr))
+		conds := make([]expression.Expression, len(selection.Conditions))
+		for i, cond := range selection.Conditions {
+			conds[i] = expression.PushDownNot(sctx, cond)
+		}
+		cols, lengths := expression.IndexInfo2PrefixCols(tbl.Columns, selection.Schema().Columns, tbl.Indices[tt.indexPos])
+		c.Assert(cols, NotNil)
+		res, err := ranger.DetachCondAndBuildRangeForIndex(sctx, conds, cols, lengths)
+		c.Assert(err, IsNil)
+		c.Assert(fmt.Sprintf("%s", res.AccessConds), Equals, tt.accessConds, Commentf("wrong access conditions for expr: %s", tt.exprStr))
+		c.Assert(fmt.Sprintf("%s", res.RemainedConds), Equals, tt.filterConds, Commentf("wrong filter conditions for expr: %s", tt.exprStr))
+		got := fmt.Sprintf("%v", res.Ranges)
+		c.Assert(got, Equals, tt.resultStr, Commentf("different for expr %s", tt.exprStr))
+	}
+}
+
 func (s *testRangerSuite) TestColumnRange(c *C) {
 	defer testleak.AfterTest(c)()
 	dom, store, err := newDomainStoreWithBootstrap(c)
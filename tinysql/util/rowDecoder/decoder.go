@@ -14,33 +14,65 @@
 package decoder
 
 import (
+	"sort"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/rowcodec"
 )
 
 // Column contains the info and generated expr of column.
 type Column struct {
-	Col *table.Column
+	Col     *table.Column
+	GenExpr expression.Expression
 }
 
 // RowDecoder decodes a byte slice into datums and eval the generated column value.
 type RowDecoder struct {
-	colTypes map[int64]*types.FieldType
+	tbl           table.Table
+	mutRow        chunk.MutRow
+	columns       map[int64]Column
+	colTypes      map[int64]*types.FieldType
+	haveGenColumn bool
+	defaultVals   []types.Datum
 }
 
 // NewRowDecoder returns a new RowDecoder.
 func NewRowDecoder(tbl table.Table, decodeColMap map[int64]Column) *RowDecoder {
 	colFieldMap := make(map[int64]*types.FieldType, len(decodeColMap))
+	haveGenCol := false
 	for id, col := range decodeColMap {
 		colFieldMap[id] = &col.Col.ColumnInfo.FieldType
+		if col.GenExpr != nil {
+			haveGenCol = true
+		}
+	}
+	if !haveGenCol {
+		return &RowDecoder{
+			colTypes: colFieldMap,
+		}
+	}
+
+	cols := tbl.Cols()
+	tps := make([]*types.FieldType, len(cols))
+	for _, col := range cols {
+		tps[col.Offset] = &col.FieldType
 	}
 	return &RowDecoder{
-		colTypes: colFieldMap,
+		tbl:           tbl,
+		mutRow:        chunk.MutRowFromTypes(tps),
+		columns:       decodeColMap,
+		colTypes:      colFieldMap,
+		haveGenColumn: haveGenCol,
+		defaultVals:   make([]types.Datum, len(cols)),
 	}
 }
 
@@ -55,12 +87,76 @@ func (rd *RowDecoder) DecodeAndEvalRowWithMap(ctx sessionctx.Context, handle int
 	if err != nil {
 		return nil, err
 	}
+	if !rd.haveGenColumn {
+		return row, nil
+	}
+
+	for _, dCol := range rd.columns {
+		colInfo := dCol.Col.ColumnInfo
+		val, ok := row[colInfo.ID]
+		if ok || dCol.GenExpr != nil {
+			rd.mutRow.SetDatum(colInfo.Offset, val)
+			continue
+		}
+
+		// Get the default value of the column in the generated column expression.
+		if dCol.Col.IsPKHandleColumn(rd.tbl.Meta()) {
+			if mysql.HasUnsignedFlag(colInfo.Flag) {
+				val.SetUint64(uint64(handle))
+			} else {
+				val.SetInt64(handle)
+			}
+		} else {
+			val, err = tables.GetColDefaultValue(ctx, dCol.Col, rd.defaultVals)
+			if err != nil {
+				return nil, err
+			}
+		}
+		rd.mutRow.SetDatum(colInfo.Offset, val)
+	}
+	// Evaluate the generated columns in definition order, a generated column
+	// may refer to the generated columns defined prior to it.
+	offsets := make([]int, 0, len(rd.columns))
+	ids := make(map[int]int64, len(rd.columns))
+	for id, col := range rd.columns {
+		offsets = append(offsets, col.Col.Offset)
+		ids[col.Col.Offset] = id
+	}
+	sort.Ints(offsets)
+	for _, offset := range offsets {
+		col := rd.columns[ids[offset]]
+		if col.GenExpr == nil {
+			continue
+		}
+		// Eval the column value
+		val, err := col.GenExpr.Eval(rd.mutRow.ToRow())
+		if err != nil {
+			return nil, err
+		}
+		val, err = table.CastValue(ctx, val, col.Col.ColumnInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		if val.Kind() == types.KindMysqlTime && sysLoc != time.UTC {
+			t := val.GetMysqlTime()
+			if t.Type() == mysql.TypeTimestamp {
+				err := t.ConvertTimeZone(sysLoc, time.UTC)
+				if err != nil {
+					return nil, err
+				}
+				val.SetMysqlTime(t)
+			}
+		}
+		rd.mutRow.SetDatum(offset, val)
+		row[ids[offset]] = val
+	}
 	return row, nil
 }
 
 // BuildFullDecodeColMap build a map that contains [columnID -> struct{*table.Column, expression.Expression}] from
-// indexed columns and all of its depending columns.
-func BuildFullDecodeColMap(indexedCols []*table.Column) (map[int64]Column, error) {
+// indexed columns and all of its depending columns. genExprProducer is used to produce a generated expression based on a table.Column.
+func BuildFullDecodeColMap(indexedCols []*table.Column, t table.Table, genExprProducer func(*table.Column) (expression.Expression, error)) (map[int64]Column, error) {
 	pendingCols := make([]*table.Column, len(indexedCols))
 	copy(pendingCols, indexedCols)
 	decodeColMap := make(map[int64]Column, len(pendingCols))
@@ -71,8 +167,27 @@ func BuildFullDecodeColMap(indexedCols []*table.Column) (map[int64]Column, error
 			continue // already discovered
 		}
 
-		decodeColMap[col.ID] = Column{
-			Col: col,
+		if col.IsGenerated() && !col.GeneratedStored {
+			// Find depended columns and put them into pendingCols. For example, idx(c) with column definition `c int as (a + b)`,
+			// depended columns of `c` is `a` and `b`, and both of them will be put into the pendingCols, waiting for next traversal.
+			for _, c := range t.Cols() {
+				if _, ok := col.Dependences[c.Name.L]; ok {
+					pendingCols = append(pendingCols, c)
+				}
+			}
+
+			e, err := genExprProducer(col)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			decodeColMap[col.ID] = Column{
+				Col:     col,
+				GenExpr: e,
+			}
+		} else {
+			decodeColMap[col.ID] = Column{
+				Col: col,
+			}
 		}
 	}
 	return decodeColMap, nil
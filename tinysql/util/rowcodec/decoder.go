@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 )
@@ -124,9 +125,46 @@ func (decoder *DatumMapDecoder) decodeColDatum(col *ColInfo, colData []byte) (ty
 			return d, err
 		}
 		d.SetFloat64(fVal)
+	case mysql.TypeNewDecimal:
+		_, dec, precision, frac, err := codec.DecodeDecimal(colData)
+		if err != nil {
+			return d, err
+		}
+		d.SetMysqlDecimal(dec)
+		d.SetLength(precision)
+		d.SetFrac(frac)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		t, err := decoder.decodeTime(col, colData)
+		if err != nil {
+			return d, err
+		}
+		d.SetMysqlTime(t)
+	case mysql.TypeDuration:
+		var dur types.Duration
+		dur.Duration = time.Duration(decodeInt(colData))
+		dur.Fsp = int8(col.Decimal)
+		d.SetMysqlDuration(dur)
+	case mysql.TypeJSON:
+		var j json.BinaryJSON
+		j.TypeCode = colData[0]
+		j.Value = colData[1:]
+		d.SetMysqlJSON(j)
 	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString,
 		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 		d.SetBytes(colData)
+	case mysql.TypeEnum:
+		// ignore error deliberately, to read empty enum value.
+		enum, err := types.ParseEnumValue(col.Elems, decodeUint(colData))
+		if err != nil {
+			enum = types.Enum{}
+		}
+		d.SetMysqlEnum(enum)
+	case mysql.TypeSet:
+		set, err := types.ParseSetValue(col.Elems, decodeUint(colData))
+		if err != nil {
+			return d, err
+		}
+		d.SetMysqlSet(set)
 	case mysql.TypeBit:
 		byteSize := (col.Flen + 7) >> 3
 		d.SetMysqlBit(types.NewBinaryLiteralFromUint(decodeUint(colData), byteSize))
@@ -136,6 +174,22 @@ func (decoder *DatumMapDecoder) decodeColDatum(col *ColInfo, colData []byte) (ty
 	return d, nil
 }
 
+// decodeTime decodes a packed time value, converting timestamp from UTC to the decoder location.
+func (decoder *decoder) decodeTime(col *ColInfo, colData []byte) (types.Time, error) {
+	t := types.NewTime(types.ZeroCoreTime, byte(col.Tp), int8(col.Decimal))
+	err := t.FromPackedUint(decodeUint(colData))
+	if err != nil {
+		return t, err
+	}
+	if byte(col.Tp) == mysql.TypeTimestamp && !t.IsZero() && decoder.loc != nil {
+		err = t.ConvertTimeZone(time.UTC, decoder.loc)
+		if err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
 // ChunkDecoder decodes the row to chunk.Chunk.
 type ChunkDecoder struct {
 	decoder
@@ -217,9 +271,52 @@ func (decoder *ChunkDecoder) decodeColToChunk(colIdx int, col *ColInfo, colData
 			return err
 		}
 		chk.AppendFloat64(colIdx, fVal)
+	case mysql.TypeNewDecimal:
+		_, dec, _, frac, err := codec.DecodeDecimal(colData)
+		if err != nil {
+			return err
+		}
+		if col.Decimal != types.UnspecifiedLength && frac > col.Decimal {
+			to := new(types.MyDecimal)
+			err := dec.Round(to, col.Decimal, types.ModeHalfUp)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			dec = to
+		}
+		chk.AppendMyDecimal(colIdx, dec)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		t, err := decoder.decodeTime(col, colData)
+		if err != nil {
+			return err
+		}
+		chk.AppendTime(colIdx, t)
+	case mysql.TypeDuration:
+		var dur types.Duration
+		dur.Duration = time.Duration(decodeInt(colData))
+		dur.Fsp = int8(col.Decimal)
+		chk.AppendDuration(colIdx, dur)
+	case mysql.TypeJSON:
+		var j json.BinaryJSON
+		j.TypeCode = colData[0]
+		j.Value = colData[1:]
+		chk.AppendJSON(colIdx, j)
 	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString,
 		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
 		chk.AppendBytes(colIdx, colData)
+	case mysql.TypeEnum:
+		// ignore error deliberately, to read empty enum value.
+		enum, err := types.ParseEnumValue(col.Elems, decodeUint(colData))
+		if err != nil {
+			enum = types.Enum{}
+		}
+		chk.AppendEnum(colIdx, enum)
+	case mysql.TypeSet:
+		set, err := types.ParseSetValue(col.Elems, decodeUint(colData))
+		if err != nil {
+			return err
+		}
+		chk.AppendSet(colIdx, set)
 	case mysql.TypeBit:
 		byteSize := (col.Flen + 7) >> 3
 		chk.AppendBytes(colIdx, types.NewBinaryLiteralFromUint(decodeUint(colData), byteSize))
@@ -16,8 +16,11 @@ package rowcodec
 import (
 	"math"
 	"sort"
+	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/codec"
@@ -169,9 +172,6 @@ func EncodeValueDatum(sc *stmtctx.StatementContext, d types.Datum, buffer []byte
 	switch d.Kind() {
 	case types.KindInt64:
 		buffer = encodeInt(buffer, d.GetInt64())
-	case types.KindMysqlTime:
-		buffer = encodeInt(buffer, d.GetInt64())
-		//fmt.Println("encodeRow: ", buffer)
 	case types.KindUint64:
 		buffer = encodeUint(buffer, d.GetUint64())
 	case types.KindString, types.KindBytes:
@@ -184,8 +184,43 @@ func EncodeValueDatum(sc *stmtctx.StatementContext, d types.Datum, buffer []byte
 			return
 		}
 		buffer = encodeUint(buffer, val)
+	case types.KindMysqlTime:
+		t := d.GetMysqlTime()
+		// Encoding timestamp need to consider timezone.
+		// If it's not in UTC, transform to UTC first.
+		if t.Type() == mysql.TypeTimestamp && sc != nil && sc.TimeZone != nil && sc.TimeZone != time.UTC {
+			err = t.ConvertTimeZone(sc.TimeZone, time.UTC)
+			if err != nil {
+				return
+			}
+		}
+		var v uint64
+		v, err = t.ToPackedUint()
+		if err != nil {
+			return
+		}
+		buffer = encodeUint(buffer, v)
+	case types.KindMysqlDuration:
+		buffer = encodeInt(buffer, int64(d.GetMysqlDuration().Duration))
+	case types.KindMysqlEnum:
+		buffer = encodeUint(buffer, d.GetMysqlEnum().Value)
+	case types.KindMysqlSet:
+		buffer = encodeUint(buffer, d.GetMysqlSet().Value)
 	case types.KindFloat32, types.KindFloat64:
 		buffer = codec.EncodeFloat(buffer, d.GetFloat64())
+	case types.KindMysqlDecimal:
+		buffer, err = codec.EncodeDecimal(buffer, d.GetMysqlDecimal(), d.Length(), d.Frac())
+		if err != nil && sc != nil {
+			if terror.ErrorEqual(err, types.ErrTruncated) {
+				err = sc.HandleTruncate(err)
+			} else if terror.ErrorEqual(err, types.ErrOverflow) {
+				err = sc.HandleOverflow(err, err)
+			}
+		}
+	case types.KindMysqlJSON:
+		j := d.GetMysqlJSON()
+		buffer = append(buffer, j.TypeCode)
+		buffer = append(buffer, j.Value...)
 	case types.KindNull:
 	case types.KindMinNotNull:
 	case types.KindMaxValue:
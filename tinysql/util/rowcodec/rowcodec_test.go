@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/rowcodec"
@@ -311,6 +312,14 @@ func (s *testSuite) TestTypesNewRowCodec(c *C) {
 			nil,
 			false,
 		},
+		{
+			120,
+			types.NewFieldType(mysql.TypeJSON),
+			types.NewJSONDatum(json.CreateBinary([]interface{}{"a", int64(1)})),
+			types.NewJSONDatum(json.CreateBinary([]interface{}{"a", int64(1)})),
+			nil,
+			false,
+		},
 	}
 
 	// test small